@@ -0,0 +1,130 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/mselser95/polymarket-arb/internal/arbitrage"
+	"github.com/mselser95/polymarket-arb/internal/backtest"
+	"github.com/mselser95/polymarket-arb/pkg/config"
+	"github.com/mselser95/polymarket-arb/pkg/types"
+	"github.com/spf13/cobra"
+)
+
+//nolint:gochecknoglobals // Cobra boilerplate
+var backtestCmd = &cobra.Command{
+	Use:   "backtest <messages.ndjson>",
+	Short: "Replay recorded orderbook messages through the detector and paper executor",
+	Long: `Replays a newline-delimited JSON file of WebSocket orderbook messages through
+the arbitrage detector and paper executor, then reports opportunities found,
+simulated profit, and a fill-rate estimate from top-of-book depth.
+
+Detector settings (ARB_MAX_PRICE_SUM, ARB_MIN_TRADE_SIZE, ARB_MAX_TRADE_SIZE,
+ARB_TAKER_FEE, ARB_MIN_PROFIT_USD) are read from the environment, so a config
+can be evaluated offline before running it live.
+
+Markets are inferred from each message's "market" field unless --markets points
+to a JSON array of markets in Gamma API format.
+
+Example:
+  polymarket-arb backtest recording.ndjson --speed 0
+  polymarket-arb backtest recording.ndjson --speed 1 --markets markets.json`,
+	Args: cobra.ExactArgs(1),
+	RunE: runBacktest,
+}
+
+//nolint:gochecknoinits // Cobra boilerplate
+func init() {
+	rootCmd.AddCommand(backtestCmd)
+	backtestCmd.Flags().Float64("speed", 0, "Replay speed (1 = realtime, 10 = 10x, 0 = as fast as possible)")
+	backtestCmd.Flags().String("markets", "", "Path to a JSON array of markets (Gamma API format)")
+}
+
+func runBacktest(cmd *cobra.Command, args []string) error {
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+
+	// Load config
+	cfg, err := config.LoadFromEnv()
+	if err != nil {
+		return fmt.Errorf("load config: %w", err)
+	}
+
+	// Create logger
+	logger, err := config.NewLogger()
+	if err != nil {
+		return fmt.Errorf("create logger: %w", err)
+	}
+	defer func() {
+		_ = logger.Sync()
+	}()
+
+	// Get flags
+	speed, _ := cmd.Flags().GetFloat64("speed")
+	marketsPath, _ := cmd.Flags().GetString("markets")
+
+	messages, err := backtest.LoadMessagesFile(args[0])
+	if err != nil {
+		return fmt.Errorf("load messages: %w", err)
+	}
+
+	var markets []types.Market
+	if marketsPath != "" {
+		data, err := os.ReadFile(marketsPath)
+		if err != nil {
+			return fmt.Errorf("read markets file: %w", err)
+		}
+
+		err = json.Unmarshal(data, &markets)
+		if err != nil {
+			return fmt.Errorf("parse markets file: %w", err)
+		}
+	}
+
+	feeModel, err := arbitrage.NewFeeModel(cfg.ArbFeeModel, cfg.ArbTakerFee, cfg.ArbMakerFee, cfg.ArbFeeTiers)
+	if err != nil {
+		return fmt.Errorf("create fee model: %w", err)
+	}
+
+	runner, err := backtest.New(&backtest.Config{
+		Messages: messages,
+		Markets:  markets,
+		Detector: arbitrage.Config{
+			MaxPriceSum:  cfg.ArbMaxPriceSum,
+			MinTradeSize: cfg.ArbMinTradeSize,
+			MaxTradeSize: cfg.ArbMaxTradeSize,
+			TakerFee:     cfg.ArbTakerFee,
+			MinProfitUSD: cfg.ArbMinProfitUSD,
+			MaxOutcomes:  cfg.ArbMaxOutcomes,
+			FeeModel:     feeModel,
+
+			MinTotalAskLiquidityUSD: cfg.ArbMinAskLiquidityUSD,
+
+			TailPriceFloor: cfg.ArbTailPriceFloor,
+		},
+		Speed:  speed,
+		Logger: logger,
+	})
+	if err != nil {
+		return fmt.Errorf("create backtest: %w", err)
+	}
+
+	report, err := runner.Run(ctx)
+	if err != nil {
+		return fmt.Errorf("run backtest: %w", err)
+	}
+
+	fmt.Printf("\n=== Backtest Report ===\n\n")
+	fmt.Printf("Messages replayed:  %d\n", report.MessagesReplayed)
+	fmt.Printf("Messages rejected:  %d\n", report.MessagesRejected)
+	fmt.Printf("Opportunities:      %d\n", report.Opportunities)
+	fmt.Printf("Simulated profit:   $%.4f\n", report.SimulatedProfit)
+	fmt.Printf("Fill-rate estimate: %.1f%%\n", report.FillRateEstimate*100)
+	fmt.Printf("Duration:           %s\n", report.Duration)
+
+	return nil
+}
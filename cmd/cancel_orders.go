@@ -70,7 +70,7 @@ func runCancelOrders(cmd *cobra.Command, args []string) (err error) {
 	defer cancel()
 
 	// Fetch open orders
-	orders, err := client.GetOpenOrders(ctx)
+	orders, err := client.GetAllOpenOrders(ctx)
 	if err != nil {
 		return fmt.Errorf("failed to fetch open orders: %w", err)
 	}
@@ -0,0 +1,252 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/joho/godotenv"
+	"github.com/mselser95/polymarket-arb/internal/discovery"
+	"github.com/mselser95/polymarket-arb/internal/execution"
+	"github.com/mselser95/polymarket-arb/internal/markets"
+	"github.com/mselser95/polymarket-arb/pkg/config"
+	"github.com/mselser95/polymarket-arb/pkg/types"
+	"github.com/mselser95/polymarket-arb/pkg/wallet"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+)
+
+//nolint:gochecknoglobals // Cobra boilerplate
+var closeCmd = &cobra.Command{
+	Use:   "close <market-slug> <outcome>",
+	Short: "Sell one held outcome at the current best bid",
+	Long: `Exits a single position by selling an outcome of a market at the best bid.
+
+The token ID, tick size and minimum order size are looked up for the outcome, and the
+order is rounded to them: the size down so no more than is held is sold, the price
+down to the tick so the order stays marketable.
+
+Without --yes the order is only previewed.
+
+Example:
+  close will-it-rain-tomorrow Yes              # Preview selling the whole YES position
+  close will-it-rain-tomorrow Yes --yes        # Place the order
+  close will-it-rain-tomorrow No --size 25 --yes
+`,
+	Args: cobra.ExactArgs(2),
+	RunE: runClose,
+}
+
+//nolint:gochecknoglobals // Cobra flag variables
+var (
+	closeSize      float64
+	closeConfirmed bool
+)
+
+//nolint:gochecknoinits // Cobra boilerplate
+func init() {
+	rootCmd.AddCommand(closeCmd)
+	closeCmd.Flags().Float64Var(&closeSize, "size", 0, "Tokens to sell (0 = the whole held position)")
+	closeCmd.Flags().BoolVar(&closeConfirmed, "yes", false, "Place the order (without it the order is only previewed)")
+}
+
+// closeTarget is the outcome to sell with the market data the order is built from.
+type closeTarget struct {
+	MarketSlug string
+	Outcome    string
+	TokenID    string
+	Size       float64
+	BidPrice   float64
+	TickSize   float64
+	MinSize    float64
+}
+
+// sellOrderPlacer builds and places SELL orders. Implemented by *execution.OrderClient.
+type sellOrderPlacer interface {
+	BuildSellOrder(tokenID string, size, price, tickSize, minSize float64) (execution.SellOrder, error)
+	PlaceSellOrder(
+		ctx context.Context,
+		tokenID string,
+		size, price, tickSize, minSize float64,
+	) (execution.SellOrder, *types.OrderSubmissionResponse, error)
+	GetOrder(ctx context.Context, orderID string) (*types.OrderQueryResponse, error)
+}
+
+func runClose(cmd *cobra.Command, args []string) (err error) {
+	envErr := godotenv.Load()
+	if envErr != nil {
+		fmt.Printf("Warning: .env file not found\n")
+	}
+
+	if closeSize < 0 {
+		return fmt.Errorf("--size must be non-negative, got %f", closeSize)
+	}
+
+	address, err := parseWalletCredentials()
+	if err != nil {
+		return fmt.Errorf("parse credentials: %w", err)
+	}
+
+	logger, err := createCloseLogger()
+	if err != nil {
+		return fmt.Errorf("create logger: %w", err)
+	}
+	defer func() {
+		_ = logger.Sync()
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	defer cancel()
+
+	target, err := resolveCloseTarget(ctx, args[0], args[1], closeSize, address, logger)
+	if err != nil {
+		return err
+	}
+
+	orderClient, err := newCloseOrderClient(address, logger)
+	if err != nil {
+		return err
+	}
+
+	return executeClose(ctx, orderClient, target, closeConfirmed, os.Stdout)
+}
+
+// resolveCloseTarget looks up the outcome's token, metadata and best bid. A zero size
+// sells the whole held position.
+func resolveCloseTarget(
+	ctx context.Context,
+	slug string,
+	outcome string,
+	size float64,
+	address common.Address,
+	logger *zap.Logger,
+) (target closeTarget, err error) {
+	cfg, err := config.LoadFromEnv()
+	if err != nil {
+		return target, fmt.Errorf("load config: %w", err)
+	}
+
+	discoveryClient := discovery.NewClient(cfg.PolymarketGammaURL, logger)
+
+	market, err := discoveryClient.FetchMarketBySlug(ctx, slug)
+	if err != nil {
+		return target, fmt.Errorf("fetch market: %w", err)
+	}
+
+	tokenID, err := findTokenIDForOutcome(market, outcome)
+	if err != nil {
+		return target, err
+	}
+
+	tickSize, minSize, err := markets.NewMetadataClient().FetchTokenMetadata(ctx, tokenID)
+	if err != nil {
+		return target, fmt.Errorf("fetch metadata: %w", err)
+	}
+
+	bidPrice, err := discoveryClient.FetchTokenBidPrice(ctx, tokenID)
+	if err != nil {
+		return target, fmt.Errorf("fetch bid price: %w", err)
+	}
+	if bidPrice <= 0 {
+		return target, fmt.Errorf("no bids available for %s (%s)", slug, outcome)
+	}
+
+	if size == 0 {
+		size, err = fetchHeldSize(ctx, address, tokenID, logger)
+		if err != nil {
+			return target, err
+		}
+	}
+
+	target = closeTarget{
+		MarketSlug: slug,
+		Outcome:    outcome,
+		TokenID:    tokenID,
+		Size:       size,
+		BidPrice:   bidPrice,
+		TickSize:   tickSize,
+		MinSize:    minSize,
+	}
+
+	return target, nil
+}
+
+// fetchHeldSize returns the wallet's position size in tokenID.
+func fetchHeldSize(
+	ctx context.Context,
+	address common.Address,
+	tokenID string,
+	logger *zap.Logger,
+) (size float64, err error) {
+	walletClient, err := wallet.NewClient("https://polygon-rpc.com", logger)
+	if err != nil {
+		return 0, fmt.Errorf("create wallet client: %w", err)
+	}
+
+	positions, err := walletClient.GetPositions(ctx, address.Hex())
+	if err != nil {
+		return 0, fmt.Errorf("get positions: %w", err)
+	}
+
+	for _, pos := range positions {
+		if pos.TokenID == tokenID && pos.Size > 0 {
+			return pos.Size, nil
+		}
+	}
+
+	return 0, errors.New("no position held in this outcome (pass --size to sell a specific amount)")
+}
+
+// executeClose previews the rounded SELL order and, only when confirmed, places it and
+// reports the fill.
+func executeClose(
+	ctx context.Context,
+	placer sellOrderPlacer,
+	target closeTarget,
+	confirmed bool,
+	out io.Writer,
+) (err error) {
+	order, err := placer.BuildSellOrder(target.TokenID, target.Size, target.BidPrice, target.TickSize, target.MinSize)
+	if err != nil {
+		return fmt.Errorf("build sell order: %w", err)
+	}
+
+	fmt.Fprintf(out, "\n=== Close %s (%s) ===\n\n", target.MarketSlug, target.Outcome)
+	fmt.Fprintf(out, "Token:     %s\n", target.TokenID)
+	fmt.Fprintf(out, "Best bid:  $%.4f (tick %v, min size %.2f)\n", target.BidPrice, target.TickSize, target.MinSize)
+	fmt.Fprintf(out, "Sell:      %.2f tokens @ $%.4f = $%.2f USDC minimum\n", order.Size, order.Price, order.Proceeds)
+
+	if !confirmed {
+		fmt.Fprintf(out, "\nPreview only. Re-run with --yes to place the order.\n")
+		return nil
+	}
+
+	order, resp, err := placer.PlaceSellOrder(ctx, target.TokenID, target.Size, target.BidPrice, target.TickSize, target.MinSize)
+	if err != nil {
+		return fmt.Errorf("place sell order: %w", err)
+	}
+	if !resp.Success {
+		return fmt.Errorf("order rejected: %s", resp.ErrorMsg)
+	}
+
+	fmt.Fprintf(out, "\n✓ Order placed: %s (status: %s)\n", resp.OrderID, resp.Status)
+
+	status, err := placer.GetOrder(ctx, resp.OrderID)
+	if err != nil {
+		// The order is placed; only the fill report is missing
+		fmt.Fprintf(out, "Fill status unavailable: %v\n", err)
+		return nil
+	}
+
+	fmt.Fprintf(out, "Filled:    %.2f / %.2f tokens @ $%.4f ≈ $%.2f USDC\n",
+		status.SizeFilled, order.Size, order.Price, status.SizeFilled*order.Price)
+	if status.SizeFilled < order.Size {
+		fmt.Fprintf(out, "The unfilled remainder rests on the book; cancel it with cancel-orders.\n")
+	}
+
+	return nil
+}
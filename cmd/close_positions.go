@@ -273,10 +273,8 @@ func enrichPosition(
 
 // findTokenIDForOutcome finds the token ID for a given outcome.
 func findTokenIDForOutcome(market *types.Market, outcome string) (tokenID string, err error) {
-	outcomeNormalized := strings.ToLower(strings.TrimSpace(outcome))
-
 	for _, token := range market.Tokens {
-		if strings.ToLower(token.Outcome) == outcomeNormalized {
+		if types.OutcomeEquals(token.Outcome, outcome) {
 			return token.TokenID, nil
 		}
 	}
@@ -319,7 +317,33 @@ func submitCloseOrders(
 	address common.Address,
 	logger *zap.Logger,
 ) (results []CloseResult, err error) {
-	// Create order client
+	orderClient, err := newCloseOrderClient(address, logger)
+	if err != nil {
+		return nil, err
+	}
+
+	results = make([]CloseResult, 0, len(positions))
+
+	// Submit orders individually with progress tracking
+	for i, ptc := range positions {
+		fmt.Printf("[%d/%d] Closing %s (%s)...\n",
+			i+1, len(positions), ptc.Position.MarketSlug, ptc.Position.Outcome)
+
+		result := submitSingleCloseOrder(ctx, orderClient, ptc)
+		results = append(results, result)
+
+		if result.Success {
+			fmt.Printf("  ✓ Order placed: %s (≈$%.2f)\n", result.OrderID, result.USDReceived)
+		} else {
+			fmt.Printf("  ✗ Failed: %v\n", result.Error)
+		}
+	}
+
+	return results, nil
+}
+
+// newCloseOrderClient creates an order client from the POLYMARKET_* credentials in the environment.
+func newCloseOrderClient(address common.Address, logger *zap.Logger) (orderClient *execution.OrderClient, err error) {
 	apiKey := os.Getenv("POLYMARKET_API_KEY")
 	secret := os.Getenv("POLYMARKET_SECRET")
 	passphrase := os.Getenv("POLYMARKET_PASSPHRASE")
@@ -335,7 +359,7 @@ func submitCloseOrders(
 
 	privateKeyHex := os.Getenv("POLYMARKET_PRIVATE_KEY")
 
-	orderClient, err := execution.NewOrderClient(&execution.OrderClientConfig{
+	orderClient, err = execution.NewOrderClient(&execution.OrderClientConfig{
 		APIKey:        apiKey,
 		Secret:        secret,
 		Passphrase:    passphrase,
@@ -348,24 +372,7 @@ func submitCloseOrders(
 		return nil, fmt.Errorf("create order client: %w", err)
 	}
 
-	results = make([]CloseResult, 0, len(positions))
-
-	// Submit orders individually with progress tracking
-	for i, ptc := range positions {
-		fmt.Printf("[%d/%d] Closing %s (%s)...\n",
-			i+1, len(positions), ptc.Position.MarketSlug, ptc.Position.Outcome)
-
-		result := submitSingleCloseOrder(ctx, orderClient, ptc)
-		results = append(results, result)
-
-		if result.Success {
-			fmt.Printf("  ✓ Order placed: %s (≈$%.2f)\n", result.OrderID, result.USDReceived)
-		} else {
-			fmt.Printf("  ✗ Failed: %v\n", result.Error)
-		}
-	}
-
-	return results, nil
+	return orderClient, nil
 }
 
 // submitSingleCloseOrder submits a single sell order.
@@ -0,0 +1,92 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/mselser95/polymarket-arb/internal/execution"
+	"github.com/mselser95/polymarket-arb/pkg/types"
+)
+
+// mockSellPlacer records SELL orders instead of submitting them.
+type mockSellPlacer struct {
+	placed     int
+	sizeFilled float64
+}
+
+func (m *mockSellPlacer) BuildSellOrder(tokenID string, size, price, tickSize, minSize float64) (execution.SellOrder, error) {
+	return execution.SellOrder{TokenID: tokenID, Size: size, Price: price, Proceeds: size * price}, nil
+}
+
+func (m *mockSellPlacer) PlaceSellOrder(
+	ctx context.Context,
+	tokenID string,
+	size, price, tickSize, minSize float64,
+) (execution.SellOrder, *types.OrderSubmissionResponse, error) {
+	m.placed++
+	order, _ := m.BuildSellOrder(tokenID, size, price, tickSize, minSize)
+	return order, &types.OrderSubmissionResponse{Success: true, OrderID: "sell-1", Status: "matched"}, nil
+}
+
+func (m *mockSellPlacer) GetOrder(ctx context.Context, orderID string) (*types.OrderQueryResponse, error) {
+	return &types.OrderQueryResponse{OrderID: orderID, SizeFilled: m.sizeFilled}, nil
+}
+
+// TestCloseCommand_Structure tests command is properly configured
+func TestCloseCommand_Structure(t *testing.T) {
+	if closeCmd.Use != "close <market-slug> <outcome>" {
+		t.Errorf("expected Use='close <market-slug> <outcome>', got '%s'", closeCmd.Use)
+	}
+
+	for _, flag := range []struct{ name, defValue string }{{"size", "0"}, {"yes", "false"}} {
+		f := closeCmd.Flags().Lookup(flag.name)
+		if f == nil {
+			t.Fatalf("%s flag not defined", flag.name)
+		}
+		if f.DefValue != flag.defValue {
+			t.Errorf("expected %s default '%s', got '%s'", flag.name, flag.defValue, f.DefValue)
+		}
+	}
+}
+
+// TestExecuteClose_ConfirmationGuard tests that no order is placed without --yes.
+func TestExecuteClose_ConfirmationGuard(t *testing.T) {
+	target := closeTarget{
+		MarketSlug: "will-it-rain", Outcome: "Yes", TokenID: "token-1",
+		Size: 10, BidPrice: 0.45, TickSize: 0.01, MinSize: 5,
+	}
+
+	t.Run("preview_without_yes", func(t *testing.T) {
+		placer := &mockSellPlacer{}
+		var out bytes.Buffer
+
+		err := executeClose(context.Background(), placer, target, false, &out)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if placer.placed != 0 {
+			t.Errorf("expected no order placed without confirmation, got %d", placer.placed)
+		}
+		if !strings.Contains(out.String(), "--yes") {
+			t.Errorf("expected preview to mention --yes, got:\n%s", out.String())
+		}
+	})
+
+	t.Run("placed_with_yes", func(t *testing.T) {
+		placer := &mockSellPlacer{sizeFilled: 6}
+		var out bytes.Buffer
+
+		err := executeClose(context.Background(), placer, target, true, &out)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if placer.placed != 1 {
+			t.Errorf("expected 1 order placed, got %d", placer.placed)
+		}
+		if !strings.Contains(out.String(), "6.00 / 10.00 tokens") {
+			t.Errorf("expected partial fill report, got:\n%s", out.String())
+		}
+	})
+}
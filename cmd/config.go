@@ -0,0 +1,101 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/mselser95/polymarket-arb/pkg/config"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+//nolint:gochecknoglobals // Cobra boilerplate
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Inspect the bot configuration",
+}
+
+//nolint:gochecknoglobals // Cobra boilerplate
+var configPrintCmd = &cobra.Command{
+	Use:   "print",
+	Short: "Print the effective configuration and where each value came from",
+	Long: `Load the configuration exactly as 'run' does and print every setting with its
+resolved value and source:
+- env:     set in the environment
+- default: unset, so the built-in default applies
+- invalid: set but unparseable, so the built-in default applies
+
+Like 'run', only the process environment is read; a .env file applies only if your
+shell or Makefile exports it. Credentials (ADMIN_TOKEN, POLYMARKET_API_KEY,
+POLYMARKET_SECRET, POLYMARKET_PASSPHRASE, POSTGRES_PASSWORD) are redacted.
+
+Settings are printed even if validation fails; the validation error is then returned.
+
+Examples:
+  # Print as YAML
+  go run . config print
+
+  # Print as JSON and show only overridden settings
+  go run . config print --format json | jq '.[] | select(.source != "default")'`,
+	Args: cobra.NoArgs,
+	RunE: runConfigPrint,
+}
+
+//nolint:gochecknoglobals // Cobra boilerplate
+var configPrintFormat string
+
+//nolint:gochecknoinits // Cobra boilerplate
+func init() {
+	rootCmd.AddCommand(configCmd)
+	configCmd.AddCommand(configPrintCmd)
+
+	configPrintCmd.Flags().StringVarP(&configPrintFormat, "format", "f", "yaml", "Output format: yaml or json")
+}
+
+func runConfigPrint(cmd *cobra.Command, args []string) error {
+	if configPrintFormat != "yaml" && configPrintFormat != "json" {
+		return fmt.Errorf("--format must be 'yaml' or 'json', got %q", configPrintFormat)
+	}
+
+	_, settings, loadErr := config.LoadWithSources()
+
+	err := printSettings(os.Stdout, settings, configPrintFormat)
+	if err != nil {
+		return err
+	}
+
+	if loadErr != nil {
+		return fmt.Errorf("load config: %w", loadErr)
+	}
+
+	return nil
+}
+
+// printSettings writes settings to w as a YAML or JSON list.
+func printSettings(w io.Writer, settings []config.Setting, format string) error {
+	if format == "json" {
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		err := enc.Encode(settings)
+		if err != nil {
+			return fmt.Errorf("encode settings: %w", err)
+		}
+		return nil
+	}
+
+	enc := yaml.NewEncoder(w)
+	enc.SetIndent(2)
+	err := enc.Encode(settings)
+	if err != nil {
+		return fmt.Errorf("encode settings: %w", err)
+	}
+
+	err = enc.Close()
+	if err != nil {
+		return fmt.Errorf("encode settings: %w", err)
+	}
+
+	return nil
+}
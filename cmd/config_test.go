@@ -0,0 +1,64 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/mselser95/polymarket-arb/pkg/config"
+	"gopkg.in/yaml.v3"
+)
+
+func TestPrintSettings(t *testing.T) {
+	t.Setenv("ARB_MAX_PRICE_SUM", "0.97")
+	t.Setenv("POLYMARKET_SECRET", "super-secret-value")
+
+	_, settings, err := config.LoadWithSources()
+	if err != nil {
+		t.Fatalf("load config: %v", err)
+	}
+
+	for _, format := range []string{"yaml", "json"} {
+		t.Run(format, func(t *testing.T) {
+			var out bytes.Buffer
+			err := printSettings(&out, settings, format)
+			if err != nil {
+				t.Fatalf("printSettings: %v", err)
+			}
+
+			if strings.Contains(out.String(), "super-secret-value") {
+				t.Fatal("expected the secret to be redacted from the output")
+			}
+
+			var printed []config.Setting
+			if format == "json" {
+				err = json.Unmarshal(out.Bytes(), &printed)
+			} else {
+				err = yaml.Unmarshal(out.Bytes(), &printed)
+			}
+			if err != nil {
+				t.Fatalf("parse output: %v", err)
+			}
+
+			if len(printed) != len(settings) {
+				t.Fatalf("expected %d settings, got %d", len(settings), len(printed))
+			}
+
+			byName := make(map[string]config.Setting, len(printed))
+			for _, setting := range printed {
+				byName[setting.Name] = setting
+			}
+
+			want := config.Setting{Name: "ARB_MAX_PRICE_SUM", Value: "0.97", Source: config.SourceEnv}
+			if got := byName["ARB_MAX_PRICE_SUM"]; got != want {
+				t.Errorf("expected %+v, got %+v", want, got)
+			}
+
+			want = config.Setting{Name: "POLYMARKET_SECRET", Value: config.RedactedValue, Source: config.SourceEnv}
+			if got := byName["POLYMARKET_SECRET"]; got != want {
+				t.Errorf("expected %+v, got %+v", want, got)
+			}
+		})
+	}
+}
@@ -63,7 +63,7 @@ func runListOrders(cmd *cobra.Command, args []string) (err error) {
 	defer cancel()
 
 	// Fetch open orders
-	orders, err := client.GetOpenOrders(ctx)
+	orders, err := client.GetAllOpenOrders(ctx)
 	if err != nil {
 		return fmt.Errorf("failed to fetch open orders: %w", err)
 	}
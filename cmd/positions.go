@@ -5,8 +5,10 @@ import (
 	"encoding/csv"
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"sort"
+	"strings"
 	"sync"
 	"time"
 
@@ -52,8 +54,14 @@ Examples:
   # Export to CSV
   go run . positions --format csv > positions.csv
 
+  # Export selected CSV columns, in order
+  go run . positions --format csv --columns status,token_id,market_id,size,value,value_ratio
+
   # Sort by P&L (most profitable first)
-  go run . positions --sort-by-pnl`,
+  go run . positions --sort-by-pnl
+
+  # Value active positions at the current CLOB best bid instead of the API snapshot
+  go run . positions --live-value`,
 	RunE: runPositions,
 }
 
@@ -62,6 +70,8 @@ var (
 	activeOnly   bool
 	outputFormat string
 	sortByPnL    bool
+	liveValue    bool
+	csvColumns   string
 )
 
 //nolint:gochecknoinits // Cobra boilerplate
@@ -72,6 +82,8 @@ func init() {
 	positionsCmd.Flags().BoolVar(&activeOnly, "active-only", false, "Show only active positions")
 	positionsCmd.Flags().StringVar(&outputFormat, "format", "table", "Output format: table, json, csv")
 	positionsCmd.Flags().BoolVar(&sortByPnL, "sort-by-pnl", false, "Sort positions by P&L (highest first)")
+	positionsCmd.Flags().BoolVar(&liveValue, "live-value", false, "Value active positions at the current best bid from the CLOB")
+	positionsCmd.Flags().StringVar(&csvColumns, "columns", "", "Comma-separated CSV columns to emit, in order (default: "+strings.Join(defaultCSVColumns, ",")+")")
 }
 
 // EnrichedPosition extends wallet.Position with market metadata and status.
@@ -88,10 +100,19 @@ type EnrichedPosition struct {
 	Status      string // "ACTIVE", "SETTLED_WIN", "SETTLED_LOSS", "SETTLED_UNKNOWN"
 	StatusEmoji string // "🟢", "🏆", "💀", "❓"
 
+	// LiveValue is set when Value and P&L were recomputed from the current best bid
+	LiveValue bool
+
 	// Error handling
 	MetadataError error
 }
 
+// markPriceSource returns the current best bid for a token.
+// discovery.Client implements this interface.
+type markPriceSource interface {
+	FetchTokenBidPrice(ctx context.Context, tokenID string) (bidPrice float64, err error)
+}
+
 // PositionSummary holds aggregate statistics.
 type PositionSummary struct {
 	TotalPositions   int
@@ -173,6 +194,10 @@ func runPositions(cmd *cobra.Command, args []string) (err error) {
 	// Enrich positions with market metadata
 	enriched := enrichPositions(ctx, positions, discoveryClient, logger)
 
+	if liveValue {
+		refreshLiveValues(ctx, enriched, discoveryClient, logger)
+	}
+
 	// Apply filters
 	enriched = applyFilters(enriched)
 
@@ -198,6 +223,17 @@ func validateFlags() (err error) {
 		return fmt.Errorf("invalid format: %s (valid: table, json, csv)", outputFormat)
 	}
 
+	if csvColumns != "" {
+		if outputFormat != "csv" {
+			return fmt.Errorf("--columns requires --format csv")
+		}
+
+		_, err = parseCSVColumns(csvColumns)
+		if err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -270,6 +306,58 @@ func enrichPositionWithStatus(
 	return enriched
 }
 
+// refreshLiveValues marks active positions to the current best bid, recomputing value
+// and P&L. Positions whose bid can't be fetched keep the API snapshot value.
+func refreshLiveValues(
+	ctx context.Context,
+	positions []EnrichedPosition,
+	source markPriceSource,
+	logger *zap.Logger,
+) {
+	var wg sync.WaitGroup
+	semaphore := make(chan struct{}, 10) // Max 10 concurrent requests
+
+	for i := range positions {
+		if positions[i].Status != "ACTIVE" || positions[i].Position.TokenID == "" {
+			continue
+		}
+
+		wg.Add(1)
+		go func(pos *EnrichedPosition) {
+			defer wg.Done()
+			semaphore <- struct{}{}        // Acquire
+			defer func() { <-semaphore }() // Release
+
+			bidPrice, err := source.FetchTokenBidPrice(ctx, pos.Position.TokenID)
+			if err != nil || bidPrice <= 0 {
+				logger.Debug("live-value-unavailable-using-api-value",
+					zap.String("market-slug", pos.Position.MarketSlug),
+					zap.String("token-id", pos.Position.TokenID),
+					zap.Float64("bid-price", bidPrice),
+					zap.Error(err))
+				return
+			}
+
+			markToPrice(pos, bidPrice)
+		}(&positions[i])
+	}
+
+	wg.Wait()
+}
+
+// markToPrice recomputes a position's value and unrealized P&L at price.
+func markToPrice(pos *EnrichedPosition, price float64) {
+	p := &pos.Position
+	p.CurrentPrice = price
+	p.Value = p.Size * price
+	p.CashPnL = p.Value - p.InitialValue
+	p.PercentPnL = 0
+	if p.InitialValue > 0 {
+		p.PercentPnL = p.CashPnL / p.InitialValue * 100
+	}
+	pos.LiveValue = true
+}
+
 func fetchMarketWithRetry(
 	ctx context.Context,
 	client *discovery.Client,
@@ -522,7 +610,9 @@ func displayPosition(pos EnrichedPosition) {
 	fmt.Printf("   Outcome: %s\n", p.Outcome)
 	fmt.Printf("   Size: %.2f tokens @ $%.4f avg price\n", p.Size, p.AvgPrice)
 
-	if pos.Status == "ACTIVE" {
+	if pos.LiveValue {
+		fmt.Printf("   Live Value: $%.2f @ $%.4f bid (cost: $%.2f)\n", p.Value, p.CurrentPrice, p.InitialValue)
+	} else if pos.Status == "ACTIVE" {
 		fmt.Printf("   Current Value: $%.2f (cost: $%.2f)\n", p.Value, p.InitialValue)
 	} else {
 		fmt.Printf("   Final Value: $%.2f (cost: $%.2f)\n", p.Value, p.InitialValue)
@@ -648,49 +738,126 @@ func displayJSONFormat(positions []EnrichedPosition) (err error) {
 	return nil
 }
 
+// csvColumn is a selectable column of the positions CSV export.
+type csvColumn struct {
+	key    string
+	header string
+	value  func(pos EnrichedPosition) string
+}
+
+// csvColumnsByKey lists every column selectable with --columns.
+//
+//nolint:gochecknoglobals // Static column registry
+var csvColumnsByKey = map[string]csvColumn{
+	"status":  {key: "status", header: "Status", value: func(pos EnrichedPosition) string { return pos.Status }},
+	"market":  {key: "market", header: "Market", value: func(pos EnrichedPosition) string { return pos.MarketQuestion }},
+	"outcome": {key: "outcome", header: "Outcome", value: func(pos EnrichedPosition) string { return pos.Position.Outcome }},
+	"size": {key: "size", header: "Size", value: func(pos EnrichedPosition) string {
+		return fmt.Sprintf("%.2f", pos.Position.Size)
+	}},
+	"avg_price": {key: "avg_price", header: "AvgPrice", value: func(pos EnrichedPosition) string {
+		return fmt.Sprintf("%.4f", pos.Position.AvgPrice)
+	}},
+	"current_price": {key: "current_price", header: "CurrentPrice", value: func(pos EnrichedPosition) string {
+		return fmt.Sprintf("%.4f", pos.Position.CurrentPrice)
+	}},
+	"value": {key: "value", header: "Value", value: func(pos EnrichedPosition) string {
+		return fmt.Sprintf("%.2f", pos.Position.Value)
+	}},
+	"cost": {key: "cost", header: "Cost", value: func(pos EnrichedPosition) string {
+		return fmt.Sprintf("%.2f", pos.Position.InitialValue)
+	}},
+	"pnl": {key: "pnl", header: "PnL", value: func(pos EnrichedPosition) string {
+		return fmt.Sprintf("%.2f", pos.Position.CashPnL)
+	}},
+	"pnl_percent": {key: "pnl_percent", header: "PnL%", value: func(pos EnrichedPosition) string {
+		return fmt.Sprintf("%.2f", pos.Position.PercentPnL)
+	}},
+	"end_date": {key: "end_date", header: "EndDate", value: func(pos EnrichedPosition) string {
+		if pos.MarketEndDate.IsZero() {
+			return ""
+		}
+		return pos.MarketEndDate.Format("2006-01-02")
+	}},
+	"token_id":    {key: "token_id", header: "TokenID", value: func(pos EnrichedPosition) string { return pos.Position.TokenID }},
+	"market_id":   {key: "market_id", header: "MarketID", value: func(pos EnrichedPosition) string { return pos.Position.ConditionID }},
+	"market_slug": {key: "market_slug", header: "MarketSlug", value: func(pos EnrichedPosition) string { return pos.Position.MarketSlug }},
+	"value_ratio": {key: "value_ratio", header: "ValueRatio", value: func(pos EnrichedPosition) string {
+		// Value per token: ~1 for a win, ~0 for a loss, the mark price while active
+		if pos.Position.Size == 0 {
+			return ""
+		}
+		return fmt.Sprintf("%.4f", pos.Position.Value/pos.Position.Size)
+	}},
+}
+
+// defaultCSVColumns is the column set emitted when --columns is not given.
+//
+//nolint:gochecknoglobals // Static default
+var defaultCSVColumns = []string{
+	"status", "market", "outcome", "size", "avg_price", "current_price",
+	"value", "cost", "pnl", "pnl_percent", "end_date",
+}
+
+// parseCSVColumns resolves a comma-separated list of column keys, in order.
+// An empty list selects defaultCSVColumns.
+func parseCSVColumns(spec string) (columns []csvColumn, err error) {
+	keys := defaultCSVColumns
+	if strings.TrimSpace(spec) != "" {
+		keys = strings.Split(spec, ",")
+	}
+
+	columns = make([]csvColumn, 0, len(keys))
+	for _, key := range keys {
+		key = strings.TrimSpace(key)
+		column, ok := csvColumnsByKey[key]
+		if !ok {
+			valid := make([]string, 0, len(csvColumnsByKey))
+			for k := range csvColumnsByKey {
+				valid = append(valid, k)
+			}
+			sort.Strings(valid)
+			return nil, fmt.Errorf("unknown CSV column %q (valid: %s)", key, strings.Join(valid, ", "))
+		}
+		columns = append(columns, column)
+	}
+
+	return columns, nil
+}
+
 func displayCSVFormat(positions []EnrichedPosition) (err error) {
-	writer := csv.NewWriter(os.Stdout)
+	columns, err := parseCSVColumns(csvColumns)
+	if err != nil {
+		return err
+	}
+
+	return writeCSV(os.Stdout, positions, columns)
+}
+
+// writeCSV writes positions as CSV with the given columns.
+func writeCSV(w io.Writer, positions []EnrichedPosition, columns []csvColumn) (err error) {
+	writer := csv.NewWriter(w)
 	defer writer.Flush()
 
 	// Write header
-	err = writer.Write([]string{
-		"Status",
-		"Market",
-		"Outcome",
-		"Size",
-		"AvgPrice",
-		"CurrentPrice",
-		"Value",
-		"Cost",
-		"PnL",
-		"PnL%",
-		"EndDate",
-	})
+	header := make([]string, len(columns))
+	for i, column := range columns {
+		header[i] = column.header
+	}
+
+	err = writer.Write(header)
 	if err != nil {
 		return fmt.Errorf("write CSV header: %w", err)
 	}
 
 	// Write rows
+	row := make([]string, len(columns))
 	for _, pos := range positions {
-		p := pos.Position
-		endDate := ""
-		if !pos.MarketEndDate.IsZero() {
-			endDate = pos.MarketEndDate.Format("2006-01-02")
+		for i, column := range columns {
+			row[i] = column.value(pos)
 		}
 
-		err = writer.Write([]string{
-			pos.Status,
-			pos.MarketQuestion,
-			p.Outcome,
-			fmt.Sprintf("%.2f", p.Size),
-			fmt.Sprintf("%.4f", p.AvgPrice),
-			fmt.Sprintf("%.4f", p.CurrentPrice),
-			fmt.Sprintf("%.2f", p.Value),
-			fmt.Sprintf("%.2f", p.InitialValue),
-			fmt.Sprintf("%.2f", p.CashPnL),
-			fmt.Sprintf("%.2f", p.PercentPnL),
-			endDate,
-		})
+		err = writer.Write(row)
 		if err != nil {
 			return fmt.Errorf("write CSV row: %w", err)
 		}
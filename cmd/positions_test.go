@@ -1,10 +1,15 @@
 package cmd
 
 import (
+	"bytes"
+	"context"
+	"errors"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
 
 	"github.com/mselser95/polymarket-arb/pkg/wallet"
 )
@@ -516,3 +521,147 @@ func TestDetermineWinLoss_EdgeCases(t *testing.T) {
 		assert.Equal(t, "💀", emoji)
 	})
 }
+
+type mockMarkPriceSource struct {
+	bids map[string]float64
+}
+
+func (m *mockMarkPriceSource) FetchTokenBidPrice(_ context.Context, tokenID string) (float64, error) {
+	bid, ok := m.bids[tokenID]
+	if !ok {
+		return 0, errors.New("no bids available in orderbook")
+	}
+	return bid, nil
+}
+
+func TestRefreshLiveValues(t *testing.T) {
+	positions := []EnrichedPosition{
+		{
+			// Marked from API price 0.40 to live bid 0.55
+			Position: wallet.Position{MarketSlug: "active", TokenID: "tok-active", Size: 100,
+				CurrentPrice: 0.40, Value: 40, InitialValue: 50, CashPnL: -10, PercentPnL: -20},
+			Status: "ACTIVE",
+		},
+		{
+			// Price lookup fails: API value kept
+			Position: wallet.Position{MarketSlug: "no-bids", TokenID: "tok-missing", Size: 10,
+				CurrentPrice: 0.30, Value: 3, InitialValue: 4, CashPnL: -1, PercentPnL: -25},
+			Status: "ACTIVE",
+		},
+		{
+			// Settled positions are never re-marked
+			Position: wallet.Position{MarketSlug: "settled", TokenID: "tok-settled", Size: 20,
+				CurrentPrice: 1, Value: 20, InitialValue: 10, CashPnL: 10, PercentPnL: 100},
+			Status: "SETTLED_WIN",
+		},
+	}
+
+	source := &mockMarkPriceSource{bids: map[string]float64{"tok-active": 0.55, "tok-settled": 0.01}}
+	refreshLiveValues(context.Background(), positions, source, zap.NewNop())
+
+	active := positions[0]
+	assert.True(t, active.LiveValue)
+	assert.InDelta(t, 0.55, active.Position.CurrentPrice, 1e-9)
+	assert.InDelta(t, 55.0, active.Position.Value, 1e-9)
+	assert.InDelta(t, 5.0, active.Position.CashPnL, 1e-9)
+	assert.InDelta(t, 10.0, active.Position.PercentPnL, 1e-9)
+
+	fallback := positions[1]
+	assert.False(t, fallback.LiveValue)
+	assert.InDelta(t, 3.0, fallback.Position.Value, 1e-9)
+	assert.InDelta(t, -1.0, fallback.Position.CashPnL, 1e-9)
+
+	settled := positions[2]
+	assert.False(t, settled.LiveValue)
+	assert.InDelta(t, 20.0, settled.Position.Value, 1e-9)
+
+	// Summary reflects the live mark
+	summary := calculateSummary(positions)
+	require.InDelta(t, 55.0+3.0+20.0, summary.TotalValueUSD, 1e-9)
+	require.InDelta(t, 5.0-1.0, summary.UnrealizedPnLUSD, 1e-9)
+}
+
+func TestParseCSVColumns(t *testing.T) {
+	t.Run("default-columns", func(t *testing.T) {
+		columns, err := parseCSVColumns("")
+		require.NoError(t, err)
+		require.Len(t, columns, len(defaultCSVColumns))
+		assert.Equal(t, "Status", columns[0].header)
+		assert.Equal(t, "EndDate", columns[len(columns)-1].header)
+	})
+
+	t.Run("selected-columns-in-order", func(t *testing.T) {
+		columns, err := parseCSVColumns("token_id, market_id,value_ratio,status")
+		require.NoError(t, err)
+		require.Len(t, columns, 4)
+		assert.Equal(t, []string{"TokenID", "MarketID", "ValueRatio", "Status"},
+			[]string{columns[0].header, columns[1].header, columns[2].header, columns[3].header})
+	})
+
+	t.Run("unknown-column", func(t *testing.T) {
+		_, err := parseCSVColumns("status,bogus")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `unknown CSV column "bogus"`)
+		assert.Contains(t, err.Error(), "token_id", "Error should list valid columns")
+	})
+}
+
+func TestWriteCSV(t *testing.T) {
+	positions := []EnrichedPosition{
+		{
+			Position: wallet.Position{MarketSlug: "btc-up", ConditionID: "0xcond", TokenID: "123",
+				Outcome: "Yes", Size: 100, Value: 97, InitialValue: 50, CashPnL: 47},
+			Status:         "SETTLED_WIN",
+			MarketQuestion: "BTC up?",
+			MarketEndDate:  time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			Position: wallet.Position{MarketSlug: "empty", Outcome: "No"},
+			Status:   "SETTLED_UNKNOWN",
+		},
+	}
+
+	t.Run("default-columns", func(t *testing.T) {
+		columns, err := parseCSVColumns("")
+		require.NoError(t, err)
+
+		var buf bytes.Buffer
+		require.NoError(t, writeCSV(&buf, positions[:1], columns))
+		assert.Equal(t,
+			"Status,Market,Outcome,Size,AvgPrice,CurrentPrice,Value,Cost,PnL,PnL%,EndDate\n"+
+				"SETTLED_WIN,BTC up?,Yes,100.00,0.0000,0.0000,97.00,50.00,47.00,0.00,2025-01-02\n",
+			buf.String())
+	})
+
+	t.Run("extra-columns", func(t *testing.T) {
+		columns, err := parseCSVColumns("market_id,token_id,value_ratio")
+		require.NoError(t, err)
+
+		var buf bytes.Buffer
+		require.NoError(t, writeCSV(&buf, positions, columns))
+		assert.Equal(t, "MarketID,TokenID,ValueRatio\n0xcond,123,0.9700\n,,\n", buf.String())
+	})
+}
+
+func TestValidateFlags_Columns(t *testing.T) {
+	defer func() {
+		outputFormat = "table"
+		csvColumns = ""
+	}()
+
+	settledOnly = false
+	activeOnly = false
+
+	outputFormat = "csv"
+	csvColumns = "status,token_id"
+	assert.NoError(t, validateFlags())
+
+	csvColumns = "status,nope"
+	assert.Error(t, validateFlags(), "Unknown column should be rejected")
+
+	outputFormat = "json"
+	csvColumns = "status"
+	err := validateFlags()
+	require.Error(t, err, "Columns only apply to CSV")
+	assert.Contains(t, err.Error(), "--columns requires --format csv")
+}
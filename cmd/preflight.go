@@ -0,0 +1,222 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/joho/godotenv"
+	"github.com/mselser95/polymarket-arb/internal/execution"
+	"github.com/mselser95/polymarket-arb/pkg/config"
+	"github.com/mselser95/polymarket-arb/pkg/wallet"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+)
+
+//nolint:gochecknoglobals // Cobra boilerplate
+var preflightCmd = &cobra.Command{
+	Use:   "preflight",
+	Short: "Verify credentials, order signing and USDC funding before trading live",
+	Long: `Check that the configured credentials are ready for live trading.
+
+Runs three checks and reports each one:
+- Order signing: signs a test order (never submitted) and verifies it recovers to
+  the signer address, catching a POLYMARKET_ADDRESS that doesn't match the key
+- CLOB API auth: sends an authenticated GET /data/orders with the API key, secret
+  and passphrase
+- USDC.e funding: reads the maker's balance and CTF Exchange allowance on-chain
+
+Exits with an error if any check fails.
+
+Examples:
+  # Check credentials from .env
+  go run . preflight
+
+  # Use a specific Polygon RPC endpoint
+  go run . preflight --rpc https://polygon-rpc.com`,
+	Args: cobra.NoArgs,
+	RunE: runPreflight,
+}
+
+//nolint:gochecknoglobals // Cobra boilerplate
+var preflightRPC string
+
+//nolint:gochecknoinits // Cobra boilerplate
+func init() {
+	rootCmd.AddCommand(preflightCmd)
+
+	preflightCmd.Flags().StringVarP(&preflightRPC, "rpc", "r", "",
+		"Polygon RPC endpoint (default: POLYGON_RPC_URL or https://polygon-rpc.com)")
+}
+
+// preflightCLOB is the part of OrderClient the preflight checks use.
+type preflightCLOB interface {
+	GetMakerAddress() string
+	GetSignerAddress() string
+	VerifySigning() error
+	GetOpenOrders(ctx context.Context, query execution.OpenOrdersQuery) ([]execution.OrderInfo, error)
+}
+
+// preflightWallet reads on-chain balances. *wallet.Client implements it.
+type preflightWallet interface {
+	GetBalances(ctx context.Context, address common.Address) (*wallet.Balances, error)
+}
+
+// preflightResult is the outcome of one preflight check.
+type preflightResult struct {
+	Name   string
+	Detail string // Shown when the check passed
+	Err    error  // Why the check failed, nil if it passed
+}
+
+func runPreflight(cmd *cobra.Command, args []string) (err error) {
+	err = godotenv.Load()
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to load .env: %w", err)
+	}
+
+	cfg, err := config.LoadFromEnv()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	client, err := createPreflightClient(cfg)
+	if err != nil {
+		return err
+	}
+
+	rpcURL := preflightRPC
+	if rpcURL == "" {
+		rpcURL = os.Getenv("POLYGON_RPC_URL")
+	}
+	if rpcURL == "" {
+		rpcURL = "https://polygon-rpc.com"
+	}
+
+	walletClient, err := wallet.NewClient(rpcURL, zap.NewNop())
+	if err != nil {
+		return fmt.Errorf("failed to create wallet client: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	results := runPreflightChecks(ctx, client, walletClient)
+
+	failed := printPreflightReport(os.Stdout, client, results)
+	if failed > 0 {
+		return fmt.Errorf("%d of %d preflight checks failed", failed, len(results))
+	}
+
+	return nil
+}
+
+// createPreflightClient builds an order client from the same credentials the bot uses
+// in live mode. Its logs are discarded; failures are reported by the checks instead.
+func createPreflightClient(cfg *config.Config) (client *execution.OrderClient, err error) {
+	privateKey := os.Getenv("POLYMARKET_PRIVATE_KEY")
+
+	switch {
+	case cfg.PolymarketAPIKey == "":
+		return nil, errors.New("POLYMARKET_API_KEY not set")
+	case cfg.PolymarketSecret == "":
+		return nil, errors.New("POLYMARKET_SECRET not set")
+	case cfg.PolymarketPassphrase == "":
+		return nil, errors.New("POLYMARKET_PASSPHRASE not set")
+	case privateKey == "":
+		return nil, errors.New("POLYMARKET_PRIVATE_KEY not set")
+	}
+
+	signatureType := 0
+	if sigTypeStr := os.Getenv("POLYMARKET_SIGNATURE_TYPE"); sigTypeStr != "" {
+		signatureType, err = strconv.Atoi(sigTypeStr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid POLYMARKET_SIGNATURE_TYPE: %w", err)
+		}
+	}
+
+	client, err = execution.NewOrderClient(&execution.OrderClientConfig{
+		APIKey:        cfg.PolymarketAPIKey,
+		Secret:        cfg.PolymarketSecret,
+		Passphrase:    cfg.PolymarketPassphrase,
+		PrivateKey:    privateKey,
+		Address:       os.Getenv("POLYMARKET_ADDRESS"),
+		SignatureType: signatureType,
+		Logger:        zap.NewNop(),
+		ChainID:       cfg.PolymarketChainID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create order client: %w", err)
+	}
+
+	return client, nil
+}
+
+// runPreflightChecks runs every check, continuing past failures so all problems are
+// reported at once.
+func runPreflightChecks(ctx context.Context, clob preflightCLOB, chain preflightWallet) []preflightResult {
+	signing := preflightResult{Name: "Order signing"}
+	signing.Err = clob.VerifySigning()
+	if signing.Err == nil {
+		signing.Detail = "test order signature recovers to " + clob.GetSignerAddress()
+	}
+
+	auth := preflightResult{Name: "CLOB API auth"}
+	orders, err := clob.GetOpenOrders(ctx, execution.OpenOrdersQuery{})
+	if err != nil {
+		auth.Err = err
+	} else {
+		auth.Detail = fmt.Sprintf("GET /data/orders accepted, %d open orders", len(orders))
+	}
+
+	funding := preflightResult{Name: "USDC.e funding"}
+	balances, err := chain.GetBalances(ctx, common.HexToAddress(clob.GetMakerAddress()))
+	switch {
+	case err != nil:
+		funding.Err = err
+	case balances.USDCAllowance.Sign() == 0:
+		funding.Err = fmt.Errorf("balance %s but no CTF Exchange allowance (run the approve command)",
+			formatPreflightUSDC(balances.USDC))
+	default:
+		funding.Detail = fmt.Sprintf("balance %s, CTF Exchange allowance %s",
+			formatPreflightUSDC(balances.USDC), formatPreflightUSDC(balances.USDCAllowance))
+	}
+
+	return []preflightResult{signing, auth, funding}
+}
+
+// printPreflightReport writes the addresses and check results and returns the number of
+// failed checks.
+func printPreflightReport(w io.Writer, clob preflightCLOB, results []preflightResult) (failed int) {
+	fmt.Fprintf(w, "=== Preflight ===\n\n")
+	fmt.Fprintf(w, "Signer: %s\n", clob.GetSignerAddress())
+	fmt.Fprintf(w, "Maker:  %s\n\n", clob.GetMakerAddress())
+
+	for _, result := range results {
+		if result.Err != nil {
+			failed++
+			fmt.Fprintf(w, "✗ %s: %v\n", result.Name, result.Err)
+			continue
+		}
+		fmt.Fprintf(w, "✓ %s: %s\n", result.Name, result.Detail)
+	}
+
+	fmt.Fprintf(w, "\n%d/%d checks passed\n", len(results)-failed, len(results))
+	return failed
+}
+
+// formatPreflightUSDC formats a 6-decimal USDC amount, showing max approvals as unlimited.
+func formatPreflightUSDC(amount *big.Int) string {
+	if amount.BitLen() > 128 {
+		return "unlimited"
+	}
+
+	usd, _ := new(big.Float).Quo(new(big.Float).SetInt(amount), big.NewFloat(1e6)).Float64()
+	return fmt.Sprintf("$%.2f", usd)
+}
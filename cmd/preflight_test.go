@@ -0,0 +1,214 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/mselser95/polymarket-arb/internal/execution"
+	"github.com/mselser95/polymarket-arb/pkg/wallet"
+	"go.uber.org/zap"
+)
+
+const (
+	preflightTestKey        = "0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
+	preflightTestSecret     = "dGVzdC1zZWNyZXQ="
+	preflightTestAPIKey     = "test-api-key"
+	preflightTestPassphrase = "test-passphrase"
+)
+
+// mockPreflightCLOB serves GET /data/orders, accepting only correctly signed requests.
+type mockPreflightCLOB struct {
+	t        *testing.T
+	status   int // Response status for valid requests (0 = 200)
+	requests int
+}
+
+func (m *mockPreflightCLOB) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	m.requests++
+
+	if r.Method != http.MethodGet || r.URL.Path != "/data/orders" {
+		m.t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	// Recompute the L2 HMAC over timestamp + method + path
+	secret, _ := base64.URLEncoding.DecodeString(preflightTestSecret)
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(r.Header.Get("POLY_TIMESTAMP") + r.Method + r.URL.Path))
+	wantSignature := base64.URLEncoding.EncodeToString(mac.Sum(nil))
+
+	if r.Header.Get("POLY_API_KEY") != preflightTestAPIKey ||
+		r.Header.Get("POLY_PASSPHRASE") != preflightTestPassphrase ||
+		r.Header.Get("POLY_SIGNATURE") != wantSignature {
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write([]byte(`{"error":"Unauthorized/Invalid api key"}`))
+		return
+	}
+	if r.Header.Get("POLY_ADDRESS") == "" {
+		m.t.Error("expected POLY_ADDRESS header")
+	}
+
+	if m.status != 0 {
+		w.WriteHeader(m.status)
+		_, _ = w.Write([]byte(`{"error":"unavailable"}`))
+		return
+	}
+	_, _ = w.Write([]byte(`{"data":[{"id":"order-1"}]}`))
+}
+
+// stubPreflightWallet returns fixed balances.
+type stubPreflightWallet struct {
+	balances *wallet.Balances
+	err      error
+	queried  common.Address
+}
+
+func (s *stubPreflightWallet) GetBalances(_ context.Context, address common.Address) (*wallet.Balances, error) {
+	s.queried = address
+	return s.balances, s.err
+}
+
+func newPreflightTestClient(t *testing.T, serverURL, secret, address string) *execution.OrderClient {
+	t.Helper()
+
+	client, err := execution.NewOrderClient(&execution.OrderClientConfig{
+		APIKey:     preflightTestAPIKey,
+		Secret:     secret,
+		Passphrase: preflightTestPassphrase,
+		PrivateKey: preflightTestKey,
+		Address:    address,
+		Logger:     zap.NewNop(),
+		BaseURL:    serverURL,
+	})
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	return client
+}
+
+// TestPreflightCommand_Structure tests command is properly configured
+func TestPreflightCommand_Structure(t *testing.T) {
+	if preflightCmd.Use != "preflight" {
+		t.Errorf("expected Use='preflight', got '%s'", preflightCmd.Use)
+	}
+
+	f := preflightCmd.Flags().Lookup("rpc")
+	if f == nil {
+		t.Fatal("rpc flag not defined")
+	}
+	if f.DefValue != "" {
+		t.Errorf("expected rpc default '', got '%s'", f.DefValue)
+	}
+}
+
+// TestRunPreflightChecks tests that signing, auth and funding are each reported as
+// passed or failed with the reason.
+func TestRunPreflightChecks(t *testing.T) {
+	funded := &wallet.Balances{USDC: big.NewInt(250_000_000), USDCAllowance: wallet.MaxAllowance}
+
+	tests := []struct {
+		name       string
+		secret     string
+		address    string
+		status     int
+		balances   *wallet.Balances
+		walletErr  error
+		wantFailed []string
+		wantOutput []string
+	}{
+		{
+			name:       "all_pass",
+			balances:   funded,
+			wantOutput: []string{"✓ Order signing", "✓ CLOB API auth: GET /data/orders accepted, 1 open orders", "balance $250.00, CTF Exchange allowance unlimited", "3/3 checks passed"},
+		},
+		{
+			name:       "wrong_secret",
+			secret:     "d3Jvbmctc2VjcmV0",
+			balances:   funded,
+			wantFailed: []string{"CLOB API auth"},
+			wantOutput: []string{"✗ CLOB API auth: API error (status 401)", "2/3 checks passed"},
+		},
+		{
+			name:       "clob_unavailable",
+			status:     http.StatusServiceUnavailable,
+			balances:   funded,
+			wantFailed: []string{"CLOB API auth"},
+			wantOutput: []string{"status 503"},
+		},
+		{
+			// The address is not the signing key's, so the test order doesn't verify
+			name:       "address_not_paired_with_key",
+			address:    "0x1111111111111111111111111111111111111111",
+			balances:   funded,
+			wantFailed: []string{"Order signing"},
+			wantOutput: []string{"✗ Order signing", "invalid order signature"},
+		},
+		{
+			name:       "no_allowance",
+			balances:   &wallet.Balances{USDC: big.NewInt(10_000_000), USDCAllowance: big.NewInt(0)},
+			wantFailed: []string{"USDC.e funding"},
+			wantOutput: []string{"balance $10.00 but no CTF Exchange allowance"},
+		},
+		{
+			name:       "rpc_error",
+			walletErr:  errors.New("dial RPC: connection refused"),
+			wantFailed: []string{"USDC.e funding"},
+			wantOutput: []string{"✗ USDC.e funding: dial RPC: connection refused"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := &mockPreflightCLOB{t: t, status: tt.status}
+			server := httptest.NewServer(mock)
+			defer server.Close()
+
+			secret := tt.secret
+			if secret == "" {
+				secret = preflightTestSecret
+			}
+			client := newPreflightTestClient(t, server.URL, secret, tt.address)
+			chain := &stubPreflightWallet{balances: tt.balances, err: tt.walletErr}
+
+			results := runPreflightChecks(context.Background(), client, chain)
+
+			var failed []string
+			for _, result := range results {
+				if result.Err != nil {
+					failed = append(failed, result.Name)
+				}
+			}
+			if strings.Join(failed, ",") != strings.Join(tt.wantFailed, ",") {
+				t.Errorf("expected failed checks %v, got %v", tt.wantFailed, failed)
+			}
+
+			if mock.requests != 1 {
+				t.Errorf("expected 1 authenticated request, got %d", mock.requests)
+			}
+			if chain.queried != common.HexToAddress(client.GetMakerAddress()) {
+				t.Errorf("expected balances for maker %s, got %s", client.GetMakerAddress(), chain.queried.Hex())
+			}
+
+			var out bytes.Buffer
+			if got := printPreflightReport(&out, client, results); got != len(tt.wantFailed) {
+				t.Errorf("expected %d failures reported, got %d", len(tt.wantFailed), got)
+			}
+			for _, want := range tt.wantOutput {
+				if !strings.Contains(out.String(), want) {
+					t.Errorf("expected output to contain %q, got:\n%s", want, out.String())
+				}
+			}
+		})
+	}
+}
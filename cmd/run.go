@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/mselser95/polymarket-arb/internal/app"
 	"github.com/mselser95/polymarket-arb/pkg/config"
@@ -18,7 +19,10 @@ var runCmd = &cobra.Command{
 3. Detect arbitrage opportunities (YES bid + NO bid < 1.0)
 4. Execute trades in paper trading mode
 
-Use --single-market to track only one market for debugging.`,
+Use --single-market to track only one market for debugging.
+Use --categories and --min-liquidity to narrow which markets are subscribed.
+Use --once to run a single detection and execution pass and exit; the exit code is
+nonzero if any execution failed.`,
 	RunE: runBot,
 }
 
@@ -26,6 +30,10 @@ Use --single-market to track only one market for debugging.`,
 func init() {
 	rootCmd.AddCommand(runCmd)
 	runCmd.Flags().StringP("single-market", "s", "", "Track only a single market by slug (for debugging)")
+	runCmd.Flags().StringSlice("categories", nil, "Only subscribe to markets in these categories (comma-separated, case-insensitive)")
+	runCmd.Flags().Float64("min-liquidity", 0, "Only subscribe to markets with at least this much liquidity in USD")
+	runCmd.Flags().Bool("once", false, "Run one discovery, detection and execution pass, wait for fills, then exit")
+	runCmd.Flags().Duration("once-book-wait", 10*time.Second, "With --once, how long to wait for initial orderbooks")
 }
 
 func runBot(cmd *cobra.Command, args []string) error {
@@ -46,10 +54,20 @@ func runBot(cmd *cobra.Command, args []string) error {
 
 	// Get flags
 	singleMarket, _ := cmd.Flags().GetString("single-market")
+	categories, _ := cmd.Flags().GetStringSlice("categories")
+	minLiquidity, _ := cmd.Flags().GetFloat64("min-liquidity")
+	once, _ := cmd.Flags().GetBool("once")
+	onceBookWait, _ := cmd.Flags().GetDuration("once-book-wait")
+
+	if minLiquidity < 0 {
+		return fmt.Errorf("--min-liquidity must be non-negative, got %f", minLiquidity)
+	}
 
 	// Create app with options
 	opts := &app.Options{
 		SingleMarket: singleMarket,
+		Categories:   categories,
+		MinLiquidity: minLiquidity,
 	}
 
 	application, err := app.New(cfg, logger, opts)
@@ -58,6 +76,14 @@ func runBot(cmd *cobra.Command, args []string) error {
 	}
 
 	// Run app
+	if once {
+		err = application.RunOnce(onceBookWait)
+		if err != nil {
+			return fmt.Errorf("run once: %w", err)
+		}
+		return nil
+	}
+
 	err = application.Run()
 	if err != nil {
 		return fmt.Errorf("run app: %w", err)
@@ -11,6 +11,7 @@ import (
 	"github.com/mselser95/polymarket-arb/pkg/config"
 	"github.com/mselser95/polymarket-arb/pkg/healthprobe"
 	"github.com/mselser95/polymarket-arb/pkg/httpserver"
+	"github.com/mselser95/polymarket-arb/pkg/statusreport"
 	"github.com/mselser95/polymarket-arb/pkg/websocket"
 	"go.uber.org/zap"
 )
@@ -26,6 +27,9 @@ type App struct {
 	obManager        *orderbook.Manager
 	arbDetector      *arbitrage.Detector
 	executor         *execution.Executor
+	statusReporter   *statusreport.StatusReporter  // nil unless STATUS_REPORT_ENABLED
+	completeSets     *execution.CompleteSetMonitor // nil unless EXECUTION_COMPLETE_SET_CHECK_INTERVAL > 0 in live mode
+	resolutions      *execution.ResolutionMonitor  // nil unless EXECUTION_REDEEM_CHECK_INTERVAL > 0 in live mode
 	storage          arbitrage.Storage
 	ctx              context.Context
 	cancel           context.CancelFunc
@@ -34,5 +38,7 @@ type App struct {
 
 // Options holds application options.
 type Options struct {
-	SingleMarket string // For debugging: slug of single market to track
+	SingleMarket string   // For debugging: slug of single market to track
+	Categories   []string // Only subscribe to markets in these categories (empty = all)
+	MinLiquidity float64  // Only subscribe to markets with at least this much liquidity in USD
 }
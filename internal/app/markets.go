@@ -23,6 +23,48 @@ func (a *App) handleNewMarkets() {
 	}
 }
 
+// handleClosedMarkets tears down subscriptions for markets that closed after discovery.
+func (a *App) handleClosedMarkets() {
+	defer a.wg.Done()
+
+	for {
+		select {
+		case <-a.ctx.Done():
+			return
+		case market, ok := <-a.discoveryService.ClosedMarketsChan():
+			if !ok {
+				return
+			}
+
+			a.unsubscribeFromMarket(market)
+		}
+	}
+}
+
+// unsubscribeFromMarket drops a closed market's WebSocket subscriptions and orderbook snapshots.
+func (a *App) unsubscribeFromMarket(market *types.MarketSubscription) {
+	tokenIDs := make([]string, 0, len(market.Outcomes))
+	for _, outcome := range market.Outcomes {
+		tokenIDs = append(tokenIDs, outcome.TokenID)
+	}
+
+	// Unsubscribe first so no fresh book messages recreate the evicted snapshots
+	err := a.wsPool.Unsubscribe(a.ctx, tokenIDs)
+	if err != nil {
+		a.logger.Error("unsubscribe-failed",
+			zap.String("market-id", market.MarketID),
+			zap.String("slug", market.MarketSlug),
+			zap.Strings("token-ids", tokenIDs),
+			zap.Error(err))
+	}
+
+	a.obManager.RemoveSnapshots(tokenIDs)
+
+	a.logger.Info("unsubscribed-from-closed-market",
+		zap.String("slug", market.MarketSlug),
+		zap.Int("outcome-count", len(tokenIDs)))
+}
+
 func (a *App) subscribeToMarket(market *types.Market) {
 	// Validate market has at least 2 outcomes
 	if len(market.Tokens) < 2 {
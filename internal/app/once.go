@@ -0,0 +1,135 @@
+package app
+
+import (
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// bookPollInterval is how often RunOnce checks whether initial snapshots have arrived.
+const bookPollInterval = 100 * time.Millisecond
+
+// RunOnce runs a single discovery and detection pass, executes any opportunities found,
+// waits for live fill verification and shuts down. bookWait bounds how long to wait for
+// initial orderbook snapshots after subscribing. Returns an error if any execution failed.
+func (a *App) RunOnce(bookWait time.Duration) error {
+	a.logger.Info("application-starting-once",
+		zap.String("mode", a.cfg.ExecutionMode),
+		zap.Float64("arb-max-price-sum", a.cfg.ArbMaxPriceSum),
+		zap.Duration("book-wait", bookWait))
+
+	failures, err := a.runOncePass(bookWait)
+
+	shutdownErr := a.Shutdown()
+	if err != nil {
+		return err
+	}
+	if shutdownErr != nil {
+		return fmt.Errorf("shutdown: %w", shutdownErr)
+	}
+
+	if failures > 0 {
+		return fmt.Errorf("%d execution(s) failed", failures)
+	}
+
+	return nil
+}
+
+// runOncePass starts the components needed for one pass (no HTTP server or background
+// loops), then discovers, detects and executes. Returns the number of failed executions.
+func (a *App) runOncePass(bookWait time.Duration) (int, error) {
+	err := a.startWebSocketManager()
+	if err != nil {
+		return 0, fmt.Errorf("start websocket manager: %w", err)
+	}
+
+	err = a.startOrderbookManager()
+	if err != nil {
+		return 0, fmt.Errorf("start orderbook manager: %w", err)
+	}
+
+	err = a.startExecutor()
+	if err != nil {
+		return 0, fmt.Errorf("start executor: %w", err)
+	}
+
+	err = a.discoveryService.PollOnce(a.ctx)
+	if err != nil {
+		return 0, fmt.Errorf("discover markets: %w", err)
+	}
+
+	// The poll has already queued every new market
+	for drained := false; !drained; {
+		select {
+		case market := <-a.discoveryService.NewMarketsChan():
+			a.subscribeToMarket(market)
+		default:
+			drained = true
+		}
+	}
+
+	a.waitForBooks(bookWait)
+
+	opportunities := a.arbDetector.Scan()
+	a.logger.Info("once-detection-complete", zap.Int("opportunity-count", len(opportunities)))
+
+	if a.executor == nil {
+		return 0, nil
+	}
+
+	failures := 0
+	for _, opp := range opportunities {
+		result := a.executor.Execute(opp)
+		if result != nil && result.Error != nil {
+			failures++
+		}
+	}
+
+	// Fills are verified asynchronously; anything short of a full fill counts as a failure
+	a.executor.WaitForFills()
+	for status, count := range a.executor.Stats().FillVerifications {
+		if status != "success" {
+			failures += count
+		}
+	}
+
+	a.logger.Info("once-execution-complete",
+		zap.Int("opportunity-count", len(opportunities)),
+		zap.Int("failure-count", failures),
+		zap.Float64("profit-usd", a.executor.CumulativeProfit()))
+
+	return failures, nil
+}
+
+// waitForBooks waits until every subscribed outcome has a snapshot or the timeout passes.
+// Markets still missing books are skipped by the detector.
+func (a *App) waitForBooks(timeout time.Duration) {
+	deadline := time.Now().Add(timeout)
+
+	for {
+		missing := 0
+		for _, market := range a.discoveryService.GetSubscribedMarkets() {
+			for _, outcome := range market.Outcomes {
+				if _, exists := a.obManager.GetSnapshot(outcome.TokenID); !exists {
+					missing++
+				}
+			}
+		}
+
+		if missing == 0 {
+			return
+		}
+
+		if !time.Now().Before(deadline) {
+			a.logger.Warn("once-orderbooks-missing", zap.Int("missing-count", missing))
+			return
+		}
+
+		select {
+		case <-a.ctx.Done():
+			return
+		case <-time.After(bookPollInterval):
+		}
+	}
+}
@@ -0,0 +1,207 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"go.uber.org/zap"
+
+	"github.com/mselser95/polymarket-arb/internal/arbitrage"
+	"github.com/mselser95/polymarket-arb/internal/discovery"
+	"github.com/mselser95/polymarket-arb/internal/execution"
+	"github.com/mselser95/polymarket-arb/internal/markets"
+	"github.com/mselser95/polymarket-arb/internal/orderbook"
+	"github.com/mselser95/polymarket-arb/internal/testutil"
+	"github.com/mselser95/polymarket-arb/pkg/cache"
+	"github.com/mselser95/polymarket-arb/pkg/config"
+	"github.com/mselser95/polymarket-arb/pkg/healthprobe"
+	"github.com/mselser95/polymarket-arb/pkg/httpserver"
+	"github.com/mselser95/polymarket-arb/pkg/types"
+	wspkg "github.com/mselser95/polymarket-arb/pkg/websocket"
+)
+
+// mockMarketChannel answers each subscription frame with a book snapshot per token,
+// using the given best ask for each token.
+func mockMarketChannel(t *testing.T, asks map[string]string) *httptest.Server {
+	t.Helper()
+
+	upgrader := websocket.Upgrader{}
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		for {
+			var frame struct {
+				AssetsIDs []string `json:"assets_ids"`
+			}
+			err = conn.ReadJSON(&frame)
+			if err != nil {
+				return
+			}
+
+			books := make([]string, 0, len(frame.AssetsIDs))
+			for _, tokenID := range frame.AssetsIDs {
+				books = append(books, fmt.Sprintf(
+					`{"event_type":"book","asset_id":%q,"market":"0xmarket","timestamp":"1700000000000",`+
+						`"bids":[{"price":"0.40","size":"100"}],"asks":[{"price":%q,"size":"200"}]}`,
+					tokenID, asks[tokenID]))
+			}
+
+			err = conn.WriteMessage(websocket.TextMessage, []byte("["+strings.Join(books, ",")+"]"))
+			if err != nil {
+				return
+			}
+		}
+	}))
+}
+
+// newOnceTestApp wires an App against a mock Gamma API serving one binary market whose
+// books form an arbitrage (0.45 + 0.48 < 0.995).
+func newOnceTestApp(t *testing.T, mode string, orderClient execution.OrderPlacer) *App {
+	t.Helper()
+
+	logger := zap.NewNop()
+
+	market := testutil.CreateTestMarket("once-market", "once-slug", "Will it happen?")
+	mockAPI := testutil.NewMockGammaAPI([]*types.Market{market})
+	t.Cleanup(mockAPI.Close)
+
+	wsServer := mockMarketChannel(t, map[string]string{
+		market.GetTokenByOutcome("YES").TokenID: "0.45",
+		market.GetTokenByOutcome("NO").TokenID:  "0.48",
+	})
+	t.Cleanup(wsServer.Close)
+
+	marketCache, err := cache.NewRistrettoCache(&cache.RistrettoConfig{
+		NumCounters: 1000,
+		MaxCost:     100,
+		BufferItems: 64,
+		Logger:      logger,
+	})
+	if err != nil {
+		t.Fatalf("create cache: %v", err)
+	}
+	t.Cleanup(marketCache.Close)
+
+	discoverySvc := discovery.New(&discovery.Config{
+		Client:       discovery.NewClient(mockAPI.URL, logger),
+		Cache:        marketCache,
+		PollInterval: time.Minute,
+		MarketLimit:  10,
+		Logger:       logger,
+	})
+
+	pool := wspkg.NewPool(wspkg.PoolConfig{
+		Size:                  1,
+		WSUrl:                 "ws" + strings.TrimPrefix(wsServer.URL, "http"),
+		DialTimeout:           time.Second,
+		PongTimeout:           10 * time.Second,
+		PingInterval:          5 * time.Second,
+		ReconnectInitialDelay: 10 * time.Millisecond,
+		ReconnectMaxDelay:     50 * time.Millisecond,
+		ReconnectBackoffMult:  2.0,
+		MessageBufferSize:     100,
+		Logger:                logger,
+	})
+
+	obManager := orderbook.New(&orderbook.Config{
+		Logger:         logger,
+		MessageChannel: pool.MessageChan(),
+	})
+
+	storage := arbitrage.NewMockStorage()
+	detector := arbitrage.New(arbitrage.Config{
+		MaxPriceSum:  0.995,
+		MinTradeSize: 1.0,
+		MaxTradeSize: 50.0,
+		TakerFee:     0.01,
+		Logger:       logger,
+	}, obManager, discoverySvc, storage, markets.NewCachedMetadataClient(markets.NewMetadataClient(), nil))
+
+	executor := execution.New(&execution.Config{
+		Mode:               mode,
+		MaxPositionSize:    50.0,
+		Logger:             logger,
+		OpportunityChannel: detector.OpportunityChan(),
+		OrderClient:        orderClient,
+		AggressionTicks:    1,
+		FillTimeout:        time.Second,
+		FillRetryInitial:   10 * time.Millisecond,
+		FillRetryMax:       50 * time.Millisecond,
+		FillRetryMult:      2.0,
+		TakerFee:           0.01,
+	})
+
+	healthChecker := healthprobe.New()
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	return &App{
+		cfg:              &config.Config{ExecutionMode: mode, ArbMaxPriceSum: 0.995},
+		logger:           logger,
+		healthChecker:    healthChecker,
+		httpServer:       httpserver.New(&httpserver.Config{Port: "0", Logger: logger, HealthChecker: healthChecker}),
+		discoveryService: discoverySvc,
+		wsPool:           pool,
+		obManager:        obManager,
+		arbDetector:      detector,
+		executor:         executor,
+		storage:          storage,
+		ctx:              ctx,
+		cancel:           cancel,
+	}
+}
+
+// TestRunOnce tests a single pass that finds one opportunity, executes it and reports
+// execution failures through the returned error.
+func TestRunOnce(t *testing.T) {
+	tests := []struct {
+		name       string
+		mode       string
+		failOrders bool
+		wantErr    bool
+		wantPlaced int
+		wantTrades int
+	}{
+		{name: "paper_success", mode: "paper", wantTrades: 2},
+		{name: "live_success", mode: "live", wantPlaced: 2},
+		{name: "live_placement_failure", mode: "live", failOrders: true, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			orderClient := testutil.NewMockOrderClient()
+			if tt.failOrders {
+				orderClient.SetFailure(true, "insufficient balance")
+			}
+
+			a := newOnceTestApp(t, tt.mode, orderClient)
+
+			err := a.RunOnce(5 * time.Second)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("expected error=%v, got %v", tt.wantErr, err)
+			}
+
+			if got := len(orderClient.GetPlacedOrders()); got != tt.wantPlaced {
+				t.Errorf("expected %d placed orders, got %d", tt.wantPlaced, got)
+			}
+
+			if got := a.executor.Stats().TotalFilledLegs; got != tt.wantTrades {
+				t.Errorf("expected %d filled legs, got %d", tt.wantTrades, got)
+			}
+
+			if got := len(a.storage.(*arbitrage.MockStorage).GetOpportunities()); got != 1 {
+				t.Errorf("expected 1 detected opportunity, got %d", got)
+			}
+		})
+	}
+}
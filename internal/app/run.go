@@ -57,6 +57,10 @@ func (a *App) startComponents() error {
 	a.wg.Add(1)
 	go a.handleNewMarkets()
 
+	// Start closed market teardown handler
+	a.wg.Add(1)
+	go a.handleClosedMarkets()
+
 	// Start orderbook manager
 	err = a.startOrderbookManager()
 	if err != nil {
@@ -75,6 +79,24 @@ func (a *App) startComponents() error {
 		return fmt.Errorf("start executor: %w", err)
 	}
 
+	// Start status line logger (opt-in)
+	if a.statusReporter != nil {
+		a.wg.Add(1)
+		go a.runStatusReporter()
+	}
+
+	// Start complete set monitor (opt-in, live only)
+	if a.completeSets != nil {
+		a.wg.Add(1)
+		go a.runCompleteSetMonitor()
+	}
+
+	// Start resolution monitor (opt-in, live only)
+	if a.resolutions != nil {
+		a.wg.Add(1)
+		go a.runResolutionMonitor()
+	}
+
 	return nil
 }
 
@@ -94,6 +116,30 @@ func (a *App) runDiscoveryService() {
 	}
 }
 
+func (a *App) runStatusReporter() {
+	defer a.wg.Done()
+	err := a.statusReporter.Run(a.ctx)
+	if err != nil && !errors.Is(err, a.ctx.Err()) {
+		a.logger.Error("status-reporter-error", zap.Error(err))
+	}
+}
+
+func (a *App) runCompleteSetMonitor() {
+	defer a.wg.Done()
+	err := a.completeSets.Run(a.ctx)
+	if err != nil && !errors.Is(err, a.ctx.Err()) {
+		a.logger.Error("complete-set-monitor-error", zap.Error(err))
+	}
+}
+
+func (a *App) runResolutionMonitor() {
+	defer a.wg.Done()
+	err := a.resolutions.Run(a.ctx)
+	if err != nil && !errors.Is(err, a.ctx.Err()) {
+		a.logger.Error("resolution-monitor-error", zap.Error(err))
+	}
+}
+
 func (a *App) startWebSocketManager() error {
 	return a.wsPool.Start()
 }
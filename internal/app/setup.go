@@ -4,11 +4,16 @@ import (
 	"context"
 	"crypto/ecdsa"
 	"fmt"
+	"math"
+	"math/big"
 	"os"
 	"strconv"
 	"strings"
+	"time"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/mselser95/polymarket-arb/internal/arbitrage"
 	"github.com/mselser95/polymarket-arb/internal/circuitbreaker"
 	"github.com/mselser95/polymarket-arb/internal/discovery"
@@ -20,7 +25,9 @@ import (
 	"github.com/mselser95/polymarket-arb/pkg/config"
 	"github.com/mselser95/polymarket-arb/pkg/healthprobe"
 	"github.com/mselser95/polymarket-arb/pkg/httpserver"
+	"github.com/mselser95/polymarket-arb/pkg/statusreport"
 	"github.com/mselser95/polymarket-arb/pkg/wallet"
+	"github.com/mselser95/polymarket-arb/pkg/warmup"
 	"github.com/mselser95/polymarket-arb/pkg/websocket"
 	"go.uber.org/zap"
 )
@@ -49,10 +56,7 @@ func New(cfg *config.Config, logger *zap.Logger, opts *Options) (*App, error) {
 
 	discoveryService := setupDiscoveryService(cfg, logger, marketCache, opts)
 	wsPool := setupWebSocketPool(cfg, logger, cachedMetadataClient)
-	obManager := setupOrderbookManager(logger, wsPool)
-
-	// Setup HTTP server (needs orderbook manager and discovery service)
-	httpServer := setupHTTPServer(cfg, logger, healthChecker, obManager, discoveryService)
+	obManager := setupOrderbookManager(cfg, logger, wsPool)
 
 	// Setup storage
 	arbStorage, err := setupStorage(cfg, logger)
@@ -61,16 +65,57 @@ func New(cfg *config.Config, logger *zap.Logger, opts *Options) (*App, error) {
 		return nil, fmt.Errorf("setup storage: %w", err)
 	}
 
+	// Gates detection and readiness until startup market data has arrived
+	warmupGate := setupWarmupGate(cfg, logger, discoveryService, obManager)
+
 	// Setup arbitrage detector
-	arbDetector := setupArbitrageDetector(cfg, logger, obManager, discoveryService, arbStorage, cachedMetadataClient)
+	arbDetector, err := setupArbitrageDetector(cfg, logger, obManager, discoveryService, arbStorage, cachedMetadataClient, warmupGate)
+	if err != nil {
+		cancel()
+		return nil, fmt.Errorf("setup arbitrage detector: %w", err)
+	}
+
+	// Setup circuit breaker
+	breaker, err := setupCircuitBreaker(ctx, cfg, logger)
+	if err != nil {
+		cancel()
+		return nil, fmt.Errorf("setup circuit breaker: %w", err)
+	}
+
+	// Check the exchange can spend our USDC before trading live
+	err = setupAllowanceCheck(ctx, cfg, logger)
+	if err != nil {
+		cancel()
+		return nil, fmt.Errorf("check USDC allowance: %w", err)
+	}
 
 	// Setup executor
-	executor, err := setupExecutor(ctx, cfg, logger, arbDetector)
+	executor, err := setupExecutor(cfg, logger, obManager, arbDetector, breaker, arbStorage)
 	if err != nil {
 		cancel()
 		return nil, fmt.Errorf("setup executor: %w", err)
 	}
 
+	// Setup HTTP server (needs orderbook manager, discovery service, executor, and admin controls)
+	httpServer := setupHTTPServer(cfg, logger, healthChecker, obManager, discoveryService, executor, arbDetector, breaker)
+
+	// Wire subsystem status into readiness checks
+	setupReadinessChecks(cfg, healthChecker, wsPool, obManager, breaker, warmupGate)
+
+	statusReporter := setupStatusReporter(cfg, logger, wsPool, discoveryService, arbDetector, executor, breaker)
+
+	completeSets, err := setupCompleteSetMonitor(cfg, logger, discoveryService, obManager)
+	if err != nil {
+		cancel()
+		return nil, fmt.Errorf("setup complete set monitor: %w", err)
+	}
+
+	resolutions, err := setupResolutionMonitor(ctx, cfg, logger)
+	if err != nil {
+		cancel()
+		return nil, fmt.Errorf("setup resolution monitor: %w", err)
+	}
+
 	return &App{
 		cfg:              cfg,
 		logger:           logger,
@@ -81,6 +126,9 @@ func New(cfg *config.Config, logger *zap.Logger, opts *Options) (*App, error) {
 		obManager:        obManager,
 		arbDetector:      arbDetector,
 		executor:         executor,
+		statusReporter:   statusReporter,
+		completeSets:     completeSets,
+		resolutions:      resolutions,
 		storage:          arbStorage,
 		ctx:              ctx,
 		cancel:           cancel,
@@ -97,14 +145,31 @@ func setupHTTPServer(
 	healthChecker *healthprobe.HealthChecker,
 	obManager *orderbook.Manager,
 	discoveryService *discovery.Service,
+	executor *execution.Executor,
+	arbDetector *arbitrage.Detector,
+	breaker *circuitbreaker.BalanceCircuitBreaker,
 ) *httpserver.Server {
-	return httpserver.New(&httpserver.Config{
-		Port:             cfg.HTTPPort,
-		Logger:           logger,
-		HealthChecker:    healthChecker,
-		OrderbookManager: obManager,
-		DiscoveryService: discoveryService,
-	})
+	httpCfg := &httpserver.Config{
+		Port:                cfg.HTTPPort,
+		Logger:              logger,
+		HealthChecker:       healthChecker,
+		OrderbookManager:    obManager,
+		DiscoveryService:    discoveryService,
+		AdminToken:          cfg.AdminToken,
+		ThresholdController: arbDetector,
+	}
+
+	// Executor is nil in dry-run mode; avoid a non-nil interface wrapping a nil pointer
+	if executor != nil {
+		httpCfg.StatsProvider = executor
+	}
+
+	// Breaker is nil when disabled, and outside live mode unless it records all modes
+	if breaker != nil {
+		httpCfg.ExecutionController = breaker
+	}
+
+	return httpserver.New(httpCfg)
 }
 
 func setupCache(logger *zap.Logger) (cache.Cache, error) {
@@ -122,10 +187,18 @@ func setupDiscoveryService(cfg *config.Config, logger *zap.Logger, marketCache c
 		Client:            discoveryClient,
 		Cache:             marketCache,
 		PollInterval:      cfg.DiscoveryPollInterval,
+		PollJitter:        cfg.DiscoveryPollJitter,
+		CleanupInterval:   cfg.CleanupInterval,
 		MarketLimit:       cfg.DiscoveryMarketLimit,
 		MaxMarketDuration: cfg.MaxMarketDuration,
+		MinMarketDuration: cfg.MinMarketDuration,
+		Categories:        opts.Categories,
+		MinLiquidity:      opts.MinLiquidity,
 		Logger:            logger,
 		SingleMarket:      opts.SingleMarket,
+		FetchMaxAttempts:  cfg.DiscoveryFetchMaxAttempts,
+		FetchRetryBackoff: cfg.DiscoveryFetchRetryBackoff,
+		FetchBudget:       cfg.DiscoveryFetchBudget,
 	})
 }
 
@@ -139,20 +212,61 @@ func setupWebSocketPool(cfg *config.Config, logger *zap.Logger, metadataUpdater
 		ReconnectInitialDelay: cfg.WSReconnectInitialDelay,
 		ReconnectMaxDelay:     cfg.WSReconnectMaxDelay,
 		ReconnectBackoffMult:  cfg.WSReconnectBackoffMult,
+		ReconnectMaxAttempts:  cfg.WSReconnectMaxAttempts,
+		ReconnectMaxDowntime:  cfg.WSReconnectMaxDowntime,
 		MessageBufferSize:     cfg.WSMessageBufferSize,
+		ResubscribeBatchSize:  cfg.WSResubscribeBatchSize,
+		ResubscribeBatchDelay: cfg.WSResubscribeBatchDelay,
+		MaxMessageSize:        int64(cfg.WSMaxMessageSizeMB) * 1024 * 1024,
 		Logger:                logger,
 		MetadataUpdater:       metadataUpdater,
+		RecordPath:            cfg.WSRecordPath,
+		RecordCompress:        cfg.WSRecordCompress,
+		RecordMaxFileSize:     int64(cfg.WSRecordMaxFileSizeMB) * 1024 * 1024,
+
+		ChannelWarnThreshold:     cfg.WSChannelWarnThreshold,
+		ChannelCriticalThreshold: cfg.WSChannelCriticalThreshold,
+		UtilizationInterval:      cfg.WSUtilizationInterval,
 	})
 }
 
-func setupOrderbookManager(logger *zap.Logger, wsPool *websocket.Pool) *orderbook.Manager {
+func setupOrderbookManager(cfg *config.Config, logger *zap.Logger, wsPool *websocket.Pool) *orderbook.Manager {
+	var bookFetcher orderbook.BookFetcher
+	if cfg.OrderbookRESTFallback {
+		bookFetcher = orderbook.NewRESTBookFetcher(execution.DefaultCLOBURL)
+		logger.Info("orderbook-rest-fallback-enabled",
+			zap.Duration("stale-after", cfg.OrderbookRESTFallbackStaleAfter),
+			zap.Duration("interval", cfg.OrderbookRESTFallbackInterval),
+			zap.Int("max-fetches", cfg.OrderbookRESTFallbackMaxFetches))
+	}
+
 	return orderbook.New(&orderbook.Config{
-		Logger:         logger,
-		MessageChannel: wsPool.MessageChan(),
+		Logger:             logger,
+		MessageChannel:     wsPool.MessageChan(),
+		UpdateBufferSize:   cfg.OrderbookUpdateBufferSize,
+		HighWatermark:      cfg.OrderbookHighWatermark,
+		SnapshotTTL:        cfg.OrderbookSnapshotTTL,
+		CleanupInterval:    cfg.CleanupInterval,
+		Subscriptions:      wsPool,
+		BookFetcher:        bookFetcher,
+		FallbackStaleAfter: cfg.OrderbookRESTFallbackStaleAfter,
+		FallbackInterval:   cfg.OrderbookRESTFallbackInterval,
+		FallbackMaxFetches: cfg.OrderbookRESTFallbackMaxFetches,
 	})
 }
 
 func setupStorage(cfg *config.Config, logger *zap.Logger) (arbitrage.Storage, error) {
+	if cfg.StorageMode == "sqlite" {
+		sqliteStorage, err := storage.NewSQLiteStorage(&storage.SQLiteConfig{
+			Path:   cfg.SQLitePath,
+			Logger: logger,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("create sqlite storage: %w", err)
+		}
+		return sqliteStorage, nil
+	}
+
 	if cfg.StorageMode == "postgres" {
 		pgStorage, err := storage.NewPostgresStorage(&storage.PostgresConfig{
 			Host:     cfg.PostgresHost,
@@ -179,38 +293,302 @@ func setupArbitrageDetector(
 	discoveryService *discovery.Service,
 	arbStorage arbitrage.Storage,
 	cachedMetadataClient *markets.CachedMetadataClient,
-) *arbitrage.Detector {
-	return arbitrage.New(
-		arbitrage.Config{
-			MaxPriceSum:  cfg.ArbMaxPriceSum,
-			MinTradeSize: cfg.ArbMinTradeSize,
-			MaxTradeSize: cfg.ArbMaxTradeSize,
-			TakerFee:     cfg.ArbTakerFee,
-			Logger:       logger,
-		},
-		obManager,
-		discoveryService,
-		arbStorage,
-		cachedMetadataClient,
-	)
+	warmupGate *warmup.Gate,
+) (*arbitrage.Detector, error) {
+	linkedGroups, err := arbitrage.ParseLinkedMarketGroups(cfg.ArbLinkedMarkets)
+	if err != nil {
+		return nil, fmt.Errorf("parse ARB_LINKED_MARKETS: %w", err)
+	}
+
+	feeModel, err := setupFeeModel(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	detectorCfg := arbitrage.Config{
+		MaxPriceSum:  cfg.ArbMaxPriceSum,
+		MinTradeSize: cfg.ArbMinTradeSize,
+		MaxTradeSize: cfg.ArbMaxTradeSize,
+		TakerFee:     cfg.ArbTakerFee,
+		MinProfitUSD: cfg.ArbMinProfitUSD,
+		Concurrency:  cfg.ArbDetectorConcurrency,
+		MaxOutcomes:  cfg.ArbMaxOutcomes,
+		Logger:       logger,
+
+		MinTotalAskLiquidityUSD: cfg.ArbMinAskLiquidityUSD,
+		LinkedGroups:            linkedGroups,
+		FeeModel:                feeModel,
+		LimitPrices:             cfg.ArbLimitPrices,
+		OpportunityBufferSize:   cfg.ArbOpportunityBuffer,
+		LogRejections:           cfg.ArbLogRejections,
+
+		// Neg-risk only; the subset traded is not a complete set
+		TailPriceFloor: cfg.ArbTailPriceFloor,
+	}
+
+	// Avoid storing a typed nil in the interface
+	if warmupGate != nil {
+		detectorCfg.Warmup = warmupGate
+	}
+
+	detector := arbitrage.New(detectorCfg, obManager, discoveryService, arbStorage, cachedMetadataClient)
+
+	return detector, nil
 }
 
-func setupExecutor(
-	ctx context.Context,
+// setupWarmupGate creates the startup warmup gate. Returns nil when no warmup is configured.
+func setupWarmupGate(
 	cfg *config.Config,
 	logger *zap.Logger,
+	discoveryService *discovery.Service,
+	obManager *orderbook.Manager,
+) *warmup.Gate {
+	if cfg.WarmupPeriod <= 0 && cfg.WarmupMinBookFraction <= 0 {
+		return nil
+	}
+
+	return warmup.New(&warmup.Config{
+		Period:          cfg.WarmupPeriod,
+		MinBookFraction: cfg.WarmupMinBookFraction,
+		Markets:         discoveryService,
+		Orderbooks:      obManager,
+		Logger:          logger,
+	})
+}
+
+// setupFeeModel builds the fee model shared by the detector and executor.
+func setupFeeModel(cfg *config.Config) (arbitrage.FeeModel, error) {
+	feeModel, err := arbitrage.NewFeeModel(cfg.ArbFeeModel, cfg.ArbTakerFee, cfg.ArbMakerFee, cfg.ArbFeeTiers)
+	if err != nil {
+		return nil, fmt.Errorf("setup fee model: %w", err)
+	}
+
+	return feeModel, nil
+}
+
+func setupReadinessChecks(
+	cfg *config.Config,
+	healthChecker *healthprobe.HealthChecker,
+	wsPool *websocket.Pool,
+	obManager *orderbook.Manager,
+	breaker *circuitbreaker.BalanceCircuitBreaker,
+	warmupGate *warmup.Gate,
+) {
+	deps := healthprobe.Dependencies{
+		WebSocket:    wsPool,
+		Orderbook:    obManager,
+		MaxUpdateAge: cfg.HealthMaxUpdateAge,
+	}
+
+	// Avoid storing a typed nil in the interface
+	if breaker != nil {
+		deps.CircuitBreaker = breaker
+	}
+
+	if warmupGate != nil {
+		deps.Warmup = warmupGate
+	}
+
+	healthChecker.SetDependencies(deps)
+}
+
+// setupStatusReporter creates the periodic status line logger.
+// Returns nil unless enabled; executor and breaker are omitted when not running.
+func setupStatusReporter(
+	cfg *config.Config,
+	logger *zap.Logger,
+	wsPool *websocket.Pool,
+	discoveryService *discovery.Service,
 	arbDetector *arbitrage.Detector,
-) (executor *execution.Executor, err error) {
-	// Don't create executor in dry-run mode
-	if cfg.ExecutionMode == "dry-run" {
-		logger.Info("executor-disabled-dry-run-mode",
-			zap.String("mode", cfg.ExecutionMode),
-			zap.String("note", "opportunities will be detected and logged only"))
+	executor *execution.Executor,
+	breaker *circuitbreaker.BalanceCircuitBreaker,
+) *statusreport.StatusReporter {
+	if !cfg.StatusReportEnabled {
+		return nil
+	}
+
+	reporterCfg := &statusreport.Config{
+		Interval:      cfg.StatusReportInterval,
+		Logger:        logger,
+		Connections:   wsPool,
+		Markets:       discoveryService,
+		Opportunities: arbDetector,
+	}
+
+	// Avoid storing typed nils in the interfaces
+	if executor != nil {
+		reporterCfg.Profit = executor
+	}
+
+	if breaker != nil {
+		reporterCfg.CircuitBreaker = breaker
+	}
+
+	return statusreport.New(reporterCfg)
+}
+
+// walletPositions reports the wallet's held positions from the Polymarket data API.
+type walletPositions struct {
+	client  *wallet.Client
+	address string
+}
+
+// HeldPositions returns held size per token ID.
+func (p walletPositions) HeldPositions(ctx context.Context) (map[string]float64, error) {
+	positions, err := p.client.GetPositions(ctx, p.address)
+	if err != nil {
+		return nil, err
+	}
+
+	held := make(map[string]float64, len(positions))
+	for _, pos := range positions {
+		held[pos.TokenID] += pos.Size
+	}
+
+	return held, nil
+}
+
+// ConditionPositions returns held positions with their condition IDs.
+func (p walletPositions) ConditionPositions(ctx context.Context) ([]execution.ConditionPosition, error) {
+	positions, err := p.client.GetPositions(ctx, p.address)
+	if err != nil {
+		return nil, err
+	}
+
+	held := make([]execution.ConditionPosition, 0, len(positions))
+	for _, pos := range positions {
+		held = append(held, execution.ConditionPosition{
+			ConditionID:  pos.ConditionID,
+			MarketSlug:   pos.MarketSlug,
+			TokenID:      pos.TokenID,
+			OutcomeIndex: pos.OutcomeIndex,
+			Size:         pos.Size,
+			NegRisk:      pos.NegRisk,
+		})
+	}
+
+	return held, nil
+}
+
+// setupCompleteSetMonitor creates the monitor that reports complete sets held in live mode.
+// Returns nil unless EXECUTION_COMPLETE_SET_CHECK_INTERVAL is set. Exiting a set is left to
+// the operator (close, redeem-positions); the monitor only logs and counts them.
+func setupCompleteSetMonitor(
+	cfg *config.Config,
+	logger *zap.Logger,
+	discoveryService *discovery.Service,
+	obManager *orderbook.Manager,
+) (*execution.CompleteSetMonitor, error) {
+	if cfg.ExecutionMode != "live" || cfg.ExecutionCompleteSetInterval <= 0 {
+		return nil, nil
+	}
+
+	address := os.Getenv("POLYMARKET_ADDRESS")
+	if address == "" {
+		logger.Warn("complete-set-monitor-disabled-no-address",
+			zap.String("note", "POLYMARKET_ADDRESS not set"))
+		return nil, nil
+	}
+
+	rpcURL := os.Getenv("POLYGON_RPC_URL")
+	if rpcURL == "" {
+		rpcURL = "https://polygon-rpc.com"
+	}
+
+	walletClient, err := wallet.NewClient(rpcURL, logger)
+	if err != nil {
+		return nil, fmt.Errorf("create wallet client: %w", err)
+	}
+
+	return execution.NewCompleteSetMonitor(&execution.CompleteSetMonitorConfig{
+		Interval:    cfg.ExecutionCompleteSetInterval,
+		Positions:   walletPositions{client: walletClient, address: address},
+		Markets:     discoveryService,
+		Books:       obManager,
+		MinSellEdge: cfg.ExecutionCompleteSetMinSellEdge,
+		Logger:      logger,
+	})
+}
+
+// setupResolutionMonitor creates the monitor that redeems winning positions in resolved
+// markets in live mode. Returns nil unless EXECUTION_REDEEM_CHECK_INTERVAL is set.
+// Redemptions are signed with POLYMARKET_PRIVATE_KEY, so positions must be held by its address.
+func setupResolutionMonitor(ctx context.Context, cfg *config.Config, logger *zap.Logger) (*execution.ResolutionMonitor, error) {
+	if cfg.ExecutionMode != "live" || cfg.ExecutionRedeemInterval <= 0 {
+		return nil, nil
+	}
+
+	privateKeyHex := os.Getenv("POLYMARKET_PRIVATE_KEY")
+	if privateKeyHex == "" {
+		logger.Warn("resolution-monitor-disabled-no-key",
+			zap.String("note", "POLYMARKET_PRIVATE_KEY not set"))
+		return nil, nil
+	}
+
+	privateKey, err := crypto.HexToECDSA(strings.TrimPrefix(privateKeyHex, "0x"))
+	if err != nil {
+		return nil, fmt.Errorf("parse private key: %w", err)
+	}
+
+	// Proxy and Safe wallets hold positions in a contract the key can't redeem from directly
+	owner := crypto.PubkeyToAddress(privateKey.PublicKey)
+	if address := os.Getenv("POLYMARKET_ADDRESS"); address != "" && !strings.EqualFold(address, owner.Hex()) {
+		logger.Warn("resolution-monitor-disabled-proxy-wallet",
+			zap.String("address", address),
+			zap.String("signer", owner.Hex()),
+			zap.String("note", "auto-redeem only supports positions held by the signing key"))
+		return nil, nil
+	}
+
+	rpcURL := os.Getenv("POLYGON_RPC_URL")
+	if rpcURL == "" {
+		rpcURL = "https://polygon-rpc.com"
+	}
+
+	walletClient, err := wallet.NewClient(rpcURL, logger)
+	if err != nil {
+		return nil, fmt.Errorf("create wallet client: %w", err)
+	}
+
+	chain, err := ethclient.DialContext(ctx, rpcURL)
+	if err != nil {
+		return nil, fmt.Errorf("dial RPC: %w", err)
+	}
+
+	redeemer, err := wallet.NewCTFRedeemer(&wallet.RedeemerConfig{
+		Chain:      chain,
+		PrivateKey: privateKey,
+		Logger:     logger,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("create redeemer: %w", err)
+	}
+
+	return execution.NewResolutionMonitor(&execution.ResolutionMonitorConfig{
+		Interval:  cfg.ExecutionRedeemInterval,
+		Positions: walletPositions{client: walletClient, address: redeemer.Owner().Hex()},
+		Resolver:  redeemer,
+		Redeemer:  redeemer,
+		Logger:    logger,
+	})
+}
+
+// setupCircuitBreaker creates the balance circuit breaker and, in live mode, starts balance monitoring.
+// Returns nil when execution is disabled (dry-run, observe), in paper mode unless trade sizes
+// are recorded in all modes, or when no wallet is configured.
+func setupCircuitBreaker(
+	ctx context.Context,
+	cfg *config.Config,
+	logger *zap.Logger,
+) (breaker *circuitbreaker.BalanceCircuitBreaker, err error) {
+	if cfg.ExecutionMode == "dry-run" || cfg.ExecutionMode == "observe" {
+		return nil, nil
+	}
+
+	if cfg.ExecutionMode != "live" && !cfg.CircuitBreakerRecordAllModes {
 		return nil, nil
 	}
 
-	// Create circuit breaker if enabled
-	var breaker *circuitbreaker.BalanceCircuitBreaker
 	if cfg.CircuitBreakerEnabled {
 		// Parse wallet address for balance checking
 		privateKeyHex := os.Getenv("POLYMARKET_PRIVATE_KEY")
@@ -243,34 +621,164 @@ func setupExecutor(
 						logger.Warn("circuit-breaker-disabled-wallet-client-failed",
 							zap.Error(walletErr))
 					} else {
+						collateralTokens, parseErr := parseCollateralTokens(cfg.CircuitBreakerCollateral)
+						if parseErr != nil {
+							return nil, fmt.Errorf("parse collateral tokens: %w", parseErr)
+						}
+
 						// Create circuit breaker
 						breaker, err = circuitbreaker.New(&circuitbreaker.Config{
-							CheckInterval:   cfg.CircuitBreakerCheckInterval,
-							TradeMultiplier: cfg.CircuitBreakerTradeMultiplier,
-							MinAbsolute:     cfg.CircuitBreakerMinAbsolute,
-							HysteresisRatio: cfg.CircuitBreakerHysteresisRatio,
-							WalletClient:    walletClient,
-							Address:         address,
-							Logger:          logger,
+							CheckInterval:    cfg.CircuitBreakerCheckInterval,
+							TradeMultiplier:  cfg.CircuitBreakerTradeMultiplier,
+							MinAbsolute:      cfg.CircuitBreakerMinAbsolute,
+							HysteresisRatio:  cfg.CircuitBreakerHysteresisRatio,
+							WalletClient:     walletClient,
+							Address:          address,
+							Logger:           logger,
+							CollateralTokens: collateralTokens,
+							ThresholdMode:    cfg.CircuitBreakerThresholdMode,
+							EMAAlpha:         cfg.CircuitBreakerEMAAlpha,
 						})
 						if err != nil {
 							return nil, fmt.Errorf("create circuit breaker: %w", err)
 						}
 
-						// Start background monitoring
-						breaker.Start(ctx)
+						// Start background monitoring (paper mode only records trade sizes)
+						if cfg.ExecutionMode == "live" {
+							breaker.Start(ctx)
+						}
 
 						logger.Info("circuit-breaker-enabled",
 							zap.Duration("check_interval", cfg.CircuitBreakerCheckInterval),
 							zap.Float64("trade_multiplier", cfg.CircuitBreakerTradeMultiplier),
 							zap.Float64("min_absolute", cfg.CircuitBreakerMinAbsolute),
-							zap.Float64("hysteresis_ratio", cfg.CircuitBreakerHysteresisRatio))
+							zap.Float64("hysteresis_ratio", cfg.CircuitBreakerHysteresisRatio),
+							zap.Strings("collateral_tokens", cfg.CircuitBreakerCollateral),
+							zap.Bool("balance_check", cfg.ExecutionMode == "live"))
 					}
 				}
 			}
 		}
 	}
 
+	return breaker, nil
+}
+
+// allowanceCheckTimeout bounds the startup allowance check, including waiting for an approval.
+const allowanceCheckTimeout = 2 * time.Minute
+
+// setupAllowanceCheck verifies in live mode that the CTF Exchange may spend enough USDC.e
+// to settle our orders. Depending on EXECUTION_ALLOWANCE_CHECK, an insufficient allowance
+// is logged, blocks startup, or is approved on-chain.
+func setupAllowanceCheck(ctx context.Context, cfg *config.Config, logger *zap.Logger) error {
+	if cfg.ExecutionMode != "live" || cfg.ExecutionAllowanceCheck == "off" {
+		return nil
+	}
+
+	privateKeyHex := os.Getenv("POLYMARKET_PRIVATE_KEY")
+	if privateKeyHex == "" {
+		// The order client reports the missing key
+		return nil
+	}
+
+	privateKey, err := crypto.HexToECDSA(strings.TrimPrefix(privateKeyHex, "0x"))
+	if err != nil {
+		return fmt.Errorf("parse private key: %w", err)
+	}
+
+	rpcURL := os.Getenv("POLYGON_RPC_URL")
+	if rpcURL == "" {
+		rpcURL = "https://polygon-rpc.com"
+	}
+
+	checkCtx, cancel := context.WithTimeout(ctx, allowanceCheckTimeout)
+	defer cancel()
+
+	client, err := ethclient.DialContext(checkCtx, rpcURL)
+	if err != nil {
+		return allowanceCheckFailed(cfg, logger, fmt.Errorf("dial RPC: %w", err))
+	}
+	defer client.Close()
+
+	manager, err := wallet.NewAllowanceManager(&wallet.AllowanceConfig{
+		Chain:      client,
+		PrivateKey: privateKey,
+		Logger:     logger,
+	})
+	if err != nil {
+		return fmt.Errorf("create allowance manager: %w", err)
+	}
+
+	minUSD := cfg.ExecutionMinAllowanceUSD
+	if minUSD == 0 {
+		minUSD = cfg.ExecutionMaxPositionSize
+	}
+	minAllowance := big.NewInt(int64(math.Round(minUSD * 1e6)))
+	spender := common.HexToAddress(wallet.PolygonCTFExchange)
+
+	if cfg.ExecutionAllowanceCheck == "approve" {
+		err = manager.EnsureAllowance(checkCtx, spender, minAllowance)
+		if err != nil {
+			return fmt.Errorf("ensure allowance: %w", err)
+		}
+		logger.Info("usdc-allowance-ok",
+			zap.String("owner", manager.Owner().Hex()),
+			zap.Float64("min-allowance-usd", minUSD))
+		return nil
+	}
+
+	allowance, err := manager.Allowance(checkCtx, spender)
+	if err != nil {
+		return allowanceCheckFailed(cfg, logger, fmt.Errorf("get allowance: %w", err))
+	}
+
+	allowanceUSD, _ := new(big.Float).Quo(new(big.Float).SetInt(allowance), big.NewFloat(1e6)).Float64()
+	if allowance.Cmp(minAllowance) >= 0 {
+		logger.Info("usdc-allowance-ok",
+			zap.String("owner", manager.Owner().Hex()),
+			zap.Float64("allowance-usd", allowanceUSD),
+			zap.Float64("min-allowance-usd", minUSD))
+		return nil
+	}
+
+	return allowanceCheckFailed(cfg, logger, fmt.Errorf(
+		"allowance $%.2f below minimum $%.2f for %s (run the approve command)",
+		allowanceUSD, minUSD, manager.Owner().Hex()))
+}
+
+// allowanceCheckFailed blocks startup in block mode and otherwise logs the failure.
+func allowanceCheckFailed(cfg *config.Config, logger *zap.Logger, err error) error {
+	if cfg.ExecutionAllowanceCheck == "block" {
+		return err
+	}
+
+	logger.Warn("usdc-allowance-insufficient",
+		zap.Error(err),
+		zap.String("note", "orders will fail on settlement until USDC is approved"))
+	return nil
+}
+
+func setupExecutor(
+	cfg *config.Config,
+	logger *zap.Logger,
+	obManager *orderbook.Manager,
+	arbDetector *arbitrage.Detector,
+	breaker *circuitbreaker.BalanceCircuitBreaker,
+	arbStorage arbitrage.Storage,
+) (executor *execution.Executor, err error) {
+	// Don't create executor in dry-run mode
+	if cfg.ExecutionMode == "dry-run" {
+		logger.Info("executor-disabled-dry-run-mode",
+			zap.String("mode", cfg.ExecutionMode),
+			zap.String("note", "opportunities will be detected and logged only"))
+		return nil, nil
+	}
+
+	feeModel, err := setupFeeModel(cfg)
+	if err != nil {
+		return nil, err
+	}
+
 	// Create OrderClient for live trading
 	var orderClient *execution.OrderClient
 	if cfg.ExecutionMode == "live" {
@@ -287,15 +795,30 @@ func setupExecutor(
 				}
 			}
 
+			roundingPolicy, err := execution.ParseRoundingPolicy(cfg.ExecutionRoundingPolicy)
+			if err != nil {
+				return nil, fmt.Errorf("parse rounding policy: %w", err)
+			}
+
 			orderClientCfg := &execution.OrderClientConfig{
-				APIKey:        cfg.PolymarketAPIKey,
-				Secret:        cfg.PolymarketSecret,
-				Passphrase:    cfg.PolymarketPassphrase,
-				PrivateKey:    privateKey,
-				Address:       os.Getenv("POLYMARKET_ADDRESS"),
-				ProxyAddress:  "", // Empty for EOA signatures (maker == signer)
-				SignatureType: signatureType,
-				Logger:        logger,
+				APIKey:          cfg.PolymarketAPIKey,
+				Secret:          cfg.PolymarketSecret,
+				Passphrase:      cfg.PolymarketPassphrase,
+				PrivateKey:      privateKey,
+				Address:         os.Getenv("POLYMARKET_ADDRESS"),
+				ProxyAddress:    "", // Empty for EOA signatures (maker == signer)
+				SignatureType:   signatureType,
+				Logger:          logger,
+				ChainID:         cfg.PolymarketChainID,
+				StrictTickSize:  cfg.ExecutionStrictTickSize,
+				StrictOrderHash: cfg.ExecutionStrictOrderHash,
+				MaxBatchSize:    cfg.ExecutionMaxBatchSize,
+				SortByTokenID:   cfg.ExecutionSortByTokenID,
+				SyncClockOnSkew: cfg.ExecutionClockSkewSync,
+				RoundingPolicy:  roundingPolicy,
+				SubmitTimeout:   cfg.ExecutionSubmitTimeout,
+				QueryTimeout:    cfg.ExecutionQueryTimeout,
+				CancelTimeout:   cfg.ExecutionCancelTimeout,
 			}
 
 			orderClient, err = execution.NewOrderClient(orderClientCfg)
@@ -303,27 +826,90 @@ func setupExecutor(
 				return nil, fmt.Errorf("create order client: %w", err)
 			}
 
+			domain := orderClient.Domain()
 			logger.Info("order-client-configured",
 				zap.String("mode", "live"),
-				zap.String("address", orderClientCfg.Address))
+				zap.String("address", orderClientCfg.Address),
+				zap.Int64("chain-id", domain.ChainID),
+				zap.String("verifying-contract", domain.VerifyingContract.Hex()),
+				zap.String("domain-separator", domain.Separator.Hex()))
 		}
 	}
 
+	// Persist profit and trade counts when the storage backend supports it (postgres, sqlite)
+	var stateStore execution.StateStore
+	if cfg.ExecutionPersistState {
+		if store, ok := arbStorage.(execution.StateStore); ok {
+			stateStore = store
+		} else {
+			logger.Info("execution-state-not-persisted",
+				zap.String("storage-mode", cfg.StorageMode),
+				zap.String("note", "state persistence requires STORAGE_MODE=postgres or sqlite"))
+		}
+	}
+
+	// Record execution results when the storage backend supports it (sqlite)
+	var resultStore execution.ResultStore
+	if store, ok := arbStorage.(execution.ResultStore); ok {
+		resultStore = store
+	}
+
 	executor = execution.New(&execution.Config{
-		Mode:               cfg.ExecutionMode,
-		MaxPositionSize:    cfg.ExecutionMaxPositionSize,
-		Logger:             logger,
-		OpportunityChannel: arbDetector.OpportunityChan(),
-		OrderClient:        orderClient,
-		CircuitBreaker:     breaker,
+		Mode:                cfg.ExecutionMode,
+		MaxPositionSize:     cfg.ExecutionMaxPositionSize,
+		MaxOpenExposureUSD:  cfg.ExecutionMaxOpenExposure,
+		MarketCooldown:      cfg.ExecutionMarketCooldown,
+		RejectionCooldown:   cfg.ExecutionRejectionCooldown,
+		Logger:              logger,
+		OpportunityChannel:  arbDetector.OpportunityChan(),
+		OrderClient:         orderClient,
+		CircuitBreaker:      breaker,
+		RecordAllModes:      cfg.CircuitBreakerRecordAllModes,
+		PaperRealisticFills: cfg.ExecutionRealisticFills,
+		Snapshots:           obManager,
+		MaxRepriceAttempts:  cfg.ExecutionMaxReprices,
+		SelfTradePrevention: cfg.ExecutionSelfTradeMode,
 		// Fill verification config
-		AggressionTicks:  cfg.ExecutionAggressionTicks,
-		FillTimeout:      cfg.ExecutionFillTimeout,
-		FillRetryInitial: cfg.ExecutionFillRetryInitial,
-		FillRetryMax:     cfg.ExecutionFillRetryMax,
-		FillRetryMult:    cfg.ExecutionFillRetryMult,
-		TakerFee:         cfg.ArbTakerFee,
+		PricingStrategy:          cfg.ExecutionPricingStrategy,
+		AggressionTicks:          cfg.ExecutionAggressionTicks,
+		AggressionMode:           cfg.ExecutionAggressionMode,
+		AggressionSpreadFraction: cfg.ExecutionAggressionSpread,
+		FillTimeout:              cfg.ExecutionFillTimeout,
+		FillRetryInitial:         cfg.ExecutionFillRetryInitial,
+		FillRetryMax:             cfg.ExecutionFillRetryMax,
+		FillRetryMult:            cfg.ExecutionFillRetryMult,
+		FillRetryJitter:          cfg.ExecutionFillRetryJitter,
+		FillMaxAttempts:          cfg.ExecutionFillMaxAttempts,
+		FillGracePeriod:          cfg.ExecutionFillGracePeriod,
+		TakerFee:                 cfg.ArbTakerFee,
+		FeeModel:                 feeModel,
+		// Cap on fill verifications polling at once
+		MaxConcurrentVerifications: cfg.ExecutionFillMaxConcurrent,
+		// Profit is accounted in micro-dollars and rounded to this for logs and stats
+		ProfitReportDecimals: cfg.ExecutionProfitReportDecimals,
+		// Opportunity queue
+		QueueSize:   cfg.ExecutionQueueSize,
+		QueueMaxAge: cfg.ExecutionQueueMaxAge,
+		// Discard opportunities that aged past this before reaching execution
+		MaxOpportunityAge: cfg.ExecutionMaxOpportunityAge,
+		// State persistence
+		StateStore:         stateStore,
+		CheckpointInterval: cfg.ExecutionCheckpointInterval,
+		ResultStore:        resultStore,
 	})
 
 	return executor, nil
 }
+
+// parseCollateralTokens converts configured collateral token addresses.
+func parseCollateralTokens(addresses []string) (tokens []common.Address, err error) {
+	tokens = make([]common.Address, 0, len(addresses))
+	for _, addr := range addresses {
+		if !common.IsHexAddress(addr) {
+			return nil, fmt.Errorf("invalid token address %q", addr)
+		}
+		tokens = append(tokens, common.HexToAddress(addr))
+	}
+
+	return tokens, nil
+}
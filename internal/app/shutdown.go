@@ -13,6 +13,9 @@ func (a *App) Shutdown() error {
 
 	a.healthChecker.SetReady(false)
 
+	// Let placed orders finish fill verification before the context cancels it
+	a.drainExecutor()
+
 	// Cancel context to signal all components
 	a.cancel()
 
@@ -68,6 +71,20 @@ func (a *App) shutdownHTTPServer(ctx context.Context) error {
 	return a.httpServer.Shutdown(ctx)
 }
 
+func (a *App) drainExecutor() {
+	if a.executor == nil || a.cfg.ExecutionDrainTimeout <= 0 {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), a.cfg.ExecutionDrainTimeout)
+	defer cancel()
+
+	err := a.executor.Drain(ctx)
+	if err != nil {
+		a.logger.Warn("executor-drain-incomplete", zap.Error(err))
+	}
+}
+
 func (a *App) shutdownExecutor() error {
 	if a.executor == nil {
 		return nil
@@ -3,12 +3,16 @@ package arbitrage
 import (
 	"context"
 	"fmt"
+	"math"
+	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/mselser95/polymarket-arb/internal/discovery"
 	"github.com/mselser95/polymarket-arb/internal/markets"
 	"github.com/mselser95/polymarket-arb/internal/orderbook"
+	"github.com/mselser95/polymarket-arb/pkg/tracing"
 	"github.com/mselser95/polymarket-arb/pkg/types"
 	"go.uber.org/zap"
 )
@@ -19,6 +23,11 @@ type Storage interface {
 	Close() error
 }
 
+// WarmupGate reports whether startup warmup has completed.
+type WarmupGate interface {
+	IsWarm() bool
+}
+
 // Detector detects arbitrage opportunities.
 type Detector struct {
 	obManager        *orderbook.Manager
@@ -31,19 +40,64 @@ type Detector struct {
 	obUpdateChan     <-chan *types.OrderbookSnapshot
 	ctx              context.Context
 	wg               sync.WaitGroup
+	opportunityCount atomic.Uint64
+	threshold        atomic.Uint64 // math.Float64bits of a runtime override of MaxPriceSum (0 = unset)
+	linkedByMarket   map[string][]*LinkedMarketGroup
 }
 
 // Config holds detector configuration.
 type Config struct {
-	MaxPriceSum  float64 // Maximum acceptable YES + NO price sum (lower = stricter)
+	MaxPriceSum  float64 // Maximum acceptable YES + NO price sum (lower = stricter); see SetThreshold
 	MinTradeSize float64
 	MaxTradeSize float64
-	TakerFee     float64
-	Logger       *zap.Logger
+	TakerFee     float64 // Flat taker fee rate, used when FeeModel is nil
+	MinProfitUSD float64 // Minimum net profit in USD after fees (0 = disabled)
+	Concurrency  int     // Workers evaluating markets in parallel (<= 1 = serial)
+	MaxOutcomes  int     // Skip markets with more outcomes than this (0 = unlimited)
+
+	// MinTotalAskLiquidityUSD is the minimum USDC resting on the ask side summed across
+	// all outcomes (0 = disabled). Thin books lead to slippage and failed fills.
+	MinTotalAskLiquidityUSD float64
+	Logger                  *zap.Logger
+
+	// FeeModel prices the taker fees of each outcome (nil = flat TakerFee).
+	FeeModel FeeModel
+
+	// LinkedGroups are evaluated as synthetic complete sets whenever a leg's market updates.
+	LinkedGroups []LinkedMarketGroup
+
+	// LimitPrices caps each outcome's order price so the set costs at most MaxPriceSum
+	// even after execution aggression; see Opportunity.SetLimitPrices.
+	LimitPrices bool
+
+	// LogRejections logs every opportunity-rejected line at info instead of debug, to see
+	// why markets that look profitable aren't trading without full debug logging.
+	LogRejections bool
+
+	// TailPriceFloor skips outcomes asking less than this in neg-risk markets, so their
+	// taker fees don't eat the spread (0 = disabled). The rest is no longer a complete set;
+	// see tailSubset for when it is still traded.
+	TailPriceFloor float64
+
+	// Warmup defers detection after startup until enough markets have orderbooks, since
+	// empty books read as markets without opportunities (nil = no warmup).
+	Warmup WarmupGate
+
+	// OpportunityBufferSize bounds OpportunityChan (0 = default). When the consumer stalls
+	// and the buffer is full, the oldest opportunity is dropped for the new one.
+	OpportunityBufferSize int
 }
 
+// defaultOpportunityBufferSize is the OpportunityChan capacity when none is configured.
+const defaultOpportunityBufferSize = 10000
+
 // New creates a new arbitrage detector.
 func New(cfg Config, obManager *orderbook.Manager, discoveryService *discovery.Service, storage Storage, metadataClient *markets.CachedMetadataClient) *Detector {
+	bufferSize := cfg.OpportunityBufferSize
+	if bufferSize <= 0 {
+		bufferSize = defaultOpportunityBufferSize
+	}
+
 	return &Detector{
 		obManager:        obManager,
 		discoveryService: discoveryService,
@@ -51,8 +105,10 @@ func New(cfg Config, obManager *orderbook.Manager, discoveryService *discovery.S
 		logger:           cfg.Logger,
 		storage:          storage,
 		metadataClient:   metadataClient,
-		opportunityChan:  make(chan *Opportunity, 10000),
+		opportunityChan:  make(chan *Opportunity, bufferSize),
 		obUpdateChan:     obManager.UpdateChan(),
+		ctx:              context.Background(),
+		linkedByMarket:   indexLinkedGroups(cfg.LinkedGroups),
 	}
 }
 
@@ -60,9 +116,11 @@ func New(cfg Config, obManager *orderbook.Manager, discoveryService *discovery.S
 func (d *Detector) Start(ctx context.Context) error {
 	d.ctx = ctx
 	d.logger.Info("arbitrage-detector-starting",
-		zap.Float64("threshold", d.config.MaxPriceSum),
+		zap.Float64("threshold", d.GetThreshold()),
 		zap.Float64("min-trade-size", d.config.MinTradeSize),
-		zap.Float64("max-trade-size", d.config.MaxTradeSize))
+		zap.Float64("max-trade-size", d.config.MaxTradeSize),
+		zap.Int("concurrency", d.config.Concurrency),
+		zap.Int("linked-groups", len(d.config.LinkedGroups)))
 
 	d.wg.Add(1)
 	go d.detectionLoop()
@@ -71,20 +129,54 @@ func (d *Detector) Start(ctx context.Context) error {
 }
 
 // detectionLoop listens for orderbook updates and checks for arbitrage.
+// With Concurrency > 1, markets are evaluated on a sharded worker pool.
 func (d *Detector) detectionLoop() {
 	defer d.wg.Done()
 
+	var pool *marketWorkerPool
+	if d.config.Concurrency > 1 {
+		pool = newMarketWorkerPool(d.config.Concurrency, workerQueueSize, d.evaluateMarketTimed)
+	}
+
 	for {
 		select {
 		case <-d.ctx.Done():
 			d.logger.Info("arbitrage-detector-stopping")
+			// Workers send on opportunityChan, so they must exit before it closes
+			if pool != nil {
+				pool.close()
+			}
 			close(d.opportunityChan)
 			return
 		case update := <-d.obUpdateChan:
 			if update == nil {
 				// Channel closed
+				if pool != nil {
+					pool.close()
+				}
 				return
 			}
+
+			// Shed work while the orderbook manager is backlogged: queued updates are
+			// stale, and current state is re-read from snapshots once the backlog drains.
+			if d.obManager.IsBackpressured() {
+				DetectionSkippedTotal.WithLabelValues("backpressure").Inc()
+				continue
+			}
+
+			if d.config.Warmup != nil && !d.config.Warmup.IsWarm() {
+				DetectionSkippedTotal.WithLabelValues("warmup").Inc()
+				continue
+			}
+
+			if pool != nil {
+				targetMarket, exists := d.discoveryService.GetMarketByTokenID(update.TokenID)
+				if exists {
+					pool.submit(targetMarket)
+				}
+				continue
+			}
+
 			start := time.Now()
 			d.checkArbitrageForToken(update)
 			DetectionDurationSeconds.Observe(time.Since(start).Seconds())
@@ -92,6 +184,12 @@ func (d *Detector) detectionLoop() {
 	}
 }
 
+// CheckUpdate runs detection for a single orderbook update synchronously.
+// Used by backtest replay, which steps the pipeline instead of calling Start.
+func (d *Detector) CheckUpdate(update *types.OrderbookSnapshot) {
+	d.checkArbitrageForToken(update)
+}
+
 // checkArbitrageForToken checks for arbitrage when a specific token's orderbook updates.
 func (d *Detector) checkArbitrageForToken(update *types.OrderbookSnapshot) {
 	// Find which market this token belongs to (O(1) lookup via reverse index)
@@ -101,6 +199,140 @@ func (d *Detector) checkArbitrageForToken(update *types.OrderbookSnapshot) {
 		return
 	}
 
+	d.evaluateMarket(targetMarket)
+}
+
+// evaluateMarketTimed is evaluateMarket with detection latency tracking (worker pool entry point).
+func (d *Detector) evaluateMarketTimed(targetMarket *types.MarketSubscription) {
+	start := time.Now()
+	d.evaluateMarket(targetMarket)
+	DetectionDurationSeconds.Observe(time.Since(start).Seconds())
+}
+
+// evaluateMarket checks a market for arbitrage using the latest snapshots of all its outcomes,
+// then checks every linked group the market is a leg of.
+// Safe for concurrent use across different markets.
+func (d *Detector) evaluateMarket(targetMarket *types.MarketSubscription) {
+	opp, exists := d.findOpportunity(targetMarket, nil)
+	if exists {
+		d.sendOpportunity(targetMarket, opp)
+	}
+
+	for _, group := range d.linkedByMarket[targetMarket.MarketID] {
+		synthetic, ok := d.linkedMarket(group)
+		if !ok {
+			continue
+		}
+
+		opp, exists = d.findOpportunity(synthetic, group)
+		if exists {
+			d.sendOpportunity(synthetic, opp)
+		}
+	}
+}
+
+// sendOpportunity publishes an opportunity on OpportunityChan without blocking. When the
+// buffer is full the oldest buffered opportunity is dropped, so a stalled consumer
+// resumes on fresh prices instead of a backlog of stale ones.
+func (d *Detector) sendOpportunity(targetMarket *types.MarketSubscription, opp *Opportunity) {
+	if !d.publish(opp) {
+		OpportunitiesDroppedTotal.Inc()
+		d.logger.Warn("opportunity-channel-full",
+			zap.String("market-slug", targetMarket.MarketSlug),
+			tracing.Field(opp.TraceID))
+		return
+	}
+
+	d.logger.Info("arbitrage-opportunity-detected",
+		zap.String("opportunity-id", opp.ID),
+		tracing.Field(opp.TraceID),
+		zap.String("market-slug", opp.MarketSlug),
+		zap.Int("net-profit-bps", opp.NetProfitBPS),
+		zap.Float64("net-profit", opp.NetProfit),
+		zap.Float64("book-imbalance", opp.BookImbalance),
+		zap.Int("outcome-count", len(opp.Outcomes)),
+		zap.Int("skipped-outcomes", len(opp.SkippedOutcomes)),
+		zap.Bool("neg-risk", opp.NegRisk),
+		zap.String("linked-group", opp.LinkedGroup))
+}
+
+// publish sends opp on OpportunityChan, evicting the oldest buffered opportunity if the
+// buffer is full. It reports false if opp itself could not be buffered because other
+// senders refilled the buffer first.
+func (d *Detector) publish(opp *Opportunity) bool {
+	select {
+	case d.opportunityChan <- opp:
+		return true
+	default:
+	}
+
+	select {
+	case stale := <-d.opportunityChan:
+		OpportunitiesDroppedTotal.Inc()
+		d.logger.Debug("opportunity-dropped-stale",
+			zap.String("opportunity-id", stale.ID),
+			tracing.Field(stale.TraceID),
+			zap.String("market-slug", stale.MarketSlug))
+	default:
+	}
+
+	select {
+	case d.opportunityChan <- opp:
+		return true
+	default:
+		return false
+	}
+}
+
+// Scan evaluates every subscribed market once and returns the opportunities found
+// instead of sending them on OpportunityChan. Used for one-shot runs.
+func (d *Detector) Scan() []*Opportunity {
+	var opportunities []*Opportunity
+	for _, market := range d.discoveryService.GetSubscribedMarkets() {
+		opp, exists := d.findOpportunity(market, nil)
+		if !exists {
+			continue
+		}
+
+		d.logScanned(opp)
+		opportunities = append(opportunities, opp)
+	}
+
+	for i := range d.config.LinkedGroups {
+		group := &d.config.LinkedGroups[i]
+		synthetic, ok := d.linkedMarket(group)
+		if !ok {
+			continue
+		}
+
+		opp, exists := d.findOpportunity(synthetic, group)
+		if !exists {
+			continue
+		}
+
+		d.logScanned(opp)
+		opportunities = append(opportunities, opp)
+	}
+
+	return opportunities
+}
+
+// logScanned logs an opportunity found by Scan.
+func (d *Detector) logScanned(opp *Opportunity) {
+	d.logger.Info("arbitrage-opportunity-detected",
+		zap.String("opportunity-id", opp.ID),
+		tracing.Field(opp.TraceID),
+		zap.String("market-slug", opp.MarketSlug),
+		zap.Int("net-profit-bps", opp.NetProfitBPS),
+		zap.Float64("net-profit", opp.NetProfit),
+		zap.Int("outcome-count", len(opp.Outcomes)),
+		zap.Bool("neg-risk", opp.NegRisk),
+		zap.String("linked-group", opp.LinkedGroup))
+}
+
+// findOpportunity runs detection on a market's latest snapshots and stores any opportunity found.
+// group is the linked group a synthetic market was built from, or nil for a real market.
+func (d *Detector) findOpportunity(targetMarket *types.MarketSubscription, group *LinkedMarketGroup) (*Opportunity, bool) {
 	// Get orderbooks for ALL outcomes in this market
 	orderbooks := make([]*types.OrderbookSnapshot, 0, len(targetMarket.Outcomes))
 	for _, outcome := range targetMarket.Outcomes {
@@ -110,7 +342,7 @@ func (d *Detector) checkArbitrageForToken(update *types.OrderbookSnapshot) {
 			d.logger.Debug("orderbook-missing-for-outcome",
 				zap.String("market-id", targetMarket.MarketID),
 				zap.String("outcome", outcome.Outcome))
-			return
+			return nil, false
 		}
 		orderbooks = append(orderbooks, snapshot)
 	}
@@ -118,7 +350,7 @@ func (d *Detector) checkArbitrageForToken(update *types.OrderbookSnapshot) {
 	// Check for arbitrage (works for both binary and multi-outcome)
 	opp, exists := d.detectMultiOutcome(targetMarket, orderbooks)
 	if !exists {
-		return
+		return nil, false
 	}
 
 	// Track end-to-end latency (from orderbook update to opportunity detection)
@@ -132,26 +364,21 @@ func (d *Detector) checkArbitrageForToken(update *types.OrderbookSnapshot) {
 	e2eLatency := time.Since(latestUpdate).Seconds()
 	EndToEndLatencySeconds.Observe(e2eLatency)
 
+	if group != nil {
+		opp.LinkedGroup = group.Name
+		LinkedOpportunitiesDetectedTotal.WithLabelValues(group.Name).Inc()
+	}
+
 	// Store opportunity
 	err := d.storage.StoreOpportunity(d.ctx, opp)
 	if err != nil {
 		d.logger.Error("failed-to-store-opportunity",
 			zap.String("opportunity-id", opp.ID),
+			tracing.Field(opp.TraceID),
 			zap.Error(err))
 	}
 
-	// Send opportunity (non-blocking)
-	select {
-	case d.opportunityChan <- opp:
-		d.logger.Info("arbitrage-opportunity-detected",
-			zap.String("opportunity-id", opp.ID),
-			zap.String("market-slug", opp.MarketSlug),
-			zap.Int("net-profit-bps", opp.NetProfitBPS),
-			zap.Float64("net-profit", opp.NetProfit),
-			zap.Int("outcome-count", len(opp.Outcomes)))
-	default:
-		d.logger.Warn("opportunity-channel-full", zap.String("market-slug", targetMarket.MarketSlug))
-	}
+	return opp, true
 }
 
 // detectOpportunities scans all markets for arbitrage opportunities.
@@ -188,20 +415,12 @@ func (d *Detector) detectOpportunities() {
 		if err != nil {
 			d.logger.Error("failed-to-store-opportunity",
 				zap.String("opportunity-id", opp.ID),
+				tracing.Field(opp.TraceID),
 				zap.Error(err))
 		}
 
 		// Send opportunity (non-blocking)
-		select {
-		case d.opportunityChan <- opp:
-			d.logger.Info("arbitrage-opportunity-detected",
-				zap.String("opportunity-id", opp.ID),
-				zap.String("market-slug", opp.MarketSlug),
-				zap.Int("net-profit-bps", opp.NetProfitBPS),
-				zap.Float64("net-profit", opp.NetProfit))
-		default:
-			d.logger.Warn("opportunity-channel-full", zap.String("market-slug", market.MarketSlug))
-		}
+		d.sendOpportunity(market, opp)
 	}
 }
 
@@ -223,23 +442,52 @@ func (d *Detector) detectMultiOutcome(
 	market *types.MarketSubscription,
 	orderbooks []*types.OrderbookSnapshot,
 ) (*Opportunity, bool) {
+	// A malformed market with hundreds of tokens would mean a huge batch order, and
+	// per-outcome fees make high-N arbitrage unprofitable anyway
+	if d.config.MaxOutcomes > 0 && len(orderbooks) > d.config.MaxOutcomes {
+		d.reject(market, RejectTooManyOutcomes,
+			zap.Int("outcome-count", len(orderbooks)),
+			zap.Int("max-outcomes", d.config.MaxOutcomes))
+		return nil, false
+	}
+
+	// Read once so the whole evaluation uses one consistent threshold
+	threshold := d.GetThreshold()
+
 	// Validate all orderbooks have valid prices and sizes
 	for i, book := range orderbooks {
-		if book.BestAskPrice <= 0 {
-			d.logger.Debug("invalid-ask-price",
-				zap.String("market-slug", market.MarketSlug),
+		// No ask at all: the outcome can't be bought, so the market can't be arbitraged
+		if book.BestAskPrice == 0 {
+			if book.BestBidPrice > 0 {
+				OneSidedBookTotal.Inc()
+			}
+			d.reject(market, RejectNoAsk,
+				zap.Int("outcome-index", i),
+				zap.String("token-id", book.TokenID),
+				zap.Float64("best-bid", book.BestBidPrice))
+			return nil, false
+		}
+
+		if book.BestAskPrice < 0 {
+			d.reject(market, RejectInvalidPrice,
 				zap.Int("outcome-index", i),
 				zap.Float64("price", book.BestAskPrice))
-			OpportunitiesRejectedTotal.WithLabelValues("invalid_price").Inc()
 			return nil, false
 		}
 
 		if book.BestAskSize <= 0 {
-			d.logger.Debug("invalid-ask-size",
-				zap.String("market-slug", market.MarketSlug),
+			d.reject(market, RejectInvalidSize,
 				zap.Int("outcome-index", i),
 				zap.Float64("size", book.BestAskSize))
-			OpportunitiesRejectedTotal.WithLabelValues("invalid_size").Inc()
+			return nil, false
+		}
+
+		// A crossed book (bid >= ask) is malformed or transient and can fake an arbitrage
+		if book.BestBidPrice > 0 && book.BestBidPrice >= book.BestAskPrice {
+			d.reject(market, RejectCrossedBook,
+				zap.Int("outcome-index", i),
+				zap.Float64("best-bid", book.BestBidPrice),
+				zap.Float64("best-ask", book.BestAskPrice))
 			return nil, false
 		}
 	}
@@ -251,18 +499,21 @@ func (d *Detector) detectMultiOutcome(
 	}
 
 	// Check if arbitrage exists
-	if priceSum >= d.config.MaxPriceSum {
-		d.logger.Debug("price-above-threshold",
-			zap.String("market-slug", market.MarketSlug),
+	if priceSum >= threshold {
+		d.reject(market, RejectPriceAboveThreshold,
 			zap.Float64("price-sum", priceSum),
-			zap.Float64("threshold", d.config.MaxPriceSum),
-			zap.Float64("shortfall", priceSum-d.config.MaxPriceSum))
-		OpportunitiesRejectedTotal.WithLabelValues("price_above_threshold").Inc()
+			zap.Float64("threshold", threshold),
+			zap.Float64("shortfall", priceSum-threshold))
 		return nil, false
 	}
 
+	// The full set is below the threshold, so the tail's asks are covered by the spread
+	// and a neg-risk market can drop its fee-heavy tail legs
+	market, orderbooks, skipped := tailSubset(market, orderbooks, d.config.TailPriceFloor)
+	tailAskSum := skippedAskSum(skipped)
+
 	// POTENTIAL ARBITRAGE DETECTED - Print detailed analysis before validation
-	d.printArbitrageAnalysis(market, orderbooks, priceSum)
+	d.printArbitrageAnalysis(market, orderbooks, priceSum, threshold)
 
 	// Find minimum size across all outcomes (bottleneck for trade size)
 	maxSize := orderbooks[0].BestAskSize
@@ -283,22 +534,44 @@ func (d *Detector) detectMultiOutcome(
 
 	// Check minimum trade size
 	if maxSize < d.config.MinTradeSize {
-		d.logger.Info("opportunity-rejected-below-min-size",
-			zap.String("market-slug", market.MarketSlug),
+		d.reject(market, RejectBelowMinSize,
 			zap.Float64("price-sum", priceSum),
-			zap.Float64("spread", d.config.MaxPriceSum-priceSum),
+			zap.Float64("spread", threshold-priceSum),
 			zap.Float64("calculated-size", maxSize),
 			zap.Float64("min-size", d.config.MinTradeSize))
-		OpportunitiesRejectedTotal.WithLabelValues("below_min_size").Inc()
 		return nil, false
 	}
 
+	// Check total ask liquidity across all outcomes
+	if d.config.MinTotalAskLiquidityUSD > 0 {
+		totalLiquidity := 0.0
+		for _, book := range orderbooks {
+			totalLiquidity += book.AskLiquidityUSD()
+		}
+
+		if totalLiquidity < d.config.MinTotalAskLiquidityUSD {
+			d.reject(market, RejectBelowMinLiquidity,
+				zap.Float64("price-sum", priceSum),
+				zap.Float64("total-ask-liquidity-usd", totalLiquidity),
+				zap.Float64("min-liquidity-usd", d.config.MinTotalAskLiquidityUSD))
+			return nil, false
+		}
+	}
+
+	// Every leg is bought in the same token count, which the executor sizes so the most
+	// expensive leg fits the USD budget
+	maxAskPrice := 0.0
+	for _, book := range orderbooks {
+		maxAskPrice = math.Max(maxAskPrice, book.BestAskPrice)
+	}
+	tokensPerOutcome := maxSize / maxAskPrice
+
 	// Fetch market-specific metadata for all outcomes
 	outcomes := make([]OpportunityOutcome, len(orderbooks))
-	var requiredUSD float64
 
 	for i, book := range orderbooks {
 		var tickSize, minSize float64
+		tickSizeUnknown := false
 
 		// Use metadata client if available, otherwise use defaults
 		if d.metadataClient != nil {
@@ -314,36 +587,27 @@ func (d *Detector) detectMultiOutcome(
 				// Use defaults
 				tickSize = 0.01
 				minSize = 5.0
+				tickSizeUnknown = true
 			}
 		} else {
 			// No metadata client available, use defaults
 			tickSize = 0.01
 			minSize = 5.0
+			tickSizeUnknown = true
 		}
 
-		// Calculate token size for this outcome
-		tokenSize := maxSize / book.BestAskPrice
-
-		// Check if this outcome meets minimum requirements
-		if tokenSize < minSize {
-			d.logger.Info("opportunity-rejected-below-market-minimum",
-				zap.String("market-slug", market.MarketSlug),
+		// Reject here rather than let the order client fail the whole batch on this leg
+		if tokensPerOutcome < minSize {
+			d.reject(market, RejectBelowMarketMin,
 				zap.String("outcome", market.Outcomes[i].Outcome),
 				zap.Float64("price-sum", priceSum),
-				zap.Float64("spread", d.config.MaxPriceSum-priceSum),
-				zap.Float64("token-size", tokenSize),
+				zap.Float64("spread", threshold-priceSum),
+				zap.Float64("token-size", tokensPerOutcome),
 				zap.Float64("market-min-size", minSize),
-				zap.Float64("required-usd", minSize*book.BestAskPrice))
-			OpportunitiesRejectedTotal.WithLabelValues("below_market_min").Inc()
+				zap.Float64("required-usd", minSize*maxAskPrice))
 			return nil, false
 		}
 
-		// Track the largest USD minimum across all outcomes
-		requiredUSDForOutcome := minSize * book.BestAskPrice
-		if requiredUSDForOutcome > requiredUSD {
-			requiredUSD = requiredUSDForOutcome
-		}
-
 		// Build outcome structure
 		outcomes[i] = OpportunityOutcome{
 			TokenID:  book.TokenID,
@@ -352,42 +616,70 @@ func (d *Detector) detectMultiOutcome(
 			AskSize:  book.BestAskSize,
 			TickSize: tickSize,
 			MinSize:  minSize,
-		}
-	}
 
-	// Adjust maxSize upward to meet all minimum requirements
-	if maxSize < requiredUSD {
-		maxSize = requiredUSD
+			BidPrice:        book.BestBidPrice,
+			BidSize:         book.BestBidSize,
+			Imbalance:       TopOfBookImbalance(book.BestBidSize, book.BestAskSize),
+			TickSizeUnknown: tickSizeUnknown,
+		}
 	}
 
 	// Create opportunity using multi-outcome constructor
-	opp := NewMultiOutcomeOpportunity(
+	opp := NewMultiOutcomeOpportunityWithFees(
 		market.MarketID,
 		market.MarketSlug,
 		market.Question,
 		outcomes,
 		maxSize, // Pass calculated maxSize (includes all constraints)
-		d.config.MaxPriceSum,
-		d.config.TakerFee,
+		threshold,
+		d.feeModel(),
+		d.config.MinProfitUSD,
 	)
+	if opp == nil {
+		d.reject(market, RejectBelowMinProfitUSD,
+			zap.Float64("price-sum", priceSum),
+			zap.Float64("trade-size", maxSize),
+			zap.Float64("min-profit-usd", d.config.MinProfitUSD))
+		return nil, false
+	}
+
+	// The price math is the same for neg-risk markets; only settlement differs
+	opp.NegRisk = market.NegRisk
+	opp.ChargeSkippedTail(skipped)
 
-	// Check if net profit is positive after fees
+	// Limits leave room for the tail's asks, or aggression could spend the spread that
+	// covers them
+	if d.config.LimitPrices {
+		opp.SetLimitPrices(opp.MaxKeptPriceSum())
+	}
+
+	// Check if net profit is positive after fees, and after the expected loss of a skipped tail
 	if opp.NetProfit <= 0 {
-		d.logger.Info("opportunity-rejected-negative-profit-after-fees",
-			zap.String("market-slug", market.MarketSlug),
+		d.reject(market, RejectNegativeProfitAfterFees,
 			zap.Float64("price-sum", opp.TotalPriceSum),
-			zap.Float64("spread", d.config.MaxPriceSum-opp.TotalPriceSum),
+			zap.Float64("spread", threshold-opp.TotalPriceSum),
 			zap.Float64("trade-size", opp.MaxTradeSize),
 			zap.Float64("gross-profit", opp.EstimatedProfit),
 			zap.Float64("total-fees", opp.TotalFees),
 			zap.Float64("net-profit", opp.NetProfit),
+			zap.Int("skipped-outcomes", len(skipped)),
+			zap.Float64("skipped-ask-sum", tailAskSum),
 			zap.Float64("taker-fee-rate", d.config.TakerFee))
-		OpportunitiesRejectedTotal.WithLabelValues("negative_profit_after_fees").Inc()
 		return nil, false
 	}
 
+	if len(skipped) > 0 {
+		TailOutcomesSkippedTotal.Add(float64(len(skipped)))
+		d.logger.Debug("tail-outcomes-skipped",
+			zap.String("market-slug", market.MarketSlug),
+			zap.Int("skipped-outcomes", len(skipped)),
+			zap.Float64("skipped-ask-sum", tailAskSum),
+			zap.Float64("tail-price-floor", d.config.TailPriceFloor))
+	}
+
 	// Update metrics
 	OpportunitiesDetectedTotal.Inc()
+	d.opportunityCount.Add(1)
 	OpportunityProfitBPS.Observe(float64(opp.ProfitBPS))
 	OpportunitySizeUSD.Observe(opp.MaxTradeSize)
 	NetProfitBPS.Observe(float64(opp.NetProfitBPS))
@@ -395,6 +687,19 @@ func (d *Detector) detectMultiOutcome(
 	return opp, true
 }
 
+// feeModel returns the configured fee model, defaulting to the flat taker fee.
+func (d *Detector) feeModel() FeeModel {
+	if d.config.FeeModel != nil {
+		return d.config.FeeModel
+	}
+	return FlatFeeModel{TakerRate: d.config.TakerFee}
+}
+
+// OpportunityCount returns the number of opportunities detected since start.
+func (d *Detector) OpportunityCount() uint64 {
+	return d.opportunityCount.Load()
+}
+
 // OpportunityChan returns the channel for receiving opportunities.
 func (d *Detector) OpportunityChan() <-chan *Opportunity {
 	return d.opportunityChan
@@ -413,32 +718,37 @@ func (d *Detector) printArbitrageAnalysis(
 	market *types.MarketSubscription,
 	orderbooks []*types.OrderbookSnapshot,
 	priceSum float64,
+	threshold float64,
 ) {
-	fmt.Println("\n" + "┌────────────────────────────────────────────────────────────────────────────┐")
-	fmt.Printf("│ POTENTIAL ARBITRAGE: %s\n", market.MarketSlug)
-	fmt.Println("└────────────────────────────────────────────────────────────────────────────┘")
-	fmt.Printf("  Question: %s\n", market.Question)
-	fmt.Printf("  Market ID: %s\n", market.MarketID)
-	fmt.Println()
+	// Built into one write: detection workers run concurrently and separate prints
+	// from different markets would interleave on stdout
+	var b strings.Builder
+
+	fmt.Fprintln(&b, "\n"+"┌────────────────────────────────────────────────────────────────────────────┐")
+	fmt.Fprintf(&b, "│ POTENTIAL ARBITRAGE: %s\n", market.MarketSlug)
+	fmt.Fprintln(&b, "└────────────────────────────────────────────────────────────────────────────┘")
+	fmt.Fprintf(&b, "  Question: %s\n", market.Question)
+	fmt.Fprintf(&b, "  Market ID: %s\n", market.MarketID)
+	b.WriteString("\n")
 
 	// Print all outcomes with prices and sizes
-	fmt.Println("  OUTCOMES:")
+	fmt.Fprintln(&b, "  OUTCOMES:")
 	for i, book := range orderbooks {
 		outcome := market.Outcomes[i].Outcome
-		fmt.Printf("    [%d] %-15s Ask: $%.4f × %.2f tokens\n",
+		fmt.Fprintf(&b, "    [%d] %-15s Ask: $%.4f × %.2f tokens\n",
 			i+1, outcome, book.BestAskPrice, book.BestAskSize)
 	}
-	fmt.Println()
+	b.WriteString("\n")
 
 	// Calculate spread and potential profit
-	spread := d.config.MaxPriceSum - priceSum
+	spread := threshold - priceSum
 	spreadBPS := spread * 10000
 
-	fmt.Println("  PRICE ANALYSIS:")
-	fmt.Printf("    Sum of Ask Prices:  %.6f\n", priceSum)
-	fmt.Printf("    Threshold:          %.6f\n", d.config.MaxPriceSum)
-	fmt.Printf("    Spread:             %.6f (%.0f bps)\n", spread, spreadBPS)
-	fmt.Println()
+	fmt.Fprintln(&b, "  PRICE ANALYSIS:")
+	fmt.Fprintf(&b, "    Sum of Ask Prices:  %.6f\n", priceSum)
+	fmt.Fprintf(&b, "    Threshold:          %.6f\n", threshold)
+	fmt.Fprintf(&b, "    Spread:             %.6f (%.0f bps)\n", spread, spreadBPS)
+	b.WriteString("\n")
 
 	// Find minimum available size
 	minSize := orderbooks[0].BestAskSize
@@ -451,54 +761,55 @@ func (d *Detector) printArbitrageAnalysis(
 	}
 
 	// Calculate trade sizes for each outcome
-	fmt.Println("  SIZE ANALYSIS:")
-	fmt.Printf("    Available Sizes:\n")
+	fmt.Fprintln(&b, "  SIZE ANALYSIS:")
+	fmt.Fprintf(&b, "    Available Sizes:\n")
 	for i, book := range orderbooks {
 		usdValue := book.BestAskSize * book.BestAskPrice
-		fmt.Printf("      %-15s %.2f tokens = $%.2f\n",
+		fmt.Fprintf(&b, "      %-15s %.2f tokens = $%.2f\n",
 			market.Outcomes[i].Outcome+":", book.BestAskSize, usdValue)
 	}
-	fmt.Printf("    Bottleneck:         %s (%.2f tokens)\n", bottleneckOutcome, minSize)
-	fmt.Printf("    Max Trade Size:     $%.2f (before caps)\n", minSize)
-	fmt.Println()
+	fmt.Fprintf(&b, "    Bottleneck:         %s (%.2f tokens)\n", bottleneckOutcome, minSize)
+	fmt.Fprintf(&b, "    Max Trade Size:     $%.2f (before caps)\n", minSize)
+	b.WriteString("\n")
 
 	// Apply size caps
 	cappedSize := minSize
 	if cappedSize > d.config.MaxTradeSize {
-		fmt.Printf("    ⚠ Capped by MAX:    $%.2f → $%.2f\n", cappedSize, d.config.MaxTradeSize)
+		fmt.Fprintf(&b, "    ⚠ Capped by MAX:    $%.2f → $%.2f\n", cappedSize, d.config.MaxTradeSize)
 		cappedSize = d.config.MaxTradeSize
 	}
 
 	// Check minimum
 	meetsMin := cappedSize >= d.config.MinTradeSize
-	fmt.Printf("    Min Trade Size:     $%.2f %s\n",
+	fmt.Fprintf(&b, "    Min Trade Size:     $%.2f %s\n",
 		d.config.MinTradeSize,
 		map[bool]string{true: "✓", false: "✗ FAILS"}[meetsMin])
-	fmt.Printf("    Final Trade Size:   $%.2f\n", cappedSize)
-	fmt.Println()
+	fmt.Fprintf(&b, "    Final Trade Size:   $%.2f\n", cappedSize)
+	b.WriteString("\n")
 
 	// Calculate gross profit and fees
 	grossProfit := cappedSize * spread
-	feesPerOutcome := cappedSize * d.config.TakerFee
-	totalFees := feesPerOutcome * float64(len(orderbooks))
+	fees := d.feeModel()
+	totalFees := 0.0
+	for _, book := range orderbooks {
+		totalFees += fees.Fee(FeeSideTaker, book.BestAskPrice, cappedSize)
+	}
 	netProfit := grossProfit - totalFees
 
-	fmt.Println("  PROFIT ANALYSIS:")
-	fmt.Printf("    Gross Profit:       $%.4f (%.0f bps)\n", grossProfit, spreadBPS)
-	fmt.Printf("    Taker Fee Rate:     %.2f%% per outcome\n", d.config.TakerFee*100)
-	fmt.Printf("    Fees (%d outcomes):  $%.4f ($%.4f × %d)\n",
-		len(orderbooks), totalFees, feesPerOutcome, len(orderbooks))
-	fmt.Printf("    Net Profit:         $%.4f ", netProfit)
+	fmt.Fprintln(&b, "  PROFIT ANALYSIS:")
+	fmt.Fprintf(&b, "    Gross Profit:       $%.4f (%.0f bps)\n", grossProfit, spreadBPS)
+	fmt.Fprintf(&b, "    Fees (%d outcomes):  $%.4f (taker)\n", len(orderbooks), totalFees)
+	fmt.Fprintf(&b, "    Net Profit:         $%.4f ", netProfit)
 	if netProfit > 0 {
 		netBPS := (netProfit / cappedSize) * 10000
-		fmt.Printf("(%.0f bps) ✓\n", netBPS)
+		fmt.Fprintf(&b, "(%.0f bps) ✓\n", netBPS)
 	} else {
-		fmt.Printf("✗ UNPROFITABLE\n")
+		fmt.Fprintf(&b, "✗ UNPROFITABLE\n")
 	}
-	fmt.Println()
+	b.WriteString("\n")
 
 	// Check market minimums (estimate)
-	fmt.Println("  MARKET MINIMUM CHECK:")
+	fmt.Fprintln(&b, "  MARKET MINIMUM CHECK:")
 	for i, book := range orderbooks {
 		// Use default minimum of 5 tokens as example
 		minTokens := 5.0
@@ -506,23 +817,25 @@ func (d *Detector) printArbitrageAnalysis(
 		requiredUSD := minTokens * book.BestAskPrice
 		meetsMarketMin := tokenAmount >= minTokens
 
-		fmt.Printf("    %-15s %.2f tokens %s (min: %.0f, need: $%.2f)\n",
+		fmt.Fprintf(&b, "    %-15s %.2f tokens %s (min: %.0f, need: $%.2f)\n",
 			market.Outcomes[i].Outcome+":",
 			tokenAmount,
 			map[bool]string{true: "✓", false: "✗"}[meetsMarketMin],
 			minTokens,
 			requiredUSD)
 	}
-	fmt.Println()
+	b.WriteString("\n")
 
 	// Print validation status
-	fmt.Println("  VALIDATION:")
-	fmt.Printf("    Price Check:        %s (sum < threshold)\n",
-		map[bool]string{true: "✓ PASS", false: "✗ FAIL"}[priceSum < d.config.MaxPriceSum])
-	fmt.Printf("    Size Check:         %s (size >= min)\n",
+	fmt.Fprintln(&b, "  VALIDATION:")
+	fmt.Fprintf(&b, "    Price Check:        %s (sum < threshold)\n",
+		map[bool]string{true: "✓ PASS", false: "✗ FAIL"}[priceSum < threshold])
+	fmt.Fprintf(&b, "    Size Check:         %s (size >= min)\n",
 		map[bool]string{true: "✓ PASS", false: "✗ FAIL"}[cappedSize >= d.config.MinTradeSize])
-	fmt.Printf("    Profit Check:       %s (net profit > 0)\n",
+	fmt.Fprintf(&b, "    Profit Check:       %s (net profit > 0)\n",
 		map[bool]string{true: "✓ PASS", false: "✗ FAIL"}[netProfit > 0])
 
-	fmt.Println("─────────────────────────────────────────────────────────────────────────────")
+	fmt.Fprintln(&b, "─────────────────────────────────────────────────────────────────────────────")
+
+	fmt.Print(b.String())
 }
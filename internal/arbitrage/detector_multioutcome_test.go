@@ -1,11 +1,17 @@
 package arbitrage
 
 import (
+	"math"
 	"testing"
 	"time"
 
+	promtestutil "github.com/prometheus/client_golang/prometheus/testutil"
+
+	"github.com/mselser95/polymarket-arb/internal/markets"
 	"github.com/mselser95/polymarket-arb/pkg/types"
 	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
 )
 
 // TestDetectMultiOutcome_3Outcome tests arbitrage detection for 3-outcome markets
@@ -216,6 +222,176 @@ func TestDetectMultiOutcome_InvalidSizes(t *testing.T) {
 	}
 }
 
+// TestDetectMultiOutcome_CrossedBook tests crossed snapshots never produce opportunities
+func TestDetectMultiOutcome_CrossedBook(t *testing.T) {
+	tests := []struct {
+		name    string
+		bestBid float64
+	}{
+		{name: "bid-above-ask", bestBid: 0.40},
+		{name: "bid-equals-ask", bestBid: 0.32},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			market := create3OutcomeMarket("test-market", "test-slug")
+			// Sum = 0.96 would otherwise be a valid arbitrage
+			orderbooks := createOrderbooksFromPrices(market, []float64{0.32, 0.32, 0.32}, []float64{100.0, 100.0, 100.0})
+			orderbooks[1].BestBidPrice = tt.bestBid
+
+			logger, _ := zap.NewDevelopment()
+			detector := &Detector{
+				config: Config{
+					MaxPriceSum:  0.995,
+					MinTradeSize: 1.0,
+					MaxTradeSize: 1000.0,
+					TakerFee:     0.01,
+				},
+				logger: logger,
+			}
+
+			_, exists := detector.detectMultiOutcome(market, orderbooks)
+			if exists {
+				t.Error("expected no opportunity with crossed book")
+			}
+		})
+	}
+}
+
+// TestDetectMultiOutcome_BookImbalance tests per-outcome and aggregate top-of-book imbalance
+func TestDetectMultiOutcome_BookImbalance(t *testing.T) {
+	tests := []struct {
+		name            string
+		bidSizes        []float64
+		expectOutcomes  []float64
+		expectAggregate float64
+	}{
+		{
+			name:            "balanced",
+			bidSizes:        []float64{100.0, 100.0, 100.0},
+			expectOutcomes:  []float64{0.5, 0.5, 0.5},
+			expectAggregate: 0.5,
+		},
+		{
+			name:            "bid-heavy",
+			bidSizes:        []float64{300.0, 300.0, 900.0},
+			expectOutcomes:  []float64{0.75, 0.75, 0.9},
+			expectAggregate: 0.75,
+		},
+		{
+			name:            "ask-heavy",
+			bidSizes:        []float64{25.0, 100.0, 0.0},
+			expectOutcomes:  []float64{0.2, 0.5, 0.0},
+			expectAggregate: 0.0, // No bid support on one leg dominates
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			market := create3OutcomeMarket("test-market", "test-slug")
+			orderbooks := createOrderbooksFromPrices(market, []float64{0.32, 0.32, 0.32}, []float64{100.0, 100.0, 100.0})
+			for i, book := range orderbooks {
+				book.BestBidPrice = 0.31
+				book.BestBidSize = tt.bidSizes[i]
+			}
+
+			detector := &Detector{
+				config: Config{
+					MaxPriceSum:  0.995,
+					MinTradeSize: 1.0,
+					MaxTradeSize: 1000.0,
+					TakerFee:     0.01,
+				},
+				logger: zap.NewNop(),
+			}
+
+			opp, exists := detector.detectMultiOutcome(market, orderbooks)
+			if !exists {
+				t.Fatal("expected opportunity")
+			}
+
+			for i, outcome := range opp.Outcomes {
+				if math.Abs(outcome.Imbalance-tt.expectOutcomes[i]) > 1e-9 {
+					t.Errorf("outcome %d: expected imbalance %.4f, got %.4f", i, tt.expectOutcomes[i], outcome.Imbalance)
+				}
+				if outcome.BidSize != tt.bidSizes[i] {
+					t.Errorf("outcome %d: expected bid size %.2f, got %.2f", i, tt.bidSizes[i], outcome.BidSize)
+				}
+			}
+
+			if math.Abs(opp.BookImbalance-tt.expectAggregate) > 1e-9 {
+				t.Errorf("expected book imbalance %.4f, got %.4f", tt.expectAggregate, opp.BookImbalance)
+			}
+		})
+	}
+}
+
+// TestDetectMultiOutcome_MinProfitUSD tests the absolute profit floor independent of spread
+func TestDetectMultiOutcome_MinProfitUSD(t *testing.T) {
+	tests := []struct {
+		name         string
+		sizes        []float64
+		minProfitUSD float64
+		expectOpp    bool
+	}{
+		// Sum = 0.96: 400bps gross spread passes MaxPriceSum in every case
+		{name: "floor-disabled", sizes: []float64{20.0, 20.0, 20.0}, minProfitUSD: 0, expectOpp: true},
+		{name: "tiny-size-below-floor", sizes: []float64{20.0, 20.0, 20.0}, minProfitUSD: 1.0, expectOpp: false},
+		{name: "large-size-above-floor", sizes: []float64{100.0, 100.0, 100.0}, minProfitUSD: 1.0, expectOpp: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			market := create3OutcomeMarket("test-market", "test-slug")
+			orderbooks := createOrderbooksFromPrices(market, []float64{0.32, 0.32, 0.32}, tt.sizes)
+
+			detector := &Detector{
+				config: Config{
+					MaxPriceSum:  0.995,
+					MinTradeSize: 1.0,
+					MaxTradeSize: 1000.0,
+					TakerFee:     0.01,
+					MinProfitUSD: tt.minProfitUSD,
+				},
+				logger: zap.NewNop(),
+			}
+
+			// size 20: net = 20 × 0.04 - 20 × 0.96 × 0.01 = $0.61; size 100: $3.04
+			opp, exists := detector.detectMultiOutcome(market, orderbooks)
+			if exists != tt.expectOpp {
+				t.Fatalf("expected opportunity=%v, got %v", tt.expectOpp, exists)
+			}
+
+			if exists && tt.minProfitUSD > 0 && opp.NetProfit < tt.minProfitUSD {
+				t.Errorf("net profit %.4f below floor %.4f", opp.NetProfit, tt.minProfitUSD)
+			}
+		})
+	}
+}
+
+// TestNewMultiOutcomeOpportunity_MinProfitUSD tests the constructor returns nil below the floor
+func TestNewMultiOutcomeOpportunity_MinProfitUSD(t *testing.T) {
+	outcomes := []OpportunityOutcome{
+		{TokenID: "yes", Outcome: "YES", AskPrice: 0.40, AskSize: 100},
+		{TokenID: "no", Outcome: "NO", AskPrice: 0.40, AskSize: 100},
+	}
+
+	// 2000bps spread on $1: net = 0.20 - 0.008 = $0.192
+	opp := NewMultiOutcomeOpportunity("m", "slug", "q", outcomes, 1.0, 0.995, 0.01, 0.50)
+	if opp != nil {
+		t.Errorf("expected nil below floor, got net profit %.4f", opp.NetProfit)
+	}
+
+	opp = NewMultiOutcomeOpportunity("m", "slug", "q", outcomes, 1.0, 0.995, 0.01, 0.10)
+	if opp == nil {
+		t.Fatal("expected opportunity above floor")
+	}
+
+	if math.Abs(opp.NetProfit-0.192) > 1e-9 {
+		t.Errorf("expected net profit 0.192, got %.4f", opp.NetProfit)
+	}
+}
+
 // TestDetectMultiOutcome_MissingOrderbook tests when orderbooks are incomplete
 func TestDetectMultiOutcome_MissingOrderbook(t *testing.T) {
 	market := create3OutcomeMarket("test-market", "test-slug")
@@ -451,6 +627,63 @@ func TestDetectMultiOutcome_LargeMarkets(t *testing.T) {
 	}
 }
 
+// TestDetectMultiOutcome_MaxOutcomes tests that markets above the outcome limit are skipped.
+func TestDetectMultiOutcome_MaxOutcomes(t *testing.T) {
+	tests := []struct {
+		name        string
+		outcomes    int
+		maxOutcomes int
+		expectOpp   bool
+	}{
+		{name: "below-limit", outcomes: 4, maxOutcomes: 5, expectOpp: true},
+		{name: "at-limit", outcomes: 5, maxOutcomes: 5, expectOpp: true},
+		{name: "above-limit", outcomes: 6, maxOutcomes: 5, expectOpp: false},
+		{name: "unlimited", outcomes: 6, maxOutcomes: 0, expectOpp: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			market := createNOutcomeMarket("test-market", "test-slug", tt.outcomes)
+
+			// 0.15 per outcome keeps every case well below threshold
+			prices := make([]float64, tt.outcomes)
+			sizes := make([]float64, tt.outcomes)
+			for i := range prices {
+				prices[i] = 0.15
+				sizes[i] = 100.0
+			}
+			orderbooks := createOrderbooksFromPrices(market, prices, sizes)
+
+			detector := &Detector{
+				config: Config{
+					MaxPriceSum:  0.995,
+					MinTradeSize: 1.0,
+					MaxTradeSize: 10.0,
+					TakerFee:     0.01,
+					MaxOutcomes:  tt.maxOutcomes,
+				},
+				logger: zap.NewNop(),
+			}
+
+			rejectedBefore := promtestutil.ToFloat64(OpportunitiesRejectedTotal.WithLabelValues("too_many_outcomes"))
+
+			_, exists := detector.detectMultiOutcome(market, orderbooks)
+			if exists != tt.expectOpp {
+				t.Errorf("expected opportunity=%v, got=%v", tt.expectOpp, exists)
+			}
+
+			wantRejected := 0.0
+			if !tt.expectOpp {
+				wantRejected = 1
+			}
+			rejected := promtestutil.ToFloat64(OpportunitiesRejectedTotal.WithLabelValues("too_many_outcomes")) - rejectedBefore
+			if rejected != wantRejected {
+				t.Errorf("expected %v too_many_outcomes rejections, got %v", wantRejected, rejected)
+			}
+		})
+	}
+}
+
 // TestDetectMultiOutcome_BinaryCompatibility ensures binary markets still work
 func TestDetectMultiOutcome_BinaryCompatibility(t *testing.T) {
 	market := &types.MarketSubscription{
@@ -524,6 +757,88 @@ func create3OutcomeMarket(marketID, slug string) *types.MarketSubscription {
 	}
 }
 
+// metadataCache is an in-memory cache.Cache for seeding token metadata in tests.
+type metadataCache map[string]interface{}
+
+func (c metadataCache) Get(key string) (interface{}, bool) {
+	value, ok := c[key]
+	return value, ok
+}
+
+func (c metadataCache) Set(key string, value interface{}, _ time.Duration) bool {
+	c[key] = value
+	return true
+}
+
+func (c metadataCache) Delete(key string) { delete(c, key) }
+func (c metadataCache) Clear()            {}
+func (c metadataCache) Close()            {}
+
+// newSeededMetadataClient returns a metadata client that serves minSizes per token from
+// its cache, without any API calls.
+func newSeededMetadataClient(minSizes map[string]float64) *markets.CachedMetadataClient {
+	c := metadataCache{}
+	for tokenID, minSize := range minSizes {
+		c["metadata:"+tokenID] = &markets.TokenMetadata{TickSize: 0.01, MinOrderSize: minSize}
+	}
+	return markets.NewCachedMetadataClient(nil, c)
+}
+
+// TestDetectMultiOutcome_PerOutcomeMinSize tests that an outcome whose market minimum
+// exceeds the shared per-leg token count rejects the opportunity at detection. Legs are
+// sized by the most expensive ask, so a cheap leg's minimum must be checked against
+// that count rather than the budget at its own price.
+func TestDetectMultiOutcome_PerOutcomeMinSize(t *testing.T) {
+	// $10 budget / 0.75 = 13.33 tokens per leg; 10 / 0.20 would be 50 for the cheap leg
+	prices := []float64{0.20, 0.75}
+
+	tests := []struct {
+		name        string
+		minSizes    map[string]float64
+		wantOpp     bool
+		wantOutcome string
+	}{
+		{name: "cheap_leg_min_above_shared_size", minSizes: map[string]float64{"token-a": 15, "token-b": 5}, wantOutcome: "Candidate A"},
+		{name: "expensive_leg_min_above_shared_size", minSizes: map[string]float64{"token-a": 5, "token-b": 14}, wantOutcome: "Candidate B"},
+		{name: "all_mins_met", minSizes: map[string]float64{"token-a": 13, "token-b": 5}, wantOpp: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			market := createNOutcomeMarket("min-size-market", "min-size-slug", 2)
+			orderbooks := createOrderbooksFromPrices(market, prices, []float64{100, 100})
+
+			core, logs := observer.New(zapcore.DebugLevel)
+			detector := &Detector{
+				config:         Config{MaxPriceSum: 0.995, MinTradeSize: 1, MaxTradeSize: 10},
+				logger:         zap.New(core),
+				metadataClient: newSeededMetadataClient(tt.minSizes),
+			}
+
+			opp, exists := detector.detectMultiOutcome(market, orderbooks)
+			if exists != tt.wantOpp {
+				t.Fatalf("expected opportunity=%v, got %v", tt.wantOpp, exists)
+			}
+
+			if tt.wantOpp {
+				if opp.Outcomes[0].MinSize != 13 || opp.MaxTradeSize != 10 {
+					t.Errorf("expected min sizes from metadata and a $10 trade, got %+v", opp)
+				}
+				return
+			}
+
+			rejected := logs.FilterMessage("opportunity-rejected").All()
+			if len(rejected) != 1 {
+				t.Fatalf("expected one opportunity-rejected log, got %d", len(rejected))
+			}
+			fields := rejected[0].ContextMap()
+			if fields["reason"] != string(RejectBelowMarketMin) || fields["outcome"] != tt.wantOutcome {
+				t.Errorf("expected %s rejection for %s, got %v", RejectBelowMarketMin, tt.wantOutcome, fields)
+			}
+		})
+	}
+}
+
 // Helper: Create N-outcome market
 func createNOutcomeMarket(marketID, slug string, n int) *types.MarketSubscription {
 	outcomes := make([]types.OutcomeToken, n)
@@ -562,3 +877,34 @@ func createOrderbooksFromPrices(market *types.MarketSubscription, prices, sizes
 
 	return orderbooks
 }
+
+// TestOpportunity_SetLimitPrices tests that limits split the headroom below the threshold,
+// round down to each outcome's tick and never fall below the ask.
+func TestOpportunity_SetLimitPrices(t *testing.T) {
+	opp := &Opportunity{
+		Outcomes: []OpportunityOutcome{
+			{Outcome: "A", AskPrice: 0.30, TickSize: 0.01},
+			{Outcome: "B", AskPrice: 0.300, TickSize: 0.001},
+			{Outcome: "C", AskPrice: 0.35, TickSize: 0.01},
+		},
+		TotalPriceSum: 0.95,
+	}
+
+	// 0.04 of headroom, 0.01333 per outcome
+	opp.SetLimitPrices(0.99)
+
+	want := []float64{0.31, 0.313, 0.36}
+	for i, outcome := range opp.Outcomes {
+		if !floatEquals(outcome.LimitPrice, want[i], 1e-9) {
+			t.Errorf("outcome %s: expected limit %f, got %f", outcome.Outcome, want[i], outcome.LimitPrice)
+		}
+	}
+
+	// No headroom: limits sit at the ask
+	opp.SetLimitPrices(0.95)
+	for _, outcome := range opp.Outcomes {
+		if !floatEquals(outcome.LimitPrice, outcome.AskPrice, 1e-9) {
+			t.Errorf("outcome %s: expected limit at ask %f, got %f", outcome.Outcome, outcome.AskPrice, outcome.LimitPrice)
+		}
+	}
+}
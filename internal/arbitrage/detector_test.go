@@ -1,11 +1,19 @@
 package arbitrage
 
 import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
+	promtestutil "github.com/prometheus/client_golang/prometheus/testutil"
+
 	"github.com/mselser95/polymarket-arb/pkg/types"
 	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
 )
 
 func TestDetect(t *testing.T) {
@@ -211,3 +219,378 @@ func TestDetectInvalidOrderbooks(t *testing.T) {
 		t.Error("expected no opportunity with zero size")
 	}
 }
+
+// TestDetect_CarriesNegRisk tests that the market's neg-risk flag is recorded on the opportunity.
+func TestDetect_CarriesNegRisk(t *testing.T) {
+	for _, negRisk := range []bool{false, true} {
+		market := &types.MarketSubscription{
+			MarketID:   "test-market",
+			MarketSlug: "test-slug",
+			Outcomes: []types.OutcomeToken{
+				{TokenID: "yes-token", Outcome: "YES"},
+				{TokenID: "no-token", Outcome: "NO"},
+			},
+			NegRisk: negRisk,
+		}
+		yesBook := &types.OrderbookSnapshot{TokenID: "yes-token", BestAskPrice: 0.45, BestAskSize: 100, LastUpdated: time.Now()}
+		noBook := &types.OrderbookSnapshot{TokenID: "no-token", BestAskPrice: 0.48, BestAskSize: 100, LastUpdated: time.Now()}
+
+		detector := &Detector{
+			config: Config{MaxPriceSum: 0.995, MinTradeSize: 1, MaxTradeSize: 100, TakerFee: 0.01},
+			logger: zap.NewNop(),
+		}
+
+		opp, exists := detector.detect(market, yesBook, noBook)
+		if !exists {
+			t.Fatalf("expected opportunity (neg-risk=%v)", negRisk)
+		}
+		if opp.NegRisk != negRisk {
+			t.Errorf("expected NegRisk=%v on opportunity, got %v", negRisk, opp.NegRisk)
+		}
+	}
+}
+
+// TestDetect_LimitPrices tests that limit prices are set only when enabled and keep the
+// set within MaxPriceSum: asks 0.45 + 0.48 leave 0.065 of headroom, 0.0325 per outcome.
+func TestDetect_LimitPrices(t *testing.T) {
+	market := &types.MarketSubscription{
+		MarketID:   "test-market",
+		MarketSlug: "test-slug",
+		Outcomes: []types.OutcomeToken{
+			{TokenID: "yes-token", Outcome: "YES"},
+			{TokenID: "no-token", Outcome: "NO"},
+		},
+	}
+	yesBook := &types.OrderbookSnapshot{TokenID: "yes-token", BestAskPrice: 0.45, BestAskSize: 100, LastUpdated: time.Now()}
+	noBook := &types.OrderbookSnapshot{TokenID: "no-token", BestAskPrice: 0.48, BestAskSize: 100, LastUpdated: time.Now()}
+
+	for _, enabled := range []bool{false, true} {
+		detector := &Detector{
+			config: Config{MaxPriceSum: 0.995, MinTradeSize: 1, MaxTradeSize: 100, TakerFee: 0.01, LimitPrices: enabled},
+			logger: zap.NewNop(),
+		}
+
+		opp, exists := detector.detect(market, yesBook, noBook)
+		if !exists {
+			t.Fatalf("expected opportunity (limit-prices=%v)", enabled)
+		}
+
+		want := []float64{0, 0}
+		if enabled {
+			want = []float64{0.48, 0.51}
+		}
+		limitSum := 0.0
+		for i, outcome := range opp.Outcomes {
+			if !floatEquals(outcome.LimitPrice, want[i], 1e-9) {
+				t.Errorf("limit-prices=%v outcome %d: expected limit %f, got %f", enabled, i, want[i], outcome.LimitPrice)
+			}
+			limitSum += outcome.LimitPrice
+		}
+		if limitSum > 0.995+1e-9 {
+			t.Errorf("expected limit sum <= 0.995, got %f", limitSum)
+		}
+	}
+}
+
+// TestDetect_MinTotalAskLiquidity tests that shallow books are rejected while deep
+// books with the same top of book pass the liquidity floor.
+func TestDetect_MinTotalAskLiquidity(t *testing.T) {
+	market := &types.MarketSubscription{
+		MarketID:   "test-market",
+		MarketSlug: "test-slug",
+		Outcomes: []types.OutcomeToken{
+			{TokenID: "yes-token", Outcome: "YES"},
+			{TokenID: "no-token", Outcome: "NO"},
+		},
+	}
+
+	tests := []struct {
+		name      string
+		yesDepth  []types.BookLevel
+		noDepth   []types.BookLevel
+		minUSD    float64
+		expectOpp bool
+	}{
+		{
+			// Top of book only: 0.45*100 + 0.48*100 = $93
+			name:      "shallow-books-rejected",
+			minUSD:    500,
+			expectOpp: false,
+		},
+		{
+			// 45 + 0.46*500 + 0.50*1000 = $775 and 48 + 0.49*500 = $293
+			name:      "deep-books-pass",
+			yesDepth:  []types.BookLevel{{Price: 0.45, Size: 100}, {Price: 0.46, Size: 500}, {Price: 0.50, Size: 1000}},
+			noDepth:   []types.BookLevel{{Price: 0.48, Size: 100}, {Price: 0.49, Size: 500}},
+			minUSD:    500,
+			expectOpp: true,
+		},
+		{
+			// Ladders present but still thin: 45 + 4.6 + 48 = $97.60
+			name:      "thin-ladders-rejected",
+			yesDepth:  []types.BookLevel{{Price: 0.45, Size: 100}, {Price: 0.46, Size: 10}},
+			noDepth:   []types.BookLevel{{Price: 0.48, Size: 100}},
+			minUSD:    100,
+			expectOpp: false,
+		},
+		{
+			name:      "gate-disabled",
+			minUSD:    0,
+			expectOpp: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			yesBook := &types.OrderbookSnapshot{TokenID: "yes-token", BestAskPrice: 0.45, BestAskSize: 100,
+				AskDepth: tt.yesDepth, LastUpdated: time.Now()}
+			noBook := &types.OrderbookSnapshot{TokenID: "no-token", BestAskPrice: 0.48, BestAskSize: 100,
+				AskDepth: tt.noDepth, LastUpdated: time.Now()}
+
+			detector := &Detector{
+				config: Config{MaxPriceSum: 0.995, MinTradeSize: 1, MaxTradeSize: 100, TakerFee: 0.01,
+					MinTotalAskLiquidityUSD: tt.minUSD},
+				logger: zap.NewNop(),
+			}
+
+			_, exists := detector.detect(market, yesBook, noBook)
+			if exists != tt.expectOpp {
+				t.Errorf("expected opportunity=%v, got %v", tt.expectOpp, exists)
+			}
+		})
+	}
+}
+
+// TestDetect_MissingAsk tests that an outcome without an ask skips the market, and that
+// only books with bids but no ask count as one-sided.
+func TestDetect_MissingAsk(t *testing.T) {
+	market := &types.MarketSubscription{
+		MarketID:   "test-market",
+		MarketSlug: "election-slug",
+		Outcomes: []types.OutcomeToken{
+			{TokenID: "yes-token", Outcome: "YES"},
+			{TokenID: "no-token", Outcome: "NO"},
+		},
+	}
+
+	tests := []struct {
+		name         string
+		noBook       *types.OrderbookSnapshot
+		wantOneSided float64
+	}{
+		{
+			name:         "bids-but-no-ask",
+			noBook:       &types.OrderbookSnapshot{TokenID: "no-token", BestBidPrice: 0.40, BestBidSize: 50},
+			wantOneSided: 1,
+		},
+		{
+			name:         "empty-book",
+			noBook:       &types.OrderbookSnapshot{TokenID: "no-token"},
+			wantOneSided: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			yesBook := &types.OrderbookSnapshot{TokenID: "yes-token", BestAskPrice: 0.45, BestAskSize: 100,
+				LastUpdated: time.Now()}
+			tt.noBook.LastUpdated = time.Now()
+
+			detector := &Detector{
+				config: Config{MaxPriceSum: 0.995, MinTradeSize: 1, MaxTradeSize: 100, TakerFee: 0.01},
+				logger: zap.NewNop(),
+			}
+
+			oneSidedBefore := promtestutil.ToFloat64(OneSidedBookTotal)
+			noAskBefore := promtestutil.ToFloat64(OpportunitiesRejectedTotal.WithLabelValues("no_ask"))
+
+			_, exists := detector.detect(market, yesBook, tt.noBook)
+			if exists {
+				t.Fatal("expected market without an ask to be skipped")
+			}
+
+			if got := promtestutil.ToFloat64(OneSidedBookTotal) - oneSidedBefore; got != tt.wantOneSided {
+				t.Errorf("expected one-sided count +%.0f, got +%.0f", tt.wantOneSided, got)
+			}
+			if got := promtestutil.ToFloat64(OpportunitiesRejectedTotal.WithLabelValues("no_ask")) - noAskBefore; got != 1 {
+				t.Errorf("expected one no_ask rejection, got %.0f", got)
+			}
+		})
+	}
+}
+
+// TestSendOpportunity_DropsOldestWhenFull tests that a stalled consumer leaves the
+// newest opportunities buffered, in order, and counts each eviction.
+func TestSendOpportunity_DropsOldestWhenFull(t *testing.T) {
+	detector := &Detector{
+		logger:          zap.NewNop(),
+		opportunityChan: make(chan *Opportunity, 3),
+	}
+	market := &types.MarketSubscription{MarketSlug: "stalled"}
+
+	droppedBefore := promtestutil.ToFloat64(OpportunitiesDroppedTotal)
+
+	for i := range 5 {
+		detector.sendOpportunity(market, &Opportunity{ID: fmt.Sprintf("opp-%d", i), MarketSlug: "stalled"})
+	}
+
+	if got := promtestutil.ToFloat64(OpportunitiesDroppedTotal) - droppedBefore; got != 2 {
+		t.Errorf("expected 2 dropped opportunities, got %.0f", got)
+	}
+
+	for _, want := range []string{"opp-2", "opp-3", "opp-4"} {
+		select {
+		case opp := <-detector.opportunityChan:
+			if opp.ID != want {
+				t.Errorf("expected %s, got %s", want, opp.ID)
+			}
+		default:
+			t.Fatalf("expected %s buffered, channel empty", want)
+		}
+	}
+}
+
+// TestSendOpportunity_ConcurrentSendersStalledConsumer tests that concurrent senders never
+// block on a full buffer and that every opportunity is either buffered or counted as dropped.
+func TestSendOpportunity_ConcurrentSendersStalledConsumer(t *testing.T) {
+	const (
+		bufferSize = 4
+		senders    = 8
+		perSender  = 50
+	)
+
+	detector := &Detector{
+		logger:          zap.NewNop(),
+		opportunityChan: make(chan *Opportunity, bufferSize),
+	}
+	market := &types.MarketSubscription{MarketSlug: "stalled"}
+
+	droppedBefore := promtestutil.ToFloat64(OpportunitiesDroppedTotal)
+
+	var wg sync.WaitGroup
+	for s := range senders {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range perSender {
+				detector.sendOpportunity(market, &Opportunity{ID: fmt.Sprintf("opp-%d-%d", s, i)})
+			}
+		}()
+	}
+
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("senders blocked on a full opportunity channel")
+	}
+
+	buffered := len(detector.opportunityChan)
+	if buffered != bufferSize {
+		t.Errorf("expected a full buffer of %d, got %d", bufferSize, buffered)
+	}
+
+	dropped := promtestutil.ToFloat64(OpportunitiesDroppedTotal) - droppedBefore
+	if int(dropped)+buffered != senders*perSender {
+		t.Errorf("expected %d opportunities buffered or dropped, got %d buffered + %.0f dropped",
+			senders*perSender, buffered, dropped)
+	}
+}
+
+// TestSendOpportunity_LogsTraceID tests that each detected opportunity gets its own trace
+// ID and that the detection log carries it.
+func TestSendOpportunity_LogsTraceID(t *testing.T) {
+	core, logs := observer.New(zapcore.InfoLevel)
+	detector := &Detector{
+		logger:          zap.New(core),
+		opportunityChan: make(chan *Opportunity, 2),
+	}
+	market := &types.MarketSubscription{MarketSlug: "traced"}
+
+	outcomes := []OpportunityOutcome{
+		{TokenID: "yes", Outcome: "YES", AskPrice: 0.48, AskSize: 100},
+		{TokenID: "no", Outcome: "NO", AskPrice: 0.50, AskSize: 100},
+	}
+	first := NewMultiOutcomeOpportunity("m1", "traced", "Q?", outcomes, 100, 0.995, 0, 0)
+	second := NewMultiOutcomeOpportunity("m1", "traced", "Q?", outcomes, 100, 0.995, 0, 0)
+	if first.TraceID == "" || first.TraceID == second.TraceID {
+		t.Fatalf("expected distinct trace IDs, got %q and %q", first.TraceID, second.TraceID)
+	}
+
+	detector.sendOpportunity(market, first)
+	detector.sendOpportunity(market, second)
+
+	detected := logs.FilterMessage("arbitrage-opportunity-detected").All()
+	if len(detected) != 2 {
+		t.Fatalf("expected 2 detection logs, got %d", len(detected))
+	}
+	for i, opp := range []*Opportunity{first, second} {
+		fields := detected[i].ContextMap()
+		if fields["trace-id"] != opp.TraceID || fields["opportunity-id"] != opp.ID {
+			t.Errorf("expected trace-id %s for %s, got %v", opp.TraceID, opp.ID, fields)
+		}
+	}
+}
+
+type fakeWarmup struct{ warm atomic.Bool }
+
+func (f *fakeWarmup) IsWarm() bool { return f.warm.Load() }
+
+// TestDetector_WarmupDefersDetection tests that updates arriving before warmup completes
+// are skipped, and detection resumes once it does.
+func TestDetector_WarmupDefersDetection(t *testing.T) {
+	// Seeding queues one update per token, all while the detector is still warming up
+	obManager, discoveryService, _ := setupSyntheticMarkets(t, 1, 0.96)
+	warmup := &fakeWarmup{}
+
+	detector := New(Config{
+		MaxPriceSum:  0.995,
+		MinTradeSize: 1.0,
+		MaxTradeSize: 10.0,
+		TakerFee:     0.01,
+		Warmup:       warmup,
+		Logger:       zap.NewNop(),
+	}, obManager, discoveryService, NewMockStorage(), nil)
+
+	skipped := DetectionSkippedTotal.WithLabelValues("warmup")
+	skippedBefore := promtestutil.ToFloat64(skipped)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	err := detector.Start(ctx)
+	if err != nil {
+		t.Fatalf("start detector: %v", err)
+	}
+
+	deadline := time.After(5 * time.Second)
+	for promtestutil.ToFloat64(skipped)-skippedBefore < 2 {
+		select {
+		case <-deadline:
+			t.Fatal("timed out waiting for updates to be skipped")
+		case <-time.After(5 * time.Millisecond):
+		}
+	}
+	if n := len(detector.OpportunityChan()); n != 0 {
+		t.Fatalf("expected no opportunities during warmup, got %d", n)
+	}
+
+	warmup.warm.Store(true)
+	err = obManager.ProcessMessage(syntheticBook("market-0", "market-0-yes", 0.50))
+	if err != nil {
+		t.Fatalf("update book: %v", err)
+	}
+
+	select {
+	case opp := <-detector.OpportunityChan():
+		if opp.MarketID != "market-0" {
+			t.Errorf("expected opportunity on market-0, got %s", opp.MarketID)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected an opportunity once warm")
+	}
+}
@@ -0,0 +1,168 @@
+package arbitrage
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// FeeSide is the liquidity role of a fill: taking resting orders or providing them.
+type FeeSide int
+
+const (
+	// FeeSideTaker fills against resting orders. Arbitrage legs are takers unless the
+	// executor prices them to rest on the book.
+	FeeSideTaker FeeSide = iota
+	// FeeSideMaker is filled while resting on the book.
+	FeeSideMaker
+)
+
+// String returns the side name used in logs.
+func (s FeeSide) String() string {
+	if s == FeeSideMaker {
+		return "maker"
+	}
+	return "taker"
+}
+
+// FeeModel computes the fee in USDC for filling size tokens at price.
+// Negative fees are rebates. The detector and executor implement their profit math on it.
+type FeeModel interface {
+	Fee(side FeeSide, price, size float64) float64
+}
+
+// FlatFeeModel charges a fixed rate of the fill's notional (price × size) per side.
+// A negative MakerRate pays a maker rebate.
+type FlatFeeModel struct {
+	TakerRate float64
+	MakerRate float64
+}
+
+// Fee implements FeeModel.
+func (m FlatFeeModel) Fee(side FeeSide, price, size float64) float64 {
+	if side == FeeSideMaker {
+		return price * size * m.MakerRate
+	}
+	return price * size * m.TakerRate
+}
+
+// FeeTier is one bracket of a TieredFeeModel.
+type FeeTier struct {
+	MinNotional float64 // Fill notional in USDC from which this tier applies
+	TakerRate   float64
+	MakerRate   float64 // Negative = rebate
+}
+
+// TieredFeeModel charges rates that depend on the fill's notional: the tier with the
+// highest MinNotional not above the notional applies. Models fee schedules where larger
+// fills or liquidity-provider accounts get lower rates or maker rebates.
+type TieredFeeModel struct {
+	tiers []FeeTier // Sorted by MinNotional ascending; the first starts at 0
+}
+
+// NewTieredFeeModel creates a tiered fee model. The tiers need not be sorted, but one
+// must start at a notional of 0 so every fill has a rate.
+func NewTieredFeeModel(tiers []FeeTier) (*TieredFeeModel, error) {
+	if len(tiers) == 0 {
+		return nil, errors.New("at least one fee tier is required")
+	}
+
+	sorted := make([]FeeTier, len(tiers))
+	copy(sorted, tiers)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].MinNotional < sorted[j].MinNotional })
+
+	if sorted[0].MinNotional != 0 {
+		return nil, fmt.Errorf("lowest fee tier must start at 0, got %f", sorted[0].MinNotional)
+	}
+
+	for i := 1; i < len(sorted); i++ {
+		if sorted[i].MinNotional == sorted[i-1].MinNotional {
+			return nil, fmt.Errorf("duplicate fee tier at notional %f", sorted[i].MinNotional)
+		}
+	}
+
+	return &TieredFeeModel{tiers: sorted}, nil
+}
+
+// Fee implements FeeModel.
+func (m *TieredFeeModel) Fee(side FeeSide, price, size float64) float64 {
+	notional := price * size
+	tier := m.tiers[0]
+	for _, t := range m.tiers[1:] {
+		if notional < t.MinNotional {
+			break
+		}
+		tier = t
+	}
+
+	if side == FeeSideMaker {
+		return notional * tier.MakerRate
+	}
+	return notional * tier.TakerRate
+}
+
+// ParseFeeTiers parses comma-separated "minNotional:takerRate:makerRate" tiers,
+// e.g. "0:0.01:0,1000:0.005:-0.001".
+func ParseFeeTiers(spec string) ([]FeeTier, error) {
+	var tiers []FeeTier
+	for _, tierSpec := range strings.Split(spec, ",") {
+		tierSpec = strings.TrimSpace(tierSpec)
+		if tierSpec == "" {
+			continue
+		}
+
+		fields := strings.Split(tierSpec, ":")
+		if len(fields) != 3 {
+			return nil, fmt.Errorf("tier %q: expected minNotional:takerRate:makerRate", tierSpec)
+		}
+
+		values := make([]float64, len(fields))
+		for i, field := range fields {
+			value, err := strconv.ParseFloat(strings.TrimSpace(field), 64)
+			if err != nil {
+				return nil, fmt.Errorf("tier %q: %w", tierSpec, err)
+			}
+			values[i] = value
+		}
+
+		if values[0] < 0 {
+			return nil, fmt.Errorf("tier %q: min notional must be non-negative", tierSpec)
+		}
+
+		tiers = append(tiers, FeeTier{MinNotional: values[0], TakerRate: values[1], MakerRate: values[2]})
+	}
+
+	return tiers, nil
+}
+
+// NewFeeModel builds the fee model named by kind: "flat" (takerRate and makerRate on
+// every fill) or "tiered" (notional brackets parsed from tiersSpec, see ParseFeeTiers).
+func NewFeeModel(kind string, takerRate, makerRate float64, tiersSpec string) (FeeModel, error) {
+	switch kind {
+	case "", "flat":
+		return FlatFeeModel{TakerRate: takerRate, MakerRate: makerRate}, nil
+	case "tiered":
+		tiers, err := ParseFeeTiers(tiersSpec)
+		if err != nil {
+			return nil, fmt.Errorf("parse fee tiers: %w", err)
+		}
+		model, err := NewTieredFeeModel(tiers)
+		if err != nil {
+			return nil, err
+		}
+		return model, nil
+	default:
+		return nil, fmt.Errorf("unknown fee model %q (want flat or tiered)", kind)
+	}
+}
+
+// totalTakerFees sums the taker fees for buying size tokens of every outcome at its ask.
+func totalTakerFees(fees FeeModel, outcomes []OpportunityOutcome, size float64) float64 {
+	total := 0.0
+	for _, outcome := range outcomes {
+		total += fees.Fee(FeeSideTaker, outcome.AskPrice, size)
+	}
+	return total
+}
@@ -0,0 +1,158 @@
+package arbitrage
+
+import "testing"
+
+func TestFlatFeeModel_Fee(t *testing.T) {
+	model := FlatFeeModel{TakerRate: 0.01, MakerRate: -0.002}
+
+	if fee := model.Fee(FeeSideTaker, 0.40, 100); !floatEquals(fee, 0.40, 1e-9) {
+		t.Errorf("expected taker fee 0.40, got %f", fee)
+	}
+	if fee := model.Fee(FeeSideMaker, 0.40, 100); !floatEquals(fee, -0.08, 1e-9) {
+		t.Errorf("expected maker rebate -0.08, got %f", fee)
+	}
+}
+
+func TestTieredFeeModel_Fee(t *testing.T) {
+	model, err := NewTieredFeeModel([]FeeTier{
+		{MinNotional: 1000, TakerRate: 0.005, MakerRate: -0.001},
+		{MinNotional: 0, TakerRate: 0.01, MakerRate: 0},
+		{MinNotional: 100, TakerRate: 0.008, MakerRate: 0},
+	})
+	if err != nil {
+		t.Fatalf("new tiered model: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		side    FeeSide
+		price   float64
+		size    float64
+		wantFee float64
+	}{
+		{name: "base_tier", side: FeeSideTaker, price: 0.50, size: 100, wantFee: 50 * 0.01},
+		{name: "tier_boundary_inclusive", side: FeeSideTaker, price: 0.50, size: 200, wantFee: 100 * 0.008},
+		{name: "top_tier_taker", side: FeeSideTaker, price: 0.50, size: 4000, wantFee: 2000 * 0.005},
+		{name: "top_tier_maker_rebate", side: FeeSideMaker, price: 0.50, size: 4000, wantFee: -2000 * 0.001},
+		{name: "base_tier_maker_free", side: FeeSideMaker, price: 0.50, size: 100, wantFee: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fee := model.Fee(tt.side, tt.price, tt.size)
+			if !floatEquals(fee, tt.wantFee, 1e-9) {
+				t.Errorf("expected fee %f, got %f", tt.wantFee, fee)
+			}
+		})
+	}
+}
+
+func TestNewTieredFeeModel_Invalid(t *testing.T) {
+	tests := []struct {
+		name  string
+		tiers []FeeTier
+	}{
+		{name: "empty"},
+		{name: "no_zero_tier", tiers: []FeeTier{{MinNotional: 10, TakerRate: 0.01}}},
+		{name: "duplicate_tier", tiers: []FeeTier{{MinNotional: 0}, {MinNotional: 0, TakerRate: 0.01}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewTieredFeeModel(tt.tiers)
+			if err == nil {
+				t.Error("expected error")
+			}
+		})
+	}
+}
+
+func TestNewFeeModel(t *testing.T) {
+	tests := []struct {
+		name    string
+		kind    string
+		tiers   string
+		wantErr bool
+	}{
+		{name: "default_flat", kind: ""},
+		{name: "flat", kind: "flat"},
+		{name: "tiered", kind: "tiered", tiers: "0:0.01:0, 1000:0.005:-0.001"},
+		{name: "tiered_without_tiers", kind: "tiered", wantErr: true},
+		{name: "tiered_malformed", kind: "tiered", tiers: "0:0.01", wantErr: true},
+		{name: "tiered_not_a_number", kind: "tiered", tiers: "0:one:0", wantErr: true},
+		{name: "tiered_negative_notional", kind: "tiered", tiers: "-1:0.01:0", wantErr: true},
+		{name: "unknown", kind: "percent", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			model, err := NewFeeModel(tt.kind, 0.01, 0, tt.tiers)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("expected error=%v, got %v", tt.wantErr, err)
+			}
+			if !tt.wantErr && model == nil {
+				t.Error("expected a fee model")
+			}
+		})
+	}
+}
+
+// TestNewMultiOutcomeOpportunityWithFees tests each fee model's contribution to net profit.
+// Outcomes ask 0.45 + 0.50 = 0.95 for 100 tokens each: cost 95, gross profit 5.
+func TestNewMultiOutcomeOpportunityWithFees(t *testing.T) {
+	outcomes := []OpportunityOutcome{
+		{TokenID: "yes", Outcome: "YES", AskPrice: 0.45, AskSize: 500},
+		{TokenID: "no", Outcome: "NO", AskPrice: 0.50, AskSize: 500},
+	}
+
+	tiered, err := NewTieredFeeModel([]FeeTier{
+		{MinNotional: 0, TakerRate: 0.01},
+		{MinNotional: 48, TakerRate: 0.004},
+	})
+	if err != nil {
+		t.Fatalf("new tiered model: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		fees     FeeModel
+		wantFees float64
+		wantNet  float64
+	}{
+		{name: "no_fees", fees: FlatFeeModel{}, wantFees: 0, wantNet: 5},
+		{name: "flat_taker", fees: FlatFeeModel{TakerRate: 0.01}, wantFees: 0.95, wantNet: 4.05},
+		{name: "maker_rate_ignored_for_takers", fees: FlatFeeModel{TakerRate: 0.01, MakerRate: -0.005}, wantFees: 0.95, wantNet: 4.05},
+		// YES notional 45 is in the base tier, NO notional 50 in the discounted tier
+		{name: "tiered", fees: tiered, wantFees: 45*0.01 + 50*0.004, wantNet: 5 - 0.65},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opp := NewMultiOutcomeOpportunityWithFees("m1", "slug", "question", outcomes, 100, 0.995, tt.fees, 0)
+			if opp == nil {
+				t.Fatal("expected opportunity")
+			}
+
+			if !floatEquals(opp.EstimatedProfit, 5, 1e-9) {
+				t.Errorf("expected gross profit 5, got %f", opp.EstimatedProfit)
+			}
+			if !floatEquals(opp.TotalFees, tt.wantFees, 1e-9) {
+				t.Errorf("expected fees %f, got %f", tt.wantFees, opp.TotalFees)
+			}
+			if !floatEquals(opp.NetProfit, tt.wantNet, 1e-9) {
+				t.Errorf("expected net profit %f, got %f", tt.wantNet, opp.NetProfit)
+			}
+
+			// The fee model also decides whether the min-profit floor is met
+			if NewMultiOutcomeOpportunityWithFees("m1", "slug", "question", outcomes, 100, 0.995, tt.fees, tt.wantNet+0.01) != nil {
+				t.Error("expected opportunity below min profit to be rejected")
+			}
+		})
+	}
+
+	// The flat-rate constructor matches the flat model
+	opp := NewMultiOutcomeOpportunity("m1", "slug", "question", outcomes, 100, 0.995, 0.01, 0)
+	if !floatEquals(opp.NetProfit, 4.05, 1e-9) {
+		t.Errorf("expected flat-rate net profit 4.05, got %f", opp.NetProfit)
+	}
+}
@@ -0,0 +1,147 @@
+package arbitrage
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/mselser95/polymarket-arb/pkg/types"
+	"go.uber.org/zap"
+)
+
+// linkedMarketIDPrefix marks the market ID of a synthetic linked-group market.
+const linkedMarketIDPrefix = "linked:"
+
+// LinkedLeg selects one outcome of one market for a linked group.
+type LinkedLeg struct {
+	MarketID string // Gamma market ID
+	Outcome  string // Outcome name within the market, matched case-insensitively
+}
+
+// LinkedMarketGroup combines outcomes of distinct markets that are mutually exclusive and
+// together exhaustive for one event, e.g. YES on "Will A win?" and YES on "Will B win?"
+// in a two-candidate race. Buying every leg forms a synthetic complete set paying $1, so
+// the group is evaluated like a single multi-outcome market.
+// The detector cannot verify the framing: a group that is not exhaustive is a directional
+// bet, not an arbitrage.
+type LinkedMarketGroup struct {
+	Name string
+	Legs []LinkedLeg
+}
+
+// ParseLinkedMarketGroups parses a semicolon-separated list of groups, each written as
+// "name=marketID:outcome,marketID:outcome[,...]". An empty spec yields no groups.
+func ParseLinkedMarketGroups(spec string) ([]LinkedMarketGroup, error) {
+	var groups []LinkedMarketGroup
+	names := make(map[string]bool)
+
+	for _, groupSpec := range strings.Split(spec, ";") {
+		groupSpec = strings.TrimSpace(groupSpec)
+		if groupSpec == "" {
+			continue
+		}
+
+		name, legsSpec, ok := strings.Cut(groupSpec, "=")
+		name = strings.TrimSpace(name)
+		if !ok || name == "" {
+			return nil, fmt.Errorf("group %q: expected name=marketID:outcome,...", groupSpec)
+		}
+		if names[name] {
+			return nil, fmt.Errorf("group %q: duplicate name", name)
+		}
+		names[name] = true
+
+		group := LinkedMarketGroup{Name: name}
+		marketIDs := make(map[string]bool)
+		for _, legSpec := range strings.Split(legsSpec, ",") {
+			marketID, outcome, ok := strings.Cut(legSpec, ":")
+			marketID = strings.TrimSpace(marketID)
+			outcome = strings.TrimSpace(outcome)
+			if !ok || marketID == "" || outcome == "" {
+				return nil, fmt.Errorf("group %q: leg %q: expected marketID:outcome", name, strings.TrimSpace(legSpec))
+			}
+			if marketIDs[marketID] {
+				return nil, fmt.Errorf("group %q: market %s appears in more than one leg", name, marketID)
+			}
+			marketIDs[marketID] = true
+
+			group.Legs = append(group.Legs, LinkedLeg{MarketID: marketID, Outcome: outcome})
+		}
+
+		if len(group.Legs) < 2 {
+			return nil, fmt.Errorf("group %q: needs at least 2 legs, got %d", name, len(group.Legs))
+		}
+
+		groups = append(groups, group)
+	}
+
+	return groups, nil
+}
+
+// indexLinkedGroups maps each market ID to the groups it is a leg of.
+func indexLinkedGroups(groups []LinkedMarketGroup) map[string][]*LinkedMarketGroup {
+	index := make(map[string][]*LinkedMarketGroup)
+	for i := range groups {
+		for _, leg := range groups[i].Legs {
+			index[leg.MarketID] = append(index[leg.MarketID], &groups[i])
+		}
+	}
+
+	return index
+}
+
+// linkedMarket builds the synthetic market for a group from its legs' subscriptions.
+// Returns false while any leg's market is not subscribed or lacks the configured outcome.
+func (d *Detector) linkedMarket(group *LinkedMarketGroup) (*types.MarketSubscription, bool) {
+	synthetic := &types.MarketSubscription{
+		MarketID:     linkedMarketIDPrefix + group.Name,
+		MarketSlug:   group.Name,
+		Outcomes:     make([]types.OutcomeToken, 0, len(group.Legs)),
+		SubscribedAt: time.Now(),
+		Active:       true,
+	}
+
+	questions := make([]string, 0, len(group.Legs))
+	for _, leg := range group.Legs {
+		market, exists := d.discoveryService.GetMarketByID(leg.MarketID)
+		if !exists {
+			d.logger.Debug("linked-market-not-subscribed",
+				zap.String("group", group.Name),
+				zap.String("market-id", leg.MarketID))
+			return nil, false
+		}
+
+		token, found := findOutcomeToken(market, leg.Outcome)
+		if !found {
+			d.logger.Debug("linked-outcome-not-found",
+				zap.String("group", group.Name),
+				zap.String("market-slug", market.MarketSlug),
+				zap.String("outcome", leg.Outcome))
+			return nil, false
+		}
+
+		synthetic.Outcomes = append(synthetic.Outcomes, types.OutcomeToken{
+			TokenID: token.TokenID,
+			Outcome: market.MarketSlug + "/" + token.Outcome,
+		})
+		questions = append(questions, market.Question)
+
+		// Any neg-risk leg settles through the neg-risk contracts
+		synthetic.NegRisk = synthetic.NegRisk || market.NegRisk
+	}
+
+	synthetic.Question = strings.Join(questions, " | ")
+	return synthetic, true
+}
+
+// findOutcomeToken returns the market's outcome whose name matches, ignoring case and
+// whitespace differences.
+func findOutcomeToken(market *types.MarketSubscription, outcome string) (types.OutcomeToken, bool) {
+	for _, token := range market.Outcomes {
+		if types.OutcomeEquals(token.Outcome, outcome) {
+			return token, true
+		}
+	}
+
+	return types.OutcomeToken{}, false
+}
@@ -0,0 +1,181 @@
+package arbitrage
+
+import (
+	"testing"
+
+	"github.com/mselser95/polymarket-arb/internal/discovery"
+	"github.com/mselser95/polymarket-arb/internal/orderbook"
+	"github.com/mselser95/polymarket-arb/pkg/types"
+	"go.uber.org/zap"
+)
+
+func TestParseLinkedMarketGroups(t *testing.T) {
+	tests := []struct {
+		name       string
+		spec       string
+		wantGroups int
+		wantErr    bool
+	}{
+		{name: "empty", spec: "", wantGroups: 0},
+		{name: "single_group", spec: "race=101:Yes,102:Yes", wantGroups: 1},
+		{name: "two_groups_with_spaces", spec: " race = 101:Yes, 102:Yes ; cup=201:Yes,202:Yes,203:Yes ;", wantGroups: 2},
+		{name: "missing_name", spec: "=101:Yes,102:Yes", wantErr: true},
+		{name: "missing_equals", spec: "101:Yes,102:Yes", wantErr: true},
+		{name: "single_leg", spec: "race=101:Yes", wantErr: true},
+		{name: "leg_without_outcome", spec: "race=101:Yes,102", wantErr: true},
+		{name: "repeated_market", spec: "race=101:Yes,101:No", wantErr: true},
+		{name: "duplicate_name", spec: "race=101:Yes,102:Yes;race=201:Yes,202:Yes", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			groups, err := ParseLinkedMarketGroups(tt.spec)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("expected error=%v, got %v", tt.wantErr, err)
+			}
+			if len(groups) != tt.wantGroups {
+				t.Errorf("expected %d groups, got %d", tt.wantGroups, len(groups))
+			}
+		})
+	}
+
+	groups, err := ParseLinkedMarketGroups(" race = 101:Yes, 102:Candidate B")
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	want := LinkedLeg{MarketID: "102", Outcome: "Candidate B"}
+	if groups[0].Name != "race" || len(groups[0].Legs) != 2 || groups[0].Legs[1] != want {
+		t.Errorf("expected race group with leg %+v, got %+v", want, groups[0])
+	}
+}
+
+// setupLinkedMarkets subscribes two binary markets framing the two sides of one race,
+// seeding YES asks from yesAsks. Neither market is arbitrageable on its own.
+func setupLinkedMarkets(t *testing.T, yesAsks [2]float64) (*orderbook.Manager, *discovery.Service) {
+	t.Helper()
+
+	obManager := orderbook.New(&orderbook.Config{Logger: zap.NewNop()})
+	discoveryService := discovery.New(&discovery.Config{Logger: zap.NewNop()})
+
+	markets := make([]types.Market, 2)
+	for i, marketID := range []string{"101", "102"} {
+		slug := "candidate-" + marketID + "-wins"
+		markets[i] = types.Market{
+			ID:       marketID,
+			Slug:     slug,
+			Question: "Will candidate " + marketID + " win?",
+			Tokens: []types.Token{
+				{TokenID: marketID + "-yes", Outcome: "Yes"},
+				{TokenID: marketID + "-no", Outcome: "No"},
+			},
+		}
+
+		// YES + NO = 1.01 within each market
+		for _, msg := range []*types.OrderbookMessage{
+			syntheticBook(marketID, marketID+"-yes", yesAsks[i]),
+			syntheticBook(marketID, marketID+"-no", 1.01-yesAsks[i]),
+		} {
+			err := obManager.ProcessMessage(msg)
+			if err != nil {
+				t.Fatalf("seed book: %v", err)
+			}
+		}
+	}
+
+	discoveryService.AddMarkets(markets)
+
+	return obManager, discoveryService
+}
+
+// TestDetector_LinkedGroup tests that YES legs of two linked markets are evaluated as one
+// synthetic complete set.
+func TestDetector_LinkedGroup(t *testing.T) {
+	linked := []LinkedMarketGroup{{
+		Name: "race",
+		Legs: []LinkedLeg{{MarketID: "101", Outcome: "YES"}, {MarketID: "102", Outcome: "yes"}},
+	}}
+
+	tests := []struct {
+		name      string
+		yesAsks   [2]float64
+		groups    []LinkedMarketGroup
+		expectOpp bool
+	}{
+		{name: "complete_set_below_threshold", yesAsks: [2]float64{0.45, 0.50}, groups: linked, expectOpp: true},
+		{name: "complete_set_above_threshold", yesAsks: [2]float64{0.50, 0.52}, groups: linked},
+		{name: "no_groups_configured", yesAsks: [2]float64{0.45, 0.50}},
+		{
+			name:    "leg_market_not_subscribed",
+			yesAsks: [2]float64{0.45, 0.50},
+			groups: []LinkedMarketGroup{{
+				Name: "race",
+				Legs: []LinkedLeg{{MarketID: "101", Outcome: "Yes"}, {MarketID: "999", Outcome: "Yes"}},
+			}},
+		},
+		{
+			name:    "leg_outcome_missing",
+			yesAsks: [2]float64{0.45, 0.50},
+			groups: []LinkedMarketGroup{{
+				Name: "race",
+				Legs: []LinkedLeg{{MarketID: "101", Outcome: "Yes"}, {MarketID: "102", Outcome: "Maybe"}},
+			}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			obManager, discoveryService := setupLinkedMarkets(t, tt.yesAsks)
+			storage := NewMockStorage()
+
+			detector := New(Config{
+				MaxPriceSum:  0.995,
+				MinTradeSize: 1.0,
+				MaxTradeSize: 10.0,
+				TakerFee:     0.01,
+				Logger:       zap.NewNop(),
+				LinkedGroups: tt.groups,
+			}, obManager, discoveryService, storage, nil)
+
+			opportunities := detector.Scan()
+			if !tt.expectOpp {
+				if len(opportunities) != 0 {
+					t.Fatalf("expected no opportunities, got %d", len(opportunities))
+				}
+				return
+			}
+
+			if len(opportunities) != 1 {
+				t.Fatalf("expected 1 linked opportunity, got %d", len(opportunities))
+			}
+
+			opp := opportunities[0]
+			if opp.LinkedGroup != "race" || opp.MarketID != linkedMarketIDPrefix+"race" {
+				t.Errorf("expected opportunity for linked group race, got group %q market %q", opp.LinkedGroup, opp.MarketID)
+			}
+			if len(opp.Outcomes) != 2 || opp.Outcomes[0].TokenID != "101-yes" || opp.Outcomes[1].TokenID != "102-yes" {
+				t.Fatalf("expected YES legs of both markets, got %+v", opp.Outcomes)
+			}
+			if !floatEquals(opp.TotalPriceSum, 0.95, 1e-9) {
+				t.Errorf("expected combined ask sum 0.95, got %f", opp.TotalPriceSum)
+			}
+			if opp.NetProfit <= 0 {
+				t.Errorf("expected positive net profit, got %f", opp.NetProfit)
+			}
+			if len(storage.GetOpportunities()) != 1 {
+				t.Errorf("expected linked opportunity to be stored, got %d", len(storage.GetOpportunities()))
+			}
+
+			// Event-driven path: an update to either leg re-evaluates the group
+			leg, _ := discoveryService.GetMarketByTokenID("102-yes")
+			detector.evaluateMarket(leg)
+			select {
+			case sent := <-detector.OpportunityChan():
+				if sent.LinkedGroup != "race" {
+					t.Errorf("expected linked opportunity on channel, got %q", sent.MarketSlug)
+				}
+			default:
+				t.Error("expected linked opportunity on channel after leg update")
+			}
+		})
+	}
+}
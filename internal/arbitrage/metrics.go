@@ -33,6 +33,15 @@ var (
 		Buckets: prometheus.DefBuckets,
 	})
 
+	// DetectionSkippedTotal tracks orderbook updates not scanned for arbitrage by reason.
+	DetectionSkippedTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "polymarket_arb_detection_skipped_total",
+			Help: "Total number of orderbook updates skipped by the detector",
+		},
+		[]string{"reason"},
+	)
+
 	// OpportunitiesRejectedTotal tracks rejected opportunities by reason.
 	OpportunitiesRejectedTotal = promauto.NewCounterVec(
 		prometheus.CounterOpts{
@@ -42,6 +51,33 @@ var (
 		[]string{"reason"},
 	)
 
+	// OpportunitiesDroppedTotal tracks opportunities dropped because OpportunityChan was full.
+	OpportunitiesDroppedTotal = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "polymarket_arb_opportunities_dropped_total",
+		Help: "Total number of opportunities dropped because the opportunity channel was full",
+	})
+
+	// OneSidedBookTotal tracks detections skipped because an outcome had bids but no ask.
+	OneSidedBookTotal = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "polymarket_arb_one_sided_book_total",
+		Help: "Total number of detections skipped because an outcome had bids but no ask",
+	})
+
+	// LinkedOpportunitiesDetectedTotal tracks opportunities spanning a linked market group.
+	LinkedOpportunitiesDetectedTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "polymarket_arb_linked_opportunities_detected_total",
+			Help: "Total number of arbitrage opportunities detected across linked market groups",
+		},
+		[]string{"group"},
+	)
+
+	// TailOutcomesSkippedTotal tracks neg-risk tail outcomes left out of opportunities.
+	TailOutcomesSkippedTotal = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "polymarket_arb_tail_outcomes_skipped_total",
+		Help: "Total number of neg-risk outcomes below ARB_TAIL_PRICE_FLOOR left out of detected opportunities",
+	})
+
 	// NetProfitBPS tracks net profit after fees in basis points.
 	NetProfitBPS = promauto.NewHistogram(prometheus.HistogramOpts{
 		Name:    "polymarket_arb_net_profit_bps",
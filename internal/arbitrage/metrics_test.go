@@ -22,6 +22,10 @@ func TestMetrics_Registration(t *testing.T) {
 		t.Error("DetectionDurationSeconds not registered")
 	}
 
+	if DetectionSkippedTotal == nil {
+		t.Error("DetectionSkippedTotal not registered")
+	}
+
 	if OpportunitiesRejectedTotal == nil {
 		t.Error("OpportunitiesRejectedTotal not registered")
 	}
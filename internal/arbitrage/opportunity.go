@@ -2,9 +2,11 @@ package arbitrage
 
 import (
 	"fmt"
+	"math"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/mselser95/polymarket-arb/pkg/tracing"
 )
 
 // OpportunityOutcome represents a single outcome in an arbitrage opportunity.
@@ -15,12 +17,23 @@ type OpportunityOutcome struct {
 	AskSize  float64 // Size available to BUY this outcome
 	TickSize float64 // Price tick size for this outcome (from market metadata)
 	MinSize  float64 // Minimum order size for this outcome (from market metadata)
+
+	BidPrice  float64 // Best bid price (0 if the bid side is empty)
+	BidSize   float64 // Size resting at the best bid
+	Imbalance float64 // Top-of-book imbalance: BidSize / (BidSize + AskSize)
+
+	TickSizeUnknown bool // TickSize/MinSize are defaults because metadata couldn't be resolved
+
+	// LimitPrice caps the order price regardless of aggression, even if the order then
+	// doesn't fill (0 = no cap)
+	LimitPrice float64
 }
 
 // Opportunity represents an arbitrage opportunity.
 // Supports both binary (2 outcomes) and multi-outcome (3+) markets.
 type Opportunity struct {
 	ID              string
+	TraceID         string  // Correlates the logs of this opportunity across the pipeline
 	MarketID        string
 	MarketSlug      string
 	MarketQuestion  string
@@ -35,6 +48,43 @@ type Opportunity struct {
 	NetProfit       float64 // Net profit after fees
 	NetProfitBPS    int     // Net profit in basis points
 	ConfigMaxPriceSum float64 // Configured threshold for detection
+	BookImbalance   float64 // Weakest outcome imbalance (0-1, higher = stronger bid support)
+	NegRisk         bool    // Market settles through the neg-risk exchange and adapter
+	LinkedGroup     string  // Linked market group the outcomes span ("" = single market)
+
+	// SkippedOutcomes are neg-risk tail outcomes below Config.TailPriceFloor that are not
+	// bought. Outcomes is then not a complete set: if a skipped outcome wins, every leg
+	// expires worthless, and the profit fields are expected values net of the tail's asks
+	// (see ChargeSkippedTail). Empty for a complete set.
+	SkippedOutcomes []OpportunityOutcome
+}
+
+// TopOfBookImbalance returns bidSize / (bidSize + askSize) in [0, 1].
+// 0.5 is balanced; above 0.5 the bid side dominates. Empty books return 0.
+func TopOfBookImbalance(bidSize, askSize float64) float64 {
+	total := bidSize + askSize
+	if total <= 0 {
+		return 0
+	}
+
+	return bidSize / total
+}
+
+// aggregateImbalance returns the minimum imbalance across outcomes.
+// Every leg must fill, so the outcome with the weakest bid support is the bottleneck.
+func aggregateImbalance(outcomes []OpportunityOutcome) float64 {
+	if len(outcomes) == 0 {
+		return 0
+	}
+
+	minImbalance := outcomes[0].Imbalance
+	for _, outcome := range outcomes[1:] {
+		if outcome.Imbalance < minImbalance {
+			minImbalance = outcome.Imbalance
+		}
+	}
+
+	return minImbalance
 }
 
 // NewOpportunity creates a new arbitrage opportunity with fee accounting.
@@ -75,12 +125,14 @@ func NewOpportunity(
 		maxTradeSize = noAskSize
 	}
 
-	return NewMultiOutcomeOpportunity(marketID, marketSlug, marketQuestion, outcomes, maxTradeSize, threshold, takerFee)
+	return NewMultiOutcomeOpportunity(marketID, marketSlug, marketQuestion, outcomes, maxTradeSize, threshold, takerFee, 0)
 }
 
 // NewMultiOutcomeOpportunity creates an arbitrage opportunity for N-outcome markets.
 // Works for both binary (2 outcomes) and multi-outcome (3+) markets.
 // The maxTradeSize parameter is pre-calculated by the detector and includes all constraints.
+// Returns nil when minProfitUSD > 0 and the net profit is below it, regardless of spread.
+// Fees are a flat takerFee rate; see NewMultiOutcomeOpportunityWithFees for other fee models.
 func NewMultiOutcomeOpportunity(
 	marketID string,
 	marketSlug string,
@@ -89,6 +141,23 @@ func NewMultiOutcomeOpportunity(
 	maxTradeSize float64, // Pre-calculated by detector (includes min/max/metadata constraints)
 	threshold float64,
 	takerFee float64,
+	minProfitUSD float64, // Absolute net profit floor in USD (0 = disabled)
+) *Opportunity {
+	return NewMultiOutcomeOpportunityWithFees(marketID, marketSlug, marketQuestion, outcomes,
+		maxTradeSize, threshold, FlatFeeModel{TakerRate: takerFee}, minProfitUSD)
+}
+
+// NewMultiOutcomeOpportunityWithFees is NewMultiOutcomeOpportunity with fees from a fee model.
+// Every outcome is bought as a taker.
+func NewMultiOutcomeOpportunityWithFees(
+	marketID string,
+	marketSlug string,
+	marketQuestion string,
+	outcomes []OpportunityOutcome,
+	maxTradeSize float64,
+	threshold float64,
+	fees FeeModel,
+	minProfitUSD float64,
 ) *Opportunity {
 	// Calculate sum of all outcome prices
 	priceSum := 0.0
@@ -102,11 +171,15 @@ func NewMultiOutcomeOpportunity(
 	maxSize := maxTradeSize
 
 	// Calculate fees (taker fee on all outcomes since we're taking liquidity)
-	totalCost := priceSum * maxSize
-	totalFees := totalCost * takerFee
+	totalFees := totalTakerFees(fees, outcomes, maxSize)
 	grossProfit := profitMargin * maxSize
 	netProfit := grossProfit - totalFees
 
+	// A wide spread on a tiny size can still be worth only cents
+	if minProfitUSD > 0 && netProfit < minProfitUSD {
+		return nil
+	}
+
 	// Calculate net profit BPS (avoid division by zero)
 	netProfitBPS := 0
 	if maxSize > 0 {
@@ -115,6 +188,7 @@ func NewMultiOutcomeOpportunity(
 
 	return &Opportunity{
 		ID:              uuid.New().String(),
+		TraceID:         tracing.NewID(),
 		MarketID:        marketID,
 		MarketSlug:      marketSlug,
 		MarketQuestion:  marketQuestion,
@@ -129,6 +203,31 @@ func NewMultiOutcomeOpportunity(
 		NetProfit:       netProfit,
 		NetProfitBPS:    netProfitBPS,
 		ConfigMaxPriceSum: threshold,
+		BookImbalance:   aggregateImbalance(outcomes),
+	}
+}
+
+// limitPriceEpsilon absorbs float error when flooring a limit price to its tick.
+const limitPriceEpsilon = 1e-9
+
+// SetLimitPrices caps each outcome's order price so the full set never costs more than
+// maxPriceSum per token. The headroom between maxPriceSum and the ask sum is split evenly
+// across outcomes and each limit is rounded down to its tick, never below the ask.
+func (o *Opportunity) SetLimitPrices(maxPriceSum float64) {
+	if len(o.Outcomes) == 0 {
+		return
+	}
+
+	share := math.Max(maxPriceSum-o.TotalPriceSum, 0) / float64(len(o.Outcomes))
+
+	for i := range o.Outcomes {
+		outcome := &o.Outcomes[i]
+
+		limit := outcome.AskPrice + share
+		if outcome.TickSize > 0 {
+			limit = math.Floor(limit/outcome.TickSize+limitPriceEpsilon) * outcome.TickSize
+		}
+		outcome.LimitPrice = math.Max(limit, outcome.AskPrice)
 	}
 }
 
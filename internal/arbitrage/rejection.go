@@ -0,0 +1,49 @@
+package arbitrage
+
+import (
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/mselser95/polymarket-arb/pkg/types"
+)
+
+// RejectReason is why the detector passed on a market. It is the reason label of
+// OpportunitiesRejectedTotal and the reason field of the opportunity-rejected log.
+type RejectReason string
+
+// Rejection reasons, in the order detectMultiOutcome checks them.
+const (
+	RejectTooManyOutcomes         RejectReason = "too_many_outcomes"          // More outcomes than Config.MaxOutcomes
+	RejectNoAsk                   RejectReason = "no_ask"                     // An outcome has no ask to buy
+	RejectInvalidPrice            RejectReason = "invalid_price"              // Negative best ask
+	RejectInvalidSize             RejectReason = "invalid_size"               // Best ask with no size
+	RejectCrossedBook             RejectReason = "crossed_book"               // Best bid at or above best ask
+	RejectPriceAboveThreshold     RejectReason = "price_above_threshold"      // Ask sum not below the threshold
+	RejectBelowMinSize            RejectReason = "below_min_size"             // Top-of-book size below Config.MinTradeSize
+	RejectBelowMinLiquidity       RejectReason = "below_min_liquidity"        // Ask liquidity below Config.MinTotalAskLiquidityUSD
+	RejectBelowMarketMin          RejectReason = "below_market_min"           // Token size below the market's minimum order size
+	RejectBelowMinProfitUSD       RejectReason = "below_min_profit_usd"       // Net profit below Config.MinProfitUSD
+	RejectNegativeProfitAfterFees RejectReason = "negative_profit_after_fees" // Fees consume the whole spread
+)
+
+// reject counts a market the detector passed on and logs it as opportunity-rejected with
+// its reason. The log is at debug level unless Config.LogRejections raises it to info.
+func (d *Detector) reject(market *types.MarketSubscription, reason RejectReason, fields ...zap.Field) {
+	OpportunitiesRejectedTotal.WithLabelValues(string(reason)).Inc()
+
+	level := zapcore.DebugLevel
+	if d.config.LogRejections {
+		level = zapcore.InfoLevel
+	}
+
+	// Skip building fields on the hot path when the level is disabled
+	entry := d.logger.Check(level, "opportunity-rejected")
+	if entry == nil {
+		return
+	}
+
+	entry.Write(append([]zap.Field{
+		zap.String("market-slug", market.MarketSlug),
+		zap.String("reason", string(reason)),
+	}, fields...)...)
+}
@@ -0,0 +1,156 @@
+package arbitrage
+
+import (
+	"testing"
+
+	promtestutil "github.com/prometheus/client_golang/prometheus/testutil"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+// TestDetectMultiOutcome_RejectReasons tests that each rejection cause is counted and
+// logged under its own reason.
+func TestDetectMultiOutcome_RejectReasons(t *testing.T) {
+	base := Config{MaxPriceSum: 0.995, MinTradeSize: 1, MaxTradeSize: 100, TakerFee: 0.01}
+
+	tests := []struct {
+		name     string
+		config   func(cfg *Config)
+		outcomes int
+		prices   []float64
+		sizes    []float64
+		bids     []float64
+		want     RejectReason
+	}{
+		{
+			name:     "too_many_outcomes",
+			config:   func(cfg *Config) { cfg.MaxOutcomes = 2 },
+			outcomes: 3,
+			prices:   []float64{0.30, 0.30, 0.30},
+			sizes:    []float64{100, 100, 100},
+			want:     RejectTooManyOutcomes,
+		},
+		{name: "no_ask", prices: []float64{0.45, 0}, sizes: []float64{100, 100}, want: RejectNoAsk},
+		{name: "invalid_price", prices: []float64{0.45, -0.10}, sizes: []float64{100, 100}, want: RejectInvalidPrice},
+		{name: "invalid_size", prices: []float64{0.45, 0.50}, sizes: []float64{100, 0}, want: RejectInvalidSize},
+		{
+			name:   "crossed_book",
+			prices: []float64{0.45, 0.50},
+			sizes:  []float64{100, 100},
+			bids:   []float64{0.40, 0.55},
+			want:   RejectCrossedBook,
+		},
+		{name: "price_above_threshold", prices: []float64{0.50, 0.50}, sizes: []float64{100, 100}, want: RejectPriceAboveThreshold},
+		{
+			name:   "below_min_size",
+			config: func(cfg *Config) { cfg.MinTradeSize = 10 },
+			prices: []float64{0.45, 0.50},
+			sizes:  []float64{5, 100},
+			want:   RejectBelowMinSize,
+		},
+		{
+			name:   "below_min_liquidity",
+			config: func(cfg *Config) { cfg.MinTotalAskLiquidityUSD = 1000 },
+			prices: []float64{0.45, 0.50},
+			sizes:  []float64{100, 100},
+			want:   RejectBelowMinLiquidity,
+		},
+		{
+			// $2 at 0.45 is 4.4 tokens, below the default 5-token market minimum
+			name:   "below_market_min",
+			prices: []float64{0.45, 0.50},
+			sizes:  []float64{2, 100},
+			want:   RejectBelowMarketMin,
+		},
+		{
+			name:   "below_min_profit_usd",
+			config: func(cfg *Config) { cfg.MinProfitUSD = 100 },
+			prices: []float64{0.45, 0.50},
+			sizes:  []float64{100, 100},
+			want:   RejectBelowMinProfitUSD,
+		},
+		{
+			// A 10% taker fee on $98 of asks exceeds the $2 spread
+			name:   "negative_profit_after_fees",
+			config: func(cfg *Config) { cfg.TakerFee = 0.10 },
+			prices: []float64{0.48, 0.50},
+			sizes:  []float64{100, 100},
+			want:   RejectNegativeProfitAfterFees,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := base
+			if tt.config != nil {
+				tt.config(&cfg)
+			}
+			outcomes := tt.outcomes
+			if outcomes == 0 {
+				outcomes = 2
+			}
+
+			market := createNOutcomeMarket("reject-market", "reject-slug", outcomes)
+			orderbooks := createOrderbooksFromPrices(market, tt.prices, tt.sizes)
+			for i, bid := range tt.bids {
+				orderbooks[i].BestBidPrice = bid
+			}
+
+			core, logs := observer.New(zapcore.DebugLevel)
+			detector := &Detector{config: cfg, logger: zap.New(core)}
+
+			before := promtestutil.ToFloat64(OpportunitiesRejectedTotal.WithLabelValues(string(tt.want)))
+
+			_, exists := detector.detectMultiOutcome(market, orderbooks)
+			if exists {
+				t.Fatal("expected market to be rejected")
+			}
+
+			if got := promtestutil.ToFloat64(OpportunitiesRejectedTotal.WithLabelValues(string(tt.want))) - before; got != 1 {
+				t.Errorf("expected one %s rejection, got %.0f", tt.want, got)
+			}
+
+			rejected := logs.FilterMessage("opportunity-rejected").All()
+			if len(rejected) != 1 {
+				t.Fatalf("expected one opportunity-rejected log, got %d", len(rejected))
+			}
+			fields := rejected[0].ContextMap()
+			if fields["reason"] != string(tt.want) || fields["market-slug"] != "reject-slug" {
+				t.Errorf("expected reason %s for reject-slug, got %v", tt.want, fields)
+			}
+		})
+	}
+}
+
+// TestReject_LogLevel tests that LogRejections raises rejection logs from debug to info.
+func TestReject_LogLevel(t *testing.T) {
+	tests := []struct {
+		name          string
+		logRejections bool
+		want          zapcore.Level
+	}{
+		{name: "default_debug", logRejections: false, want: zapcore.DebugLevel},
+		{name: "info", logRejections: true, want: zapcore.InfoLevel},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			core, logs := observer.New(zapcore.DebugLevel)
+			detector := &Detector{
+				config: Config{LogRejections: tt.logRejections},
+				logger: zap.New(core),
+			}
+
+			detector.reject(createNOutcomeMarket("m", "slug", 2), RejectNoAsk)
+
+			entries := logs.FilterMessage("opportunity-rejected").All()
+			if len(entries) != 1 {
+				t.Fatalf("expected one log entry, got %d", len(entries))
+			}
+			if entries[0].Level != tt.want {
+				t.Errorf("expected level %s, got %s", tt.want, entries[0].Level)
+			}
+		})
+	}
+}
@@ -0,0 +1,103 @@
+package arbitrage
+
+import (
+	"strings"
+
+	"github.com/mselser95/polymarket-arb/pkg/types"
+)
+
+// tailSubset drops outcomes asking less than floor from a neg-risk market, so a
+// many-outcome market isn't charged a taker fee on every near-zero leg.
+//
+// The kept outcomes are NOT a complete set: if a dropped outcome wins, every leg expires
+// worthless. The caller only trades the subset when the full set, tail included, is
+// below the threshold, so the tail's asks (its implied probability of winning) are
+// covered by the spread and the expected profit stays positive.
+//
+// Returns the market and books unchanged, and no skipped outcomes, unless the market is
+// a single neg-risk event and at least two outcomes remain. A linked group is refused even
+// when its legs are neg-risk: its outcomes come from separate markets, so no complement
+// conversion backs the set. The returned market is a copy.
+func tailSubset(
+	market *types.MarketSubscription,
+	orderbooks []*types.OrderbookSnapshot,
+	floor float64,
+) (*types.MarketSubscription, []*types.OrderbookSnapshot, []OpportunityOutcome) {
+	if floor <= 0 || !market.NegRisk || strings.HasPrefix(market.MarketID, linkedMarketIDPrefix) {
+		return market, orderbooks, nil
+	}
+
+	keptOutcomes := make([]types.OutcomeToken, 0, len(orderbooks))
+	keptBooks := make([]*types.OrderbookSnapshot, 0, len(orderbooks))
+	var skipped []OpportunityOutcome
+	for i, book := range orderbooks {
+		if book.BestAskPrice < floor {
+			skipped = append(skipped, OpportunityOutcome{
+				TokenID:  book.TokenID,
+				Outcome:  market.Outcomes[i].Outcome,
+				AskPrice: book.BestAskPrice,
+				AskSize:  book.BestAskSize,
+			})
+			continue
+		}
+
+		keptOutcomes = append(keptOutcomes, market.Outcomes[i])
+		keptBooks = append(keptBooks, book)
+	}
+
+	// Nothing below the floor, or too little left to trade as a set
+	if len(skipped) == 0 || len(keptBooks) < 2 {
+		return market, orderbooks, nil
+	}
+
+	subset := *market
+	subset.Outcomes = keptOutcomes
+	return &subset, keptBooks, skipped
+}
+
+// ChargeSkippedTail records the skipped tail outcomes and charges their asks against the
+// profit as the expected loss: without the tail the bought legs pay out only if one of them
+// wins, which the market prices at one less the tail's asks. Margin, gross and net profit
+// and their BPS are then expected values, so opportunities rank below complete sets with
+// the same spread.
+func (o *Opportunity) ChargeSkippedTail(skipped []OpportunityOutcome) {
+	o.SkippedOutcomes = skipped
+	tailAskSum := skippedAskSum(skipped)
+	if tailAskSum == 0 {
+		return
+	}
+
+	expectedLoss := tailAskSum * o.MaxTradeSize
+	o.ProfitMargin -= tailAskSum
+	o.ProfitBPS = int(o.ProfitMargin * 10000)
+	o.EstimatedProfit -= expectedLoss
+	o.NetProfit -= expectedLoss
+	if o.MaxTradeSize > 0 {
+		o.NetProfitBPS = int((o.NetProfit / o.MaxTradeSize) * 10000)
+	}
+}
+
+// MaxKeptPriceSum returns the most the bought outcomes may cost per token: the threshold
+// the opportunity was detected with, less the asks of its skipped tail outcomes, so the
+// full set including the tail stays below the threshold.
+func (o *Opportunity) MaxKeptPriceSum() float64 {
+	threshold := o.ConfigMaxPriceSum
+	if threshold <= 0 {
+		threshold = 1.0
+	}
+	return threshold - skippedAskSum(o.SkippedOutcomes)
+}
+
+// SkippedAskSum returns the summed ask of the skipped tail outcomes.
+func (o *Opportunity) SkippedAskSum() float64 {
+	return skippedAskSum(o.SkippedOutcomes)
+}
+
+// skippedAskSum returns the summed ask of the skipped outcomes.
+func skippedAskSum(skipped []OpportunityOutcome) float64 {
+	sum := 0.0
+	for _, outcome := range skipped {
+		sum += outcome.AskPrice
+	}
+	return sum
+}
@@ -0,0 +1,270 @@
+package arbitrage
+
+import (
+	"math"
+	"testing"
+
+	promtestutil "github.com/prometheus/client_golang/prometheus/testutil"
+
+	"go.uber.org/zap"
+)
+
+func TestTailSubset(t *testing.T) {
+	tests := []struct {
+		name        string
+		negRisk     bool
+		linked      bool
+		floor       float64
+		prices      []float64
+		wantKept    []string
+		wantSkipped []string
+	}{
+		{
+			name:     "disabled",
+			negRisk:  true,
+			prices:   []float64{0.6, 0.35, 0.005},
+			wantKept: []string{"Candidate A", "Candidate B", "Candidate C"},
+		},
+		{
+			// Only neg-risk markets trade without their tail
+			name:     "not_neg_risk",
+			floor:    0.01,
+			prices:   []float64{0.6, 0.35, 0.005},
+			wantKept: []string{"Candidate A", "Candidate B", "Candidate C"},
+		},
+		{
+			// Neg-risk legs of separate markets have no complement conversion between them
+			name:     "linked_group",
+			negRisk:  true,
+			linked:   true,
+			floor:    0.01,
+			prices:   []float64{0.6, 0.35, 0.005},
+			wantKept: []string{"Candidate A", "Candidate B", "Candidate C"},
+		},
+		{
+			name:     "nothing_below_floor",
+			negRisk:  true,
+			floor:    0.01,
+			prices:   []float64{0.6, 0.35, 0.02},
+			wantKept: []string{"Candidate A", "Candidate B", "Candidate C"},
+		},
+		{
+			name:        "skips_tail",
+			negRisk:     true,
+			floor:       0.01,
+			prices:      []float64{0.005, 0.6, 0.009, 0.35, 0.01},
+			wantKept:    []string{"Candidate B", "Candidate D", "Candidate E"},
+			wantSkipped: []string{"Candidate A", "Candidate C"},
+		},
+		{
+			name:     "one_outcome_left",
+			negRisk:  true,
+			floor:    0.1,
+			prices:   []float64{0.9, 0.05, 0.04},
+			wantKept: []string{"Candidate A", "Candidate B", "Candidate C"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			market := createNOutcomeMarket("market", "slug", len(tt.prices))
+			market.NegRisk = tt.negRisk
+			if tt.linked {
+				market.MarketID = linkedMarketIDPrefix + "group"
+			}
+			sizes := make([]float64, len(tt.prices))
+			for i := range sizes {
+				sizes[i] = 100
+			}
+			orderbooks := createOrderbooksFromPrices(market, tt.prices, sizes)
+
+			subset, books, skipped := tailSubset(market, orderbooks, tt.floor)
+
+			if len(subset.Outcomes) != len(tt.wantKept) || len(books) != len(tt.wantKept) {
+				t.Fatalf("expected %d kept outcomes, got %d outcomes and %d books",
+					len(tt.wantKept), len(subset.Outcomes), len(books))
+			}
+			for i, want := range tt.wantKept {
+				// Outcomes and books stay aligned
+				if subset.Outcomes[i].Outcome != want || books[i].Outcome != want {
+					t.Errorf("kept[%d]: expected %s, got outcome %s book %s",
+						i, want, subset.Outcomes[i].Outcome, books[i].Outcome)
+				}
+			}
+
+			if len(skipped) != len(tt.wantSkipped) {
+				t.Fatalf("expected %d skipped outcomes, got %d", len(tt.wantSkipped), len(skipped))
+			}
+			for i, want := range tt.wantSkipped {
+				if skipped[i].Outcome != want {
+					t.Errorf("skipped[%d]: expected %s, got %s", i, want, skipped[i].Outcome)
+				}
+			}
+
+			if len(market.Outcomes) != len(tt.prices) {
+				t.Errorf("expected the original market untouched, got %d outcomes", len(market.Outcomes))
+			}
+		})
+	}
+}
+
+func TestDetectMultiOutcome_TailPriceFloor(t *testing.T) {
+	tests := []struct {
+		name         string
+		negRisk      bool
+		prices       []float64
+		takerFee     float64
+		expectOpp    bool
+		expectReason string
+		wantOutcomes int
+	}{
+		{
+			// Full set 0.96: the two tail legs are dropped
+			name:         "neg_risk_tail_skipped",
+			negRisk:      true,
+			prices:       []float64{0.45, 0.30, 0.20, 0.005, 0.005},
+			takerFee:     0.01,
+			expectOpp:    true,
+			wantOutcomes: 3,
+		},
+		{
+			name:         "not_neg_risk_keeps_complete_set",
+			prices:       []float64{0.45, 0.30, 0.20, 0.005, 0.005},
+			takerFee:     0.01,
+			expectOpp:    true,
+			wantOutcomes: 5,
+		},
+		{
+			// The subset alone sums to 0.95, but the full set's 1.01 isn't an arbitrage
+			name:         "full_set_above_threshold",
+			negRisk:      true,
+			prices:       []float64{0.45, 0.30, 0.20, 0.03, 0.03},
+			takerFee:     0.01,
+			expectReason: "price_above_threshold",
+		},
+		{
+			// Without the tail the set nets 0.04%, less than the 1% tail it no longer covers
+			name:         "tail_loss_exceeds_profit",
+			negRisk:      true,
+			prices:       []float64{0.50, 0.48, 0.005, 0.005},
+			takerFee:     0.02,
+			expectReason: "negative_profit_after_fees",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			market := createNOutcomeMarket("tail-market", "tail-slug", len(tt.prices))
+			market.NegRisk = tt.negRisk
+			sizes := make([]float64, len(tt.prices))
+			for i := range sizes {
+				sizes[i] = 100
+			}
+			orderbooks := createOrderbooksFromPrices(market, tt.prices, sizes)
+
+			detector := &Detector{
+				config: Config{
+					MaxPriceSum:    0.995,
+					MinTradeSize:   1.0,
+					MaxTradeSize:   1000.0,
+					TakerFee:       tt.takerFee,
+					TailPriceFloor: 0.01,
+				},
+				logger: zap.NewNop(),
+			}
+
+			skippedBefore := promtestutil.ToFloat64(TailOutcomesSkippedTotal)
+			rejectedBefore := 0.0
+			if tt.expectReason != "" {
+				rejectedBefore = promtestutil.ToFloat64(OpportunitiesRejectedTotal.WithLabelValues(tt.expectReason))
+			}
+
+			opp, exists := detector.detectMultiOutcome(market, orderbooks)
+			if exists != tt.expectOpp {
+				t.Fatalf("expected opportunity=%v, got %v", tt.expectOpp, exists)
+			}
+
+			if !exists {
+				rejected := promtestutil.ToFloat64(OpportunitiesRejectedTotal.WithLabelValues(tt.expectReason)) - rejectedBefore
+				if rejected != 1 {
+					t.Errorf("expected one %s rejection, got %.0f", tt.expectReason, rejected)
+				}
+				return
+			}
+
+			if len(opp.Outcomes) != tt.wantOutcomes {
+				t.Errorf("expected %d outcomes bought, got %d", tt.wantOutcomes, len(opp.Outcomes))
+			}
+			wantSkipped := len(tt.prices) - tt.wantOutcomes
+			if len(opp.SkippedOutcomes) != wantSkipped {
+				t.Errorf("expected %d skipped outcomes, got %d", wantSkipped, len(opp.SkippedOutcomes))
+			}
+			if got := promtestutil.ToFloat64(TailOutcomesSkippedTotal) - skippedBefore; got != float64(wantSkipped) {
+				t.Errorf("expected %d skipped outcomes counted, got %.0f", wantSkipped, got)
+			}
+
+			// Profit is priced on the legs actually bought
+			boughtSum := 0.0
+			for _, price := range tt.prices[:tt.wantOutcomes] {
+				boughtSum += price
+			}
+			if math.Abs(opp.TotalPriceSum-boughtSum) > 1e-9 {
+				t.Errorf("expected price sum %.4f, got %.4f", boughtSum, opp.TotalPriceSum)
+			}
+
+			// ...but the skipped tail's asks are charged as expected loss, so ranking sees
+			// the full set's spread
+			fullSum := 0.0
+			for _, price := range tt.prices {
+				fullSum += price
+			}
+			wantGross := (1 - fullSum) * opp.MaxTradeSize
+			if math.Abs(opp.EstimatedProfit-wantGross) > 1e-6 {
+				t.Errorf("expected gross profit %.4f, got %.4f", wantGross, opp.EstimatedProfit)
+			}
+			if math.Abs(opp.NetProfit-(wantGross-opp.TotalFees)) > 1e-6 {
+				t.Errorf("expected net profit %.4f, got %.4f", wantGross-opp.TotalFees, opp.NetProfit)
+			}
+			if wantBPS := (1 - fullSum) * 10000; math.Abs(float64(opp.ProfitBPS)-wantBPS) > 1 {
+				t.Errorf("expected profit %.0f bps, got %d", wantBPS, opp.ProfitBPS)
+			}
+		})
+	}
+}
+
+// TestDetectMultiOutcome_TailLimitPrices tests that limit prices on a tail subset leave
+// room for the skipped outcomes' asks below the threshold.
+func TestDetectMultiOutcome_TailLimitPrices(t *testing.T) {
+	market := createNOutcomeMarket("tail-market", "tail-slug", 5)
+	market.NegRisk = true
+	prices := []float64{0.40, 0.30, 0.20, 0.005, 0.005}
+	orderbooks := createOrderbooksFromPrices(market, prices, []float64{100, 100, 100, 100, 100})
+
+	detector := &Detector{
+		config: Config{
+			MaxPriceSum:    0.995,
+			MinTradeSize:   1.0,
+			MaxTradeSize:   1000.0,
+			LimitPrices:    true,
+			TailPriceFloor: 0.01,
+		},
+		logger: zap.NewNop(),
+	}
+
+	opp, exists := detector.detectMultiOutcome(market, orderbooks)
+	if !exists {
+		t.Fatal("expected an opportunity")
+	}
+
+	if got := opp.MaxKeptPriceSum(); math.Abs(got-0.985) > 1e-9 {
+		t.Errorf("expected max kept price sum 0.985, got %.4f", got)
+	}
+
+	limitSum := 0.0
+	for _, outcome := range opp.Outcomes {
+		limitSum += outcome.LimitPrice
+	}
+	if limitSum > opp.MaxKeptPriceSum()+1e-9 {
+		t.Errorf("expected limits to sum to at most %.4f, got %.4f", opp.MaxKeptPriceSum(), limitSum)
+	}
+}
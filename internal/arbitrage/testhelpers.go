@@ -26,6 +26,7 @@ func CreateTestOpportunity(marketID string, marketSlug string) *Opportunity {
 
 	return &Opportunity{
 		ID:                "test-opp-" + marketID,
+		TraceID:           "test-trace-" + marketID,
 		MarketID:          marketID,
 		MarketSlug:        marketSlug,
 		MarketQuestion:    "Test market: " + marketSlug,
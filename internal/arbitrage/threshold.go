@@ -0,0 +1,40 @@
+package arbitrage
+
+import (
+	"fmt"
+	"math"
+
+	"go.uber.org/zap"
+)
+
+// MaxThreshold is the highest accepted max price sum. Values above 1.0 only make sense
+// for research, since they flag markets that are not real arbitrage.
+const MaxThreshold = 1.10
+
+// GetThreshold returns the current maximum price sum: the last value passed to
+// SetThreshold, or Config.MaxPriceSum if it was never called. Safe for concurrent use.
+func (d *Detector) GetThreshold() float64 {
+	bits := d.threshold.Load()
+	if bits == 0 {
+		return d.config.MaxPriceSum
+	}
+
+	return math.Float64frombits(bits)
+}
+
+// SetThreshold changes the maximum price sum used by subsequent evaluations without a
+// restart. The threshold must be in (0, MaxThreshold]. Safe for concurrent use.
+func (d *Detector) SetThreshold(threshold float64) error {
+	if math.IsNaN(threshold) || threshold <= 0 || threshold > MaxThreshold {
+		return fmt.Errorf("threshold must be between 0 and %.2f, got %f", MaxThreshold, threshold)
+	}
+
+	previous := d.GetThreshold()
+	d.threshold.Store(math.Float64bits(threshold))
+
+	d.logger.Info("detector-threshold-updated",
+		zap.Float64("previous-threshold", previous),
+		zap.Float64("threshold", threshold))
+
+	return nil
+}
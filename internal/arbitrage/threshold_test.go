@@ -0,0 +1,122 @@
+package arbitrage
+
+import (
+	"math"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/mselser95/polymarket-arb/pkg/types"
+	"go.uber.org/zap"
+)
+
+// TestSetThreshold_Bounds tests that thresholds outside (0, 1.10] are rejected and leave
+// the current value unchanged.
+func TestSetThreshold_Bounds(t *testing.T) {
+	tests := []struct {
+		name      string
+		threshold float64
+		wantErr   bool
+	}{
+		{name: "typical", threshold: 0.99},
+		{name: "research_upper_bound", threshold: MaxThreshold},
+		{name: "tiny_positive", threshold: 0.0001},
+		{name: "zero", threshold: 0, wantErr: true},
+		{name: "negative", threshold: -0.5, wantErr: true},
+		{name: "above_upper_bound", threshold: 1.1001, wantErr: true},
+		{name: "nan", threshold: math.NaN(), wantErr: true},
+		{name: "infinite", threshold: math.Inf(1), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := &Detector{config: Config{MaxPriceSum: 0.995}, logger: zap.NewNop()}
+
+			err := d.SetThreshold(tt.threshold)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("expected error=%v, got %v", tt.wantErr, err)
+			}
+
+			want := tt.threshold
+			if tt.wantErr {
+				want = 0.995
+			}
+			if got := d.GetThreshold(); got != want {
+				t.Errorf("expected threshold %f, got %f", want, got)
+			}
+		})
+	}
+}
+
+// TestSetThreshold_AppliesToDetection tests that a lowered threshold rejects an
+// opportunity the configured threshold would accept.
+func TestSetThreshold_AppliesToDetection(t *testing.T) {
+	d := &Detector{
+		config: Config{MaxPriceSum: 0.995, MinTradeSize: 1, MaxTradeSize: 100, TakerFee: 0.01},
+		logger: zap.NewNop(),
+	}
+
+	market := &types.MarketSubscription{
+		MarketID:   "test-market",
+		MarketSlug: "test-slug",
+		Outcomes: []types.OutcomeToken{
+			{TokenID: "yes-token", Outcome: "YES"},
+			{TokenID: "no-token", Outcome: "NO"},
+		},
+	}
+	yesBook := &types.OrderbookSnapshot{TokenID: "yes-token", BestAskPrice: 0.45, BestAskSize: 100, LastUpdated: time.Now()}
+	noBook := &types.OrderbookSnapshot{TokenID: "no-token", BestAskPrice: 0.48, BestAskSize: 100, LastUpdated: time.Now()}
+
+	opp, exists := d.detect(market, yesBook, noBook)
+	if !exists {
+		t.Fatal("expected opportunity at the configured threshold")
+	}
+	if opp.ConfigMaxPriceSum != 0.995 {
+		t.Errorf("expected opportunity to record threshold 0.995, got %f", opp.ConfigMaxPriceSum)
+	}
+
+	err := d.SetThreshold(0.90)
+	if err != nil {
+		t.Fatalf("set threshold: %v", err)
+	}
+
+	if _, exists = d.detect(market, yesBook, noBook); exists {
+		t.Error("expected no opportunity after lowering the threshold below the price sum")
+	}
+}
+
+// TestSetThreshold_Concurrent tests that concurrent sets and gets only ever observe a
+// value that was actually set. Run with -race.
+func TestSetThreshold_Concurrent(t *testing.T) {
+	d := &Detector{config: Config{MaxPriceSum: 0.995}, logger: zap.NewNop()}
+	valid := map[float64]bool{0.995: true, 0.97: true, 0.98: true, 0.99: true, 1.05: true}
+
+	var wg sync.WaitGroup
+	for _, threshold := range []float64{0.97, 0.98, 0.99, 1.05} {
+		wg.Add(1)
+		go func(threshold float64) {
+			defer wg.Done()
+			for range 1000 {
+				if err := d.SetThreshold(threshold); err != nil {
+					t.Errorf("set threshold: %v", err)
+					return
+				}
+			}
+		}(threshold)
+	}
+
+	for range 4 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range 1000 {
+				if got := d.GetThreshold(); !valid[got] {
+					t.Errorf("observed threshold %f that was never set", got)
+					return
+				}
+			}
+		}()
+	}
+
+	wg.Wait()
+}
@@ -0,0 +1,68 @@
+package arbitrage
+
+import (
+	"hash/fnv"
+	"sync"
+
+	"github.com/mselser95/polymarket-arb/pkg/types"
+)
+
+// workerQueueSize is the per-worker backlog before submit blocks the dispatcher.
+const workerQueueSize = 1024
+
+// marketWorkerPool evaluates markets on a fixed set of workers.
+// Markets are sharded by ID, so updates for one market are evaluated in arrival
+// order by a single worker while independent markets proceed in parallel.
+type marketWorkerPool struct {
+	queues   []chan *types.MarketSubscription
+	evaluate func(*types.MarketSubscription)
+	wg       sync.WaitGroup
+}
+
+// newMarketWorkerPool starts workers goroutines calling evaluate for each submitted market.
+func newMarketWorkerPool(workers int, queueSize int, evaluate func(*types.MarketSubscription)) *marketWorkerPool {
+	p := &marketWorkerPool{
+		queues:   make([]chan *types.MarketSubscription, workers),
+		evaluate: evaluate,
+	}
+
+	for i := range p.queues {
+		p.queues[i] = make(chan *types.MarketSubscription, queueSize)
+
+		p.wg.Add(1)
+		go p.work(p.queues[i])
+	}
+
+	return p
+}
+
+// work evaluates markets from one shard queue until it is closed.
+func (p *marketWorkerPool) work(queue <-chan *types.MarketSubscription) {
+	defer p.wg.Done()
+
+	for market := range queue {
+		p.evaluate(market)
+	}
+}
+
+// submit queues a market on its shard, blocking if that worker is backlogged.
+func (p *marketWorkerPool) submit(market *types.MarketSubscription) {
+	p.queues[p.shard(market.MarketID)] <- market
+}
+
+// shard maps a market ID to a worker index.
+func (p *marketWorkerPool) shard(marketID string) int {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(marketID))
+
+	return int(h.Sum32() % uint32(len(p.queues)))
+}
+
+// close stops accepting work and waits for queued markets to be evaluated.
+func (p *marketWorkerPool) close() {
+	for _, queue := range p.queues {
+		close(queue)
+	}
+
+	p.wg.Wait()
+}
@@ -0,0 +1,200 @@
+package arbitrage
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/mselser95/polymarket-arb/internal/discovery"
+	"github.com/mselser95/polymarket-arb/internal/orderbook"
+	"github.com/mselser95/polymarket-arb/pkg/types"
+	"go.uber.org/zap"
+)
+
+// syntheticBook builds a two-sided book message for one token.
+func syntheticBook(marketID, tokenID string, askPrice float64) *types.OrderbookMessage {
+	return &types.OrderbookMessage{
+		EventType: "book",
+		AssetID:   tokenID,
+		Market:    marketID,
+		Bids:      []types.PriceLevel{{Price: fmt.Sprintf("%.2f", askPrice-0.01), Size: "100"}},
+		Asks:      []types.PriceLevel{{Price: fmt.Sprintf("%.2f", askPrice), Size: "100"}},
+	}
+}
+
+// setupSyntheticMarkets subscribes n binary markets and seeds their books.
+// Ask prices sum to askSum for every market.
+func setupSyntheticMarkets(tb testing.TB, n int, askSum float64) (*orderbook.Manager, *discovery.Service, []*types.MarketSubscription) {
+	tb.Helper()
+
+	obManager := orderbook.New(&orderbook.Config{Logger: zap.NewNop()})
+	discoveryService := discovery.New(&discovery.Config{Logger: zap.NewNop()})
+
+	markets := make([]types.Market, n)
+	for i := range n {
+		marketID := fmt.Sprintf("market-%d", i)
+		markets[i] = types.Market{
+			ID:   marketID,
+			Slug: marketID,
+			Tokens: []types.Token{
+				{TokenID: marketID + "-yes", Outcome: "YES"},
+				{TokenID: marketID + "-no", Outcome: "NO"},
+			},
+		}
+
+		for _, msg := range []*types.OrderbookMessage{
+			syntheticBook(marketID, marketID+"-yes", 0.50),
+			syntheticBook(marketID, marketID+"-no", askSum-0.50),
+		} {
+			err := obManager.ProcessMessage(msg)
+			if err != nil {
+				tb.Fatalf("seed book: %v", err)
+			}
+		}
+	}
+
+	discoveryService.AddMarkets(markets)
+
+	return obManager, discoveryService, discoveryService.GetSubscribedMarkets()
+}
+
+// TestMarketWorkerPool_ShardOrdering tests that one market's submissions stay ordered on one worker.
+func TestMarketWorkerPool_ShardOrdering(t *testing.T) {
+	var mu sync.Mutex
+	seen := make(map[string][]int)
+
+	pool := newMarketWorkerPool(4, 16, func(market *types.MarketSubscription) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		seen[market.MarketID] = append(seen[market.MarketID], len(seen[market.MarketID]))
+		// Question carries the submission sequence number
+		if market.Question != fmt.Sprintf("%d", len(seen[market.MarketID])-1) {
+			t.Errorf("market %s evaluated out of order: got seq %s", market.MarketID, market.Question)
+		}
+	})
+
+	for seq := range 100 {
+		for m := range 10 {
+			pool.submit(&types.MarketSubscription{
+				MarketID: fmt.Sprintf("market-%d", m),
+				Question: fmt.Sprintf("%d", seq),
+			})
+		}
+	}
+
+	pool.close()
+
+	for m := range 10 {
+		if got := len(seen[fmt.Sprintf("market-%d", m)]); got != 100 {
+			t.Errorf("market-%d: expected 100 evaluations, got %d", m, got)
+		}
+	}
+}
+
+// TestDetector_ConcurrentDetection exercises the worker pool while books are written
+// from several goroutines. Run with -race.
+func TestDetector_ConcurrentDetection(t *testing.T) {
+	const marketCount = 50
+
+	// Seeded without arbitrage (sum = 1.02); writers then open a 0.96 spread on every market
+	obManager, discoveryService, _ := setupSyntheticMarkets(t, marketCount, 1.02)
+
+	detector := New(Config{
+		MaxPriceSum:  0.995,
+		MinTradeSize: 1.0,
+		MaxTradeSize: 10.0,
+		TakerFee:     0.01,
+		Concurrency:  8,
+		Logger:       zap.NewNop(),
+	}, obManager, discoveryService, NewMockStorage(), nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	err := detector.Start(ctx)
+	if err != nil {
+		t.Fatalf("start detector: %v", err)
+	}
+
+	found := make(map[string]bool)
+	var opportunities atomic.Int64
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for opp := range detector.OpportunityChan() {
+			opportunities.Add(1)
+			found[opp.MarketID] = true
+		}
+	}()
+
+	var writers sync.WaitGroup
+	for w := range 4 {
+		writers.Add(1)
+		go func(w int) {
+			defer writers.Done()
+			for i := w; i < marketCount; i += 4 {
+				marketID := fmt.Sprintf("market-%d", i)
+				_ = obManager.ProcessMessage(syntheticBook(marketID, marketID+"-no", 0.46))
+				_ = obManager.ProcessMessage(syntheticBook(marketID, marketID+"-yes", 0.50))
+			}
+		}(w)
+	}
+	writers.Wait()
+
+	deadline := time.After(5 * time.Second)
+	for len(obManager.UpdateChan()) > 0 {
+		select {
+		case <-deadline:
+			t.Fatal("timed out waiting for updates to drain")
+		case <-time.After(5 * time.Millisecond):
+		}
+	}
+
+	// Cancel drains the worker queues before closing the opportunity channel
+	cancel()
+	_ = detector.Close()
+	<-done
+
+	if len(found) != marketCount {
+		t.Errorf("expected opportunities for all %d markets, got %d", marketCount, len(found))
+	}
+
+	if opportunities.Load() < marketCount {
+		t.Errorf("expected at least %d opportunities, got %d", marketCount, opportunities.Load())
+	}
+}
+
+// benchmarkMarketCount is the synthetic market set size for serial vs concurrent benchmarks.
+const benchmarkMarketCount = 1000
+
+// BenchmarkEvaluateMarkets_Serial evaluates 1000 markets one after another.
+func BenchmarkEvaluateMarkets_Serial(b *testing.B) {
+	obManager, discoveryService, markets := setupSyntheticMarkets(b, benchmarkMarketCount, 1.02)
+	detector := New(Config{MaxPriceSum: 0.995, MinTradeSize: 1.0, MaxTradeSize: 10.0, TakerFee: 0.01, Logger: zap.NewNop()},
+		obManager, discoveryService, NewMockStorage(), nil)
+
+	b.ResetTimer()
+	for range b.N {
+		for _, market := range markets {
+			detector.evaluateMarket(market)
+		}
+	}
+}
+
+// BenchmarkEvaluateMarkets_Concurrent evaluates 1000 markets on an 8-worker pool.
+func BenchmarkEvaluateMarkets_Concurrent(b *testing.B) {
+	obManager, discoveryService, markets := setupSyntheticMarkets(b, benchmarkMarketCount, 1.02)
+	detector := New(Config{MaxPriceSum: 0.995, MinTradeSize: 1.0, MaxTradeSize: 10.0, TakerFee: 0.01, Logger: zap.NewNop()},
+		obManager, discoveryService, NewMockStorage(), nil)
+
+	b.ResetTimer()
+	for range b.N {
+		pool := newMarketWorkerPool(8, workerQueueSize, detector.evaluateMarket)
+		for _, market := range markets {
+			pool.submit(market)
+		}
+		pool.close()
+	}
+}
@@ -0,0 +1,319 @@
+// Package backtest replays recorded orderbook messages through the detector and
+// paper executor to evaluate a configuration offline.
+package backtest
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/mselser95/polymarket-arb/internal/arbitrage"
+	"github.com/mselser95/polymarket-arb/internal/discovery"
+	"github.com/mselser95/polymarket-arb/internal/execution"
+	"github.com/mselser95/polymarket-arb/internal/orderbook"
+	"github.com/mselser95/polymarket-arb/pkg/types"
+	"go.uber.org/zap"
+)
+
+// Runner replays orderbook messages through the arbitrage pipeline.
+type Runner struct {
+	messages []*types.OrderbookMessage
+	markets  []types.Market
+	detector arbitrage.Config
+	speed    float64
+	logger   *zap.Logger
+}
+
+// Config holds backtest configuration.
+type Config struct {
+	// Messages to replay, in order (see LoadMessages).
+	Messages []*types.OrderbookMessage
+
+	// Markets to subscribe. When empty, markets are inferred from the
+	// "market" field of the messages, with outcomes ordered by token ID.
+	Markets []types.Market
+
+	// Detector configuration (Logger is filled in from Config.Logger if unset).
+	Detector arbitrage.Config
+
+	// Speed is the replay rate relative to the recorded message timestamps:
+	// 1 replays in realtime, 10 at 10x, and 0 as fast as possible.
+	Speed float64
+
+	Logger *zap.Logger
+}
+
+// Report summarizes a backtest run.
+type Report struct {
+	MessagesReplayed int
+	MessagesRejected int // Messages the orderbook manager refused (empty or crossed books)
+	Opportunities    int
+	SimulatedProfit  float64 // Paper executor realized profit in USD
+	FillRateEstimate float64 // Mean fraction of each trade fillable from top-of-book depth
+	Duration         time.Duration
+}
+
+// New creates a new backtest runner.
+func New(cfg *Config) (*Runner, error) {
+	if cfg.Logger == nil {
+		return nil, fmt.Errorf("logger is required")
+	}
+
+	if cfg.Speed < 0 {
+		return nil, fmt.Errorf("speed must be >= 0, got %v", cfg.Speed)
+	}
+
+	detectorCfg := cfg.Detector
+	if detectorCfg.Logger == nil {
+		detectorCfg.Logger = cfg.Logger
+	}
+
+	markets := cfg.Markets
+	if len(markets) == 0 {
+		markets = inferMarkets(cfg.Messages)
+	}
+
+	return &Runner{
+		messages: cfg.Messages,
+		markets:  markets,
+		detector: detectorCfg,
+		speed:    cfg.Speed,
+		logger:   cfg.Logger,
+	}, nil
+}
+
+// Run replays all messages and returns the resulting report.
+// The pipeline is stepped synchronously (message -> orderbook -> detector), so
+// results are deterministic regardless of replay speed.
+func (r *Runner) Run(ctx context.Context) (*Report, error) {
+	start := time.Now()
+
+	obManager := orderbook.New(&orderbook.Config{
+		Logger: r.logger,
+	})
+
+	discoveryService := discovery.New(&discovery.Config{
+		Logger: r.logger,
+	})
+	subscribed := discoveryService.AddMarkets(r.markets)
+
+	r.logger.Info("backtest-starting",
+		zap.Int("messages", len(r.messages)),
+		zap.Int("markets", len(subscribed)),
+		zap.Float64("speed", r.speed))
+
+	// No metadata client: replays must not depend on the live CLOB API
+	detector := arbitrage.New(r.detector, obManager, discoveryService, &noopStorage{}, nil)
+
+	execCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	execChan := make(chan *arbitrage.Opportunity, 100)
+	executor := execution.New(&execution.Config{
+		Mode:               "paper",
+		Logger:             r.logger,
+		OpportunityChannel: execChan,
+		TakerFee:           r.detector.TakerFee,
+		FeeModel:           r.detector.FeeModel,
+	})
+
+	err := executor.Start(execCtx)
+	if err != nil {
+		return nil, fmt.Errorf("start executor: %w", err)
+	}
+
+	report := &Report{}
+	var fillRateSum float64
+	var prevTimestamp int64
+
+	for _, msg := range r.messages {
+		err = r.pace(ctx, prevTimestamp, msg.Timestamp)
+		if err != nil {
+			close(execChan)
+			_ = executor.Close()
+			return nil, err
+		}
+		if msg.Timestamp > 0 {
+			prevTimestamp = msg.Timestamp
+		}
+
+		report.MessagesReplayed++
+
+		err = obManager.ProcessMessage(msg)
+		if err != nil {
+			report.MessagesRejected++
+			r.logger.Debug("backtest-message-rejected",
+				zap.String("asset-id", msg.AssetID),
+				zap.Error(err))
+		}
+
+		r.drainUpdates(obManager, detector)
+
+		for _, opp := range drainOpportunities(detector) {
+			report.Opportunities++
+			fillRateSum += estimateFillRate(opp)
+
+			select {
+			case execChan <- opp:
+			case <-ctx.Done():
+				close(execChan)
+				_ = executor.Close()
+				return nil, ctx.Err()
+			}
+		}
+	}
+
+	// Closing the channel lets the executor finish queued opportunities before exiting
+	close(execChan)
+	err = executor.Close()
+	if err != nil {
+		return nil, fmt.Errorf("close executor: %w", err)
+	}
+
+	report.SimulatedProfit = executor.CumulativeProfit()
+	if report.Opportunities > 0 {
+		report.FillRateEstimate = fillRateSum / float64(report.Opportunities)
+	}
+	report.Duration = time.Since(start)
+
+	r.logger.Info("backtest-complete",
+		zap.Int("messages-replayed", report.MessagesReplayed),
+		zap.Int("messages-rejected", report.MessagesRejected),
+		zap.Int("opportunities", report.Opportunities),
+		zap.Float64("simulated-profit-usd", report.SimulatedProfit),
+		zap.Float64("fill-rate-estimate", report.FillRateEstimate),
+		zap.Duration("duration", report.Duration))
+
+	return report, nil
+}
+
+// pace sleeps for the recorded gap between two messages, scaled by speed.
+func (r *Runner) pace(ctx context.Context, prevTimestamp, timestamp int64) error {
+	if r.speed == 0 || prevTimestamp == 0 || timestamp <= prevTimestamp {
+		return nil
+	}
+
+	// Recorded timestamps are in milliseconds
+	gap := time.Duration(float64(timestamp-prevTimestamp) * float64(time.Millisecond) / r.speed)
+
+	timer := time.NewTimer(gap)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// drainUpdates runs detection for every update produced by the last message.
+func (r *Runner) drainUpdates(obManager *orderbook.Manager, detector *arbitrage.Detector) {
+	for {
+		select {
+		case update := <-obManager.UpdateChan():
+			detector.CheckUpdate(update)
+		default:
+			return
+		}
+	}
+}
+
+// drainOpportunities collects all opportunities emitted so far.
+func drainOpportunities(detector *arbitrage.Detector) []*arbitrage.Opportunity {
+	var opps []*arbitrage.Opportunity
+
+	for {
+		select {
+		case opp := <-detector.OpportunityChan():
+			opps = append(opps, opp)
+		default:
+			return opps
+		}
+	}
+}
+
+// estimateFillRate returns the fraction of the trade fillable at the best ask,
+// limited by the thinnest outcome. Deeper levels are ignored, so this is a
+// conservative estimate.
+func estimateFillRate(opp *arbitrage.Opportunity) float64 {
+	fillRate := 1.0
+
+	for _, outcome := range opp.Outcomes {
+		if outcome.AskPrice <= 0 {
+			return 0
+		}
+
+		tokensNeeded := opp.MaxTradeSize / outcome.AskPrice
+		if tokensNeeded <= 0 {
+			continue
+		}
+
+		fillRate = math.Min(fillRate, outcome.AskSize/tokensNeeded)
+	}
+
+	return fillRate
+}
+
+// inferMarkets groups asset IDs by their condition ID to build market definitions
+// when no market file is supplied.
+func inferMarkets(messages []*types.OrderbookMessage) []types.Market {
+	tokensByMarket := make(map[string]map[string]bool)
+
+	for _, msg := range messages {
+		if msg.Market == "" || msg.AssetID == "" {
+			continue
+		}
+
+		if tokensByMarket[msg.Market] == nil {
+			tokensByMarket[msg.Market] = make(map[string]bool)
+		}
+		tokensByMarket[msg.Market][msg.AssetID] = true
+	}
+
+	marketIDs := make([]string, 0, len(tokensByMarket))
+	for marketID := range tokensByMarket {
+		marketIDs = append(marketIDs, marketID)
+	}
+	sort.Strings(marketIDs)
+
+	markets := make([]types.Market, 0, len(marketIDs))
+	for _, marketID := range marketIDs {
+		tokenIDs := make([]string, 0, len(tokensByMarket[marketID]))
+		for tokenID := range tokensByMarket[marketID] {
+			tokenIDs = append(tokenIDs, tokenID)
+		}
+		sort.Strings(tokenIDs)
+
+		tokens := make([]types.Token, len(tokenIDs))
+		for i, tokenID := range tokenIDs {
+			tokens[i] = types.Token{
+				TokenID: tokenID,
+				Outcome: fmt.Sprintf("OUTCOME-%d", i+1),
+			}
+		}
+
+		markets = append(markets, types.Market{
+			ID:       marketID,
+			Slug:     marketID,
+			Question: marketID,
+			Active:   true,
+			Tokens:   tokens,
+		})
+	}
+
+	return markets
+}
+
+// noopStorage discards opportunities; the report is the backtest's output.
+type noopStorage struct{}
+
+func (s *noopStorage) StoreOpportunity(_ context.Context, _ *arbitrage.Opportunity) error {
+	return nil
+}
+
+func (s *noopStorage) Close() error {
+	return nil
+}
@@ -0,0 +1,185 @@
+package backtest
+
+import (
+	"context"
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mselser95/polymarket-arb/internal/arbitrage"
+	"go.uber.org/zap"
+)
+
+func testDetectorConfig() arbitrage.Config {
+	return arbitrage.Config{
+		MaxPriceSum:  0.995,
+		MinTradeSize: 1.0,
+		MaxTradeSize: 10.0,
+		TakerFee:     0.01,
+	}
+}
+
+// TestRunner_Fixture replays the fixture and checks the known opportunity count.
+// Fixture walk-through:
+//   - market-a books complete at 0.45 + 0.50 -> opportunity 1
+//   - a1 reprices to 0.55 -> no opportunity
+//   - market-b completes at 0.40 + 0.55 with thin books -> opportunity 2 (fill 0.4)
+//   - b2 crossed book -> rejected, market-b incomplete
+//   - array frame: last_trade_price (ignored) + a1 back to 0.45 -> opportunity 3
+func TestRunner_Fixture(t *testing.T) {
+	messages, err := LoadMessagesFile("testdata/replay.ndjson")
+	if err != nil {
+		t.Fatalf("failed to load fixture: %v", err)
+	}
+
+	if len(messages) != 8 {
+		t.Fatalf("expected 8 messages, got %d", len(messages))
+	}
+
+	runner, err := New(&Config{
+		Messages: messages,
+		Detector: testDetectorConfig(),
+		Logger:   zap.NewNop(),
+	})
+	if err != nil {
+		t.Fatalf("failed to create runner: %v", err)
+	}
+
+	report, err := runner.Run(context.Background())
+	if err != nil {
+		t.Fatalf("run failed: %v", err)
+	}
+
+	if report.MessagesReplayed != 8 {
+		t.Errorf("expected 8 messages replayed, got %d", report.MessagesReplayed)
+	}
+
+	if report.MessagesRejected != 1 {
+		t.Errorf("expected 1 message rejected, got %d", report.MessagesRejected)
+	}
+
+	if report.Opportunities != 3 {
+		t.Errorf("expected 3 opportunities, got %d", report.Opportunities)
+	}
+
+	// Each opportunity spends up to $10 a leg on a 5% margin less 1% taker fees: 20 tokens
+	// per leg on market-a (twice), 10/0.55 on market-b
+	wantProfit := 2*20*(0.05-0.95*0.01) + 10/0.55*(0.05-0.95*0.01)
+	if math.Abs(report.SimulatedProfit-wantProfit) > 1e-5 {
+		t.Errorf("expected simulated profit %f, got %f", wantProfit, report.SimulatedProfit)
+	}
+
+	// (1.0 + 0.4 + 1.0) / 3
+	if math.Abs(report.FillRateEstimate-0.8) > 1e-9 {
+		t.Errorf("expected fill rate estimate 0.8, got %f", report.FillRateEstimate)
+	}
+}
+
+// TestRunner_Realtime tests that replay speed scales recorded message gaps.
+func TestRunner_Realtime(t *testing.T) {
+	messages, err := LoadMessagesFile("testdata/replay.ndjson")
+	if err != nil {
+		t.Fatalf("failed to load fixture: %v", err)
+	}
+
+	// Fixture spans 700ms of recorded time; at 10x that's ~70ms
+	runner, err := New(&Config{
+		Messages: messages,
+		Detector: testDetectorConfig(),
+		Speed:    10,
+		Logger:   zap.NewNop(),
+	})
+	if err != nil {
+		t.Fatalf("failed to create runner: %v", err)
+	}
+
+	start := time.Now()
+	report, err := runner.Run(context.Background())
+	if err != nil {
+		t.Fatalf("run failed: %v", err)
+	}
+
+	elapsed := time.Since(start)
+	if elapsed < 70*time.Millisecond {
+		t.Errorf("expected replay to take at least 70ms, took %v", elapsed)
+	}
+
+	if report.Opportunities != 3 {
+		t.Errorf("expected 3 opportunities, got %d", report.Opportunities)
+	}
+}
+
+// TestRunner_ContextCanceled tests that a realtime replay stops on cancellation.
+func TestRunner_ContextCanceled(t *testing.T) {
+	messages, err := LoadMessagesFile("testdata/replay.ndjson")
+	if err != nil {
+		t.Fatalf("failed to load fixture: %v", err)
+	}
+
+	runner, err := New(&Config{
+		Messages: messages,
+		Detector: testDetectorConfig(),
+		Speed:    1,
+		Logger:   zap.NewNop(),
+	})
+	if err != nil {
+		t.Fatalf("failed to create runner: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	_, err = runner.Run(ctx)
+	if err == nil {
+		t.Fatal("expected error on canceled context, got nil")
+	}
+}
+
+// TestNew_InvalidSpeed tests that negative speeds are rejected.
+func TestNew_InvalidSpeed(t *testing.T) {
+	_, err := New(&Config{
+		Speed:  -1,
+		Logger: zap.NewNop(),
+	})
+	if err == nil {
+		t.Fatal("expected error for negative speed, got nil")
+	}
+}
+
+// TestLoadMessages_InvalidLine tests that malformed lines report their line number.
+func TestLoadMessages_InvalidLine(t *testing.T) {
+	input := `{"event_type":"book","asset_id":"a1","market":"m","timestamp":"1"}
+not-json
+`
+
+	_, err := LoadMessages(strings.NewReader(input))
+	if err == nil {
+		t.Fatal("expected error for malformed line, got nil")
+	}
+
+	if !strings.Contains(err.Error(), "line 2") {
+		t.Errorf("expected error to reference line 2, got %v", err)
+	}
+}
+
+// TestLoadMessages_RecordedEnvelope tests that websocket.Recorder lines are unwrapped.
+func TestLoadMessages_RecordedEnvelope(t *testing.T) {
+	input := `{"received_at":"2026-01-02T03:04:05Z","data":[{"event_type":"book","asset_id":"a1","market":"m","timestamp":"1"},{"event_type":"book","asset_id":"a2","market":"m","timestamp":"2"}]}
+{"received_at":"2026-01-02T03:04:06Z","data":"INVALID OPERATION"}
+{"received_at":"2026-01-02T03:04:07Z","data":{"event_type":"price_change","asset_id":"a1","market":"m","timestamp":"3"}}
+`
+
+	messages, err := LoadMessages(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(messages) != 3 {
+		t.Fatalf("expected 3 messages, got %d", len(messages))
+	}
+
+	if messages[2].EventType != "price_change" || messages[2].Timestamp != 3 {
+		t.Errorf("unexpected last message: %+v", messages[2])
+	}
+}
@@ -18,6 +18,7 @@ import (
 // Both wallet.Client and test mocks can implement this interface.
 type BalanceFetcher interface {
 	GetBalances(ctx context.Context, address common.Address) (*wallet.Balances, error)
+	GetERC20Balance(ctx context.Context, token common.Address, holder common.Address) (*big.Int, error)
 }
 
 // BalanceCircuitBreaker monitors wallet balance and controls trade execution.
@@ -25,6 +26,7 @@ type BalanceFetcher interface {
 // hysteresis to prevent rapid state changes.
 type BalanceCircuitBreaker struct {
 	enabled atomic.Bool // Atomic for lock-free reads
+	paused  atomic.Bool // Manual operator pause, independent of the balance state
 
 	// Configuration
 	checkInterval   time.Duration
@@ -34,16 +36,31 @@ type BalanceCircuitBreaker struct {
 	tradeMultiplier float64 // Multiplier for avg trade size
 	minAbsolute     float64 // Absolute minimum balance
 	hysteresisRatio float64 // Re-enable at ratio * disable threshold
+	collateral      []common.Address
+	thresholdMode   string  // ThresholdModeSMA or ThresholdModeEMA
+	emaAlpha        float64 // EMA smoothing factor (weight of the newest trade)
 
 	// Protected by mutex
 	mu               sync.RWMutex
 	lastBalance      float64   // Last checked balance (USDC)
 	lastCheck        time.Time // When we last checked
 	recentTrades     []float64 // Rolling window of trade sizes
+	avgTradeSize     float64   // Effective average trade size (SMA or EMA)
 	disableThreshold float64   // Current disable threshold
 	enableThreshold  float64   // Current enable threshold
 }
 
+const (
+	// ThresholdModeSMA averages the rolling window of recent trades equally.
+	ThresholdModeSMA = "sma"
+
+	// ThresholdModeEMA weights recent trades more, so a single outlier decays quickly.
+	ThresholdModeEMA = "ema"
+
+	// DefaultEMAAlpha is the EMA smoothing factor used when none is configured.
+	DefaultEMAAlpha = 0.2
+)
+
 // Config holds circuit breaker configuration.
 type Config struct {
 	CheckInterval   time.Duration
@@ -53,16 +70,27 @@ type Config struct {
 	WalletClient    BalanceFetcher
 	Address         common.Address
 	Logger          *zap.Logger
+
+	// ThresholdMode selects how the average trade size is computed: "sma" (default) or "ema".
+	ThresholdMode string
+	// EMAAlpha is the EMA smoothing factor in (0, 1] (default: 0.2). Only used in "ema" mode.
+	EMAAlpha float64
+
+	// CollateralTokens are the ERC20 tokens (6 decimals) whose balances are summed
+	// as available collateral, e.g. USDC.e and native USDC. Empty = USDC.e only.
+	CollateralTokens []common.Address
 }
 
 // Status holds current circuit breaker status for debugging.
 type Status struct {
-	Enabled          bool
+	Enabled          bool // Balance state only; see Paused
+	Paused           bool
 	LastBalance      float64
 	LastCheck        time.Time
 	DisableThreshold float64
 	EnableThreshold  float64
-	AvgTradeSize     float64
+	AvgTradeSize     float64 // Effective average used for thresholds (SMA or EMA)
+	ThresholdMode    string
 	RecentTradeCount int
 }
 
@@ -90,6 +118,22 @@ func New(cfg *Config) (breaker *BalanceCircuitBreaker, err error) {
 		return nil, fmt.Errorf("hysteresis ratio must be >= 1.0")
 	}
 
+	thresholdMode := cfg.ThresholdMode
+	if thresholdMode == "" {
+		thresholdMode = ThresholdModeSMA
+	}
+	if thresholdMode != ThresholdModeSMA && thresholdMode != ThresholdModeEMA {
+		return nil, fmt.Errorf("threshold mode must be %q or %q, got %q", ThresholdModeSMA, ThresholdModeEMA, cfg.ThresholdMode)
+	}
+
+	emaAlpha := cfg.EMAAlpha
+	if emaAlpha == 0 {
+		emaAlpha = DefaultEMAAlpha
+	}
+	if emaAlpha < 0 || emaAlpha > 1 {
+		return nil, fmt.Errorf("EMA alpha must be in (0, 1], got %f", cfg.EMAAlpha)
+	}
+
 	breaker = &BalanceCircuitBreaker{
 		checkInterval:    cfg.CheckInterval,
 		walletClient:     cfg.WalletClient,
@@ -98,6 +142,9 @@ func New(cfg *Config) (breaker *BalanceCircuitBreaker, err error) {
 		tradeMultiplier:  cfg.TradeMultiplier,
 		minAbsolute:      cfg.MinAbsolute,
 		hysteresisRatio:  cfg.HysteresisRatio,
+		collateral:       cfg.CollateralTokens,
+		thresholdMode:    thresholdMode,
+		emaAlpha:         emaAlpha,
 		recentTrades:     make([]float64, 0, 20),
 		disableThreshold: cfg.MinAbsolute, // Start with minimum
 		enableThreshold:  cfg.MinAbsolute * cfg.HysteresisRatio,
@@ -107,7 +154,7 @@ func New(cfg *Config) (breaker *BalanceCircuitBreaker, err error) {
 	breaker.enabled.Store(true)
 
 	// Initialize metrics
-	CircuitBreakerEnabled.Set(1)
+	CircuitBreakerStateGauge.Set(1)
 	CircuitBreakerDisableThreshold.Set(breaker.disableThreshold)
 	CircuitBreakerEnableThreshold.Set(breaker.enableThreshold)
 	CircuitBreakerAvgTradeSize.Set(0)
@@ -115,10 +162,36 @@ func New(cfg *Config) (breaker *BalanceCircuitBreaker, err error) {
 	return breaker, nil
 }
 
-// IsEnabled returns true if trades should be executed.
-// This is lock-free and safe to call from hot paths.
+// IsEnabled returns true if trades should be executed: the balance is healthy and
+// execution has not been paused manually. This is lock-free and safe to call from hot paths.
 func (b *BalanceCircuitBreaker) IsEnabled() (enabled bool) {
-	return b.enabled.Load()
+	return b.enabled.Load() && !b.paused.Load()
+}
+
+// IsPaused reports whether execution has been paused manually, regardless of balance.
+// This is lock-free and safe to call from hot paths.
+func (b *BalanceCircuitBreaker) IsPaused() bool {
+	return b.paused.Load()
+}
+
+// Pause stops trade execution until Resume is called, regardless of balance.
+func (b *BalanceCircuitBreaker) Pause() {
+	if b.paused.Swap(true) {
+		return
+	}
+
+	CircuitBreakerPaused.Set(1)
+	b.logger.Warn("circuit-breaker-paused")
+}
+
+// Resume lifts a manual pause. Execution still requires a healthy balance.
+func (b *BalanceCircuitBreaker) Resume() {
+	if !b.paused.Swap(false) {
+		return
+	}
+
+	CircuitBreakerPaused.Set(0)
+	b.logger.Info("circuit-breaker-resumed", zap.Bool("balance-enabled", b.enabled.Load()))
 }
 
 // RecordTrade adds a trade to the rolling window and recalculates thresholds.
@@ -140,11 +213,22 @@ func (b *BalanceCircuitBreaker) RecordTrade(tradeSize float64) {
 	}
 
 	// Calculate average
-	sum := 0.0
-	for _, size := range b.recentTrades {
-		sum += size
+	var avgTradeSize float64
+	if b.thresholdMode == ThresholdModeEMA {
+		if len(b.recentTrades) == 1 {
+			// Seed the EMA with the first trade
+			avgTradeSize = tradeSize
+		} else {
+			avgTradeSize = b.emaAlpha*tradeSize + (1-b.emaAlpha)*b.avgTradeSize
+		}
+	} else {
+		sum := 0.0
+		for _, size := range b.recentTrades {
+			sum += size
+		}
+		avgTradeSize = sum / float64(len(b.recentTrades))
 	}
-	avgTradeSize := sum / float64(len(b.recentTrades))
+	b.avgTradeSize = avgTradeSize
 
 	// Calculate thresholds
 	b.disableThreshold = math.Max(avgTradeSize*b.tradeMultiplier, b.minAbsolute)
@@ -156,6 +240,7 @@ func (b *BalanceCircuitBreaker) RecordTrade(tradeSize float64) {
 	CircuitBreakerEnableThreshold.Set(b.enableThreshold)
 
 	b.logger.Debug("thresholds-updated",
+		zap.String("threshold_mode", b.thresholdMode),
 		zap.Float64("avg_trade_size", avgTradeSize),
 		zap.Int("trade_count", len(b.recentTrades)),
 		zap.Float64("disable_threshold", b.disableThreshold),
@@ -170,18 +255,18 @@ func (b *BalanceCircuitBreaker) CheckBalance(ctx context.Context) (err error) {
 		CircuitBreakerCheckDuration.Observe(duration)
 	}()
 
-	// Fetch balances
-	balances, err := b.walletClient.GetBalances(ctx, b.address)
+	// Fetch collateral balance
+	collateral, err := b.fetchCollateralBalance(ctx)
 	if err != nil {
 		b.logger.Error("failed-to-check-balance",
 			zap.Error(err),
 			zap.String("address", b.address.Hex()))
-		return fmt.Errorf("get balances: %w", err)
+		return err
 	}
 
 	// Convert USDC balance to float (6 decimals)
 	usdcFloat := new(big.Float).Quo(
-		new(big.Float).SetInt(balances.USDC),
+		new(big.Float).SetInt(collateral),
 		big.NewFloat(1e6))
 	balance, _ := usdcFloat.Float64()
 
@@ -208,8 +293,9 @@ func (b *BalanceCircuitBreaker) CheckBalance(ctx context.Context) (err error) {
 
 	if shouldDisable {
 		b.enabled.Store(false)
-		CircuitBreakerEnabled.Set(0)
+		CircuitBreakerStateGauge.Set(0)
 		CircuitBreakerStateChanges.Inc()
+		CircuitBreakerTransitionsTotal.WithLabelValues("disable").Inc()
 
 		b.logger.Warn("circuit-breaker-disabled",
 			zap.Float64("balance", balance),
@@ -217,8 +303,9 @@ func (b *BalanceCircuitBreaker) CheckBalance(ctx context.Context) (err error) {
 			zap.Float64("enable_threshold", enableThreshold))
 	} else if shouldEnable {
 		b.enabled.Store(true)
-		CircuitBreakerEnabled.Set(1)
+		CircuitBreakerStateGauge.Set(1)
 		CircuitBreakerStateChanges.Inc()
+		CircuitBreakerTransitionsTotal.WithLabelValues("enable").Inc()
 
 		b.logger.Info("circuit-breaker-enabled",
 			zap.Float64("balance", balance),
@@ -236,6 +323,29 @@ func (b *BalanceCircuitBreaker) CheckBalance(ctx context.Context) (err error) {
 	return nil
 }
 
+// fetchCollateralBalance returns the total collateral balance in 6-decimal units.
+// Without configured collateral tokens it uses the USDC balance from GetBalances.
+func (b *BalanceCircuitBreaker) fetchCollateralBalance(ctx context.Context) (total *big.Int, err error) {
+	if len(b.collateral) == 0 {
+		balances, err := b.walletClient.GetBalances(ctx, b.address)
+		if err != nil {
+			return nil, fmt.Errorf("get balances: %w", err)
+		}
+		return balances.USDC, nil
+	}
+
+	total = big.NewInt(0)
+	for _, token := range b.collateral {
+		balance, err := b.walletClient.GetERC20Balance(ctx, token, b.address)
+		if err != nil {
+			return nil, fmt.Errorf("get collateral balance %s: %w", token.Hex(), err)
+		}
+		total.Add(total, balance)
+	}
+
+	return total, nil
+}
+
 // Start begins the background monitoring loop that periodically checks balance.
 // This runs until the context is cancelled.
 func (b *BalanceCircuitBreaker) Start(ctx context.Context) {
@@ -243,7 +353,8 @@ func (b *BalanceCircuitBreaker) Start(ctx context.Context) {
 		zap.Duration("check_interval", b.checkInterval),
 		zap.Float64("trade_multiplier", b.tradeMultiplier),
 		zap.Float64("min_absolute", b.minAbsolute),
-		zap.Float64("hysteresis_ratio", b.hysteresisRatio))
+		zap.Float64("hysteresis_ratio", b.hysteresisRatio),
+		zap.String("threshold_mode", b.thresholdMode))
 
 	// Check balance immediately on startup
 	if err := b.CheckBalance(ctx); err != nil {
@@ -278,22 +389,15 @@ func (b *BalanceCircuitBreaker) GetStatus() (status Status) {
 	b.mu.RLock()
 	defer b.mu.RUnlock()
 
-	sum := 0.0
-	for _, size := range b.recentTrades {
-		sum += size
-	}
-	avgTradeSize := 0.0
-	if len(b.recentTrades) > 0 {
-		avgTradeSize = sum / float64(len(b.recentTrades))
-	}
-
 	status = Status{
 		Enabled:          b.enabled.Load(),
+		Paused:           b.paused.Load(),
 		LastBalance:      b.lastBalance,
 		LastCheck:        b.lastCheck,
 		DisableThreshold: b.disableThreshold,
 		EnableThreshold:  b.enableThreshold,
-		AvgTradeSize:     avgTradeSize,
+		AvgTradeSize:     b.avgTradeSize,
+		ThresholdMode:    b.thresholdMode,
 		RecentTradeCount: len(b.recentTrades),
 	}
 
@@ -241,6 +241,102 @@ func TestCalculateThresholds_RollingWindowOverflow(t *testing.T) {
 	}
 }
 
+// TestCalculateThresholds_SMAvsEMASpike tests EMA recovers from a trade-size spike faster than SMA
+func TestCalculateThresholds_SMAvsEMASpike(t *testing.T) {
+	t.Parallel()
+
+	newBreaker := func(mode string) *BalanceCircuitBreaker {
+		breaker, err := New(&Config{
+			CheckInterval:   5 * time.Minute,
+			TradeMultiplier: 3.0,
+			MinAbsolute:     5.0,
+			HysteresisRatio: 1.5,
+			WalletClient:    testutil.NewMockWalletClient(),
+			Address:         common.HexToAddress("0x1234567890abcdef1234567890abcdef12345678"),
+			Logger:          zaptest.NewLogger(t),
+			ThresholdMode:   mode,
+			EMAAlpha:        0.5,
+		})
+		if err != nil {
+			t.Fatalf("failed to create %s breaker: %v", mode, err)
+		}
+		return breaker
+	}
+
+	sma := newBreaker(ThresholdModeSMA)
+	ema := newBreaker(ThresholdModeEMA)
+
+	// Steady state of $10 trades, one $1000 spike, then $10 trades again
+	trades := []float64{10, 10, 10, 10, 1000}
+	for range 5 {
+		trades = append(trades, 10)
+	}
+
+	for _, trade := range trades {
+		sma.RecordTrade(trade)
+		ema.RecordTrade(trade)
+	}
+
+	smaStatus := sma.GetStatus()
+	emaStatus := ema.GetStatus()
+
+	if smaStatus.ThresholdMode != ThresholdModeSMA || emaStatus.ThresholdMode != ThresholdModeEMA {
+		t.Errorf("unexpected modes: sma=%q ema=%q", smaStatus.ThresholdMode, emaStatus.ThresholdMode)
+	}
+
+	// SMA: (9*10 + 1000) / 10 = 109, spike still dominates
+	if !floatEquals(smaStatus.AvgTradeSize, 109.0, 0.01) {
+		t.Errorf("expected SMA avg 109.0, got %f", smaStatus.AvgTradeSize)
+	}
+
+	// EMA (alpha 0.5): spike to 505, then halves toward 10 each trade: 10 + 495/32 = 25.47
+	if !floatEquals(emaStatus.AvgTradeSize, 25.46875, 0.01) {
+		t.Errorf("expected EMA avg 25.47, got %f", emaStatus.AvgTradeSize)
+	}
+
+	if emaStatus.DisableThreshold >= smaStatus.DisableThreshold {
+		t.Errorf("expected EMA disable threshold (%f) below SMA (%f) after spike decays",
+			emaStatus.DisableThreshold, smaStatus.DisableThreshold)
+	}
+
+	if !floatEquals(emaStatus.DisableThreshold, emaStatus.AvgTradeSize*3.0, 0.01) {
+		t.Errorf("expected EMA disable threshold %f, got %f", emaStatus.AvgTradeSize*3.0, emaStatus.DisableThreshold)
+	}
+}
+
+// TestNew_InvalidThresholdMode tests threshold mode and EMA alpha validation
+func TestNew_InvalidThresholdMode(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		mode  string
+		alpha float64
+	}{
+		{name: "unknown-mode", mode: "wma"},
+		{name: "alpha-above-one", mode: ThresholdModeEMA, alpha: 1.5},
+		{name: "negative-alpha", mode: ThresholdModeEMA, alpha: -0.1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := New(&Config{
+				CheckInterval:   5 * time.Minute,
+				TradeMultiplier: 3.0,
+				MinAbsolute:     5.0,
+				HysteresisRatio: 1.5,
+				WalletClient:    testutil.NewMockWalletClient(),
+				Logger:          zaptest.NewLogger(t),
+				ThresholdMode:   tt.mode,
+				EMAAlpha:        tt.alpha,
+			})
+			if err == nil {
+				t.Error("expected error, got nil")
+			}
+		})
+	}
+}
+
 // TestCalculateThresholds_MinAbsoluteFloor tests that threshold never goes below min absolute
 func TestCalculateThresholds_MinAbsoluteFloor(t *testing.T) {
 	t.Parallel()
@@ -8,6 +8,7 @@ import (
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/mselser95/polymarket-arb/internal/testutil"
+	"github.com/mselser95/polymarket-arb/pkg/wallet"
 	"go.uber.org/zap/zaptest"
 )
 
@@ -212,6 +213,45 @@ func TestIsEnabled(t *testing.T) {
 	}
 }
 
+// TestPauseResume tests that a manual pause blocks execution independently of the
+// balance state and that resuming does not override a low balance.
+func TestPauseResume(t *testing.T) {
+	t.Parallel()
+
+	breaker, err := New(&Config{
+		CheckInterval:   5 * time.Minute,
+		TradeMultiplier: 3.0,
+		MinAbsolute:     5.0,
+		HysteresisRatio: 1.5,
+		WalletClient:    testutil.NewMockWalletClient(),
+		Logger:          zaptest.NewLogger(t),
+	})
+	if err != nil {
+		t.Fatalf("failed to create breaker: %v", err)
+	}
+
+	breaker.Pause()
+	if breaker.IsEnabled() {
+		t.Error("expected breaker to be disabled while paused")
+	}
+	if status := breaker.GetStatus(); !status.Paused || !status.Enabled {
+		t.Errorf("expected paused with healthy balance, got %+v", status)
+	}
+
+	breaker.Resume()
+	if !breaker.IsEnabled() {
+		t.Error("expected breaker to be enabled after resume")
+	}
+
+	// Resume does not re-enable a breaker tripped by balance
+	breaker.enabled.Store(false)
+	breaker.Pause()
+	breaker.Resume()
+	if breaker.IsEnabled() {
+		t.Error("expected breaker to stay disabled by balance after resume")
+	}
+}
+
 // Test RecordTrade and threshold calculation
 func TestRecordTrade(t *testing.T) {
 	t.Parallel()
@@ -516,6 +556,103 @@ func TestCheckBalance_WalletError(t *testing.T) {
 	}
 }
 
+// Test CheckBalance with a non-default collateral token
+func TestCheckBalance_CustomCollateralToken(t *testing.T) {
+	t.Parallel()
+
+	logger := zaptest.NewLogger(t)
+	mockWallet := testutil.NewMockWalletClient()
+	address := common.HexToAddress("0x1234567890abcdef1234567890abcdef12345678")
+	nativeUSDC := common.HexToAddress(wallet.PolygonNativeUSDC)
+
+	// Default USDC.e balance is large but must be ignored
+	mockWallet.SetUSDCBalance(testutil.NewUSDCBigInt(1000.0))
+	mockWallet.SetERC20Balance(nativeUSDC, testutil.NewUSDCBigInt(2.0))
+
+	breaker, err := New(&Config{
+		CheckInterval:    5 * time.Minute,
+		TradeMultiplier:  3.0,
+		MinAbsolute:      5.0,
+		HysteresisRatio:  1.5,
+		WalletClient:     mockWallet,
+		Address:          address,
+		Logger:           logger,
+		CollateralTokens: []common.Address{nativeUSDC},
+	})
+	if err != nil {
+		t.Fatalf("failed to create breaker: %v", err)
+	}
+
+	err = breaker.CheckBalance(context.Background())
+	if err != nil {
+		t.Fatalf("CheckBalance failed: %v", err)
+	}
+
+	status := breaker.GetStatus()
+	if status.LastBalance != 2.0 {
+		t.Errorf("expected balance 2.0 from native USDC, got %f", status.LastBalance)
+	}
+
+	if breaker.IsEnabled() {
+		t.Error("expected breaker to be disabled (2.0 < 5.0)")
+	}
+}
+
+// Test CheckBalance sums multiple collateral tokens
+func TestCheckBalance_MultipleCollateralTokens(t *testing.T) {
+	t.Parallel()
+
+	logger := zaptest.NewLogger(t)
+	mockWallet := testutil.NewMockWalletClient()
+	address := common.HexToAddress("0x1234567890abcdef1234567890abcdef12345678")
+	bridgedUSDC := common.HexToAddress(wallet.PolygonUSDCe)
+	nativeUSDC := common.HexToAddress(wallet.PolygonNativeUSDC)
+
+	// Neither balance alone clears the 5.0 floor, together they do
+	mockWallet.SetERC20Balance(bridgedUSDC, testutil.NewUSDCBigInt(3.0))
+	mockWallet.SetERC20Balance(nativeUSDC, testutil.NewUSDCBigInt(4.5))
+
+	breaker, err := New(&Config{
+		CheckInterval:    5 * time.Minute,
+		TradeMultiplier:  3.0,
+		MinAbsolute:      5.0,
+		HysteresisRatio:  1.5,
+		WalletClient:     mockWallet,
+		Address:          address,
+		Logger:           logger,
+		CollateralTokens: []common.Address{bridgedUSDC, nativeUSDC},
+	})
+	if err != nil {
+		t.Fatalf("failed to create breaker: %v", err)
+	}
+
+	err = breaker.CheckBalance(context.Background())
+	if err != nil {
+		t.Fatalf("CheckBalance failed: %v", err)
+	}
+
+	status := breaker.GetStatus()
+	if status.LastBalance != 7.5 {
+		t.Errorf("expected summed balance 7.5, got %f", status.LastBalance)
+	}
+
+	if !breaker.IsEnabled() {
+		t.Error("expected breaker to remain enabled (7.5 >= 5.0)")
+	}
+
+	// A failing token lookup surfaces as an error and leaves state unchanged
+	mockWallet.SetGetERC20BalanceError(errors.New("RPC connection failed"))
+
+	err = breaker.CheckBalance(context.Background())
+	if err == nil {
+		t.Error("expected error from CheckBalance, got nil")
+	}
+
+	if !breaker.IsEnabled() {
+		t.Error("expected breaker to remain enabled after error")
+	}
+}
+
 // Test Start and monitorLoop
 func TestStart_MonitorLoop(t *testing.T) {
 	t.Parallel()
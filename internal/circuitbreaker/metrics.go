@@ -6,12 +6,18 @@ import (
 )
 
 var (
-	// CircuitBreakerEnabled indicates whether the circuit breaker allows trade execution.
-	CircuitBreakerEnabled = promauto.NewGauge(prometheus.GaugeOpts{
+	// CircuitBreakerStateGauge indicates whether the circuit breaker allows trade execution.
+	CircuitBreakerStateGauge = promauto.NewGauge(prometheus.GaugeOpts{
 		Name: "polymarket_circuit_breaker_enabled",
 		Help: "Whether circuit breaker allows trade execution (1=enabled, 0=disabled)",
 	})
 
+	// CircuitBreakerPaused indicates whether execution has been paused manually.
+	CircuitBreakerPaused = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "polymarket_circuit_breaker_paused",
+		Help: "Whether trade execution is manually paused (1=paused, 0=not paused)",
+	})
+
 	// CircuitBreakerBalance tracks the last checked USDC balance.
 	CircuitBreakerBalance = promauto.NewGauge(prometheus.GaugeOpts{
 		Name: "polymarket_circuit_breaker_balance_usdc",
@@ -42,6 +48,15 @@ var (
 		Help: "Total number of times circuit breaker changed state (enabled/disabled)",
 	})
 
+	// CircuitBreakerTransitionsTotal tracks circuit breaker state transitions by direction.
+	CircuitBreakerTransitionsTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "polymarket_circuit_breaker_transitions_total",
+			Help: "Total number of circuit breaker state transitions",
+		},
+		[]string{"direction"}, // enable, disable
+	)
+
 	// CircuitBreakerCheckDuration tracks the time taken to check balance.
 	CircuitBreakerCheckDuration = promauto.NewHistogram(prometheus.HistogramOpts{
 		Name:    "polymarket_circuit_breaker_check_duration_seconds",
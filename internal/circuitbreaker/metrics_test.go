@@ -1,13 +1,20 @@
 package circuitbreaker
 
 import (
+	"context"
 	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/mselser95/polymarket-arb/internal/testutil"
+	promtest "github.com/prometheus/client_golang/prometheus/testutil"
+	"go.uber.org/zap/zaptest"
 )
 
 // TestMetrics_Registration tests all metrics are initialized
 func TestMetrics_Registration(t *testing.T) {
-	if CircuitBreakerEnabled == nil {
-		t.Error("CircuitBreakerEnabled not registered")
+	if CircuitBreakerStateGauge == nil {
+		t.Error("CircuitBreakerStateGauge not registered")
 	}
 
 	if CircuitBreakerBalance == nil {
@@ -30,6 +37,10 @@ func TestMetrics_Registration(t *testing.T) {
 		t.Error("CircuitBreakerStateChanges not registered")
 	}
 
+	if CircuitBreakerTransitionsTotal == nil {
+		t.Error("CircuitBreakerTransitionsTotal not registered")
+	}
+
 	if CircuitBreakerCheckDuration == nil {
 		t.Error("CircuitBreakerCheckDuration not registered")
 	}
@@ -37,7 +48,7 @@ func TestMetrics_Registration(t *testing.T) {
 
 // TestMetrics_GaugeSet tests gauge can be set
 func TestMetrics_GaugeSet(t *testing.T) {
-	CircuitBreakerEnabled.Set(1.0)
+	CircuitBreakerStateGauge.Set(1.0)
 	CircuitBreakerBalance.Set(100.0)
 	CircuitBreakerDisableThreshold.Set(30.0)
 	CircuitBreakerEnableThreshold.Set(45.0)
@@ -47,6 +58,8 @@ func TestMetrics_GaugeSet(t *testing.T) {
 // TestMetrics_CounterIncrement tests counter can be incremented
 func TestMetrics_CounterIncrement(t *testing.T) {
 	CircuitBreakerStateChanges.Inc()
+	CircuitBreakerTransitionsTotal.WithLabelValues("enable").Inc()
+	CircuitBreakerTransitionsTotal.WithLabelValues("disable").Inc()
 }
 
 // TestMetrics_HistogramObserve tests histogram can observe values
@@ -57,11 +70,78 @@ func TestMetrics_HistogramObserve(t *testing.T) {
 // TestMetrics_StateTransitions tests state transitions
 func TestMetrics_StateTransitions(t *testing.T) {
 	// Enabled state
-	CircuitBreakerEnabled.Set(1.0)
+	CircuitBreakerStateGauge.Set(1.0)
 
 	// Disabled state
-	CircuitBreakerEnabled.Set(0.0)
+	CircuitBreakerStateGauge.Set(0.0)
 
 	// Track state change
 	CircuitBreakerStateChanges.Inc()
 }
+
+// TestMetrics_TripBreaker tests the state gauge and transition counter follow CheckBalance transitions
+func TestMetrics_TripBreaker(t *testing.T) {
+	// Not parallel: asserts on package-level metrics
+	mockWallet := testutil.NewMockWalletClient()
+
+	breaker, err := New(&Config{
+		CheckInterval:   5 * time.Minute,
+		TradeMultiplier: 3.0,
+		MinAbsolute:     5.0,
+		HysteresisRatio: 1.5,
+		WalletClient:    mockWallet,
+		Address:         common.HexToAddress("0x1234567890abcdef1234567890abcdef12345678"),
+		Logger:          zaptest.NewLogger(t),
+	})
+	if err != nil {
+		t.Fatalf("failed to create breaker: %v", err)
+	}
+
+	if got := promtest.ToFloat64(CircuitBreakerStateGauge); got != 1 {
+		t.Errorf("expected initial state gauge 1, got %f", got)
+	}
+
+	disableBefore := promtest.ToFloat64(CircuitBreakerTransitionsTotal.WithLabelValues("disable"))
+	enableBefore := promtest.ToFloat64(CircuitBreakerTransitionsTotal.WithLabelValues("enable"))
+	ctx := context.Background()
+
+	// Trip: balance below disable threshold (5.0)
+	mockWallet.SetUSDCBalance(testutil.NewUSDCBigInt(1.0))
+	err = breaker.CheckBalance(ctx)
+	if err != nil {
+		t.Fatalf("CheckBalance failed: %v", err)
+	}
+
+	if got := promtest.ToFloat64(CircuitBreakerStateGauge); got != 0 {
+		t.Errorf("expected state gauge 0 after trip, got %f", got)
+	}
+
+	if got := promtest.ToFloat64(CircuitBreakerTransitionsTotal.WithLabelValues("disable")) - disableBefore; got != 1 {
+		t.Errorf("expected 1 disable transition, got %f", got)
+	}
+
+	// Still below enable threshold: no further transition
+	err = breaker.CheckBalance(ctx)
+	if err != nil {
+		t.Fatalf("CheckBalance failed: %v", err)
+	}
+
+	if got := promtest.ToFloat64(CircuitBreakerTransitionsTotal.WithLabelValues("disable")) - disableBefore; got != 1 {
+		t.Errorf("expected disable transitions to stay at 1, got %f", got)
+	}
+
+	// Recover: balance above enable threshold (7.5)
+	mockWallet.SetUSDCBalance(testutil.NewUSDCBigInt(10.0))
+	err = breaker.CheckBalance(ctx)
+	if err != nil {
+		t.Fatalf("CheckBalance failed: %v", err)
+	}
+
+	if got := promtest.ToFloat64(CircuitBreakerStateGauge); got != 1 {
+		t.Errorf("expected state gauge 1 after recovery, got %f", got)
+	}
+
+	if got := promtest.ToFloat64(CircuitBreakerTransitionsTotal.WithLabelValues("enable")) - enableBefore; got != 1 {
+		t.Errorf("expected 1 enable transition, got %f", got)
+	}
+}
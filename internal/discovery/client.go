@@ -42,12 +42,19 @@ const (
 // If limit > MaxBatchSize, multiple requests are made and results are aggregated.
 // orderBy specifies the field to sort by: "volume24hr", "createdAt", or "endDate".
 func (c *Client) FetchActiveMarkets(ctx context.Context, limit int, offset int, orderBy string) (*types.MarketsResponse, error) {
+	return c.FetchActiveMarketsUntil(ctx, limit, offset, orderBy, time.Time{})
+}
+
+// FetchActiveMarketsUntil is FetchActiveMarkets with a time budget for pagination: once
+// deadline passes, no further pages are requested and the markets fetched so far are
+// returned. The first page is always fetched. A zero deadline means no budget.
+func (c *Client) FetchActiveMarketsUntil(ctx context.Context, limit int, offset int, orderBy string, deadline time.Time) (*types.MarketsResponse, error) {
 	// Handle unlimited: 0 means fetch all available markets
 	fetchAll := limit == 0
 
 	// If requesting more than one batch, use pagination
 	if limit > MaxBatchSize || fetchAll {
-		return c.fetchWithPagination(ctx, limit, offset, orderBy)
+		return c.fetchWithPagination(ctx, limit, offset, orderBy, deadline)
 	}
 
 	// Single request for small limits
@@ -126,23 +133,46 @@ func (c *Client) fetchSinglePageWithFilter(ctx context.Context, limit int, offse
 		return nil, fmt.Errorf("unmarshal response: %w", err)
 	}
 
-	// Wrap in response object for consistency
+	// Wrap in response object for consistency. Count is the page size as returned, so
+	// pagination still detects the last page when invalid markets are dropped.
 	marketsResp := &types.MarketsResponse{
-		Data:   markets,
+		Data:   c.validMarkets(markets),
 		Count:  len(markets),
 		Limit:  limit,
 		Offset: offset,
 	}
 
 	c.logger.Debug("fetched-markets",
-		zap.Int("count", len(markets)))
+		zap.Int("count", len(markets)),
+		zap.Int("valid", len(marketsResp.Data)))
 
 	return marketsResp, nil
 }
 
+// validMarkets drops markets whose outcomes and clobTokenIds can't be paired by index,
+// so no caller maps an outcome to another outcome's token.
+func (c *Client) validMarkets(markets []types.Market) []types.Market {
+	valid := markets[:0]
+	for i := range markets {
+		_, err := markets[i].ParseTokens()
+		if err != nil {
+			c.logger.Warn("gamma-market-invalid",
+				zap.String("market-id", markets[i].ID),
+				zap.String("slug", markets[i].Slug),
+				zap.Error(err))
+			MarketsFilteredTotal.WithLabelValues("misaligned_outcomes").Inc()
+			continue
+		}
+		valid = append(valid, markets[i])
+	}
+
+	return valid
+}
+
 // fetchWithPagination fetches markets across multiple pages and aggregates results.
 // Automatically handles pagination when limit > MaxBatchSize or limit == 0 (fetch all).
-func (c *Client) fetchWithPagination(ctx context.Context, limit int, offset int, orderBy string) (*types.MarketsResponse, error) {
+// Stops early with the pages fetched so far once a non-zero deadline passes.
+func (c *Client) fetchWithPagination(ctx context.Context, limit int, offset int, orderBy string, deadline time.Time) (*types.MarketsResponse, error) {
 	var (
 		allMarkets   []types.Market
 		currentPage  = 0
@@ -174,6 +204,16 @@ func (c *Client) fetchWithPagination(ctx context.Context, limit int, offset int,
 			}
 		}
 
+		// Out of time: return what we have rather than stalling the poll on a slow API
+		if currentPage > 0 && !deadline.IsZero() && time.Now().After(deadline) {
+			PaginationTruncatedTotal.Inc()
+			c.logger.Warn("market-pagination-budget-exhausted",
+				zap.Int("pages", currentPage),
+				zap.Int("total-fetched", totalFetched),
+				zap.Int("requested-limit", limit))
+			break
+		}
+
 		// Calculate offset for this page
 		pageOffset := offset + (currentPage * batchSize)
 
@@ -185,7 +225,7 @@ func (c *Client) fetchWithPagination(ctx context.Context, limit int, offset int,
 
 		// Append results
 		allMarkets = append(allMarkets, resp.Data...)
-		totalFetched += len(resp.Data)
+		totalFetched += resp.Count
 
 		c.logger.Debug("fetched-page",
 			zap.Int("page", currentPage),
@@ -193,7 +233,7 @@ func (c *Client) fetchWithPagination(ctx context.Context, limit int, offset int,
 			zap.Int("total", totalFetched))
 
 		// Stop if we got fewer results than requested (no more data)
-		if len(resp.Data) < pageBatchSize {
+		if resp.Count < pageBatchSize {
 			c.logger.Debug("pagination-complete-no-more-data",
 				zap.Int("total-fetched", totalFetched))
 			break
@@ -265,7 +305,7 @@ func (c *Client) searchMarketsBySlug(ctx context.Context, slug string, closed bo
 		}
 
 		// If we got fewer markets than the limit, we've reached the end
-		if len(resp.Data) < limit {
+		if resp.Count < limit {
 			break
 		}
 
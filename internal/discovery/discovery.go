@@ -3,6 +3,8 @@ package discovery
 import (
 	"context"
 	"fmt"
+	"math/rand"
+	"strings"
 	"sync"
 	"time"
 
@@ -11,19 +13,40 @@ import (
 	"go.uber.org/zap"
 )
 
+const (
+	// defaultFetchMaxAttempts is used when Config.FetchMaxAttempts is unset.
+	defaultFetchMaxAttempts = 3
+
+	// defaultFetchRetryBackoff is used when Config.FetchRetryBackoff is unset.
+	defaultFetchRetryBackoff = time.Second
+
+	// maxFetchRetryBackoff caps the doubling backoff between fetch attempts.
+	maxFetchRetryBackoff = 10 * time.Second
+)
+
 // Service discovers new markets by polling the Gamma API.
 type Service struct {
 	client            *Client
 	cache             cache.Cache
 	pollInterval      time.Duration
+	pollJitter        float64
+	cleanupInterval   time.Duration
 	marketLimit       int
 	maxMarketDuration time.Duration
+	minMarketDuration time.Duration
+	categories        map[string]bool // Lowercased; empty = all categories
+	minLiquidity      float64
 	logger            *zap.Logger
 	subscribed        map[string]*types.MarketSubscription
 	tokenToMarket     map[string]*types.MarketSubscription // Reverse index: tokenID -> market
+	closed            map[string]bool                      // Slugs torn down after closing; never resubscribed
 	mu                sync.RWMutex
 	newMarketsCh      chan *types.Market
+	closedMarketsCh   chan *types.MarketSubscription
 	singleMarket      string // For debugging: if set, only track this one market
+	fetchMaxAttempts  int
+	fetchRetryBackoff time.Duration
+	fetchBudget       time.Duration
 }
 
 // Config holds discovery service configuration.
@@ -31,25 +54,66 @@ type Config struct {
 	Client            *Client
 	Cache             cache.Cache
 	PollInterval      time.Duration
+	PollJitter        float64       // Randomize each poll interval by ±this fraction (0.1 = ±10%, 0 = fixed)
+	CleanupInterval   time.Duration // How often to tear down expired markets (0 = only on poll)
 	MarketLimit       int
 	MaxMarketDuration time.Duration
+	MinMarketDuration time.Duration // Skip markets expiring sooner than this (0 = no minimum)
+	Categories        []string      // Only subscribe to these categories, case-insensitive (empty = all)
+	MinLiquidity      float64       // Skip markets with less Gamma-reported liquidity in USD (0 = no minimum)
 	Logger            *zap.Logger
 	SingleMarket      string // For debugging: slug of single market to track
+
+	// Failed Gamma fetches are retried within the poll with doubling backoff
+	FetchMaxAttempts  int           // Attempts per poll, including the first (0 = default 3, 1 = no retry)
+	FetchRetryBackoff time.Duration // Wait before the first retry (0 = default 1s)
+
+	// FetchBudget bounds the time spent paging through Gamma per poll, retries included;
+	// when it runs out the poll proceeds with the pages fetched so far (0 = unlimited)
+	FetchBudget time.Duration
 }
 
 // New creates a new discovery service.
 func New(cfg *Config) *Service {
+	categories := make(map[string]bool, len(cfg.Categories))
+	for _, category := range cfg.Categories {
+		category = strings.ToLower(strings.TrimSpace(category))
+		if category != "" {
+			categories[category] = true
+		}
+	}
+
+	fetchMaxAttempts := cfg.FetchMaxAttempts
+	if fetchMaxAttempts <= 0 {
+		fetchMaxAttempts = defaultFetchMaxAttempts
+	}
+
+	fetchRetryBackoff := cfg.FetchRetryBackoff
+	if fetchRetryBackoff <= 0 {
+		fetchRetryBackoff = defaultFetchRetryBackoff
+	}
+
 	return &Service{
 		client:            cfg.Client,
 		cache:             cfg.Cache,
 		pollInterval:      cfg.PollInterval,
+		pollJitter:        cfg.PollJitter,
+		cleanupInterval:   cfg.CleanupInterval,
 		marketLimit:       cfg.MarketLimit,
 		maxMarketDuration: cfg.MaxMarketDuration,
+		minMarketDuration: cfg.MinMarketDuration,
+		categories:        categories,
+		minLiquidity:      cfg.MinLiquidity,
 		logger:            cfg.Logger,
 		subscribed:        make(map[string]*types.MarketSubscription),
 		tokenToMarket:     make(map[string]*types.MarketSubscription),
+		closed:            make(map[string]bool),
 		newMarketsCh:      make(chan *types.Market, 10000),
+		closedMarketsCh:   make(chan *types.MarketSubscription, 10000),
 		singleMarket:      cfg.SingleMarket,
+		fetchMaxAttempts:  fetchMaxAttempts,
+		fetchRetryBackoff: fetchRetryBackoff,
+		fetchBudget:       cfg.FetchBudget,
 	}
 }
 
@@ -57,33 +121,68 @@ func New(cfg *Config) *Service {
 func (s *Service) Run(ctx context.Context) error {
 	s.logger.Info("discovery-service-starting",
 		zap.Duration("poll-interval", s.pollInterval),
+		zap.Float64("poll-jitter", s.pollJitter),
 		zap.Int("market-limit", s.marketLimit),
+		zap.Int("categories", len(s.categories)),
+		zap.Float64("min-liquidity", s.minLiquidity),
 		zap.String("single-market", s.singleMarket))
 
-	ticker := time.NewTicker(s.pollInterval)
-	defer ticker.Stop()
-
 	// Initial poll
 	err := s.poll(ctx)
 	if err != nil {
 		s.logger.Error("initial-poll-failed", zap.Error(err))
 	}
 
+	// Timer instead of ticker so each wait can be jittered independently
+	timer := time.NewTimer(s.nextPollInterval())
+	defer timer.Stop()
+
+	// Nil channel (never fires) when periodic cleanup is disabled
+	var cleanupC <-chan time.Time
+	if s.cleanupInterval > 0 {
+		cleanupTicker := time.NewTicker(s.cleanupInterval)
+		defer cleanupTicker.Stop()
+		cleanupC = cleanupTicker.C
+	}
+
 	for {
 		select {
 		case <-ctx.Done():
 			s.logger.Info("discovery-service-stopping")
 			close(s.newMarketsCh)
+			close(s.closedMarketsCh)
 			return ctx.Err()
-		case <-ticker.C:
+		case now := <-cleanupC:
+			s.cleanupExpiredMarkets(now)
+		case <-timer.C:
 			err = s.poll(ctx)
 			if err != nil {
 				s.logger.Error("poll-failed", zap.Error(err))
 			}
+			timer.Reset(s.nextPollInterval())
 		}
 	}
 }
 
+// PollOnce runs a single poll without starting the polling loop. New markets are sent
+// on NewMarketsChan as in Run. Used for one-shot runs.
+func (s *Service) PollOnce(ctx context.Context) error {
+	return s.poll(ctx)
+}
+
+// nextPollInterval returns the poll interval randomized by ±pollJitter, so bots
+// started together don't hit the Gamma API in lockstep. Zero jitter is fixed.
+func (s *Service) nextPollInterval() time.Duration {
+	if s.pollJitter <= 0 {
+		return s.pollInterval
+	}
+
+	// Uniform in [1-jitter, 1+jitter)
+	factor := 1 + s.pollJitter*(2*rand.Float64()-1)
+
+	return time.Duration(float64(s.pollInterval) * factor)
+}
+
 // poll fetches markets from the API and identifies new ones.
 func (s *Service) poll(ctx context.Context) error {
 	start := time.Now()
@@ -97,7 +196,7 @@ func (s *Service) poll(ctx context.Context) error {
 	}
 
 	// Fetch active markets sorted by creation date (DESC) - newest markets first
-	resp, err := s.client.FetchActiveMarkets(ctx, s.marketLimit, 0, "createdAt")
+	resp, err := s.fetchActiveMarkets(ctx)
 	if err != nil {
 		PollErrorsTotal.Inc()
 		return fmt.Errorf("fetch active markets: %w", err)
@@ -105,6 +204,9 @@ func (s *Service) poll(ctx context.Context) error {
 
 	MarketsDiscoveredTotal.Add(float64(len(resp.Data)))
 
+	// Tear down subscribed markets the API now reports as closed
+	s.detectClosedMarkets(resp.Data)
+
 	// Identify new markets
 	newMarkets := s.identifyNewMarkets(resp.Data)
 
@@ -139,6 +241,46 @@ func (s *Service) poll(ctx context.Context) error {
 	return nil
 }
 
+// fetchActiveMarkets fetches active markets, retrying failures with doubling backoff so a
+// transient Gamma error doesn't delay discovery by a whole poll interval.
+func (s *Service) fetchActiveMarkets(ctx context.Context) (*types.MarketsResponse, error) {
+	backoff := s.fetchRetryBackoff
+
+	var deadline time.Time
+	if s.fetchBudget > 0 {
+		deadline = time.Now().Add(s.fetchBudget)
+	}
+
+	for attempt := 1; ; attempt++ {
+		resp, err := s.client.FetchActiveMarketsUntil(ctx, s.marketLimit, 0, "createdAt", deadline)
+		if err == nil {
+			return resp, nil
+		}
+
+		FetchFailuresTotal.Inc()
+
+		if attempt >= s.fetchMaxAttempts || ctx.Err() != nil {
+			return nil, fmt.Errorf("after %d attempts: %w", attempt, err)
+		}
+
+		s.logger.Warn("market-fetch-failed-retrying",
+			zap.Int("attempt", attempt),
+			zap.Int("max-attempts", s.fetchMaxAttempts),
+			zap.Duration("backoff", backoff),
+			zap.Error(err))
+
+		timer := time.NewTimer(backoff)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, fmt.Errorf("after %d attempts: %w", attempt, ctx.Err())
+		case <-timer.C:
+		}
+
+		backoff = min(backoff*2, maxFetchRetryBackoff)
+	}
+}
+
 // pollSingleMarket polls only a specific market (for debugging).
 func (s *Service) pollSingleMarket(ctx context.Context) error {
 	// Check if already subscribed
@@ -166,14 +308,7 @@ func (s *Service) pollSingleMarket(ctx context.Context) error {
 			s.singleMarket, len(market.Tokens))
 	}
 
-	// Map all outcomes to OutcomeToken structs
-	outcomes := make([]types.OutcomeToken, len(market.Tokens))
-	for i, token := range market.Tokens {
-		outcomes[i] = types.OutcomeToken{
-			TokenID: token.TokenID,
-			Outcome: token.Outcome,
-		}
-	}
+	outcomes := subscriptionOutcomes(market)
 
 	// Mark as subscribed
 	s.mu.Lock()
@@ -183,6 +318,9 @@ func (s *Service) pollSingleMarket(ctx context.Context) error {
 		Question:     market.Question,
 		Outcomes:     outcomes,
 		SubscribedAt: time.Now(),
+		EndDate:      market.EndDate,
+		Active:       market.Active,
+		NegRisk:      market.NegRisk,
 	}
 	s.subscribed[market.Slug] = marketSub
 	// Build reverse index: tokenID -> market
@@ -222,8 +360,21 @@ func (s *Service) identifyNewMarkets(markets []types.Market) []*types.Market {
 			continue
 		}
 
+		// Skip closed markets, including ones already torn down that the API still lists
+		if market.Closed || s.closed[market.Slug] {
+			continue
+		}
+
 		// Check if market has at least 2 outcomes (binary or multi-outcome)
 		if len(market.Tokens) < 2 {
+			if _, err := market.ParseTokens(); err != nil {
+				s.logger.Debug("skipping-market-misaligned-outcomes",
+					zap.String("market-id", market.ID),
+					zap.String("slug", market.Slug),
+					zap.Error(err))
+				MarketsFilteredTotal.WithLabelValues("misaligned_outcomes").Inc()
+				continue
+			}
 			s.logger.Debug("skipping-market-insufficient-outcomes",
 				zap.String("market-id", market.ID),
 				zap.String("question", market.Question),
@@ -231,9 +382,26 @@ func (s *Service) identifyNewMarkets(markets []types.Market) []*types.Market {
 			continue
 		}
 
-		// Filter by EndDate (only subscribe to markets expiring within threshold)
-		// If maxMarketDuration == 0, skip all duration checks (unlimited)
-		if !market.EndDate.IsZero() && s.maxMarketDuration > 0 {
+		if len(s.categories) > 0 && !s.categories[strings.ToLower(market.Category)] {
+			s.logger.Debug("skipping-market-category",
+				zap.String("slug", market.Slug),
+				zap.String("category", market.Category))
+			MarketsFilteredTotal.WithLabelValues("category").Inc()
+			continue
+		}
+
+		if s.minLiquidity > 0 && market.Liquidity < s.minLiquidity {
+			s.logger.Debug("skipping-market-low-liquidity",
+				zap.String("slug", market.Slug),
+				zap.Float64("liquidity", market.Liquidity),
+				zap.Float64("min-liquidity", s.minLiquidity))
+			MarketsFilteredTotal.WithLabelValues("liquidity").Inc()
+			continue
+		}
+
+		// Filter by EndDate (only subscribe to markets expiring within the window)
+		// If both durations are 0, skip all duration checks (unlimited)
+		if !market.EndDate.IsZero() && (s.maxMarketDuration > 0 || s.minMarketDuration > 0) {
 			timeUntilExpiry := time.Until(market.EndDate)
 
 			if timeUntilExpiry < 0 {
@@ -245,7 +413,17 @@ func (s *Service) identifyNewMarkets(markets []types.Market) []*types.Market {
 				continue
 			}
 
-			if timeUntilExpiry > s.maxMarketDuration {
+			if timeUntilExpiry < s.minMarketDuration {
+				// Market expires too soon to trade
+				s.logger.Debug("skipping-market-expires-too-soon",
+					zap.String("slug", market.Slug),
+					zap.Duration("time-until-expiry", timeUntilExpiry),
+					zap.Duration("min-duration", s.minMarketDuration))
+				MarketsFilteredByEndDateTotal.Inc()
+				continue
+			}
+
+			if s.maxMarketDuration > 0 && timeUntilExpiry > s.maxMarketDuration {
 				// Market expires too far in future
 				s.logger.Info("market filtered by duration",
 					zap.String("market", market.Slug),
@@ -256,14 +434,7 @@ func (s *Service) identifyNewMarkets(markets []types.Market) []*types.Market {
 			}
 		}
 
-		// Map all outcomes to OutcomeToken structs
-		outcomes := make([]types.OutcomeToken, len(market.Tokens))
-		for i, token := range market.Tokens {
-			outcomes[i] = types.OutcomeToken{
-				TokenID: token.TokenID,
-				Outcome: token.Outcome,
-			}
-		}
+		outcomes := subscriptionOutcomes(market)
 
 		// Mark as subscribed
 		marketSub := &types.MarketSubscription{
@@ -272,6 +443,9 @@ func (s *Service) identifyNewMarkets(markets []types.Market) []*types.Market {
 			Question:     market.Question,
 			Outcomes:     outcomes,
 			SubscribedAt: time.Now(),
+			EndDate:      market.EndDate,
+			Active:       market.Active,
+			NegRisk:      market.NegRisk,
 		}
 		s.subscribed[market.Slug] = marketSub
 		// Build reverse index: tokenID -> market
@@ -285,11 +459,98 @@ func (s *Service) identifyNewMarkets(markets []types.Market) []*types.Market {
 	return newMarkets
 }
 
+// subscriptionOutcomes maps a market's tokens to subscription outcomes, index-aligned
+// with market.Tokens. Names are normalized again for markets not decoded from Gamma JSON.
+func subscriptionOutcomes(market *types.Market) []types.OutcomeToken {
+	outcomes := make([]types.OutcomeToken, len(market.Tokens))
+	for i, token := range market.Tokens {
+		outcomes[i] = types.OutcomeToken{
+			TokenID: strings.TrimSpace(token.TokenID),
+			Outcome: types.NormalizeOutcome(token.Outcome),
+		}
+	}
+
+	return outcomes
+}
+
+// AddMarkets subscribes markets directly, bypassing the Gamma API poll.
+// Used by backtest replay where the market set is known up front.
+// Returns the markets that were newly subscribed.
+func (s *Service) AddMarkets(markets []types.Market) []*types.Market {
+	return s.identifyNewMarkets(markets)
+}
+
+// detectClosedMarkets tears down subscribed markets that the API reports as closed,
+// or that were active when discovered and have since gone inactive.
+func (s *Service) detectClosedMarkets(markets []types.Market) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i := range markets {
+		sub, exists := s.subscribed[markets[i].Slug]
+		if !exists {
+			continue
+		}
+
+		switch {
+		case markets[i].Closed:
+			s.closeMarketLocked(sub, "closed")
+		case sub.Active && !markets[i].Active:
+			s.closeMarketLocked(sub, "inactive")
+		}
+	}
+}
+
+// cleanupExpiredMarkets tears down subscribed markets whose end date has passed.
+// Runs every CleanupInterval so markets that drop out of the poll results are still released.
+func (s *Service) cleanupExpiredMarkets(now time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, sub := range s.subscribed {
+		if !sub.EndDate.IsZero() && now.After(sub.EndDate) {
+			s.closeMarketLocked(sub, "expired")
+		}
+	}
+}
+
+// closeMarketLocked unsubscribes a market and notifies ClosedMarketsChan consumers.
+// Caller must hold s.mu.
+func (s *Service) closeMarketLocked(sub *types.MarketSubscription, reason string) {
+	delete(s.subscribed, sub.MarketSlug)
+	for _, outcome := range sub.Outcomes {
+		delete(s.tokenToMarket, outcome.TokenID)
+	}
+	s.closed[sub.MarketSlug] = true
+
+	if s.cache != nil {
+		s.cache.Delete(sub.MarketID)
+	}
+
+	MarketsClosedTotal.WithLabelValues(reason).Inc()
+
+	select {
+	case s.closedMarketsCh <- sub:
+		s.logger.Info("market-closed",
+			zap.String("slug", sub.MarketSlug),
+			zap.String("reason", reason))
+	default:
+		s.logger.Warn("closed-markets-channel-full",
+			zap.String("slug", sub.MarketSlug))
+	}
+}
+
 // NewMarketsChan returns the channel for receiving new markets.
 func (s *Service) NewMarketsChan() <-chan *types.Market {
 	return s.newMarketsCh
 }
 
+// ClosedMarketsChan returns the channel for receiving markets that closed after
+// being subscribed. Consumers should unsubscribe their tokens and evict snapshots.
+func (s *Service) ClosedMarketsChan() <-chan *types.MarketSubscription {
+	return s.closedMarketsCh
+}
+
 // GetSubscribedMarkets returns all currently subscribed markets.
 func (s *Service) GetSubscribedMarkets() []*types.MarketSubscription {
 	s.mu.RLock()
@@ -321,6 +582,21 @@ func (s *Service) GetMarketByTokenID(tokenID string) (*types.MarketSubscription,
 	return market, exists
 }
 
+// GetMarketByID retrieves a market subscription by its Gamma market ID.
+// Scans all subscriptions; use GetMarketByTokenID on hot paths.
+func (s *Service) GetMarketByID(marketID string) (*types.MarketSubscription, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, sub := range s.subscribed {
+		if sub.MarketID == marketID {
+			return sub, true
+		}
+	}
+
+	return nil, false
+}
+
 // cacheMarket stores a market in the cache.
 func (s *Service) cacheMarket(market *types.Market) {
 	if s.cache == nil {
@@ -363,6 +639,9 @@ func (s *Service) RemoveMarkets(markets []*types.MarketSubscription) {
 
 	for _, market := range markets {
 		delete(s.subscribed, market.MarketSlug)
+		for _, outcome := range market.Outcomes {
+			delete(s.tokenToMarket, outcome.TokenID)
+		}
 
 		// Also remove from cache if present
 		if s.cache != nil {
@@ -3,11 +3,17 @@ package discovery
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
 	"testing"
 	"time"
 
+	promtestutil "github.com/prometheus/client_golang/prometheus/testutil"
+
 	"github.com/mselser95/polymarket-arb/pkg/cache"
 	"github.com/mselser95/polymarket-arb/pkg/types"
 	"go.uber.org/zap"
@@ -824,3 +830,564 @@ func TestService_identifyNewMarkets_WithDurationFilter(t *testing.T) {
 	}
 	svc.mu.RUnlock()
 }
+
+func TestService_NextPollInterval_Jitter(t *testing.T) {
+	service := New(&Config{
+		PollInterval: 10 * time.Second,
+		PollJitter:   0.2,
+		Logger:       zap.NewNop(),
+	})
+
+	low := 8 * time.Second
+	high := 12 * time.Second
+	seen := make(map[time.Duration]bool)
+
+	for range 100 {
+		interval := service.nextPollInterval()
+		if interval < low || interval > high {
+			t.Fatalf("interval %v outside jitter band [%v, %v]", interval, low, high)
+		}
+		seen[interval] = true
+	}
+
+	if len(seen) < 2 {
+		t.Errorf("expected jittered intervals to vary, got %d distinct values", len(seen))
+	}
+}
+
+func TestService_NextPollInterval_NoJitter(t *testing.T) {
+	service := New(&Config{
+		PollInterval: 10 * time.Second,
+		Logger:       zap.NewNop(),
+	})
+
+	for range 10 {
+		interval := service.nextPollInterval()
+		if interval != 10*time.Second {
+			t.Fatalf("expected fixed 10s interval without jitter, got %v", interval)
+		}
+	}
+}
+
+func TestService_Poll_Filters(t *testing.T) {
+	now := time.Now()
+
+	// Gamma API returns a mix of markets; only "keep" should pass every filter
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		markets := []map[string]any{
+			{"id": "keep", "slug": "keep", "category": "Sports", "liquidityNum": 5000.0,
+				"endDate": now.Add(2 * time.Hour), "outcomes": `["Yes", "No"]`, "clobTokenIds": `["k1", "k2"]`},
+			{"id": "wrong-category", "slug": "wrong-category", "category": "Politics", "liquidityNum": 5000.0,
+				"endDate": now.Add(2 * time.Hour), "outcomes": `["Yes", "No"]`, "clobTokenIds": `["c1", "c2"]`},
+			{"id": "illiquid", "slug": "illiquid", "category": "sports", "liquidityNum": 50.0,
+				"endDate": now.Add(2 * time.Hour), "outcomes": `["Yes", "No"]`, "clobTokenIds": `["i1", "i2"]`},
+			{"id": "too-soon", "slug": "too-soon", "category": "sports", "liquidityNum": 5000.0,
+				"endDate": now.Add(5 * time.Minute), "outcomes": `["Yes", "No"]`, "clobTokenIds": `["s1", "s2"]`},
+			{"id": "too-late", "slug": "too-late", "category": "sports", "liquidityNum": 5000.0,
+				"endDate": now.Add(48 * time.Hour), "outcomes": `["Yes", "No"]`, "clobTokenIds": `["l1", "l2"]`},
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(markets)
+	}))
+	defer server.Close()
+
+	svc := New(&Config{
+		Client:            NewClient(server.URL, zap.NewNop()),
+		PollInterval:      30 * time.Second,
+		MarketLimit:       10,
+		MaxMarketDuration: 24 * time.Hour,
+		MinMarketDuration: 30 * time.Minute,
+		Categories:        []string{"sports", " crypto "},
+		MinLiquidity:      1000,
+		Logger:            zap.NewNop(),
+	})
+
+	err := svc.poll(context.Background())
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	var surfaced []string
+	for len(svc.NewMarketsChan()) > 0 {
+		surfaced = append(surfaced, (<-svc.NewMarketsChan()).ID)
+	}
+
+	if len(surfaced) != 1 || surfaced[0] != "keep" {
+		t.Errorf("expected only [keep] on NewMarketsChan, got %v", surfaced)
+	}
+
+	if len(svc.GetSubscribedMarkets()) != 1 {
+		t.Errorf("expected 1 subscribed market, got %d", len(svc.GetSubscribedMarkets()))
+	}
+}
+
+func TestService_Poll_NoFilters(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		markets := []map[string]any{
+			{"id": "a", "slug": "a", "category": "Politics", "outcomes": `["Yes", "No"]`, "clobTokenIds": `["a1", "a2"]`},
+			{"id": "b", "slug": "b", "liquidityNum": 1.0, "outcomes": `["Yes", "No"]`, "clobTokenIds": `["b1", "b2"]`},
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(markets)
+	}))
+	defer server.Close()
+
+	svc := New(&Config{
+		Client:      NewClient(server.URL, zap.NewNop()),
+		MarketLimit: 10,
+		Logger:      zap.NewNop(),
+	})
+
+	err := svc.poll(context.Background())
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if got := len(svc.NewMarketsChan()); got != 2 {
+		t.Errorf("expected 2 markets without filters, got %d", got)
+	}
+}
+
+func TestService_Poll_ClosedMarketTornDown(t *testing.T) {
+	var closed atomic.Bool
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		markets := []map[string]any{
+			{"id": "m1", "slug": "closing", "active": true, "closed": closed.Load(),
+				"outcomes": `["Yes", "No"]`, "clobTokenIds": `["c1", "c2"]`},
+			{"id": "m2", "slug": "open", "active": true,
+				"outcomes": `["Yes", "No"]`, "clobTokenIds": `["o1", "o2"]`},
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(markets)
+	}))
+	defer server.Close()
+
+	svc := New(&Config{
+		Client:      NewClient(server.URL, zap.NewNop()),
+		MarketLimit: 10,
+		Logger:      zap.NewNop(),
+	})
+
+	err := svc.poll(context.Background())
+	if err != nil {
+		t.Fatalf("first poll: %v", err)
+	}
+
+	if len(svc.GetSubscribedMarkets()) != 2 {
+		t.Fatalf("expected 2 subscribed markets, got %d", len(svc.GetSubscribedMarkets()))
+	}
+
+	closed.Store(true)
+
+	err = svc.poll(context.Background())
+	if err != nil {
+		t.Fatalf("second poll: %v", err)
+	}
+
+	select {
+	case sub := <-svc.ClosedMarketsChan():
+		if sub.MarketSlug != "closing" {
+			t.Errorf("expected closing market on ClosedMarketsChan, got %s", sub.MarketSlug)
+		}
+		if len(sub.Outcomes) != 2 {
+			t.Errorf("expected 2 outcomes to unsubscribe, got %d", len(sub.Outcomes))
+		}
+	default:
+		t.Fatal("expected closed market on ClosedMarketsChan")
+	}
+
+	if len(svc.ClosedMarketsChan()) != 0 {
+		t.Errorf("expected only one closed market, got %d more", len(svc.ClosedMarketsChan()))
+	}
+
+	if _, exists := svc.GetMarketBySlug("closing"); exists {
+		t.Error("expected closed market to be unsubscribed")
+	}
+
+	if _, exists := svc.GetMarketByTokenID("c1"); exists {
+		t.Error("expected closed market tokens to be removed from reverse index")
+	}
+
+	if _, exists := svc.GetMarketBySlug("open"); !exists {
+		t.Error("expected open market to stay subscribed")
+	}
+
+	// A third poll must not resubscribe or re-close the torn-down market
+	err = svc.poll(context.Background())
+	if err != nil {
+		t.Fatalf("third poll: %v", err)
+	}
+
+	if len(svc.GetSubscribedMarkets()) != 1 || len(svc.ClosedMarketsChan()) != 0 {
+		t.Errorf("expected closed market to stay torn down, subscribed=%d closed=%d",
+			len(svc.GetSubscribedMarkets()), len(svc.ClosedMarketsChan()))
+	}
+}
+
+func TestService_Poll_RetriesFailedFetch(t *testing.T) {
+	var requests atomic.Int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Gamma fails the first request, then recovers
+		if requests.Add(1) == 1 {
+			http.Error(w, "service unavailable", http.StatusServiceUnavailable)
+			return
+		}
+
+		markets := []map[string]any{
+			{"id": "m1", "slug": "recovered", "active": true,
+				"outcomes": `["Yes", "No"]`, "clobTokenIds": `["r1", "r2"]`},
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(markets)
+	}))
+	defer server.Close()
+
+	svc := New(&Config{
+		Client:            NewClient(server.URL, zap.NewNop()),
+		MarketLimit:       10,
+		Logger:            zap.NewNop(),
+		FetchRetryBackoff: time.Millisecond,
+	})
+
+	failuresBefore := promtestutil.ToFloat64(FetchFailuresTotal)
+
+	err := svc.poll(context.Background())
+	if err != nil {
+		t.Fatalf("expected retry to recover within the poll, got %v", err)
+	}
+
+	if got := requests.Load(); got != 2 {
+		t.Errorf("expected 2 fetch attempts, got %d", got)
+	}
+
+	select {
+	case market := <-svc.NewMarketsChan():
+		if market.Slug != "recovered" {
+			t.Errorf("expected recovered market, got %s", market.Slug)
+		}
+	default:
+		t.Fatal("expected market on NewMarketsChan after retry")
+	}
+
+	if got := promtestutil.ToFloat64(FetchFailuresTotal) - failuresBefore; got != 1 {
+		t.Errorf("expected 1 fetch failure recorded, got %f", got)
+	}
+}
+
+func TestService_Poll_FetchRetryBounded(t *testing.T) {
+	var requests atomic.Int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		http.Error(w, "service unavailable", http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	t.Run("attempts_exhausted", func(t *testing.T) {
+		requests.Store(0)
+		svc := New(&Config{
+			Client:            NewClient(server.URL, zap.NewNop()),
+			Logger:            zap.NewNop(),
+			FetchMaxAttempts:  3,
+			FetchRetryBackoff: time.Millisecond,
+		})
+
+		err := svc.poll(context.Background())
+		if err == nil {
+			t.Fatal("expected error after exhausting attempts")
+		}
+
+		if got := requests.Load(); got != 3 {
+			t.Errorf("expected 3 fetch attempts, got %d", got)
+		}
+	})
+
+	t.Run("context_cancelled", func(t *testing.T) {
+		requests.Store(0)
+		svc := New(&Config{
+			Client:            NewClient(server.URL, zap.NewNop()),
+			Logger:            zap.NewNop(),
+			FetchMaxAttempts:  5,
+			FetchRetryBackoff: time.Hour,
+		})
+
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+
+		start := time.Now()
+		err := svc.poll(ctx)
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Fatalf("expected context deadline error, got %v", err)
+		}
+
+		if elapsed := time.Since(start); elapsed > 5*time.Second {
+			t.Errorf("expected retry wait to stop on context end, took %v", elapsed)
+		}
+
+		if got := requests.Load(); got != 1 {
+			t.Errorf("expected 1 fetch attempt before cancellation, got %d", got)
+		}
+	})
+}
+
+// TestService_Poll_MessyOutcomes tests that outcome names from a messy Gamma payload are
+// normalized and stay mapped to their own token IDs, and that misaligned markets are skipped.
+func TestService_Poll_MessyOutcomes(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		markets := []map[string]any{
+			{"id": "m1", "slug": "messy-binary", "active": true,
+				"outcomes": `[" YES", "no  "]`, "clobTokenIds": `["y1 ", " n1"]`},
+			{"id": "m2", "slug": "messy-candidates", "active": true,
+				"outcomes": `["Alice   Smith ", " bob", "OTHER"]`, "clobTokenIds": `["a2", "b2", "o2"]`},
+			{"id": "m3", "slug": "misaligned", "active": true,
+				"outcomes": `["Yes", "No", "Maybe"]`, "clobTokenIds": `["y3", "n3"]`},
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(markets)
+	}))
+	defer server.Close()
+
+	svc := New(&Config{
+		Client:      NewClient(server.URL, zap.NewNop()),
+		MarketLimit: 10,
+		Logger:      zap.NewNop(),
+	})
+
+	misalignedBefore := promtestutil.ToFloat64(MarketsFilteredTotal.WithLabelValues("misaligned_outcomes"))
+
+	err := svc.poll(context.Background())
+	if err != nil {
+		t.Fatalf("poll: %v", err)
+	}
+
+	want := map[string][]types.OutcomeToken{
+		"messy-binary": {{TokenID: "y1", Outcome: "Yes"}, {TokenID: "n1", Outcome: "No"}},
+		"messy-candidates": {
+			{TokenID: "a2", Outcome: "Alice Smith"},
+			{TokenID: "b2", Outcome: "bob"},
+			{TokenID: "o2", Outcome: "OTHER"},
+		},
+	}
+
+	for slug, wantOutcomes := range want {
+		sub, exists := svc.GetMarketBySlug(slug)
+		if !exists {
+			t.Fatalf("expected %s to be subscribed", slug)
+		}
+		if len(sub.Outcomes) != len(wantOutcomes) {
+			t.Fatalf("%s: expected %d outcomes, got %+v", slug, len(wantOutcomes), sub.Outcomes)
+		}
+		for i, outcome := range wantOutcomes {
+			if sub.Outcomes[i] != outcome {
+				t.Errorf("%s outcome %d: expected %+v, got %+v", slug, i, outcome, sub.Outcomes[i])
+			}
+
+			// Reverse index resolves the trimmed token ID
+			byToken, ok := svc.GetMarketByTokenID(outcome.TokenID)
+			if !ok || byToken.MarketSlug != slug {
+				t.Errorf("expected token %s to map to %s", outcome.TokenID, slug)
+			}
+		}
+	}
+
+	if _, exists := svc.GetMarketBySlug("misaligned"); exists {
+		t.Error("expected misaligned market to be skipped")
+	}
+	if got := promtestutil.ToFloat64(MarketsFilteredTotal.WithLabelValues("misaligned_outcomes")) - misalignedBefore; got != 1 {
+		t.Errorf("expected 1 misaligned market recorded, got %f", got)
+	}
+}
+
+// TestClient_FetchActiveMarkets_DropsMisalignedMarkets tests that the client rejects
+// markets whose outcomes and token arrays can't be paired, while pagination still
+// advances past a full page that contained them.
+func TestClient_FetchActiveMarkets_DropsMisalignedMarkets(t *testing.T) {
+	total := MaxBatchSize + 5
+	misaligned := map[int]map[string]any{
+		0: {"outcomes": `["Yes", "No", "Maybe"]`, "clobTokenIds": `["y0", "n0"]`},
+		1: {"outcomes": `["Yes", "No"]`},
+		2: {"outcomes": `["Yes", ""]`, "clobTokenIds": `["y2", "n2"]`},
+	}
+
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+
+		limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+
+		markets := []map[string]any{}
+		for i := offset; i < min(offset+limit, total); i++ {
+			market := map[string]any{
+				"id": fmt.Sprintf("m%d", i), "slug": fmt.Sprintf("market-%d", i), "active": true,
+				"outcomes":     `["Yes", "No"]`,
+				"clobTokenIds": fmt.Sprintf(`["y%d", "n%d"]`, i, i),
+			}
+			if fields, ok := misaligned[i]; ok {
+				delete(market, "clobTokenIds")
+				for k, v := range fields {
+					market[k] = v
+				}
+			}
+			markets = append(markets, market)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(markets)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, zap.NewNop())
+	misalignedBefore := promtestutil.ToFloat64(MarketsFilteredTotal.WithLabelValues("misaligned_outcomes"))
+
+	resp, err := client.FetchActiveMarkets(context.Background(), 0, 0, "volume24hr")
+	if err != nil {
+		t.Fatalf("fetch: %v", err)
+	}
+
+	if got := requests.Load(); got != 2 {
+		t.Errorf("expected 2 page requests, got %d", got)
+	}
+	if len(resp.Data) != total-len(misaligned) {
+		t.Errorf("expected %d valid markets, got %d", total-len(misaligned), len(resp.Data))
+	}
+	for _, market := range resp.Data {
+		if market.ID == "m0" || market.ID == "m1" || market.ID == "m2" {
+			t.Errorf("expected misaligned market %s to be dropped", market.ID)
+		}
+		if len(market.Tokens) != 2 {
+			t.Errorf("market %s: expected 2 tokens, got %+v", market.ID, market.Tokens)
+		}
+	}
+
+	got := promtestutil.ToFloat64(MarketsFilteredTotal.WithLabelValues("misaligned_outcomes")) - misalignedBefore
+	if got != float64(len(misaligned)) {
+		t.Errorf("expected %d misaligned markets recorded, got %f", len(misaligned), got)
+	}
+}
+
+// pagedGammaServer serves total markets in offset/limit pages, waiting delay per request.
+func pagedGammaServer(t *testing.T, total int, delay time.Duration, requests *atomic.Int32) *httptest.Server {
+	t.Helper()
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		time.Sleep(delay)
+
+		limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+
+		markets := []map[string]any{}
+		for i := offset; i < min(offset+limit, total); i++ {
+			markets = append(markets, map[string]any{
+				"id": fmt.Sprintf("m%d", i), "slug": fmt.Sprintf("market-%d", i), "active": true,
+				"outcomes":     `["Yes", "No"]`,
+				"clobTokenIds": fmt.Sprintf(`["y%d", "n%d"]`, i, i),
+			})
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(markets)
+	}))
+}
+
+func TestService_Poll_PagesUpToMarketLimit(t *testing.T) {
+	var requests atomic.Int32
+	server := pagedGammaServer(t, 1000, 0, &requests)
+	defer server.Close()
+
+	svc := New(&Config{
+		Client:      NewClient(server.URL, zap.NewNop()),
+		MarketLimit: 250,
+		Logger:      zap.NewNop(),
+		FetchBudget: time.Minute,
+	})
+
+	err := svc.poll(context.Background())
+	if err != nil {
+		t.Fatalf("poll: %v", err)
+	}
+
+	// 100 + 100 + 50
+	if got := requests.Load(); got != 3 {
+		t.Errorf("expected 3 page requests, got %d", got)
+	}
+
+	if got := len(svc.GetSubscribedMarkets()); got != 250 {
+		t.Errorf("expected 250 markets aggregated across pages, got %d", got)
+	}
+}
+
+func TestService_Poll_PaginationBudget(t *testing.T) {
+	var requests atomic.Int32
+	server := pagedGammaServer(t, 1000, 20*time.Millisecond, &requests)
+	defer server.Close()
+
+	svc := New(&Config{
+		Client:      NewClient(server.URL, zap.NewNop()),
+		MarketLimit: 500,
+		Logger:      zap.NewNop(),
+		FetchBudget: 10 * time.Millisecond,
+	})
+
+	truncatedBefore := promtestutil.ToFloat64(PaginationTruncatedTotal)
+
+	// The first page outlasts the budget, so the poll keeps it and stops paging
+	err := svc.poll(context.Background())
+	if err != nil {
+		t.Fatalf("expected partial result without error, got %v", err)
+	}
+
+	if got := requests.Load(); got != 1 {
+		t.Errorf("expected 1 page request within the budget, got %d", got)
+	}
+
+	if got := len(svc.GetSubscribedMarkets()); got != 100 {
+		t.Errorf("expected the 100 markets of the first page, got %d", got)
+	}
+
+	if got := promtestutil.ToFloat64(PaginationTruncatedTotal) - truncatedBefore; got != 1 {
+		t.Errorf("expected 1 truncated fetch recorded, got %f", got)
+	}
+}
+
+func TestService_CleanupExpiredMarkets(t *testing.T) {
+	now := time.Now()
+	svc := New(&Config{Logger: zap.NewNop()})
+
+	svc.AddMarkets([]types.Market{
+		{
+			ID: "expired", Slug: "expired", EndDate: now.Add(-time.Minute),
+			Tokens: []types.Token{{TokenID: "e1", Outcome: "YES"}, {TokenID: "e2", Outcome: "NO"}},
+		},
+		{
+			ID: "live", Slug: "live", EndDate: now.Add(time.Hour),
+			Tokens: []types.Token{{TokenID: "l1", Outcome: "YES"}, {TokenID: "l2", Outcome: "NO"}},
+		},
+		{
+			ID: "no-end-date", Slug: "no-end-date",
+			Tokens: []types.Token{{TokenID: "n1", Outcome: "YES"}, {TokenID: "n2", Outcome: "NO"}},
+		},
+	})
+
+	svc.cleanupExpiredMarkets(now)
+
+	if len(svc.ClosedMarketsChan()) != 1 {
+		t.Fatalf("expected 1 expired market, got %d", len(svc.ClosedMarketsChan()))
+	}
+
+	if sub := <-svc.ClosedMarketsChan(); sub.MarketSlug != "expired" {
+		t.Errorf("expected expired market to be torn down, got %s", sub.MarketSlug)
+	}
+
+	if len(svc.GetSubscribedMarkets()) != 2 {
+		t.Errorf("expected 2 markets to remain subscribed, got %d", len(svc.GetSubscribedMarkets()))
+	}
+}
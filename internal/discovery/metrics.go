@@ -31,9 +31,33 @@ var (
 		Help: "Total number of Gamma API poll failures",
 	})
 
+	// FetchFailuresTotal tracks failed Gamma API fetch attempts, including ones retried within a poll.
+	FetchFailuresTotal = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "polymarket_discovery_fetch_failures_total",
+		Help: "Total number of failed Gamma API fetch attempts, including retried ones",
+	})
+
+	// PaginationTruncatedTotal tracks market fetches cut short by the per-poll time budget.
+	PaginationTruncatedTotal = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "polymarket_discovery_pagination_truncated_total",
+		Help: "Total number of paginated market fetches stopped early by the time budget",
+	})
+
 	// MarketsFilteredByEndDateTotal tracks markets filtered due to EndDate threshold.
 	MarketsFilteredByEndDateTotal = promauto.NewCounter(prometheus.CounterOpts{
 		Name: "polymarket_discovery_markets_filtered_by_end_date_total",
 		Help: "Total number of markets filtered out due to EndDate threshold",
 	})
+
+	// MarketsFilteredTotal tracks markets filtered by category or liquidity.
+	MarketsFilteredTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "polymarket_discovery_markets_filtered_total",
+		Help: "Total number of markets filtered out by discovery filters (reason: category, liquidity, misaligned_outcomes)",
+	}, []string{"reason"})
+
+	// MarketsClosedTotal tracks subscribed markets torn down after closing.
+	MarketsClosedTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "polymarket_discovery_markets_closed_total",
+		Help: "Total number of subscribed markets torn down (reason: closed, inactive, expired)",
+	}, []string{"reason"})
 )
@@ -0,0 +1,40 @@
+package execution
+
+import (
+	"math/big"
+	"strconv"
+)
+
+// USDCDecimals is the number of decimals of USDC on Polygon. Outcome tokens are minted
+// 1:1 against USDC and use the same decimals, so both order amounts are scaled by it.
+const USDCDecimals = 6
+
+// RawAmount converts a decimal amount to its integer on-chain representation with the
+// given number of decimals, rounding half away from zero.
+//
+// The amount is scaled from its shortest decimal form (0.0157, not 0.015699999...) using
+// exact rational arithmetic. Multiplying the float directly loses units once the product
+// exceeds 2^53, e.g. 35990671856.3 * 1e6 is 35990671856300004.
+// NaN and infinities have no raw representation and yield "0".
+func RawAmount(amount float64, decimals int) string {
+	value, ok := new(big.Rat).SetString(strconv.FormatFloat(amount, 'f', -1, 64))
+	if !ok {
+		return "0"
+	}
+
+	scale := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(decimals)), nil)
+	value.Mul(value, new(big.Rat).SetInt(scale))
+
+	quo, rem := new(big.Int).QuoRem(value.Num(), value.Denom(), new(big.Int))
+
+	// Round half away from zero: |rem| / denom >= 1/2
+	if new(big.Int).Lsh(rem.Abs(rem), 1).Cmp(value.Denom()) >= 0 {
+		if value.Sign() < 0 {
+			quo.Sub(quo, big.NewInt(1))
+		} else {
+			quo.Add(quo, big.NewInt(1))
+		}
+	}
+
+	return quo.String()
+}
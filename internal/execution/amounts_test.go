@@ -0,0 +1,71 @@
+package execution
+
+import "testing"
+
+// TestRawAmount_LargeNotional tests amounts whose scaled value exceeds float64's exact
+// integer range, where multiplying by 1e6 drifts by several units.
+func TestRawAmount_LargeNotional(t *testing.T) {
+	tests := []struct {
+		name     string
+		amount   float64
+		expected string
+	}{
+		{name: "below_2^53", amount: 4503599627.370496, expected: "4503599627370496"},
+		{name: "float_product_high", amount: 35990671856.3, expected: "35990671856300000"},
+		{name: "float_product_low", amount: 70330982796.65, expected: "70330982796650000"},
+		{name: "beyond_int64_micro_range", amount: 1e14, expected: "100000000000000000000"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := RawAmount(tt.amount, USDCDecimals)
+			if result != tt.expected {
+				t.Errorf("expected %s, got %s", tt.expected, result)
+			}
+		})
+	}
+}
+
+// TestRawAmount_FractionalCents tests sub-cent amounts and rounding at the last decimal.
+func TestRawAmount_FractionalCents(t *testing.T) {
+	tests := []struct {
+		name     string
+		amount   float64
+		expected string
+	}{
+		{name: "half_cent", amount: 0.005, expected: "5000"},
+		{name: "truncation_prone", amount: 1.005, expected: "1005000"},
+		{name: "one_micro", amount: 0.000001, expected: "1"},
+		{name: "half_micro_rounds_up", amount: 0.0000005, expected: "1"},
+		{name: "below_half_micro_rounds_down", amount: 0.0000004, expected: "0"},
+		{name: "negative_rounds_away_from_zero", amount: -0.0000005, expected: "-1"},
+		{name: "zero", amount: 0, expected: "0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := RawAmount(tt.amount, USDCDecimals)
+			if result != tt.expected {
+				t.Errorf("expected %s, got %s", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestRawAmount_Decimals(t *testing.T) {
+	tests := []struct {
+		decimals int
+		expected string
+	}{
+		{decimals: 0, expected: "2"},
+		{decimals: 2, expected: "153"},
+		{decimals: 18, expected: "1525000000000000000"},
+	}
+
+	for _, tt := range tests {
+		result := RawAmount(1.525, tt.decimals)
+		if result != tt.expected {
+			t.Errorf("decimals=%d: expected %s, got %s", tt.decimals, tt.expected, result)
+		}
+	}
+}
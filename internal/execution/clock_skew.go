@@ -0,0 +1,155 @@
+package execution
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// SetClockOffset sets the offset added to the local clock when timestamping signed
+// requests. Use it when the local clock is known to drift from the CLOB server.
+func (c *OrderClient) SetClockOffset(d time.Duration) {
+	c.clockOffset.Store(int64(d))
+}
+
+// ClockOffset returns the offset currently applied to signed request timestamps.
+func (c *OrderClient) ClockOffset() time.Duration {
+	return time.Duration(c.clockOffset.Load())
+}
+
+// now returns the local time corrected by the clock offset.
+func (c *OrderClient) now() time.Time {
+	return time.Now().Add(c.ClockOffset())
+}
+
+// isTimestampRejection reports whether an API error looks like a stale or future
+// request timestamp rather than a genuine auth failure.
+func isTimestampRejection(statusCode int, body []byte) bool {
+	if statusCode != http.StatusBadRequest && statusCode != http.StatusUnauthorized {
+		return false
+	}
+
+	return strings.Contains(strings.ToLower(string(body)), "timestamp")
+}
+
+// doSigned sends an HMAC-authenticated request and returns the status code and body.
+// If the server rejects the request timestamp and clock sync is enabled, the server
+// time from the Date header becomes the new clock offset and the request is re-signed
+// and retried once.
+func (c *OrderClient) doSigned(
+	ctx context.Context,
+	method string,
+	requestPath string,
+	body []byte,
+) (statusCode int, respBody []byte, err error) {
+	statusCode, respBody, header, err := c.sendSigned(ctx, method, requestPath, body)
+	if err != nil || !isTimestampRejection(statusCode, respBody) {
+		return statusCode, respBody, err
+	}
+
+	serverTime, parseErr := http.ParseTime(header.Get("Date"))
+	if !c.syncClockOnSkew || parseErr != nil {
+		ClockSkewRejectionsTotal.WithLabelValues("unsynced").Inc()
+		c.logger.Warn("request-timestamp-rejected",
+			zap.String("path", requestPath),
+			zap.Bool("sync-enabled", c.syncClockOnSkew),
+			zap.String("date-header", header.Get("Date")))
+		return statusCode, respBody, nil
+	}
+
+	offset := time.Until(serverTime)
+	c.SetClockOffset(offset)
+	ClockSkewRejectionsTotal.WithLabelValues("synced").Inc()
+	c.logger.Warn("clock-skew-detected-resyncing",
+		zap.String("path", requestPath),
+		zap.Duration("clock-offset", offset))
+
+	statusCode, respBody, _, err = c.sendSigned(ctx, method, requestPath, body)
+	return statusCode, respBody, err
+}
+
+// sendSigned signs and sends a single request with POLY_* auth headers.
+func (c *OrderClient) sendSigned(
+	ctx context.Context,
+	method string,
+	requestPath string,
+	body []byte,
+) (statusCode int, respBody []byte, header http.Header, err error) {
+	// The signature covers the path only; query parameters are not signed
+	signedPath, _, _ := strings.Cut(requestPath, "?")
+
+	timestamp := fmt.Sprintf("%d", c.now().Unix())
+	signaturePayload := timestamp + method + signedPath + string(body)
+
+	// Decode secret using URL-safe base64 (Python client uses urlsafe_b64decode)
+	secretBytes, err := base64.URLEncoding.DecodeString(c.secret)
+	if err != nil {
+		return 0, nil, nil, fmt.Errorf("decode secret: %w", err)
+	}
+
+	h := hmac.New(sha256.New, secretBytes)
+	h.Write([]byte(signaturePayload))
+	// Encode signature using URL-safe base64 (Python client uses urlsafe_b64encode)
+	signature := base64.URLEncoding.EncodeToString(h.Sum(nil))
+
+	var reqBody io.Reader
+	if len(body) > 0 {
+		reqBody = bytes.NewReader(body)
+	}
+
+	// Each attempt gets its own deadline, so a clock-skew retry is not starved by the first
+	ctx, cancel := context.WithTimeout(ctx, c.requestTimeout(method))
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+requestPath, reqBody)
+	if err != nil {
+		return 0, nil, nil, fmt.Errorf("create request: %w", err)
+	}
+
+	// POLY_ADDRESS header should be the EOA address (per Python client: signer.address())
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("POLY_API_KEY", c.apiKey)
+	req.Header.Set("POLY_SIGNATURE", signature)
+	req.Header.Set("POLY_TIMESTAMP", timestamp)
+	req.Header.Set("POLY_PASSPHRASE", c.passphrase)
+	req.Header.Set("POLY_ADDRESS", c.address)
+
+	httpResp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return 0, nil, nil, fmt.Errorf("send request: %w", err)
+	}
+	defer httpResp.Body.Close()
+
+	respBody, err = io.ReadAll(httpResp.Body)
+	if err != nil {
+		return 0, nil, nil, fmt.Errorf("read response: %w", err)
+	}
+
+	return httpResp.StatusCode, respBody, httpResp.Header, nil
+}
+
+// requestTimeout returns the deadline for a signed request by operation: order submissions
+// are POSTs, cancellations DELETEs and queries GETs.
+func (c *OrderClient) requestTimeout(method string) time.Duration {
+	timeout := c.queryTimeout
+	switch method {
+	case http.MethodPost:
+		timeout = c.submitTimeout
+	case http.MethodDelete:
+		timeout = c.cancelTimeout
+	}
+
+	if timeout <= 0 {
+		return DefaultRequestTimeout
+	}
+	return timeout
+}
@@ -0,0 +1,227 @@
+package execution
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/mselser95/polymarket-arb/pkg/types"
+)
+
+// skewedCLOB simulates a server whose clock runs ahead of ours by skew. Requests whose
+// POLY_TIMESTAMP is more than 30s off the server clock are rejected like the CLOB does.
+func skewedCLOB(t *testing.T, skew time.Duration, requests *atomic.Int32) *httptest.Server {
+	t.Helper()
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		serverNow := time.Now().Add(skew)
+		w.Header().Set("Date", serverNow.UTC().Format(http.TimeFormat))
+
+		ts, err := strconv.ParseInt(r.Header.Get("POLY_TIMESTAMP"), 10, 64)
+		if err != nil || r.Header.Get("POLY_SIGNATURE") == "" {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"error":"Unauthorized/Invalid api key"}`))
+			return
+		}
+
+		if d := serverNow.Sub(time.Unix(ts, 0)); d > 30*time.Second || d < -30*time.Second {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"error":"invalid timestamp"}`))
+			return
+		}
+
+		_ = json.NewEncoder(w).Encode(types.OrderQueryResponse{OrderID: "order-1", Status: "MATCHED"})
+	}))
+}
+
+func newSkewTestClient(t *testing.T, baseURL string, sync bool) *OrderClient {
+	t.Helper()
+
+	client, err := NewOrderClient(&OrderClientConfig{
+		APIKey:          "test-api-key",
+		Secret:          "dGVzdC1zZWNyZXQ=",
+		Passphrase:      "test-passphrase",
+		PrivateKey:      "0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef",
+		Logger:          zap.NewNop(),
+		BaseURL:         baseURL,
+		SyncClockOnSkew: sync,
+	})
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	return client
+}
+
+// TestDoSigned_SkewRejectionResyncsAndRetries tests that a timestamp rejection adopts the
+// server clock offset and the re-signed retry succeeds.
+func TestDoSigned_SkewRejectionResyncsAndRetries(t *testing.T) {
+	var requests atomic.Int32
+	server := skewedCLOB(t, time.Hour, &requests)
+	defer server.Close()
+
+	client := newSkewTestClient(t, server.URL, true)
+
+	resp, err := client.GetOrder(context.Background(), "order-1")
+	if err != nil {
+		t.Fatalf("expected retry with synced clock to succeed, got %v", err)
+	}
+
+	if resp.OrderID != "order-1" {
+		t.Errorf("expected order-1, got %s", resp.OrderID)
+	}
+
+	if got := requests.Load(); got != 2 {
+		t.Errorf("expected rejected request plus one retry, got %d requests", got)
+	}
+
+	// Date header has 1s resolution
+	offset := client.ClockOffset()
+	if offset < time.Hour-2*time.Second || offset > time.Hour+2*time.Second {
+		t.Errorf("expected clock offset ~1h, got %v", offset)
+	}
+
+	// Later requests are signed with the offset and succeed first time
+	_, err = client.GetOrder(context.Background(), "order-1")
+	if err != nil {
+		t.Fatalf("unexpected error after resync: %v", err)
+	}
+
+	if got := requests.Load(); got != 3 {
+		t.Errorf("expected no further retries after resync, got %d requests", got)
+	}
+}
+
+// TestDoSigned_SkewRejectionWithoutSync tests that the rejection surfaces when sync is disabled.
+func TestDoSigned_SkewRejectionWithoutSync(t *testing.T) {
+	var requests atomic.Int32
+	server := skewedCLOB(t, time.Hour, &requests)
+	defer server.Close()
+
+	client := newSkewTestClient(t, server.URL, false)
+
+	_, err := client.GetOrder(context.Background(), "order-1")
+	if err == nil {
+		t.Fatal("expected timestamp rejection error, got nil")
+	}
+
+	if got := requests.Load(); got != 1 {
+		t.Errorf("expected no retry without sync, got %d requests", got)
+	}
+
+	if client.ClockOffset() != 0 {
+		t.Errorf("expected clock offset to stay 0, got %v", client.ClockOffset())
+	}
+}
+
+// TestSetClockOffset tests that a manually set offset is applied to signed timestamps.
+func TestSetClockOffset(t *testing.T) {
+	var requests atomic.Int32
+	server := skewedCLOB(t, -10*time.Minute, &requests)
+	defer server.Close()
+
+	client := newSkewTestClient(t, server.URL, false)
+	client.SetClockOffset(-10 * time.Minute)
+
+	_, err := client.GetOrder(context.Background(), "order-1")
+	if err != nil {
+		t.Fatalf("expected request signed with offset to succeed, got %v", err)
+	}
+}
+
+// TestIsTimestampRejection tests skew detection against other API errors.
+func TestIsTimestampRejection(t *testing.T) {
+	tests := []struct {
+		name   string
+		status int
+		body   string
+		want   bool
+	}{
+		{name: "invalid-timestamp", status: http.StatusUnauthorized, body: `{"error":"invalid timestamp"}`, want: true},
+		{name: "bad-request-timestamp", status: http.StatusBadRequest, body: `{"error":"Timestamp too old"}`, want: true},
+		{name: "invalid-api-key", status: http.StatusUnauthorized, body: `{"error":"Unauthorized/Invalid api key"}`, want: false},
+		{name: "server-error-mentions-timestamp", status: http.StatusInternalServerError, body: `timestamp`, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isTimestampRejection(tt.status, []byte(tt.body)); got != tt.want {
+				t.Errorf("isTimestampRejection(%d, %q) = %v, want %v", tt.status, tt.body, got, tt.want)
+			}
+		})
+	}
+}
+
+// TestDoSigned_OperationTimeouts tests that each request gets the deadline of its
+// operation: a short timeout aborts only the operation it is configured for.
+func TestDoSigned_OperationTimeouts(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-time.After(100 * time.Millisecond):
+		case <-r.Context().Done():
+			return
+		}
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	const short = 10 * time.Millisecond
+	methods := []string{http.MethodPost, http.MethodGet, http.MethodDelete}
+
+	tests := []struct {
+		name     string
+		cfg      OrderClientConfig
+		timesOut string // Method expected to hit its deadline
+	}{
+		{name: "submit", cfg: OrderClientConfig{SubmitTimeout: short}, timesOut: http.MethodPost},
+		{name: "query", cfg: OrderClientConfig{QueryTimeout: short}, timesOut: http.MethodGet},
+		{name: "cancel", cfg: OrderClientConfig{CancelTimeout: short}, timesOut: http.MethodDelete},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := tt.cfg
+			cfg.Secret = "dGVzdC1zZWNyZXQ="
+			cfg.PrivateKey = "0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
+			cfg.Logger = zap.NewNop()
+			cfg.BaseURL = server.URL
+
+			client, err := NewOrderClient(&cfg)
+			if err != nil {
+				t.Fatalf("failed to create client: %v", err)
+			}
+
+			for _, method := range methods {
+				_, _, err := client.doSigned(context.Background(), method, "/orders", nil)
+				if method == tt.timesOut && !errors.Is(err, context.DeadlineExceeded) {
+					t.Errorf("%s: expected deadline exceeded, got %v", method, err)
+				}
+				if method != tt.timesOut && err != nil {
+					t.Errorf("%s: expected default timeout to allow the slow response, got %v", method, err)
+				}
+			}
+		})
+	}
+}
+
+func TestRequestTimeout_Default(t *testing.T) {
+	client := &OrderClient{submitTimeout: 2 * time.Second}
+
+	if got := client.requestTimeout(http.MethodPost); got != 2*time.Second {
+		t.Errorf("expected submit timeout 2s, got %s", got)
+	}
+	for _, method := range []string{http.MethodGet, http.MethodDelete} {
+		if got := client.requestTimeout(method); got != DefaultRequestTimeout {
+			t.Errorf("%s: expected default %s, got %s", method, DefaultRequestTimeout, got)
+		}
+	}
+}
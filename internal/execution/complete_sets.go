@@ -0,0 +1,200 @@
+package execution
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/mselser95/polymarket-arb/pkg/types"
+)
+
+// Complete set exit actions.
+const (
+	CompleteSetActionSell   = "sell"   // Best bids sum above $1: selling every leg beats redemption
+	CompleteSetActionRedeem = "redeem" // Merge or redeem for $1 per set
+)
+
+// CompleteSet is a position in every outcome of one market. Exactly one outcome pays
+// $1, so each set is worth $1 at resolution or when merged, whatever was paid for it.
+type CompleteSet struct {
+	MarketID   string
+	MarketSlug string
+	TokenIDs   []string
+	Sets       float64 // Smallest held size across outcomes
+	BidSum     float64 // Sum of best bids (0 if any outcome has no bid)
+	Action     string  // CompleteSetActionSell or CompleteSetActionRedeem
+}
+
+// SaleValue returns the USD received by selling every set at the best bids.
+func (s CompleteSet) SaleValue() float64 {
+	return s.Sets * s.BidSum
+}
+
+// FindCompleteSets returns the markets where every outcome is held. held maps token ID
+// to held size. A set is marked for sale when the best bids sum to more than
+// 1 + minSellEdge per set, and for redemption otherwise. books may be nil, in which
+// case every set is marked for redemption.
+func FindCompleteSets(
+	markets []*types.MarketSubscription,
+	held map[string]float64,
+	books SnapshotProvider,
+	minSellEdge float64,
+) []CompleteSet {
+	var sets []CompleteSet
+
+	for _, market := range markets {
+		if len(market.Outcomes) < 2 {
+			continue
+		}
+
+		set := CompleteSet{
+			MarketID:   market.MarketID,
+			MarketSlug: market.MarketSlug,
+			TokenIDs:   make([]string, len(market.Outcomes)),
+			Sets:       math.Inf(1),
+			Action:     CompleteSetActionRedeem,
+		}
+
+		for i, outcome := range market.Outcomes {
+			set.TokenIDs[i] = outcome.TokenID
+			set.Sets = math.Min(set.Sets, held[outcome.TokenID])
+		}
+		if set.Sets <= 0 {
+			continue
+		}
+
+		set.BidSum = bidSum(set.TokenIDs, books)
+		if set.BidSum > 1+minSellEdge {
+			set.Action = CompleteSetActionSell
+		}
+
+		sets = append(sets, set)
+	}
+
+	return sets
+}
+
+// bidSum returns the sum of best bids for tokenIDs, or 0 if any bid is missing.
+func bidSum(tokenIDs []string, books SnapshotProvider) float64 {
+	if books == nil {
+		return 0
+	}
+
+	sum := 0.0
+	for _, tokenID := range tokenIDs {
+		snapshot, ok := books.GetSnapshot(tokenID)
+		if !ok || snapshot.BestBidPrice <= 0 {
+			return 0
+		}
+		sum += snapshot.BestBidPrice
+	}
+
+	return sum
+}
+
+// HeldPositions reports held size per token ID.
+type HeldPositions interface {
+	HeldPositions(ctx context.Context) (map[string]float64, error)
+}
+
+// MarketLister lists the markets to check for complete sets.
+// discovery.Service implements this interface.
+type MarketLister interface {
+	GetSubscribedMarkets() []*types.MarketSubscription
+}
+
+// CompleteSetMonitorConfig holds complete set monitor configuration.
+type CompleteSetMonitorConfig struct {
+	Interval    time.Duration
+	Positions   HeldPositions
+	Markets     MarketLister
+	Books       SnapshotProvider // Optional: best bids for the sell decision
+	MinSellEdge float64          // USD per set the bid sum must exceed $1 by to prefer selling
+	Logger      *zap.Logger
+
+	// OnCompleteSet is called for every complete set found on each check. Selling or
+	// redeeming is left to the hook; the monitor only detects.
+	OnCompleteSet func(set CompleteSet)
+}
+
+// CompleteSetMonitor periodically checks held positions for complete sets that could be
+// exited early instead of held to resolution.
+type CompleteSetMonitor struct {
+	interval      time.Duration
+	positions     HeldPositions
+	markets       MarketLister
+	books         SnapshotProvider
+	minSellEdge   float64
+	logger        *zap.Logger
+	onCompleteSet func(set CompleteSet)
+}
+
+// NewCompleteSetMonitor creates a complete set monitor.
+func NewCompleteSetMonitor(cfg *CompleteSetMonitorConfig) (*CompleteSetMonitor, error) {
+	if cfg.Interval <= 0 {
+		return nil, fmt.Errorf("interval must be positive, got %s", cfg.Interval)
+	}
+	if cfg.Positions == nil || cfg.Markets == nil {
+		return nil, errors.New("positions and markets are required")
+	}
+
+	return &CompleteSetMonitor{
+		interval:      cfg.Interval,
+		positions:     cfg.Positions,
+		markets:       cfg.Markets,
+		books:         cfg.Books,
+		minSellEdge:   cfg.MinSellEdge,
+		logger:        cfg.Logger,
+		onCompleteSet: cfg.OnCompleteSet,
+	}, nil
+}
+
+// Run checks for complete sets every interval until ctx is canceled.
+func (m *CompleteSetMonitor) Run(ctx context.Context) error {
+	ticker := time.NewTicker(m.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+			_, err := m.Check(ctx)
+			if err != nil && ctx.Err() == nil {
+				m.logger.Warn("complete-set-check-failed", zap.Error(err))
+			}
+		}
+	}
+}
+
+// Check runs one complete set check and returns the sets found.
+func (m *CompleteSetMonitor) Check(ctx context.Context) ([]CompleteSet, error) {
+	held, err := m.positions.HeldPositions(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("held positions: %w", err)
+	}
+
+	sets := FindCompleteSets(m.markets.GetSubscribedMarkets(), held, m.books, m.minSellEdge)
+
+	for _, set := range sets {
+		CompleteSetsDetectedTotal.WithLabelValues(set.Action).Inc()
+
+		m.logger.Info("complete-set-held",
+			zap.String("market-slug", set.MarketSlug),
+			zap.Float64("sets", set.Sets),
+			zap.Float64("bid-sum", set.BidSum),
+			zap.Float64("sale-value-usd", set.SaleValue()),
+			zap.Float64("redeem-value-usd", set.Sets),
+			zap.String("action", set.Action))
+
+		if m.onCompleteSet != nil {
+			m.onCompleteSet(set)
+		}
+	}
+
+	return sets, nil
+}
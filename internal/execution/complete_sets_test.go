@@ -0,0 +1,202 @@
+package execution
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/mselser95/polymarket-arb/pkg/types"
+)
+
+// bidSnapshots serves fixed best bids by token ID.
+type bidSnapshots map[string]float64
+
+func (s bidSnapshots) GetSnapshot(tokenID string) (*types.OrderbookSnapshot, bool) {
+	bid, ok := s[tokenID]
+	if !ok {
+		return nil, false
+	}
+
+	return &types.OrderbookSnapshot{TokenID: tokenID, BestBidPrice: bid, BestBidSize: 100}, true
+}
+
+func completeSetTestMarkets() []*types.MarketSubscription {
+	return []*types.MarketSubscription{
+		{
+			MarketID:   "binary",
+			MarketSlug: "binary-slug",
+			Outcomes: []types.OutcomeToken{
+				{TokenID: "b-yes", Outcome: "Yes"},
+				{TokenID: "b-no", Outcome: "No"},
+			},
+		},
+		{
+			MarketID:   "multi",
+			MarketSlug: "multi-slug",
+			Outcomes: []types.OutcomeToken{
+				{TokenID: "m-a", Outcome: "A"},
+				{TokenID: "m-b", Outcome: "B"},
+				{TokenID: "m-c", Outcome: "C"},
+			},
+		},
+	}
+}
+
+func TestFindCompleteSets(t *testing.T) {
+	tests := []struct {
+		name       string
+		held       map[string]float64
+		books      SnapshotProvider
+		wantSlugs  []string
+		wantSets   []float64
+		wantAction []string
+	}{
+		{
+			name: "no_positions",
+			held: map[string]float64{},
+		},
+		{
+			name: "one_leg_only",
+			held: map[string]float64{"b-yes": 10, "m-a": 5, "m-b": 5},
+		},
+		{
+			name:       "binary_set_smallest_leg",
+			held:       map[string]float64{"b-yes": 10, "b-no": 7.5},
+			books:      bidSnapshots{"b-yes": 0.48, "b-no": 0.50},
+			wantSlugs:  []string{"binary-slug"},
+			wantSets:   []float64{7.5},
+			wantAction: []string{CompleteSetActionRedeem},
+		},
+		{
+			name:       "bids_above_one_plus_edge_sell",
+			held:       map[string]float64{"b-yes": 10, "b-no": 10},
+			books:      bidSnapshots{"b-yes": 0.55, "b-no": 0.47},
+			wantSlugs:  []string{"binary-slug"},
+			wantSets:   []float64{10},
+			wantAction: []string{CompleteSetActionSell},
+		},
+		{
+			name:       "bids_within_edge_redeem",
+			held:       map[string]float64{"b-yes": 10, "b-no": 10},
+			books:      bidSnapshots{"b-yes": 0.51, "b-no": 0.495},
+			wantSlugs:  []string{"binary-slug"},
+			wantSets:   []float64{10},
+			wantAction: []string{CompleteSetActionRedeem},
+		},
+		{
+			name:       "missing_bid_redeem",
+			held:       map[string]float64{"b-yes": 10, "b-no": 10},
+			books:      bidSnapshots{"b-yes": 0.90},
+			wantSlugs:  []string{"binary-slug"},
+			wantSets:   []float64{10},
+			wantAction: []string{CompleteSetActionRedeem},
+		},
+		{
+			name:       "multi_outcome_and_binary",
+			held:       map[string]float64{"b-yes": 3, "b-no": 3, "m-a": 20, "m-b": 25, "m-c": 20},
+			books:      bidSnapshots{"m-a": 0.40, "m-b": 0.35, "m-c": 0.30},
+			wantSlugs:  []string{"binary-slug", "multi-slug"},
+			wantSets:   []float64{3, 20},
+			wantAction: []string{CompleteSetActionRedeem, CompleteSetActionSell},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sets := FindCompleteSets(completeSetTestMarkets(), tt.held, tt.books, 0.01)
+
+			if len(sets) != len(tt.wantSlugs) {
+				t.Fatalf("expected %d complete sets, got %+v", len(tt.wantSlugs), sets)
+			}
+			for i, set := range sets {
+				if set.MarketSlug != tt.wantSlugs[i] {
+					t.Errorf("set %d: expected market %s, got %s", i, tt.wantSlugs[i], set.MarketSlug)
+				}
+				if !floatEquals(set.Sets, tt.wantSets[i], 1e-9) {
+					t.Errorf("set %d: expected %f sets, got %f", i, tt.wantSets[i], set.Sets)
+				}
+				if set.Action != tt.wantAction[i] {
+					t.Errorf("set %d: expected action %s, got %s", i, tt.wantAction[i], set.Action)
+				}
+			}
+		})
+	}
+}
+
+type staticPositions struct {
+	held map[string]float64
+	err  error
+}
+
+func (p staticPositions) HeldPositions(context.Context) (map[string]float64, error) {
+	return p.held, p.err
+}
+
+type staticMarkets []*types.MarketSubscription
+
+func (m staticMarkets) GetSubscribedMarkets() []*types.MarketSubscription {
+	return m
+}
+
+// TestCompleteSetMonitor_Check tests that each complete set is passed to the hook.
+func TestCompleteSetMonitor_Check(t *testing.T) {
+	var hooked []CompleteSet
+	monitor, err := NewCompleteSetMonitor(&CompleteSetMonitorConfig{
+		Interval:      time.Minute,
+		Positions:     staticPositions{held: map[string]float64{"b-yes": 10, "b-no": 10}},
+		Markets:       staticMarkets(completeSetTestMarkets()),
+		Books:         bidSnapshots{"b-yes": 0.60, "b-no": 0.45},
+		MinSellEdge:   0.01,
+		Logger:        zap.NewNop(),
+		OnCompleteSet: func(set CompleteSet) { hooked = append(hooked, set) },
+	})
+	if err != nil {
+		t.Fatalf("new monitor: %v", err)
+	}
+
+	sets, err := monitor.Check(context.Background())
+	if err != nil {
+		t.Fatalf("check: %v", err)
+	}
+
+	if len(sets) != 1 || len(hooked) != 1 {
+		t.Fatalf("expected 1 set passed to the hook, got %d sets and %d hook calls", len(sets), len(hooked))
+	}
+	if hooked[0].Action != CompleteSetActionSell || !floatEquals(hooked[0].SaleValue(), 10.5, 1e-9) {
+		t.Errorf("expected sell worth $10.50, got %s worth $%f", hooked[0].Action, hooked[0].SaleValue())
+	}
+}
+
+func TestCompleteSetMonitor_PositionsError(t *testing.T) {
+	positionsErr := errors.New("data API down")
+	monitor, err := NewCompleteSetMonitor(&CompleteSetMonitorConfig{
+		Interval:      time.Minute,
+		Positions:     staticPositions{err: positionsErr},
+		Markets:       staticMarkets(completeSetTestMarkets()),
+		Logger:        zap.NewNop(),
+		OnCompleteSet: func(CompleteSet) { t.Error("hook called without positions") },
+	})
+	if err != nil {
+		t.Fatalf("new monitor: %v", err)
+	}
+
+	_, err = monitor.Check(context.Background())
+	if !errors.Is(err, positionsErr) {
+		t.Errorf("expected positions error, got %v", err)
+	}
+}
+
+func TestNewCompleteSetMonitor_Invalid(t *testing.T) {
+	_, err := NewCompleteSetMonitor(&CompleteSetMonitorConfig{Markets: staticMarkets(nil), Positions: staticPositions{}})
+	if err == nil {
+		t.Error("expected error for zero interval")
+	}
+
+	_, err = NewCompleteSetMonitor(&CompleteSetMonitorConfig{Interval: time.Minute})
+	if err == nil {
+		t.Error("expected error without positions and markets")
+	}
+}
@@ -0,0 +1,52 @@
+package execution
+
+import (
+	"time"
+)
+
+// inCooldown reports whether marketID was executed less than marketCooldown before now.
+// Expired entries are removed so the map only holds markets still cooling down.
+func (e *Executor) inCooldown(marketID string, now time.Time) bool {
+	if e.marketCooldown <= 0 {
+		return false
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	last, ok := e.lastExecuted[marketID]
+	if !ok {
+		return false
+	}
+	if now.Sub(last) >= e.marketCooldown {
+		delete(e.lastExecuted, marketID)
+		return false
+	}
+
+	return true
+}
+
+// recordExecution starts marketID's cooldown at executedAt.
+func (e *Executor) recordExecution(marketID string, executedAt time.Time) {
+	if e.marketCooldown <= 0 {
+		return
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.lastExecuted == nil {
+		e.lastExecuted = make(map[string]time.Time)
+	}
+	e.lastExecuted[marketID] = executedAt
+}
+
+// LastExecutedAt returns when an opportunity for marketID was last executed successfully,
+// if it is still cooling down. Always false when the cooldown is disabled.
+func (e *Executor) LastExecutedAt(marketID string) (time.Time, bool) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	last, ok := e.lastExecuted[marketID]
+	return last, ok
+}
@@ -0,0 +1,72 @@
+package execution
+
+import (
+	"testing"
+	"time"
+
+	promtestutil "github.com/prometheus/client_golang/prometheus/testutil"
+	"go.uber.org/zap"
+
+	"github.com/mselser95/polymarket-arb/internal/arbitrage"
+)
+
+// TestExecute_MarketCooldown tests that a second opportunity for a market inside the
+// cooldown is skipped while other markets still execute.
+func TestExecute_MarketCooldown(t *testing.T) {
+	exec := New(&Config{Mode: "paper", Logger: zap.NewNop(), MarketCooldown: time.Minute})
+
+	skippedBefore := promtestutil.ToFloat64(OpportunitiesSkippedTotal.WithLabelValues("market_cooldown"))
+
+	first := exec.Execute(arbitrage.CreateTestOpportunity("market-a", "slug-a"))
+	if first == nil || !first.Success {
+		t.Fatalf("expected first opportunity to execute, got %+v", first)
+	}
+	if _, ok := exec.LastExecutedAt("market-a"); !ok {
+		t.Error("expected market-a to be cooling down")
+	}
+
+	if result := exec.Execute(arbitrage.CreateTestOpportunity("market-a", "slug-a")); result != nil {
+		t.Errorf("expected repeat opportunity for market-a to be skipped, got %+v", result)
+	}
+
+	other := exec.Execute(arbitrage.CreateTestOpportunity("market-b", "slug-b"))
+	if other == nil || !other.Success {
+		t.Errorf("expected market-b to execute during market-a's cooldown, got %+v", other)
+	}
+
+	if got := promtestutil.ToFloat64(OpportunitiesSkippedTotal.WithLabelValues("market_cooldown")) - skippedBefore; got != 1 {
+		t.Errorf("expected 1 market_cooldown skip, got %.0f", got)
+	}
+	if got := exec.CumulativeProfit(); !floatEquals(got, 2*testPaperProfit, 1e-5) {
+		t.Errorf("expected profit from two executions ($%.2f), got $%.2f", 2*testPaperProfit, got)
+	}
+}
+
+// TestExecute_MarketCooldownExpires tests that a market trades again once its cooldown
+// has passed, and that a zero cooldown never skips.
+func TestExecute_MarketCooldownExpires(t *testing.T) {
+	tests := []struct {
+		name     string
+		cooldown time.Duration
+		wait     time.Duration
+	}{
+		{name: "expired", cooldown: 20 * time.Millisecond, wait: 30 * time.Millisecond},
+		{name: "disabled", cooldown: 0, wait: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exec := New(&Config{Mode: "paper", Logger: zap.NewNop(), MarketCooldown: tt.cooldown})
+
+			if result := exec.Execute(arbitrage.CreateTestOpportunity("market-a", "slug-a")); result == nil {
+				t.Fatal("expected first opportunity to execute")
+			}
+
+			time.Sleep(tt.wait)
+
+			if result := exec.Execute(arbitrage.CreateTestOpportunity("market-a", "slug-a")); result == nil {
+				t.Error("expected market-a to execute again")
+			}
+		})
+	}
+}
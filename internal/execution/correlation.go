@@ -0,0 +1,96 @@
+package execution
+
+import (
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"strconv"
+	"strings"
+
+	"github.com/mselser95/polymarket-arb/internal/arbitrage"
+)
+
+// ErrInvalidCorrelationID is returned when a correlation ID is not of the form <opportunity-id>:<outcome-index>.
+var ErrInvalidCorrelationID = errors.New("invalid correlation ID")
+
+// correlationSaltMask keeps derived salts within 2^53 so they survive JSON number
+// decoding on the server exactly, like the timestamp-sized salts other clients send.
+const correlationSaltMask = 1<<53 - 1
+
+// CorrelationID identifies the order for one outcome of an opportunity. The CLOB has no
+// client order ID, so it is hashed into the order salt (see CorrelationSalt) and logged
+// alongside the order hash the server uses as order ID.
+func CorrelationID(opportunityID string, outcomeIndex int) string {
+	return opportunityID + ":" + strconv.Itoa(outcomeIndex)
+}
+
+// ParseCorrelationID splits a correlation ID back into its opportunity ID and outcome index.
+func ParseCorrelationID(id string) (opportunityID string, outcomeIndex int, err error) {
+	sep := strings.LastIndex(id, ":")
+	if sep <= 0 {
+		return "", 0, fmt.Errorf("%w: %q", ErrInvalidCorrelationID, id)
+	}
+
+	outcomeIndex, err = strconv.Atoi(id[sep+1:])
+	if err != nil || outcomeIndex < 0 {
+		return "", 0, fmt.Errorf("%w: %q", ErrInvalidCorrelationID, id)
+	}
+
+	return id[:sep], outcomeIndex, nil
+}
+
+// CorrelationSalt derives the order salt for a correlation ID. The same ID always yields
+// the same salt, so re-signing an order for the same ID reproduces its hash. The salt is a
+// one-way hash: orders are tied back to opportunities through the order-correlated log and,
+// while fills are verified, OpportunityForCorrelation.
+// Never returns 0, which the order client treats as "generate a random salt".
+func CorrelationSalt(id string) int64 {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(id))
+
+	salt := int64(h.Sum64() & correlationSaltMask)
+	if salt == 0 {
+		salt = 1
+	}
+
+	return salt
+}
+
+// registerCorrelations maps the correlation ID of each outcome order to opp while its
+// fills are being verified.
+func (e *Executor) registerCorrelations(opp *arbitrage.Opportunity) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.correlations == nil {
+		e.correlations = make(map[string]*arbitrage.Opportunity)
+	}
+	for i := range opp.Outcomes {
+		e.correlations[CorrelationID(opp.ID, i)] = opp
+	}
+}
+
+// unregisterCorrelations drops the correlation IDs of opp once fill verification ends.
+func (e *Executor) unregisterCorrelations(opp *arbitrage.Opportunity) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	for i := range opp.Outcomes {
+		delete(e.correlations, CorrelationID(opp.ID, i))
+	}
+}
+
+// OpportunityForCorrelation returns the opportunity and outcome index a live order was
+// placed for, while its fills are being verified.
+func (e *Executor) OpportunityForCorrelation(id string) (opp *arbitrage.Opportunity, outcomeIndex int, ok bool) {
+	_, outcomeIndex, err := ParseCorrelationID(id)
+	if err != nil {
+		return nil, 0, false
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	opp, ok = e.correlations[id]
+	return opp, outcomeIndex, ok
+}
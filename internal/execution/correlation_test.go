@@ -0,0 +1,157 @@
+package execution
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/mselser95/polymarket-arb/internal/arbitrage"
+	"github.com/mselser95/polymarket-arb/pkg/types"
+)
+
+func TestCorrelationID_RoundTrip(t *testing.T) {
+	tests := []struct {
+		name          string
+		opportunityID string
+		outcomeIndex  int
+	}{
+		{name: "uuid", opportunityID: "3f2b8c1e-9a4d-4e6f-8b1a-2c3d4e5f6a7b", outcomeIndex: 0},
+		{name: "multi_outcome", opportunityID: "opp-1", outcomeIndex: 11},
+		{name: "separator_in_id", opportunityID: "run:42", outcomeIndex: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			id := CorrelationID(tt.opportunityID, tt.outcomeIndex)
+			if again := CorrelationID(tt.opportunityID, tt.outcomeIndex); again != id {
+				t.Errorf("expected stable ID %q, got %q", id, again)
+			}
+
+			oppID, index, err := ParseCorrelationID(id)
+			if err != nil {
+				t.Fatalf("parse %q: %v", id, err)
+			}
+			if oppID != tt.opportunityID || index != tt.outcomeIndex {
+				t.Errorf("expected (%q, %d), got (%q, %d)", tt.opportunityID, tt.outcomeIndex, oppID, index)
+			}
+		})
+	}
+}
+
+func TestParseCorrelationID_Invalid(t *testing.T) {
+	for _, id := range []string{"", "opp-1", ":0", "opp-1:", "opp-1:x", "opp-1:-1"} {
+		_, _, err := ParseCorrelationID(id)
+		if !errors.Is(err, ErrInvalidCorrelationID) {
+			t.Errorf("%q: expected ErrInvalidCorrelationID, got %v", id, err)
+		}
+	}
+}
+
+func TestCorrelationSalt(t *testing.T) {
+	yes := CorrelationSalt(CorrelationID("opp-1", 0))
+	no := CorrelationSalt(CorrelationID("opp-1", 1))
+
+	if again := CorrelationSalt(CorrelationID("opp-1", 0)); again != yes {
+		t.Errorf("expected stable salt %d, got %d", yes, again)
+	}
+	if yes == no {
+		t.Errorf("expected distinct salts per outcome, both %d", yes)
+	}
+	for _, salt := range []int64{yes, no} {
+		if salt <= 0 || salt > correlationSaltMask {
+			t.Errorf("expected salt in (0, 2^53), got %d", salt)
+		}
+	}
+}
+
+// TestPlaceOrdersMultiOutcome_CorrelationSalt tests that correlated orders are signed
+// with the salt derived from their correlation ID.
+func TestPlaceOrdersMultiOutcome_CorrelationSalt(t *testing.T) {
+	var (
+		mu    sync.Mutex
+		salts []int64
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req types.BatchOrderRequest
+		_ = json.NewDecoder(r.Body).Decode(&req)
+
+		mu.Lock()
+		resp := make(types.BatchOrderResponse, len(req))
+		for i := range req {
+			salts = append(salts, req[i].Order.Salt)
+			resp[i] = types.OrderSubmissionResponse{Success: true, OrderID: "order-" + req[i].Order.TokenID, Status: "live"}
+		}
+		mu.Unlock()
+
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+	defer server.Close()
+
+	client := newBatchTestClient(t, server.URL, 0)
+	outcomes := batchTestOutcomes(2)
+	for i := range outcomes {
+		outcomes[i].CorrelationID = CorrelationID("opp-1", i)
+	}
+
+	_, err := client.PlaceOrdersMultiOutcome(context.Background(), outcomes, 10)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(salts) != 2 {
+		t.Fatalf("expected 2 orders, got %d", len(salts))
+	}
+	for i, salt := range salts {
+		if want := CorrelationSalt(outcomes[i].CorrelationID); salt != want {
+			t.Errorf("order %d: expected salt %d, got %d", i, want, salt)
+		}
+	}
+}
+
+// TestExecuteLive_CorrelatesOrders tests that live orders carry per-outcome correlation
+// IDs that map back to the opportunity until fill verification ends.
+func TestExecuteLive_CorrelatesOrders(t *testing.T) {
+	client := &mockLiveClient{filled: false}
+	exec := newLiveTestExecutor(client, 30*time.Second)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	err := exec.Start(ctx)
+	if err != nil {
+		t.Fatalf("start executor: %v", err)
+	}
+
+	opp := arbitrage.CreateTestOpportunity("test-market", "test-slug")
+	result := exec.executeLive(opp)
+	if !result.Success {
+		t.Fatalf("expected orders to be placed, got %v", result.Error)
+	}
+
+	if len(client.placed) != len(opp.Outcomes) {
+		t.Fatalf("expected %d placed outcomes, got %d", len(opp.Outcomes), len(client.placed))
+	}
+	for i, params := range client.placed {
+		if want := CorrelationID(opp.ID, i); params.CorrelationID != want {
+			t.Errorf("outcome %d: expected correlation ID %q, got %q", i, want, params.CorrelationID)
+		}
+
+		got, index, ok := exec.OpportunityForCorrelation(params.CorrelationID)
+		if !ok || got.ID != opp.ID || index != i {
+			t.Errorf("outcome %d: expected (%s, %d), got (%v, %d, %v)", i, opp.ID, i, got, index, ok)
+		}
+	}
+
+	// Verification is aborted by shutdown and the correlations are released
+	cancel()
+	_ = exec.Close()
+
+	if _, _, ok := exec.OpportunityForCorrelation(client.placed[0].CorrelationID); ok {
+		t.Error("expected correlation to be released after verification ended")
+	}
+}
@@ -0,0 +1,58 @@
+package execution
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/polymarket/go-order-utils/pkg/eip712"
+	"github.com/polymarket/go-order-utils/pkg/model"
+	"github.com/polymarket/go-order-utils/pkg/utils"
+)
+
+// PolygonChainID is the chain ID of Polygon mainnet, where the CTF exchange trades.
+const PolygonChainID = 137
+
+// EIP-712 domain name and version of the CTF exchange, as hashed by go-order-utils.
+var (
+	exchangeDomainName    = crypto.Keccak256Hash([]byte("Polymarket CTF Exchange"))
+	exchangeDomainVersion = crypto.Keccak256Hash([]byte("1"))
+)
+
+// ExchangeDomain is the EIP-712 domain orders are signed for.
+type ExchangeDomain struct {
+	ChainID           int64
+	VerifyingContract common.Address
+	Separator         common.Hash
+}
+
+// exchangeDomain resolves the CTF exchange domain for chainID. It fails for chains
+// without a known exchange deployment, so a misconfigured chain is caught at startup
+// rather than producing orders the CLOB rejects as badly signed.
+func exchangeDomain(chainID int64) (domain ExchangeDomain, err error) {
+	chain := big.NewInt(chainID)
+
+	contract, err := utils.GetVerifyingContractAddress(chain, model.CTFExchange)
+	if err != nil {
+		return domain, fmt.Errorf("unsupported chain ID %d: %w", chainID, err)
+	}
+
+	separator, err := eip712.BuildEIP712DomainSeparator(exchangeDomainName, exchangeDomainVersion, chain, contract)
+	if err != nil {
+		return domain, fmt.Errorf("build domain separator: %w", err)
+	}
+
+	domain = ExchangeDomain{
+		ChainID:           chainID,
+		VerifyingContract: contract,
+		Separator:         separator,
+	}
+
+	return domain, nil
+}
+
+// Domain returns the EIP-712 domain the client signs orders for.
+func (c *OrderClient) Domain() ExchangeDomain {
+	return c.domain
+}
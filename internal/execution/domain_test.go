@@ -0,0 +1,112 @@
+package execution
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/polymarket/go-order-utils/pkg/model"
+	"go.uber.org/zap"
+)
+
+// expectedDomainSeparator computes the EIP-712 domain separator from its definition,
+// independently of go-order-utils.
+func expectedDomainSeparator(chainID int64, contract common.Address) common.Hash {
+	typeHash := crypto.Keccak256([]byte("EIP712Domain(string name,string version,uint256 chainId,address verifyingContract)"))
+
+	return crypto.Keccak256Hash(
+		typeHash,
+		crypto.Keccak256([]byte("Polymarket CTF Exchange")),
+		crypto.Keccak256([]byte("1")),
+		common.LeftPadBytes(big.NewInt(chainID).Bytes(), 32),
+		common.LeftPadBytes(contract.Bytes(), 32),
+	)
+}
+
+func TestNewOrderClient_ChainID(t *testing.T) {
+	tests := []struct {
+		name         string
+		chainID      int64
+		wantChainID  int64
+		wantContract string
+		wantErr      bool
+	}{
+		{name: "default_polygon", chainID: 0, wantChainID: 137, wantContract: "0x4bFb41d5B3570DeFd03C39a9A4D8dE6Bd8B8982E"},
+		{name: "amoy", chainID: 80002, wantChainID: 80002, wantContract: "0xdFE02Eb6733538f8Ea35D585af8DE5958AD99E40"},
+		{name: "unsupported", chainID: 1, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, err := NewOrderClient(&OrderClientConfig{
+				PrivateKey: "0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef",
+				Logger:     zap.NewNop(),
+				ChainID:    tt.chainID,
+			})
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected error for unsupported chain, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("failed to create client: %v", err)
+			}
+
+			domain := client.Domain()
+			if domain.ChainID != tt.wantChainID {
+				t.Errorf("expected chain ID %d, got %d", tt.wantChainID, domain.ChainID)
+			}
+			if domain.VerifyingContract != common.HexToAddress(tt.wantContract) {
+				t.Errorf("expected verifying contract %s, got %s", tt.wantContract, domain.VerifyingContract.Hex())
+			}
+
+			want := expectedDomainSeparator(tt.wantChainID, common.HexToAddress(tt.wantContract))
+			if domain.Separator != want {
+				t.Errorf("expected domain separator %s, got %s", want.Hex(), domain.Separator.Hex())
+			}
+		})
+	}
+}
+
+// TestOrderHash_ChainID tests that the same order hashes differently per chain, so orders
+// built for Amoy are signed under the Amoy domain rather than Polygon's.
+func TestOrderHash_ChainID(t *testing.T) {
+	order := &model.Order{
+		Salt:          big.NewInt(1),
+		Maker:         common.HexToAddress("0x1111111111111111111111111111111111111111"),
+		Signer:        common.HexToAddress("0x1111111111111111111111111111111111111111"),
+		Taker:         common.Address{},
+		TokenId:       big.NewInt(1234),
+		MakerAmount:   big.NewInt(5200000),
+		TakerAmount:   big.NewInt(10000000),
+		Expiration:    big.NewInt(0),
+		Nonce:         big.NewInt(0),
+		FeeRateBps:    big.NewInt(0),
+		Side:          big.NewInt(int64(model.BUY)),
+		SignatureType: big.NewInt(int64(model.EOA)),
+	}
+
+	hashes := make(map[int64]common.Hash)
+	for _, chainID := range []int64{PolygonChainID, 80002} {
+		client, err := NewOrderClient(&OrderClientConfig{
+			PrivateKey: "0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef",
+			Logger:     zap.NewNop(),
+			ChainID:    chainID,
+		})
+		if err != nil {
+			t.Fatalf("chain %d: failed to create client: %v", chainID, err)
+		}
+
+		hash, err := client.orderBuilder.BuildOrderHash(order, model.CTFExchange)
+		if err != nil {
+			t.Fatalf("chain %d: failed to hash order: %v", chainID, err)
+		}
+		hashes[chainID] = common.Hash(hash)
+	}
+
+	if hashes[PolygonChainID] == hashes[80002] {
+		t.Error("expected order hashes to differ between Polygon and Amoy domains")
+	}
+}
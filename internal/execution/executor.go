@@ -2,6 +2,7 @@ package execution
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"math"
 	"strings"
@@ -10,21 +11,44 @@ import (
 
 	"github.com/mselser95/polymarket-arb/internal/arbitrage"
 	"github.com/mselser95/polymarket-arb/internal/circuitbreaker"
+	"github.com/mselser95/polymarket-arb/pkg/tracing"
 	"github.com/mselser95/polymarket-arb/pkg/types"
 	"go.uber.org/zap"
 )
 
 // Executor executes trades for arbitrage opportunities.
 type Executor struct {
-	mode             string // "paper" or "live"
+	mode             string // "paper", "live", or "observe"
 	logger           *zap.Logger
 	opportunityChan  <-chan *arbitrage.Opportunity
 	ctx              context.Context
 	wg               sync.WaitGroup
-	cumulativeProfit float64
+	verifyWg         sync.WaitGroup // In-flight fill verifications spawned by executeLive
+	intakeClosed     chan struct{}  // Closed by Drain to stop taking opportunities
+	closeIntakeOnce  sync.Once
+	loopDone         chan struct{} // Closed when the executionLoop started by Start exits
+	cumulativeProfit USD
 	mu               sync.Mutex
 	orderClient      OrderPlacer // For live trading (interface)
 	circuitBreaker   *circuitbreaker.BalanceCircuitBreaker
+	recordAllModes   bool // Feed paper trade sizes to the circuit breaker too
+
+	// Paper fills walk the current ask ladder instead of filling fully at the detected ask
+	paperRealisticFills bool
+
+	// Reprice-and-retry on stale-price rejections
+	snapshots          SnapshotProvider
+	maxRepriceAttempts int
+
+	// Self-trade prevention mode (off, cancel, skip)
+	selfTradePrevention string
+
+	// Order pricing strategy (ask, bid, mid)
+	pricingStrategy string
+
+	// Aggressive pricing config
+	aggressionMode           string  // "ticks" or "spread_fraction"
+	aggressionSpreadFraction float64 // Fraction of the bid-ask spread added to the ask (spread_fraction mode)
 
 	// Fill verification config
 	aggressionTicks  int
@@ -32,7 +56,53 @@ type Executor struct {
 	fillRetryInitial time.Duration
 	fillRetryMax     time.Duration
 	fillRetryMult    float64
+	fillRetryJitter  float64
+	fillMaxAttempts  int
+	fillGracePeriod  time.Duration
 	takerFee         float64
+	feeModel         arbitrage.FeeModel
+
+	// Slots for fill verifications polling at once (nil = unlimited)
+	verifySlots chan struct{}
+
+	// Stats counters (guarded by mu)
+	tradeCounts       map[string]map[string]int
+	fillVerifications map[string]int
+	realizedProfit    map[string]USD // Profit realized this run, by mode (ProfitRealizedUSD)
+
+	// Decimal places profit is reported with in logs and stats (0 = full precision)
+	profitDecimals int
+
+	// State persistence across restarts
+	stateStore         StateStore
+	checkpointInterval time.Duration
+	checkpointMu       sync.Mutex           // Serializes checkpoints
+	stopCheckpoint     chan struct{}        // Closed by Close to stop checkpointLoop
+	pendingTrades      []types.PendingTrade // Live trades awaiting fill confirmation (guarded by mu)
+
+	// Execution result history (nil = not recorded)
+	resultStore ResultStore
+
+	// Correlation ID -> opportunity for live orders awaiting fill verification (guarded by mu)
+	correlations map[string]*arbitrage.Opportunity
+
+	// Exposure guard: notional of live orders placed but not yet settled (guarded by mu)
+	maxOpenExposure float64
+	openExposure    float64
+
+	// Per-market cooldown: market ID -> last successful execution (guarded by mu)
+	marketCooldown time.Duration
+	lastExecuted   map[string]time.Time
+
+	// Markets skipped after a rejection that retrying won't fix: market ID -> skip until (guarded by mu)
+	rejectionCooldown time.Duration
+	rejectedUntil     map[string]time.Time
+
+	// Opportunities older than this at Execute are discarded (0 = disabled)
+	maxOpportunityAge time.Duration
+
+	// Opportunities awaiting execution (touched only by executionLoop)
+	queue *opportunityQueue
 }
 
 // Config holds executor configuration.
@@ -43,6 +113,27 @@ type Config struct {
 	OpportunityChannel <-chan *arbitrage.Opportunity
 	OrderClient        OrderPlacer                           // Optional: for live trading (interface)
 	CircuitBreaker     *circuitbreaker.BalanceCircuitBreaker // Optional: for balance monitoring
+	RecordAllModes     bool                                  // Record trade sizes in paper mode (balance check stays live-only; pauses apply to every mode)
+
+	// Paper fills walk the ask ladder from Snapshots (VWAP, partial fills) instead of
+	// filling fully at the detected ask. Falls back to the idealized fill without depth.
+	PaperRealisticFills bool
+
+	// Reprice-and-retry config (live only)
+	Snapshots          SnapshotProvider // Optional: current books for repricing
+	MaxRepriceAttempts int              // Resubmissions after a stale-price rejection (0 = disabled)
+
+	// Self-trade prevention (live only): off, cancel, or skip when we have open orders on target tokens
+	SelfTradePrevention string
+
+	// Order pricing (live only): "ask" (default) crosses the spread using the aggression
+	// settings below; "bid" and "mid" rest as maker orders below the ask, trading fill
+	// probability for better prices and maker fees
+	PricingStrategy string
+
+	// Aggressive pricing config
+	AggressionMode           string  // "ticks" (default) or "spread_fraction"
+	AggressionSpreadFraction float64 // Fraction of the bid-ask spread added to the ask (spread_fraction mode)
 
 	// Fill verification config
 	AggressionTicks  int
@@ -50,23 +141,140 @@ type Config struct {
 	FillRetryInitial time.Duration
 	FillRetryMax     time.Duration
 	FillRetryMult    float64
-	TakerFee         float64
+	FillRetryJitter  float64            // Random fraction added to each fill-query backoff (0 = none)
+	FillMaxAttempts  int                // Fill-query rounds before giving up, independent of FillTimeout (0 = unlimited)
+	FillGracePeriod  time.Duration      // Extra time past FillTimeout before verification is abandoned (0 = default)
+	TakerFee         float64            // Flat taker fee rate, used when FeeModel is nil
+	FeeModel         arbitrage.FeeModel // Estimates fees for fills without trade data (nil = flat TakerFee)
+
+	// Fill verifications polling the CLOB at once; the rest wait for a slot, so a burst of
+	// executions doesn't flood GetOrder (0 = default)
+	MaxConcurrentVerifications int
+
+	// Decimal places profit is reported with in logs, stats, and CumulativeProfit. Profit
+	// is always accounted in whole micro-dollars (0 = default 6)
+	ProfitReportDecimals int
+
+	// State persistence (optional): restore profit and trade counts on Start, checkpoint periodically
+	StateStore         StateStore
+	CheckpointInterval time.Duration // 0 = default
+
+	// Records every execution result (optional)
+	ResultStore ResultStore
+
+	// Reject live executions that would push unsettled notional past this (0 = unlimited)
+	MaxOpenExposureUSD float64
+
+	// Skip opportunities for a market executed successfully within this long (0 = disabled).
+	// Stops prices oscillating around the threshold from trading one market repeatedly.
+	MarketCooldown time.Duration
+
+	// Skip a market's opportunities this long after the CLOB rejects its orders with a code
+	// that retrying won't fix, e.g. an invalid tick size (0 = disabled)
+	RejectionCooldown time.Duration
+
+	// Opportunities waiting for execution are buffered and served highest net profit first
+	QueueSize   int           // Max buffered opportunities; the least profitable is dropped when full (0 = default)
+	QueueMaxAge time.Duration // Buffered opportunities older than this are evicted as stale (0 = default)
+
+	// Discard opportunities detected longer ago than this when they reach Execute, since
+	// their prices are likely stale (0 = disabled). Unlike QueueMaxAge it also covers
+	// time spent waiting on the opportunity channel and direct Execute callers.
+	MaxOpportunityAge time.Duration
 }
 
+// Aggressive pricing modes.
+const (
+	AggressionModeTicks          = "ticks"           // Ask + a fixed number of ticks
+	AggressionModeSpreadFraction = "spread_fraction" // Ask + a fraction of the bid-ask spread
+)
+
+// defaultFillGracePeriod is used when Config.FillGracePeriod is unset.
+const defaultFillGracePeriod = 10 * time.Second
+
+// unfilledCancelTimeout bounds canceling the unfilled orders of an abandoned set.
+const unfilledCancelTimeout = 10 * time.Second
+
+// defaultMaxConcurrentVerifications is used when Config.MaxConcurrentVerifications is unset.
+const defaultMaxConcurrentVerifications = 10
+
 // New creates a new trade executor.
 func New(cfg *Config) *Executor {
+	fillGracePeriod := cfg.FillGracePeriod
+	if fillGracePeriod <= 0 {
+		fillGracePeriod = defaultFillGracePeriod
+	}
+
+	aggressionMode := cfg.AggressionMode
+	if aggressionMode == "" {
+		aggressionMode = AggressionModeTicks
+	}
+
+	pricingStrategy := cfg.PricingStrategy
+	if pricingStrategy == "" {
+		pricingStrategy = PricingStrategyAsk
+	}
+
+	checkpointInterval := cfg.CheckpointInterval
+	if checkpointInterval <= 0 {
+		checkpointInterval = defaultCheckpointInterval
+	}
+
+	queueSize := cfg.QueueSize
+	if queueSize <= 0 {
+		queueSize = defaultQueueSize
+	}
+
+	queueMaxAge := cfg.QueueMaxAge
+	if queueMaxAge <= 0 {
+		queueMaxAge = defaultQueueMaxAge
+	}
+
+	maxConcurrentVerifications := cfg.MaxConcurrentVerifications
+	if maxConcurrentVerifications <= 0 {
+		maxConcurrentVerifications = defaultMaxConcurrentVerifications
+	}
+
+	profitDecimals := cfg.ProfitReportDecimals
+	if profitDecimals <= 0 {
+		profitDecimals = usdDecimals
+	}
+
 	return &Executor{
-		mode:             cfg.Mode,
-		logger:           cfg.Logger,
-		opportunityChan:  cfg.OpportunityChannel,
-		orderClient:      cfg.OrderClient,
-		circuitBreaker:   cfg.CircuitBreaker,
-		aggressionTicks:  cfg.AggressionTicks,
-		fillTimeout:      cfg.FillTimeout,
-		fillRetryInitial: cfg.FillRetryInitial,
-		fillRetryMax:     cfg.FillRetryMax,
-		fillRetryMult:    cfg.FillRetryMult,
-		takerFee:         cfg.TakerFee,
+		mode:                     cfg.Mode,
+		logger:                   cfg.Logger,
+		opportunityChan:          cfg.OpportunityChannel,
+		orderClient:              cfg.OrderClient,
+		circuitBreaker:           cfg.CircuitBreaker,
+		recordAllModes:           cfg.RecordAllModes,
+		paperRealisticFills:      cfg.PaperRealisticFills,
+		snapshots:                cfg.Snapshots,
+		maxRepriceAttempts:       cfg.MaxRepriceAttempts,
+		selfTradePrevention:      cfg.SelfTradePrevention,
+		aggressionTicks:          cfg.AggressionTicks,
+		aggressionMode:           aggressionMode,
+		pricingStrategy:          pricingStrategy,
+		aggressionSpreadFraction: cfg.AggressionSpreadFraction,
+		fillTimeout:              cfg.FillTimeout,
+		fillRetryInitial:         cfg.FillRetryInitial,
+		fillRetryMax:             cfg.FillRetryMax,
+		fillRetryMult:            cfg.FillRetryMult,
+		fillRetryJitter:          cfg.FillRetryJitter,
+		fillMaxAttempts:          cfg.FillMaxAttempts,
+		fillGracePeriod:          fillGracePeriod,
+		takerFee:                 cfg.TakerFee,
+		feeModel:                 cfg.FeeModel,
+		verifySlots:              make(chan struct{}, maxConcurrentVerifications),
+		profitDecimals:           profitDecimals,
+		stateStore:               cfg.StateStore,
+		checkpointInterval:       checkpointInterval,
+		resultStore:              cfg.ResultStore,
+		maxOpenExposure:          cfg.MaxOpenExposureUSD,
+		marketCooldown:           cfg.MarketCooldown,
+		rejectionCooldown:        cfg.RejectionCooldown,
+		maxOpportunityAge:        cfg.MaxOpportunityAge,
+		queue:                    newOpportunityQueue(queueSize, queueMaxAge),
+		intakeClosed:             make(chan struct{}),
 	}
 }
 
@@ -75,69 +283,221 @@ func (e *Executor) Start(ctx context.Context) error {
 	e.ctx = ctx
 	e.logger.Info("executor-starting", zap.String("mode", e.mode))
 
+	// Observe mode records no profit or trades, so there is nothing to persist
+	if e.stateStore != nil && e.mode != "observe" {
+		err := e.restoreState(ctx)
+		if err != nil {
+			return err
+		}
+
+		// Settle orders the previous run placed but never confirmed, before trading resumes
+		if e.mode == "live" && len(e.PendingTrades()) > 0 {
+			e.reconcilePendingTrades(ctx)
+
+			err = e.checkpoint(ctx)
+			if err != nil {
+				e.logger.Warn("execution-state-checkpoint-failed", zap.Error(err))
+			}
+		}
+
+		e.stopCheckpoint = make(chan struct{})
+		e.wg.Add(1)
+		go e.checkpointLoop()
+	}
+
+	e.loopDone = make(chan struct{})
 	e.wg.Add(1)
-	go e.executionLoop()
+	go func() {
+		defer close(e.loopDone)
+		e.executionLoop()
+	}()
 
 	return nil
 }
 
-// executionLoop processes opportunities.
+// executionLoop processes opportunities, most profitable first. Everything already
+// waiting on the channel is buffered before each execution so a better opportunity that
+// arrived meanwhile is not stuck behind a worse one.
 func (e *Executor) executionLoop() {
 	defer e.wg.Done()
 
-	for {
+	open := true
+	for open || e.queue.Len() > 0 {
+		if e.queue.Len() == 0 {
+			select {
+			case <-e.ctx.Done():
+				e.logger.Info("executor-stopping")
+				return
+			case <-e.intakeClosed:
+				e.logger.Info("executor-intake-closed")
+				return
+			case opp, ok := <-e.opportunityChan:
+				if !ok {
+					e.logger.Info("opportunity-channel-closed")
+					return
+				}
+				e.enqueue(opp)
+			}
+		}
+
+		open = e.drainOpportunities()
+
+		opp := e.dequeue()
+		if opp == nil {
+			continue
+		}
+
 		select {
 		case <-e.ctx.Done():
 			e.logger.Info("executor-stopping")
 			return
+		case <-e.intakeClosed:
+			e.logger.Info("executor-intake-closed",
+				zap.Int("abandoned-opportunities", e.queue.Len()+1))
+			return
+		default:
+		}
+
+		e.Execute(opp)
+	}
+
+	e.logger.Info("opportunity-channel-closed")
+}
+
+// drainOpportunities buffers every opportunity already waiting on the channel without
+// blocking. Returns false once the channel is closed.
+func (e *Executor) drainOpportunities() bool {
+	for {
+		select {
 		case opp, ok := <-e.opportunityChan:
 			if !ok {
-				e.logger.Info("opportunity-channel-closed")
-				return
+				return false
 			}
+			e.enqueue(opp)
+		default:
+			return true
+		}
+	}
+}
 
-			// Track opportunity received
-			OpportunitiesReceived.Inc()
+// enqueue buffers an opportunity, dropping the least profitable one when the queue is full.
+func (e *Executor) enqueue(opp *arbitrage.Opportunity) {
+	dropped := e.queue.Push(opp)
+	if dropped != nil {
+		e.logger.Debug("opportunity-dropped-queue-full",
+			zap.String("opportunity-id", dropped.ID),
+			tracing.Field(dropped.TraceID),
+			zap.String("market-slug", dropped.MarketSlug),
+			zap.Float64("net-profit", dropped.NetProfit))
+		OpportunitiesSkippedTotal.WithLabelValues("queue_full").Inc()
+	}
+	ExecutionQueueDepth.Set(float64(e.queue.Len()))
+}
 
-			// Check circuit breaker before executing
-			if e.circuitBreaker != nil && !e.circuitBreaker.IsEnabled() {
-				e.logger.Warn("skipping-opportunity-circuit-breaker-disabled",
-					zap.String("opportunity-id", opp.ID),
-					zap.String("market-slug", opp.MarketSlug),
-					zap.Float64("spread", opp.ProfitMargin))
-				OpportunitiesSkippedTotal.WithLabelValues("circuit_breaker").Inc()
-				continue
-			}
+// dequeue returns the most profitable fresh opportunity, evicting stale ones.
+func (e *Executor) dequeue() *arbitrage.Opportunity {
+	opp, stale := e.queue.Pop(time.Now())
+	for _, s := range stale {
+		e.logger.Debug("opportunity-evicted-stale",
+			zap.String("opportunity-id", s.ID),
+			tracing.Field(s.TraceID),
+			zap.String("market-slug", s.MarketSlug),
+			zap.Duration("age", time.Since(s.DetectedAt)))
+		OpportunitiesSkippedTotal.WithLabelValues("stale").Inc()
+	}
+	ExecutionQueueDepth.Set(float64(e.queue.Len()))
+	return opp
+}
 
-			start := time.Now()
-			result := e.execute(opp)
-			ExecutionDurationSeconds.Observe(time.Since(start).Seconds())
+// Execute runs one opportunity through the same checks and accounting as the execution
+// loop. Returns nil if the opportunity was skipped as expired, by the circuit breaker, or
+// because its market is cooling down.
+func (e *Executor) Execute(opp *arbitrage.Opportunity) *types.ExecutionResult {
+	// Track opportunity received
+	OpportunitiesReceived.Inc()
 
-			if result.Error != nil {
-				e.logger.Error("execution-failed",
-					zap.String("opportunity-id", opp.ID),
-					zap.Error(result.Error))
+	// Observe mode records nothing beyond what the detector already stored
+	if e.mode == "observe" {
+		return e.executeObserve(opp)
+	}
 
-				// Classify error type
-				errorType := classifyError(result.Error)
-				ExecutionErrorsTotal.Inc()
-				ExecutionErrorsByType.WithLabelValues(errorType).Inc()
-			} else {
-				// Track successful execution
-				OpportunitiesExecuted.Inc()
+	logger := e.traceLogger(opp)
 
-				e.logger.Info("execution-successful",
-					zap.String("opportunity-id", opp.ID),
-					zap.String("market-slug", opp.MarketSlug),
-					zap.Float64("profit", result.RealizedProfit))
+	if age := time.Since(opp.DetectedAt); e.maxOpportunityAge > 0 && age > e.maxOpportunityAge {
+		logger.Debug("skipping-opportunity-expired",
+			zap.String("opportunity-id", opp.ID),
+			zap.String("market-slug", opp.MarketSlug),
+			zap.Duration("age", age),
+			zap.Duration("max-age", e.maxOpportunityAge))
+		OpportunitiesExpiredTotal.Inc()
+		return nil
+	}
 
-				// Record successful trade for circuit breaker threshold calculation
-				if e.circuitBreaker != nil && e.mode == "live" {
-					e.circuitBreaker.RecordTrade(opp.MaxTradeSize)
-				}
-			}
+	// Check circuit breaker before executing: a manual pause stops every mode the breaker
+	// runs in, while the balance only matters for live orders
+	if e.circuitBreaker != nil && (e.circuitBreaker.IsPaused() || (e.mode == "live" && !e.circuitBreaker.IsEnabled())) {
+		logger.Warn("skipping-opportunity-circuit-breaker-disabled",
+			zap.String("opportunity-id", opp.ID),
+			zap.String("market-slug", opp.MarketSlug),
+			zap.Float64("spread", opp.ProfitMargin))
+		OpportunitiesSkippedTotal.WithLabelValues("circuit_breaker").Inc()
+		return nil
+	}
+
+	if e.inCooldown(opp.MarketID, time.Now()) {
+		logger.Debug("skipping-opportunity-market-cooldown",
+			zap.String("opportunity-id", opp.ID),
+			zap.String("market-slug", opp.MarketSlug),
+			zap.Duration("cooldown", e.marketCooldown))
+		OpportunitiesSkippedTotal.WithLabelValues("market_cooldown").Inc()
+		return nil
+	}
+
+	if e.marketRejected(opp.MarketID, time.Now()) {
+		logger.Debug("skipping-opportunity-market-rejected",
+			zap.String("opportunity-id", opp.ID),
+			zap.String("market-slug", opp.MarketSlug),
+			zap.Duration("rejection-cooldown", e.rejectionCooldown))
+		OpportunitiesSkippedTotal.WithLabelValues("market_rejected").Inc()
+		return nil
+	}
+
+	start := time.Now()
+	result := e.execute(opp)
+	ExecutionDurationSeconds.Observe(time.Since(start).Seconds())
+	e.storeResult(logger, result)
+
+	if result.Error != nil {
+		logger.Error("execution-failed",
+			zap.String("opportunity-id", opp.ID),
+			zap.Error(result.Error))
+
+		// Classify error type
+		errorType := classifyError(result.Error)
+		ExecutionErrorsTotal.Inc()
+		ExecutionErrorsByType.WithLabelValues(errorType).Inc()
+	} else {
+		// Track successful execution
+		OpportunitiesExecuted.Inc()
+		e.recordExecution(opp.MarketID, time.Now())
+
+		logger.Info("execution-successful",
+			zap.String("opportunity-id", opp.ID),
+			zap.String("market-slug", opp.MarketSlug),
+			zap.Float64("profit", result.RealizedProfit))
+
+		// Record successful trade notional for circuit breaker threshold calculation
+		if e.circuitBreaker != nil && (e.mode == "live" || e.recordAllModes) {
+			e.circuitBreaker.RecordTrade(result.Notional)
 		}
 	}
+
+	return result
+}
+
+// traceLogger returns the executor logger with opp's trace ID on every entry.
+func (e *Executor) traceLogger(opp *arbitrage.Opportunity) *zap.Logger {
+	return e.logger.With(tracing.Field(opp.TraceID))
 }
 
 // execute executes an arbitrage opportunity.
@@ -147,6 +507,8 @@ func (e *Executor) execute(opp *arbitrage.Opportunity) *types.ExecutionResult {
 		return e.executePaper(opp)
 	case "live":
 		return e.executeLive(opp)
+	case "observe":
+		return e.executeObserve(opp)
 	default:
 		return &types.ExecutionResult{
 			OpportunityID: opp.ID,
@@ -158,11 +520,58 @@ func (e *Executor) execute(opp *arbitrage.Opportunity) *types.ExecutionResult {
 	}
 }
 
+// executeObserve acknowledges an opportunity without placing or simulating orders.
+// The detector has already stored it; no trades, profit, or stats are recorded.
+func (e *Executor) executeObserve(opp *arbitrage.Opportunity) *types.ExecutionResult {
+	OpportunitiesObserved.Inc()
+
+	e.logger.Debug("opportunity-observed",
+		zap.String("opportunity-id", opp.ID),
+		tracing.Field(opp.TraceID),
+		zap.String("market-slug", opp.MarketSlug),
+		zap.Int("profit-bps", opp.ProfitBPS))
+
+	return &types.ExecutionResult{
+		OpportunityID: opp.ID,
+		MarketSlug:    opp.MarketSlug,
+		ExecutedAt:    time.Now(),
+		Success:       true,
+	}
+}
+
 // executePaper executes a paper trade (simulated).
 // Supports both binary (2 outcomes) and multi-outcome (3+) markets.
 func (e *Executor) executePaper(opp *arbitrage.Opportunity) *types.ExecutionResult {
+	logger := e.traceLogger(opp)
 	now := time.Now()
 
+	// Size legs the same way live orders are sized so notional is comparable
+	askPrices := opportunityAskPrices(opp)
+	tokens := tokensForBudget(opp.MaxTradeSize, askPrices)
+
+	// Legs are checked as the order client would size orders at the detected asks
+	outcomeParams := make([]types.OutcomeOrderParams, len(opp.Outcomes))
+	for i, outcome := range opp.Outcomes {
+		outcomeParams[i] = types.OutcomeOrderParams{
+			TokenID:         outcome.TokenID,
+			Price:           outcome.AskPrice,
+			TickSize:        outcome.TickSize,
+			MinSize:         outcome.MinSize,
+			TickSizeUnknown: outcome.TickSizeUnknown,
+		}
+	}
+
+	err := e.assertCompleteSet(opp, outcomeParams, tokens)
+	if err != nil {
+		return &types.ExecutionResult{
+			OpportunityID: opp.ID,
+			MarketSlug:    opp.MarketSlug,
+			ExecutedAt:    now,
+			Success:       false,
+			Error:         err,
+		}
+	}
+
 	// Simulate buying all outcomes
 	trades := make([]*types.Trade, len(opp.Outcomes))
 	for i, outcome := range opp.Outcomes {
@@ -170,7 +579,7 @@ func (e *Executor) executePaper(opp *arbitrage.Opportunity) *types.ExecutionResu
 			Outcome:   outcome.Outcome,
 			Side:      "BUY",
 			Price:     outcome.AskPrice,
-			Size:      opp.MaxTradeSize,
+			Size:      tokens,
 			Timestamp: now,
 		}
 
@@ -178,23 +587,42 @@ func (e *Executor) executePaper(opp *arbitrage.Opportunity) *types.ExecutionResu
 		TradesTotal.WithLabelValues("paper", outcome.Outcome).Inc()
 	}
 
-	// Calculate realized profit
-	realizedProfit := opp.MaxTradeSize * opp.ProfitMargin
-
-	// Update metrics
-	ProfitRealizedUSD.WithLabelValues("paper").Add(realizedProfit)
+	// Fill against current depth instead of the detected ask when the books allow it.
+	// Either way profit is the complete sets paid out less everything paid for the fills.
+	fillModel := "idealized"
+	sim := e.idealPaperFills(opp, tokens)
+	if e.paperRealisticFills {
+		realistic, ok := e.simulatePaperFills(opp, tokens)
+		if ok {
+			fillModel = "realistic"
+			sim = realistic
+			for i, leg := range sim.Legs {
+				trades[i].Price = leg.AvgPrice()
+				trades[i].Size = leg.Size
+			}
+			if sim.Partial(tokens) {
+				PaperPartialFillsTotal.Inc()
+			}
+		} else {
+			logger.Debug("paper-depth-unavailable", zap.String("market-slug", opp.MarketSlug))
+		}
+	}
+	// A tail subset is not a complete set: charge its expected loss on the filled sets
+	realizedProfit := sim.Profit - opp.SkippedAskSum()*sim.Sets
 
-	// Update cumulative profit
+	// Update cumulative profit and metrics
 	e.mu.Lock()
-	e.cumulativeProfit += realizedProfit
-	cumulativeProfit := e.cumulativeProfit
+	cumulativeProfit := e.recordProfit("paper", realizedProfit)
+	for _, outcome := range opp.Outcomes {
+		e.recordTrade("paper", outcome.Outcome)
+	}
 	e.mu.Unlock()
 
 	// Build log fields for all outcomes
 	outcomeFields := make([]zap.Field, 0, len(opp.Outcomes))
 	for i, outcome := range opp.Outcomes {
 		outcomeFields = append(outcomeFields,
-			zap.Float64(fmt.Sprintf("outcome%d-price", i+1), outcome.AskPrice),
+			zap.Float64(fmt.Sprintf("outcome%d-price", i+1), trades[i].Price),
 			zap.String(fmt.Sprintf("outcome%d-name", i+1), outcome.Outcome))
 	}
 
@@ -203,19 +631,27 @@ func (e *Executor) executePaper(opp *arbitrage.Opportunity) *types.ExecutionResu
 		zap.String("question", opp.MarketQuestion),
 		zap.Int("outcome-count", len(opp.Outcomes)),
 		zap.Float64("size", opp.MaxTradeSize),
+		zap.String("fill-model", fillModel),
+		zap.Float64("filled-sets", sim.Sets),
 		zap.Int("profit-bps", opp.ProfitBPS),
-		zap.Float64("profit-usd", realizedProfit),
-		zap.Float64("cumulative-profit-usd", cumulativeProfit),
+		zap.Float64("profit-usd", e.reportUSD(USDFromFloat(realizedProfit))),
+		zap.Float64("cumulative-profit-usd", e.reportUSD(cumulativeProfit)),
 	}
 
-	e.logger.Info("paper-trade-executed", append(baseFields, outcomeFields...)...)
+	logger.Info("paper-trade-executed", append(baseFields, outcomeFields...)...)
 
-	// Create execution result
+	// Create execution result. Both fill models pay estimated taker fees; the rest of the
+	// spread at the detected asks is slippage from walking the book and unhedged surplus fills.
+	grossProfit := sim.Sets * opp.ProfitMargin
 	result := &types.ExecutionResult{
 		OpportunityID:  opp.ID,
 		MarketSlug:     opp.MarketSlug,
 		ExecutedAt:     now,
 		RealizedProfit: realizedProfit,
+		GrossProfit:    grossProfit,
+		TotalFees:      sim.Fees,
+		Slippage:       grossProfit - sim.Fees - realizedProfit,
+		Notional:       sim.Notional,
 		Success:        true,
 		Error:          nil,
 		AllTrades:      trades, // Store all trades
@@ -245,19 +681,86 @@ func adjustPriceForAggression(askPrice, tickSize float64, aggressionTicks int) (
 	return adjustedPrice
 }
 
+// adjustPriceForSpread adjusts the ask price upward by a fraction of the bid-ask spread.
+// Unlike a fixed tick count this scales with how wide the book is: tight books are barely
+// crossed, wide ones get a larger buffer. An empty bid side or crossed book adds nothing.
+func adjustPriceForSpread(askPrice, bidPrice, tickSize, fraction float64) (adjustedPrice float64) {
+	spread := askPrice - bidPrice
+	if bidPrice <= 0 || spread < 0 {
+		spread = 0
+	}
+
+	adjustedPrice = askPrice + spread*fraction
+
+	// Cap at 0.9999 (max valid price on Polymarket)
+	if adjustedPrice > 0.9999 {
+		adjustedPrice = 0.9999
+	}
+
+	// Round to tick size boundaries
+	adjustedPrice = math.Round(adjustedPrice/tickSize) * tickSize
+
+	return adjustedPrice
+}
+
+// aggressivePrice returns the order price for an outcome under the configured aggression
+// mode, capped at the outcome's limit price.
+func (e *Executor) aggressivePrice(outcome arbitrage.OpportunityOutcome) float64 {
+	var price float64
+	if e.aggressionMode == AggressionModeSpreadFraction {
+		price = adjustPriceForSpread(outcome.AskPrice, outcome.BidPrice, outcome.TickSize, e.aggressionSpreadFraction)
+	} else {
+		price = adjustPriceForAggression(outcome.AskPrice, outcome.TickSize, e.aggressionTicks)
+	}
+
+	return clampToLimitPrice(price, outcome.LimitPrice, outcome.TickSize)
+}
+
+// clampToLimitPrice caps price at limitPrice rounded down to the tick, so the order never
+// pays more than the limit even if it then doesn't fill. A zero limit leaves price unchanged.
+func clampToLimitPrice(price, limitPrice, tickSize float64) float64 {
+	if limitPrice <= 0 || price <= limitPrice {
+		return price
+	}
+
+	if tickSize > 0 {
+		limitPrice = math.Floor(limitPrice/tickSize+roundingEpsilon) * tickSize
+	}
+
+	return limitPrice
+}
+
+// fees returns the configured fee model, defaulting to the flat taker fee.
+func (e *Executor) fees() arbitrage.FeeModel {
+	if e.feeModel != nil {
+		return e.feeModel
+	}
+	return arbitrage.FlatFeeModel{TakerRate: e.takerFee}
+}
+
 // calculateActualProfit computes profit from fill verification results.
 // Returns (actualProfit, allFilled).
 // Requires all orders to be 100% filled; partial fills return 0.0, false.
-func calculateActualProfit(fills []types.FillStatus, takerFee float64) (actualProfit float64, allFilled bool) {
+// Fees come from trade data where available and are otherwise estimated by the fee model
+// for fills on side.
+func calculateActualProfit(
+	fills []types.FillStatus,
+	fees arbitrage.FeeModel,
+	side arbitrage.FeeSide,
+) (actualProfit float64, allFilled bool) {
 	allFilled = true
 	totalCost := 0.0
 	tokenCount := 0.0
+	totalFees := 0.0
 
 	for i, fill := range fills {
 		if !fill.FullyFilled {
 			return 0.0, false // Require 100% fill
 		}
-		totalCost += fill.SizeFilled * fill.ActualPrice
+		cost := fill.SizeFilled * fill.ActualPrice
+		totalCost += cost
+
+		totalFees += fillFee(fill, fees, side)
 
 		// All outcomes should have equal token counts (arbitrage strategy)
 		if i == 0 {
@@ -267,20 +770,80 @@ func calculateActualProfit(fills []types.FillStatus, takerFee float64) (actualPr
 
 	// Revenue from winning outcome: tokenCount * $1.00
 	revenue := tokenCount
-	fees := totalCost * takerFee
-	actualProfit = revenue - totalCost - fees
+	actualProfit = revenue - totalCost - totalFees
 
 	return actualProfit, true
 }
 
+// expectedSetProfit returns the profit of buying tokens of every outcome at prices if every
+// leg fills in full: one dollar per complete set, less the cost and each leg's estimated fee.
+func expectedSetProfit(tokens float64, prices []float64, fees arbitrage.FeeModel, side arbitrage.FeeSide) float64 {
+	profit := tokens
+	for _, price := range prices {
+		profit -= tokens*price + fees.Fee(side, price, tokens)
+	}
+
+	return profit
+}
+
+// opportunityAskPrices returns the ask price of each outcome of opp.
+func opportunityAskPrices(opp *arbitrage.Opportunity) []float64 {
+	askPrices := make([]float64, len(opp.Outcomes))
+	for i, outcome := range opp.Outcomes {
+		askPrices[i] = outcome.AskPrice
+	}
+	return askPrices
+}
+
+// fillFee returns the fee charged on a fill: the fee from its trades when known, otherwise
+// the fee model's estimate for a fill on side.
+func fillFee(fill types.FillStatus, fees arbitrage.FeeModel, side arbitrage.FeeSide) float64 {
+	if fill.FeeFromTrades {
+		return fill.ActualFeePaid
+	}
+	return fees.Fee(side, fill.ActualPrice, fill.SizeFilled)
+}
+
+// setProfitBreakdown splits the profit of fully filled legs into the spread at the detected
+// ask prices, the fees paid and slippage against those asks. The components add up to the
+// profit calculateActualProfit reports for the same fills.
+func setProfitBreakdown(
+	result *types.ExecutionResult,
+	fills []types.FillStatus,
+	askPrices []float64,
+	fees arbitrage.FeeModel,
+	side arbitrage.FeeSide,
+) {
+	if len(fills) == 0 {
+		return
+	}
+
+	// Revenue from the winning outcome, as in calculateActualProfit
+	result.GrossProfit = fills[0].SizeFilled
+	result.TotalFees = 0
+	result.Slippage = 0
+
+	for i, fill := range fills {
+		askPrice := fill.ActualPrice
+		if i < len(askPrices) {
+			askPrice = askPrices[i]
+		}
+
+		result.GrossProfit -= fill.SizeFilled * askPrice
+		result.Slippage += fill.SizeFilled * (fill.ActualPrice - askPrice)
+		result.TotalFees += fillFee(fill, fees, side)
+	}
+}
+
 // executeLive executes a live trade via Polymarket CLOB API.
 // Supports both binary (2 outcomes) and multi-outcome (3+) markets.
 // All orders are submitted atomically via the batch API endpoint.
 func (e *Executor) executeLive(opp *arbitrage.Opportunity) *types.ExecutionResult {
+	logger := e.traceLogger(opp)
 	now := time.Now()
 
 	if e.orderClient == nil {
-		e.logger.Error("order-client-not-configured")
+		logger.Error("order-client-not-configured")
 		return &types.ExecutionResult{
 			OpportunityID: opp.ID,
 			MarketSlug:    opp.MarketSlug,
@@ -293,7 +856,7 @@ func (e *Executor) executeLive(opp *arbitrage.Opportunity) *types.ExecutionResul
 	// Validate all outcomes have token IDs
 	for i, outcome := range opp.Outcomes {
 		if outcome.TokenID == "" {
-			e.logger.Error("missing-token-id",
+			logger.Error("missing-token-id",
 				zap.String("opportunity-id", opp.ID),
 				zap.Int("outcome-index", i),
 				zap.String("outcome", outcome.Outcome))
@@ -315,107 +878,182 @@ func (e *Executor) executeLive(opp *arbitrage.Opportunity) *types.ExecutionResul
 			zap.Float64(fmt.Sprintf("price%d", i+1), outcome.AskPrice))
 	}
 
-	e.logger.Info("placing-multi-outcome-orders",
+	logger.Info("placing-multi-outcome-orders",
 		append([]zap.Field{
 			zap.String("market-slug", opp.MarketSlug),
 			zap.Int("outcome-count", len(opp.Outcomes)),
 			zap.Float64("size", opp.MaxTradeSize),
 		}, outcomeLogFields...)...)
 
-	// Build outcome parameters for order client with aggressive pricing
-	outcomeParams := make([]types.OutcomeOrderParams, len(opp.Outcomes))
-	adjustedPrices := make([]float64, len(opp.Outcomes))
-
-	for i, outcome := range opp.Outcomes {
-		// Adjust price upward by N ticks to jump queue and ensure fills
-		adjustedPrice := adjustPriceForAggression(outcome.AskPrice, outcome.TickSize, e.aggressionTicks)
-		adjustedPrices[i] = adjustedPrice
+	outcomeParams, adjustedPrices, tokensPerOutcome := e.buildOrderParams(opp)
 
-		outcomeParams[i] = types.OutcomeOrderParams{
-			TokenID:  outcome.TokenID,
-			Price:    adjustedPrice, // Use adjusted price, not raw ask
-			TickSize: outcome.TickSize,
-			MinSize:  outcome.MinSize,
+	err := e.assertCompleteSet(opp, outcomeParams, tokensPerOutcome)
+	if err != nil {
+		return &types.ExecutionResult{
+			OpportunityID: opp.ID,
+			MarketSlug:    opp.MarketSlug,
+			ExecutedAt:    now,
+			Success:       false,
+			Error:         err,
 		}
 	}
 
-	// Log aggressive pricing adjustment for monitoring
-	// Profit calculation is based on orderbook prices, not adjusted prices
-	// Aggressive pricing is to ensure fast fills, expecting to get filled near orderbook prices
-	originalAskSum := 0.0
-	for _, o := range opp.Outcomes {
-		originalAskSum += o.AskPrice
-	}
+	// Place orders using batch endpoint for atomic submission
+	// The trace ID rides along so the order client's logs can be tied to this opportunity
+	ctx, cancel := context.WithTimeout(tracing.WithID(e.ctx, opp.TraceID), 30*time.Second)
+	defer cancel()
 
-	adjustedAskSum := 0.0
-	for _, p := range adjustedPrices {
-		adjustedAskSum += p
-	}
+	// Our own resting orders on these tokens could match the new ones
+	err = e.preventSelfTrade(ctx, opp)
+	if err != nil {
+		if errors.Is(err, ErrSelfTrade) && e.selfTradePrevention == SelfTradePreventionSkip {
+			OpportunitiesSkippedTotal.WithLabelValues("self_trade").Inc()
+		}
 
-	e.logger.Info("aggressive-pricing-applied",
-		zap.String("opportunity-id", opp.ID),
-		zap.String("market-slug", opp.MarketSlug),
-		zap.Int("aggression-ticks", e.aggressionTicks),
-		zap.Float64("original-ask-sum", originalAskSum),
-		zap.Float64("adjusted-ask-sum", adjustedAskSum),
-		zap.Float64("adjustment", adjustedAskSum-originalAskSum))
+		return &types.ExecutionResult{
+			OpportunityID: opp.ID,
+			MarketSlug:    opp.MarketSlug,
+			ExecutedAt:    now,
+			Success:       false,
+			Error:         err,
+		}
+	}
 
-	// Convert USD budget to token count per outcome
-	// OrderClient expects token count, but opp.MaxTradeSize is in USD
-	// Calculate how many tokens we can afford at each outcome's price
-	tokensPerOutcome := opp.MaxTradeSize / adjustedPrices[0]
+	// Reserve the order cost so executions awaiting fill verification cannot together
+	// commit more than the exposure limit. Released on failure or when verification ends.
+	reserved := orderNotional(tokensPerOutcome, adjustedPrices)
+	err = e.reserveExposure(reserved)
+	if err != nil {
+		logger.Warn("skipping-opportunity-exposure-limit",
+			zap.String("opportunity-id", opp.ID),
+			zap.String("market-slug", opp.MarketSlug),
+			zap.Error(err))
+		OpportunitiesSkippedTotal.WithLabelValues("exposure_limit").Inc()
 
-	// Use the lowest affordable token count across all outcomes to ensure budget compliance
-	for _, price := range adjustedPrices {
-		maxAffordable := opp.MaxTradeSize / price
-		if maxAffordable < tokensPerOutcome {
-			tokensPerOutcome = maxAffordable
+		return &types.ExecutionResult{
+			OpportunityID: opp.ID,
+			MarketSlug:    opp.MarketSlug,
+			ExecutedAt:    now,
+			Success:       false,
+			Error:         err,
 		}
 	}
 
-	// Log token calculation for verification
-	e.logger.Info("calculated-token-count",
-		zap.String("opportunity-id", opp.ID),
-		zap.Float64("usd-budget", opp.MaxTradeSize),
-		zap.Float64("tokens-per-outcome", tokensPerOutcome))
+	verifying := false
+	defer func() {
+		if !verifying {
+			e.releaseExposure(reserved)
+		}
+	}()
 
-	// Calculate and log estimated total USD cost for validation
-	estimatedCost := 0.0
-	for i, price := range adjustedPrices {
-		cost := tokensPerOutcome * price
-		estimatedCost += cost
-		e.logger.Debug("outcome-cost-estimate",
-			zap.Int("outcome-index", i),
-			zap.Float64("price", price),
-			zap.Float64("tokens", tokensPerOutcome),
-			zap.Float64("cost-usd", cost))
+	if len(opp.SkippedOutcomes) > 0 {
+		logger.Warn("placing-tail-subset-unhedged",
+			zap.String("opportunity-id", opp.ID),
+			zap.String("market-slug", opp.MarketSlug),
+			zap.Int("skipped-outcomes", len(opp.SkippedOutcomes)),
+			zap.Float64("skipped-ask-sum", opp.SkippedAskSum()),
+			zap.String("note", "not a complete set: the stake is lost if a skipped outcome wins"))
 	}
 
-	e.logger.Info("total-cost-estimate",
-		zap.String("opportunity-id", opp.ID),
-		zap.Float64("estimated-total-usd", estimatedCost),
-		zap.Float64("max-budget-usd", opp.MaxTradeSize),
-		zap.Bool("within-budget", estimatedCost <= opp.MaxTradeSize))
-
-	// Place orders using batch endpoint for atomic submission
-	ctx, cancel := context.WithTimeout(e.ctx, 30*time.Second)
-	defer cancel()
-
 	responses, err := e.orderClient.PlaceOrdersMultiOutcome(
 		ctx,
 		outcomeParams,
 		tokensPerOutcome, // Pass token count, not USD amount
 	)
 
+	// The book may have moved since detection: reprice from fresh snapshots and resubmit
+	for attempt := 1; attempt <= e.maxRepriceAttempts && e.canReprice(responses, err); attempt++ {
+		repriced, repriceErr := e.repriceOpportunity(opp)
+		if repriceErr != nil {
+			logger.Warn("reprice-aborted",
+				zap.String("opportunity-id", opp.ID),
+				zap.String("market-slug", opp.MarketSlug),
+				zap.Int("attempt", attempt),
+				zap.Error(repriceErr))
+			RepriceAttemptsTotal.WithLabelValues("aborted").Inc()
+			break
+		}
+
+		logger.Info("repricing-after-stale-price",
+			zap.String("opportunity-id", opp.ID),
+			zap.String("market-slug", opp.MarketSlug),
+			zap.Int("attempt", attempt),
+			zap.Float64("old-price-sum", opp.TotalPriceSum),
+			zap.Float64("new-price-sum", repriced.TotalPriceSum),
+			zap.NamedError("rejection", err))
+		RepriceAttemptsTotal.WithLabelValues("retried").Inc()
+
+		opp = repriced
+		outcomeParams, adjustedPrices, tokensPerOutcome = e.buildOrderParams(opp)
+
+		// Nothing from earlier attempts is resting, so a violation just ends the execution
+		err = e.assertCompleteSet(opp, outcomeParams, tokensPerOutcome)
+		if err != nil {
+			return &types.ExecutionResult{
+				OpportunityID: opp.ID,
+				MarketSlug:    opp.MarketSlug,
+				ExecutedAt:    now,
+				Success:       false,
+				Error:         err,
+			}
+		}
+
+		// Keep the reservation in step with the repriced order cost; a costlier order set
+		// must still fit under the exposure limit
+		notional := orderNotional(tokensPerOutcome, adjustedPrices)
+		if notional > reserved {
+			err = e.reserveExposure(notional - reserved)
+			if err != nil {
+				logger.Warn("reprice-aborted-exposure-limit",
+					zap.String("opportunity-id", opp.ID),
+					zap.String("market-slug", opp.MarketSlug),
+					zap.Int("attempt", attempt),
+					zap.Error(err))
+				OpportunitiesSkippedTotal.WithLabelValues("exposure_limit").Inc()
+
+				return &types.ExecutionResult{
+					OpportunityID: opp.ID,
+					MarketSlug:    opp.MarketSlug,
+					ExecutedAt:    now,
+					Success:       false,
+					Error:         err,
+				}
+			}
+		} else {
+			e.releaseExposure(reserved - notional)
+		}
+		reserved = notional
+
+		responses, err = e.orderClient.PlaceOrdersMultiOutcome(ctx, outcomeParams, tokensPerOutcome)
+	}
+
+	if errors.Is(err, ErrBelowMinSize) {
+		// Nothing was submitted; the size shrank below the market minimum after rounding
+		logger.Warn("skipping-opportunity-below-min-size",
+			zap.String("opportunity-id", opp.ID),
+			zap.String("market-slug", opp.MarketSlug),
+			zap.Error(err))
+		OpportunitiesSkippedTotal.WithLabelValues("below_min_size").Inc()
+
+		return &types.ExecutionResult{
+			OpportunityID: opp.ID,
+			MarketSlug:    opp.MarketSlug,
+			ExecutedAt:    now,
+			Success:       false,
+			Error:         err,
+		}
+	}
+
 	if err != nil {
 		// Log detailed error information
-		e.logger.Error("multi-outcome-order-placement-failed",
+		logger.Error("multi-outcome-order-placement-failed",
 			zap.String("opportunity-id", opp.ID),
 			zap.String("market-slug", opp.MarketSlug),
 			zap.Int("outcome-count", len(opp.Outcomes)),
 			zap.Error(err))
 
 		ExecutionErrorsTotal.Inc()
+		e.handleRejection(logger, opp, responses, err)
 
 		return &types.ExecutionResult{
 			OpportunityID: opp.ID,
@@ -441,7 +1079,7 @@ func (e *Executor) executeLive(opp *arbitrage.Opportunity) *types.ExecutionResul
 
 	if len(failedOutcomes) > 0 {
 		errorMsg := strings.Join(failedOutcomes, "; ")
-		e.logger.Error("some-orders-failed",
+		logger.Error("some-orders-failed",
 			zap.String("opportunity-id", opp.ID),
 			zap.String("market-slug", opp.MarketSlug),
 			zap.Strings("failed-outcomes", failedOutcomes))
@@ -458,26 +1096,42 @@ func (e *Executor) executeLive(opp *arbitrage.Opportunity) *types.ExecutionResul
 	// Extract order IDs for fill verification
 	orderIDs := make([]string, len(responses))
 	outcomes := make([]string, len(opp.Outcomes))
-	expectedSizes := make([]float64, len(opp.Outcomes))
+	expectedSizes := e.orderSizes(outcomeParams, tokensPerOutcome) // Tokens each leg was signed for
 
 	for i, resp := range responses {
 		orderIDs[i] = resp.OrderID
 		outcomes[i] = opp.Outcomes[i].Outcome
-		expectedSizes[i] = opp.MaxTradeSize // Total USDC to spend
 	}
 
-	// Calculate expected profit based on adjusted prices
-	expectedProfit := opp.MaxTradeSize * opp.ProfitMargin
+	// Matched legs are filled on submission; delayed and live legs must be polled
+	immediateFills := make([]*types.FillStatus, len(responses))
+	for i, resp := range responses {
+		OrderSubmissionStatusTotal.WithLabelValues(submissionStatusLabel(resp)).Inc()
+		immediateFills[i] = matchedFillStatus(resp, outcomes[i], expectedSizes[i], now)
+
+		if resp.IsDelayed() {
+			logger.Info("order-delayed-polling-fill",
+				zap.String("opportunity-id", opp.ID),
+				zap.String("outcome", outcomes[i]),
+				zap.String("order-id", resp.OrderID))
+		}
+	}
+
+	// Expected profit of the signed token count at the detected asks, or at the order prices
+	// of resting maker orders, net of estimated fees and of a skipped tail's expected loss
+	expectedProfit := expectedSetProfit(expectedSizes[0], e.expectedFillPrices(opp, adjustedPrices), e.fees(), e.feeSide())
+	expectedProfit -= opp.SkippedAskSum() * expectedSizes[0]
 
 	// Build log fields for order IDs
 	orderLogFields := make([]zap.Field, 0, len(responses)*2)
 	for i, resp := range responses {
 		orderLogFields = append(orderLogFields,
 			zap.String(fmt.Sprintf("outcome%d", i+1), opp.Outcomes[i].Outcome),
-			zap.String(fmt.Sprintf("order-id%d", i+1), resp.OrderID))
+			zap.String(fmt.Sprintf("order-id%d", i+1), resp.OrderID),
+			zap.String(fmt.Sprintf("correlation-id%d", i+1), outcomeParams[i].CorrelationID))
 	}
 
-	e.logger.Info("orders-placed-verifying-fills",
+	logger.Info("orders-placed-verifying-fills",
 		append([]zap.Field{
 			zap.String("market-slug", opp.MarketSlug),
 			zap.Int("outcome-count", len(opp.Outcomes)),
@@ -486,8 +1140,39 @@ func (e *Executor) executeLive(opp *arbitrage.Opportunity) *types.ExecutionResul
 			zap.String("note", "spawning goroutine for fill verification"),
 		}, orderLogFields...)...)
 
+	// Keep the order set until fills are confirmed so a restart can reconcile it
+	e.addPendingTrade(types.PendingTrade{
+		OpportunityID: opp.ID,
+		MarketSlug:    opp.MarketSlug,
+		OrderIDs:      orderIDs,
+		Outcomes:      outcomes,
+		PlacedAt:      now,
+	})
+
+	e.registerCorrelations(opp)
+
 	// Spawn non-blocking goroutine for fill verification and metric updates
-	go e.verifyFillsAndUpdateMetrics(orderIDs, outcomes, expectedSizes, adjustedPrices, opp, expectedProfit, now)
+	verifying = true
+	e.verifyWg.Add(1)
+	go func() {
+		defer e.verifyWg.Done()
+		defer e.unregisterCorrelations(opp)
+
+		release := e.acquireVerifySlot(opp)
+		defer release()
+		settled := e.verifyFillsAndUpdateMetrics(orderIDs, outcomes, expectedSizes, immediateFills, adjustedPrices, opp, expectedProfit, now)
+
+		// Orders that may still be resting keep counting toward the cap, until the pending
+		// trade is reconciled
+		if !settled {
+			logger.Warn("exposure-held-unsettled-orders",
+				zap.String("opportunity-id", opp.ID),
+				zap.String("market-slug", opp.MarketSlug),
+				zap.Float64("notional-usd", reserved))
+			return
+		}
+		e.releaseExposure(reserved)
+	}()
 
 	// Return immediately with partial result (orders placed but not yet verified)
 	result := &types.ExecutionResult{
@@ -496,6 +1181,7 @@ func (e *Executor) executeLive(opp *arbitrage.Opportunity) *types.ExecutionResul
 		ExecutedAt:     now,
 		OrderIDs:       orderIDs,
 		ExpectedProfit: expectedProfit,
+		Notional:       reserved,
 		Success:        true, // Orders placed successfully
 		Error:          nil,
 	}
@@ -503,96 +1189,386 @@ func (e *Executor) executeLive(opp *arbitrage.Opportunity) *types.ExecutionResul
 	return result
 }
 
+// buildOrderParams applies aggressive pricing to each outcome and sizes the order
+// so every leg buys the same token count within the USD budget.
+func (e *Executor) buildOrderParams(
+	opp *arbitrage.Opportunity,
+) (outcomeParams []types.OutcomeOrderParams, adjustedPrices []float64, tokensPerOutcome float64) {
+	logger := e.traceLogger(opp)
+
+	// Build outcome parameters for order client with aggressive pricing
+	outcomeParams = make([]types.OutcomeOrderParams, len(opp.Outcomes))
+	adjustedPrices = make([]float64, len(opp.Outcomes))
+
+	for i, outcome := range opp.Outcomes {
+		// Cross the ask to ensure fills, or rest below it under a maker strategy
+		adjustedPrice := e.orderPrice(outcome)
+		adjustedPrices[i] = adjustedPrice
+
+		outcomeParams[i] = types.OutcomeOrderParams{
+			TokenID:         outcome.TokenID,
+			Price:           adjustedPrice, // Use adjusted price, not raw ask
+			TickSize:        outcome.TickSize,
+			MinSize:         outcome.MinSize,
+			TickSizeUnknown: outcome.TickSizeUnknown,
+			CorrelationID:   CorrelationID(opp.ID, i),
+		}
+	}
+
+	// Log aggressive pricing adjustment for monitoring
+	// Profit calculation is based on orderbook prices, not adjusted prices
+	// Aggressive pricing is to ensure fast fills, expecting to get filled near orderbook prices
+	originalAskSum := 0.0
+	for _, o := range opp.Outcomes {
+		originalAskSum += o.AskPrice
+	}
+
+	adjustedAskSum := 0.0
+	for _, p := range adjustedPrices {
+		adjustedAskSum += p
+	}
+
+	logger.Info("aggressive-pricing-applied",
+		zap.String("opportunity-id", opp.ID),
+		zap.String("market-slug", opp.MarketSlug),
+		zap.String("pricing-strategy", e.pricingStrategy),
+		zap.String("aggression-mode", e.aggressionMode),
+		zap.Int("aggression-ticks", e.aggressionTicks),
+		zap.Float64("aggression-spread-fraction", e.aggressionSpreadFraction),
+		zap.Float64("original-ask-sum", originalAskSum),
+		zap.Float64("adjusted-ask-sum", adjustedAskSum),
+		zap.Float64("adjustment", adjustedAskSum-originalAskSum))
+
+	// Convert USD budget to token count per outcome
+	// OrderClient expects token count, but opp.MaxTradeSize is in USD
+	tokensPerOutcome = tokensForBudget(opp.MaxTradeSize, adjustedPrices)
+
+	// Log token calculation for verification
+	logger.Info("calculated-token-count",
+		zap.String("opportunity-id", opp.ID),
+		zap.Float64("usd-budget", opp.MaxTradeSize),
+		zap.Float64("tokens-per-outcome", tokensPerOutcome))
+
+	// Calculate and log estimated total USD cost for validation
+	estimatedCost := 0.0
+	for i, price := range adjustedPrices {
+		cost := tokensPerOutcome * price
+		estimatedCost += cost
+		logger.Debug("outcome-cost-estimate",
+			zap.Int("outcome-index", i),
+			zap.Float64("price", price),
+			zap.Float64("tokens", tokensPerOutcome),
+			zap.Float64("cost-usd", cost))
+	}
+
+	logger.Info("total-cost-estimate",
+		zap.String("opportunity-id", opp.ID),
+		zap.Float64("estimated-total-usd", estimatedCost),
+		zap.Float64("max-budget-usd", opp.MaxTradeSize),
+		zap.Bool("within-budget", estimatedCost <= opp.MaxTradeSize))
+
+	return outcomeParams, adjustedPrices, tokensPerOutcome
+}
+
+// submissionStatusLabel maps a submission response status to a bounded metric label.
+func submissionStatusLabel(resp *types.OrderSubmissionResponse) string {
+	switch status := resp.NormalizedStatus(); status {
+	case types.OrderStatusMatched, types.OrderStatusLive, types.OrderStatusDelayed, types.OrderStatusUnmatched:
+		return status
+	default:
+		return "unknown"
+	}
+}
+
+// matchedFillStatus builds the fill for an order that fully matched on submission from the
+// response amounts, so it needs no polling. expectedSize is the order's token count.
+// Returns nil for delayed, live or unmatched orders, for matched orders whose amounts are
+// missing, and for orders that matched only part of expectedSize and may still fill as they
+// rest, leaving those to the fill tracker.
+func matchedFillStatus(
+	resp *types.OrderSubmissionResponse,
+	outcome string,
+	expectedSize float64,
+	matchedAt time.Time,
+) *types.FillStatus {
+	if !resp.IsMatched() {
+		return nil
+	}
+
+	making, taking, err := resp.MatchedAmounts()
+	if err != nil || taking <= 0 || taking < expectedSize-roundingEpsilon {
+		return nil
+	}
+
+	return &types.FillStatus{
+		OrderID:      resp.OrderID,
+		Outcome:      outcome,
+		Status:       types.OrderStatusMatched,
+		OriginalSize: expectedSize,
+		SizeFilled:   taking,
+		ActualPrice:  making / taking,
+		FullyFilled:  true,
+		VerifiedAt:   matchedAt,
+	}
+}
+
+// tokensForBudget returns the token count every leg can buy within budget USD,
+// i.e. the lowest affordable count across all outcome prices.
+func tokensForBudget(budget float64, prices []float64) (tokens float64) {
+	for i, price := range prices {
+		maxAffordable := budget / price
+		if i == 0 || maxAffordable < tokens {
+			tokens = maxAffordable
+		}
+	}
+
+	return tokens
+}
+
+// orderSizes returns the token count each leg is signed for when buying tokens of every
+// outcome: from the order client when it reports sizes, otherwise tokens rounded down to
+// each leg's size precision as under DirectionalRoundingPolicy.
+func (e *Executor) orderSizes(outcomeParams []types.OutcomeOrderParams, tokens float64) []float64 {
+	sizer, ok := e.orderClient.(OrderSizer)
+	if ok {
+		return sizer.OrderSizes(outcomeParams, tokens)
+	}
+
+	sizes := make([]float64, len(outcomeParams))
+	for i, outcome := range outcomeParams {
+		sizePrecision, _ := getRoundingConfig(outcome.TickSize)
+		sizes[i] = roundDown(tokens, sizePrecision)
+	}
+
+	return sizes
+}
+
+// orderNotional returns the USD cost of buying tokens of every outcome at prices.
+func orderNotional(tokens float64, prices []float64) (notional float64) {
+	for _, price := range prices {
+		notional += tokens * price
+	}
+
+	return notional
+}
+
+// acquireVerifySlot blocks until fewer than MaxConcurrentVerifications fill verifications
+// are running, and returns the function that frees the slot. A queued verification's fill
+// timeout only starts once it has a slot.
+func (e *Executor) acquireVerifySlot(opp *arbitrage.Opportunity) (release func()) {
+	if e.verifySlots == nil {
+		return func() {}
+	}
+
+	select {
+	case e.verifySlots <- struct{}{}:
+	default:
+		FillVerificationsQueued.Inc()
+		e.traceLogger(opp).Debug("fill-verification-queued",
+			zap.Int("max-concurrent", cap(e.verifySlots)))
+		e.verifySlots <- struct{}{}
+		FillVerificationsQueued.Dec()
+	}
+	FillVerificationsActive.Inc()
+
+	return func() {
+		FillVerificationsActive.Dec()
+		<-e.verifySlots
+	}
+}
+
 // verifyFillsAndUpdateMetrics runs in a goroutine to verify fills and update metrics asynchronously.
+// Legs with a non-nil entry in immediateFills matched on submission and are not polled.
+// Returns whether the order set is settled: every leg filled, or every unfilled leg
+// confirmed canceled. Until then orders may still be resting and fill.
 func (e *Executor) verifyFillsAndUpdateMetrics(
 	orderIDs []string,
 	outcomes []string,
 	expectedSizes []float64,
+	immediateFills []*types.FillStatus,
 	adjustedPrices []float64,
 	opp *arbitrage.Opportunity,
 	expectedProfit float64,
 	executedAt time.Time,
-) {
-	// Create a new context for fill verification (independent of request context)
-	ctx, cancel := context.WithTimeout(context.Background(), e.fillTimeout+10*time.Second)
+) (settled bool) {
+	logger := e.traceLogger(opp)
+
+	// Derive from the executor context rather than the request context: verification
+	// outlives executeLive's placement timeout but aborts when the executor shuts down.
+	parent := e.ctx
+	if parent == nil {
+		parent = context.Background()
+	}
+
+	ctx, cancel := context.WithTimeout(tracing.WithID(parent, opp.TraceID), e.fillTimeout+e.fillGracePeriod)
 	defer cancel()
 
-	// Create fill tracker (requires concrete OrderClient for GetOrder method)
-	// If orderClient is not a concrete *OrderClient (e.g., mock), skip fill verification
-	concreteClient, ok := e.orderClient.(*OrderClient)
-	if !ok {
-		e.logger.Warn("skipping-fill-verification-not-concrete-client",
-			zap.String("opportunity-id", opp.ID))
-		return
+	fillStatuses := make([]types.FillStatus, len(orderIDs))
+	var pending []int
+	for i := range orderIDs {
+		if i < len(immediateFills) && immediateFills[i] != nil {
+			fillStatuses[i] = *immediateFills[i]
+			continue
+		}
+		// Unverified until polled, so an abandoned set still knows which orders to cancel
+		fillStatuses[i] = types.FillStatus{OrderID: orderIDs[i], OriginalSize: expectedSizes[i]}
+		if i < len(outcomes) {
+			fillStatuses[i].Outcome = outcomes[i]
+		}
+		pending = append(pending, i)
 	}
 
-	fillTracker := NewFillTracker(
-		concreteClient,
-		e.logger,
-		&FillTrackerConfig{
-			InitialBackoff: e.fillRetryInitial,
-			MaxBackoff:     e.fillRetryMax,
-			BackoffMult:    e.fillRetryMult,
-			FillTimeout:    e.fillTimeout,
-		},
-	)
-
 	// Verify fills with exponential backoff
 	fillStartTime := time.Now()
-	fillStatuses, err := fillTracker.VerifyFills(ctx, orderIDs, outcomes, expectedSizes)
+	var err error
+	if len(pending) > 0 {
+		// Fill tracking requires an order client that can query order status
+		querier, ok := e.orderClient.(OrderQuerier)
+		if !ok {
+			logger.Warn("skipping-fill-verification-no-order-querier",
+				zap.String("opportunity-id", opp.ID))
+			return e.abandonPartialSet(parent, logger, opp, orderIDs, fillStatuses)
+		}
+
+		fillTracker := NewFillTracker(
+			querier,
+			e.logger,
+			&FillTrackerConfig{
+				InitialBackoff: e.fillRetryInitial,
+				MaxBackoff:     e.fillRetryMax,
+				BackoffMult:    e.fillRetryMult,
+				Jitter:         e.fillRetryJitter,
+				MaxAttempts:    e.fillMaxAttempts,
+				FillTimeout:    e.fillTimeout,
+			},
+		)
+
+		pendingIDs := make([]string, len(pending))
+		pendingOutcomes := make([]string, len(pending))
+		pendingSizes := make([]float64, len(pending))
+		for j, i := range pending {
+			pendingIDs[j] = orderIDs[i]
+			pendingOutcomes[j] = outcomes[i]
+			pendingSizes[j] = expectedSizes[i]
+		}
+
+		var polled []types.FillStatus
+		polled, err = fillTracker.VerifyFills(ctx, pendingIDs, pendingOutcomes, pendingSizes)
+		for j, fill := range polled {
+			fillStatuses[pending[j]] = fill
+		}
+	}
 	fillDuration := time.Since(fillStartTime)
 
 	// Update fill verification duration metric
 	FillVerificationDurationSeconds.Observe(fillDuration.Seconds())
 
+	if err != nil && parent.Err() != nil {
+		// Executor shutting down: orders may still fill, but we stop tracking them
+		logger.Warn("fill-verification-aborted-shutdown",
+			zap.String("opportunity-id", opp.ID),
+			zap.String("market-slug", opp.MarketSlug),
+			zap.Strings("order-ids", orderIDs),
+			zap.Duration("fill-duration", fillDuration))
+		e.recordFillVerification("aborted")
+		return false
+	}
+
 	if err != nil {
-		e.logger.Error("fill-verification-failed",
+		logger.Error("fill-verification-failed",
 			zap.String("opportunity-id", opp.ID),
 			zap.String("market-slug", opp.MarketSlug),
 			zap.Error(err))
-		FillVerificationTotal.WithLabelValues("error").Inc()
-		return
+		e.recordFillVerification("error")
+		return e.abandonPartialSet(parent, logger, opp, orderIDs, fillStatuses)
 	}
 
 	// Calculate actual profit from fill data
-	actualProfit, allFilled := calculateActualProfit(fillStatuses, e.takerFee)
+	actualProfit, allFilled := calculateActualProfit(fillStatuses, e.fees(), e.feeSide())
+	settled = allFilled
+
+	// Record the verified outcome next to the placement result stored by Execute
+	verified := &types.ExecutionResult{
+		OpportunityID:   opp.ID,
+		MarketSlug:      opp.MarketSlug,
+		ExecutedAt:      executedAt,
+		OrderIDs:        orderIDs,
+		FillStatuses:    fillStatuses,
+		AllOrdersFilled: allFilled,
+		ExpectedProfit:  expectedProfit,
+		VerifiedAt:      time.Now(),
+		Success:         allFilled,
+	}
+	defer e.storeResult(logger, verified)
 
 	// Update metrics and logs based on fill status
-	if allFilled {
-		FillVerificationTotal.WithLabelValues("success").Inc()
-
-		// Update profit metrics ONLY after 100% fill confirmation
-		ProfitRealizedUSD.WithLabelValues("live").Add(actualProfit)
+	if allFilled && len(opp.SkippedOutcomes) > 0 {
+		// Without its skipped tail the legs are not a complete set: each token pays $1 only
+		// if a bought outcome wins, so the fill is an unhedged position, not realized profit
+		e.recordFillVerification("unhedged")
+		e.removePendingTrade(orderIDs)
+		e.recordFilledTrades(fillStatuses)
+
+		logger.Warn("tail-subset-filled-unhedged",
+			zap.String("opportunity-id", opp.ID),
+			zap.String("market-slug", opp.MarketSlug),
+			zap.Int("skipped-outcomes", len(opp.SkippedOutcomes)),
+			zap.Float64("skipped-ask-sum", opp.SkippedAskSum()),
+			zap.Float64("expected-profit-usd", e.reportUSD(USDFromFloat(expectedProfit))),
+			zap.Float64("profit-if-bought-outcome-wins-usd", e.reportUSD(USDFromFloat(actualProfit))),
+			zap.Duration("fill-duration", fillDuration))
+	} else if allFilled {
+		verified.RealizedProfit = actualProfit
+		setProfitBreakdown(verified, fillStatuses, opportunityAskPrices(opp), e.fees(), e.feeSide())
+
+		e.recordFillVerification("success")
+		e.removePendingTrade(orderIDs)
+
+		// Slippage and fees show up as a shortfall against the detection-time estimate
+		deviation := actualProfit - expectedProfit
+		ProfitDeviationUSD.Observe(deviation)
+		if deviation < -roundingEpsilon {
+			ProfitShortfallTotal.Inc()
+		}
 
+		// Update profit ONLY after 100% fill confirmation
 		e.mu.Lock()
-		e.cumulativeProfit += actualProfit
-		cumulativeActualProfit := e.cumulativeProfit
+		cumulativeActualProfit := e.recordProfit("live", actualProfit)
 		e.mu.Unlock()
+		e.recordFilledTrades(fillStatuses)
 
-		e.logger.Info("all-orders-fully-filled",
+		logger.Info("all-orders-fully-filled",
 			zap.String("opportunity-id", opp.ID),
 			zap.String("market-slug", opp.MarketSlug),
-			zap.Float64("expected-profit-usd", expectedProfit),
-			zap.Float64("actual-profit-usd", actualProfit),
-			zap.Float64("profit-deviation-usd", actualProfit-expectedProfit),
-			zap.Float64("cumulative-actual-profit-usd", cumulativeActualProfit),
+			zap.Float64("expected-profit-usd", e.reportUSD(USDFromFloat(expectedProfit))),
+			zap.Float64("actual-profit-usd", e.reportUSD(USDFromFloat(actualProfit))),
+			zap.Float64("gross-profit-usd", e.reportUSD(USDFromFloat(verified.GrossProfit))),
+			zap.Float64("fees-usd", e.reportUSD(USDFromFloat(verified.TotalFees))),
+			zap.Float64("slippage-usd", e.reportUSD(USDFromFloat(verified.Slippage))),
+			zap.Float64("profit-deviation-usd", e.reportUSD(USDFromFloat(deviation))),
+			zap.Float64("cumulative-actual-profit-usd", e.reportUSD(cumulativeActualProfit)),
 			zap.Duration("fill-duration", fillDuration))
+	} else if hasDelayedFill(fillStatuses) {
+		// Still queued for matching when verification gave up
+		e.recordFillVerification("delayed")
 
-		// Update trade count metrics for each filled outcome
-		for _, fill := range fillStatuses {
-			if fill.FullyFilled {
-				TradesTotal.WithLabelValues("live", fill.Outcome).Inc()
-			}
-		}
+		logger.Warn("orders-still-delayed",
+			zap.String("opportunity-id", opp.ID),
+			zap.String("market-slug", opp.MarketSlug),
+			zap.Duration("fill-duration", fillDuration))
 	} else {
-		FillVerificationTotal.WithLabelValues("partial").Inc()
+		e.recordFillVerification("partial")
 
-		e.logger.Warn("orders-not-fully-filled",
+		logger.Warn("orders-not-fully-filled",
 			zap.String("opportunity-id", opp.ID),
 			zap.String("market-slug", opp.MarketSlug),
 			zap.Duration("fill-duration", fillDuration))
 	}
 
+	if !allFilled {
+		settled = e.abandonPartialSet(parent, logger, opp, orderIDs, fillStatuses)
+	}
+
 	// Track price deviation for each fill
 	for i, fill := range fillStatuses {
 		if fill.FullyFilled && i < len(adjustedPrices) {
@@ -600,19 +1576,219 @@ func (e *Executor) verifyFillsAndUpdateMetrics(
 			ActualFillPriceDeviation.Observe(deviation)
 		}
 	}
+
+	return settled
+}
+
+// abandonPartialSet gives up on a set whose verification ended without every leg filled.
+// Unfilled orders are canceled so they cannot fill after the set is abandoned, and once
+// none is left on the book the pending trade is cleared. Filled legs stay as an unhedged
+// partial set. Returns whether no unfilled leg is left on the book.
+func (e *Executor) abandonPartialSet(
+	parent context.Context,
+	logger *zap.Logger,
+	opp *arbitrage.Opportunity,
+	orderIDs []string,
+	fills []types.FillStatus,
+) (canceled bool) {
+	// Verification may have used up its own deadline, so cancels get a fresh one
+	ctx, cancel := context.WithTimeout(tracing.WithID(parent, opp.TraceID), unfilledCancelTimeout)
+	defer cancel()
+
+	canceled = e.cancelUnfilledLegs(ctx, logger, opp, fills)
+	if canceled {
+		e.removePendingTrade(orderIDs)
+	}
+
+	var filledOutcomes []string
+	var filledSizes []float64
+	for _, fill := range fills {
+		if fill.SizeFilled > 0 {
+			filledOutcomes = append(filledOutcomes, fill.Outcome)
+			filledSizes = append(filledSizes, fill.SizeFilled)
+		}
+	}
+	if len(filledOutcomes) > 0 {
+		logger.Warn("partial-set-unhedged",
+			zap.String("opportunity-id", opp.ID),
+			zap.String("market-slug", opp.MarketSlug),
+			zap.Strings("filled-outcomes", filledOutcomes),
+			zap.Float64s("filled-sizes", filledSizes),
+			zap.Bool("unfilled-orders-canceled", canceled))
+	}
+
+	return canceled
+}
+
+// cancelUnfilledLegs cancels the orders of legs left unfilled when fill verification
+// times out or gives up. Orders are GTC, so otherwise they could fill long after the set
+// was abandoned and its exposure released. Legs that failed their delayed match are no
+// longer on the book and are skipped. Returns whether no unfilled leg is left on the book.
+func (e *Executor) cancelUnfilledLegs(
+	ctx context.Context,
+	logger *zap.Logger,
+	opp *arbitrage.Opportunity,
+	fills []types.FillStatus,
+) (canceled bool) {
+	var restingIDs []string
+	for _, fill := range fills {
+		if !fill.FullyFilled && !strings.EqualFold(fill.Status, types.OrderStatusUnmatched) {
+			restingIDs = append(restingIDs, fill.OrderID)
+		}
+	}
+	if len(restingIDs) == 0 {
+		return true
+	}
+
+	manager, ok := e.orderClient.(OpenOrderManager)
+	if !ok {
+		UnfilledOrderCancelsTotal.WithLabelValues("failed").Add(float64(len(restingIDs)))
+		logger.Error("unfilled-orders-not-canceled",
+			zap.String("opportunity-id", opp.ID),
+			zap.String("market-slug", opp.MarketSlug),
+			zap.Strings("order-ids", restingIDs),
+			zap.String("note", "order client cannot cancel orders"))
+		return false
+	}
+
+	result, err := manager.CancelOrders(ctx, restingIDs)
+	if err != nil {
+		UnfilledOrderCancelsTotal.WithLabelValues("failed").Add(float64(len(restingIDs)))
+		logger.Error("unfilled-orders-cancel-failed",
+			zap.String("opportunity-id", opp.ID),
+			zap.String("market-slug", opp.MarketSlug),
+			zap.Strings("order-ids", restingIDs),
+			zap.Error(err))
+		return false
+	}
+
+	UnfilledOrderCancelsTotal.WithLabelValues("canceled").Add(float64(len(restingIDs) - len(result.NotCanceled)))
+	if len(result.NotCanceled) > 0 {
+		UnfilledOrderCancelsTotal.WithLabelValues("failed").Add(float64(len(result.NotCanceled)))
+		logger.Error("unfilled-orders-not-canceled",
+			zap.String("opportunity-id", opp.ID),
+			zap.String("market-slug", opp.MarketSlug),
+			zap.Any("not-canceled", result.NotCanceled))
+		return false
+	}
+
+	logger.Warn("unfilled-orders-canceled",
+		zap.String("opportunity-id", opp.ID),
+		zap.String("market-slug", opp.MarketSlug),
+		zap.Strings("order-ids", restingIDs))
+
+	return true
+}
+
+// recordFilledTrades counts a live trade for every fully filled leg.
+func (e *Executor) recordFilledTrades(fillStatuses []types.FillStatus) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	for _, fill := range fillStatuses {
+		if fill.FullyFilled {
+			e.recordTrade("live", fill.Outcome)
+			TradesTotal.WithLabelValues("live", fill.Outcome).Inc()
+		}
+	}
+}
+
+// hasDelayedFill reports whether any unfilled order was last seen queued for delayed matching.
+func hasDelayedFill(fills []types.FillStatus) bool {
+	for _, fill := range fills {
+		if !fill.FullyFilled && strings.EqualFold(fill.Status, types.OrderStatusDelayed) {
+			return true
+		}
+	}
+	return false
+}
+
+// CumulativeProfit returns the total realized profit across all executions, rounded to
+// the report precision.
+func (e *Executor) CumulativeProfit() float64 {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	return e.reportUSD(e.cumulativeProfit)
+}
+
+// WaitForFills blocks until all in-flight live fill verifications have finished.
+func (e *Executor) WaitForFills() {
+	e.verifyWg.Wait()
+}
+
+// Drain stops taking new opportunities and waits for the execution in progress and all
+// in-flight fill verifications to finish, so shutdown does not abandon live orders.
+// Opportunities still buffered are discarded. Returns an error if ctx ends first.
+//
+// Verifications abort when the context passed to Start is canceled, so call Drain
+// before canceling it, and Close afterwards.
+func (e *Executor) Drain(ctx context.Context) error {
+	e.closeIntakeOnce.Do(func() { close(e.intakeClosed) })
+	e.logger.Info("executor-draining")
+
+	// Without Start there is no execution loop to wait for
+	if e.loopDone != nil {
+		select {
+		case <-e.loopDone:
+		case <-ctx.Done():
+			return fmt.Errorf("wait for execution loop: %w", ctx.Err())
+		}
+	}
+
+	verifyDone := make(chan struct{})
+	go func() {
+		e.verifyWg.Wait()
+		close(verifyDone)
+	}()
+
+	select {
+	case <-verifyDone:
+		e.logger.Info("executor-drained")
+		return nil
+	case <-ctx.Done():
+		e.logger.Warn("fill-verifications-still-running-at-drain-deadline")
+		return fmt.Errorf("wait for fill verifications: %w", ctx.Err())
+	}
 }
 
 // Close gracefully closes the executor.
 func (e *Executor) Close() error {
 	e.logger.Info("closing-executor")
+	if e.stopCheckpoint != nil {
+		close(e.stopCheckpoint)
+	}
 	e.wg.Wait()
 
+	// Wait for in-flight fill verifications so they don't update profit after Close.
+	// Each verification is bounded by its own timeout; this bound is a backstop.
+	verifyDone := make(chan struct{})
+	go func() {
+		e.verifyWg.Wait()
+		close(verifyDone)
+	}()
+
+	select {
+	case <-verifyDone:
+	case <-time.After(e.fillTimeout + e.fillGracePeriod):
+		e.logger.Warn("fill-verifications-still-running-at-close",
+			zap.Duration("waited", e.fillTimeout+e.fillGracePeriod))
+	}
+
+	// Final checkpoint so the next run starts from the latest totals
+	if e.stopCheckpoint != nil {
+		err := e.checkpoint(context.Background())
+		if err != nil {
+			e.logger.Warn("final-execution-state-checkpoint-failed", zap.Error(err))
+		}
+	}
+
 	e.mu.Lock()
 	finalProfit := e.cumulativeProfit
 	e.mu.Unlock()
 
 	e.logger.Info("executor-closed",
-		zap.Float64("total-profit-usd", finalProfit),
+		zap.Float64("total-profit-usd", e.reportUSD(finalProfit)),
 		zap.String("mode", e.mode))
 
 	return nil
@@ -624,6 +1800,10 @@ func classifyError(err error) string {
 		return "unknown"
 	}
 
+	if errors.Is(err, ErrBelowMinSize) {
+		return "below_min_size"
+	}
+
 	errMsg := strings.ToLower(err.Error())
 
 	// Network errors
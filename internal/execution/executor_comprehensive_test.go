@@ -8,6 +8,7 @@ import (
 
 	"github.com/mselser95/polymarket-arb/internal/arbitrage"
 	"github.com/mselser95/polymarket-arb/pkg/types"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"go.uber.org/zap/zaptest"
 )
 
@@ -71,6 +72,74 @@ func TestExecute_LiveMode(t *testing.T) {
 	}
 }
 
+// TestExecute_ObserveMode tests that observe mode neither places nor simulates orders
+func TestExecute_ObserveMode(t *testing.T) {
+	t.Parallel()
+
+	logger := zaptest.NewLogger(t)
+	exec := &Executor{
+		mode:   "observe",
+		logger: logger,
+	}
+
+	opp := arbitrage.CreateTestOpportunity("test-market", "test-slug")
+	result := exec.execute(opp)
+
+	if result == nil {
+		t.Fatal("expected non-nil result")
+	}
+
+	if !result.Success || result.Error != nil {
+		t.Errorf("expected observe to succeed, got success=%v err=%v", result.Success, result.Error)
+	}
+
+	if result.RealizedProfit != 0 || len(result.AllTrades) != 0 || len(result.OrderIDs) != 0 {
+		t.Errorf("expected no trades or profit, got %+v", result)
+	}
+
+	stats := exec.Stats()
+	if stats.CumulativeProfit != 0 || stats.TotalFilledLegs != 0 {
+		t.Errorf("expected empty stats in observe mode, got %+v", stats)
+	}
+}
+
+// TestExecutionLoop_ObserveMode tests that observed opportunities are counted
+// but never executed. Not parallel: asserts global metrics.
+func TestExecutionLoop_ObserveMode(t *testing.T) {
+	oppChan := make(chan *arbitrage.Opportunity, 5)
+	exec := New(&Config{
+		Mode:               "observe",
+		Logger:             zaptest.NewLogger(t),
+		OpportunityChannel: oppChan,
+	})
+
+	observedBefore := testutil.ToFloat64(OpportunitiesObserved)
+	executedBefore := testutil.ToFloat64(OpportunitiesExecuted)
+
+	err := exec.Start(context.Background())
+	if err != nil {
+		t.Fatalf("start executor: %v", err)
+	}
+
+	for i := 0; i < 3; i++ {
+		oppChan <- arbitrage.CreateTestOpportunity(fmt.Sprintf("market-%d", i), "test-slug")
+	}
+	close(oppChan)
+	_ = exec.Close()
+
+	if got := testutil.ToFloat64(OpportunitiesObserved) - observedBefore; got != 3 {
+		t.Errorf("expected 3 observed opportunities, got %v", got)
+	}
+
+	if got := testutil.ToFloat64(OpportunitiesExecuted) - executedBefore; got != 0 {
+		t.Errorf("expected no executed opportunities, got %v", got)
+	}
+
+	if profit := exec.CumulativeProfit(); profit != 0 {
+		t.Errorf("expected no profit in observe mode, got %f", profit)
+	}
+}
+
 // TestExecute_UnknownMode tests error on invalid mode
 func TestExecute_UnknownMode(t *testing.T) {
 	t.Parallel()
@@ -232,14 +301,15 @@ func TestExecutePaper_MultiOutcome(t *testing.T) {
 				t.Errorf("expected %d trades, got %d", tt.expectedTrades, len(result.AllTrades))
 			}
 
-			// Verify each trade
+			// Verify each trade: every leg buys the tokens the budget affords at the highest ask
+			wantTokens := tokensForBudget(tt.maxTradeSize, opportunityAskPrices(opp))
 			for i, trade := range result.AllTrades {
 				if trade.Side != "BUY" {
 					t.Errorf("trade %d: expected BUY side, got %s", i, trade.Side)
 				}
 
-				if trade.Size != tt.maxTradeSize {
-					t.Errorf("trade %d: expected size %f, got %f", i, tt.maxTradeSize, trade.Size)
+				if !floatEquals(trade.Size, wantTokens, 1e-9) {
+					t.Errorf("trade %d: expected size %f, got %f", i, wantTokens, trade.Size)
 				}
 
 				if trade.Outcome == "" {
@@ -264,19 +334,19 @@ func TestExecutePaper_ProfitCalculation(t *testing.T) {
 			name:           "1%-margin",
 			maxTradeSize:   100.0,
 			profitMargin:   0.01,
-			expectedProfit: 1.0, // 100 * 0.01
+			expectedProfit: 2.0, // 100/0.50 tokens * 0.01
 		},
 		{
 			name:           "5%-margin",
 			maxTradeSize:   50.0,
 			profitMargin:   0.05,
-			expectedProfit: 2.5, // 50 * 0.05
+			expectedProfit: 5.0, // 50/0.50 tokens * 0.05
 		},
 		{
 			name:           "0.5%-margin",
 			maxTradeSize:   200.0,
 			profitMargin:   0.005,
-			expectedProfit: 1.0, // 200 * 0.005
+			expectedProfit: 2.0, // 200/0.50 tokens * 0.005
 		},
 	}
 
@@ -296,7 +366,7 @@ func TestExecutePaper_ProfitCalculation(t *testing.T) {
 				MaxTradeSize:   tt.maxTradeSize,
 				ProfitMargin:   tt.profitMargin,
 				Outcomes: []arbitrage.OpportunityOutcome{
-					{Outcome: "YES", AskPrice: 0.49, AskSize: 100},
+					{Outcome: "YES", AskPrice: 0.50 - tt.profitMargin, AskSize: 100},
 					{Outcome: "NO", AskPrice: 0.50, AskSize: 100},
 				},
 			}
@@ -325,9 +395,9 @@ func TestExecutePaper_CumulativeProfit(t *testing.T) {
 		maxTradeSize float64
 		profitMargin float64
 	}{
-		{100.0, 0.01}, // +1.0
-		{50.0, 0.02},  // +1.0
-		{200.0, 0.005}, // +1.0
+		{100.0, 0.01},  // +2.0: 200 tokens
+		{50.0, 0.02},   // +2.0: 100 tokens
+		{200.0, 0.005}, // +2.0: 400 tokens
 	}
 
 	expectedCumulative := 0.0
@@ -340,7 +410,7 @@ func TestExecutePaper_CumulativeProfit(t *testing.T) {
 			MaxTradeSize: trade.maxTradeSize,
 			ProfitMargin: trade.profitMargin,
 			Outcomes: []arbitrage.OpportunityOutcome{
-				{Outcome: "YES", AskPrice: 0.49},
+				{Outcome: "YES", AskPrice: 0.50 - trade.profitMargin},
 				{Outcome: "NO", AskPrice: 0.50},
 			},
 		}
@@ -351,7 +421,7 @@ func TestExecutePaper_CumulativeProfit(t *testing.T) {
 
 		// Check current cumulative
 		exec.mu.Lock()
-		actual := exec.cumulativeProfit
+		actual := exec.cumulativeProfit.Float64()
 		exec.mu.Unlock()
 
 		if !floatEquals(actual, expectedCumulative, 0.0001) {
@@ -359,13 +429,13 @@ func TestExecutePaper_CumulativeProfit(t *testing.T) {
 		}
 	}
 
-	// Final cumulative should be 3.0
+	// Final cumulative should be 6.0
 	exec.mu.Lock()
-	final := exec.cumulativeProfit
+	final := exec.cumulativeProfit.Float64()
 	exec.mu.Unlock()
 
-	if !floatEquals(final, 3.0, 0.0001) {
-		t.Errorf("expected final cumulative 3.0, got %f", final)
+	if !floatEquals(final, 6.0, 0.0001) {
+		t.Errorf("expected final cumulative 6.0, got %f", final)
 	}
 }
 
@@ -567,6 +637,152 @@ func TestAdjustPriceForAggression(t *testing.T) {
 	}
 }
 
+// TestAggressivePrice_Modes compares fixed-tick and spread-fraction pricing across tick sizes
+func TestAggressivePrice_Modes(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		askPrice      float64
+		bidPrice      float64
+		tickSize      float64
+		wantTicks     float64 // 5 ticks
+		wantSpreadFrc float64 // 0.4 of spread
+	}{
+		{
+			name:          "tight-book-0.01-tick",
+			askPrice:      0.50,
+			bidPrice:      0.49,
+			tickSize:      0.01,
+			wantTicks:     0.55, // 5 ticks over-pays a one-tick spread
+			wantSpreadFrc: 0.50, // 0.004 rounds back to the ask
+		},
+		{
+			name:          "wide-book-0.01-tick",
+			askPrice:      0.50,
+			bidPrice:      0.30,
+			tickSize:      0.01,
+			wantTicks:     0.55,
+			wantSpreadFrc: 0.58, // +0.08
+		},
+		{
+			name:          "tight-book-0.001-tick",
+			askPrice:      0.500,
+			bidPrice:      0.495,
+			tickSize:      0.001,
+			wantTicks:     0.505,
+			wantSpreadFrc: 0.502, // +0.002
+		},
+		{
+			name:          "wide-book-0.001-tick",
+			askPrice:      0.500,
+			bidPrice:      0.400,
+			tickSize:      0.001,
+			wantTicks:     0.505, // 5 small ticks under-fill a wide book
+			wantSpreadFrc: 0.540, // +0.04
+		},
+		{
+			name:          "empty-bid-side",
+			askPrice:      0.50,
+			bidPrice:      0,
+			tickSize:      0.01,
+			wantTicks:     0.55,
+			wantSpreadFrc: 0.50,
+		},
+		{
+			name:          "crossed-book",
+			askPrice:      0.50,
+			bidPrice:      0.52,
+			tickSize:      0.01,
+			wantTicks:     0.55,
+			wantSpreadFrc: 0.50,
+		},
+		{
+			name:          "capped-at-0.9999",
+			askPrice:      0.99,
+			bidPrice:      0.50,
+			tickSize:      0.01,
+			wantTicks:     1.00, // capped to 0.9999, rounded to 1.00
+			wantSpreadFrc: 1.00,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			outcome := arbitrage.OpportunityOutcome{
+				AskPrice: tt.askPrice,
+				BidPrice: tt.bidPrice,
+				TickSize: tt.tickSize,
+			}
+
+			ticksExec := New(&Config{Mode: "live", Logger: zaptest.NewLogger(t), AggressionTicks: 5})
+			if got := ticksExec.aggressivePrice(outcome); !floatEquals(got, tt.wantTicks, 0.00001) {
+				t.Errorf("ticks mode: expected %f, got %f", tt.wantTicks, got)
+			}
+
+			spreadExec := New(&Config{
+				Mode:                     "live",
+				Logger:                   zaptest.NewLogger(t),
+				AggressionTicks:          5, // Ignored in spread_fraction mode
+				AggressionMode:           AggressionModeSpreadFraction,
+				AggressionSpreadFraction: 0.4,
+			})
+			if got := spreadExec.aggressivePrice(outcome); !floatEquals(got, tt.wantSpreadFrc, 0.00001) {
+				t.Errorf("spread_fraction mode: expected %f, got %f", tt.wantSpreadFrc, got)
+			}
+		})
+	}
+}
+
+// TestAggressivePrice_LimitPrice tests that a limit price caps aggression in both modes.
+func TestAggressivePrice_LimitPrice(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		askPrice   float64
+		limitPrice float64
+		want       float64
+	}{
+		{name: "no-limit", askPrice: 0.50, limitPrice: 0, want: 0.55},
+		{name: "limit-above-aggression", askPrice: 0.50, limitPrice: 0.60, want: 0.55},
+		{name: "aggression-clamped", askPrice: 0.50, limitPrice: 0.52, want: 0.52},
+		{name: "off-tick-limit-rounds-down", askPrice: 0.50, limitPrice: 0.527, want: 0.52},
+		{name: "limit-below-ask", askPrice: 0.50, limitPrice: 0.48, want: 0.48}, // Won't fill, by design
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			outcome := arbitrage.OpportunityOutcome{
+				AskPrice:   tt.askPrice,
+				BidPrice:   0.30,
+				TickSize:   0.01,
+				LimitPrice: tt.limitPrice,
+			}
+
+			ticksExec := New(&Config{Mode: "live", Logger: zaptest.NewLogger(t), AggressionTicks: 5})
+			if got := ticksExec.aggressivePrice(outcome); !floatEquals(got, tt.want, 0.00001) {
+				t.Errorf("ticks mode: expected %f, got %f", tt.want, got)
+			}
+
+			// 0.5 of the 0.20 spread would be 0.60
+			spreadExec := New(&Config{
+				Mode:                     "live",
+				Logger:                   zaptest.NewLogger(t),
+				AggressionMode:           AggressionModeSpreadFraction,
+				AggressionSpreadFraction: 0.5,
+			})
+			want := tt.want
+			if tt.limitPrice == 0 || tt.limitPrice >= 0.60 {
+				want = 0.60
+			}
+			if got := spreadExec.aggressivePrice(outcome); !floatEquals(got, want, 0.00001) {
+				t.Errorf("spread_fraction mode: expected %f, got %f", want, got)
+			}
+		})
+	}
+}
+
 // TestCalculateActualProfit_FullFill tests 100% fill requirement
 func TestCalculateActualProfit_FullFill(t *testing.T) {
 	t.Parallel()
@@ -622,7 +838,7 @@ func TestCalculateActualProfit_FullFill(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			profit, filled := calculateActualProfit(tt.fills, tt.takerFee)
+			profit, filled := calculateActualProfit(tt.fills, arbitrage.FlatFeeModel{TakerRate: tt.takerFee}, arbitrage.FeeSideTaker)
 
 			if filled != tt.expectFilled {
 				t.Errorf("expected filled=%v, got %v", tt.expectFilled, filled)
@@ -676,7 +892,7 @@ func TestCalculateActualProfit_Fees(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			profit, filled := calculateActualProfit(tt.fills, tt.takerFee)
+			profit, filled := calculateActualProfit(tt.fills, arbitrage.FlatFeeModel{TakerRate: tt.takerFee}, arbitrage.FeeSideTaker)
 
 			if !filled {
 				t.Fatal("expected all fills to be complete")
@@ -743,7 +959,7 @@ func TestCalculateActualProfit_Revenue(t *testing.T) {
 				{Outcome: "NO", FullyFilled: true, SizeFilled: tt.tokenCount, ActualPrice: tt.price2},
 			}
 
-			profit, filled := calculateActualProfit(fills, tt.takerFee)
+			profit, filled := calculateActualProfit(fills, arbitrage.FlatFeeModel{TakerRate: tt.takerFee}, arbitrage.FeeSideTaker)
 
 			if !filled {
 				t.Fatal("expected all fills to be complete")
@@ -815,6 +1031,11 @@ func TestClassifyError(t *testing.T) {
 			err:          fmt.Errorf("insufficient balance"),
 			expectedType: "funds",
 		},
+		{
+			name:         "below-min-size",
+			err:          fmt.Errorf("place orders: %w", &BelowMinSizeError{OutcomeIndex: 1, Size: 2, MinSize: 5}),
+			expectedType: "below_min_size",
+		},
 		{
 			name:         "unknown-error",
 			err:          fmt.Errorf("something unexpected"),
@@ -2,11 +2,29 @@ package execution
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/mselser95/polymarket-arb/internal/arbitrage"
+	"github.com/mselser95/polymarket-arb/internal/circuitbreaker"
+	"github.com/mselser95/polymarket-arb/internal/testutil"
+	"github.com/mselser95/polymarket-arb/pkg/tracing"
+	"github.com/mselser95/polymarket-arb/pkg/types"
+	promtestutil "github.com/prometheus/client_golang/prometheus/testutil"
 	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
 )
 
 func TestNew(t *testing.T) {
@@ -39,7 +57,7 @@ func TestNew(t *testing.T) {
 	}
 
 	if exec.cumulativeProfit != 0 {
-		t.Errorf("expected cumulative profit to be 0, got %f", exec.cumulativeProfit)
+		t.Errorf("expected cumulative profit to be 0, got %v", exec.cumulativeProfit)
 	}
 }
 
@@ -93,21 +111,50 @@ func TestExecutor_ExecutePaper(t *testing.T) {
 	}
 
 	// Check profit calculation
-	expectedProfit := opp.MaxTradeSize * opp.ProfitMargin // 100 * 0.01 = 1.0
-	if result.RealizedProfit != expectedProfit {
+	expectedProfit := testPaperProfit
+	if !floatEquals(result.RealizedProfit, expectedProfit, 1e-9) {
 		t.Errorf("expected profit %f, got %f", expectedProfit, result.RealizedProfit)
 	}
 
 	// Check cumulative profit
 	exec.mu.Lock()
-	cumulativeProfit := exec.cumulativeProfit
+	cumulativeProfit := exec.cumulativeProfit.Float64()
 	exec.mu.Unlock()
 
-	if cumulativeProfit != expectedProfit {
+	if !floatEquals(cumulativeProfit, expectedProfit, 1e-6) {
 		t.Errorf("expected cumulative profit %f, got %f", expectedProfit, cumulativeProfit)
 	}
 }
 
+// TestExecutePaper_SkippedTailChargesExpectedLoss tests that paper profit on a tail subset
+// is the full set's profit less the skipped tail's asks on every filled set.
+func TestExecutePaper_SkippedTailChargesExpectedLoss(t *testing.T) {
+	exec := &Executor{
+		mode:   "paper",
+		logger: zap.NewNop(),
+	}
+
+	full := exec.executePaper(arbitrage.CreateTestOpportunity("full-market", "full-slug"))
+
+	tail := arbitrage.CreateTestOpportunity("tail-market", "tail-slug")
+	tail.ChargeSkippedTail([]arbitrage.OpportunityOutcome{{TokenID: "tail-token", Outcome: "TAIL", AskPrice: 0.004}})
+	subset := exec.executePaper(tail)
+
+	if subset.RealizedProfit >= full.RealizedProfit {
+		t.Fatalf("expected skipped-tail profit below the full set's %f, got %f", full.RealizedProfit, subset.RealizedProfit)
+	}
+
+	sets := tokensForBudget(tail.MaxTradeSize, []float64{0.48, 0.51})
+	want := full.RealizedProfit - 0.004*sets
+	if !floatEquals(subset.RealizedProfit, want, 1e-9) {
+		t.Errorf("expected profit %f, got %f", want, subset.RealizedProfit)
+	}
+	if !floatEquals(subset.GrossProfit-subset.TotalFees-subset.Slippage, subset.RealizedProfit, 1e-9) {
+		t.Errorf("expected breakdown to add up to %f, got gross %f fees %f slippage %f",
+			subset.RealizedProfit, subset.GrossProfit, subset.TotalFees, subset.Slippage)
+	}
+}
+
 func TestExecutor_ExecuteLive(t *testing.T) {
 	logger, _ := zap.NewDevelopment()
 
@@ -167,6 +214,7 @@ func TestExecutor_ExecutionLoop(t *testing.T) {
 		mode:            "paper",
 		logger:          logger,
 		opportunityChan: oppChan,
+		queue:           newOpportunityQueue(defaultQueueSize, defaultQueueMaxAge),
 	}
 
 	ctx, cancel := context.WithCancel(context.Background())
@@ -185,11 +233,11 @@ func TestExecutor_ExecutionLoop(t *testing.T) {
 
 	// Check cumulative profit
 	exec.mu.Lock()
-	cumulativeProfit := exec.cumulativeProfit
+	cumulativeProfit := exec.cumulativeProfit.Float64()
 	exec.mu.Unlock()
 
-	expectedProfit := 1.0 // 100 * 0.01
-	if cumulativeProfit != expectedProfit {
+	expectedProfit := testPaperProfit
+	if !floatEquals(cumulativeProfit, expectedProfit, 1e-6) {
 		t.Errorf("expected cumulative profit %f, got %f", expectedProfit, cumulativeProfit)
 	}
 
@@ -206,6 +254,7 @@ func TestExecutor_ConcurrentExecution(t *testing.T) {
 		mode:            "paper",
 		logger:          logger,
 		opportunityChan: oppChan,
+		queue:           newOpportunityQueue(defaultQueueSize, defaultQueueMaxAge),
 	}
 
 	ctx, cancel := context.WithCancel(context.Background())
@@ -228,11 +277,11 @@ func TestExecutor_ConcurrentExecution(t *testing.T) {
 
 	// Check cumulative profit
 	exec.mu.Lock()
-	cumulativeProfit := exec.cumulativeProfit
+	cumulativeProfit := exec.cumulativeProfit.Float64()
 	exec.mu.Unlock()
 
-	expectedProfit := 10.0 // 10 opportunities * 100 * 0.01
-	if cumulativeProfit != expectedProfit {
+	expectedProfit := 10 * testPaperProfit
+	if !floatEquals(cumulativeProfit, expectedProfit, 1e-5) {
 		t.Errorf("expected cumulative profit %f, got %f", expectedProfit, cumulativeProfit)
 	}
 
@@ -240,3 +289,1041 @@ func TestExecutor_ConcurrentExecution(t *testing.T) {
 	cancel()
 	exec.wg.Wait()
 }
+
+func TestExecutor_Stats(t *testing.T) {
+	exec := New(&Config{
+		Mode:   "paper",
+		Logger: zap.NewNop(),
+	})
+
+	// Empty snapshot before any trades
+	stats := exec.Stats()
+	if stats.TotalFilledLegs != 0 || stats.CumulativeProfit != 0 {
+		t.Errorf("expected empty stats, got %+v", stats)
+	}
+
+	for i := 0; i < 3; i++ {
+		opp := arbitrage.CreateTestOpportunity("test-market", "test-slug")
+		result := exec.executePaper(opp)
+		if !result.Success {
+			t.Fatalf("paper trade %d failed: %v", i, result.Error)
+		}
+	}
+
+	exec.recordFillVerification("partial")
+
+	stats = exec.Stats()
+
+	if stats.Mode != "paper" {
+		t.Errorf("expected mode paper, got %s", stats.Mode)
+	}
+
+	expectedProfit := 3 * testPaperProfit
+	if math.Abs(stats.CumulativeProfit-expectedProfit) > 1e-5 {
+		t.Errorf("expected cumulative profit %f, got %f", expectedProfit, stats.CumulativeProfit)
+	}
+
+	if stats.TotalFilledLegs != 6 {
+		t.Errorf("expected 6 filled legs (3 opportunities x 2 outcomes), got %d", stats.TotalFilledLegs)
+	}
+
+	if stats.TradesByMode["paper"]["YES"] != 3 || stats.TradesByMode["paper"]["NO"] != 3 {
+		t.Errorf("expected 3 YES and 3 NO paper trades, got %v", stats.TradesByMode["paper"])
+	}
+
+	if stats.FillVerifications["partial"] != 1 {
+		t.Errorf("expected 1 partial fill verification, got %v", stats.FillVerifications)
+	}
+
+	// Snapshot maps must not alias executor state
+	stats.TradesByMode["paper"]["YES"] = 100
+	if exec.Stats().TradesByMode["paper"]["YES"] != 3 {
+		t.Error("expected Stats() to return a copy of trade counts")
+	}
+}
+
+func TestExecutor_StatsConcurrentReads(t *testing.T) {
+	oppChan := make(chan *arbitrage.Opportunity, 100)
+	exec := New(&Config{
+		Mode:               "paper",
+		Logger:             zap.NewNop(),
+		OpportunityChannel: oppChan,
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	err := exec.Start(ctx)
+	if err != nil {
+		t.Fatalf("start executor: %v", err)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 100; i++ {
+			_ = exec.Stats()
+		}
+	}()
+
+	for i := 0; i < 20; i++ {
+		oppChan <- arbitrage.CreateTestOpportunity("test-market", "test-slug")
+	}
+	close(oppChan)
+
+	<-done
+	_ = exec.Close()
+
+	if got := exec.Stats().TotalFilledLegs; got != 40 {
+		t.Errorf("expected 40 trades, got %d", got)
+	}
+}
+
+// mockLiveClient places orders successfully and reports fills from GetOrder.
+// submitStatuses optionally sets the submission status per leg; matched legs
+// report the whole order bought at its price, or half of it with partialMatch.
+// With fillAfter set, orders report filled once GetOrder has been called that
+// many times.
+type mockLiveClient struct {
+	filled         bool
+	fillAfter      int64
+	submitStatuses []string
+	partialMatch   bool
+	queryStatus    string // Status of unfilled orders (default "live")
+	placeErr       error
+	queries        atomic.Int64
+	queriedIDs     sync.Map
+	placed         []types.OutcomeOrderParams // Outcomes of the last placement
+}
+
+func (m *mockLiveClient) PlaceOrdersMultiOutcome(
+	_ context.Context,
+	outcomes []types.OutcomeOrderParams,
+	tokenCount float64,
+) ([]*types.OrderSubmissionResponse, error) {
+	m.placed = outcomes
+	if m.placeErr != nil {
+		return nil, m.placeErr
+	}
+
+	responses := make([]*types.OrderSubmissionResponse, len(outcomes))
+	for i := range outcomes {
+		responses[i] = &types.OrderSubmissionResponse{
+			Success: true,
+			OrderID: fmt.Sprintf("order-%d", i),
+		}
+		if i < len(m.submitStatuses) {
+			responses[i].Status = m.submitStatuses[i]
+			if m.submitStatuses[i] == types.OrderStatusMatched {
+				taking := roundDown(tokenCount, 2)
+				if m.partialMatch {
+					taking /= 2
+				}
+				responses[i].MakingAmount = strconv.FormatFloat(taking*outcomes[i].Price, 'f', -1, 64)
+				responses[i].TakingAmount = strconv.FormatFloat(taking, 'f', -1, 64)
+			}
+		}
+	}
+
+	return responses, nil
+}
+
+func (m *mockLiveClient) GetOrder(_ context.Context, orderID string) (*types.OrderQueryResponse, error) {
+	queries := m.queries.Add(1)
+	m.queriedIDs.Store(orderID, true)
+
+	resp := &types.OrderQueryResponse{
+		OrderID: orderID,
+		Status:  "live",
+		Price:   0.50,
+		Size:    10.0,
+	}
+	if m.queryStatus != "" {
+		resp.Status = m.queryStatus
+	}
+	if m.filled || (m.fillAfter > 0 && queries >= m.fillAfter) {
+		resp.Status = "matched"
+		resp.SizeFilled = resp.Size
+	}
+
+	return resp, nil
+}
+
+// testPaperProfit is the idealized paper profit of arbitrage.CreateTestOpportunity: its
+// $100 budget buys 100/0.51 tokens of each leg at asks 0.48 + 0.51, a cent per set.
+const testPaperProfit = 100.0 / 0.51 * (1 - 0.48 - 0.51)
+
+func newLiveTestExecutor(client *mockLiveClient, fillTimeout time.Duration) *Executor {
+	return New(&Config{
+		Mode:             "live",
+		Logger:           zap.NewNop(),
+		OrderClient:      client,
+		AggressionTicks:  1,
+		FillTimeout:      fillTimeout,
+		FillRetryInitial: 10 * time.Millisecond,
+		FillRetryMax:     20 * time.Millisecond,
+		FillRetryMult:    2.0,
+	})
+}
+
+func TestNew_FillGracePeriod(t *testing.T) {
+	exec := New(&Config{Mode: "live", Logger: zap.NewNop()})
+	if exec.fillGracePeriod != defaultFillGracePeriod {
+		t.Errorf("expected default grace period %v, got %v", defaultFillGracePeriod, exec.fillGracePeriod)
+	}
+
+	exec = New(&Config{Mode: "live", Logger: zap.NewNop(), FillGracePeriod: 3 * time.Second})
+	if exec.fillGracePeriod != 3*time.Second {
+		t.Errorf("expected grace period 3s, got %v", exec.fillGracePeriod)
+	}
+}
+
+// TestVerifyFills_StopsOnExecutorShutdown tests that an in-flight verification
+// aborts promptly when the executor's context is canceled, long before the fill timeout.
+func TestVerifyFills_StopsOnExecutorShutdown(t *testing.T) {
+	client := &mockLiveClient{filled: false}
+	exec := newLiveTestExecutor(client, 30*time.Second)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	err := exec.Start(ctx)
+	if err != nil {
+		t.Fatalf("start executor: %v", err)
+	}
+
+	opp := arbitrage.CreateTestOpportunity("test-market", "test-slug")
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		exec.verifyFillsAndUpdateMetrics(
+			[]string{"order-0", "order-1"},
+			[]string{"YES", "NO"},
+			[]float64{10.0, 10.0},
+			nil,
+			[]float64{0.49, 0.52},
+			opp, 1.0, time.Now())
+	}()
+
+	// Let verification poll a few times before shutting down
+	time.Sleep(50 * time.Millisecond)
+	cancel()
+	_ = exec.Close()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("verification did not stop after executor shutdown")
+	}
+
+	if client.queries.Load() == 0 {
+		t.Error("expected verification to query orders before shutdown")
+	}
+
+	stats := exec.Stats()
+	if stats.FillVerifications["aborted"] != 1 {
+		t.Errorf("expected 1 aborted verification, got %v", stats.FillVerifications)
+	}
+
+	if stats.CumulativeProfit != 0 {
+		t.Errorf("expected no profit from aborted verification, got %f", stats.CumulativeProfit)
+	}
+}
+
+// TestVerifyFills_TailSubsetUnhedged tests that a filled tail subset is recorded as an
+// unhedged position rather than realized arbitrage profit.
+func TestVerifyFills_TailSubsetUnhedged(t *testing.T) {
+	client := &mockLiveClient{filled: true}
+	exec := newLiveTestExecutor(client, 5*time.Second)
+	exec.ctx = context.Background()
+
+	opp := arbitrage.CreateTestOpportunity("test-market", "test-slug")
+	opp.SkippedOutcomes = []arbitrage.OpportunityOutcome{{TokenID: "tail", Outcome: "Other", AskPrice: 0.005}}
+
+	exec.verifyFillsAndUpdateMetrics(
+		[]string{"order-0", "order-1"},
+		[]string{"YES", "NO"},
+		[]float64{10.0, 10.0},
+		nil,
+		[]float64{0.48, 0.51},
+		opp, 0.1, time.Now())
+
+	stats := exec.Stats()
+	if stats.FillVerifications["unhedged"] != 1 || stats.FillVerifications["success"] != 0 {
+		t.Errorf("expected 1 unhedged verification, got %v", stats.FillVerifications)
+	}
+	if stats.CumulativeProfit != 0 {
+		t.Errorf("expected no realized profit, got %f", stats.CumulativeProfit)
+	}
+	if got := stats.TradesByMode["live"]; got["YES"] != 1 || got["NO"] != 1 {
+		t.Errorf("expected one filled leg per outcome, got %v", got)
+	}
+}
+
+// TestExecuteLive_VerificationOutlivesRequestContext tests that verification keeps
+// running after executeLive returns and its placement context is canceled.
+func TestExecuteLive_VerificationOutlivesRequestContext(t *testing.T) {
+	client := &mockLiveClient{filled: true}
+	exec := newLiveTestExecutor(client, 5*time.Second)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	exec.ctx = ctx
+
+	result := exec.executeLive(arbitrage.CreateTestOpportunity("test-market", "test-slug"))
+	if !result.Success {
+		t.Fatalf("expected orders to be placed, got %v", result.Error)
+	}
+
+	deadline := time.After(2 * time.Second)
+	for exec.Stats().FillVerifications["success"] == 0 {
+		select {
+		case <-deadline:
+			t.Fatalf("verification did not complete, stats: %+v", exec.Stats())
+		case <-time.After(5 * time.Millisecond):
+		}
+	}
+
+	if got := exec.Stats().TradesByMode["live"]; got["YES"] != 1 || got["NO"] != 1 {
+		t.Errorf("expected one filled leg per outcome, got %v", got)
+	}
+}
+
+// TestExecuteLive_FillVerificationConcurrencyCap tests that a burst of live executions
+// runs at most MaxConcurrentVerifications verifications at once and queues the rest.
+func TestExecuteLive_FillVerificationConcurrencyCap(t *testing.T) {
+	const maxConcurrent = 3
+	const executions = 12
+
+	client := &mockLiveClient{filled: false}
+	exec := New(&Config{
+		Mode:                       "live",
+		Logger:                     zap.NewNop(),
+		OrderClient:                client,
+		AggressionTicks:            1,
+		FillTimeout:                30 * time.Second,
+		FillRetryInitial:           10 * time.Millisecond,
+		FillRetryMax:               20 * time.Millisecond,
+		FillRetryMult:              2.0,
+		MaxConcurrentVerifications: maxConcurrent,
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	exec.ctx = ctx
+
+	activeBefore := promtestutil.ToFloat64(FillVerificationsActive)
+	queuedBefore := promtestutil.ToFloat64(FillVerificationsQueued)
+
+	for i := range executions {
+		opp := arbitrage.CreateTestOpportunity(fmt.Sprintf("market-%d", i), fmt.Sprintf("slug-%d", i))
+		result := exec.executeLive(opp)
+		if !result.Success {
+			t.Fatalf("execution %d: expected orders placed, got %v", i, result.Error)
+		}
+	}
+
+	deadline := time.Now().Add(2 * time.Second)
+	for promtestutil.ToFloat64(FillVerificationsQueued)-queuedBefore < executions-maxConcurrent {
+		if time.Now().After(deadline) {
+			t.Fatalf("expected %d queued verifications, got %.0f",
+				executions-maxConcurrent, promtestutil.ToFloat64(FillVerificationsQueued)-queuedBefore)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	// The cap holds while the active verifications keep polling
+	for range 10 {
+		if n := len(exec.verifySlots); n > maxConcurrent {
+			t.Fatalf("expected at most %d verifications running, got %d", maxConcurrent, n)
+		}
+		if got := promtestutil.ToFloat64(FillVerificationsActive) - activeBefore; got != maxConcurrent {
+			t.Fatalf("expected %d active verifications, got %.0f", maxConcurrent, got)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	if client.queries.Load() == 0 {
+		t.Error("expected active verifications to poll orders")
+	}
+
+	// Shutdown lets the queued verifications through, which abort at once
+	cancel()
+	exec.WaitForFills()
+
+	if got := exec.Stats().FillVerifications["aborted"]; got != executions {
+		t.Errorf("expected %d aborted verifications, got %d", executions, got)
+	}
+	if got := promtestutil.ToFloat64(FillVerificationsActive) - activeBefore; got != 0 {
+		t.Errorf("expected no active verifications after shutdown, got %.0f", got)
+	}
+	if got := promtestutil.ToFloat64(FillVerificationsQueued) - queuedBefore; got != 0 {
+		t.Errorf("expected no queued verifications after shutdown, got %.0f", got)
+	}
+}
+
+// TestClose_WaitsForFillVerification tests that Close returns only after a live
+// execution's verification has either completed or been canceled.
+func TestClose_WaitsForFillVerification(t *testing.T) {
+	tests := []struct {
+		name       string
+		filled     bool
+		wantStatus string
+	}{
+		{name: "verification_completes", filled: true, wantStatus: "success"},
+		{name: "verification_canceled", filled: false, wantStatus: "aborted"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := &mockLiveClient{filled: tt.filled}
+			oppChan := make(chan *arbitrage.Opportunity, 1)
+			exec := newLiveTestExecutor(client, 30*time.Second)
+			exec.opportunityChan = oppChan
+
+			ctx, cancel := context.WithCancel(context.Background())
+			err := exec.Start(ctx)
+			if err != nil {
+				t.Fatalf("start executor: %v", err)
+			}
+
+			oppChan <- arbitrage.CreateTestOpportunity("test-market", "test-slug")
+			close(oppChan)
+
+			// Wait for the verification goroutine to start polling
+			deadline := time.After(2 * time.Second)
+			for client.queries.Load() == 0 {
+				select {
+				case <-deadline:
+					t.Fatal("verification never started")
+				case <-time.After(time.Millisecond):
+				}
+			}
+
+			if !tt.filled {
+				cancel()
+			}
+
+			closed := make(chan struct{})
+			go func() {
+				_ = exec.Close()
+				close(closed)
+			}()
+
+			select {
+			case <-closed:
+			case <-time.After(2 * time.Second):
+				t.Fatal("Close did not return")
+			}
+			cancel()
+
+			// No further updates can happen once Close has returned
+			stats := exec.Stats()
+			if stats.FillVerifications[tt.wantStatus] != 1 {
+				t.Errorf("expected 1 %s verification before Close returned, got %v",
+					tt.wantStatus, stats.FillVerifications)
+			}
+		})
+	}
+}
+
+// TestDrain_CompletesPendingVerification tests that Drain stops taking opportunities but
+// waits for a placed order's verification to finish rather than abandoning it.
+func TestDrain_CompletesPendingVerification(t *testing.T) {
+	// Two legs per poll: the orders fill on the third verification round
+	client := &mockLiveClient{fillAfter: 6}
+	oppChan := make(chan *arbitrage.Opportunity, 1)
+	exec := newLiveTestExecutor(client, 30*time.Second)
+	exec.opportunityChan = oppChan
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	err := exec.Start(ctx)
+	if err != nil {
+		t.Fatalf("start executor: %v", err)
+	}
+
+	oppChan <- arbitrage.CreateTestOpportunity("test-market", "test-slug")
+
+	deadline := time.After(2 * time.Second)
+	for client.queries.Load() == 0 {
+		select {
+		case <-deadline:
+			t.Fatal("verification never started")
+		case <-time.After(time.Millisecond):
+		}
+	}
+
+	drainCtx, drainCancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer drainCancel()
+	err = exec.Drain(drainCtx)
+	if err != nil {
+		t.Fatalf("unexpected drain error: %v", err)
+	}
+
+	// The verification completed instead of being aborted
+	if got := exec.Stats().FillVerifications; got["success"] != 1 {
+		t.Errorf("expected 1 successful verification when Drain returned, got %v", got)
+	}
+
+	// Opportunities arriving after Drain are not executed
+	queries := client.queries.Load()
+	oppChan <- arbitrage.CreateTestOpportunity("test-market-2", "test-slug-2")
+	time.Sleep(50 * time.Millisecond)
+	if len(oppChan) != 1 {
+		t.Error("expected opportunity sent after Drain to stay unread")
+	}
+	if got := client.queries.Load(); got != queries {
+		t.Errorf("expected no new orders after Drain, got %d more queries", got-queries)
+	}
+
+	_ = exec.Close()
+}
+
+// TestDrain_Deadline tests that Drain gives up when verifications outlast its context.
+func TestDrain_Deadline(t *testing.T) {
+	client := &mockLiveClient{filled: false}
+	oppChan := make(chan *arbitrage.Opportunity, 1)
+	exec := newLiveTestExecutor(client, 30*time.Second)
+	exec.opportunityChan = oppChan
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	err := exec.Start(ctx)
+	if err != nil {
+		t.Fatalf("start executor: %v", err)
+	}
+
+	oppChan <- arbitrage.CreateTestOpportunity("test-market", "test-slug")
+
+	deadline := time.After(2 * time.Second)
+	for client.queries.Load() == 0 {
+		select {
+		case <-deadline:
+			t.Fatal("verification never started")
+		case <-time.After(time.Millisecond):
+		}
+	}
+
+	drainCtx, drainCancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer drainCancel()
+	err = exec.Drain(drainCtx)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected deadline exceeded, got %v", err)
+	}
+
+	cancel()
+	_ = exec.Close()
+}
+
+// TestExecuteLive_SubmissionStatuses tests that matched legs settle from the submission
+// response without polling, while delayed and live legs go through fill verification.
+func TestExecuteLive_SubmissionStatuses(t *testing.T) {
+	tests := []struct {
+		name         string
+		statuses     []string
+		partialMatch bool
+		wantQueried  []string
+	}{
+		{name: "all_matched", statuses: []string{"matched", "matched"}},
+		{name: "partially_matched_polled", statuses: []string{"matched", "matched"}, partialMatch: true, wantQueried: []string{"order-0", "order-1"}},
+		{name: "matched_and_delayed", statuses: []string{"matched", "delayed"}, wantQueried: []string{"order-1"}},
+		{name: "matched_and_live", statuses: []string{"matched", "live"}, wantQueried: []string{"order-1"}},
+		{name: "live_and_matched", statuses: []string{"live", "matched"}, wantQueried: []string{"order-0"}},
+		{name: "all_delayed", statuses: []string{"delayed", "delayed"}, wantQueried: []string{"order-0", "order-1"}},
+		{name: "matched_without_amounts_polled", statuses: []string{"MATCHED", "live"}, wantQueried: []string{"order-0", "order-1"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := &mockLiveClient{filled: true, submitStatuses: tt.statuses, partialMatch: tt.partialMatch}
+			exec := newLiveTestExecutor(client, 5*time.Second)
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			exec.ctx = ctx
+
+			result := exec.executeLive(arbitrage.CreateTestOpportunity("test-market", "test-slug"))
+			if !result.Success {
+				t.Fatalf("expected orders to be placed, got %v", result.Error)
+			}
+
+			exec.verifyWg.Wait()
+
+			if got := exec.Stats().FillVerifications["success"]; got != 1 {
+				t.Fatalf("expected successful verification, got %v", exec.Stats().FillVerifications)
+			}
+
+			if got := client.queries.Load(); got < int64(len(tt.wantQueried)) || (len(tt.wantQueried) == 0 && got != 0) {
+				t.Errorf("expected queries for %v, got %d queries", tt.wantQueried, got)
+			}
+
+			for _, id := range tt.wantQueried {
+				if _, ok := client.queriedIDs.Load(id); !ok {
+					t.Errorf("expected %s to be polled", id)
+				}
+			}
+
+			client.queriedIDs.Range(func(key, _ any) bool {
+				id, _ := key.(string)
+				wanted := false
+				for _, want := range tt.wantQueried {
+					wanted = wanted || want == id
+				}
+				if !wanted {
+					t.Errorf("matched leg %s should not be polled", id)
+				}
+				return true
+			})
+		})
+	}
+}
+
+// TestExecuteLive_BelowMinSizeSkipped tests that an undersized placement is counted as a
+// skip, keeps its typed error, and releases the reserved exposure.
+func TestExecuteLive_BelowMinSizeSkipped(t *testing.T) {
+	client := &mockLiveClient{placeErr: &BelowMinSizeError{OutcomeIndex: 1, Size: 2, MinSize: 5}}
+	exec := newLiveTestExecutor(client, time.Second)
+	exec.ctx = context.Background()
+	skippedBefore := promtestutil.ToFloat64(OpportunitiesSkippedTotal.WithLabelValues("below_min_size"))
+
+	result := exec.executeLive(arbitrage.CreateTestOpportunity("test-market", "test-slug"))
+	if result.Success || !errors.Is(result.Error, ErrBelowMinSize) {
+		t.Fatalf("expected ErrBelowMinSize, got %v", result.Error)
+	}
+
+	if got := promtestutil.ToFloat64(OpportunitiesSkippedTotal.WithLabelValues("below_min_size")) - skippedBefore; got != 1 {
+		t.Errorf("expected 1 below_min_size skip, got %f", got)
+	}
+
+	exec.mu.Lock()
+	defer exec.mu.Unlock()
+	if exec.openExposure != 0 {
+		t.Errorf("expected exposure released, got %f", exec.openExposure)
+	}
+}
+
+// TestExecutionLoop_PaperTradesWarmCircuitBreaker tests that paper trades feed their
+// notional to the circuit breaker only when recording in all modes is enabled.
+func TestExecutionLoop_PaperTradesWarmCircuitBreaker(t *testing.T) {
+	tests := []struct {
+		name           string
+		recordAllModes bool
+		wantCount      int
+	}{
+		{name: "record_all_modes", recordAllModes: true, wantCount: 3},
+		{name: "live_only", recordAllModes: false, wantCount: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			breaker, err := circuitbreaker.New(&circuitbreaker.Config{
+				CheckInterval:   time.Minute,
+				TradeMultiplier: 3.0,
+				MinAbsolute:     5.0,
+				HysteresisRatio: 1.5,
+				WalletClient:    testutil.NewMockWalletClient(),
+				Address:         common.HexToAddress("0x1234567890abcdef1234567890abcdef12345678"),
+				Logger:          zap.NewNop(),
+			})
+			if err != nil {
+				t.Fatalf("create breaker: %v", err)
+			}
+
+			oppChan := make(chan *arbitrage.Opportunity, 3)
+			exec := New(&Config{
+				Mode:               "paper",
+				Logger:             zap.NewNop(),
+				OpportunityChannel: oppChan,
+				CircuitBreaker:     breaker,
+				RecordAllModes:     tt.recordAllModes,
+			})
+
+			for i := 0; i < 3; i++ {
+				oppChan <- arbitrage.CreateTestOpportunity("test-market", "test-slug")
+			}
+			close(oppChan)
+
+			err = exec.Start(context.Background())
+			if err != nil {
+				t.Fatalf("start executor: %v", err)
+			}
+			_ = exec.Close()
+
+			status := breaker.GetStatus()
+			if status.RecentTradeCount != tt.wantCount {
+				t.Fatalf("expected %d recorded trades, got %d", tt.wantCount, status.RecentTradeCount)
+			}
+
+			if tt.wantCount == 0 {
+				return
+			}
+
+			// $100 budget buys 100/0.51 tokens of each leg at asks 0.48 + 0.51
+			wantNotional := 100.0 / 0.51 * 0.99
+			if math.Abs(status.AvgTradeSize-wantNotional) > 1e-9 {
+				t.Errorf("expected recorded notional %f, got %f", wantNotional, status.AvgTradeSize)
+			}
+		})
+	}
+}
+
+// TestExecute_MaxOpportunityAge tests that an opportunity older than MaxOpportunityAge is
+// discarded at execution while a fresh one proceeds, and that a zero max age never expires.
+func TestExecute_MaxOpportunityAge(t *testing.T) {
+	tests := []struct {
+		name        string
+		maxAge      time.Duration
+		age         time.Duration
+		wantExpired bool
+	}{
+		{name: "fresh", maxAge: time.Second, age: 0},
+		{name: "aged", maxAge: time.Second, age: 3 * time.Second, wantExpired: true},
+		{name: "disabled", maxAge: 0, age: time.Hour},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exec := New(&Config{Mode: "paper", Logger: zap.NewNop(), MaxOpportunityAge: tt.maxAge})
+
+			opp := arbitrage.CreateTestOpportunity("market-a", "slug-a")
+			opp.DetectedAt = time.Now().Add(-tt.age)
+
+			expiredBefore := promtestutil.ToFloat64(OpportunitiesExpiredTotal)
+			result := exec.Execute(opp)
+			expired := promtestutil.ToFloat64(OpportunitiesExpiredTotal) - expiredBefore
+
+			if tt.wantExpired {
+				if result != nil {
+					t.Errorf("expected aged opportunity to be discarded, got %+v", result)
+				}
+				if expired != 1 {
+					t.Errorf("expected 1 expired opportunity, got %.0f", expired)
+				}
+				if exec.CumulativeProfit() != 0 {
+					t.Errorf("expected no profit recorded, got $%.2f", exec.CumulativeProfit())
+				}
+				return
+			}
+
+			if result == nil || !result.Success {
+				t.Errorf("expected opportunity to execute, got %+v", result)
+			}
+			if expired != 0 {
+				t.Errorf("expected no expired opportunities, got %.0f", expired)
+			}
+		})
+	}
+}
+
+// recordingResultStore records stored execution results. Live verification stores from
+// its own goroutine, so access is locked.
+type recordingResultStore struct {
+	mu      sync.Mutex
+	results []*types.ExecutionResult
+	err     error
+}
+
+func (s *recordingResultStore) StoreExecutionResult(_ context.Context, result *types.ExecutionResult) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.results = append(s.results, result)
+	return s.err
+}
+
+// stored returns the results stored so far.
+func (s *recordingResultStore) stored() []*types.ExecutionResult {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]*types.ExecutionResult(nil), s.results...)
+}
+
+// failWith makes later stores return err.
+func (s *recordingResultStore) failWith(err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.err = err
+}
+
+// TestExecute_StoresResults tests that executed opportunities are recorded in the result
+// store, skipped ones are not, and a failing store doesn't fail the execution.
+func TestExecute_StoresResults(t *testing.T) {
+	store := &recordingResultStore{}
+	exec := New(&Config{Mode: "paper", Logger: zap.NewNop(), ResultStore: store, MaxOpportunityAge: time.Second})
+
+	result := exec.Execute(arbitrage.CreateTestOpportunity("market-a", "slug-a"))
+	if result == nil || !result.Success {
+		t.Fatalf("expected execution to succeed, got %+v", result)
+	}
+	if stored := store.stored(); len(stored) != 1 || stored[0] != result {
+		t.Fatalf("expected the result to be stored, got %+v", stored)
+	}
+
+	aged := arbitrage.CreateTestOpportunity("market-b", "slug-b")
+	aged.DetectedAt = time.Now().Add(-time.Minute)
+	exec.Execute(aged)
+	if stored := store.stored(); len(stored) != 1 {
+		t.Errorf("expected skipped opportunity not to be stored, got %d results", len(stored))
+	}
+
+	store.failWith(errors.New("database is locked"))
+	result = exec.Execute(arbitrage.CreateTestOpportunity("market-c", "slug-c"))
+	if result == nil || !result.Success {
+		t.Errorf("expected execution to succeed despite store failure, got %+v", result)
+	}
+}
+
+// TestVerifyFills_DelayedNotPartial tests that a verification ending with orders still
+// queued for delayed matching is recorded as delayed, not partial, and the trade stays
+// pending for reconciliation.
+func TestVerifyFills_DelayedNotPartial(t *testing.T) {
+	exec := newLiveTestExecutor(&mockLiveClient{queryStatus: types.OrderStatusDelayed}, 50*time.Millisecond)
+	exec.ctx = context.Background()
+
+	placed := exec.Execute(arbitrage.CreateTestOpportunity("delayed-market", "delayed-slug"))
+	exec.WaitForFills()
+	if placed == nil || !placed.Success {
+		t.Fatalf("expected orders placed, got %+v", placed)
+	}
+
+	stats := exec.Stats()
+	if stats.FillVerifications["delayed"] != 1 || stats.FillVerifications["partial"] != 0 {
+		t.Errorf("expected one delayed verification, got %v", stats.FillVerifications)
+	}
+	if n := len(exec.PendingTrades()); n != 1 {
+		t.Errorf("expected the delayed trade to stay pending, got %d pending", n)
+	}
+}
+
+// TestVerifyFills_PartialTakerSetCanceled tests that when a taker set ends with only some
+// legs filled, the resting legs are canceled, the pending trade is cleared and its exposure
+// is released.
+func TestVerifyFills_PartialTakerSetCanceled(t *testing.T) {
+	client := &cancelRecordingClient{
+		mockLiveClient: mockLiveClient{submitStatuses: []string{types.OrderStatusMatched, types.OrderStatusLive}},
+	}
+	exec := New(&Config{
+		Mode:             "live",
+		Logger:           zap.NewNop(),
+		OrderClient:      client,
+		FillTimeout:      50 * time.Millisecond,
+		FillRetryInitial: 10 * time.Millisecond,
+		FillRetryMax:     20 * time.Millisecond,
+		FillRetryMult:    2.0,
+	})
+	exec.ctx = context.Background()
+	client.exec = exec
+
+	placed := exec.Execute(arbitrage.CreateTestOpportunity("partial-market", "partial-slug"))
+	exec.WaitForFills()
+	if placed == nil || !placed.Success {
+		t.Fatalf("expected orders placed, got %+v", placed)
+	}
+
+	if got := exec.Stats().FillVerifications["partial"]; got != 1 {
+		t.Errorf("expected 1 partial verification, got %d", got)
+	}
+
+	client.mu.Lock()
+	canceled := client.canceled
+	client.mu.Unlock()
+	if len(canceled) != 1 || canceled[0] != "order-1" {
+		t.Errorf("expected only the resting leg canceled, got %v", canceled)
+	}
+	if n := len(exec.PendingTrades()); n != 0 {
+		t.Errorf("expected the pending trade cleared, got %d pending", n)
+	}
+	if exec.OpenExposure() != 0 {
+		t.Errorf("expected exposure released after cancel, got %f", exec.OpenExposure())
+	}
+}
+
+// TestSetProfitBreakdown tests that gross profit, fees and slippage add up to the profit
+// calculateActualProfit reports for the same fills.
+func TestSetProfitBreakdown(t *testing.T) {
+	askPrices := []float64{0.48, 0.50}
+	fees := arbitrage.FlatFeeModel{TakerRate: 0.02}
+
+	tests := []struct {
+		name         string
+		fills        []types.FillStatus
+		wantGross    float64
+		wantSlippage float64
+	}{
+		{
+			name: "filled_at_ask",
+			fills: []types.FillStatus{
+				{FullyFilled: true, SizeFilled: 100, ActualPrice: 0.48},
+				{FullyFilled: true, SizeFilled: 100, ActualPrice: 0.50},
+			},
+			wantGross: 2.0,
+		},
+		{
+			name: "filled_above_ask",
+			fills: []types.FillStatus{
+				{FullyFilled: true, SizeFilled: 100, ActualPrice: 0.49},
+				{FullyFilled: true, SizeFilled: 100, ActualPrice: 0.50},
+			},
+			wantGross:    2.0,
+			wantSlippage: 1.0,
+		},
+		{
+			name: "price_improvement_and_trade_fees",
+			fills: []types.FillStatus{
+				{FullyFilled: true, SizeFilled: 100, ActualPrice: 0.47, FeeFromTrades: true, ActualFeePaid: 0.3},
+				{FullyFilled: true, SizeFilled: 100, ActualPrice: 0.50},
+			},
+			wantGross:    2.0,
+			wantSlippage: -1.0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := &types.ExecutionResult{}
+			setProfitBreakdown(result, tt.fills, askPrices, fees, arbitrage.FeeSideTaker)
+
+			if !floatEquals(result.GrossProfit, tt.wantGross, 1e-9) || !floatEquals(result.Slippage, tt.wantSlippage, 1e-9) {
+				t.Errorf("expected gross %.4f and slippage %.4f, got %.4f and %.4f",
+					tt.wantGross, tt.wantSlippage, result.GrossProfit, result.Slippage)
+			}
+
+			profit, filled := calculateActualProfit(tt.fills, fees, arbitrage.FeeSideTaker)
+			if !filled {
+				t.Fatal("expected fills to count as filled")
+			}
+			if net := result.GrossProfit - result.TotalFees - result.Slippage; !floatEquals(net, profit, 1e-9) {
+				t.Errorf("breakdown sums to %.4f, expected net profit %.4f", net, profit)
+			}
+		})
+	}
+}
+
+// TestVerifyFills_StoresProfitBreakdown tests that a verified live execution is recorded
+// with a profit breakdown that sums to its realized profit.
+func TestVerifyFills_StoresProfitBreakdown(t *testing.T) {
+	store := &recordingResultStore{}
+	exec := newLiveTestExecutor(&mockLiveClient{filled: true}, time.Second)
+	exec.ctx = context.Background()
+	exec.resultStore = store
+	exec.takerFee = 0.01
+
+	// Detected at 0.48 + 0.51; both legs fill at 0.50
+	opp := arbitrage.CreateTestOpportunity("breakdown-market", "breakdown-slug")
+	placed := exec.Execute(opp)
+	exec.WaitForFills()
+	if placed == nil || !placed.Success {
+		t.Fatalf("expected orders placed, got %+v", placed)
+	}
+
+	stored := store.stored()
+	if len(stored) != 2 {
+		t.Fatalf("expected placement and verification results, got %d", len(stored))
+	}
+	verified := stored[1]
+	if !verified.AllOrdersFilled || verified.VerifiedAt.IsZero() || verified.OpportunityID != opp.ID {
+		t.Fatalf("expected a verified result for %s, got %+v", opp.ID, verified)
+	}
+
+	wantSlippage := 0.0
+	for i, outcome := range opp.Outcomes {
+		wantSlippage += verified.FillStatuses[i].SizeFilled * (0.50 - outcome.AskPrice)
+	}
+	if !floatEquals(verified.Slippage, wantSlippage, 1e-9) {
+		t.Errorf("expected slippage %.4f, got %.4f", wantSlippage, verified.Slippage)
+	}
+	if verified.TotalFees <= 0 {
+		t.Errorf("expected estimated taker fees, got %.4f", verified.TotalFees)
+	}
+	if net := verified.GrossProfit - verified.TotalFees - verified.Slippage; !floatEquals(net, verified.RealizedProfit, 1e-9) {
+		t.Errorf("breakdown sums to %.4f, expected realized profit %.4f", net, verified.RealizedProfit)
+	}
+}
+
+// TestExecute_TraceIDPropagates tests that a single live opportunity carries its trace ID
+// through every log line of the executor, the order client and the fill tracker.
+func TestExecute_TraceIDPropagates(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/orders":
+			var req types.BatchOrderRequest
+			err := json.NewDecoder(r.Body).Decode(&req)
+			if err != nil {
+				t.Errorf("decode batch request: %v", err)
+			}
+
+			resp := make(types.BatchOrderResponse, len(req))
+			for i := range req {
+				resp[i] = types.OrderSubmissionResponse{Success: true, OrderID: fmt.Sprintf("order-%d", i), Status: "live"}
+			}
+			_ = json.NewEncoder(w).Encode(resp)
+		case r.Method == http.MethodGet && strings.HasPrefix(r.URL.Path, "/order/"):
+			_ = json.NewEncoder(w).Encode(map[string]string{
+				"orderID":       strings.TrimPrefix(r.URL.Path, "/order/"),
+				"status":        "matched",
+				"price":         "0.50",
+				"original_size": "10",
+				"size_matched":  "10",
+			})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	core, logs := observer.New(zapcore.DebugLevel)
+	logger := zap.New(core)
+
+	client, err := NewOrderClient(&OrderClientConfig{
+		APIKey:     "test-api-key",
+		Secret:     "dGVzdC1zZWNyZXQ=",
+		Passphrase: "test-passphrase",
+		PrivateKey: "0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef",
+		Logger:     logger,
+		BaseURL:    server.URL,
+	})
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	exec := New(&Config{
+		Mode:             "live",
+		Logger:           logger,
+		OrderClient:      client,
+		FillTimeout:      time.Second,
+		FillRetryInitial: 10 * time.Millisecond,
+		FillRetryMax:     20 * time.Millisecond,
+		FillRetryMult:    2.0,
+	})
+	exec.ctx = context.Background()
+
+	opp := arbitrage.NewMultiOutcomeOpportunity("trace-market", "trace-slug", "Traced?", []arbitrage.OpportunityOutcome{
+		{TokenID: "1001", Outcome: "YES", AskPrice: 0.48, AskSize: 100, TickSize: 0.01, MinSize: 5},
+		{TokenID: "1002", Outcome: "NO", AskPrice: 0.50, AskSize: 100, TickSize: 0.01, MinSize: 5},
+	}, 10, 0.995, 0, 0)
+
+	setupLogs := logs.Len()
+
+	result := exec.Execute(opp)
+	exec.WaitForFills()
+	if result == nil || !result.Success {
+		t.Fatalf("expected successful execution, got %+v", result)
+	}
+
+	// Executor, order client and fill tracker stages must all have logged
+	for _, msg := range []string{
+		"placing-multi-outcome-orders",
+		"order-signed",
+		"batch-order-submitted",
+		"order-fully-filled",
+		"all-orders-fully-filled",
+		"execution-successful",
+	} {
+		if logs.FilterMessage(msg).Len() == 0 {
+			t.Errorf("expected %s to be logged", msg)
+		}
+	}
+
+	for _, entry := range logs.All()[setupLogs:] {
+		if got := entry.ContextMap()[tracing.LogKey]; got != opp.TraceID {
+			t.Errorf("%s: expected %s=%s, got %v", entry.Message, tracing.LogKey, opp.TraceID, got)
+		}
+	}
+}
@@ -0,0 +1,47 @@
+package execution
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrExposureLimit is returned when placing an opportunity would push open exposure
+// past the configured maximum.
+var ErrExposureLimit = errors.New("open exposure limit reached")
+
+// reserveExposure adds notional to open exposure, or returns ErrExposureLimit if that
+// would exceed maxOpenExposure. Reserved exposure must be released with releaseExposure.
+func (e *Executor) reserveExposure(notional float64) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.maxOpenExposure > 0 && e.openExposure+notional > e.maxOpenExposure {
+		return fmt.Errorf("%w: $%.2f open + $%.2f new > $%.2f max",
+			ErrExposureLimit, e.openExposure, notional, e.maxOpenExposure)
+	}
+
+	e.openExposure += notional
+	OpenExposureUSD.Set(e.openExposure)
+
+	return nil
+}
+
+// releaseExposure removes notional from open exposure.
+func (e *Executor) releaseExposure(notional float64) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.openExposure -= notional
+	if e.openExposure < 1e-9 {
+		e.openExposure = 0 // Absorb float drift
+	}
+	OpenExposureUSD.Set(e.openExposure)
+}
+
+// OpenExposure returns the USD notional of live orders placed but not yet settled.
+func (e *Executor) OpenExposure() float64 {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	return e.openExposure
+}
@@ -0,0 +1,136 @@
+package execution
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/mselser95/polymarket-arb/internal/arbitrage"
+)
+
+// TestExecuteLive_ExposureLimit tests that opportunities fired while earlier orders await
+// fill verification are rejected once open exposure reaches the cap, and that the cap is
+// not freed when verification is aborted with the orders unsettled.
+func TestExecuteLive_ExposureLimit(t *testing.T) {
+	client := &mockLiveClient{filled: false}
+	exec := New(&Config{
+		Mode:             "live",
+		Logger:           zap.NewNop(),
+		OrderClient:      client,
+		AggressionTicks:  1,
+		FillTimeout:      30 * time.Second,
+		FillRetryInitial: 10 * time.Millisecond,
+		FillRetryMax:     20 * time.Millisecond,
+		FillRetryMult:    2.0,
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	exec.ctx = ctx
+
+	// Size the cap from one trade's notional: room for two, not three
+	first := exec.executeLive(arbitrage.CreateTestOpportunity("market-1", "slug-1"))
+	if !first.Success {
+		t.Fatalf("expected first execution to succeed, got %v", first.Error)
+	}
+	if exec.OpenExposure() != first.Notional {
+		t.Fatalf("expected open exposure %f, got %f", first.Notional, exec.OpenExposure())
+	}
+	exec.maxOpenExposure = first.Notional * 2.5
+
+	var rejected int
+	for i := 0; i < 4; i++ {
+		result := exec.executeLive(arbitrage.CreateTestOpportunity("market-2", "slug-2"))
+		if errors.Is(result.Error, ErrExposureLimit) {
+			rejected++
+			continue
+		}
+		if !result.Success {
+			t.Fatalf("unexpected failure: %v", result.Error)
+		}
+	}
+
+	if rejected != 3 {
+		t.Errorf("expected 3 rejections after the cap was hit, got %d", rejected)
+	}
+
+	if want := first.Notional * 2; exec.OpenExposure()-want > 1e-9 || want-exec.OpenExposure() > 1e-9 {
+		t.Errorf("expected open exposure %f, got %f", want, exec.OpenExposure())
+	}
+
+	// Verification aborted by shutdown leaves its orders unsettled, so exposure is held
+	cancel()
+	exec.verifyWg.Wait()
+
+	if want := first.Notional * 2; !floatEquals(exec.OpenExposure(), want, 1e-9) {
+		t.Errorf("expected exposure %f held after aborted verification, got %f", want, exec.OpenExposure())
+	}
+}
+
+// TestExecuteLive_ExposureReleasedOnFill tests that a verified fill releases its exposure.
+func TestExecuteLive_ExposureReleasedOnFill(t *testing.T) {
+	exec := newLiveTestExecutor(&mockLiveClient{filled: true}, time.Second)
+	exec.ctx = context.Background()
+
+	result := exec.executeLive(arbitrage.CreateTestOpportunity("market-1", "slug-1"))
+	if !result.Success {
+		t.Fatalf("expected execution to succeed, got %v", result.Error)
+	}
+	exec.verifyWg.Wait()
+
+	if exec.OpenExposure() != 0 {
+		t.Errorf("expected exposure released after fill, got %f", exec.OpenExposure())
+	}
+}
+
+// TestExecuteLive_ExposureHeldAfterPartialVerification tests that orders left unfilled
+// and not canceled when verification times out keep their exposure, so the cap still
+// rejects the next opportunity.
+func TestExecuteLive_ExposureHeldAfterPartialVerification(t *testing.T) {
+	exec := newLiveTestExecutor(&mockLiveClient{filled: false}, 50*time.Millisecond)
+	exec.ctx = context.Background()
+
+	first := exec.executeLive(arbitrage.CreateTestOpportunity("market-1", "slug-1"))
+	if !first.Success {
+		t.Fatalf("expected first execution to succeed, got %v", first.Error)
+	}
+	exec.maxOpenExposure = first.Notional * 1.5
+	exec.verifyWg.Wait()
+
+	if got := exec.Stats().FillVerifications["partial"]; got != 1 {
+		t.Fatalf("expected 1 partial verification, got %d", got)
+	}
+	if !floatEquals(exec.OpenExposure(), first.Notional, 1e-9) {
+		t.Errorf("expected exposure %f held, got %f", first.Notional, exec.OpenExposure())
+	}
+
+	second := exec.executeLive(arbitrage.CreateTestOpportunity("market-2", "slug-2"))
+	if !errors.Is(second.Error, ErrExposureLimit) {
+		t.Errorf("expected ErrExposureLimit, got %v", second.Error)
+	}
+}
+
+// TestExecuteLive_ExposureReleasedOnPlacementFailure tests that a failed placement does
+// not leave its reservation behind.
+func TestExecuteLive_ExposureReleasedOnPlacementFailure(t *testing.T) {
+	exec := New(&Config{
+		Mode:               "live",
+		Logger:             zap.NewNop(),
+		OrderClient:        &mockReconcileClient{},
+		AggressionTicks:    1,
+		MaxOpenExposureUSD: 1000,
+	})
+	exec.ctx = context.Background()
+
+	result := exec.executeLive(arbitrage.CreateTestOpportunity("market-1", "slug-1"))
+	if result.Success {
+		t.Fatal("expected placement failure")
+	}
+
+	if exec.OpenExposure() != 0 {
+		t.Errorf("expected no open exposure after failed placement, got %f", exec.OpenExposure())
+	}
+}
@@ -3,19 +3,24 @@ package execution
 import (
 	"context"
 	"fmt"
+	"math/rand"
+	"strings"
 	"time"
 
+	"github.com/mselser95/polymarket-arb/pkg/tracing"
 	"github.com/mselser95/polymarket-arb/pkg/types"
 	"go.uber.org/zap"
 )
 
 // FillTracker verifies order fills with exponential backoff.
 type FillTracker struct {
-	orderClient    *OrderClient
+	orderClient    OrderQuerier
 	logger         *zap.Logger
 	initialBackoff time.Duration
 	maxBackoff     time.Duration
 	backoffMult    float64
+	jitter         float64
+	maxAttempts    int
 	fillTimeout    time.Duration
 }
 
@@ -24,12 +29,14 @@ type FillTrackerConfig struct {
 	InitialBackoff time.Duration
 	MaxBackoff     time.Duration
 	BackoffMult    float64
+	Jitter         float64 // Up to this fraction is added to each backoff (0.2 = up to +20%), so concurrent verifications don't poll in lockstep
+	MaxAttempts    int     // Poll rounds before giving up, independent of FillTimeout (0 = unlimited)
 	FillTimeout    time.Duration
 }
 
 // NewFillTracker creates a new FillTracker instance.
 func NewFillTracker(
-	orderClient *OrderClient,
+	orderClient OrderQuerier,
 	logger *zap.Logger,
 	cfg *FillTrackerConfig,
 ) *FillTracker {
@@ -39,11 +46,17 @@ func NewFillTracker(
 		initialBackoff: cfg.InitialBackoff,
 		maxBackoff:     cfg.MaxBackoff,
 		backoffMult:    cfg.BackoffMult,
+		jitter:         cfg.Jitter,
+		maxAttempts:    cfg.MaxAttempts,
 		fillTimeout:    cfg.FillTimeout,
 	}
 }
 
 // VerifyFills checks if all orders are 100% filled with exponential backoff.
+//
+// Orders in "delayed" status are queued for matching and may still fill, so rounds with
+// an order still delayed do not count toward MaxAttempts; only FillTimeout and ctx bound
+// them. An order reported "unmatched" failed its delayed match and is not polled again.
 func (ft *FillTracker) VerifyFills(
 	ctx context.Context,
 	orderIDs []string,
@@ -56,6 +69,7 @@ func (ft *FillTracker) VerifyFills(
 		return fillStatuses, err
 	}
 
+	logger := tracing.Logger(ctx, ft.logger)
 	startTime := time.Now()
 	timeout := time.NewTimer(ft.fillTimeout)
 	defer timeout.Stop()
@@ -73,24 +87,26 @@ func (ft *FillTracker) VerifyFills(
 
 	backoff := ft.initialBackoff
 	attempt := 1
+	countedAttempts := 0 // Rounds that count toward maxAttempts
+	wasDelayed := make([]bool, len(orderIDs))
 
 	for {
-		// Check if all orders are filled
-		allFilled := true
+		resolved := true
+		anyDelayed := false
 		for i := range fillStatuses {
-			if fillStatuses[i].FullyFilled {
-				continue // Already verified
+			if fillStatuses[i].FullyFilled || fillStatuses[i].Error != nil {
+				continue // Already verified, or unmatched
 			}
 
 			// Query order status
 			orderResp, queryErr := ft.orderClient.GetOrder(ctx, orderIDs[i])
 			if queryErr != nil {
 				// Log error but continue retrying (transient errors)
-				ft.logger.Warn("order-query-failed-retrying",
+				logger.Warn("order-query-failed-retrying",
 					zap.String("order-id", orderIDs[i]),
 					zap.Error(queryErr),
 					zap.Int("attempt", attempt))
-				allFilled = false
+				resolved = false
 				continue
 			}
 
@@ -104,15 +120,38 @@ func (ft *FillTracker) VerifyFills(
 			tolerance := 0.001
 			if orderResp.SizeFilled >= orderResp.Size-tolerance {
 				fillStatuses[i].FullyFilled = true
-				ft.logger.Info("order-fully-filled",
+				ft.recordActualFee(ctx, &fillStatuses[i], orderResp.AssociateTrades)
+				if wasDelayed[i] {
+					DelayedOrdersTotal.WithLabelValues("filled").Inc()
+				}
+				logger.Info("order-fully-filled",
 					zap.String("order-id", orderIDs[i]),
 					zap.String("outcome", outcomes[i]),
 					zap.Float64("size-filled", orderResp.SizeFilled),
 					zap.Float64("actual-price", orderResp.Price),
+					zap.Bool("was-delayed", wasDelayed[i]),
 					zap.Duration("duration", time.Since(startTime)))
+			} else if strings.EqualFold(orderResp.Status, types.OrderStatusUnmatched) {
+				// Terminal: the delayed match failed, nothing more will fill
+				fillStatuses[i].Error = fmt.Errorf("order unmatched after delayed matching")
+				DelayedOrdersTotal.WithLabelValues("unmatched").Inc()
+				logger.Warn("order-unmatched",
+					zap.String("order-id", orderIDs[i]),
+					zap.String("outcome", outcomes[i]),
+					zap.Float64("size-filled", orderResp.SizeFilled),
+					zap.Float64("size-expected", orderResp.Size))
 			} else {
-				allFilled = false
-				ft.logger.Debug("order-not-yet-filled",
+				resolved = false
+				if strings.EqualFold(orderResp.Status, types.OrderStatusDelayed) {
+					anyDelayed = true
+					if !wasDelayed[i] {
+						wasDelayed[i] = true
+						logger.Info("order-delayed-polling",
+							zap.String("order-id", orderIDs[i]),
+							zap.String("outcome", outcomes[i]))
+					}
+				}
+				logger.Debug("order-not-yet-filled",
 					zap.String("order-id", orderIDs[i]),
 					zap.String("outcome", outcomes[i]),
 					zap.Float64("size-filled", orderResp.SizeFilled),
@@ -121,44 +160,65 @@ func (ft *FillTracker) VerifyFills(
 			}
 		}
 
-		if allFilled {
-			ft.logger.Info("all-orders-fully-filled",
+		// Legs unmatched in an earlier round are skipped above, so check every leg
+		if allFullyFilled(fillStatuses) {
+			logger.Info("all-orders-fully-filled",
+				zap.Int("order-count", len(orderIDs)),
+				zap.Duration("total-duration", time.Since(startTime)),
+				zap.Int("attempts", attempt))
+			return fillStatuses, nil
+		}
+
+		if resolved {
+			logger.Warn("fill-verification-resolved-unfilled",
 				zap.Int("order-count", len(orderIDs)),
 				zap.Duration("total-duration", time.Since(startTime)),
 				zap.Int("attempts", attempt))
 			return fillStatuses, nil
 		}
 
+		if !anyDelayed {
+			countedAttempts++
+		}
+
+		if ft.maxAttempts > 0 && countedAttempts >= ft.maxAttempts {
+			logger.Warn("fill-verification-attempts-exhausted",
+				zap.Int("order-count", len(orderIDs)),
+				zap.Int("attempts", attempt),
+				zap.Duration("elapsed", time.Since(startTime)))
+
+			ft.markUnfilled(fillStatuses, wasDelayed, fmt.Errorf("fill verification gave up after %d attempts", attempt))
+			return fillStatuses, nil
+		}
+
+		wait := jitteredBackoff(backoff, ft.jitter)
+
 		// Wait with exponential backoff
 		select {
 		case <-timeout.C:
 			// Timeout reached
-			ft.logger.Warn("fill-verification-timeout",
+			logger.Warn("fill-verification-timeout",
 				zap.Int("order-count", len(orderIDs)),
 				zap.Duration("timeout", ft.fillTimeout),
 				zap.Int("attempts", attempt))
 
 			// Mark unfilled orders with error
-			for i := range fillStatuses {
-				if !fillStatuses[i].FullyFilled {
-					fillStatuses[i].Error = fmt.Errorf("fill verification timeout after %s", ft.fillTimeout)
-				}
-			}
+			ft.markUnfilled(fillStatuses, wasDelayed, fmt.Errorf("fill verification timeout after %s", ft.fillTimeout))
 			return fillStatuses, nil
 
 		case <-ctx.Done():
 			// Context canceled
-			ft.logger.Warn("fill-verification-canceled",
+			logger.Warn("fill-verification-canceled",
 				zap.Error(ctx.Err()),
 				zap.Int("attempts", attempt))
 			return fillStatuses, ctx.Err()
 
-		case <-time.After(backoff):
+		case <-time.After(wait):
 			// Continue to next attempt
 			attempt++
-			ft.logger.Debug("fill-verification-retry",
+			logger.Debug("fill-verification-retry",
 				zap.Int("attempt", attempt),
-				zap.Duration("backoff", backoff))
+				zap.Duration("backoff", wait))
 
 			// Exponential backoff with cap
 			backoff = time.Duration(float64(backoff) * ft.backoffMult)
@@ -168,3 +228,57 @@ func (ft *FillTracker) VerifyFills(
 		}
 	}
 }
+
+// allFullyFilled reports whether every order is fully filled.
+func allFullyFilled(fillStatuses []types.FillStatus) bool {
+	for i := range fillStatuses {
+		if !fillStatuses[i].FullyFilled {
+			return false
+		}
+	}
+	return true
+}
+
+// markUnfilled sets err on every order still pending when verification gives up, and
+// counts orders that were still queued for delayed matching.
+func (ft *FillTracker) markUnfilled(fillStatuses []types.FillStatus, wasDelayed []bool, err error) {
+	for i := range fillStatuses {
+		if fillStatuses[i].FullyFilled || fillStatuses[i].Error != nil {
+			continue
+		}
+
+		fillStatuses[i].Error = err
+		if wasDelayed[i] && strings.EqualFold(fillStatuses[i].Status, types.OrderStatusDelayed) {
+			DelayedOrdersTotal.WithLabelValues("unresolved").Inc()
+		}
+	}
+}
+
+// jitteredBackoff returns base stretched by a random fraction in [0, jitter), so the
+// result lies in [base, base*(1+jitter)].
+func jitteredBackoff(base time.Duration, jitter float64) time.Duration {
+	if jitter <= 0 {
+		return base
+	}
+	return time.Duration(float64(base) * (1.0 + rand.Float64()*jitter))
+}
+
+// recordActualFee fills in the fees actually charged for a filled order from its trades.
+// Without a trade querier or trade data the fee stays unset and profit uses the estimate.
+func (ft *FillTracker) recordActualFee(ctx context.Context, fill *types.FillStatus, tradeIDs []string) {
+	querier, ok := ft.orderClient.(TradeQuerier)
+	if !ok || len(tradeIDs) == 0 {
+		return
+	}
+
+	fee, err := actualFeePaid(ctx, querier, fill.OrderID, tradeIDs)
+	if err != nil {
+		tracing.Logger(ctx, ft.logger).Warn("actual-fee-unavailable-using-estimate",
+			zap.String("order-id", fill.OrderID),
+			zap.Error(err))
+		return
+	}
+
+	fill.ActualFeePaid = fee
+	fill.FeeFromTrades = true
+}
@@ -1,11 +1,14 @@
 package execution
 
 import (
+	"context"
 	"errors"
 	"testing"
 	"time"
 
+	promtestutil "github.com/prometheus/client_golang/prometheus/testutil"
 	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
 
 	"github.com/mselser95/polymarket-arb/pkg/types"
 )
@@ -546,3 +549,258 @@ func hasSubstringMatch(s, substr string) bool {
 	}
 	return false
 }
+
+// TestJitteredBackoff_WithinBounds tests that jittered intervals stay within [base, base*(1+jitter)]
+// and actually vary, so concurrent verifications spread out.
+func TestJitteredBackoff_WithinBounds(t *testing.T) {
+	tests := []struct {
+		name   string
+		base   time.Duration
+		jitter float64
+	}{
+		{name: "no-jitter", base: 2 * time.Second, jitter: 0},
+		{name: "20-percent", base: 2 * time.Second, jitter: 0.2},
+		{name: "full", base: 100 * time.Millisecond, jitter: 1.0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			upper := time.Duration(float64(tt.base) * (1 + tt.jitter))
+			seen := make(map[time.Duration]bool)
+
+			for range 1000 {
+				got := jitteredBackoff(tt.base, tt.jitter)
+				if got < tt.base || got > upper {
+					t.Fatalf("interval %v outside [%v, %v]", got, tt.base, upper)
+				}
+				seen[got] = true
+			}
+
+			if tt.jitter == 0 && len(seen) != 1 {
+				t.Errorf("expected a fixed interval without jitter, got %d distinct", len(seen))
+			}
+			if tt.jitter > 0 && len(seen) < 10 {
+				t.Errorf("expected varied intervals with jitter, got %d distinct", len(seen))
+			}
+		})
+	}
+}
+
+// countingQuerier reports orders as never filled and counts GetOrder calls.
+type countingQuerier struct {
+	calls int
+}
+
+func (q *countingQuerier) GetOrder(_ context.Context, orderID string) (*types.OrderQueryResponse, error) {
+	q.calls++
+	return &types.OrderQueryResponse{OrderID: orderID, Status: "LIVE", Size: 10}, nil
+}
+
+// TestVerifyFills_MaxAttempts tests that polling stops after MaxAttempts rounds even though
+// the time budget is far from spent.
+func TestVerifyFills_MaxAttempts(t *testing.T) {
+	tests := []struct {
+		name        string
+		maxAttempts int
+		wantCalls   int
+	}{
+		{name: "capped-at-3", maxAttempts: 3, wantCalls: 3 * 2},
+		{name: "single-attempt", maxAttempts: 1, wantCalls: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			querier := &countingQuerier{}
+			tracker := NewFillTracker(querier, zap.NewNop(), &FillTrackerConfig{
+				InitialBackoff: time.Millisecond,
+				MaxBackoff:     2 * time.Millisecond,
+				BackoffMult:    2.0,
+				Jitter:         0.5,
+				MaxAttempts:    tt.maxAttempts,
+				FillTimeout:    time.Minute,
+			})
+
+			start := time.Now()
+			fills, err := tracker.VerifyFills(context.Background(),
+				[]string{"order-1", "order-2"}, []string{"YES", "NO"}, []float64{10, 10})
+			if err != nil {
+				t.Fatalf("verify fills: %v", err)
+			}
+
+			if querier.calls != tt.wantCalls {
+				t.Errorf("expected %d GetOrder calls, got %d", tt.wantCalls, querier.calls)
+			}
+			if time.Since(start) > 5*time.Second {
+				t.Error("expected the attempt cap to end verification long before the timeout")
+			}
+			for _, fill := range fills {
+				if fill.FullyFilled || fill.Error == nil {
+					t.Errorf("expected unfilled order with an error, got %+v", fill)
+				}
+			}
+		})
+	}
+}
+
+// sequenceQuerier replays a fixed progression of order states per order ID, repeating
+// the last state once the progression is exhausted.
+type sequenceQuerier struct {
+	states map[string][]types.OrderQueryResponse
+	calls  map[string]int
+}
+
+func (q *sequenceQuerier) GetOrder(_ context.Context, orderID string) (*types.OrderQueryResponse, error) {
+	if q.calls == nil {
+		q.calls = make(map[string]int)
+	}
+
+	states := q.states[orderID]
+	n := q.calls[orderID]
+	q.calls[orderID]++
+	if n >= len(states) {
+		n = len(states) - 1
+	}
+
+	resp := states[n]
+	resp.OrderID = orderID
+	return &resp, nil
+}
+
+func newDelayedTestTracker(querier OrderQuerier, maxAttempts int, fillTimeout time.Duration) *FillTracker {
+	return NewFillTracker(querier, zap.NewNop(), &FillTrackerConfig{
+		InitialBackoff: time.Millisecond,
+		MaxBackoff:     time.Millisecond,
+		BackoffMult:    1.0,
+		MaxAttempts:    maxAttempts,
+		FillTimeout:    fillTimeout,
+	})
+}
+
+// TestVerifyFills_DelayedThenFilled tests that a delayed order keeps being polled past
+// MaxAttempts through delayed -> matched (partial) -> fully filled.
+func TestVerifyFills_DelayedThenFilled(t *testing.T) {
+	querier := &sequenceQuerier{states: map[string][]types.OrderQueryResponse{
+		"order-1": {
+			{Status: "DELAYED", Size: 10, Price: 0.48},
+			{Status: "DELAYED", Size: 10, Price: 0.48},
+			{Status: "DELAYED", Size: 10, Price: 0.48},
+			{Status: "MATCHED", Size: 10, SizeFilled: 4, Price: 0.48},
+			{Status: "MATCHED", Size: 10, SizeFilled: 10, Price: 0.48},
+		},
+		"order-2": {{Status: "MATCHED", Size: 10, SizeFilled: 10, Price: 0.51}},
+	}}
+	filledBefore := promtestutil.ToFloat64(DelayedOrdersTotal.WithLabelValues("filled"))
+
+	// Two attempts would give up on a live order; the delayed one runs to its fill
+	tracker := newDelayedTestTracker(querier, 2, time.Minute)
+	fills, err := tracker.VerifyFills(context.Background(),
+		[]string{"order-1", "order-2"}, []string{"YES", "NO"}, []float64{10, 10})
+	if err != nil {
+		t.Fatalf("verify fills: %v", err)
+	}
+
+	for _, fill := range fills {
+		if !fill.FullyFilled || fill.Error != nil {
+			t.Errorf("expected %s fully filled, got %+v", fill.OrderID, fill)
+		}
+	}
+	if querier.calls["order-1"] != 5 || querier.calls["order-2"] != 1 {
+		t.Errorf("expected 5 polls of the delayed order and 1 of the matched one, got %v", querier.calls)
+	}
+	if got := promtestutil.ToFloat64(DelayedOrdersTotal.WithLabelValues("filled")) - filledBefore; got != 1 {
+		t.Errorf("expected delayed filled counter +1, got %.0f", got)
+	}
+}
+
+// TestVerifyFills_DelayedOutcomes tests how delayed orders end when they do not fill: an
+// unmatched order stops polling at once, and one still delayed at the timeout keeps its
+// delayed status rather than reading as a live partial.
+func TestVerifyFills_DelayedOutcomes(t *testing.T) {
+	tests := []struct {
+		name       string
+		states     []types.OrderQueryResponse
+		wantStatus string
+		wantResult string
+		wantCalls  int // 0 = polled until the timeout
+	}{
+		{
+			name: "unmatched",
+			states: []types.OrderQueryResponse{
+				{Status: "delayed", Size: 10},
+				{Status: "unmatched", Size: 10},
+			},
+			wantStatus: "unmatched",
+			wantResult: "unmatched",
+			wantCalls:  2,
+		},
+		{
+			name:       "still_delayed_at_timeout",
+			states:     []types.OrderQueryResponse{{Status: "delayed", Size: 10}},
+			wantStatus: "delayed",
+			wantResult: "unresolved",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			querier := &sequenceQuerier{states: map[string][]types.OrderQueryResponse{"order-1": tt.states}}
+			before := promtestutil.ToFloat64(DelayedOrdersTotal.WithLabelValues(tt.wantResult))
+
+			tracker := newDelayedTestTracker(querier, 1, 50*time.Millisecond)
+			fills, err := tracker.VerifyFills(context.Background(),
+				[]string{"order-1"}, []string{"YES"}, []float64{10})
+			if err != nil {
+				t.Fatalf("verify fills: %v", err)
+			}
+
+			fill := fills[0]
+			if fill.FullyFilled || fill.Error == nil || fill.Status != tt.wantStatus {
+				t.Errorf("expected unfilled %s order with an error, got %+v", tt.wantStatus, fill)
+			}
+			if tt.wantCalls > 0 && querier.calls["order-1"] != tt.wantCalls {
+				t.Errorf("expected %d polls, got %d", tt.wantCalls, querier.calls["order-1"])
+			}
+			if tt.wantCalls == 0 && querier.calls["order-1"] < 3 {
+				t.Errorf("expected polling past MaxAttempts until the timeout, got %d polls", querier.calls["order-1"])
+			}
+			if got := promtestutil.ToFloat64(DelayedOrdersTotal.WithLabelValues(tt.wantResult)) - before; got != 1 {
+				t.Errorf("expected delayed %s counter +1, got %.0f", tt.wantResult, got)
+			}
+		})
+	}
+}
+
+// TestVerifyFills_UnmatchedLegNotAllFilled tests that a leg unmatched in an earlier round
+// keeps the verification from reporting every order filled once the other legs fill.
+func TestVerifyFills_UnmatchedLegNotAllFilled(t *testing.T) {
+	querier := &sequenceQuerier{states: map[string][]types.OrderQueryResponse{
+		"order-1": {{Status: "unmatched", Size: 10}},
+		"order-2": {
+			{Status: "LIVE", Size: 10, Price: 0.51},
+			{Status: "MATCHED", Size: 10, SizeFilled: 10, Price: 0.51},
+		},
+	}}
+	core, logs := observer.New(zap.InfoLevel)
+
+	tracker := NewFillTracker(querier, zap.New(core), &FillTrackerConfig{
+		InitialBackoff: time.Millisecond,
+		MaxBackoff:     time.Millisecond,
+		BackoffMult:    1.0,
+		FillTimeout:    time.Minute,
+	})
+	fills, err := tracker.VerifyFills(context.Background(),
+		[]string{"order-1", "order-2"}, []string{"YES", "NO"}, []float64{10, 10})
+	if err != nil {
+		t.Fatalf("verify fills: %v", err)
+	}
+
+	if fills[0].FullyFilled || fills[0].Error == nil || !fills[1].FullyFilled {
+		t.Errorf("expected the YES leg unmatched and the NO leg filled, got %+v", fills)
+	}
+	if logs.FilterMessage("all-orders-fully-filled").Len() != 0 {
+		t.Error("expected verification not to report all orders filled")
+	}
+	if logs.FilterMessage("fill-verification-resolved-unfilled").Len() != 1 {
+		t.Error("expected verification to end resolved unfilled")
+	}
+}
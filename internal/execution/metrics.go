@@ -15,9 +15,10 @@ var (
 		[]string{"mode", "outcome"},
 	)
 
-	// ProfitRealizedUSD tracks cumulative profit.
-	ProfitRealizedUSD = promauto.NewCounterVec(
-		prometheus.CounterOpts{
+	// ProfitRealizedUSD tracks cumulative profit. A gauge because fully filled trades can
+	// lose money after slippage and fees.
+	ProfitRealizedUSD = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
 			Name: "polymarket_execution_profit_realized_usd",
 			Help: "Cumulative profit realized (hypothetical for paper trading)",
 		},
@@ -52,12 +53,24 @@ var (
 		Help: "Total number of arbitrage opportunities received for execution",
 	})
 
+	// OpportunitiesObserved tracks opportunities consumed in observe mode without execution.
+	OpportunitiesObserved = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "polymarket_execution_opportunities_observed_total",
+		Help: "Total number of arbitrage opportunities observed without execution (observe mode)",
+	})
+
 	// OpportunitiesExecuted tracks successfully executed opportunities.
 	OpportunitiesExecuted = promauto.NewCounter(prometheus.CounterOpts{
 		Name: "polymarket_execution_opportunities_executed_total",
 		Help: "Total number of opportunities successfully executed",
 	})
 
+	// OpportunitiesExpiredTotal tracks opportunities discarded for exceeding the max age at execution.
+	OpportunitiesExpiredTotal = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "polymarket_execution_opportunities_expired_total",
+		Help: "Total number of opportunities discarded for being older than the max opportunity age at execution",
+	})
+
 	// OpportunitiesSkippedTotal tracks opportunities skipped for various reasons.
 	OpportunitiesSkippedTotal = promauto.NewCounterVec(
 		prometheus.CounterOpts{
@@ -71,11 +84,42 @@ var (
 	FillVerificationTotal = promauto.NewCounterVec(
 		prometheus.CounterOpts{
 			Name: "polymarket_execution_fill_verification_total",
-			Help: "Total fill verification attempts by result (success, partial, timeout)",
+			Help: "Total fill verification attempts by result (success, unhedged, partial, delayed, error, aborted)",
+		},
+		[]string{"result"},
+	)
+
+	// UnfilledOrderCancelsTotal tracks unfilled orders canceled after fill verification
+	// ended without every leg filled.
+	UnfilledOrderCancelsTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "polymarket_execution_unfilled_order_cancels_total",
+			Help: "Unfilled orders canceled after fill verification by result (canceled, failed)",
 		},
 		[]string{"result"},
 	)
 
+	// DelayedOrdersTotal tracks orders queued for delayed matching by how verification saw them end.
+	DelayedOrdersTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "polymarket_execution_delayed_orders_total",
+			Help: "Total orders seen in delayed status during fill verification by result",
+		},
+		[]string{"result"}, // filled, unmatched, unresolved
+	)
+
+	// FillVerificationsActive tracks fill verifications currently polling order status.
+	FillVerificationsActive = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "polymarket_execution_fill_verifications_active",
+		Help: "Number of live fill verifications currently polling order status",
+	})
+
+	// FillVerificationsQueued tracks fill verifications waiting for a concurrency slot.
+	FillVerificationsQueued = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "polymarket_execution_fill_verifications_queued",
+		Help: "Number of live fill verifications waiting for a free slot (EXECUTION_FILL_MAX_CONCURRENT)",
+	})
+
 	// FillVerificationDurationSeconds tracks fill verification duration.
 	FillVerificationDurationSeconds = promauto.NewHistogram(prometheus.HistogramOpts{
 		Name:    "polymarket_execution_fill_verification_duration_seconds",
@@ -89,4 +133,137 @@ var (
 		Help:    "Difference between expected and actual fill price",
 		Buckets: prometheus.LinearBuckets(-0.01, 0.001, 20),
 	})
+
+	// ProfitDeviationUSD tracks actual minus expected profit of fully filled live trades.
+	ProfitDeviationUSD = promauto.NewHistogram(prometheus.HistogramOpts{
+		Name:    "polymarket_execution_profit_deviation_usd",
+		Help:    "Actual minus expected profit in USD of fully filled live trades (negative = underperformed)",
+		Buckets: []float64{-5, -1, -0.5, -0.25, -0.1, -0.05, -0.01, 0, 0.01, 0.05, 0.1, 0.5, 1},
+	})
+
+	// ProfitShortfallTotal tracks fully filled live trades that made less than expected.
+	ProfitShortfallTotal = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "polymarket_execution_profit_shortfall_total",
+		Help: "Total number of fully filled live trades whose actual profit fell short of the expected profit",
+	})
+
+	// PaperPartialFillsTotal tracks paper trades the ask ladder could not fully fill.
+	PaperPartialFillsTotal = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "polymarket_execution_paper_partial_fills_total",
+		Help: "Total number of paper trades with realistic fills where the ask depth could not fill every leg",
+	})
+
+	// TickSizeUnknownTotal tracks orders built without a resolved tick size.
+	TickSizeUnknownTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "polymarket_execution_tick_size_unknown_total",
+			Help: "Total number of orders with an unknown tick size",
+		},
+		[]string{"action"}, // rejected (strict), defaulted (lenient)
+	)
+
+	// OrderHashChecksTotal tracks local EIP-712 hash vs API order ID comparisons.
+	OrderHashChecksTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "polymarket_execution_order_hash_checks_total",
+			Help: "Total number of accepted orders whose API order ID was checked against the local EIP-712 hash",
+		},
+		[]string{"result"}, // match, mismatch
+	)
+
+	// ClockSkewRejectionsTotal tracks signed requests rejected for their timestamp.
+	ClockSkewRejectionsTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "polymarket_execution_clock_skew_rejections_total",
+			Help: "Total number of signed CLOB requests rejected for a skewed timestamp",
+		},
+		[]string{"action"}, // synced (offset updated and retried), unsynced
+	)
+
+	// OrderSubmissionStatusTotal tracks the status of each accepted live order on submission.
+	OrderSubmissionStatusTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "polymarket_execution_order_submission_status_total",
+			Help: "Total number of accepted live orders by status returned on submission",
+		},
+		[]string{"status"}, // matched, live, delayed, unmatched, unknown
+	)
+
+	// SelfTradeConflictsTotal tracks opportunities that found our own open orders on their tokens.
+	SelfTradeConflictsTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "polymarket_execution_self_trade_conflicts_total",
+			Help: "Total number of live opportunities with our own open orders on a target token",
+		},
+		[]string{"action"}, // canceled, cancel_failed, skipped
+	)
+
+	// OpenExposureUSD tracks notional of live orders placed but not yet settled.
+	OpenExposureUSD = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "polymarket_execution_open_exposure_usd",
+		Help: "USD notional of live orders awaiting fill verification",
+	})
+
+	// ExecutionQueueDepth tracks opportunities buffered for execution.
+	ExecutionQueueDepth = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "polymarket_execution_queue_depth",
+		Help: "Opportunities buffered for execution, served highest net profit first",
+	})
+
+	// ReconciledTradesTotal tracks pending live trades from a previous run settled on startup.
+	ReconciledTradesTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "polymarket_execution_reconciled_trades_total",
+			Help: "Total number of unverified live trades from a previous run reconciled on startup",
+		},
+		[]string{"outcome"}, // filled, partial, canceled, error
+	)
+
+	// CompleteSetsDetectedTotal tracks complete sets found by the complete set monitor.
+	CompleteSetsDetectedTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "polymarket_execution_complete_sets_detected_total",
+			Help: "Total complete sets held across every outcome of a market, found per monitor check",
+		},
+		[]string{"action"}, // sell, redeem
+	)
+
+	// ResolvedConditionsTotal tracks resolved conditions found by the resolution monitor.
+	ResolvedConditionsTotal = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "polymarket_execution_resolved_conditions_total",
+		Help: "Total resolved conditions found among held positions",
+	})
+
+	// RedemptionsTotal tracks automatic redemptions of resolved conditions.
+	RedemptionsTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "polymarket_execution_redemptions_total",
+			Help: "Total automatic redemptions of resolved conditions by result",
+		},
+		[]string{"result"}, // redeemed, skipped, error
+	)
+
+	// RedeemedValueUSD tracks the USD value of positions redeemed automatically.
+	RedeemedValueUSD = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "polymarket_execution_redeemed_value_usd_total",
+		Help: "Total USD value of winning positions redeemed automatically",
+	})
+
+	// RepriceAttemptsTotal tracks reprice-and-retry decisions after stale-price rejections.
+	RepriceAttemptsTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "polymarket_execution_reprice_attempts_total",
+			Help: "Total reprice decisions after stale-price order rejections",
+		},
+		[]string{"result"}, // retried, aborted
+	)
+
+	// OrderRejectionsTotal tracks CLOB order rejections by code and the strategy applied.
+	OrderRejectionsTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "polymarket_execution_order_rejections_total",
+			Help: "Total CLOB order rejections by rejection code and handling strategy",
+		},
+		[]string{"code", "strategy"}, // strategy: retry, skip_market, disable_trading
+	)
 )
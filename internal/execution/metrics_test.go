@@ -1,7 +1,17 @@
 package execution
 
 import (
+	"context"
+	"math"
 	"testing"
+	"time"
+
+	"github.com/mselser95/polymarket-arb/internal/arbitrage"
+	"github.com/mselser95/polymarket-arb/pkg/types"
+	"github.com/prometheus/client_golang/prometheus"
+	promtestutil "github.com/prometheus/client_golang/prometheus/testutil"
+	dto "github.com/prometheus/client_model/go"
+	"go.uber.org/zap"
 )
 
 // TestMetrics_Registration tests all metrics are initialized
@@ -70,3 +80,52 @@ func TestMetrics_Labels(t *testing.T) {
 	OpportunitiesSkippedTotal.WithLabelValues("circuit_breaker").Inc()
 	OpportunitiesSkippedTotal.WithLabelValues("validation_failed").Inc()
 }
+
+// histogramSample returns the observation count and sum of a histogram.
+func histogramSample(t *testing.T, h prometheus.Histogram) (count uint64, sum float64) {
+	t.Helper()
+
+	var m dto.Metric
+	err := h.Write(&m)
+	if err != nil {
+		t.Fatalf("read histogram: %v", err)
+	}
+
+	return m.GetHistogram().GetSampleCount(), m.GetHistogram().GetSampleSum()
+}
+
+// TestVerifyFills_ObservesProfitDeviation tests that a fully filled trade records actual
+// minus expected profit and counts a shortfall. 10 tokens at 0.50 + 0.52 without fees
+// lose 0.20 against an expected profit of 0.30: a deviation of -0.50.
+func TestVerifyFills_ObservesProfitDeviation(t *testing.T) {
+	exec := New(&Config{Mode: "live", Logger: zap.NewNop()})
+	exec.ctx = context.Background()
+
+	countBefore, sumBefore := histogramSample(t, ProfitDeviationUSD)
+	shortfallsBefore := promtestutil.ToFloat64(ProfitShortfallTotal)
+
+	fills := []*types.FillStatus{
+		{OrderID: "order-0", Outcome: "YES", SizeFilled: 10, ActualPrice: 0.50, FullyFilled: true},
+		{OrderID: "order-1", Outcome: "NO", SizeFilled: 10, ActualPrice: 0.52, FullyFilled: true},
+	}
+	exec.verifyFillsAndUpdateMetrics(
+		[]string{"order-0", "order-1"},
+		[]string{"YES", "NO"},
+		[]float64{10, 10},
+		fills,
+		[]float64{0.50, 0.52},
+		arbitrage.CreateTestOpportunity("test-market", "test-slug"),
+		0.30,
+		time.Now())
+
+	count, sum := histogramSample(t, ProfitDeviationUSD)
+	if count-countBefore != 1 {
+		t.Fatalf("expected 1 deviation observation, got %d", count-countBefore)
+	}
+	if got := sum - sumBefore; math.Abs(got-(-0.50)) > 1e-9 {
+		t.Errorf("expected deviation -0.50, got %f", got)
+	}
+	if got := promtestutil.ToFloat64(ProfitShortfallTotal) - shortfallsBefore; got != 1 {
+		t.Errorf("expected 1 shortfall, got %f", got)
+	}
+}
@@ -0,0 +1,48 @@
+package execution
+
+import (
+	"math"
+	"strconv"
+)
+
+// USD is an amount of money in micro-dollars, the base unit of USDC. Profit is
+// accumulated in USD so thousands of small additions don't drift the way a float64 sum
+// does; each amount is rounded once, on the way in.
+type USD int64
+
+// usdDecimals is the number of decimal places a USD amount holds.
+const usdDecimals = 6
+
+// microsPerDollar is the number of USD units in one dollar.
+const microsPerDollar = 1_000_000
+
+// USDFromFloat converts a dollar amount to USD, rounding to the nearest micro-dollar.
+// NaN converts to zero.
+func USDFromFloat(dollars float64) USD {
+	if math.IsNaN(dollars) {
+		return 0
+	}
+
+	return USD(math.Round(dollars * microsPerDollar))
+}
+
+// Float64 returns the amount in dollars.
+func (u USD) Float64() float64 {
+	return float64(u) / microsPerDollar
+}
+
+// Round returns the amount in dollars rounded half away from zero to decimals places.
+// Decimals outside 0-6 report the full micro-dollar precision.
+func (u USD) Round(decimals int) float64 {
+	if decimals < 0 || decimals >= usdDecimals {
+		return u.Float64()
+	}
+
+	unit := math.Pow10(usdDecimals - decimals)
+	return math.Round(float64(u)/unit) / math.Pow10(decimals)
+}
+
+// String formats the amount in dollars with all six decimal places.
+func (u USD) String() string {
+	return strconv.FormatFloat(u.Float64(), 'f', usdDecimals, 64)
+}
@@ -0,0 +1,128 @@
+package execution
+
+import (
+	"math"
+	"testing"
+
+	promtestutil "github.com/prometheus/client_golang/prometheus/testutil"
+
+	"go.uber.org/zap"
+)
+
+func TestUSDFromFloat(t *testing.T) {
+	tests := []struct {
+		name    string
+		dollars float64
+		want    USD
+	}{
+		{name: "whole", dollars: 12, want: 12_000_000},
+		{name: "tenth", dollars: 0.1, want: 100_000},
+		{name: "micro", dollars: 0.000001, want: 1},
+		{name: "below_micro", dollars: 0.0000004, want: 0},
+		{name: "half_micro_rounds_away", dollars: 0.0000005, want: 1},
+		{name: "negative", dollars: -2.5, want: -2_500_000},
+		{name: "nan", dollars: math.NaN(), want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := USDFromFloat(tt.dollars); got != tt.want {
+				t.Errorf("expected %d, got %d", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestUSD_Round(t *testing.T) {
+	tests := []struct {
+		name     string
+		amount   USD
+		decimals int
+		want     float64
+	}{
+		{name: "two_decimals", amount: 1_234_567, decimals: 2, want: 1.23},
+		{name: "four_decimals", amount: 1_234_567, decimals: 4, want: 1.2346},
+		{name: "full_precision", amount: 1_234_567, decimals: 6, want: 1.234567},
+		{name: "whole_dollars", amount: 1_500_000, decimals: 0, want: 2},
+		{name: "half_cent_rounds_away", amount: 5_000, decimals: 2, want: 0.01},
+		{name: "negative_half_cent_rounds_away", amount: -5_000, decimals: 2, want: -0.01},
+		{name: "negative", amount: -1_234_567, decimals: 2, want: -1.23},
+		{name: "out_of_range", amount: 1_234_567, decimals: 9, want: 1.234567},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.amount.Round(tt.decimals); got != tt.want {
+				t.Errorf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestUSD_String(t *testing.T) {
+	if got := USD(1_234_567).String(); got != "1.234567" {
+		t.Errorf("expected 1.234567, got %s", got)
+	}
+	if got := USD(-1).String(); got != "-0.000001" {
+		t.Errorf("expected -0.000001, got %s", got)
+	}
+}
+
+// TestUSD_NoDrift tests that many small additions sum exactly, where the same float64
+// sum drifts.
+func TestUSD_NoDrift(t *testing.T) {
+	const additions = 10_000
+
+	var floatTotal float64
+	var total USD
+	for i := 0; i < additions; i++ {
+		floatTotal += 0.1
+		total += USDFromFloat(0.1)
+	}
+
+	if floatTotal == 1000 {
+		t.Fatalf("expected float64 sum to drift from 1000")
+	}
+	if total.Float64() != 1000 {
+		t.Errorf("expected exactly 1000, got %v", total.Float64())
+	}
+}
+
+// TestRecordProfit_NoDrift tests that cumulative profit, stats, and the realized profit
+// gauge stay exact over many small trades, and are reported at the configured precision.
+func TestRecordProfit_NoDrift(t *testing.T) {
+	exec := New(&Config{Mode: "paper", Logger: zap.NewNop(), ProfitReportDecimals: 2})
+
+	// A mode label no other test uses, since the gauge is shared
+	const mode = "no-drift-test"
+
+	exec.mu.Lock()
+	for i := 0; i < 10_000; i++ {
+		exec.recordProfit(mode, 0.01)
+		exec.recordProfit(mode, 0.003)
+	}
+	exec.recordProfit(mode, -0.000001)
+	exec.mu.Unlock()
+
+	if exec.cumulativeProfit != 129_999_999 {
+		t.Errorf("expected exactly 129999999 micro-dollars, got %d", exec.cumulativeProfit)
+	}
+	if got := promtestutil.ToFloat64(ProfitRealizedUSD.WithLabelValues(mode)); got != 129.999999 {
+		t.Errorf("expected realized profit gauge 129.999999, got %v", got)
+	}
+
+	// Reports round to two decimals
+	if got := exec.CumulativeProfit(); got != 130 {
+		t.Errorf("expected reported profit 130, got %v", got)
+	}
+	if got := exec.Stats().CumulativeProfit; got != 130 {
+		t.Errorf("expected stats profit 130, got %v", got)
+	}
+}
+
+func TestNew_ProfitReportDecimalsDefault(t *testing.T) {
+	exec := New(&Config{Mode: "paper", Logger: zap.NewNop()})
+	if exec.profitDecimals != usdDecimals {
+		t.Errorf("expected default %d decimals, got %d", usdDecimals, exec.profitDecimals)
+	}
+}
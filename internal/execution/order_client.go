@@ -1,27 +1,26 @@
 package execution
 
 import (
-	"bytes"
+	"cmp"
 	"context"
-	"crypto/ecdsa"
-	"crypto/hmac"
-	"crypto/sha256"
-	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"fmt"
-	"io"
 	"math"
 	"math/big"
 	"net/http"
+	"net/url"
+	"slices"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
-	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/polymarket/go-order-utils/pkg/builder"
 	"github.com/polymarket/go-order-utils/pkg/model"
 	"go.uber.org/zap"
 
+	"github.com/mselser95/polymarket-arb/pkg/tracing"
 	"github.com/mselser95/polymarket-arb/pkg/types"
 )
 
@@ -38,22 +37,109 @@ type OrderPlacer interface {
 	) ([]*types.OrderSubmissionResponse, error)
 }
 
+// OrderSizer reports the token count each leg of a multi-outcome order is signed for,
+// after the size rounding PlaceOrdersMultiOutcome applies. OrderClient implements this
+// interface.
+type OrderSizer interface {
+	OrderSizes(outcomes []types.OutcomeOrderParams, tokenCount float64) []float64
+}
+
+// OrderQuerier abstracts order status lookups for fill verification.
+// OrderClient implements this interface; tests can supply a mock.
+type OrderQuerier interface {
+	GetOrder(ctx context.Context, orderID string) (*types.OrderQueryResponse, error)
+}
+
 // OrderClient handles order submission to Polymarket CLOB
 type OrderClient struct {
 	apiKey        string
 	secret        string
 	passphrase    string
-	privateKey    *ecdsa.PrivateKey
+	signer        Signer
 	address       string // EOA address (signer)
 	proxyAddress  string // Proxy address (maker/funder)
 	signatureType model.SignatureType
 	orderBuilder  builder.ExchangeOrderBuilder
+	domain        ExchangeDomain // EIP-712 domain orders are signed for
+	baseURL       string // CLOB API base URL
+	maxBatchSize  int    // Orders per POST /orders request
 	logger        *zap.Logger
+
+	// strictTickSize rejects orders whose tick size is unknown instead of defaulting to 0.01
+	strictTickSize bool
+
+	// strictOrderHash fails placements whose API order ID differs from the local EIP-712 hash
+	strictOrderHash bool
+
+	// clockOffset (nanoseconds) is added to the local clock when timestamping signed requests
+	clockOffset atomic.Int64
+
+	// syncClockOnSkew adopts the server clock and retries once when a timestamp is rejected
+	syncClockOnSkew bool
+
+	// sortByTokenID submits multi-outcome batches in token ID order instead of outcome order
+	sortByTokenID bool
+
+	// rounding sets the rounding direction of order sizes and amounts
+	rounding RoundingPolicy
+
+	// Per-request deadlines for order submissions, queries and cancellations (0 = DefaultRequestTimeout)
+	submitTimeout time.Duration
+	queryTimeout  time.Duration
+	cancelTimeout time.Duration
+}
+
+// ErrUnknownTickSize is returned in strict mode when an order's tick size can't be resolved.
+var ErrUnknownTickSize = errors.New("unknown tick size")
+
+// ErrTakerTokenMismatch is returned when per-outcome rounding yields different token
+// counts across legs, which would buy an incomplete set.
+var ErrTakerTokenMismatch = errors.New("taker token counts differ across outcomes")
+
+// ErrBelowMinSize is returned when an order's size is below the market minimum. The
+// error carries a *BelowMinSizeError with the outcome and sizes; match with errors.Is.
+var ErrBelowMinSize = errors.New("order size below minimum")
+
+// BelowMinSizeError reports which outcome's order was undersized.
+type BelowMinSizeError struct {
+	OutcomeIndex int     // Index of the outcome in the order batch
+	Size         float64 // Rounded order size in tokens
+	MinSize      float64 // Market minimum in tokens
 }
 
+func (e *BelowMinSizeError) Error() string {
+	return fmt.Sprintf("outcome %d: order size %.2f below minimum %.2f tokens", e.OutcomeIndex, e.Size, e.MinSize)
+}
+
+// Unwrap makes errors.Is(err, ErrBelowMinSize) match.
+func (e *BelowMinSizeError) Unwrap() error {
+	return ErrBelowMinSize
+}
+
+// takerTokenEpsilon is the tolerance when comparing rounded taker token counts.
+const takerTokenEpsilon = 1e-9
+
+const (
+	// DefaultCLOBURL is the Polymarket CLOB API base URL.
+	DefaultCLOBURL = "https://clob.polymarket.com"
+
+	// DefaultMaxBatchSize is the CLOB's limit on orders per batch request.
+	DefaultMaxBatchSize = 15
+
+	// DefaultRequestTimeout bounds a CLOB API request when no per-operation timeout is set.
+	DefaultRequestTimeout = 30 * time.Second
+)
+
+// ErrBatchRolledBack is returned when a sub-batch fails after earlier sub-batches
+// were placed, and those earlier orders were canceled to avoid an incomplete set.
+var ErrBatchRolledBack = errors.New("sub-batch failed, earlier sub-batches canceled")
+
 // Compile-time check that OrderClient implements OrderPlacer
 var _ OrderPlacer = (*OrderClient)(nil)
 
+// Compile-time check that OrderClient implements OrderSizer
+var _ OrderSizer = (*OrderClient)(nil)
+
 // OrderClientConfig holds configuration for the order client
 type OrderClientConfig struct {
 	APIKey        string
@@ -64,6 +150,48 @@ type OrderClientConfig struct {
 	ProxyAddress  string
 	SignatureType int
 	Logger        *zap.Logger
+
+	// Signer signs orders (optional). Without it a LocalSigner is created from PrivateKey;
+	// with it PrivateKey is not needed, so the key can stay in an external signer.
+	Signer Signer
+
+	// ChainID selects the EIP-712 signing domain (default: PolygonChainID). Only chains
+	// with a known CTF exchange deployment are accepted, e.g. 80002 for Amoy testnet.
+	ChainID int64
+
+	// BaseURL overrides the CLOB API base URL (default: DefaultCLOBURL).
+	BaseURL string
+
+	// MaxBatchSize caps orders per batch request; larger order sets are split into
+	// sequential sub-batches (default: DefaultMaxBatchSize).
+	MaxBatchSize int
+
+	// StrictTickSize rejects orders with an unknown tick size instead of
+	// warning and rounding with the 0.01-tick default.
+	StrictTickSize bool
+
+	// StrictOrderHash returns ErrOrderHashMismatch when the API's order ID differs
+	// from the locally computed EIP-712 order hash, instead of only warning.
+	StrictOrderHash bool
+
+	// SyncClockOnSkew retries a request rejected for its timestamp after adopting the
+	// server time from the response Date header as the clock offset.
+	SyncClockOnSkew bool
+
+	// RoundingPolicy sets the rounding direction of token sizes and USD amounts.
+	// The zero value rounds both to nearest; see DirectionalRoundingPolicy.
+	RoundingPolicy RoundingPolicy
+
+	// SortByTokenID submits multi-outcome batches in ascending token ID order, so the same
+	// order set always produces the same request. Responses are still returned in outcome order.
+	SortByTokenID bool
+
+	// SubmitTimeout bounds each order submission request, QueryTimeout each order, open
+	// order and trade query, and CancelTimeout each cancellation (default for each:
+	// DefaultRequestTimeout). A submission that times out may still have reached the CLOB.
+	SubmitTimeout time.Duration
+	QueryTimeout  time.Duration
+	CancelTimeout time.Duration
 }
 
 // OrderInfo represents an open order from GET /data/orders
@@ -94,33 +222,62 @@ type CancelAllResult struct {
 
 // NewOrderClient creates a new order client
 func NewOrderClient(cfg *OrderClientConfig) (*OrderClient, error) {
-	// Parse private key
-	privateKey, err := crypto.HexToECDSA(strings.TrimPrefix(cfg.PrivateKey, "0x"))
+	chainID := cfg.ChainID
+	if chainID == 0 {
+		chainID = PolygonChainID
+	}
+
+	domain, err := exchangeDomain(chainID)
 	if err != nil {
-		return nil, fmt.Errorf("parse private key: %w", err)
+		return nil, err
+	}
+
+	orderSigner := cfg.Signer
+	if orderSigner == nil {
+		orderSigner, err = NewLocalSigner(cfg.PrivateKey, chainID)
+		if err != nil {
+			return nil, err
+		}
 	}
 
-	// Derive EOA address if not provided
+	// Use the signer's EOA address if not provided
 	address := cfg.Address
 	if address == "" {
-		publicKey := privateKey.Public()
-		publicKeyECDSA, _ := publicKey.(*ecdsa.PublicKey)
-		address = crypto.PubkeyToAddress(*publicKeyECDSA).Hex()
+		address = orderSigner.Address()
+	}
+	orderBuilder := builder.NewExchangeOrderBuilderImpl(big.NewInt(chainID), nil)
+
+	baseURL := cfg.BaseURL
+	if baseURL == "" {
+		baseURL = DefaultCLOBURL
 	}
 
-	chainID := big.NewInt(137) // Polygon mainnet
-	orderBuilder := builder.NewExchangeOrderBuilderImpl(chainID, nil)
+	maxBatchSize := cfg.MaxBatchSize
+	if maxBatchSize <= 0 {
+		maxBatchSize = DefaultMaxBatchSize
+	}
 
 	return &OrderClient{
-		apiKey:        cfg.APIKey,
-		secret:        cfg.Secret,
-		passphrase:    cfg.Passphrase,
-		privateKey:    privateKey,
-		address:       address,
-		proxyAddress:  cfg.ProxyAddress,
-		signatureType: model.SignatureType(cfg.SignatureType),
-		orderBuilder:  orderBuilder,
-		logger:        cfg.Logger,
+		apiKey:          cfg.APIKey,
+		secret:          cfg.Secret,
+		passphrase:      cfg.Passphrase,
+		signer:          orderSigner,
+		address:         address,
+		proxyAddress:    cfg.ProxyAddress,
+		signatureType:   model.SignatureType(cfg.SignatureType),
+		orderBuilder:    orderBuilder,
+		domain:          domain,
+		baseURL:         strings.TrimSuffix(baseURL, "/"),
+		maxBatchSize:    maxBatchSize,
+		logger:          cfg.Logger,
+		strictTickSize:  cfg.StrictTickSize,
+		strictOrderHash: cfg.StrictOrderHash,
+		syncClockOnSkew: cfg.SyncClockOnSkew,
+		sortByTokenID:   cfg.SortByTokenID,
+		rounding:        cfg.RoundingPolicy,
+		submitTimeout:   cfg.SubmitTimeout,
+		queryTimeout:    cfg.QueryTimeout,
+		cancelTimeout:   cfg.CancelTimeout,
 	}, nil
 }
 
@@ -148,8 +305,10 @@ func (c *OrderClient) PlaceSingleOrder(
 	ctx context.Context,
 	orderData *model.OrderData,
 ) (resp *types.OrderSubmissionResponse, err error) {
+	logger := tracing.Logger(ctx, c.logger)
+
 	// Build and sign the order
-	signedOrder, err := c.orderBuilder.BuildSignedOrder(c.privateKey, orderData, model.CTFExchange)
+	signedOrder, err := c.buildSignedOrder(orderData, "")
 	if err != nil {
 		return nil, fmt.Errorf("build order: %w", err)
 	}
@@ -160,7 +319,7 @@ func (c *OrderClient) PlaceSingleOrder(
 		sideStr = "SELL"
 	}
 
-	c.logger.Info("single-order-built",
+	logger.Info("single-order-built",
 		zap.String("maker", orderData.Maker),
 		zap.String("signer", orderData.Signer),
 		zap.String("token_id", orderData.TokenId),
@@ -192,32 +351,45 @@ func (c *OrderClient) PlaceOrdersBatch(
 	noTickSize float64,
 	noMinSize float64,
 ) (yesResp *types.OrderSubmissionResponse, noResp *types.OrderSubmissionResponse, err error) {
+	logger := tracing.Logger(ctx, c.logger)
+
 	// For EOA: maker and signer must be the same address
 	makerAddress := c.address
 	signerAddress := c.address
 
 	// Get rounding precision for each token
-	yesSizePrecision, yesAmountPrecision := getRoundingConfig(yesTickSize)
-	noSizePrecision, noAmountPrecision := getRoundingConfig(noTickSize)
+	yesSizePrecision, yesAmountPrecision, err := c.resolveRoundingConfig(yesTokenID, yesTickSize, false)
+	if err != nil {
+		return yesResp, noResp, fmt.Errorf("YES order: %w", err)
+	}
+	noSizePrecision, noAmountPrecision, err := c.resolveRoundingConfig(noTokenID, noTickSize, false)
+	if err != nil {
+		return yesResp, noResp, fmt.Errorf("NO order: %w", err)
+	}
 
 	// size parameter is already in tokens (matches Python client behavior)
-	yesTakerTokens := roundAmount(size, yesSizePrecision)
-	noTakerTokens := roundAmount(size, noSizePrecision)
+	yesTakerTokens := round(size, yesSizePrecision, c.rounding.TakerSize)
+	noTakerTokens := round(size, noSizePrecision, c.rounding.TakerSize)
+
+	err = checkEqualTakerTokens([]float64{yesTakerTokens, noTakerTokens})
+	if err != nil {
+		return yesResp, noResp, err
+	}
 
 	// Validate against minimums
 	if yesTakerTokens < yesMinSize {
-		err = fmt.Errorf("YES order size %.2f below minimum %.2f tokens", yesTakerTokens, yesMinSize)
+		err = fmt.Errorf("YES order: %w", &BelowMinSizeError{OutcomeIndex: 0, Size: yesTakerTokens, MinSize: yesMinSize})
 		return yesResp, noResp, err
 	}
 	if noTakerTokens < noMinSize {
-		err = fmt.Errorf("NO order size %.2f below minimum %.2f tokens", noTakerTokens, noMinSize)
+		err = fmt.Errorf("NO order: %w", &BelowMinSizeError{OutcomeIndex: 1, Size: noTakerTokens, MinSize: noMinSize})
 		return yesResp, noResp, err
 	}
 
 	// Build YES order with rounded amounts
-	yesMakerUSD := roundAmount(yesTakerTokens*yesPrice, yesAmountPrecision)
-	yesMakerAmount := usdToRawAmount(yesMakerUSD)
-	yesTakerAmount := usdToRawAmount(yesTakerTokens)
+	yesMakerUSD := round(yesTakerTokens*yesPrice, yesAmountPrecision, c.rounding.MakerAmount)
+	yesMakerAmount := RawAmount(yesMakerUSD, USDCDecimals)
+	yesTakerAmount := RawAmount(yesTakerTokens, USDCDecimals)
 
 	yesOrderData := &model.OrderData{
 		Maker:         makerAddress,
@@ -233,16 +405,16 @@ func (c *OrderClient) PlaceOrdersBatch(
 		SignatureType: c.signatureType,
 	}
 
-	yesSignedOrder, err := c.orderBuilder.BuildSignedOrder(c.privateKey, yesOrderData, model.CTFExchange)
+	yesSignedOrder, err := c.buildSignedOrder(yesOrderData, "")
 	if err != nil {
 		err = fmt.Errorf("build YES order: %w", err)
 		return yesResp, noResp, err
 	}
 
 	// Build NO order with rounded amounts
-	noMakerUSD := roundAmount(noTakerTokens*noPrice, noAmountPrecision)
-	noMakerAmount := usdToRawAmount(noMakerUSD)
-	noTakerAmount := usdToRawAmount(noTakerTokens)
+	noMakerUSD := round(noTakerTokens*noPrice, noAmountPrecision, c.rounding.MakerAmount)
+	noMakerAmount := RawAmount(noMakerUSD, USDCDecimals)
+	noTakerAmount := RawAmount(noTakerTokens, USDCDecimals)
 
 	noOrderData := &model.OrderData{
 		Maker:         makerAddress,
@@ -258,37 +430,33 @@ func (c *OrderClient) PlaceOrdersBatch(
 		SignatureType: c.signatureType,
 	}
 
-	noSignedOrder, err := c.orderBuilder.BuildSignedOrder(c.privateKey, noOrderData, model.CTFExchange)
+	noSignedOrder, err := c.buildSignedOrder(noOrderData, "")
 	if err != nil {
 		err = fmt.Errorf("build NO order: %w", err)
 		return yesResp, noResp, err
 	}
 
-	c.logger.Info("batch-orders-built",
+	logger.Info("batch-orders-built",
 		zap.String("maker", makerAddress),
 		zap.String("signer", signerAddress),
 		zap.Float64("size", size))
 
-	// DEBUG: Print YES order structure for comparison
-	c.logger.Info("GO-YES-ORDER-STRUCTURE",
-		zap.Int64("salt", yesSignedOrder.Salt.Int64()),
-		zap.String("maker", yesSignedOrder.Maker.Hex()),
-		zap.String("signer", yesSignedOrder.Signer.Hex()),
-		zap.String("taker", yesSignedOrder.Taker.Hex()),
-		zap.String("tokenId", yesSignedOrder.TokenId.String()),
-		zap.String("makerAmount", yesSignedOrder.MakerAmount.String()),
-		zap.String("takerAmount", yesSignedOrder.TakerAmount.String()),
-		zap.Int64("side", yesSignedOrder.Side.Int64()),
-		zap.String("feeRateBps", yesSignedOrder.FeeRateBps.String()),
-		zap.String("nonce", yesSignedOrder.Nonce.String()),
-		zap.String("expiration", yesSignedOrder.Expiration.String()),
-		zap.Int64("signatureType", yesSignedOrder.SignatureType.Int64()),
-		zap.String("signature", fmt.Sprintf("0x%x", yesSignedOrder.Signature)))
-
 	// Convert signed orders to JSON format
 	yesOrderJSON := c.convertToOrderJSON(yesSignedOrder)
 	noOrderJSON := c.convertToOrderJSON(noSignedOrder)
 
+	yesHash, err := c.orderHashFromJSON(yesOrderJSON)
+	if err != nil {
+		return yesResp, noResp, fmt.Errorf("YES order hash: %w", err)
+	}
+	noHash, err := c.orderHashFromJSON(noOrderJSON)
+	if err != nil {
+		return yesResp, noResp, fmt.Errorf("NO order hash: %w", err)
+	}
+
+	c.logSignedOrder(ctx, yesSignedOrder, yesHash)
+	c.logSignedOrder(ctx, noSignedOrder, noHash)
+
 	// Create batch request
 	batchReq := types.BatchOrderRequest{
 		{Order: yesOrderJSON, Owner: c.apiKey, OrderType: "GTC"},
@@ -310,10 +478,15 @@ func (c *OrderClient) PlaceOrdersBatch(
 	yesResp = &batchResp[0]
 	noResp = &batchResp[1]
 
+	err = errors.Join(c.verifyOrderHash(ctx, yesHash, yesResp), c.verifyOrderHash(ctx, noHash, noResp))
+	if err != nil {
+		return yesResp, noResp, err
+	}
+
 	// Check for errors
 	if !yesResp.Success {
 		err = &types.OrderError{
-			Code:    yesResp.ErrorMsg,
+			Code:    types.ParseRejectCode(yesResp.ErrorMsg),
 			Message: yesResp.ErrorMsg,
 			OrderID: yesResp.OrderID,
 			Side:    "YES",
@@ -322,7 +495,7 @@ func (c *OrderClient) PlaceOrdersBatch(
 	}
 	if !noResp.Success {
 		err = &types.OrderError{
-			Code:    noResp.ErrorMsg,
+			Code:    types.ParseRejectCode(noResp.ErrorMsg),
 			Message: noResp.ErrorMsg,
 			OrderID: noResp.OrderID,
 			Side:    "NO",
@@ -342,6 +515,8 @@ func (c *OrderClient) PlaceOrdersMultiOutcome(
 	outcomes []types.OutcomeOrderParams,
 	size float64,
 ) (responses []*types.OrderSubmissionResponse, err error) {
+	logger := tracing.Logger(ctx, c.logger)
+
 	if len(outcomes) < 2 {
 		return nil, fmt.Errorf("at least 2 outcomes required, got %d", len(outcomes))
 	}
@@ -352,24 +527,47 @@ func (c *OrderClient) PlaceOrdersMultiOutcome(
 
 	// Build signed orders for each outcome
 	batchReq := make(types.BatchOrderRequest, 0, len(outcomes))
+	orderHashes := make([]string, 0, len(outcomes))
+
+	// Round every leg before building any order so mismatched counts are caught up front
+	amountPrecisions := make([]int, len(outcomes))
+	takerTokenCounts := make([]float64, len(outcomes))
 
 	for i, outcome := range outcomes {
 		// Get rounding precision
-		sizePrecision, amountPrecision := getRoundingConfig(outcome.TickSize)
+		sizePrecision, amountPrecision, err := c.resolveRoundingConfig(outcome.TokenID, outcome.TickSize, outcome.TickSizeUnknown)
+		if err != nil {
+			return nil, fmt.Errorf("outcome %d: %w", i, err)
+		}
 
 		// size parameter is already in tokens (matches Python client behavior)
-		takerTokens := roundAmount(size, sizePrecision)
+		takerTokens := round(size, sizePrecision, c.rounding.TakerSize)
 
 		// Validate against minimum
 		if takerTokens < outcome.MinSize {
-			return nil, fmt.Errorf("outcome %d: order size %.2f below minimum %.2f tokens",
-				i, takerTokens, outcome.MinSize)
+			return nil, &BelowMinSizeError{OutcomeIndex: i, Size: takerTokens, MinSize: outcome.MinSize}
 		}
 
+		amountPrecisions[i] = amountPrecision
+		takerTokenCounts[i] = takerTokens
+	}
+
+	err = checkEqualTakerTokens(takerTokenCounts)
+	if err != nil {
+		return nil, err
+	}
+
+	// batchOrder[j] is the outcome index of the j-th order in the batch
+	batchOrder := c.batchOrder(outcomes)
+
+	for _, i := range batchOrder {
+		outcome := outcomes[i]
+		takerTokens := takerTokenCounts[i]
+
 		// Build order with rounded amounts
-		makerUSD := roundAmount(takerTokens*outcome.Price, amountPrecision)
-		makerAmount := usdToRawAmount(makerUSD)
-		takerAmount := usdToRawAmount(takerTokens)
+		makerUSD := round(takerTokens*outcome.Price, amountPrecisions[i], c.rounding.MakerAmount)
+		makerAmount := RawAmount(makerUSD, USDCDecimals)
+		takerAmount := RawAmount(takerTokens, USDCDecimals)
 
 		orderData := &model.OrderData{
 			Maker:         makerAddress,
@@ -385,31 +583,27 @@ func (c *OrderClient) PlaceOrdersMultiOutcome(
 			SignatureType: c.signatureType,
 		}
 
-		signedOrder, err := c.orderBuilder.BuildSignedOrder(c.privateKey, orderData, model.CTFExchange)
+		signedOrder, err := c.buildSignedOrder(orderData, outcome.CorrelationID)
 		if err != nil {
 			return nil, fmt.Errorf("build order %d: %w", i, err)
 		}
 
-		// DEBUG: Print first order (YES) structure for comparison
-		if i == 0 {
-			c.logger.Info("GO-YES-ORDER-STRUCTURE",
-				zap.Int64("salt", signedOrder.Salt.Int64()),
-				zap.String("maker", signedOrder.Maker.Hex()),
-				zap.String("signer", signedOrder.Signer.Hex()),
-				zap.String("taker", signedOrder.Taker.Hex()),
-				zap.String("tokenId", signedOrder.TokenId.String()),
-				zap.String("makerAmount", signedOrder.MakerAmount.String()),
-				zap.String("takerAmount", signedOrder.TakerAmount.String()),
-				zap.Int64("side", signedOrder.Side.Int64()),
-				zap.String("feeRateBps", signedOrder.FeeRateBps.String()),
-				zap.String("nonce", signedOrder.Nonce.String()),
-				zap.String("expiration", signedOrder.Expiration.String()),
-				zap.Int64("signatureType", signedOrder.SignatureType.Int64()),
-				zap.String("signature", fmt.Sprintf("0x%x", signedOrder.Signature)))
-		}
-
 		// Convert to JSON and add to batch
 		orderJSON := c.convertToOrderJSON(signedOrder)
+
+		orderHash, err := c.orderHashFromJSON(orderJSON)
+		if err != nil {
+			return nil, fmt.Errorf("order %d hash: %w", i, err)
+		}
+		orderHashes = append(orderHashes, orderHash)
+		c.logSignedOrder(ctx, signedOrder, orderHash)
+		if outcome.CorrelationID != "" {
+			logger.Info("order-correlated",
+				zap.String("order-hash", orderHash),
+				zap.String("correlation-id", outcome.CorrelationID),
+				zap.String("salt", signedOrder.Salt.String()))
+		}
+
 		batchReq = append(batchReq, types.OrderSubmissionRequest{
 			Order:     orderJSON,
 			Owner:     c.apiKey,
@@ -417,40 +611,48 @@ func (c *OrderClient) PlaceOrdersMultiOutcome(
 		})
 	}
 
-	c.logger.Info("multi-outcome-batch-orders-built",
+	logger.Info("multi-outcome-batch-orders-built",
 		zap.String("maker", makerAddress),
 		zap.String("signer", signerAddress),
 		zap.Int("outcome-count", len(outcomes)),
 		zap.Float64("size", size))
 
-	// Submit batch
-	batchResp, err := c.submitBatchOrder(ctx, batchReq)
+	responses, err = c.submitSubBatches(ctx, batchReq)
 	if err != nil {
-		return nil, fmt.Errorf("submit batch: %w", err)
+		return c.inOutcomeOrder(responses, batchOrder), err
 	}
 
-	// Validate we got N responses
-	if len(batchResp) != len(outcomes) {
-		return nil, fmt.Errorf("expected %d responses, got %d", len(outcomes), len(batchResp))
+	var hashErrs []error
+	for i := range responses {
+		hashErrs = append(hashErrs, c.verifyOrderHash(ctx, orderHashes[i], responses[i]))
 	}
 
-	// Convert to response pointers
-	responses = make([]*types.OrderSubmissionResponse, len(batchResp))
-	for i := range batchResp {
-		responses[i] = &batchResp[i]
+	responses = c.inOutcomeOrder(responses, batchOrder)
+
+	err = errors.Join(hashErrs...)
+	if err != nil {
+		return responses, err
 	}
 
 	// Check for any errors
 	var errMsgs []string
+	code := types.RejectCode("")
 	for i, resp := range responses {
+		if resp == nil {
+			continue // Not submitted: an earlier sub-batch was rejected
+		}
 		if !resp.Success {
 			errMsgs = append(errMsgs, fmt.Sprintf("outcome %d: %s", i, resp.ErrorMsg))
+			if code == "" {
+				code = types.ParseRejectCode(resp.ErrorMsg)
+			}
 		}
 	}
 
 	if len(errMsgs) > 0 {
+		// Code is the first rejected outcome's; each response keeps its own ErrorMsg
 		return responses, &types.OrderError{
-			Code:    "BATCH_ERROR",
+			Code:    code,
 			Message: strings.Join(errMsgs, "; "),
 		}
 	}
@@ -458,6 +660,189 @@ func (c *OrderClient) PlaceOrdersMultiOutcome(
 	return responses, nil
 }
 
+// OrderSizes returns the token count PlaceOrdersMultiOutcome signs each leg for when asked
+// to buy tokenCount of every outcome. Unknown tick sizes get 0.01-tick precision, as in
+// lenient mode; strict mode rejects those orders instead of placing them.
+func (c *OrderClient) OrderSizes(outcomes []types.OutcomeOrderParams, tokenCount float64) []float64 {
+	sizes := make([]float64, len(outcomes))
+	for i, outcome := range outcomes {
+		sizePrecision, _ := getRoundingConfig(outcome.TickSize)
+		sizes[i] = round(tokenCount, sizePrecision, c.rounding.TakerSize)
+	}
+
+	return sizes
+}
+
+// batchOrder returns the outcome indexes in the order their orders go into the batch:
+// outcome order, or ascending token ID when sortByTokenID is set.
+func (c *OrderClient) batchOrder(outcomes []types.OutcomeOrderParams) []int {
+	order := make([]int, len(outcomes))
+	for i := range order {
+		order[i] = i
+	}
+
+	if c.sortByTokenID {
+		slices.SortStableFunc(order, func(a, b int) int {
+			return compareTokenIDs(outcomes[a].TokenID, outcomes[b].TokenID)
+		})
+	}
+
+	return order
+}
+
+// compareTokenIDs orders decimal token IDs numerically: shorter IDs first, then lexically.
+func compareTokenIDs(a, b string) int {
+	if len(a) != len(b) {
+		return cmp.Compare(len(a), len(b))
+	}
+	return strings.Compare(a, b)
+}
+
+// inOutcomeOrder maps batch-ordered responses back to outcome order. Outcomes whose
+// order wasn't submitted (a rejected or failed earlier sub-batch) get nil. Without
+// sorting, batch order is outcome order and responses are returned unchanged.
+func (c *OrderClient) inOutcomeOrder(
+	responses []*types.OrderSubmissionResponse,
+	batchOrder []int,
+) []*types.OrderSubmissionResponse {
+	if !c.sortByTokenID || responses == nil {
+		return responses
+	}
+
+	ordered := make([]*types.OrderSubmissionResponse, len(batchOrder))
+	for j, resp := range responses {
+		ordered[batchOrder[j]] = resp
+	}
+
+	return ordered
+}
+
+// submitSubBatches submits orders in chunks of at most maxBatchSize, preserving order.
+// A single chunk behaves exactly like one batch call. If a later chunk fails (transport
+// error or any rejected order), remaining chunks are skipped and orders accepted in
+// earlier chunks and in the failing chunk itself are canceled, since a partial set is an
+// unhedged position.
+func (c *OrderClient) submitSubBatches(
+	ctx context.Context,
+	batchReq types.BatchOrderRequest,
+) (responses []*types.OrderSubmissionResponse, err error) {
+	batchSize := c.maxBatchSize
+	if batchSize <= 0 {
+		batchSize = DefaultMaxBatchSize
+	}
+
+	responses = make([]*types.OrderSubmissionResponse, 0, len(batchReq))
+
+	for start := 0; start < len(batchReq); start += batchSize {
+		end := min(start+batchSize, len(batchReq))
+
+		batchResp, err := c.submitBatchOrder(ctx, batchReq[start:end])
+		if err == nil && len(batchResp) != end-start {
+			err = fmt.Errorf("expected %d responses, got %d", end-start, len(batchResp))
+		}
+		if err != nil {
+			if start == 0 {
+				return nil, fmt.Errorf("submit batch: %w", err)
+			}
+			return responses, c.rollbackSubBatches(ctx, responses, fmt.Errorf("submit orders %d-%d: %w", start, end-1, err))
+		}
+
+		rejected := false
+		for i := range batchResp {
+			responses = append(responses, &batchResp[i])
+			rejected = rejected || !batchResp[i].Success
+		}
+
+		if rejected && start > 0 {
+			return responses, c.rollbackSubBatches(ctx, responses, fmt.Errorf("orders %d-%d rejected", start, end-1))
+		}
+
+		if rejected {
+			// Nothing placed in earlier chunks; the caller sees the rejection per outcome
+			break
+		}
+	}
+
+	return responses, nil
+}
+
+// rollbackSubBatches cancels every accepted order in placed after a later sub-batch failed.
+func (c *OrderClient) rollbackSubBatches(
+	ctx context.Context,
+	placed []*types.OrderSubmissionResponse,
+	cause error,
+) error {
+	logger := tracing.Logger(ctx, c.logger)
+
+	orderIDs := make([]string, 0, len(placed))
+	for _, resp := range placed {
+		if resp.Success && resp.OrderID != "" {
+			orderIDs = append(orderIDs, resp.OrderID)
+		}
+	}
+
+	logger.Warn("sub-batch-failed-rolling-back",
+		zap.Strings("order-ids", orderIDs),
+		zap.Error(cause))
+
+	result, err := c.CancelOrders(ctx, orderIDs)
+	if err != nil {
+		return fmt.Errorf("%w: %w (cancel failed: %w)", ErrBatchRolledBack, cause, err)
+	}
+
+	if len(result.NotCanceled) > 0 {
+		return fmt.Errorf("%w: %w (%d orders not canceled: %v)",
+			ErrBatchRolledBack, cause, len(result.NotCanceled), result.NotCanceled)
+	}
+
+	return fmt.Errorf("%w: %w", ErrBatchRolledBack, cause)
+}
+
+// buildSignedOrder builds and signs orderData. With a correlation ID the salt is derived
+// from it (see CorrelationSalt) instead of generated randomly.
+func (c *OrderClient) buildSignedOrder(orderData *model.OrderData, correlationID string) (*model.SignedOrder, error) {
+	order, err := c.orderBuilder.BuildOrder(orderData)
+	if err != nil {
+		return nil, err
+	}
+	if correlationID != "" {
+		order.Salt = big.NewInt(CorrelationSalt(correlationID))
+	}
+
+	return c.signOrder(order)
+}
+
+// logSignedOrder traces a built order. Info logs carry only identifying fields; the full
+// EIP-712 payload and signature are logged at debug, e.g. to compare against other clients.
+func (c *OrderClient) logSignedOrder(ctx context.Context, order *model.SignedOrder, orderHash string) {
+	logger := tracing.Logger(ctx, c.logger)
+
+	logger.Info("order-signed",
+		zap.String("order-hash", orderHash),
+		zap.String("token-id", order.TokenId.String()),
+		zap.String("maker-amount", order.MakerAmount.String()),
+		zap.String("taker-amount", order.TakerAmount.String()))
+
+	// Checked first so the payload fields aren't built when debug is off
+	if ce := logger.Check(zap.DebugLevel, "order-eip712-payload"); ce != nil {
+		ce.Write(
+			zap.String("order-hash", orderHash),
+			zap.String("salt", order.Salt.String()),
+			zap.String("maker", order.Maker.Hex()),
+			zap.String("signer", order.Signer.Hex()),
+			zap.String("taker", order.Taker.Hex()),
+			zap.String("token-id", order.TokenId.String()),
+			zap.String("maker-amount", order.MakerAmount.String()),
+			zap.String("taker-amount", order.TakerAmount.String()),
+			zap.Int64("side", order.Side.Int64()),
+			zap.String("fee-rate-bps", order.FeeRateBps.String()),
+			zap.String("nonce", order.Nonce.String()),
+			zap.String("expiration", order.Expiration.String()),
+			zap.Int64("signature-type", order.SignatureType.Int64()),
+			zap.String("signature", fmt.Sprintf("0x%x", order.Signature)))
+	}
+}
+
 // convertToOrderJSON converts a signed order to JSON format
 func (c *OrderClient) convertToOrderJSON(order *model.SignedOrder) types.SignedOrderJSON {
 	sideStr := "BUY"
@@ -487,82 +872,46 @@ func (c *OrderClient) submitBatchOrder(
 	ctx context.Context,
 	req types.BatchOrderRequest,
 ) (resp types.BatchOrderResponse, err error) {
+	logger := tracing.Logger(ctx, c.logger)
+
 	reqBody, err := json.Marshal(req)
 	if err != nil {
 		err = fmt.Errorf("marshal batch request: %w", err)
 		return resp, err
 	}
 
-	// Create HMAC signature
-	timestamp := fmt.Sprintf("%d", time.Now().Unix())
-	method := "POST"
 	requestPath := "/orders" // Note: plural for batch endpoint
 
-	signaturePayload := timestamp + method + requestPath + string(reqBody)
-
-	// Decode secret using URL-safe base64
-	secretBytes, err := base64.URLEncoding.DecodeString(c.secret)
-	if err != nil {
-		err = fmt.Errorf("decode secret: %w", err)
-		return resp, err
-	}
-
-	h := hmac.New(sha256.New, secretBytes)
-	h.Write([]byte(signaturePayload))
-	signature := base64.URLEncoding.EncodeToString(h.Sum(nil))
-
-	// Make request
-	url := "https://clob.polymarket.com" + requestPath
-	httpReq, err := http.NewRequestWithContext(ctx, method, url, bytes.NewReader(reqBody))
-	if err != nil {
-		err = fmt.Errorf("create request: %w", err)
-		return resp, err
-	}
-
-	// Set headers (same as single order)
-	httpReq.Header.Set("Content-Type", "application/json")
-	httpReq.Header.Set("POLY_API_KEY", c.apiKey)
-	httpReq.Header.Set("POLY_SIGNATURE", signature)
-	httpReq.Header.Set("POLY_TIMESTAMP", timestamp)
-	httpReq.Header.Set("POLY_PASSPHRASE", c.passphrase)
-	httpReq.Header.Set("POLY_ADDRESS", c.address)
-
 	// Log the request being sent (at DEBUG level)
-	c.logger.Debug("submitting-batch-order-request",
-		zap.String("url", url),
+	logger.Debug("submitting-batch-order-request",
+		zap.String("url", c.baseURL+requestPath),
 		zap.String("request-body", string(reqBody)))
 
-	client := &http.Client{Timeout: 30 * time.Second}
-	httpResp, err := client.Do(httpReq)
-	if err != nil {
-		err = fmt.Errorf("send request: %w", err)
-		return resp, err
-	}
-	defer httpResp.Body.Close()
-
-	body, err := io.ReadAll(httpResp.Body)
+	statusCode, body, err := c.doSigned(ctx, http.MethodPost, requestPath, reqBody)
 	if err != nil {
-		err = fmt.Errorf("read response: %w", err)
 		return resp, err
 	}
 
 	// Log the raw response (at DEBUG level for now, will be useful for troubleshooting)
-	c.logger.Debug("batch-order-api-response",
-		zap.Int("status-code", httpResp.StatusCode),
+	logger.Debug("batch-order-api-response",
+		zap.Int("status-code", statusCode),
 		zap.String("response-body", string(body)))
 
-	if httpResp.StatusCode != http.StatusOK && httpResp.StatusCode != http.StatusCreated {
+	if statusCode != http.StatusOK && statusCode != http.StatusCreated {
 		// Log error responses at ERROR level
-		c.logger.Error("batch-order-api-error",
-			zap.Int("status-code", httpResp.StatusCode),
+		logger.Error("batch-order-api-error",
+			zap.Int("status-code", statusCode),
 			zap.String("response-body", string(body)))
-		err = fmt.Errorf("API error (status %d): %s", httpResp.StatusCode, string(body))
+		err = &types.OrderError{
+			Code:    types.ParseRejectCode(string(body)),
+			Message: fmt.Sprintf("API error (status %d): %s", statusCode, string(body)),
+		}
 		return resp, err
 	}
 
 	err = json.Unmarshal(body, &resp)
 	if err != nil {
-		c.logger.Error("failed-to-parse-batch-response",
+		logger.Error("failed-to-parse-batch-response",
 			zap.Error(err),
 			zap.String("response-body", string(body)))
 		err = fmt.Errorf("parse batch response: %w\nBody: %s", err, string(body))
@@ -570,13 +919,13 @@ func (c *OrderClient) submitBatchOrder(
 	}
 
 	// Log the parsed response structure (resp is already a slice)
-	c.logger.Info("batch-order-submitted",
+	logger.Info("batch-order-submitted",
 		zap.Int("order-count", len(resp)),
 		zap.Bool("has-orders", len(resp) > 0))
 
 	// Log each order result
 	for i, order := range resp {
-		c.logger.Info("batch-order-result",
+		logger.Info("batch-order-result",
 			zap.Int("order-index", i),
 			zap.String("order-id", order.OrderID),
 			zap.String("status", order.Status),
@@ -592,73 +941,34 @@ func (c *OrderClient) GetOrder(
 	ctx context.Context,
 	orderID string,
 ) (resp *types.OrderQueryResponse, err error) {
-	// Create HMAC signature for GET request
-	timestamp := fmt.Sprintf("%d", time.Now().Unix())
-	method := "GET"
-	requestPath := "/order/" + orderID
-
-	signaturePayload := timestamp + method + requestPath // Empty body for GET
+	logger := tracing.Logger(ctx, c.logger)
 
-	// Decode secret using URL-safe base64
-	secretBytes, err := base64.URLEncoding.DecodeString(c.secret)
-	if err != nil {
-		err = fmt.Errorf("decode secret: %w", err)
-		return resp, err
-	}
-
-	h := hmac.New(sha256.New, secretBytes)
-	h.Write([]byte(signaturePayload))
-	signature := base64.URLEncoding.EncodeToString(h.Sum(nil))
-
-	// Make request
-	url := "https://clob.polymarket.com" + requestPath
-	httpReq, err := http.NewRequestWithContext(ctx, method, url, nil)
-	if err != nil {
-		err = fmt.Errorf("create request: %w", err)
-		return resp, err
-	}
-
-	// Set headers (same as POST requests)
-	httpReq.Header.Set("Content-Type", "application/json")
-	httpReq.Header.Set("POLY_API_KEY", c.apiKey)
-	httpReq.Header.Set("POLY_SIGNATURE", signature)
-	httpReq.Header.Set("POLY_TIMESTAMP", timestamp)
-	httpReq.Header.Set("POLY_PASSPHRASE", c.passphrase)
-	httpReq.Header.Set("POLY_ADDRESS", c.address)
-
-	client := &http.Client{Timeout: 30 * time.Second}
-	httpResp, err := client.Do(httpReq)
-	if err != nil {
-		err = fmt.Errorf("send request: %w", err)
-		return resp, err
-	}
-	defer httpResp.Body.Close()
+	requestPath := "/order/" + orderID
 
-	body, err := io.ReadAll(httpResp.Body)
+	statusCode, body, err := c.doSigned(ctx, http.MethodGet, requestPath, nil)
 	if err != nil {
-		err = fmt.Errorf("read response: %w", err)
 		return resp, err
 	}
 
 	// Log response for debugging
-	c.logger.Debug("get-order-api-response",
+	logger.Debug("get-order-api-response",
 		zap.String("order-id", orderID),
-		zap.Int("status-code", httpResp.StatusCode),
+		zap.Int("status-code", statusCode),
 		zap.String("response-body", string(body)))
 
-	if httpResp.StatusCode != http.StatusOK {
+	if statusCode != http.StatusOK {
 		// Log error responses at ERROR level
-		c.logger.Error("get-order-api-error",
+		logger.Error("get-order-api-error",
 			zap.String("order-id", orderID),
-			zap.Int("status-code", httpResp.StatusCode),
+			zap.Int("status-code", statusCode),
 			zap.String("response-body", string(body)))
-		err = fmt.Errorf("API error (status %d): %s", httpResp.StatusCode, string(body))
+		err = fmt.Errorf("API error (status %d): %s", statusCode, string(body))
 		return resp, err
 	}
 
 	err = json.Unmarshal(body, &resp)
 	if err != nil {
-		c.logger.Error("failed-to-parse-order-response",
+		logger.Error("failed-to-parse-order-response",
 			zap.String("order-id", orderID),
 			zap.Error(err),
 			zap.String("response-body", string(body)))
@@ -676,6 +986,12 @@ func (c *OrderClient) submitOrder(
 	// Convert to JSON format using helper method
 	jsonOrder := c.convertToOrderJSON(order)
 
+	localHash, err := c.orderHashFromJSON(jsonOrder)
+	if err != nil {
+		err = fmt.Errorf("compute order hash: %w", err)
+		return resp, err
+	}
+
 	// Wrap order in the required structure
 	// Note: "owner" is the API key, not the maker address (per Python client)
 	orderRequest := types.OrderSubmissionRequest{
@@ -690,89 +1006,96 @@ func (c *OrderClient) submitOrder(
 		return resp, err
 	}
 
-	// Create HMAC signature
-	timestamp := fmt.Sprintf("%d", time.Now().Unix())
-	method := "POST"
-	requestPath := "/order"
-
-	signaturePayload := timestamp + method + requestPath + string(reqBody)
-
-	// Decode secret using URL-safe base64 (Python client uses urlsafe_b64decode)
-	secretBytes, err := base64.URLEncoding.DecodeString(c.secret)
-	if err != nil {
-		err = fmt.Errorf("decode secret: %w", err)
-		return resp, err
-	}
-
-	h := hmac.New(sha256.New, secretBytes)
-	h.Write([]byte(signaturePayload))
-	// Encode signature using URL-safe base64 (Python client uses urlsafe_b64encode)
-	signature := base64.URLEncoding.EncodeToString(h.Sum(nil))
-
-	// Make request
-	url := "https://clob.polymarket.com" + requestPath
-	req, err := http.NewRequestWithContext(ctx, method, url, strings.NewReader(string(reqBody)))
+	statusCode, body, err := c.doSigned(ctx, http.MethodPost, "/order", reqBody)
 	if err != nil {
-		err = fmt.Errorf("create request: %w", err)
 		return resp, err
 	}
 
-	// POLY_ADDRESS header should be the EOA address (per Python client: signer.address())
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("POLY_API_KEY", c.apiKey)
-	req.Header.Set("POLY_SIGNATURE", signature)
-	req.Header.Set("POLY_TIMESTAMP", timestamp)
-	req.Header.Set("POLY_PASSPHRASE", c.passphrase)
-	req.Header.Set("POLY_ADDRESS", c.address) // EOA address from private key
-
-	client := &http.Client{Timeout: 30 * time.Second}
-	httpResp, err := client.Do(req)
-	if err != nil {
-		err = fmt.Errorf("send request: %w", err)
+	if statusCode != http.StatusOK && statusCode != http.StatusCreated {
+		err = fmt.Errorf("API error (status %d): %s", statusCode, string(body))
 		return resp, err
 	}
-	defer httpResp.Body.Close()
 
-	body, err := io.ReadAll(httpResp.Body)
+	err = json.Unmarshal(body, &resp)
 	if err != nil {
-		err = fmt.Errorf("read response: %w", err)
-		return resp, err
-	}
-
-	if httpResp.StatusCode != http.StatusOK && httpResp.StatusCode != http.StatusCreated {
-		err = fmt.Errorf("API error (status %d): %s", httpResp.StatusCode, string(body))
+		err = fmt.Errorf("parse response: %w", err)
 		return resp, err
 	}
 
-	err = json.Unmarshal(body, &resp)
+	err = c.verifyOrderHash(ctx, localHash, resp)
 	if err != nil {
-		err = fmt.Errorf("parse response: %w", err)
 		return resp, err
 	}
 
 	return resp, nil
 }
 
-func usdToRawAmount(usd float64) string {
-	rawAmount := int64(usd * 1000000)
-	return fmt.Sprintf("%d", rawAmount)
-}
-
 // getRoundingConfig returns the precision for size and amount based on tick size
 // Matches Python client's ROUNDING_CONFIG
 func getRoundingConfig(tickSize float64) (sizePrecision int, amountPrecision int) {
+	sizePrecision, amountPrecision, _ = lookupRoundingConfig(tickSize)
+	return sizePrecision, amountPrecision
+}
+
+// lookupRoundingConfig is getRoundingConfig that also reports whether the tick size
+// is a supported value. Unsupported values get 0.01-tick precision.
+func lookupRoundingConfig(tickSize float64) (sizePrecision int, amountPrecision int, ok bool) {
 	switch tickSize {
 	case 0.1:
-		return 2, 3 // size=2, amount=3
+		return 2, 3, true // size=2, amount=3
 	case 0.01:
-		return 2, 4 // size=2, amount=4
+		return 2, 4, true // size=2, amount=4
 	case 0.001:
-		return 2, 5 // size=2, amount=5
+		return 2, 5, true // size=2, amount=5
 	case 0.0001:
-		return 2, 6 // size=2, amount=6
+		return 2, 6, true // size=2, amount=6
 	default:
-		return 2, 4 // Default to 0.01 tick size
+		return 2, 4, false // Default to 0.01 tick size
+	}
+}
+
+// resolveRoundingConfig returns rounding precision for a token's order.
+// When the tick size is unknown (metadata lookup failed upstream or unsupported value),
+// strict mode rejects the order; lenient mode warns and falls back to 0.01-tick precision,
+// which can mis-round orders on 0.001-tick markets.
+func (c *OrderClient) resolveRoundingConfig(
+	tokenID string,
+	tickSize float64,
+	tickSizeUnknown bool,
+) (sizePrecision int, amountPrecision int, err error) {
+	sizePrecision, amountPrecision, ok := lookupRoundingConfig(tickSize)
+	if ok && !tickSizeUnknown {
+		return sizePrecision, amountPrecision, nil
 	}
+
+	if c.strictTickSize {
+		TickSizeUnknownTotal.WithLabelValues("rejected").Inc()
+		return 0, 0, fmt.Errorf("%w: token %s tick size %v", ErrUnknownTickSize, tokenID, tickSize)
+	}
+
+	TickSizeUnknownTotal.WithLabelValues("defaulted").Inc()
+	c.logger.Warn("tick-size-unknown-using-default",
+		zap.String("token-id", tokenID),
+		zap.Float64("tick-size", tickSize),
+		zap.Bool("metadata-unresolved", tickSizeUnknown),
+		zap.Int("size-precision", sizePrecision),
+		zap.Int("amount-precision", amountPrecision))
+
+	return sizePrecision, amountPrecision, nil
+}
+
+// checkEqualTakerTokens verifies every leg buys the same token count after rounding.
+// Arbitrage only pays out if exactly one full set is held; unequal legs leave
+// an unhedged position in the larger outcome.
+func checkEqualTakerTokens(takerTokens []float64) error {
+	for i := 1; i < len(takerTokens); i++ {
+		if math.Abs(takerTokens[i]-takerTokens[0]) > takerTokenEpsilon {
+			return fmt.Errorf("%w: outcome 0 has %.6f tokens, outcome %d has %.6f",
+				ErrTakerTokenMismatch, takerTokens[0], i, takerTokens[i])
+		}
+	}
+
+	return nil
 }
 
 // roundAmount rounds an amount to the specified number of decimal places
@@ -781,80 +1104,65 @@ func roundAmount(value float64, decimals int) float64 {
 	return math.Round(value*multiplier) / multiplier
 }
 
-// GetOpenOrders fetches all open orders for the authenticated user
-func (c *OrderClient) GetOpenOrders(ctx context.Context) (orders []OrderInfo, err error) {
-	method := "GET"
-	requestPath := "/data/orders"
-	body := ""
+// OpenOrdersQuery filters GET /data/orders. Empty fields are not sent.
+type OpenOrdersQuery struct {
+	Market  string // Condition ID
+	AssetID string // Token ID
+}
 
-	// Build HMAC signature
-	timestamp := fmt.Sprintf("%d", time.Now().Unix())
-	signaturePayload := timestamp + method + requestPath + body
+// requestPath returns the /data/orders path with the filter as query parameters.
+func (q OpenOrdersQuery) requestPath() string {
+	params := url.Values{}
+	if q.Market != "" {
+		params.Set("market", q.Market)
+	}
+	if q.AssetID != "" {
+		params.Set("asset_id", q.AssetID)
+	}
 
-	// Decode secret using URL-safe base64
-	secretBytes, err := base64.URLEncoding.DecodeString(c.secret)
-	if err != nil {
-		err = fmt.Errorf("decode secret: %w", err)
-		return orders, err
+	if len(params) == 0 {
+		return "/data/orders"
 	}
+	return "/data/orders?" + params.Encode()
+}
 
-	// Generate HMAC-SHA256 signature
-	h := hmac.New(sha256.New, secretBytes)
-	h.Write([]byte(signaturePayload))
-	signature := base64.URLEncoding.EncodeToString(h.Sum(nil))
+// GetAllOpenOrders fetches all open orders for the authenticated user
+func (c *OrderClient) GetAllOpenOrders(ctx context.Context) ([]OrderInfo, error) {
+	return c.GetOpenOrders(ctx, OpenOrdersQuery{})
+}
 
-	// Create request
-	url := "https://clob.polymarket.com" + requestPath
-	req, err := http.NewRequestWithContext(ctx, method, url, nil)
-	if err != nil {
-		err = fmt.Errorf("create request: %w", err)
-		return orders, err
-	}
+// GetOpenOrders fetches the authenticated user's open orders matching the query
+func (c *OrderClient) GetOpenOrders(ctx context.Context, query OpenOrdersQuery) (orders []OrderInfo, err error) {
+	logger := tracing.Logger(ctx, c.logger)
 
-	// Set authentication headers
-	req.Header.Set("POLY_API_KEY", c.apiKey)
-	req.Header.Set("POLY_SIGNATURE", signature)
-	req.Header.Set("POLY_TIMESTAMP", timestamp)
-	req.Header.Set("POLY_PASSPHRASE", c.passphrase)
-	req.Header.Set("POLY_ADDRESS", c.address)
+	requestPath := query.requestPath()
 
-	c.logger.Debug("fetching-open-orders",
+	logger.Debug("fetching-open-orders",
 		zap.String("endpoint", requestPath))
 
-	// Make request
-	client := &http.Client{Timeout: 30 * time.Second}
-	httpResp, err := client.Do(req)
-	if err != nil {
-		err = fmt.Errorf("send request: %w", err)
-		return orders, err
-	}
-	defer httpResp.Body.Close()
-
-	// Read response
-	respBody, err := io.ReadAll(httpResp.Body)
+	statusCode, respBody, err := c.doSigned(ctx, http.MethodGet, requestPath, nil)
 	if err != nil {
-		err = fmt.Errorf("read response: %w", err)
 		return orders, err
 	}
 
 	// Check status code
-	if httpResp.StatusCode != http.StatusOK {
-		c.logger.Error("fetch-orders-api-error",
-			zap.Int("status-code", httpResp.StatusCode),
+	if statusCode != http.StatusOK {
+		logger.Error("fetch-orders-api-error",
+			zap.Int("status-code", statusCode),
 			zap.String("response-body", string(respBody)))
-		err = fmt.Errorf("API error (status %d): %s", httpResp.StatusCode, string(respBody))
+		err = fmt.Errorf("API error (status %d): %s", statusCode, string(respBody))
 		return orders, err
 	}
 
 	// Log raw response for debugging
-	c.logger.Debug("fetch-orders-raw-response",
+	logger.Debug("fetch-orders-raw-response",
 		zap.String("body", string(respBody)))
 
 	// Parse response (API returns wrapper with "data" field)
 	var response OpenOrdersResponse
 	err = json.Unmarshal(respBody, &response)
 	if err != nil {
-		c.logger.Error("parse-orders-error",
+		logger.Error("parse-orders-error",
 			zap.Error(err),
 			zap.String("response-body", string(respBody)))
 		err = fmt.Errorf("parse response: %w", err)
@@ -862,7 +1170,7 @@ func (c *OrderClient) GetOpenOrders(ctx context.Context) (orders []OrderInfo, er
 	}
 
 	orders = response.Data
-	c.logger.Info("fetched-open-orders",
+	logger.Info("fetched-open-orders",
 		zap.Int("count", len(orders)))
 
 	return orders, nil
@@ -870,65 +1178,65 @@ func (c *OrderClient) GetOpenOrders(ctx context.Context) (orders []OrderInfo, er
 
 // CancelAllOrders cancels all open orders atomically via DELETE /cancel-all
 func (c *OrderClient) CancelAllOrders(ctx context.Context) (result CancelAllResult, err error) {
-	method := "DELETE"
-	requestPath := "/cancel-all"
-	body := ""
+	logger := tracing.Logger(ctx, c.logger)
 
-	// Build HMAC signature
-	timestamp := fmt.Sprintf("%d", time.Now().Unix())
-	signaturePayload := timestamp + method + requestPath + body
+	logger.Info("canceling-all-orders")
 
-	// Decode secret using URL-safe base64
-	secretBytes, err := base64.URLEncoding.DecodeString(c.secret)
+	statusCode, respBody, err := c.doSigned(ctx, http.MethodDelete, "/cancel-all", nil)
 	if err != nil {
-		err = fmt.Errorf("decode secret: %w", err)
 		return result, err
 	}
 
-	// Generate HMAC-SHA256 signature
-	h := hmac.New(sha256.New, secretBytes)
-	h.Write([]byte(signaturePayload))
-	signature := base64.URLEncoding.EncodeToString(h.Sum(nil))
+	// Check status code
+	if statusCode != http.StatusOK {
+		logger.Error("cancel-all-api-error",
+			zap.Int("status-code", statusCode),
+			zap.String("response-body", string(respBody)))
+		err = fmt.Errorf("API error (status %d): %s", statusCode, string(respBody))
+		return result, err
+	}
 
-	// Create request
-	url := "https://clob.polymarket.com" + requestPath
-	req, err := http.NewRequestWithContext(ctx, method, url, nil)
+	// Parse response
+	err = json.Unmarshal(respBody, &result)
 	if err != nil {
-		err = fmt.Errorf("create request: %w", err)
+		err = fmt.Errorf("parse response: %w", err)
 		return result, err
 	}
 
-	// Set authentication headers
-	req.Header.Set("POLY_API_KEY", c.apiKey)
-	req.Header.Set("POLY_SIGNATURE", signature)
-	req.Header.Set("POLY_TIMESTAMP", timestamp)
-	req.Header.Set("POLY_PASSPHRASE", c.passphrase)
-	req.Header.Set("POLY_ADDRESS", c.address)
+	logger.Info("cancellation-completed",
+		zap.Int("canceled", len(result.Canceled)),
+		zap.Int("not-canceled", len(result.NotCanceled)))
 
-	c.logger.Info("canceling-all-orders")
+	return result, nil
+}
 
-	// Make request
-	client := &http.Client{Timeout: 30 * time.Second}
-	httpResp, err := client.Do(req)
+// CancelOrders cancels specific orders via DELETE /orders
+func (c *OrderClient) CancelOrders(ctx context.Context, orderIDs []string) (result CancelAllResult, err error) {
+	logger := tracing.Logger(ctx, c.logger)
+
+	if len(orderIDs) == 0 {
+		return result, nil
+	}
+
+	reqBody, err := json.Marshal(orderIDs)
 	if err != nil {
-		err = fmt.Errorf("send request: %w", err)
+		err = fmt.Errorf("marshal request: %w", err)
 		return result, err
 	}
-	defer httpResp.Body.Close()
 
-	// Read response
-	respBody, err := io.ReadAll(httpResp.Body)
+	logger.Info("canceling-orders", zap.Strings("order-ids", orderIDs))
+
+	statusCode, respBody, err := c.doSigned(ctx, http.MethodDelete, "/orders", reqBody)
 	if err != nil {
-		err = fmt.Errorf("read response: %w", err)
 		return result, err
 	}
 
 	// Check status code
-	if httpResp.StatusCode != http.StatusOK {
-		c.logger.Error("cancel-all-api-error",
-			zap.Int("status-code", httpResp.StatusCode),
+	if statusCode != http.StatusOK {
+		logger.Error("cancel-orders-api-error",
+			zap.Int("status-code", statusCode),
 			zap.String("response-body", string(respBody)))
-		err = fmt.Errorf("API error (status %d): %s", httpResp.StatusCode, string(respBody))
+		err = fmt.Errorf("API error (status %d): %s", statusCode, string(respBody))
 		return result, err
 	}
 
@@ -939,7 +1247,7 @@ func (c *OrderClient) CancelAllOrders(ctx context.Context) (result CancelAllResu
 		return result, err
 	}
 
-	c.logger.Info("cancellation-completed",
+	logger.Info("cancellation-completed",
 		zap.Int("canceled", len(result.Canceled)),
 		zap.Int("not-canceled", len(result.NotCanceled)))
 
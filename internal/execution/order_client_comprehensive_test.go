@@ -2,14 +2,24 @@ package execution
 
 import (
 	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
+	"slices"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
 	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
 
 	"github.com/mselser95/polymarket-arb/pkg/types"
 )
@@ -36,8 +46,8 @@ func TestNewOrderClient_ValidPrivateKey(t *testing.T) {
 		t.Fatal("expected non-nil client")
 	}
 
-	if client.privateKey == nil {
-		t.Error("expected private key to be set")
+	if client.signer == nil {
+		t.Error("expected signer to be set")
 	}
 
 	if client.address == "" {
@@ -325,6 +335,81 @@ func TestPlaceOrdersMultiOutcome_BelowMinSize(t *testing.T) {
 	}
 }
 
+// TestPlaceOrdersMultiOutcome_UnknownTickSizeStrict tests strict mode rejects unresolved tick sizes
+func TestPlaceOrdersMultiOutcome_UnknownTickSizeStrict(t *testing.T) {
+	cfg := &OrderClientConfig{
+		APIKey:         "test-api-key",
+		Secret:         "dGVzdC1zZWNyZXQ=",
+		Passphrase:     "test-passphrase",
+		PrivateKey:     "0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef",
+		SignatureType:  0,
+		Logger:         zap.NewNop(),
+		StrictTickSize: true,
+	}
+
+	client, err := NewOrderClient(cfg)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	outcomes := []types.OutcomeOrderParams{
+		{TokenID: "token1", Price: 0.50, TickSize: 0.01, MinSize: 1.0, TickSizeUnknown: true},
+		{TokenID: "token2", Price: 0.50, TickSize: 0.01, MinSize: 1.0},
+	}
+
+	_, err = client.PlaceOrdersMultiOutcome(context.Background(), outcomes, 10.0)
+	if !errors.Is(err, ErrUnknownTickSize) {
+		t.Fatalf("expected ErrUnknownTickSize, got %v", err)
+	}
+
+	if !strings.Contains(err.Error(), "token1") {
+		t.Errorf("expected error to name token1, got %v", err)
+	}
+}
+
+// TestResolveRoundingConfig tests strict and lenient handling of unknown tick sizes
+func TestResolveRoundingConfig(t *testing.T) {
+	tests := []struct {
+		name            string
+		strict          bool
+		tickSize        float64
+		tickSizeUnknown bool
+		expectedAmount  int
+		expectErr       bool
+	}{
+		{name: "known-0.001", tickSize: 0.001, expectedAmount: 5},
+		{name: "known-0.001-strict", strict: true, tickSize: 0.001, expectedAmount: 5},
+		{name: "unresolved-lenient", tickSize: 0.01, tickSizeUnknown: true, expectedAmount: 4},
+		{name: "unresolved-strict", strict: true, tickSize: 0.01, tickSizeUnknown: true, expectErr: true},
+		{name: "unsupported-lenient", tickSize: 0.05, expectedAmount: 4},
+		{name: "unsupported-strict", strict: true, tickSize: 0.05, expectErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := &OrderClient{logger: zap.NewNop(), strictTickSize: tt.strict}
+
+			sizePrecision, amountPrecision, err := client.resolveRoundingConfig("token", tt.tickSize, tt.tickSizeUnknown)
+			if tt.expectErr {
+				if !errors.Is(err, ErrUnknownTickSize) {
+					t.Fatalf("expected ErrUnknownTickSize, got %v", err)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if sizePrecision != 2 {
+				t.Errorf("expected size precision 2, got %d", sizePrecision)
+			}
+			if amountPrecision != tt.expectedAmount {
+				t.Errorf("expected amount precision %d, got %d", tt.expectedAmount, amountPrecision)
+			}
+		})
+	}
+}
+
 // TestRoundAmount tests amount rounding to specified precision
 func TestRoundAmount(t *testing.T) {
 	tests := []struct {
@@ -430,8 +515,8 @@ func TestGetRoundingConfig(t *testing.T) {
 	}
 }
 
-// TestUsdToRawAmount tests USD to raw amount conversion
-func TestUsdToRawAmount(t *testing.T) {
+// TestRawAmount_USDC tests USD to raw amount conversion
+func TestRawAmount_USDC(t *testing.T) {
 	tests := []struct {
 		name     string
 		usd      float64
@@ -457,11 +542,16 @@ func TestUsdToRawAmount(t *testing.T) {
 			usd:      0.01,
 			expected: "10000",
 		},
+		{
+			name:     "float-noise-below-boundary",
+			usd:      0.0157,
+			expected: "15700",
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := usdToRawAmount(tt.usd)
+			result := RawAmount(tt.usd, USDCDecimals)
 			if result != tt.expected {
 				t.Errorf("expected %s, got %s", tt.expected, result)
 			}
@@ -627,3 +717,625 @@ func TestSubmitBatchOrder_RateLimit(t *testing.T) {
 		t.Errorf("expected status 429, got %d", statusCode)
 	}
 }
+
+// TestCheckEqualTakerTokens tests that divergent per-leg token counts are rejected
+func TestCheckEqualTakerTokens(t *testing.T) {
+	tests := []struct {
+		name        string
+		takerTokens []float64
+		wantErr     bool
+	}{
+		{name: "equal-binary", takerTokens: []float64{10.12, 10.12}},
+		{name: "equal-multi", takerTokens: []float64{33.34, 33.34, 33.34, 33.34}},
+		{name: "float-noise-within-epsilon", takerTokens: []float64{0.1 + 0.2, 0.3}},
+		// Size 10.125 rounded at size precision 1 vs 2
+		{name: "divergent-binary", takerTokens: []float64{10.1, 10.13}, wantErr: true},
+		{name: "divergent-last-leg", takerTokens: []float64{5.0, 5.0, 5.01}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkEqualTakerTokens(tt.takerTokens)
+			if tt.wantErr {
+				if !errors.Is(err, ErrTakerTokenMismatch) {
+					t.Errorf("expected ErrTakerTokenMismatch, got %v", err)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+// TestMixedTickSizes_TakerTokensEqual tests that legs with different tick sizes
+// round the shared token count identically for all supported tick sizes
+func TestMixedTickSizes_TakerTokensEqual(t *testing.T) {
+	tickSizes := []float64{0.1, 0.01, 0.001, 0.0001}
+	sizes := []float64{10.0, 10.125, 33.335, 1.005, 19.999999}
+
+	for _, size := range sizes {
+		takerTokens := make([]float64, len(tickSizes))
+		for i, tick := range tickSizes {
+			sizePrecision, _, ok := lookupRoundingConfig(tick)
+			if !ok {
+				t.Fatalf("tick size %v unexpectedly unsupported", tick)
+			}
+			takerTokens[i] = roundAmount(size, sizePrecision)
+		}
+
+		err := checkEqualTakerTokens(takerTokens)
+		if err != nil {
+			t.Errorf("size %v: mixed tick sizes produced unequal legs: %v", size, err)
+		}
+	}
+}
+
+// mockBatchCLOB serves POST /orders and DELETE /orders, recording every request.
+// Each accepted order gets ID "order-<tokenId>". failBatch (1-based) rejects every
+// order in that batch; failStatus instead makes that batch return an HTTP error.
+type mockBatchCLOB struct {
+	mu         sync.Mutex
+	batches    [][]string // token IDs per POST, in request order
+	canceled   []string
+	failBatch  int
+	failStatus int
+	failAccept int // Orders accepted at the start of the failing batch before the rejections
+}
+
+func (m *mockBatchCLOB) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if r.URL.Path != "/orders" {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	if r.Method == http.MethodDelete {
+		var ids []string
+		_ = json.NewDecoder(r.Body).Decode(&ids)
+		m.canceled = append(m.canceled, ids...)
+		_ = json.NewEncoder(w).Encode(CancelAllResult{Canceled: ids})
+		return
+	}
+
+	var req types.BatchOrderRequest
+	_ = json.NewDecoder(r.Body).Decode(&req)
+
+	tokenIDs := make([]string, len(req))
+	for i := range req {
+		tokenIDs[i] = req[i].Order.TokenID
+	}
+	m.batches = append(m.batches, tokenIDs)
+
+	if len(m.batches) == m.failBatch && m.failStatus != 0 {
+		w.WriteHeader(m.failStatus)
+		return
+	}
+
+	resp := make(types.BatchOrderResponse, len(req))
+	for i, tokenID := range tokenIDs {
+		if len(m.batches) == m.failBatch && i >= m.failAccept {
+			resp[i] = types.OrderSubmissionResponse{Success: false, ErrorMsg: "not enough balance"}
+			continue
+		}
+		resp[i] = types.OrderSubmissionResponse{Success: true, OrderID: "order-" + tokenID, Status: "live"}
+	}
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+func newBatchTestClient(t *testing.T, serverURL string, maxBatchSize int) *OrderClient {
+	t.Helper()
+
+	client, err := NewOrderClient(&OrderClientConfig{
+		APIKey:       "test-api-key",
+		Secret:       "dGVzdC1zZWNyZXQ=",
+		Passphrase:   "test-passphrase",
+		PrivateKey:   "0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef",
+		Logger:       zap.NewNop(),
+		BaseURL:      serverURL,
+		MaxBatchSize: maxBatchSize,
+	})
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	return client
+}
+
+func batchTestOutcomes(n int) []types.OutcomeOrderParams {
+	outcomes := make([]types.OutcomeOrderParams, n)
+	for i := range outcomes {
+		outcomes[i] = types.OutcomeOrderParams{
+			TokenID:  fmt.Sprintf("%d", 1000+i),
+			Price:    0.09,
+			TickSize: 0.01,
+			MinSize:  1.0,
+		}
+	}
+	return outcomes
+}
+
+// TestPlaceOrdersMultiOutcome_SplitsBatches tests that 10 outcomes with a batch cap
+// of 4 are sent as 4+4+2 and responses keep outcome order.
+func TestPlaceOrdersMultiOutcome_SplitsBatches(t *testing.T) {
+	mock := &mockBatchCLOB{}
+	server := httptest.NewServer(mock)
+	defer server.Close()
+
+	client := newBatchTestClient(t, server.URL, 4)
+	outcomes := batchTestOutcomes(10)
+
+	responses, err := client.PlaceOrdersMultiOutcome(context.Background(), outcomes, 10)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(mock.batches) != 3 {
+		t.Fatalf("expected 3 sub-batches, got %d", len(mock.batches))
+	}
+	for i, want := range []int{4, 4, 2} {
+		if len(mock.batches[i]) != want {
+			t.Errorf("sub-batch %d: expected %d orders, got %d", i, want, len(mock.batches[i]))
+		}
+	}
+
+	if len(responses) != len(outcomes) {
+		t.Fatalf("expected %d responses, got %d", len(outcomes), len(responses))
+	}
+	for i, resp := range responses {
+		if resp.OrderID != "order-"+outcomes[i].TokenID {
+			t.Errorf("response %d: expected order for token %s, got %s", i, outcomes[i].TokenID, resp.OrderID)
+		}
+	}
+
+	if len(mock.canceled) != 0 {
+		t.Errorf("expected no cancellations, got %v", mock.canceled)
+	}
+}
+
+// TestPlaceOrdersMultiOutcome_SingleBatchUnderCap tests that small order sets stay in one request.
+func TestPlaceOrdersMultiOutcome_SingleBatchUnderCap(t *testing.T) {
+	mock := &mockBatchCLOB{}
+	server := httptest.NewServer(mock)
+	defer server.Close()
+
+	client := newBatchTestClient(t, server.URL, 0) // default cap
+
+	_, err := client.PlaceOrdersMultiOutcome(context.Background(), batchTestOutcomes(10), 10)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(mock.batches) != 1 {
+		t.Errorf("expected 1 batch under the default cap, got %d", len(mock.batches))
+	}
+}
+
+// TestPlaceOrdersMultiOutcome_SubBatchRollback tests that a failing later sub-batch
+// cancels orders accepted in earlier sub-batches and in the failing one, and skips the
+// remaining ones.
+func TestPlaceOrdersMultiOutcome_SubBatchRollback(t *testing.T) {
+	tests := []struct {
+		name       string
+		failStatus int
+		failAccept int
+		want       []string
+	}{
+		{
+			name: "orders-rejected",
+			want: []string{"order-1000", "order-1001", "order-1002", "order-1003"},
+		},
+		{
+			name:       "http-error",
+			failStatus: http.StatusInternalServerError,
+			want:       []string{"order-1000", "order-1001", "order-1002", "order-1003"},
+		},
+		{
+			name:       "partially-rejected",
+			failAccept: 2,
+			want:       []string{"order-1000", "order-1001", "order-1002", "order-1003", "order-1004", "order-1005"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := &mockBatchCLOB{failBatch: 2, failStatus: tt.failStatus, failAccept: tt.failAccept}
+			server := httptest.NewServer(mock)
+			defer server.Close()
+
+			client := newBatchTestClient(t, server.URL, 4)
+
+			_, err := client.PlaceOrdersMultiOutcome(context.Background(), batchTestOutcomes(10), 10)
+			if !errors.Is(err, ErrBatchRolledBack) {
+				t.Fatalf("expected ErrBatchRolledBack, got %v", err)
+			}
+
+			if len(mock.batches) != 2 {
+				t.Errorf("expected submission to stop after the failed sub-batch, got %d batches", len(mock.batches))
+			}
+
+			if strings.Join(mock.canceled, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("expected canceled %v, got %v", tt.want, mock.canceled)
+			}
+		})
+	}
+}
+
+// TestPlaceOrdersMultiOutcome_FirstSubBatchRejected tests that a failure in the first
+// sub-batch has nothing to roll back.
+func TestPlaceOrdersMultiOutcome_FirstSubBatchRejected(t *testing.T) {
+	mock := &mockBatchCLOB{failBatch: 1}
+	server := httptest.NewServer(mock)
+	defer server.Close()
+
+	client := newBatchTestClient(t, server.URL, 4)
+
+	_, err := client.PlaceOrdersMultiOutcome(context.Background(), batchTestOutcomes(10), 10)
+	if err == nil {
+		t.Fatal("expected error for rejected orders, got nil")
+	}
+
+	if errors.Is(err, ErrBatchRolledBack) {
+		t.Errorf("expected no rollback when the first sub-batch fails, got %v", err)
+	}
+
+	if len(mock.batches) != 1 || len(mock.canceled) != 0 {
+		t.Errorf("expected 1 batch and no cancels, got %d batches and %v", len(mock.batches), mock.canceled)
+	}
+}
+
+// TestBelowMinSizeError_Typed tests that undersized orders return ErrBelowMinSize with the
+// offending outcome and sizes, for both the multi-outcome and binary batch paths.
+func TestBelowMinSizeError_Typed(t *testing.T) {
+	client, err := NewOrderClient(&OrderClientConfig{
+		APIKey:     "test-api-key",
+		Secret:     "dGVzdC1zZWNyZXQ=",
+		Passphrase: "test-passphrase",
+		PrivateKey: "0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef",
+		Logger:     zap.NewNop(),
+	})
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	ctx := context.Background()
+
+	tests := []struct {
+		name      string
+		place     func() error
+		wantIndex int
+		wantSize  float64
+		wantMin   float64
+	}{
+		{
+			name: "multi-outcome-second-outcome",
+			place: func() error {
+				_, err := client.PlaceOrdersMultiOutcome(ctx, []types.OutcomeOrderParams{
+					{TokenID: "token1", Price: 0.30, TickSize: 0.01, MinSize: 5.0},
+					{TokenID: "token2", Price: 0.30, TickSize: 0.01, MinSize: 10.0},
+					{TokenID: "token3", Price: 0.30, TickSize: 0.01, MinSize: 5.0},
+				}, 8.0)
+				return err
+			},
+			wantIndex: 1,
+			wantSize:  8.0,
+			wantMin:   10.0,
+		},
+		{
+			name: "batch-yes",
+			place: func() error {
+				_, _, err := client.PlaceOrdersBatch(ctx, "yes", "no", 2.0, 0.45, 0.50, 0.01, 5.0, 0.01, 1.0)
+				return err
+			},
+			wantIndex: 0,
+			wantSize:  2.0,
+			wantMin:   5.0,
+		},
+		{
+			name: "batch-no",
+			place: func() error {
+				_, _, err := client.PlaceOrdersBatch(ctx, "yes", "no", 3.0, 0.45, 0.50, 0.01, 1.0, 0.01, 15.0)
+				return err
+			},
+			wantIndex: 1,
+			wantSize:  3.0,
+			wantMin:   15.0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.place()
+			if !errors.Is(err, ErrBelowMinSize) {
+				t.Fatalf("expected ErrBelowMinSize, got %v", err)
+			}
+
+			var minErr *BelowMinSizeError
+			if !errors.As(err, &minErr) {
+				t.Fatalf("expected *BelowMinSizeError, got %T", err)
+			}
+			if minErr.OutcomeIndex != tt.wantIndex || minErr.Size != tt.wantSize || minErr.MinSize != tt.wantMin {
+				t.Errorf("expected outcome %d size %.2f min %.2f, got %+v",
+					tt.wantIndex, tt.wantSize, tt.wantMin, minErr)
+			}
+		})
+	}
+
+	if errors.Is(ErrUnknownTickSize, ErrBelowMinSize) {
+		t.Error("unrelated errors must not match ErrBelowMinSize")
+	}
+}
+
+// TestGetOpenOrders_Filter tests that market and asset filters are sent as query
+// parameters, the signature covers the bare path, and the wrapper sends no filter.
+func TestGetOpenOrders_Filter(t *testing.T) {
+	type request struct {
+		query     url.Values
+		signature string
+		timestamp string
+	}
+	requests := make(chan request, 10)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || r.URL.Path != "/data/orders" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		requests <- request{
+			query:     r.URL.Query(),
+			signature: r.Header.Get("POLY_SIGNATURE"),
+			timestamp: r.Header.Get("POLY_TIMESTAMP"),
+		}
+
+		_ = json.NewEncoder(w).Encode(OpenOrdersResponse{Data: []OrderInfo{
+			{OrderID: "order-1", Market: r.URL.Query().Get("market"), AssetID: r.URL.Query().Get("asset_id")},
+		}})
+	}))
+	defer server.Close()
+
+	client := newSkewTestClient(t, server.URL, false)
+	ctx := context.Background()
+
+	expectedSignature := func(timestamp string) string {
+		secret, _ := base64.URLEncoding.DecodeString("dGVzdC1zZWNyZXQ=")
+		h := hmac.New(sha256.New, secret)
+		h.Write([]byte(timestamp + http.MethodGet + "/data/orders"))
+		return base64.URLEncoding.EncodeToString(h.Sum(nil))
+	}
+
+	tests := []struct {
+		name  string
+		fetch func() ([]OrderInfo, error)
+		want  url.Values
+	}{
+		{
+			name: "market-and-asset",
+			fetch: func() ([]OrderInfo, error) {
+				return client.GetOpenOrders(ctx, OpenOrdersQuery{Market: "0xcondition", AssetID: "12345"})
+			},
+			want: url.Values{"market": {"0xcondition"}, "asset_id": {"12345"}},
+		},
+		{
+			name: "market-only",
+			fetch: func() ([]OrderInfo, error) {
+				return client.GetOpenOrders(ctx, OpenOrdersQuery{Market: "0xcondition"})
+			},
+			want: url.Values{"market": {"0xcondition"}},
+		},
+		{
+			name:  "all-orders-wrapper",
+			fetch: func() ([]OrderInfo, error) { return client.GetAllOpenOrders(ctx) },
+			want:  url.Values{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			orders, err := tt.fetch()
+			if err != nil {
+				t.Fatalf("get open orders: %v", err)
+			}
+
+			req := <-requests
+			if req.query.Encode() != tt.want.Encode() {
+				t.Errorf("expected query %q, got %q", tt.want.Encode(), req.query.Encode())
+			}
+			if req.signature != expectedSignature(req.timestamp) {
+				t.Error("expected signature over timestamp + method + /data/orders")
+			}
+			if len(orders) != 1 || orders[0].Market != tt.want.Get("market") {
+				t.Errorf("unexpected orders: %+v", orders)
+			}
+		})
+	}
+}
+
+// TestPlaceOrders_SignaturesOnlyLoggedAtDebug tests that order signatures never reach
+// info-level logs, while the debug payload still carries them for troubleshooting.
+func TestPlaceOrders_SignaturesOnlyLoggedAtDebug(t *testing.T) {
+	const (
+		yesToken = "71321045679252212594626385532706912750332728571942532289631379312455583992563"
+		noToken  = "52114319501245915516055106046884209969926127482827954674443846427813813222426"
+	)
+
+	place := map[string]func(client *OrderClient) error{
+		"batch": func(client *OrderClient) error {
+			_, _, err := client.PlaceOrdersBatch(context.Background(), yesToken, noToken, 10, 0.50, 0.45, 0.01, 1, 0.01, 1)
+			return err
+		},
+		"multi_outcome": func(client *OrderClient) error {
+			_, err := client.PlaceOrdersMultiOutcome(context.Background(), []types.OutcomeOrderParams{
+				{TokenID: yesToken, Price: 0.50, TickSize: 0.01, MinSize: 1},
+				{TokenID: noToken, Price: 0.45, TickSize: 0.01, MinSize: 1},
+			}, 10)
+			return err
+		},
+	}
+
+	for name, placeOrders := range place {
+		for _, level := range []zapcore.Level{zapcore.InfoLevel, zapcore.DebugLevel} {
+			t.Run(name+"_"+level.String(), func(t *testing.T) {
+				var mu sync.Mutex
+				var signatures []string
+				server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					var req types.BatchOrderRequest
+					err := json.NewDecoder(r.Body).Decode(&req)
+					if err != nil {
+						t.Errorf("decode batch request: %v", err)
+					}
+
+					mu.Lock()
+					for _, order := range req {
+						signatures = append(signatures, order.Order.Signature)
+					}
+					mu.Unlock()
+
+					// Rejected orders end placement without follow-up calls
+					_ = json.NewEncoder(w).Encode(types.BatchOrderResponse{
+						{Success: false, ErrorMsg: "rejected"},
+						{Success: false, ErrorMsg: "rejected"},
+					})
+				}))
+				defer server.Close()
+
+				core, logs := observer.New(level)
+				client, err := NewOrderClient(&OrderClientConfig{
+					APIKey:     "test-api-key",
+					Secret:     "dGVzdC1zZWNyZXQ=",
+					Passphrase: "test-passphrase",
+					PrivateKey: "0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef",
+					Logger:     zap.New(core),
+					BaseURL:    server.URL,
+				})
+				if err != nil {
+					t.Fatalf("failed to create client: %v", err)
+				}
+
+				_ = placeOrders(client)
+
+				if len(signatures) != 2 {
+					t.Fatalf("expected 2 signed orders submitted, got %d", len(signatures))
+				}
+
+				if got := logs.FilterMessage("order-signed").Len(); got != 2 {
+					t.Errorf("expected order-signed logged for both orders, got %d", got)
+				}
+
+				debugHits := 0
+				for _, entry := range logs.All() {
+					for _, field := range entry.Context {
+						for _, signature := range signatures {
+							if field.String == "" || !strings.Contains(field.String, strings.TrimPrefix(signature, "0x")) {
+								continue
+							}
+							if entry.Level > zapcore.DebugLevel {
+								t.Errorf("signature leaked at %s in %q field %q", entry.Level, entry.Message, field.Key)
+							}
+							debugHits++
+						}
+					}
+				}
+
+				// The debug payload carries the signatures, which also proves the search above works
+				if level == zapcore.DebugLevel {
+					if got := logs.FilterMessage("order-eip712-payload").Len(); got != 2 {
+						t.Errorf("expected debug payload for both orders, got %d", got)
+					}
+					if debugHits == 0 {
+						t.Error("expected signatures in debug output")
+					}
+				}
+			})
+		}
+	}
+}
+
+// sortTestOutcomes returns outcomes whose token IDs are out of numeric order, including
+// one that sorts differently lexically ("999" < "1001" numerically).
+func sortTestOutcomes() []types.OutcomeOrderParams {
+	tokenIDs := []string{"1003", "999", "1010", "1001"}
+	outcomes := make([]types.OutcomeOrderParams, len(tokenIDs))
+	for i, tokenID := range tokenIDs {
+		outcomes[i] = types.OutcomeOrderParams{TokenID: tokenID, Price: 0.20, TickSize: 0.01, MinSize: 1.0}
+	}
+	return outcomes
+}
+
+// TestPlaceOrdersMultiOutcome_SortByTokenID tests that sorted batches are sent in token ID
+// order while responses stay aligned with the caller's outcome order.
+func TestPlaceOrdersMultiOutcome_SortByTokenID(t *testing.T) {
+	tests := []struct {
+		name      string
+		sort      bool
+		wantBatch []string
+	}{
+		{name: "outcome_order", sort: false, wantBatch: []string{"1003", "999", "1010", "1001"}},
+		{name: "token_id_order", sort: true, wantBatch: []string{"999", "1001", "1003", "1010"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := &mockBatchCLOB{}
+			server := httptest.NewServer(mock)
+			defer server.Close()
+
+			client := newBatchTestClient(t, server.URL, 0)
+			client.sortByTokenID = tt.sort
+			outcomes := sortTestOutcomes()
+
+			responses, err := client.PlaceOrdersMultiOutcome(context.Background(), outcomes, 10)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if len(mock.batches) != 1 || !slices.Equal(mock.batches[0], tt.wantBatch) {
+				t.Fatalf("expected batch %v, got %v", tt.wantBatch, mock.batches)
+			}
+
+			if len(responses) != len(outcomes) {
+				t.Fatalf("expected %d responses, got %d", len(outcomes), len(responses))
+			}
+			for i, resp := range responses {
+				if resp.OrderID != "order-"+outcomes[i].TokenID {
+					t.Errorf("response %d: expected order for token %s, got %s", i, outcomes[i].TokenID, resp.OrderID)
+				}
+			}
+		})
+	}
+}
+
+// TestPlaceOrdersMultiOutcome_SortByTokenID_PartialBatch tests that when a sorted first
+// sub-batch is rejected, its responses map to the right outcomes and unsent ones are nil.
+func TestPlaceOrdersMultiOutcome_SortByTokenID_PartialBatch(t *testing.T) {
+	mock := &mockBatchCLOB{failBatch: 1}
+	server := httptest.NewServer(mock)
+	defer server.Close()
+
+	client := newBatchTestClient(t, server.URL, 2)
+	client.sortByTokenID = true
+	outcomes := sortTestOutcomes()
+
+	responses, err := client.PlaceOrdersMultiOutcome(context.Background(), outcomes, 10)
+	if err == nil {
+		t.Fatal("expected error for rejected orders, got nil")
+	}
+
+	// The first sub-batch holds the two lowest token IDs: 999 (outcome 1) and 1001 (outcome 3)
+	if len(responses) != len(outcomes) {
+		t.Fatalf("expected %d responses, got %d", len(outcomes), len(responses))
+	}
+	for i, resp := range responses {
+		submitted := i == 1 || i == 3
+		if submitted && (resp == nil || resp.Success) {
+			t.Errorf("outcome %d: expected a rejection, got %+v", i, resp)
+		}
+		if !submitted && resp != nil {
+			t.Errorf("outcome %d: expected no response, got %+v", i, resp)
+		}
+	}
+	if !strings.Contains(err.Error(), "outcome 1") || !strings.Contains(err.Error(), "outcome 3") {
+		t.Errorf("expected rejections reported by outcome index, got %v", err)
+	}
+}
@@ -0,0 +1,96 @@
+package execution
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/polymarket/go-order-utils/pkg/model"
+	"go.uber.org/zap"
+
+	"github.com/mselser95/polymarket-arb/pkg/tracing"
+	"github.com/mselser95/polymarket-arb/pkg/types"
+)
+
+// ErrOrderHashMismatch is returned in strict mode when the API reports a different
+// order ID than the EIP-712 hash we computed for the submitted order.
+var ErrOrderHashMismatch = errors.New("order hash mismatch")
+
+// orderHashFromJSON computes the EIP-712 hash of an order exactly as serialized for the API.
+// Hashing the wire format (not the signed struct) catches drift between our JSON encoding
+// and the on-chain order domain, e.g. a truncated salt or a mis-encoded side.
+func (c *OrderClient) orderHashFromJSON(order types.SignedOrderJSON) (string, error) {
+	side := model.BUY
+	switch order.Side {
+	case "BUY":
+	case "SELL":
+		side = model.SELL
+	default:
+		return "", fmt.Errorf("unknown side %q", order.Side)
+	}
+
+	parsed := &model.Order{
+		Salt:          big.NewInt(order.Salt),
+		Maker:         common.HexToAddress(order.Maker),
+		Signer:        common.HexToAddress(order.Signer),
+		Taker:         common.HexToAddress(order.Taker),
+		Side:          big.NewInt(int64(side)),
+		SignatureType: big.NewInt(int64(order.SignatureType)),
+	}
+
+	fields := []struct {
+		name  string
+		value string
+		dst   **big.Int
+	}{
+		{"tokenId", order.TokenID, &parsed.TokenId},
+		{"makerAmount", order.MakerAmount, &parsed.MakerAmount},
+		{"takerAmount", order.TakerAmount, &parsed.TakerAmount},
+		{"expiration", order.Expiration, &parsed.Expiration},
+		{"nonce", order.Nonce, &parsed.Nonce},
+		{"feeRateBps", order.FeeRateBps, &parsed.FeeRateBps},
+	}
+	for _, field := range fields {
+		value, ok := new(big.Int).SetString(field.value, 10)
+		if !ok {
+			return "", fmt.Errorf("parse %s %q", field.name, field.value)
+		}
+		*field.dst = value
+	}
+
+	hash, err := c.orderBuilder.BuildOrderHash(parsed, model.CTFExchange)
+	if err != nil {
+		return "", fmt.Errorf("build order hash: %w", err)
+	}
+
+	return hash.Hex(), nil
+}
+
+// verifyOrderHash compares the locally computed order hash against the order ID the API
+// assigned. Rejected orders carry no ID and are skipped. A mismatch is logged; in strict
+// mode it is also returned as ErrOrderHashMismatch.
+func (c *OrderClient) verifyOrderHash(ctx context.Context, localHash string, resp *types.OrderSubmissionResponse) error {
+	if resp == nil || !resp.Success || resp.OrderID == "" {
+		return nil
+	}
+
+	if strings.EqualFold(localHash, resp.OrderID) {
+		OrderHashChecksTotal.WithLabelValues("match").Inc()
+		return nil
+	}
+
+	OrderHashChecksTotal.WithLabelValues("mismatch").Inc()
+	tracing.Logger(ctx, c.logger).Warn("order-hash-mismatch",
+		zap.String("local-hash", localHash),
+		zap.String("order-id", resp.OrderID),
+		zap.Bool("strict", c.strictOrderHash))
+
+	if c.strictOrderHash {
+		return fmt.Errorf("%w: computed %s, API returned %s", ErrOrderHashMismatch, localHash, resp.OrderID)
+	}
+
+	return nil
+}
@@ -0,0 +1,172 @@
+package execution
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/polymarket/go-order-utils/pkg/builder"
+	"github.com/polymarket/go-order-utils/pkg/model"
+	"go.uber.org/zap"
+
+	"github.com/mselser95/polymarket-arb/pkg/types"
+)
+
+// TestOrderHashFromJSON_KnownVector hashes the go-order-utils reference order
+// (Amoy chain, fixed salt) and checks it against the library's published hash.
+func TestOrderHashFromJSON_KnownVector(t *testing.T) {
+	client := &OrderClient{
+		orderBuilder: builder.NewExchangeOrderBuilderImpl(big.NewInt(80002), nil),
+		logger:       zap.NewNop(),
+	}
+
+	hash, err := client.orderHashFromJSON(types.SignedOrderJSON{
+		Salt:          479249096354,
+		Maker:         "0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266",
+		Signer:        "0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266",
+		Taker:         "0x0000000000000000000000000000000000000000",
+		TokenID:       "1234",
+		MakerAmount:   "100000000",
+		TakerAmount:   "50000000",
+		Side:          "BUY",
+		Expiration:    "0",
+		Nonce:         "0",
+		FeeRateBps:    "100",
+		SignatureType: 0,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := "0x02ca1d1aa31103804173ad1acd70066cb6c1258a4be6dada055111f9a7ea4e55"
+	if hash != expected {
+		t.Errorf("expected hash %s, got %s", expected, hash)
+	}
+}
+
+// TestOrderHashFromJSON_MatchesSignedOrder tests that our JSON encoding hashes
+// to the same value as the order that was actually signed.
+func TestOrderHashFromJSON_MatchesSignedOrder(t *testing.T) {
+	client, err := NewOrderClient(&OrderClientConfig{
+		PrivateKey: "0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef",
+		Logger:     zap.NewNop(),
+	})
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	signed, err := client.buildSignedOrder(&model.OrderData{
+		Maker:       client.address,
+		Taker:       "0x0000000000000000000000000000000000000000",
+		TokenId:     "71321045679252212594626385532706912750332728571942532289631379312455583992563",
+		MakerAmount: "5200000",
+		TakerAmount: "10000000",
+		Side:        model.BUY,
+		FeeRateBps:  "0",
+		Nonce:       "0",
+		Signer:      client.address,
+		Expiration:  "0",
+	}, "")
+	if err != nil {
+		t.Fatalf("failed to build order: %v", err)
+	}
+
+	signedHash, err := client.orderBuilder.BuildOrderHash(&signed.Order, model.CTFExchange)
+	if err != nil {
+		t.Fatalf("failed to hash signed order: %v", err)
+	}
+
+	jsonHash, err := client.orderHashFromJSON(client.convertToOrderJSON(signed))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if jsonHash != signedHash.Hex() {
+		t.Errorf("JSON encoding drifted from signed order: %s != %s", jsonHash, signedHash.Hex())
+	}
+}
+
+// TestOrderHashFromJSON_InvalidFields tests that unparseable fields are rejected.
+func TestOrderHashFromJSON_InvalidFields(t *testing.T) {
+	client := &OrderClient{
+		orderBuilder: builder.NewExchangeOrderBuilderImpl(big.NewInt(137), nil),
+		logger:       zap.NewNop(),
+	}
+
+	valid := types.SignedOrderJSON{
+		TokenID: "1", MakerAmount: "1", TakerAmount: "1",
+		Expiration: "0", Nonce: "0", FeeRateBps: "0", Side: "BUY",
+	}
+
+	badSide := valid
+	badSide.Side = "HOLD"
+	_, err := client.orderHashFromJSON(badSide)
+	if err == nil {
+		t.Error("expected error for unknown side")
+	}
+
+	badAmount := valid
+	badAmount.MakerAmount = "1.5"
+	_, err = client.orderHashFromJSON(badAmount)
+	if err == nil {
+		t.Error("expected error for non-integer maker amount")
+	}
+}
+
+// TestVerifyOrderHash tests match, mismatch (lenient and strict) and rejected orders.
+func TestVerifyOrderHash(t *testing.T) {
+	const localHash = "0x02ca1d1aa31103804173ad1acd70066cb6c1258a4be6dada055111f9a7ea4e55"
+
+	tests := []struct {
+		name    string
+		strict  bool
+		resp    *types.OrderSubmissionResponse
+		wantErr bool
+	}{
+		{
+			name: "match-case-insensitive",
+			resp: &types.OrderSubmissionResponse{
+				Success: true,
+				OrderID: "0x02CA1D1AA31103804173AD1ACD70066CB6C1258A4BE6DADA055111F9A7EA4E55",
+			},
+		},
+		{
+			name: "mismatch-lenient",
+			resp: &types.OrderSubmissionResponse{Success: true, OrderID: "0xdeadbeef"},
+		},
+		{
+			name:    "mismatch-strict",
+			strict:  true,
+			resp:    &types.OrderSubmissionResponse{Success: true, OrderID: "0xdeadbeef"},
+			wantErr: true,
+		},
+		{
+			name:   "rejected-order-skipped",
+			strict: true,
+			resp:   &types.OrderSubmissionResponse{Success: false, ErrorMsg: "not enough balance"},
+		},
+		{
+			name:   "nil-response-skipped",
+			strict: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := &OrderClient{logger: zap.NewNop(), strictOrderHash: tt.strict}
+
+			err := client.verifyOrderHash(context.Background(), localHash, tt.resp)
+			if tt.wantErr {
+				if !errors.Is(err, ErrOrderHashMismatch) {
+					t.Errorf("expected ErrOrderHashMismatch, got %v", err)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+}
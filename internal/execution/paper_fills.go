@@ -0,0 +1,103 @@
+package execution
+
+import (
+	"math"
+
+	"github.com/mselser95/polymarket-arb/internal/arbitrage"
+	"github.com/mselser95/polymarket-arb/pkg/types"
+)
+
+// paperFill is one outcome leg of a paper trade filled against the ask ladder.
+type paperFill struct {
+	Size float64 // Tokens filled, at most the requested size
+	Cost float64 // USD paid for Size tokens
+}
+
+// AvgPrice returns the volume-weighted average fill price, or 0 if nothing filled.
+func (f paperFill) AvgPrice() float64 {
+	if f.Size <= 0 {
+		return 0
+	}
+	return f.Cost / f.Size
+}
+
+// paperSimulation is a paper trade filled against current orderbook depth.
+type paperSimulation struct {
+	Legs     []paperFill // Per outcome, in opportunity order
+	Sets     float64     // Complete sets bought: the smallest leg fill
+	Profit   float64     // Sets paid out at $1 minus Notional and Fees
+	Notional float64     // USD paid across all legs, including fills beyond Sets
+	Fees     float64     // Estimated taker fees on every leg's fill
+}
+
+// newPaperSimulation totals filled legs. Tokens bought beyond Sets on the deeper legs are
+// unhedged and pay nothing, but their cost and fees still count against Profit.
+func newPaperSimulation(legs []paperFill, fees arbitrage.FeeModel) *paperSimulation {
+	sim := &paperSimulation{Legs: legs}
+	for i, leg := range legs {
+		if i == 0 || leg.Size < sim.Sets {
+			sim.Sets = leg.Size
+		}
+		sim.Notional += leg.Cost
+		sim.Fees += fees.Fee(arbitrage.FeeSideTaker, leg.AvgPrice(), leg.Size)
+	}
+	sim.Profit = sim.Sets - sim.Notional - sim.Fees
+
+	return sim
+}
+
+// Partial reports whether any leg filled less than tokens.
+func (s *paperSimulation) Partial(tokens float64) bool {
+	return s.Sets < tokens-roundingEpsilon
+}
+
+// walkAskDepth buys up to tokens from an ask ladder, best level first.
+func walkAskDepth(depth []types.BookLevel, tokens float64) (fill paperFill) {
+	for _, level := range depth {
+		remaining := tokens - fill.Size
+		if remaining <= 0 {
+			break
+		}
+
+		size := math.Min(level.Size, remaining)
+		fill.Size += size
+		fill.Cost += size * level.Price
+	}
+
+	return fill
+}
+
+// idealPaperFills fills tokens of every outcome in full at its detected ask as taker orders.
+func (e *Executor) idealPaperFills(opp *arbitrage.Opportunity, tokens float64) *paperSimulation {
+	legs := make([]paperFill, len(opp.Outcomes))
+	for i, outcome := range opp.Outcomes {
+		legs[i] = paperFill{Size: tokens, Cost: tokens * outcome.AskPrice}
+	}
+
+	return newPaperSimulation(legs, e.fees())
+}
+
+// simulatePaperFills fills tokens of every outcome against the current ask ladders as
+// taker orders, charging fees estimated by the executor's fee model. ok is false when any
+// ladder is unavailable, in which case the idealized fill at the detected ask applies.
+func (e *Executor) simulatePaperFills(opp *arbitrage.Opportunity, tokens float64) (sim *paperSimulation, ok bool) {
+	if e.snapshots == nil {
+		return nil, false
+	}
+
+	depths := make([][]types.BookLevel, len(opp.Outcomes))
+	for i, outcome := range opp.Outcomes {
+		snapshot, found := e.snapshots.GetSnapshot(outcome.TokenID)
+		if !found || len(snapshot.AskDepth) == 0 {
+			return nil, false
+		}
+		depths[i] = snapshot.AskDepth
+	}
+
+	legs := make([]paperFill, len(depths))
+	for i, depth := range depths {
+		legs[i] = walkAskDepth(depth, tokens)
+	}
+
+	return newPaperSimulation(legs, e.fees()), true
+}
@@ -0,0 +1,180 @@
+package execution
+
+import (
+	"testing"
+
+	promtestutil "github.com/prometheus/client_golang/prometheus/testutil"
+	"go.uber.org/zap"
+
+	"github.com/mselser95/polymarket-arb/internal/arbitrage"
+	"github.com/mselser95/polymarket-arb/pkg/types"
+)
+
+// depthSnapshots serves ask ladders keyed by token ID.
+type depthSnapshots map[string][]types.BookLevel
+
+func (s depthSnapshots) GetSnapshot(tokenID string) (*types.OrderbookSnapshot, bool) {
+	depth, ok := s[tokenID]
+	if !ok {
+		return nil, false
+	}
+
+	snapshot := &types.OrderbookSnapshot{TokenID: tokenID, AskDepth: depth}
+	if len(depth) > 0 {
+		snapshot.BestAskPrice = depth[0].Price
+		snapshot.BestAskSize = depth[0].Size
+	}
+	return snapshot, true
+}
+
+func TestWalkAskDepth(t *testing.T) {
+	depth := []types.BookLevel{{Price: 0.40, Size: 10}, {Price: 0.45, Size: 20}}
+
+	tests := []struct {
+		name     string
+		tokens   float64
+		wantSize float64
+		wantCost float64
+	}{
+		{name: "best_level_only", tokens: 5, wantSize: 5, wantCost: 2.0},
+		{name: "two_levels", tokens: 20, wantSize: 20, wantCost: 4.0 + 4.5},
+		{name: "beyond_depth", tokens: 50, wantSize: 30, wantCost: 4.0 + 9.0},
+		{name: "zero", tokens: 0, wantSize: 0, wantCost: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fill := walkAskDepth(depth, tt.tokens)
+			if !floatEquals(fill.Size, tt.wantSize, 1e-9) || !floatEquals(fill.Cost, tt.wantCost, 1e-9) {
+				t.Errorf("expected %.2f tokens for $%.4f, got %.2f for $%.4f", tt.wantSize, tt.wantCost, fill.Size, fill.Cost)
+			}
+		})
+	}
+}
+
+// TestExecutePaper_RealisticFills compares idealized and realistic paper profit for the
+// test opportunity at YES 0.48 + NO 0.51 with a $51 budget: 100 tokens per leg, an
+// idealized $1.00 profit.
+func TestExecutePaper_RealisticFills(t *testing.T) {
+	opp := arbitrage.CreateTestOpportunity("paper-depth", "paper-depth")
+	opp.MaxTradeSize = 51
+	yesToken, noToken := opp.Outcomes[0].TokenID, opp.Outcomes[1].TokenID
+
+	tests := []struct {
+		name         string
+		realistic    bool
+		takerFee     float64
+		snapshots    SnapshotProvider
+		wantProfit   float64
+		wantPrices   []float64
+		wantSizes    []float64
+		wantNotional float64
+		wantSlippage float64
+		wantFees     float64
+		wantPartial  bool
+	}{
+		{
+			name:       "idealized",
+			realistic:  false,
+			snapshots:  depthSnapshots{yesToken: {{Price: 0.48, Size: 50}, {Price: 0.49, Size: 100}}, noToken: {{Price: 0.51, Size: 100}}},
+			wantProfit: 100 - 48.0 - 51.0,
+			wantPrices: []float64{0.48, 0.51},
+			wantSizes:  []float64{100, 100},
+		},
+		{
+			// YES: 50 @ 0.48 + 50 @ 0.49 = $48.50, NO: 100 @ 0.51 = $51.00
+			name:         "walks_second_level",
+			realistic:    true,
+			snapshots:    depthSnapshots{yesToken: {{Price: 0.48, Size: 50}, {Price: 0.49, Size: 100}}, noToken: {{Price: 0.51, Size: 100}}},
+			wantProfit:   100 - 48.5 - 51.0,
+			wantPrices:   []float64{0.485, 0.51},
+			wantSizes:    []float64{100, 100},
+			wantNotional: 99.5,
+			wantSlippage: 0.5, // 50 YES filled a tick above the detected ask
+		},
+		{
+			// Only 60 YES available: 60 sets pay out, but all 100 NO were paid for and the 40
+			// extra are unhedged
+			name:         "partial_fill",
+			realistic:    true,
+			snapshots:    depthSnapshots{yesToken: {{Price: 0.48, Size: 60}}, noToken: {{Price: 0.51, Size: 100}}},
+			wantProfit:   60 - 28.8 - 51.0,
+			wantPrices:   []float64{0.48, 0.51},
+			wantSizes:    []float64{60, 100},
+			wantNotional: 28.8 + 51.0,
+			wantSlippage: 20.4, // The extra NO's cost
+			wantPartial:  true,
+		},
+		{
+			// 2% of the $99 paid across both legs
+			name:         "taker_fees",
+			realistic:    true,
+			takerFee:     0.02,
+			snapshots:    depthSnapshots{yesToken: {{Price: 0.48, Size: 100}}, noToken: {{Price: 0.51, Size: 100}}},
+			wantProfit:   100 - 48.0 - 51.0 - 1.98,
+			wantPrices:   []float64{0.48, 0.51},
+			wantSizes:    []float64{100, 100},
+			wantNotional: 99.0,
+			wantFees:     1.98,
+		},
+		{
+			name:       "no_depth_falls_back",
+			realistic:  true,
+			snapshots:  depthSnapshots{yesToken: {{Price: 0.48, Size: 50}}, noToken: nil},
+			wantProfit: 100 - 48.0 - 51.0,
+			wantPrices: []float64{0.48, 0.51},
+			wantSizes:  []float64{100, 100},
+		},
+		{
+			name:       "no_snapshots_falls_back",
+			realistic:  true,
+			snapshots:  nil,
+			wantProfit: 100 - 48.0 - 51.0,
+			wantPrices: []float64{0.48, 0.51},
+			wantSizes:  []float64{100, 100},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exec := New(&Config{
+				Mode:                "paper",
+				Logger:              zap.NewNop(),
+				Snapshots:           tt.snapshots,
+				PaperRealisticFills: tt.realistic,
+				TakerFee:            tt.takerFee,
+			})
+
+			partialBefore := promtestutil.ToFloat64(PaperPartialFillsTotal)
+
+			result := exec.executePaper(opp)
+
+			if !floatEquals(result.RealizedProfit, tt.wantProfit, 1e-9) {
+				t.Errorf("expected profit %.4f, got %.4f", tt.wantProfit, result.RealizedProfit)
+			}
+			for i, trade := range result.AllTrades {
+				if !floatEquals(trade.Price, tt.wantPrices[i], 1e-9) || !floatEquals(trade.Size, tt.wantSizes[i], 1e-9) {
+					t.Errorf("leg %d: expected %.2f @ %.4f, got %.2f @ %.4f",
+						i, tt.wantSizes[i], tt.wantPrices[i], trade.Size, trade.Price)
+				}
+			}
+			if tt.wantNotional > 0 && !floatEquals(result.Notional, tt.wantNotional, 1e-9) {
+				t.Errorf("expected notional %.4f, got %.4f", tt.wantNotional, result.Notional)
+			}
+
+			// The spread less fees and slippage is the realized profit
+			if !floatEquals(result.Slippage, tt.wantSlippage, 1e-9) || !floatEquals(result.TotalFees, tt.wantFees, 1e-9) {
+				t.Errorf("expected slippage %.4f and fees %.4f, got %.4f and %.4f",
+					tt.wantSlippage, tt.wantFees, result.Slippage, result.TotalFees)
+			}
+			if net := result.GrossProfit - result.TotalFees - result.Slippage; !floatEquals(net, result.RealizedProfit, 1e-9) {
+				t.Errorf("breakdown sums to %.4f, expected realized profit %.4f", net, result.RealizedProfit)
+			}
+
+			partials := promtestutil.ToFloat64(PaperPartialFillsTotal) - partialBefore
+			if (partials == 1) != tt.wantPartial {
+				t.Errorf("expected partial fill %v, counted %.0f", tt.wantPartial, partials)
+			}
+		})
+	}
+}
@@ -0,0 +1,35 @@
+package execution
+
+import (
+	"fmt"
+
+	"github.com/polymarket/go-order-utils/pkg/model"
+)
+
+// preflightTokenID is the token of the throwaway order signed by VerifySigning. Any
+// token works since the order is never submitted.
+const preflightTokenID = "1"
+
+// VerifySigning signs a throwaway order and checks that the signature recovers to the
+// signer address under the client's EIP-712 domain. It fails when the configured address
+// is not the signing key's, which the CLOB would otherwise only report by rejecting orders.
+func (c *OrderClient) VerifySigning() error {
+	_, err := c.buildSignedOrder(&model.OrderData{
+		Maker:         c.GetMakerAddress(),
+		Taker:         "0x0000000000000000000000000000000000000000",
+		TokenId:       preflightTokenID,
+		MakerAmount:   "1000000",
+		TakerAmount:   "2000000",
+		Side:          model.BUY,
+		FeeRateBps:    "0",
+		Nonce:         "0",
+		Signer:        c.address,
+		Expiration:    "0",
+		SignatureType: c.signatureType,
+	}, "")
+	if err != nil {
+		return fmt.Errorf("sign test order as %s: %w", c.address, err)
+	}
+
+	return nil
+}
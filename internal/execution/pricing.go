@@ -0,0 +1,70 @@
+package execution
+
+import (
+	"math"
+
+	"github.com/mselser95/polymarket-arb/internal/arbitrage"
+)
+
+// Order pricing strategies.
+const (
+	PricingStrategyAsk = "ask" // Cross the spread: the ask plus the configured aggression
+	PricingStrategyBid = "bid" // Rest at the best bid as a maker order
+	PricingStrategyMid = "mid" // Rest at the bid-ask midpoint, rounded down to the tick
+)
+
+// makerPricing reports whether live orders rest on the book under the bid or mid strategy.
+func (e *Executor) makerPricing() bool {
+	return e.pricingStrategy == PricingStrategyBid || e.pricingStrategy == PricingStrategyMid
+}
+
+// feeSide returns the liquidity role live fills take under the pricing strategy.
+func (e *Executor) feeSide() arbitrage.FeeSide {
+	if e.makerPricing() {
+		return arbitrage.FeeSideMaker
+	}
+	return arbitrage.FeeSideTaker
+}
+
+// expectedFillPrices returns the prices live legs are expected to fill at: the detected
+// asks when crossing the spread, or the order prices when resting as maker orders.
+func (e *Executor) expectedFillPrices(opp *arbitrage.Opportunity, orderPrices []float64) []float64 {
+	if e.makerPricing() {
+		return orderPrices
+	}
+	return opportunityAskPrices(opp)
+}
+
+// orderPrice returns the order price for an outcome under the configured pricing strategy.
+func (e *Executor) orderPrice(outcome arbitrage.OpportunityOutcome) float64 {
+	if e.makerPricing() {
+		price := makerPrice(outcome.AskPrice, outcome.BidPrice, outcome.TickSize, e.pricingStrategy)
+		return clampToLimitPrice(price, outcome.LimitPrice, outcome.TickSize)
+	}
+
+	return e.aggressivePrice(outcome)
+}
+
+// makerPrice returns a buy price that rests on the book instead of crossing to the ask:
+// the best bid, or the midpoint rounded down to the tick. The price is kept at least one
+// tick under the ask, so an empty bid side, a one-tick spread, or a crossed book quotes
+// one tick under the ask. It never goes below one tick.
+func makerPrice(askPrice, bidPrice, tickSize float64, strategy string) (price float64) {
+	// Highest price that doesn't take liquidity
+	ceiling := (math.Round(askPrice/tickSize) - 1) * tickSize
+
+	price = bidPrice
+	if strategy == PricingStrategyMid && bidPrice > 0 {
+		price = (bidPrice + askPrice) / 2
+	}
+	price = math.Floor(price/tickSize+roundingEpsilon) * tickSize
+
+	if bidPrice <= 0 || price > ceiling {
+		price = ceiling
+	}
+	if price < tickSize {
+		price = tickSize
+	}
+
+	return price
+}
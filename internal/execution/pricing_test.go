@@ -0,0 +1,205 @@
+package execution
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/mselser95/polymarket-arb/internal/arbitrage"
+)
+
+// TestMakerPrice tests that bid and mid pricing rest below the ask, falling back to one
+// tick under the ask when the book gives no room.
+func TestMakerPrice(t *testing.T) {
+	tests := []struct {
+		name     string
+		askPrice float64
+		bidPrice float64
+		tickSize float64
+		wantBid  float64
+		wantMid  float64
+	}{
+		{name: "wide_spread", askPrice: 0.50, bidPrice: 0.40, tickSize: 0.01, wantBid: 0.40, wantMid: 0.45},
+		{name: "mid_rounds_down", askPrice: 0.50, bidPrice: 0.45, tickSize: 0.01, wantBid: 0.45, wantMid: 0.47},
+		{name: "two_tick_spread", askPrice: 0.50, bidPrice: 0.48, tickSize: 0.01, wantBid: 0.48, wantMid: 0.49},
+		{name: "one_tick_spread", askPrice: 0.50, bidPrice: 0.49, tickSize: 0.01, wantBid: 0.49, wantMid: 0.49},
+		{name: "fine_tick", askPrice: 0.052, bidPrice: 0.047, tickSize: 0.001, wantBid: 0.047, wantMid: 0.049},
+		{name: "no_bids", askPrice: 0.50, bidPrice: 0, tickSize: 0.01, wantBid: 0.49, wantMid: 0.49},
+		{name: "crossed_book", askPrice: 0.50, bidPrice: 0.52, tickSize: 0.01, wantBid: 0.49, wantMid: 0.49},
+		{name: "ask_at_one_tick", askPrice: 0.01, bidPrice: 0, tickSize: 0.01, wantBid: 0.01, wantMid: 0.01},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := makerPrice(tt.askPrice, tt.bidPrice, tt.tickSize, PricingStrategyBid); !floatEquals(got, tt.wantBid, 1e-9) {
+				t.Errorf("bid: expected %f, got %f", tt.wantBid, got)
+			}
+			if got := makerPrice(tt.askPrice, tt.bidPrice, tt.tickSize, PricingStrategyMid); !floatEquals(got, tt.wantMid, 1e-9) {
+				t.Errorf("mid: expected %f, got %f", tt.wantMid, got)
+			}
+		})
+	}
+}
+
+// TestOrderPrice_Strategies tests that the executor's pricing strategy picks between
+// crossing the ask and resting below it, and that the limit price still caps maker prices.
+func TestOrderPrice_Strategies(t *testing.T) {
+	tests := []struct {
+		name       string
+		strategy   string
+		limitPrice float64
+		want       float64
+	}{
+		{name: "default_crosses", strategy: "", want: 0.55},
+		{name: "ask_crosses", strategy: PricingStrategyAsk, want: 0.55},
+		{name: "bid", strategy: PricingStrategyBid, want: 0.40},
+		{name: "mid", strategy: PricingStrategyMid, want: 0.45},
+		{name: "mid_clamped_to_limit", strategy: PricingStrategyMid, limitPrice: 0.43, want: 0.43},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			outcome := arbitrage.OpportunityOutcome{
+				AskPrice:   0.50,
+				BidPrice:   0.40,
+				TickSize:   0.01,
+				LimitPrice: tt.limitPrice,
+			}
+
+			exec := New(&Config{Mode: "live", Logger: zap.NewNop(), AggressionTicks: 5, PricingStrategy: tt.strategy})
+			got := exec.orderPrice(outcome)
+			if !floatEquals(got, tt.want, 1e-9) {
+				t.Errorf("expected %f, got %f", tt.want, got)
+			}
+
+			if tt.strategy == PricingStrategyBid || tt.strategy == PricingStrategyMid {
+				if got >= outcome.AskPrice {
+					t.Errorf("maker price %f crosses the ask %f", got, outcome.AskPrice)
+				}
+			}
+		})
+	}
+}
+
+// TestBuildOrderParams_MakerPricing tests that maker-priced orders are submitted below
+// every ask and sized for the budget at the maker prices.
+func TestBuildOrderParams_MakerPricing(t *testing.T) {
+	exec := New(&Config{Mode: "live", Logger: zap.NewNop(), AggressionTicks: 5, PricingStrategy: PricingStrategyBid})
+
+	opp := arbitrage.CreateTestOpportunity("test-market", "test-slug")
+	opp.Outcomes[0].BidPrice = 0.46
+	opp.Outcomes[1].BidPrice = 0.49
+
+	params, prices, tokens := exec.buildOrderParams(opp)
+
+	for i, p := range params {
+		if p.Price >= opp.Outcomes[i].AskPrice {
+			t.Errorf("outcome %d: price %f crosses the ask %f", i, p.Price, opp.Outcomes[i].AskPrice)
+		}
+	}
+	if !floatEquals(prices[0], 0.46, 1e-9) || !floatEquals(prices[1], 0.49, 1e-9) {
+		t.Errorf("expected bid prices [0.46 0.49], got %v", prices)
+	}
+	if want := tokensForBudget(opp.MaxTradeSize, prices); !floatEquals(tokens, want, 1e-9) {
+		t.Errorf("expected %f tokens at maker prices, got %f", want, tokens)
+	}
+}
+
+// TestExecuteLive_MakerExpectedProfit tests that maker-priced orders expect the profit of
+// filling at their order prices with the maker fee, not at the asks as a taker.
+func TestExecuteLive_MakerExpectedProfit(t *testing.T) {
+	fees := arbitrage.FlatFeeModel{TakerRate: 0.05, MakerRate: -0.01}
+	exec := New(&Config{
+		Mode:            "live",
+		Logger:          zap.NewNop(),
+		OrderClient:     &mockLiveClient{filled: true},
+		PricingStrategy: PricingStrategyBid,
+		FeeModel:        fees,
+		FillTimeout:     5 * time.Second,
+	})
+	exec.ctx = context.Background()
+
+	opp := arbitrage.CreateTestOpportunity("test-market", "test-slug")
+	opp.Outcomes[0].BidPrice = 0.46
+	opp.Outcomes[1].BidPrice = 0.49
+
+	result := exec.executeLive(opp)
+	if !result.Success {
+		t.Fatalf("expected orders to be placed, got %v", result.Error)
+	}
+	exec.verifyWg.Wait()
+
+	// Bought at the bids with a 1% maker rebate
+	tokens := roundDown(tokensForBudget(opp.MaxTradeSize, []float64{0.46, 0.49}), 2)
+	want := tokens*(1-0.95) + tokens*0.95*0.01
+	if !floatEquals(result.ExpectedProfit, want, 1e-9) {
+		t.Errorf("expected profit %f at maker prices, got %f", want, result.ExpectedProfit)
+	}
+}
+
+// cancelRecordingClient is a mockLiveClient that records the orders it is asked to cancel
+// and the executor's open exposure when they were canceled.
+type cancelRecordingClient struct {
+	mockLiveClient
+	exec *Executor
+
+	mu               sync.Mutex
+	canceled         []string
+	exposureAtCancel float64
+}
+
+func (c *cancelRecordingClient) GetOpenOrders(_ context.Context, _ OpenOrdersQuery) ([]OrderInfo, error) {
+	return nil, nil
+}
+
+func (c *cancelRecordingClient) CancelOrders(_ context.Context, orderIDs []string) (CancelAllResult, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.canceled = append(c.canceled, orderIDs...)
+	c.exposureAtCancel = c.exec.OpenExposure()
+	return CancelAllResult{Canceled: orderIDs}, nil
+}
+
+// TestExecuteLive_MakerOrdersCanceledOnTimeout tests that maker orders still resting when
+// fill verification times out are canceled before their exposure is released.
+func TestExecuteLive_MakerOrdersCanceledOnTimeout(t *testing.T) {
+	client := &cancelRecordingClient{}
+	exec := New(&Config{
+		Mode:             "live",
+		Logger:           zap.NewNop(),
+		OrderClient:      client,
+		PricingStrategy:  PricingStrategyBid,
+		FillTimeout:      50 * time.Millisecond,
+		FillRetryInitial: 10 * time.Millisecond,
+		FillRetryMax:     20 * time.Millisecond,
+		FillRetryMult:    2.0,
+	})
+	exec.ctx = context.Background()
+	client.exec = exec
+
+	result := exec.executeLive(arbitrage.CreateTestOpportunity("test-market", "test-slug"))
+	if !result.Success {
+		t.Fatalf("expected orders to be placed, got %v", result.Error)
+	}
+	exec.verifyWg.Wait()
+
+	if got := exec.Stats().FillVerifications["partial"]; got != 1 {
+		t.Errorf("expected 1 partial verification, got %d", got)
+	}
+
+	client.mu.Lock()
+	defer client.mu.Unlock()
+	if len(client.canceled) != 2 {
+		t.Fatalf("expected both legs canceled, got %v", client.canceled)
+	}
+	if !floatEquals(client.exposureAtCancel, result.Notional, 1e-9) {
+		t.Errorf("expected exposure %f held until canceled, got %f", result.Notional, client.exposureAtCancel)
+	}
+	if exec.OpenExposure() != 0 {
+		t.Errorf("expected exposure released after cancel, got %f", exec.OpenExposure())
+	}
+}
@@ -0,0 +1,107 @@
+package execution
+
+import (
+	"container/heap"
+	"time"
+
+	"github.com/mselser95/polymarket-arb/internal/arbitrage"
+)
+
+const (
+	// defaultQueueSize is used when Config.QueueSize is unset.
+	defaultQueueSize = 100
+
+	// defaultQueueMaxAge is used when Config.QueueMaxAge is unset.
+	defaultQueueMaxAge = 5 * time.Second
+)
+
+// opportunityQueue buffers opportunities waiting for execution, highest net profit first.
+// Equal profits are served oldest first, and every opportunity is served once nothing
+// more profitable is waiting, so low-profit opportunities still execute when the
+// executor has capacity. Opportunities older than maxAge are evicted as stale, and when
+// the queue is full the least profitable opportunity is dropped.
+// Not safe for concurrent use; only the execution loop touches it.
+type opportunityQueue struct {
+	items   opportunityHeap
+	maxSize int
+	maxAge  time.Duration
+}
+
+func newOpportunityQueue(maxSize int, maxAge time.Duration) *opportunityQueue {
+	return &opportunityQueue{maxSize: maxSize, maxAge: maxAge}
+}
+
+// Len returns the number of buffered opportunities.
+func (q *opportunityQueue) Len() int {
+	return len(q.items)
+}
+
+// Push buffers an opportunity. When the queue is full the least profitable of the
+// buffered and incoming opportunities is returned as dropped.
+func (q *opportunityQueue) Push(opp *arbitrage.Opportunity) (dropped *arbitrage.Opportunity) {
+	if len(q.items) < q.maxSize {
+		heap.Push(&q.items, opp)
+		return nil
+	}
+
+	// The least profitable item is always a leaf of the max-heap
+	lowest := len(q.items) / 2
+	for i := lowest + 1; i < len(q.items); i++ {
+		if q.items.Less(lowest, i) {
+			lowest = i
+		}
+	}
+
+	if !higherPriority(opp, q.items[lowest]) {
+		return opp
+	}
+
+	dropped = q.items[lowest]
+	q.items[lowest] = opp
+	heap.Fix(&q.items, lowest)
+	return dropped
+}
+
+// Pop returns the most profitable fresh opportunity, or nil if none is buffered.
+// Stale opportunities encountered on the way are returned for accounting.
+func (q *opportunityQueue) Pop(now time.Time) (opp *arbitrage.Opportunity, stale []*arbitrage.Opportunity) {
+	for len(q.items) > 0 {
+		next, _ := heap.Pop(&q.items).(*arbitrage.Opportunity)
+		if q.maxAge > 0 && now.Sub(next.DetectedAt) > q.maxAge {
+			stale = append(stale, next)
+			continue
+		}
+		return next, stale
+	}
+
+	return nil, stale
+}
+
+// higherPriority reports whether a should execute before b.
+func higherPriority(a, b *arbitrage.Opportunity) bool {
+	if a.NetProfit != b.NetProfit {
+		return a.NetProfit > b.NetProfit
+	}
+	return a.DetectedAt.Before(b.DetectedAt)
+}
+
+// opportunityHeap is a max-heap by priority implementing heap.Interface.
+type opportunityHeap []*arbitrage.Opportunity
+
+func (h opportunityHeap) Len() int           { return len(h) }
+func (h opportunityHeap) Less(i, j int) bool { return higherPriority(h[i], h[j]) }
+func (h opportunityHeap) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+
+func (h *opportunityHeap) Push(x any) {
+	opp, _ := x.(*arbitrage.Opportunity)
+	*h = append(*h, opp)
+}
+
+func (h *opportunityHeap) Pop() any {
+	old := *h
+	n := len(old)
+	opp := old[n-1]
+	old[n-1] = nil
+	*h = old[:n-1]
+	return opp
+}
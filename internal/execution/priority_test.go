@@ -0,0 +1,106 @@
+package execution
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mselser95/polymarket-arb/internal/arbitrage"
+)
+
+func queuedOpp(id string, netProfit float64, detectedAt time.Time) *arbitrage.Opportunity {
+	return &arbitrage.Opportunity{ID: id, NetProfit: netProfit, DetectedAt: detectedAt}
+}
+
+func popIDs(t *testing.T, q *opportunityQueue, now time.Time) []string {
+	t.Helper()
+
+	var ids []string
+	for q.Len() > 0 {
+		opp, _ := q.Pop(now)
+		if opp == nil {
+			break
+		}
+		ids = append(ids, opp.ID)
+	}
+	return ids
+}
+
+// TestOpportunityQueue_OrdersByNetProfit tests that the most profitable opportunity is
+// served first, oldest first on equal profit, and that low-profit opportunities are
+// still served once nothing better is waiting.
+func TestOpportunityQueue_OrdersByNetProfit(t *testing.T) {
+	now := time.Now()
+	q := newOpportunityQueue(10, time.Minute)
+
+	q.Push(queuedOpp("low", 0.10, now))
+	q.Push(queuedOpp("high", 2.00, now))
+	q.Push(queuedOpp("mid-newer", 0.50, now))
+	q.Push(queuedOpp("mid-older", 0.50, now.Add(-time.Second)))
+
+	got := popIDs(t, q, now)
+	want := []string{"high", "mid-older", "mid-newer", "low"}
+	if len(got) != len(want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("expected %v, got %v", want, got)
+		}
+	}
+}
+
+// TestOpportunityQueue_EvictsStale tests that opportunities older than maxAge are
+// returned as stale instead of served.
+func TestOpportunityQueue_EvictsStale(t *testing.T) {
+	now := time.Now()
+	q := newOpportunityQueue(10, 5*time.Second)
+
+	q.Push(queuedOpp("stale-rich", 5.00, now.Add(-10*time.Second)))
+	q.Push(queuedOpp("fresh", 0.20, now.Add(-time.Second)))
+
+	opp, stale := q.Pop(now)
+	if opp == nil || opp.ID != "fresh" {
+		t.Fatalf("expected fresh opportunity, got %+v", opp)
+	}
+	if len(stale) != 1 || stale[0].ID != "stale-rich" {
+		t.Fatalf("expected stale-rich to be evicted, got %+v", stale)
+	}
+
+	opp, stale = q.Pop(now)
+	if opp != nil || len(stale) != 0 || q.Len() != 0 {
+		t.Errorf("expected empty queue, got opp=%+v stale=%d len=%d", opp, len(stale), q.Len())
+	}
+}
+
+// TestOpportunityQueue_FullDropsLeastProfitable tests that a full queue keeps the most
+// profitable opportunities.
+func TestOpportunityQueue_FullDropsLeastProfitable(t *testing.T) {
+	now := time.Now()
+	q := newOpportunityQueue(3, time.Minute)
+
+	for i, profit := range []float64{0.30, 0.10, 0.20} {
+		if dropped := q.Push(queuedOpp(string(rune('a'+i)), profit, now)); dropped != nil {
+			t.Fatalf("unexpected drop %s below capacity", dropped.ID)
+		}
+	}
+
+	// Better than the lowest: the lowest is dropped
+	dropped := q.Push(queuedOpp("d", 0.50, now))
+	if dropped == nil || dropped.ID != "b" {
+		t.Fatalf("expected b to be dropped, got %+v", dropped)
+	}
+
+	// Worse than everything buffered: the incoming opportunity is dropped
+	dropped = q.Push(queuedOpp("e", 0.05, now))
+	if dropped == nil || dropped.ID != "e" {
+		t.Fatalf("expected e to be dropped, got %+v", dropped)
+	}
+
+	got := popIDs(t, q, now)
+	want := []string{"d", "a", "c"}
+	for i := range want {
+		if i >= len(got) || got[i] != want[i] {
+			t.Fatalf("expected %v, got %v", want, got)
+		}
+	}
+}
@@ -0,0 +1,154 @@
+package execution
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/mselser95/polymarket-arb/pkg/types"
+)
+
+// reconcileTimeout bounds startup reconciliation of all pending trades.
+const reconcileTimeout = 60 * time.Second
+
+// fillTolerance is the size difference below which an order counts as fully filled.
+const fillTolerance = 0.001
+
+// reconcilePendingTrades resolves live trades whose fill verification did not finish
+// before the previous run stopped. Fully filled trades are credited to cumulative profit.
+// Any other trade has lost its hedge, so legs still resting on the book are canceled.
+// Trades that cannot be resolved (query or cancel failures) stay pending for the next start.
+func (e *Executor) reconcilePendingTrades(ctx context.Context) {
+	trades := e.PendingTrades()
+	if len(trades) == 0 {
+		return
+	}
+
+	querier, ok := e.orderClient.(OrderQuerier)
+	if !ok {
+		e.logger.Warn("skipping-reconciliation-no-order-querier",
+			zap.Int("pending-trades", len(trades)))
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, reconcileTimeout)
+	defer cancel()
+
+	e.logger.Info("reconciling-pending-trades", zap.Int("pending-trades", len(trades)))
+
+	for _, trade := range trades {
+		outcome, err := e.reconcileTrade(ctx, querier, trade)
+		if err != nil {
+			e.logger.Warn("trade-reconciliation-failed",
+				zap.String("opportunity-id", trade.OpportunityID),
+				zap.String("market-slug", trade.MarketSlug),
+				zap.Strings("order-ids", trade.OrderIDs),
+				zap.Error(err))
+			ReconciledTradesTotal.WithLabelValues("error").Inc()
+			continue
+		}
+
+		ReconciledTradesTotal.WithLabelValues(outcome).Inc()
+		e.removePendingTrade(trade.OrderIDs)
+	}
+}
+
+// reconcileTrade queries every leg of trade and settles it. Returns the reconciliation
+// outcome: filled, canceled (resting legs canceled) or partial (nothing left to cancel).
+func (e *Executor) reconcileTrade(
+	ctx context.Context,
+	querier OrderQuerier,
+	trade types.PendingTrade,
+) (outcome string, err error) {
+	fills := make([]types.FillStatus, len(trade.OrderIDs))
+	var restingIDs []string
+
+	for i, orderID := range trade.OrderIDs {
+		resp, err := querier.GetOrder(ctx, orderID)
+		if err != nil {
+			return "", fmt.Errorf("get order %s: %w", orderID, err)
+		}
+
+		fills[i] = types.FillStatus{
+			OrderID:      orderID,
+			Status:       resp.Status,
+			OriginalSize: resp.Size,
+			SizeFilled:   resp.SizeFilled,
+			ActualPrice:  resp.Price,
+			FullyFilled:  resp.SizeFilled >= resp.Size-fillTolerance,
+			VerifiedAt:   time.Now(),
+		}
+		if i < len(trade.Outcomes) {
+			fills[i].Outcome = trade.Outcomes[i]
+		}
+
+		if !fills[i].FullyFilled && strings.EqualFold(resp.Status, types.OrderStatusLive) {
+			restingIDs = append(restingIDs, orderID)
+		}
+	}
+
+	actualProfit, allFilled := calculateActualProfit(fills, e.fees(), e.feeSide())
+	if allFilled {
+		e.mu.Lock()
+		cumulativeProfit := e.recordProfit("live", actualProfit)
+		for _, fill := range fills {
+			e.recordTrade("live", fill.Outcome)
+		}
+		e.mu.Unlock()
+
+		for _, fill := range fills {
+			TradesTotal.WithLabelValues("live", fill.Outcome).Inc()
+		}
+
+		e.logger.Info("reconciled-trade-filled",
+			zap.String("opportunity-id", trade.OpportunityID),
+			zap.String("market-slug", trade.MarketSlug),
+			zap.Float64("actual-profit-usd", e.reportUSD(USDFromFloat(actualProfit))),
+			zap.Float64("cumulative-actual-profit-usd", e.reportUSD(cumulativeProfit)))
+
+		return "filled", nil
+	}
+
+	fillFields := make([]zap.Field, 0, len(fills))
+	for _, fill := range fills {
+		fillFields = append(fillFields,
+			zap.Float64(fmt.Sprintf("%s-size-filled", fill.Outcome), fill.SizeFilled))
+	}
+
+	if len(restingIDs) == 0 {
+		e.logger.Warn("reconciled-trade-partial-fill",
+			append([]zap.Field{
+				zap.String("opportunity-id", trade.OpportunityID),
+				zap.String("market-slug", trade.MarketSlug),
+				zap.String("note", "incomplete hedge, filled legs are unhedged exposure"),
+			}, fillFields...)...)
+
+		return "partial", nil
+	}
+
+	manager, ok := e.orderClient.(OpenOrderManager)
+	if !ok {
+		return "", fmt.Errorf("%d resting orders and no order client to cancel them", len(restingIDs))
+	}
+
+	result, err := manager.CancelOrders(ctx, restingIDs)
+	if err != nil {
+		return "", fmt.Errorf("cancel resting orders: %w", err)
+	}
+
+	if len(result.NotCanceled) > 0 {
+		return "", fmt.Errorf("%d resting orders not canceled", len(result.NotCanceled))
+	}
+
+	e.logger.Warn("reconciled-trade-resting-canceled",
+		append([]zap.Field{
+			zap.String("opportunity-id", trade.OpportunityID),
+			zap.String("market-slug", trade.MarketSlug),
+			zap.Strings("canceled-order-ids", restingIDs),
+		}, fillFields...)...)
+
+	return "canceled", nil
+}
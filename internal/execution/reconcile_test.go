@@ -0,0 +1,182 @@
+package execution
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/mselser95/polymarket-arb/internal/arbitrage"
+	"github.com/mselser95/polymarket-arb/pkg/types"
+)
+
+// mockReconcileClient reports fixed order statuses and records cancellations.
+type mockReconcileClient struct {
+	mu          sync.Mutex
+	orders      map[string]*types.OrderQueryResponse
+	notCanceled map[string]string
+
+	canceledIDs []string
+}
+
+func (m *mockReconcileClient) PlaceOrdersMultiOutcome(
+	_ context.Context,
+	_ []types.OutcomeOrderParams,
+	_ float64,
+) ([]*types.OrderSubmissionResponse, error) {
+	return nil, errors.New("unexpected order placement")
+}
+
+func (m *mockReconcileClient) GetOrder(_ context.Context, orderID string) (*types.OrderQueryResponse, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	resp, ok := m.orders[orderID]
+	if !ok {
+		return nil, fmt.Errorf("order %s: 503 service unavailable", orderID)
+	}
+
+	return resp, nil
+}
+
+func (m *mockReconcileClient) GetOpenOrders(_ context.Context, _ OpenOrdersQuery) ([]OrderInfo, error) {
+	return nil, nil
+}
+
+func (m *mockReconcileClient) CancelOrders(_ context.Context, orderIDs []string) (CancelAllResult, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.canceledIDs = append(m.canceledIDs, orderIDs...)
+	return CancelAllResult{Canceled: orderIDs, NotCanceled: m.notCanceled}, nil
+}
+
+func filledOrder(id string, price float64) *types.OrderQueryResponse {
+	return &types.OrderQueryResponse{OrderID: id, Status: "MATCHED", Price: price, Size: 10, SizeFilled: 10}
+}
+
+func restingOrder(id string, filled float64) *types.OrderQueryResponse {
+	return &types.OrderQueryResponse{OrderID: id, Status: "LIVE", Price: 0.5, Size: 10, SizeFilled: filled}
+}
+
+// TestStart_ReconcilesPendingTrades tests startup reconciliation of trades left unverified
+// by a previous run.
+func TestStart_ReconcilesPendingTrades(t *testing.T) {
+	tests := []struct {
+		name            string
+		orders          map[string]*types.OrderQueryResponse
+		notCanceled     map[string]string
+		wantProfit      float64
+		wantTrades      int
+		wantCanceledIDs []string
+		wantPending     bool
+	}{
+		{
+			name: "filled",
+			orders: map[string]*types.OrderQueryResponse{
+				"yes": filledOrder("yes", 0.45),
+				"no":  filledOrder("no", 0.50),
+			},
+			wantProfit: 10 - 9.5 - 9.5*0.01,
+			wantTrades: 2,
+		},
+		{
+			name: "partial_fill_cancels_resting_leg",
+			orders: map[string]*types.OrderQueryResponse{
+				"yes": filledOrder("yes", 0.45),
+				"no":  restingOrder("no", 4),
+			},
+			wantCanceledIDs: []string{"no"},
+		},
+		{
+			name: "all_resting_canceled",
+			orders: map[string]*types.OrderQueryResponse{
+				"yes": restingOrder("yes", 0),
+				"no":  restingOrder("no", 0),
+			},
+			wantCanceledIDs: []string{"yes", "no"},
+		},
+		{
+			name: "partial_nothing_resting",
+			orders: map[string]*types.OrderQueryResponse{
+				"yes": filledOrder("yes", 0.45),
+				"no":  {OrderID: "no", Status: "CANCELED", Price: 0.5, Size: 10, SizeFilled: 0},
+			},
+		},
+		{
+			name: "cancel_failure_stays_pending",
+			orders: map[string]*types.OrderQueryResponse{
+				"yes": filledOrder("yes", 0.45),
+				"no":  restingOrder("no", 0),
+			},
+			notCanceled:     map[string]string{"no": "order already matched"},
+			wantCanceledIDs: []string{"no"},
+			wantPending:     true,
+		},
+		{
+			name: "query_failure_stays_pending",
+			orders: map[string]*types.OrderQueryResponse{
+				"yes": filledOrder("yes", 0.45),
+			},
+			wantPending: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := newMockStateStore()
+			store.states["live"] = &types.ExecutionState{
+				Mode:             "live",
+				CumulativeProfit: 1.0,
+				TradeCounts:      map[string]int{},
+				PendingTrades: []types.PendingTrade{{
+					OpportunityID: "opp-1",
+					MarketSlug:    "test-slug",
+					OrderIDs:      []string{"yes", "no"},
+					Outcomes:      []string{"YES", "NO"},
+					PlacedAt:      time.Now().Add(-time.Minute),
+				}},
+			}
+
+			client := &mockReconcileClient{orders: tt.orders, notCanceled: tt.notCanceled}
+			exec := New(&Config{
+				Mode:               "live",
+				Logger:             zap.NewNop(),
+				OpportunityChannel: make(chan *arbitrage.Opportunity),
+				OrderClient:        client,
+				StateStore:         store,
+				TakerFee:           0.01,
+				CheckpointInterval: time.Hour,
+			})
+
+			ctx, cancel := context.WithCancel(context.Background())
+			err := exec.Start(ctx)
+			if err != nil {
+				t.Fatalf("start executor: %v", err)
+			}
+			cancel()
+			_ = exec.Close()
+
+			wantProfit := 1.0 + tt.wantProfit
+			if diff := exec.CumulativeProfit() - wantProfit; diff > 1e-9 || diff < -1e-9 {
+				t.Errorf("expected cumulative profit %f, got %f", wantProfit, exec.CumulativeProfit())
+			}
+
+			if got := exec.Stats().TotalFilledLegs; got != tt.wantTrades {
+				t.Errorf("expected %d filled legs, got %d", tt.wantTrades, got)
+			}
+
+			if fmt.Sprint(client.canceledIDs) != fmt.Sprint(tt.wantCanceledIDs) {
+				t.Errorf("expected canceled %v, got %v", tt.wantCanceledIDs, client.canceledIDs)
+			}
+
+			if got := len(store.states["live"].PendingTrades) > 0; got != tt.wantPending {
+				t.Errorf("expected saved pending=%v, got %+v", tt.wantPending, store.states["live"].PendingTrades)
+			}
+		})
+	}
+}
@@ -0,0 +1,149 @@
+package execution
+
+import (
+	"errors"
+	"time"
+
+	"github.com/mselser95/polymarket-arb/internal/arbitrage"
+	"github.com/mselser95/polymarket-arb/pkg/types"
+	"go.uber.org/zap"
+)
+
+// RejectionStrategy is how the executor responds to a CLOB order rejection.
+type RejectionStrategy string
+
+// Rejection handling strategies, from least to most severe.
+const (
+	RejectionRetry          RejectionStrategy = "retry"           // Transient: later opportunities for the market proceed
+	RejectionSkipMarket     RejectionStrategy = "skip_market"     // The market's orders won't succeed as built: skip it for a while
+	RejectionDisableTrading RejectionStrategy = "disable_trading" // The account can't trade: pause execution until resumed
+)
+
+//nolint:gochecknoglobals // Read-only lookup table
+var rejectionSeverity = map[RejectionStrategy]int{
+	RejectionRetry:          0,
+	RejectionSkipMarket:     1,
+	RejectionDisableTrading: 2,
+}
+
+// rejectionStrategyFor maps a rejection code to its handling strategy. Unknown codes
+// are retried, since most CLOB rejections are transient.
+func rejectionStrategyFor(code types.RejectCode) RejectionStrategy {
+	switch code {
+	case types.ErrNotEnoughBalance:
+		return RejectionDisableTrading
+	case types.ErrInvalidMinTickSize,
+		types.ErrInvalidMinSize,
+		types.ErrDuplicatedOrder,
+		types.ErrInvalidExpiration:
+		return RejectionSkipMarket
+	default:
+		return RejectionRetry
+	}
+}
+
+// rejectionCodes returns the codes of the rejected orders in a failed placement: one per
+// rejected response, or the error's own code when the request was rejected as a whole.
+// Empty when err is not a CLOB rejection.
+func rejectionCodes(responses []*types.OrderSubmissionResponse, err error) []types.RejectCode {
+	var orderErr *types.OrderError
+	if !errors.As(err, &orderErr) {
+		return nil
+	}
+
+	var codes []types.RejectCode
+	for _, resp := range responses {
+		if resp != nil && !resp.Success {
+			codes = append(codes, types.ParseRejectCode(resp.ErrorMsg))
+		}
+	}
+	if len(codes) == 0 {
+		codes = append(codes, orderErr.Code)
+	}
+
+	return codes
+}
+
+// handleRejection applies the most severe strategy among the rejection codes of a failed
+// placement. Errors that aren't CLOB rejections (transport, signing) are left alone.
+func (e *Executor) handleRejection(
+	logger *zap.Logger,
+	opp *arbitrage.Opportunity,
+	responses []*types.OrderSubmissionResponse,
+	err error,
+) {
+	codes := rejectionCodes(responses, err)
+	if len(codes) == 0 {
+		return
+	}
+
+	strategy := RejectionRetry
+	code := codes[0]
+	for _, c := range codes {
+		s := rejectionStrategyFor(c)
+		OrderRejectionsTotal.WithLabelValues(string(c), string(s)).Inc()
+		if rejectionSeverity[s] > rejectionSeverity[strategy] {
+			strategy, code = s, c
+		}
+	}
+
+	switch strategy {
+	case RejectionSkipMarket:
+		e.recordRejection(opp.MarketID, time.Now())
+		logger.Warn("market-skipped-after-rejection",
+			zap.String("opportunity-id", opp.ID),
+			zap.String("market-slug", opp.MarketSlug),
+			zap.String("reject-code", string(code)),
+			zap.Duration("rejection-cooldown", e.rejectionCooldown))
+	case RejectionDisableTrading:
+		logger.Error("trading-disabled-after-rejection",
+			zap.String("opportunity-id", opp.ID),
+			zap.String("market-slug", opp.MarketSlug),
+			zap.String("reject-code", string(code)),
+			zap.Bool("circuit-breaker", e.circuitBreaker != nil))
+		if e.circuitBreaker != nil {
+			e.circuitBreaker.Pause()
+		}
+	case RejectionRetry:
+		logger.Debug("order-rejection-retryable",
+			zap.String("opportunity-id", opp.ID),
+			zap.String("reject-code", string(code)))
+	}
+}
+
+// marketRejected reports whether marketID was rejected less than rejectionCooldown before
+// now. Expired entries are removed so the map only holds markets still being skipped.
+func (e *Executor) marketRejected(marketID string, now time.Time) bool {
+	if e.rejectionCooldown <= 0 {
+		return false
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	until, ok := e.rejectedUntil[marketID]
+	if !ok {
+		return false
+	}
+	if !now.Before(until) {
+		delete(e.rejectedUntil, marketID)
+		return false
+	}
+
+	return true
+}
+
+// recordRejection skips marketID for rejectionCooldown from rejectedAt.
+func (e *Executor) recordRejection(marketID string, rejectedAt time.Time) {
+	if e.rejectionCooldown <= 0 {
+		return
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.rejectedUntil == nil {
+		e.rejectedUntil = make(map[string]time.Time)
+	}
+	e.rejectedUntil[marketID] = rejectedAt.Add(e.rejectionCooldown)
+}
@@ -0,0 +1,197 @@
+package execution
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/mselser95/polymarket-arb/internal/arbitrage"
+	"github.com/mselser95/polymarket-arb/internal/circuitbreaker"
+	"github.com/mselser95/polymarket-arb/internal/testutil"
+	"github.com/mselser95/polymarket-arb/pkg/types"
+	promtestutil "github.com/prometheus/client_golang/prometheus/testutil"
+	"go.uber.org/zap"
+)
+
+func TestRejectionStrategyFor(t *testing.T) {
+	tests := []struct {
+		code types.RejectCode
+		want RejectionStrategy
+	}{
+		{code: types.ErrNotEnoughBalance, want: RejectionDisableTrading},
+		{code: types.ErrInvalidMinTickSize, want: RejectionSkipMarket},
+		{code: types.ErrInvalidMinSize, want: RejectionSkipMarket},
+		{code: types.ErrDuplicatedOrder, want: RejectionSkipMarket},
+		{code: types.ErrInvalidExpiration, want: RejectionSkipMarket},
+		{code: types.ErrMarketNotReady, want: RejectionRetry},
+		{code: types.ErrFOKNotFilled, want: RejectionRetry},
+		{code: types.ErrExecution, want: RejectionRetry},
+		{code: types.ErrOrderDelayed, want: RejectionRetry},
+		{code: types.ErrInvalidOrder, want: RejectionRetry},
+		{code: types.ErrUnknownRejection, want: RejectionRetry},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.code), func(t *testing.T) {
+			if got := rejectionStrategyFor(tt.code); got != tt.want {
+				t.Errorf("expected %s, got %s", tt.want, got)
+			}
+		})
+	}
+}
+
+// newRejectionTestBreaker returns a healthy, unpaused circuit breaker.
+func newRejectionTestBreaker(t *testing.T) *circuitbreaker.BalanceCircuitBreaker {
+	t.Helper()
+
+	breaker, err := circuitbreaker.New(&circuitbreaker.Config{
+		CheckInterval:   time.Minute,
+		TradeMultiplier: 3.0,
+		MinAbsolute:     5.0,
+		HysteresisRatio: 1.5,
+		WalletClient:    testutil.NewMockWalletClient(),
+		Address:         common.HexToAddress("0x1234567890abcdef1234567890abcdef12345678"),
+		Logger:          zap.NewNop(),
+	})
+	if err != nil {
+		t.Fatalf("create breaker: %v", err)
+	}
+
+	return breaker
+}
+
+// TestExecuteLive_RejectionStrategies tests that a rejected placement applies the strategy
+// mapped from its code: skipping the market, pausing trading, or nothing for retryable codes.
+func TestExecuteLive_RejectionStrategies(t *testing.T) {
+	tests := []struct {
+		name         string
+		placeErr     error
+		wantCode     types.RejectCode
+		wantStrategy RejectionStrategy
+		wantRejected bool
+		wantPaused   bool
+	}{
+		{
+			name:         "tick_size_skips_market",
+			placeErr:     &types.OrderError{Code: types.ErrInvalidMinTickSize, Message: "outcome 0: breaks minimum tick size rule"},
+			wantCode:     types.ErrInvalidMinTickSize,
+			wantStrategy: RejectionSkipMarket,
+			wantRejected: true,
+		},
+		{
+			name:         "balance_disables_trading",
+			placeErr:     &types.OrderError{Code: types.ErrNotEnoughBalance, Message: "API error (status 400): not enough balance / allowance"},
+			wantCode:     types.ErrNotEnoughBalance,
+			wantStrategy: RejectionDisableTrading,
+			wantPaused:   true,
+		},
+		{
+			name:         "market_not_ready_retries",
+			placeErr:     &types.OrderError{Code: types.ErrMarketNotReady, Message: "outcome 1: the market is not yet ready"},
+			wantCode:     types.ErrMarketNotReady,
+			wantStrategy: RejectionRetry,
+		},
+		{
+			name:     "transport_error_ignored",
+			placeErr: errors.New("submit batch: connection refused"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			breaker := newRejectionTestBreaker(t)
+			exec := New(&Config{
+				Mode:              "live",
+				Logger:            zap.NewNop(),
+				OrderClient:       &mockLiveClient{placeErr: tt.placeErr},
+				CircuitBreaker:    breaker,
+				AggressionTicks:   1,
+				FillTimeout:       time.Second,
+				RejectionCooldown: time.Minute,
+			})
+			exec.ctx = context.Background()
+
+			var rejectionsBefore float64
+			if tt.wantCode != "" {
+				rejectionsBefore = promtestutil.ToFloat64(
+					OrderRejectionsTotal.WithLabelValues(string(tt.wantCode), string(tt.wantStrategy)))
+			}
+
+			result := exec.executeLive(arbitrage.CreateTestOpportunity("test-market", "test-slug"))
+			if result.Success || result.Error == nil {
+				t.Fatalf("expected placement failure, got %+v", result)
+			}
+
+			if tt.wantCode != "" {
+				got := promtestutil.ToFloat64(
+					OrderRejectionsTotal.WithLabelValues(string(tt.wantCode), string(tt.wantStrategy))) - rejectionsBefore
+				if got != 1 {
+					t.Errorf("expected 1 %s/%s rejection, got %f", tt.wantCode, tt.wantStrategy, got)
+				}
+			}
+
+			if got := exec.marketRejected("test-market", time.Now()); got != tt.wantRejected {
+				t.Errorf("expected market rejected %v, got %v", tt.wantRejected, got)
+			}
+			if got := breaker.GetStatus().Paused; got != tt.wantPaused {
+				t.Errorf("expected breaker paused %v, got %v", tt.wantPaused, got)
+			}
+		})
+	}
+}
+
+// TestHandleRejection_MostSevereWins tests that per-order codes in a batch are each counted
+// and the most severe strategy among them is applied.
+func TestHandleRejection_MostSevereWins(t *testing.T) {
+	breaker := newRejectionTestBreaker(t)
+	exec := New(&Config{Mode: "live", Logger: zap.NewNop(), CircuitBreaker: breaker, RejectionCooldown: time.Minute})
+
+	responses := []*types.OrderSubmissionResponse{
+		{Success: false, ErrorMsg: "the market is not yet ready to process new orders"},
+		{Success: false, ErrorMsg: "not enough balance / allowance"},
+		nil, // Not submitted
+	}
+	err := &types.OrderError{Code: types.ErrMarketNotReady, Message: "outcome 0: ...; outcome 1: ..."}
+
+	exec.handleRejection(zap.NewNop(), arbitrage.CreateTestOpportunity("test-market", "test-slug"), responses, err)
+
+	if !breaker.GetStatus().Paused {
+		t.Error("expected the balance rejection to pause trading")
+	}
+	if exec.marketRejected("test-market", time.Now()) {
+		t.Error("expected the market not to be skipped")
+	}
+}
+
+// TestExecute_MarketRejectedSkipped tests that a market skipped after a rejection is
+// skipped until the rejection cooldown elapses, and never when the cooldown is disabled.
+func TestExecute_MarketRejectedSkipped(t *testing.T) {
+	exec := New(&Config{Mode: "paper", Logger: zap.NewNop(), RejectionCooldown: time.Minute})
+	skippedBefore := promtestutil.ToFloat64(OpportunitiesSkippedTotal.WithLabelValues("market_rejected"))
+
+	now := time.Now()
+	exec.recordRejection("market-a", now)
+
+	if result := exec.Execute(arbitrage.CreateTestOpportunity("market-a", "slug-a")); result != nil {
+		t.Errorf("expected market-a to be skipped, got %+v", result)
+	}
+	if result := exec.Execute(arbitrage.CreateTestOpportunity("market-b", "slug-b")); result == nil || !result.Success {
+		t.Errorf("expected market-b to execute, got %+v", result)
+	}
+
+	if got := promtestutil.ToFloat64(OpportunitiesSkippedTotal.WithLabelValues("market_rejected")) - skippedBefore; got != 1 {
+		t.Errorf("expected 1 market_rejected skip, got %f", got)
+	}
+
+	if exec.marketRejected("market-a", now.Add(time.Minute)) {
+		t.Error("expected market-a to be tradable once the cooldown elapsed")
+	}
+
+	disabled := New(&Config{Mode: "paper", Logger: zap.NewNop()})
+	disabled.recordRejection("market-a", now)
+	if disabled.marketRejected("market-a", now) {
+		t.Error("expected no skip with the rejection cooldown disabled")
+	}
+}
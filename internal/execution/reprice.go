@@ -0,0 +1,136 @@
+package execution
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/mselser95/polymarket-arb/internal/arbitrage"
+	"github.com/mselser95/polymarket-arb/pkg/types"
+)
+
+// SnapshotProvider supplies current top-of-book snapshots for repricing.
+// orderbook.Manager implements this interface.
+type SnapshotProvider interface {
+	GetSnapshot(tokenID string) (*types.OrderbookSnapshot, bool)
+}
+
+var (
+	// errBookUnavailable indicates a fresh snapshot was missing or had no asks.
+	errBookUnavailable = errors.New("orderbook snapshot unavailable")
+
+	// errSpreadClosed indicates fresh prices no longer clear the detection threshold.
+	errSpreadClosed = errors.New("spread no longer clears threshold")
+
+	// errNoNetProfit indicates fees at fresh prices and sizes eat the whole spread.
+	errNoNetProfit = errors.New("no net profit after fees")
+)
+
+// isPriceRejection reports whether a failed placement was rejected because of a stale
+// price, i.e. the book moved between detection and submission. It is decided from the
+// rejection codes, and only when every rejected order has one: resubmitting at fresh
+// prices won't help an order that was also rejected for balance or size.
+func isPriceRejection(responses []*types.OrderSubmissionResponse, err error) bool {
+	codes := rejectionCodes(responses, err)
+	if len(codes) == 0 {
+		return false
+	}
+
+	for _, code := range codes {
+		if code != types.ErrFOKNotFilled && code != types.ErrPostOnlyCrosses {
+			return false
+		}
+	}
+
+	return true
+}
+
+// canReprice reports whether a failed placement is safe to resubmit at fresh prices.
+// Resubmitting after any leg was accepted would double-buy that outcome.
+func (e *Executor) canReprice(responses []*types.OrderSubmissionResponse, err error) bool {
+	if e.snapshots == nil || !isPriceRejection(responses, err) {
+		return false
+	}
+
+	for _, resp := range responses {
+		if resp != nil && resp.Success {
+			return false
+		}
+	}
+
+	return true
+}
+
+// repriceOpportunity rebuilds opp from current snapshots: ask and bid prices and sizes,
+// the trade size re-capped to the fresh ask sizes, and fees and net profit recomputed with
+// the executor's fee model. It fails if any book is unavailable, the fresh price sum no
+// longer clears the threshold the opportunity was detected with (less the asks of any
+// skipped tail outcomes), or the order set would no longer make money after fees and the
+// tail's expected loss.
+func (e *Executor) repriceOpportunity(opp *arbitrage.Opportunity) (*arbitrage.Opportunity, error) {
+	outcomes := make([]arbitrage.OpportunityOutcome, len(opp.Outcomes))
+	copy(outcomes, opp.Outcomes)
+
+	maxSize := opp.MaxTradeSize
+	limitPrices := false
+	for i := range outcomes {
+		outcome := &outcomes[i]
+
+		snapshot, ok := e.snapshots.GetSnapshot(outcome.TokenID)
+		if !ok || snapshot.BestAskPrice <= 0 {
+			return nil, fmt.Errorf("%w: outcome %s", errBookUnavailable, outcome.Outcome)
+		}
+
+		outcome.AskPrice = snapshot.BestAskPrice
+		outcome.AskSize = snapshot.BestAskSize
+		outcome.BidPrice = snapshot.BestBidPrice
+		outcome.BidSize = snapshot.BestBidSize
+		outcome.Imbalance = arbitrage.TopOfBookImbalance(snapshot.BestBidSize, snapshot.BestAskSize)
+
+		limitPrices = limitPrices || outcome.LimitPrice > 0
+		outcome.LimitPrice = 0
+
+		maxSize = math.Min(maxSize, snapshot.BestAskSize)
+	}
+
+	threshold := opp.ConfigMaxPriceSum
+	if threshold <= 0 {
+		threshold = 1.0
+	}
+
+	repriced := arbitrage.NewMultiOutcomeOpportunityWithFees(
+		opp.MarketID,
+		opp.MarketSlug,
+		opp.MarketQuestion,
+		outcomes,
+		maxSize,
+		threshold,
+		e.fees(),
+		0, // The min-profit floor was applied at detection
+	)
+
+	// A tail subset must leave room for its skipped outcomes' asks, as at detection
+	maxPriceSum := opp.MaxKeptPriceSum()
+	if repriced.TotalPriceSum >= maxPriceSum {
+		return nil, fmt.Errorf("%w: price sum %.4f >= %.4f", errSpreadClosed, repriced.TotalPriceSum, maxPriceSum)
+	}
+
+	repriced.ChargeSkippedTail(opp.SkippedOutcomes)
+	if repriced.NetProfit <= 0 {
+		return nil, fmt.Errorf("%w: net profit %.4f on size %.2f", errNoNetProfit, repriced.NetProfit, repriced.MaxTradeSize)
+	}
+
+	// Same opportunity, so keep its identity and market-level attributes
+	repriced.ID = opp.ID
+	repriced.TraceID = opp.TraceID
+	repriced.DetectedAt = opp.DetectedAt
+	repriced.NegRisk = opp.NegRisk
+	repriced.LinkedGroup = opp.LinkedGroup
+
+	// Stale limits would cap orders at the old asks
+	if limitPrices {
+		repriced.SetLimitPrices(maxPriceSum)
+	}
+
+	return repriced, nil
+}
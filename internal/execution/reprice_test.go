@@ -0,0 +1,304 @@
+package execution
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/mselser95/polymarket-arb/internal/arbitrage"
+	"github.com/mselser95/polymarket-arb/pkg/types"
+	"go.uber.org/zap"
+)
+
+// rejectOnceClient rejects the first batch with a stale-price error, then accepts.
+type rejectOnceClient struct {
+	rejections int
+	calls      [][]types.OutcomeOrderParams
+}
+
+func (c *rejectOnceClient) PlaceOrdersMultiOutcome(
+	_ context.Context,
+	outcomes []types.OutcomeOrderParams,
+	tokenCount float64,
+) ([]*types.OrderSubmissionResponse, error) {
+	c.calls = append(c.calls, outcomes)
+
+	responses := make([]*types.OrderSubmissionResponse, len(outcomes))
+	if len(c.calls) <= c.rejections {
+		for i := range outcomes {
+			responses[i] = &types.OrderSubmissionResponse{Success: false, ErrorMsg: "order crosses book at stale price"}
+		}
+		return responses, &types.OrderError{Code: types.ErrPostOnlyCrosses, Message: "outcome 0: order crosses book at stale price"}
+	}
+
+	for i := range outcomes {
+		responses[i] = &types.OrderSubmissionResponse{Success: true, OrderID: fmt.Sprintf("order-%d", i)}
+	}
+
+	return responses, nil
+}
+
+// staticSnapshots serves fixed best asks by token ID.
+type staticSnapshots map[string]float64
+
+func (s staticSnapshots) GetSnapshot(tokenID string) (*types.OrderbookSnapshot, bool) {
+	ask, ok := s[tokenID]
+	if !ok {
+		return nil, false
+	}
+
+	return &types.OrderbookSnapshot{TokenID: tokenID, BestAskPrice: ask, BestAskSize: 100}, true
+}
+
+func newRepriceTestExecutor(client OrderPlacer, snapshots SnapshotProvider, attempts int) *Executor {
+	exec := New(&Config{
+		Mode:               "live",
+		Logger:             zap.NewNop(),
+		OrderClient:        client,
+		Snapshots:          snapshots,
+		MaxRepriceAttempts: attempts,
+		AggressionTicks:    1,
+	})
+	exec.ctx = context.Background()
+
+	return exec
+}
+
+// TestExecuteLive_RepriceUsesFreshPrices tests that a stale-price rejection is retried
+// at prices re-read from the orderbook.
+func TestExecuteLive_RepriceUsesFreshPrices(t *testing.T) {
+	opp := arbitrage.CreateTestOpportunity("m1", "slug") // asks 0.48 + 0.51, threshold 0.995
+	snapshots := staticSnapshots{
+		opp.Outcomes[0].TokenID: 0.47,
+		opp.Outcomes[1].TokenID: 0.50,
+	}
+	client := &rejectOnceClient{rejections: 1}
+	exec := newRepriceTestExecutor(client, snapshots, 2)
+
+	result := exec.executeLive(opp)
+	if !result.Success {
+		t.Fatalf("expected success after reprice, got %v", result.Error)
+	}
+
+	if len(client.calls) != 2 {
+		t.Fatalf("expected 2 placement calls, got %d", len(client.calls))
+	}
+
+	// One aggression tick (0.01) above each fresh ask
+	wantPrices := []float64{0.48, 0.51}
+	for i, params := range client.calls[1] {
+		if math.Abs(params.Price-wantPrices[i]) > 1e-9 {
+			t.Errorf("outcome %d: expected repriced order at %.2f, got %.4f", i, wantPrices[i], params.Price)
+		}
+	}
+
+	// Expected profit reflects the fresh 0.97 price sum on the tokens the budget buys at 0.51
+	wantProfit := roundDown(opp.MaxTradeSize/0.51, 2) * 0.03
+	if math.Abs(result.ExpectedProfit-wantProfit) > 1e-9 {
+		t.Errorf("expected profit from fresh prices %.4f, got %.4f", wantProfit, result.ExpectedProfit)
+	}
+
+	// Original opportunity is left untouched
+	if opp.Outcomes[0].AskPrice != 0.48 {
+		t.Errorf("expected original opportunity unchanged, got ask %.2f", opp.Outcomes[0].AskPrice)
+	}
+}
+
+// TestExecuteLive_RepriceAbortsWhenSpreadCloses tests that no retry is sent when
+// fresh prices no longer clear the detection threshold.
+func TestExecuteLive_RepriceAbortsWhenSpreadCloses(t *testing.T) {
+	opp := arbitrage.CreateTestOpportunity("m1", "slug")
+	snapshots := staticSnapshots{
+		opp.Outcomes[0].TokenID: 0.50,
+		opp.Outcomes[1].TokenID: 0.50, // sum 1.00 >= 0.995
+	}
+	client := &rejectOnceClient{rejections: 1}
+	exec := newRepriceTestExecutor(client, snapshots, 3)
+
+	result := exec.executeLive(opp)
+	if result.Success {
+		t.Fatal("expected failure when spread closed")
+	}
+
+	if len(client.calls) != 1 {
+		t.Errorf("expected no resubmission, got %d calls", len(client.calls))
+	}
+}
+
+// TestExecuteLive_RepriceAttemptsBounded tests that retries stop at MaxRepriceAttempts.
+func TestExecuteLive_RepriceAttemptsBounded(t *testing.T) {
+	opp := arbitrage.CreateTestOpportunity("m1", "slug")
+	snapshots := staticSnapshots{
+		opp.Outcomes[0].TokenID: 0.47,
+		opp.Outcomes[1].TokenID: 0.50,
+	}
+	client := &rejectOnceClient{rejections: 10}
+	exec := newRepriceTestExecutor(client, snapshots, 2)
+
+	result := exec.executeLive(opp)
+	if result.Success {
+		t.Fatal("expected failure after exhausting reprice attempts")
+	}
+
+	if len(client.calls) != 3 {
+		t.Errorf("expected 1 initial + 2 reprice calls, got %d", len(client.calls))
+	}
+}
+
+// TestExecuteLive_RepriceRespectsExposureLimit tests that a reprice raising the order cost
+// past the exposure limit is aborted, and the original reservation is released.
+func TestExecuteLive_RepriceRespectsExposureLimit(t *testing.T) {
+	opp := arbitrage.CreateTestOpportunity("m1", "slug") // orders at 0.49 + 0.52
+	snapshots := staticSnapshots{
+		opp.Outcomes[0].TokenID: 0.49,
+		opp.Outcomes[1].TokenID: 0.49, // Even prices buy more tokens within the budget
+	}
+	client := &rejectOnceClient{rejections: 1}
+	exec := newRepriceTestExecutor(client, snapshots, 1)
+
+	initial := orderNotional(tokensForBudget(opp.MaxTradeSize, []float64{0.49, 0.52}), []float64{0.49, 0.52})
+	exec.maxOpenExposure = initial + 1
+
+	result := exec.executeLive(opp)
+	if !errors.Is(result.Error, ErrExposureLimit) {
+		t.Fatalf("expected ErrExposureLimit, got %v", result.Error)
+	}
+
+	if len(client.calls) != 1 {
+		t.Errorf("expected no resubmission past the exposure limit, got %d calls", len(client.calls))
+	}
+
+	if exec.OpenExposure() != 0 {
+		t.Errorf("expected exposure released, got %f", exec.OpenExposure())
+	}
+}
+
+// TestCanReprice tests that only placements whose every rejection carries a stale-price
+// code, and with no accepted leg, are repriced.
+func TestCanReprice(t *testing.T) {
+	priceErr := &types.OrderError{Code: types.ErrPostOnlyCrosses, Message: "outcome 0: order crosses book"}
+	crosses := &types.OrderSubmissionResponse{Success: false, ErrorMsg: "invalid post-only order: order crosses book"}
+	fok := &types.OrderSubmissionResponse{Success: false, ErrorMsg: "no orders found to match with FOK order"}
+	balance := &types.OrderSubmissionResponse{Success: false, ErrorMsg: "not enough balance / allowance"}
+	tickSize := &types.OrderSubmissionResponse{Success: false, ErrorMsg: "order price breaks minimum tick size rule: 0.01"}
+	accepted := &types.OrderSubmissionResponse{Success: true, OrderID: "a"}
+
+	tests := []struct {
+		name      string
+		snapshots SnapshotProvider
+		responses []*types.OrderSubmissionResponse
+		err       error
+		want      bool
+	}{
+		{name: "price_rejection", snapshots: staticSnapshots{}, responses: []*types.OrderSubmissionResponse{crosses, fok}, err: priceErr, want: true},
+		{name: "no_responses", snapshots: staticSnapshots{}, responses: nil, err: priceErr, want: true},
+		{name: "wrapped", snapshots: staticSnapshots{}, responses: nil, err: fmt.Errorf("submit batch: %w", priceErr), want: true},
+		{name: "leg_accepted", snapshots: staticSnapshots{}, responses: []*types.OrderSubmissionResponse{accepted, crosses}, err: priceErr, want: false},
+		{name: "mixed_rejection", snapshots: staticSnapshots{}, responses: []*types.OrderSubmissionResponse{crosses, balance}, err: priceErr, want: false},
+		{name: "price_in_message", snapshots: staticSnapshots{}, responses: []*types.OrderSubmissionResponse{tickSize}, err: &types.OrderError{Code: types.ErrInvalidMinTickSize, Message: "outcome 0: order price breaks minimum tick size rule"}, want: false},
+		{name: "not_order_error", snapshots: staticSnapshots{}, responses: nil, err: errors.New("order crosses book"), want: false},
+		{name: "no_error", snapshots: staticSnapshots{}, responses: []*types.OrderSubmissionResponse{crosses}, err: nil, want: false},
+		{name: "no_snapshots", snapshots: nil, responses: []*types.OrderSubmissionResponse{crosses}, err: priceErr, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exec := &Executor{snapshots: tt.snapshots}
+			if got := exec.canReprice(tt.responses, tt.err); got != tt.want {
+				t.Errorf("canReprice() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRepriceOpportunity_MissingBook(t *testing.T) {
+	opp := arbitrage.CreateTestOpportunity("m1", "slug")
+	exec := &Executor{snapshots: staticSnapshots{opp.Outcomes[0].TokenID: 0.40}}
+
+	_, err := exec.repriceOpportunity(opp)
+	if !errors.Is(err, errBookUnavailable) {
+		t.Errorf("expected errBookUnavailable, got %v", err)
+	}
+}
+
+// bookSnapshots serves fixed top-of-book snapshots by token ID.
+type bookSnapshots map[string]*types.OrderbookSnapshot
+
+func (s bookSnapshots) GetSnapshot(tokenID string) (*types.OrderbookSnapshot, bool) {
+	snapshot, ok := s[tokenID]
+	return snapshot, ok
+}
+
+// TestRepriceOpportunity_RecomputesProfit tests that a repriced opportunity is sized to
+// the fresh ask depth and its fees and net profit are recomputed, and that it is rejected
+// once fees exceed the spread.
+func TestRepriceOpportunity_RecomputesProfit(t *testing.T) {
+	opp := arbitrage.CreateTestOpportunity("m1", "slug") // MaxTradeSize 100
+	snapshots := bookSnapshots{
+		opp.Outcomes[0].TokenID: {BestAskPrice: 0.47, BestAskSize: 40, BestBidPrice: 0.45, BestBidSize: 10},
+		opp.Outcomes[1].TokenID: {BestAskPrice: 0.50, BestAskSize: 80},
+	}
+
+	tests := []struct {
+		name     string
+		takerFee float64
+		wantErr  error
+	}{
+		{name: "profitable", takerFee: 0.01},
+		{name: "fees_exceed_spread", takerFee: 0.05, wantErr: errNoNetProfit}, // 0.05 × 0.97 > 0.03
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exec := &Executor{snapshots: snapshots, takerFee: tt.takerFee}
+
+			repriced, err := exec.repriceOpportunity(opp)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("expected error %v, got %v", tt.wantErr, err)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+
+			// Re-capped to the shallower fresh ask
+			if repriced.MaxTradeSize != 40 {
+				t.Errorf("expected trade size 40, got %f", repriced.MaxTradeSize)
+			}
+
+			wantFees := 40 * 0.97 * tt.takerFee
+			if math.Abs(repriced.TotalFees-wantFees) > 1e-9 {
+				t.Errorf("expected fees %f, got %f", wantFees, repriced.TotalFees)
+			}
+			if want := 40*0.03 - wantFees; math.Abs(repriced.NetProfit-want) > 1e-9 {
+				t.Errorf("expected net profit %f, got %f", want, repriced.NetProfit)
+			}
+			if repriced.ID != opp.ID || repriced.TraceID != opp.TraceID {
+				t.Errorf("expected identity kept, got ID %s trace %s", repriced.ID, repriced.TraceID)
+			}
+			if repriced.Outcomes[0].BidPrice != 0.45 {
+				t.Errorf("expected fresh bid 0.45, got %f", repriced.Outcomes[0].BidPrice)
+			}
+		})
+	}
+}
+
+// TestRepriceOpportunity_TailSubsetLeavesRoomForTail tests that a tail subset is rejected
+// once its fresh price sum no longer leaves room for the skipped outcomes' asks, even
+// though it alone is still below the threshold.
+func TestRepriceOpportunity_TailSubsetLeavesRoomForTail(t *testing.T) {
+	opp := arbitrage.CreateTestOpportunity("m1", "slug") // Threshold 0.995
+	opp.SkippedOutcomes = []arbitrage.OpportunityOutcome{{TokenID: "tail", Outcome: "Other", AskPrice: 0.005}}
+	snapshots := bookSnapshots{
+		opp.Outcomes[0].TokenID: {BestAskPrice: 0.47, BestAskSize: 100},
+		opp.Outcomes[1].TokenID: {BestAskPrice: 0.522, BestAskSize: 100},
+	}
+	exec := &Executor{snapshots: snapshots}
+
+	// 0.992 clears 0.995 but not 0.995 - 0.005
+	_, err := exec.repriceOpportunity(opp)
+	if !errors.Is(err, errSpreadClosed) {
+		t.Errorf("expected errSpreadClosed, got %v", err)
+	}
+}
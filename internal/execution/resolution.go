@@ -0,0 +1,229 @@
+package execution
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// ConditionPosition is a held outcome token and the condition it belongs to.
+type ConditionPosition struct {
+	ConditionID  string
+	MarketSlug   string
+	TokenID      string
+	OutcomeIndex int // Outcome slot in the condition
+	Size         float64
+	NegRisk      bool
+}
+
+// ConditionPositions lists held positions with their condition IDs. Markets are
+// unsubscribed once they close, so the wallet is the source of what we still hold.
+type ConditionPositions interface {
+	ConditionPositions(ctx context.Context) ([]ConditionPosition, error)
+}
+
+// ConditionResolver reports how a condition resolved.
+// *wallet.CTFRedeemer implements this interface.
+type ConditionResolver interface {
+	// ConditionPayouts returns the payout fraction per outcome slot, or nil if the
+	// condition has not resolved.
+	ConditionPayouts(ctx context.Context, conditionID string) ([]float64, error)
+}
+
+// Redeemer redeems every outcome slot of a resolved condition for collateral. A call for
+// a condition whose redemption is still unmined from an earlier call must wait on that
+// transaction rather than send another. *wallet.CTFRedeemer implements this interface.
+type Redeemer interface {
+	Redeem(ctx context.Context, conditionID string, outcomeCount int) (txHash string, err error)
+}
+
+// ResolvedCondition is a condition we hold positions in that has resolved.
+type ResolvedCondition struct {
+	ConditionID string
+	MarketSlug  string
+	Payouts     []float64 // Payout fraction per outcome slot
+	Value       float64   // USD redeemable for the held positions
+	NegRisk     bool
+}
+
+// ResolutionMonitorConfig holds resolution monitor configuration.
+type ResolutionMonitorConfig struct {
+	Interval  time.Duration
+	Positions ConditionPositions
+	Resolver  ConditionResolver
+	Redeemer  Redeemer // Optional: nil only detects resolved conditions
+	Logger    *zap.Logger
+}
+
+// ResolutionMonitor periodically checks the conditions of held positions and redeems
+// the ones that resolved in our favor, turning winning tokens into collateral without
+// waiting for the operator to run redeem-positions.
+type ResolutionMonitor struct {
+	interval  time.Duration
+	positions ConditionPositions
+	resolver  ConditionResolver
+	redeemer  Redeemer
+	logger    *zap.Logger
+
+	// handled holds conditions already redeemed or reported; they are skipped until the
+	// positions disappear from the wallet. Only the Run goroutine touches it.
+	handled map[string]bool
+}
+
+// NewResolutionMonitor creates a resolution monitor.
+func NewResolutionMonitor(cfg *ResolutionMonitorConfig) (*ResolutionMonitor, error) {
+	if cfg.Interval <= 0 {
+		return nil, fmt.Errorf("interval must be positive, got %s", cfg.Interval)
+	}
+	if cfg.Positions == nil || cfg.Resolver == nil {
+		return nil, errors.New("positions and resolver are required")
+	}
+
+	return &ResolutionMonitor{
+		interval:  cfg.Interval,
+		positions: cfg.Positions,
+		resolver:  cfg.Resolver,
+		redeemer:  cfg.Redeemer,
+		logger:    cfg.Logger,
+		handled:   make(map[string]bool),
+	}, nil
+}
+
+// Run checks for resolved conditions every interval until ctx is canceled.
+func (m *ResolutionMonitor) Run(ctx context.Context) error {
+	ticker := time.NewTicker(m.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+			_, err := m.Check(ctx)
+			if err != nil && ctx.Err() == nil {
+				m.logger.Warn("resolution-check-failed", zap.Error(err))
+			}
+		}
+	}
+}
+
+// Check runs one resolution check and returns the resolved conditions not handled by
+// an earlier check. A condition whose redemption fails is retried on the next check.
+func (m *ResolutionMonitor) Check(ctx context.Context) ([]ResolvedCondition, error) {
+	positions, err := m.positions.ConditionPositions(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("condition positions: %w", err)
+	}
+
+	// Group positions by condition, keeping the wallet's order
+	var conditionIDs []string
+	byCondition := make(map[string][]ConditionPosition)
+	for _, pos := range positions {
+		if _, ok := byCondition[pos.ConditionID]; !ok {
+			conditionIDs = append(conditionIDs, pos.ConditionID)
+		}
+		byCondition[pos.ConditionID] = append(byCondition[pos.ConditionID], pos)
+	}
+
+	// Forget handled conditions we no longer hold
+	for conditionID := range m.handled {
+		if _, ok := byCondition[conditionID]; !ok {
+			delete(m.handled, conditionID)
+		}
+	}
+
+	var resolved []ResolvedCondition
+	for _, conditionID := range conditionIDs {
+		if m.handled[conditionID] {
+			continue
+		}
+
+		payouts, err := m.resolver.ConditionPayouts(ctx, conditionID)
+		if err != nil {
+			m.logger.Warn("condition-resolution-check-failed",
+				zap.String("condition-id", conditionID),
+				zap.Error(err))
+			continue
+		}
+		if payouts == nil {
+			continue
+		}
+
+		condition := resolvedCondition(conditionID, payouts, byCondition[conditionID])
+		resolved = append(resolved, condition)
+		ResolvedConditionsTotal.Inc()
+
+		m.logger.Info("condition-resolved",
+			zap.String("condition-id", conditionID),
+			zap.String("market-slug", condition.MarketSlug),
+			zap.Float64s("payouts", condition.Payouts),
+			zap.Float64("redeem-value-usd", condition.Value))
+
+		m.handled[conditionID] = m.redeem(ctx, condition)
+	}
+
+	return resolved, nil
+}
+
+// redeem submits the redemption for a resolved condition and reports whether the
+// condition is done with (redeemed, or nothing to redeem automatically). A redemption
+// not mined in time fails and is re-checked, not resent, on the next check.
+func (m *ResolutionMonitor) redeem(ctx context.Context, condition ResolvedCondition) bool {
+	switch {
+	case m.redeemer == nil:
+		return true
+	case condition.Value <= 0:
+		// Only losing tokens are held; redeeming them pays nothing but gas
+		RedemptionsTotal.WithLabelValues("skipped").Inc()
+		return true
+	case condition.NegRisk:
+		RedemptionsTotal.WithLabelValues("skipped").Inc()
+		m.logger.Warn("neg-risk-redemption-unsupported",
+			zap.String("condition-id", condition.ConditionID),
+			zap.String("market-slug", condition.MarketSlug),
+			zap.String("note", "redeem through the NegRiskAdapter manually"))
+		return true
+	}
+
+	txHash, err := m.redeemer.Redeem(ctx, condition.ConditionID, len(condition.Payouts))
+	if err != nil {
+		RedemptionsTotal.WithLabelValues("error").Inc()
+		m.logger.Warn("redemption-failed",
+			zap.String("condition-id", condition.ConditionID),
+			zap.String("market-slug", condition.MarketSlug),
+			zap.String("tx-hash", txHash),
+			zap.Error(err))
+		return false
+	}
+
+	RedemptionsTotal.WithLabelValues("redeemed").Inc()
+	RedeemedValueUSD.Add(condition.Value)
+	m.logger.Info("condition-redeemed",
+		zap.String("condition-id", condition.ConditionID),
+		zap.String("market-slug", condition.MarketSlug),
+		zap.String("tx-hash", txHash),
+		zap.Float64("redeem-value-usd", condition.Value))
+
+	return true
+}
+
+// resolvedCondition values the held positions of a condition at its payouts.
+func resolvedCondition(conditionID string, payouts []float64, positions []ConditionPosition) ResolvedCondition {
+	condition := ResolvedCondition{
+		ConditionID: conditionID,
+		MarketSlug:  positions[0].MarketSlug,
+		Payouts:     payouts,
+	}
+
+	for _, pos := range positions {
+		if pos.OutcomeIndex >= 0 && pos.OutcomeIndex < len(payouts) {
+			condition.Value += pos.Size * payouts[pos.OutcomeIndex]
+		}
+		condition.NegRisk = condition.NegRisk || pos.NegRisk
+	}
+
+	return condition
+}
@@ -0,0 +1,265 @@
+package execution
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	promtestutil "github.com/prometheus/client_golang/prometheus/testutil"
+	"go.uber.org/zap"
+)
+
+// stubConditionPositions returns fixed positions.
+type stubConditionPositions struct {
+	positions []ConditionPosition
+	err       error
+}
+
+func (s *stubConditionPositions) ConditionPositions(context.Context) ([]ConditionPosition, error) {
+	return s.positions, s.err
+}
+
+// stubResolver serves payouts by condition ID; conditions without payouts are unresolved.
+type stubResolver struct {
+	payouts map[string][]float64
+	errs    map[string]error
+	calls   int
+}
+
+func (s *stubResolver) ConditionPayouts(_ context.Context, conditionID string) ([]float64, error) {
+	s.calls++
+	return s.payouts[conditionID], s.errs[conditionID]
+}
+
+// stubRedeemer records redemptions, failing while err is set.
+type stubRedeemer struct {
+	redeemed []string
+	outcomes []int
+	err      error
+}
+
+func (s *stubRedeemer) Redeem(_ context.Context, conditionID string, outcomeCount int) (string, error) {
+	if s.err != nil {
+		return "", s.err
+	}
+	s.redeemed = append(s.redeemed, conditionID)
+	s.outcomes = append(s.outcomes, outcomeCount)
+	return "0xtx-" + conditionID, nil
+}
+
+func newTestResolutionMonitor(t *testing.T, positions ConditionPositions, resolver ConditionResolver, redeemer *stubRedeemer) *ResolutionMonitor {
+	t.Helper()
+
+	cfg := &ResolutionMonitorConfig{
+		Interval:  time.Minute,
+		Positions: positions,
+		Resolver:  resolver,
+		Logger:    zap.NewNop(),
+	}
+	// Avoid storing a typed nil in the interface
+	if redeemer != nil {
+		cfg.Redeemer = redeemer
+	}
+
+	monitor, err := NewResolutionMonitor(cfg)
+	if err != nil {
+		t.Fatalf("failed to create monitor: %v", err)
+	}
+	return monitor
+}
+
+// TestResolutionMonitor_Check tests that only resolved conditions with winning positions
+// are redeemed, valued at their payouts.
+func TestResolutionMonitor_Check(t *testing.T) {
+	positions := &stubConditionPositions{positions: []ConditionPosition{
+		// Complete set in a resolved binary market: the YES leg won
+		{ConditionID: "0xwon", MarketSlug: "won-slug", TokenID: "w-yes", OutcomeIndex: 0, Size: 40},
+		{ConditionID: "0xwon", MarketSlug: "won-slug", TokenID: "w-no", OutcomeIndex: 1, Size: 40},
+		// Resolved against us
+		{ConditionID: "0xlost", MarketSlug: "lost-slug", TokenID: "l-yes", OutcomeIndex: 0, Size: 10},
+		// Still open
+		{ConditionID: "0xopen", MarketSlug: "open-slug", TokenID: "o-yes", OutcomeIndex: 0, Size: 5},
+		// Resolved 50/50
+		{ConditionID: "0xsplit", MarketSlug: "split-slug", TokenID: "s-no", OutcomeIndex: 1, Size: 8},
+	}}
+	resolver := &stubResolver{payouts: map[string][]float64{
+		"0xwon":   {1, 0},
+		"0xlost":  {0, 1},
+		"0xsplit": {0.5, 0.5},
+	}}
+	redeemer := &stubRedeemer{}
+	monitor := newTestResolutionMonitor(t, positions, resolver, redeemer)
+
+	redeemedBefore := promtestutil.ToFloat64(RedemptionsTotal.WithLabelValues("redeemed"))
+	skippedBefore := promtestutil.ToFloat64(RedemptionsTotal.WithLabelValues("skipped"))
+	valueBefore := promtestutil.ToFloat64(RedeemedValueUSD)
+
+	resolved, err := monitor.Check(context.Background())
+	if err != nil {
+		t.Fatalf("Check() error: %v", err)
+	}
+
+	wantValues := map[string]float64{"0xwon": 40, "0xlost": 0, "0xsplit": 4}
+	if len(resolved) != len(wantValues) {
+		t.Fatalf("expected %d resolved conditions, got %+v", len(wantValues), resolved)
+	}
+	for _, condition := range resolved {
+		want, ok := wantValues[condition.ConditionID]
+		if !ok {
+			t.Errorf("unexpected resolved condition %s", condition.ConditionID)
+			continue
+		}
+		if !floatEquals(condition.Value, want, 1e-9) {
+			t.Errorf("%s: expected value $%.2f, got $%.2f", condition.ConditionID, want, condition.Value)
+		}
+	}
+
+	if len(redeemer.redeemed) != 2 || redeemer.redeemed[0] != "0xwon" || redeemer.redeemed[1] != "0xsplit" {
+		t.Errorf("expected 0xwon and 0xsplit redeemed, got %v", redeemer.redeemed)
+	}
+	if redeemer.outcomes[0] != 2 {
+		t.Errorf("expected both outcome slots redeemed, got %d", redeemer.outcomes[0])
+	}
+
+	if got := promtestutil.ToFloat64(RedemptionsTotal.WithLabelValues("redeemed")) - redeemedBefore; got != 2 {
+		t.Errorf("expected 2 redeemed, got %.0f", got)
+	}
+	if got := promtestutil.ToFloat64(RedemptionsTotal.WithLabelValues("skipped")) - skippedBefore; got != 1 {
+		t.Errorf("expected 1 skipped (losing position), got %.0f", got)
+	}
+	if got := promtestutil.ToFloat64(RedeemedValueUSD) - valueBefore; !floatEquals(got, 44, 1e-9) {
+		t.Errorf("expected $44 redeemed, got $%.2f", got)
+	}
+
+	// Handled conditions are not checked or redeemed again; the open one still is
+	resolver.calls = 0
+	resolved, err = monitor.Check(context.Background())
+	if err != nil {
+		t.Fatalf("second Check() error: %v", err)
+	}
+	if len(resolved) != 0 || len(redeemer.redeemed) != 2 {
+		t.Errorf("expected no new redemptions, got %+v (redeemed %v)", resolved, redeemer.redeemed)
+	}
+	if resolver.calls != 1 {
+		t.Errorf("expected only the open condition to be checked, got %d checks", resolver.calls)
+	}
+}
+
+// TestResolutionMonitor_RedeemFailureRetries tests that a failed redemption is retried on
+// the next check.
+func TestResolutionMonitor_RedeemFailureRetries(t *testing.T) {
+	positions := &stubConditionPositions{positions: []ConditionPosition{
+		{ConditionID: "0xwon", MarketSlug: "won-slug", OutcomeIndex: 1, Size: 25},
+	}}
+	resolver := &stubResolver{payouts: map[string][]float64{"0xwon": {0, 1}}}
+	redeemer := &stubRedeemer{err: errors.New("send transaction: insufficient funds for gas")}
+	monitor := newTestResolutionMonitor(t, positions, resolver, redeemer)
+
+	errorsBefore := promtestutil.ToFloat64(RedemptionsTotal.WithLabelValues("error"))
+
+	_, err := monitor.Check(context.Background())
+	if err != nil {
+		t.Fatalf("Check() error: %v", err)
+	}
+	if got := promtestutil.ToFloat64(RedemptionsTotal.WithLabelValues("error")) - errorsBefore; got != 1 {
+		t.Errorf("expected 1 redemption error, got %.0f", got)
+	}
+
+	redeemer.err = nil
+	resolved, err := monitor.Check(context.Background())
+	if err != nil {
+		t.Fatalf("second Check() error: %v", err)
+	}
+	if len(resolved) != 1 || len(redeemer.redeemed) != 1 {
+		t.Errorf("expected redemption retried, got %+v (redeemed %v)", resolved, redeemer.redeemed)
+	}
+}
+
+// TestResolutionMonitor_NotRedeemed tests conditions that are detected but never sent to
+// the redeemer: detection-only monitors, neg-risk markets and resolver errors.
+func TestResolutionMonitor_NotRedeemed(t *testing.T) {
+	tests := []struct {
+		name         string
+		position     ConditionPosition
+		resolverErr  error
+		withRedeemer bool
+		wantResolved int
+	}{
+		{
+			name:         "no_redeemer",
+			position:     ConditionPosition{ConditionID: "0xwon", OutcomeIndex: 0, Size: 10},
+			wantResolved: 1,
+		},
+		{
+			name:         "neg_risk",
+			position:     ConditionPosition{ConditionID: "0xwon", OutcomeIndex: 0, Size: 10, NegRisk: true},
+			withRedeemer: true,
+			wantResolved: 1,
+		},
+		{
+			name:         "resolver_error",
+			position:     ConditionPosition{ConditionID: "0xwon", OutcomeIndex: 0, Size: 10},
+			resolverErr:  errors.New("call contract: rate limited"),
+			withRedeemer: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			positions := &stubConditionPositions{positions: []ConditionPosition{tt.position}}
+			resolver := &stubResolver{
+				payouts: map[string][]float64{"0xwon": {1, 0}},
+				errs:    map[string]error{"0xwon": tt.resolverErr},
+			}
+
+			var redeemer *stubRedeemer
+			if tt.withRedeemer {
+				redeemer = &stubRedeemer{}
+			}
+			monitor := newTestResolutionMonitor(t, positions, resolver, redeemer)
+
+			resolved, err := monitor.Check(context.Background())
+			if err != nil {
+				t.Fatalf("Check() error: %v", err)
+			}
+			if len(resolved) != tt.wantResolved {
+				t.Errorf("expected %d resolved, got %+v", tt.wantResolved, resolved)
+			}
+			if redeemer != nil && len(redeemer.redeemed) != 0 {
+				t.Errorf("expected nothing redeemed, got %v", redeemer.redeemed)
+			}
+		})
+	}
+}
+
+// TestResolutionMonitor_PositionsError tests that a failed position lookup is returned.
+func TestResolutionMonitor_PositionsError(t *testing.T) {
+	positions := &stubConditionPositions{err: errors.New("API error: status 503")}
+	monitor := newTestResolutionMonitor(t, positions, &stubResolver{}, nil)
+
+	_, err := monitor.Check(context.Background())
+	if err == nil {
+		t.Fatal("expected error from positions lookup")
+	}
+}
+
+func TestNewResolutionMonitor_Invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  *ResolutionMonitorConfig
+	}{
+		{name: "zero_interval", cfg: &ResolutionMonitorConfig{Positions: &stubConditionPositions{}, Resolver: &stubResolver{}}},
+		{name: "no_positions", cfg: &ResolutionMonitorConfig{Interval: time.Minute, Resolver: &stubResolver{}}},
+		{name: "no_resolver", cfg: &ResolutionMonitorConfig{Interval: time.Minute, Positions: &stubConditionPositions{}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewResolutionMonitor(tt.cfg)
+			if err == nil {
+				t.Error("expected error")
+			}
+		})
+	}
+}
@@ -0,0 +1,75 @@
+package execution
+
+import (
+	"fmt"
+	"math"
+)
+
+// roundingEpsilon absorbs float error in the scaled value, so an amount that is
+// exactly on a precision boundary (e.g. 0.1*3) is not pushed to the next step.
+const roundingEpsilon = 1e-9
+
+// RoundingDirection selects how an amount is rounded to its precision.
+type RoundingDirection int
+
+const (
+	// RoundNearest rounds half away from zero.
+	RoundNearest RoundingDirection = iota
+	// RoundUp rounds towards positive infinity.
+	RoundUp
+	// RoundDown rounds towards zero.
+	RoundDown
+)
+
+// RoundingPolicy sets the rounding direction of each BUY order field.
+type RoundingPolicy struct {
+	// TakerSize rounds the token count bought. Rounding down never asks for more
+	// tokens than the requested size, which was sized to the available liquidity.
+	TakerSize RoundingDirection
+
+	// MakerAmount rounds the USD paid. Rounding up keeps the implied price
+	// (maker / taker) at or above the limit price, so the order still crosses the ask.
+	MakerAmount RoundingDirection
+}
+
+// DirectionalRoundingPolicy rounds token size down and the USD amount up.
+var DirectionalRoundingPolicy = RoundingPolicy{TakerSize: RoundDown, MakerAmount: RoundUp}
+
+// NearestRoundingPolicy rounds every field to the nearest step.
+var NearestRoundingPolicy = RoundingPolicy{TakerSize: RoundNearest, MakerAmount: RoundNearest}
+
+// ParseRoundingPolicy maps a policy name ("directional" or "nearest") to its policy.
+func ParseRoundingPolicy(name string) (RoundingPolicy, error) {
+	switch name {
+	case "", "directional":
+		return DirectionalRoundingPolicy, nil
+	case "nearest":
+		return NearestRoundingPolicy, nil
+	default:
+		return RoundingPolicy{}, fmt.Errorf("unknown rounding policy %q", name)
+	}
+}
+
+// round rounds value to the given number of decimal places in direction dir.
+func round(value float64, decimals int, dir RoundingDirection) float64 {
+	switch dir {
+	case RoundUp:
+		return roundUp(value, decimals)
+	case RoundDown:
+		return roundDown(value, decimals)
+	default:
+		return roundAmount(value, decimals)
+	}
+}
+
+// roundUp rounds value up to the given number of decimal places.
+func roundUp(value float64, decimals int) float64 {
+	multiplier := math.Pow(10, float64(decimals))
+	return math.Ceil(value*multiplier-roundingEpsilon) / multiplier
+}
+
+// roundDown rounds value down to the given number of decimal places.
+func roundDown(value float64, decimals int) float64 {
+	multiplier := math.Pow(10, float64(decimals))
+	return math.Floor(value*multiplier+roundingEpsilon) / multiplier
+}
@@ -0,0 +1,118 @@
+package execution
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/mselser95/polymarket-arb/pkg/types"
+)
+
+// TestRoundUpDown tests directional rounding, including values that float error leaves
+// a hair above or below a precision boundary.
+func TestRoundUpDown(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    float64
+		decimals int
+		wantUp   float64
+		wantDown float64
+	}{
+		{name: "between_steps", value: 10.339, decimals: 2, wantUp: 10.34, wantDown: 10.33},
+		{name: "just_above_step", value: 4.64851, decimals: 4, wantUp: 4.6486, wantDown: 4.6485},
+		{name: "just_below_step", value: 19.999, decimals: 2, wantUp: 20.00, wantDown: 19.99},
+		{name: "exact_step", value: 4.6485, decimals: 4, wantUp: 4.6485, wantDown: 4.6485},
+		{name: "float_noise_above_step", value: 0.1 * 3, decimals: 2, wantUp: 0.30, wantDown: 0.30},
+		{name: "float_noise_below_step", value: 0.0157, decimals: 4, wantUp: 0.0157, wantDown: 0.0157},
+		{name: "zero", value: 0, decimals: 2, wantUp: 0, wantDown: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := roundUp(tt.value, tt.decimals); !floatEquals(got, tt.wantUp, 1e-12) {
+				t.Errorf("roundUp(%v, %d) = %v, want %v", tt.value, tt.decimals, got, tt.wantUp)
+			}
+			if got := roundDown(tt.value, tt.decimals); !floatEquals(got, tt.wantDown, 1e-12) {
+				t.Errorf("roundDown(%v, %d) = %v, want %v", tt.value, tt.decimals, got, tt.wantDown)
+			}
+		})
+	}
+}
+
+func TestParseRoundingPolicy(t *testing.T) {
+	tests := []struct {
+		name    string
+		want    RoundingPolicy
+		wantErr bool
+	}{
+		{name: "", want: DirectionalRoundingPolicy},
+		{name: "directional", want: DirectionalRoundingPolicy},
+		{name: "nearest", want: NearestRoundingPolicy},
+		{name: "up", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseRoundingPolicy(tt.name)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("ParseRoundingPolicy(%q) = %+v, %v; want %+v (err=%v)", tt.name, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+// TestPlaceOrdersMultiOutcome_RoundingPolicy tests that the batch builder rounds token
+// size and USD amount in the configured direction, and that OrderSizes reports the
+// signed token count.
+func TestPlaceOrdersMultiOutcome_RoundingPolicy(t *testing.T) {
+	tests := []struct {
+		name      string
+		policy    RoundingPolicy
+		wantTaker string
+		wantMaker string
+	}{
+		// 10.339 tokens at 0.45: size down to 10.33, cost 4.6485
+		{name: "directional", policy: DirectionalRoundingPolicy, wantTaker: "10330000", wantMaker: "4648500"},
+		// size to nearest 10.34, cost 4.653
+		{name: "nearest", policy: NearestRoundingPolicy, wantTaker: "10340000", wantMaker: "4653000"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var batch types.BatchOrderRequest
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				err := json.NewDecoder(r.Body).Decode(&batch)
+				if err != nil {
+					t.Errorf("decode batch: %v", err)
+				}
+				_, _ = w.Write([]byte(`[{"success":true,"orderID":"a"},{"success":true,"orderID":"b"}]`))
+			}))
+			defer server.Close()
+
+			client := newSkewTestClient(t, server.URL, false)
+			client.rounding = tt.policy
+
+			outcomes := []types.OutcomeOrderParams{
+				{TokenID: "1", Price: 0.45, TickSize: 0.01, MinSize: 5},
+				{TokenID: "2", Price: 0.45, TickSize: 0.01, MinSize: 5},
+			}
+			_, _ = client.PlaceOrdersMultiOutcome(context.Background(), outcomes, 10.339)
+
+			if len(batch) != 2 {
+				t.Fatalf("expected 2 orders, got %d", len(batch))
+			}
+			for _, req := range batch {
+				if req.Order.TakerAmount != tt.wantTaker || req.Order.MakerAmount != tt.wantMaker {
+					t.Errorf("expected taker %s maker %s, got taker %s maker %s",
+						tt.wantTaker, tt.wantMaker, req.Order.TakerAmount, req.Order.MakerAmount)
+				}
+			}
+
+			for i, size := range client.OrderSizes(outcomes, 10.339) {
+				if got := RawAmount(size, USDCDecimals); got != tt.wantTaker {
+					t.Errorf("outcome %d: expected OrderSizes %s, got %s", i, tt.wantTaker, got)
+				}
+			}
+		})
+	}
+}
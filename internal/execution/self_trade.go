@@ -0,0 +1,98 @@
+package execution
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"go.uber.org/zap"
+
+	"github.com/mselser95/polymarket-arb/internal/arbitrage"
+	"github.com/mselser95/polymarket-arb/pkg/tracing"
+)
+
+// Self-trade prevention modes.
+const (
+	SelfTradePreventionOff    = "off"    // Submit without checking open orders
+	SelfTradePreventionCancel = "cancel" // Cancel our open orders on the target tokens, then submit
+	SelfTradePreventionSkip   = "skip"   // Skip the opportunity while we have open orders on the target tokens
+)
+
+// ErrSelfTrade is returned when an opportunity is skipped, or its conflicting orders could
+// not be canceled, because we already have open orders on one of its tokens.
+var ErrSelfTrade = errors.New("open orders on target tokens")
+
+// OpenOrderManager lists and cancels our resting orders.
+// OrderClient implements this interface; tests can supply a mock.
+type OpenOrderManager interface {
+	GetOpenOrders(ctx context.Context, query OpenOrdersQuery) ([]OrderInfo, error)
+	CancelOrders(ctx context.Context, orderIDs []string) (CancelAllResult, error)
+}
+
+// preventSelfTrade checks for our own open orders on the opportunity's tokens before
+// submission. A resting order on a target token could match our new order (wasting
+// fees) or double our exposure on that outcome. Depending on the mode the conflicting
+// orders are canceled or ErrSelfTrade is returned so the opportunity is skipped.
+//
+// Orders of pending trades are left alone: they are legs of our own earlier set on the
+// same market, and canceling them would leave that set incomplete.
+func (e *Executor) preventSelfTrade(ctx context.Context, opp *arbitrage.Opportunity) error {
+	if e.selfTradePrevention == "" || e.selfTradePrevention == SelfTradePreventionOff {
+		return nil
+	}
+
+	manager, ok := e.orderClient.(OpenOrderManager)
+	if !ok {
+		return nil
+	}
+
+	pending := e.pendingOrderIDs()
+
+	// Queried per token: opp.MarketID is the Gamma market ID, not the condition ID the
+	// market filter takes
+	var conflictIDs []string
+	for _, outcome := range opp.Outcomes {
+		openOrders, err := manager.GetOpenOrders(ctx, OpenOrdersQuery{AssetID: outcome.TokenID})
+		if err != nil {
+			return fmt.Errorf("get open orders for %s: %w", outcome.Outcome, err)
+		}
+
+		for _, order := range openOrders {
+			if order.AssetID == outcome.TokenID && !pending[order.OrderID] {
+				conflictIDs = append(conflictIDs, order.OrderID)
+			}
+		}
+	}
+
+	if len(conflictIDs) == 0 {
+		return nil
+	}
+
+	e.logger.Warn("self-trade-conflict",
+		zap.String("opportunity-id", opp.ID),
+		tracing.Field(opp.TraceID),
+		zap.String("market-slug", opp.MarketSlug),
+		zap.String("mode", e.selfTradePrevention),
+		zap.Strings("order-ids", conflictIDs))
+
+	if e.selfTradePrevention == SelfTradePreventionSkip {
+		SelfTradeConflictsTotal.WithLabelValues("skipped").Inc()
+		return fmt.Errorf("%w: %s", ErrSelfTrade, strings.Join(conflictIDs, ", "))
+	}
+
+	result, err := manager.CancelOrders(ctx, conflictIDs)
+	if err != nil {
+		SelfTradeConflictsTotal.WithLabelValues("cancel_failed").Inc()
+		return fmt.Errorf("%w: cancel conflicting orders: %w", ErrSelfTrade, err)
+	}
+
+	if len(result.NotCanceled) > 0 {
+		SelfTradeConflictsTotal.WithLabelValues("cancel_failed").Inc()
+		return fmt.Errorf("%w: %d conflicting orders not canceled", ErrSelfTrade, len(result.NotCanceled))
+	}
+
+	SelfTradeConflictsTotal.WithLabelValues("canceled").Inc()
+
+	return nil
+}
@@ -0,0 +1,208 @@
+package execution
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+
+	"go.uber.org/zap"
+
+	"github.com/mselser95/polymarket-arb/internal/arbitrage"
+	"github.com/mselser95/polymarket-arb/pkg/types"
+)
+
+// mockOpenOrdersClient places orders and reports a fixed set of open orders.
+type mockOpenOrdersClient struct {
+	mu          sync.Mutex
+	openOrders  []OrderInfo
+	notCanceled map[string]string
+	cancelErr   error
+
+	openOrderCalls int
+	queriedAssets  []string
+	canceledIDs    []string
+	placeCalls     int
+}
+
+func (m *mockOpenOrdersClient) PlaceOrdersMultiOutcome(
+	_ context.Context,
+	outcomes []types.OutcomeOrderParams,
+	_ float64,
+) ([]*types.OrderSubmissionResponse, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.placeCalls++
+	responses := make([]*types.OrderSubmissionResponse, len(outcomes))
+	for i := range outcomes {
+		responses[i] = &types.OrderSubmissionResponse{Success: true, OrderID: fmt.Sprintf("new-order-%d", i)}
+	}
+
+	return responses, nil
+}
+
+func (m *mockOpenOrdersClient) GetOpenOrders(_ context.Context, query OpenOrdersQuery) ([]OrderInfo, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.openOrderCalls++
+	m.queriedAssets = append(m.queriedAssets, query.AssetID)
+
+	var orders []OrderInfo
+	for _, order := range m.openOrders {
+		if query.AssetID == "" || order.AssetID == query.AssetID {
+			orders = append(orders, order)
+		}
+	}
+
+	return orders, nil
+}
+
+func (m *mockOpenOrdersClient) CancelOrders(_ context.Context, orderIDs []string) (CancelAllResult, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.cancelErr != nil {
+		return CancelAllResult{}, m.cancelErr
+	}
+
+	m.canceledIDs = append(m.canceledIDs, orderIDs...)
+	return CancelAllResult{Canceled: orderIDs, NotCanceled: m.notCanceled}, nil
+}
+
+// TestExecuteLive_SelfTradePrevention tests each mode against conflicting open orders.
+func TestExecuteLive_SelfTradePrevention(t *testing.T) {
+	opp := arbitrage.CreateTestOpportunity("test-market", "test-slug")
+
+	conflicting := []OrderInfo{
+		{OrderID: "resting-yes", AssetID: opp.Outcomes[0].TokenID, Side: "BUY"},
+		{OrderID: "other-market", AssetID: "unrelated-token", Side: "BUY"},
+		{OrderID: "resting-no", AssetID: opp.Outcomes[1].TokenID, Side: "SELL"},
+	}
+
+	tests := []struct {
+		name            string
+		mode            string
+		openOrders      []OrderInfo
+		pendingIDs      []string
+		notCanceled     map[string]string
+		cancelErr       error
+		wantPlaced      bool
+		wantSelfTrade   bool
+		wantQueried     bool
+		wantCanceledIDs []string
+	}{
+		{
+			name:       "off_ignores_open_orders",
+			mode:       SelfTradePreventionOff,
+			openOrders: conflicting,
+			wantPlaced: true,
+		},
+		{
+			name:        "no_conflicts",
+			mode:        SelfTradePreventionSkip,
+			openOrders:  []OrderInfo{{OrderID: "other-market", AssetID: "unrelated-token"}},
+			wantPlaced:  true,
+			wantQueried: true,
+		},
+		{
+			name:            "cancel_conflicts_then_place",
+			mode:            SelfTradePreventionCancel,
+			openOrders:      conflicting,
+			wantPlaced:      true,
+			wantQueried:     true,
+			wantCanceledIDs: []string{"resting-yes", "resting-no"},
+		},
+		{
+			name:        "pending_trade_orders_ignored",
+			mode:        SelfTradePreventionSkip,
+			openOrders:  conflicting,
+			pendingIDs:  []string{"resting-yes", "resting-no"},
+			wantPlaced:  true,
+			wantQueried: true,
+		},
+		{
+			name:          "skip_on_conflict",
+			mode:          SelfTradePreventionSkip,
+			openOrders:    conflicting,
+			wantSelfTrade: true,
+			wantQueried:   true,
+		},
+		{
+			name:            "cancel_partially_failed",
+			mode:            SelfTradePreventionCancel,
+			openOrders:      conflicting,
+			notCanceled:     map[string]string{"resting-no": "order already matched"},
+			wantSelfTrade:   true,
+			wantQueried:     true,
+			wantCanceledIDs: []string{"resting-yes", "resting-no"},
+		},
+		{
+			name:          "cancel_request_failed",
+			mode:          SelfTradePreventionCancel,
+			openOrders:    conflicting,
+			cancelErr:     errors.New("connection refused"),
+			wantSelfTrade: true,
+			wantQueried:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := &mockOpenOrdersClient{
+				openOrders:  tt.openOrders,
+				notCanceled: tt.notCanceled,
+				cancelErr:   tt.cancelErr,
+			}
+
+			exec := New(&Config{
+				Mode:                "live",
+				Logger:              zap.NewNop(),
+				OrderClient:         client,
+				AggressionTicks:     1,
+				SelfTradePrevention: tt.mode,
+			})
+			exec.ctx = context.Background()
+			if tt.pendingIDs != nil {
+				exec.addPendingTrade(types.PendingTrade{OpportunityID: "earlier", OrderIDs: tt.pendingIDs})
+			}
+
+			result := exec.executeLive(opp)
+			exec.verifyWg.Wait()
+
+			if tt.wantPlaced != (client.placeCalls == 1) {
+				t.Errorf("expected placed=%v, got %d place calls", tt.wantPlaced, client.placeCalls)
+			}
+
+			if result.Success != tt.wantPlaced {
+				t.Errorf("expected success=%v, got %v (err: %v)", tt.wantPlaced, result.Success, result.Error)
+			}
+
+			if got := errors.Is(result.Error, ErrSelfTrade); got != tt.wantSelfTrade {
+				t.Errorf("expected ErrSelfTrade=%v, got error %v", tt.wantSelfTrade, result.Error)
+			}
+
+			if got := client.openOrderCalls > 0; got != tt.wantQueried {
+				t.Errorf("expected open orders queried=%v, got %d calls", tt.wantQueried, client.openOrderCalls)
+			}
+
+			if tt.wantQueried && fmt.Sprint(client.queriedAssets) != fmt.Sprint([]string{opp.Outcomes[0].TokenID, opp.Outcomes[1].TokenID}) {
+				t.Errorf("expected open orders queried per target token, got %v", client.queriedAssets)
+			}
+
+			// Without an order querier the placed set is abandoned and its orders canceled too
+			var selfTradeCanceled []string
+			for _, id := range client.canceledIDs {
+				if !strings.HasPrefix(id, "new-order-") {
+					selfTradeCanceled = append(selfTradeCanceled, id)
+				}
+			}
+			if fmt.Sprint(selfTradeCanceled) != fmt.Sprint(tt.wantCanceledIDs) {
+				t.Errorf("expected canceled %v, got %v", tt.wantCanceledIDs, selfTradeCanceled)
+			}
+		})
+	}
+}
@@ -0,0 +1,105 @@
+package execution
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/polymarket/go-order-utils/pkg/model"
+
+	"github.com/mselser95/polymarket-arb/pkg/types"
+)
+
+// ErrInvalidSellPrice is returned when a SELL limit price is not strictly between 0 and 1
+// after snapping to the tick.
+var ErrInvalidSellPrice = errors.New("sell price must be between 0 and 1")
+
+// SellOrder is a SELL of held outcome tokens after rounding to the market's precision.
+type SellOrder struct {
+	TokenID  string
+	Size     float64 // Tokens sold, rounded down to the size precision
+	Price    float64 // Limit price, snapped down to the tick
+	Proceeds float64 // Minimum USDC received, rounded down to the amount precision
+}
+
+// BuildSellOrder rounds a SELL of size tokens at price to the token's tick size and
+// precision. Rounding always goes down: the size so the order never sells more than is
+// held, the price and proceeds so the order stays marketable against a bid at price.
+// Returns an ErrBelowMinSize error if the rounded size is under minSize.
+func (c *OrderClient) BuildSellOrder(
+	tokenID string,
+	size float64,
+	price float64,
+	tickSize float64,
+	minSize float64,
+) (order SellOrder, err error) {
+	sizePrecision, amountPrecision, err := c.resolveRoundingConfig(tokenID, tickSize, false)
+	if err != nil {
+		return order, err
+	}
+
+	// Snap to the tick; tickSize is a resolved 0.1/0.01/0.001/0.0001 when the lookup passed
+	if tickSize > 0 {
+		price = math.Floor(price/tickSize+roundingEpsilon) * tickSize
+	}
+	price = roundAmount(price, amountPrecision)
+	if price <= 0 || price >= 1 {
+		return order, fmt.Errorf("%w: got %.4f", ErrInvalidSellPrice, price)
+	}
+
+	tokens := roundDown(size, sizePrecision)
+	if tokens < minSize || tokens <= 0 {
+		return order, &BelowMinSizeError{OutcomeIndex: 0, Size: tokens, MinSize: minSize}
+	}
+
+	order = SellOrder{
+		TokenID:  tokenID,
+		Size:     tokens,
+		Price:    price,
+		Proceeds: roundDown(tokens*price, amountPrecision),
+	}
+
+	return order, nil
+}
+
+// orderData returns the SELL order for signing: the maker gives outcome tokens and the
+// taker gives USDC.
+func (o SellOrder) orderData(c *OrderClient) *model.OrderData {
+	return &model.OrderData{
+		Maker:         c.GetMakerAddress(),
+		Taker:         "0x0000000000000000000000000000000000000000",
+		TokenId:       o.TokenID,
+		MakerAmount:   RawAmount(o.Size, USDCDecimals),
+		TakerAmount:   RawAmount(o.Proceeds, USDCDecimals),
+		Side:          model.SELL,
+		FeeRateBps:    "0",
+		Nonce:         "0",
+		Signer:        c.GetSignerAddress(),
+		Expiration:    "0",
+		SignatureType: c.signatureType,
+	}
+}
+
+// PlaceSellOrder builds, signs and submits a SELL order of held outcome tokens.
+// See BuildSellOrder for rounding and validation.
+func (c *OrderClient) PlaceSellOrder(
+	ctx context.Context,
+	tokenID string,
+	size float64,
+	price float64,
+	tickSize float64,
+	minSize float64,
+) (order SellOrder, resp *types.OrderSubmissionResponse, err error) {
+	order, err = c.BuildSellOrder(tokenID, size, price, tickSize, minSize)
+	if err != nil {
+		return order, nil, fmt.Errorf("build sell order: %w", err)
+	}
+
+	resp, err = c.PlaceSingleOrder(ctx, order.orderData(c))
+	if err != nil {
+		return order, nil, err
+	}
+
+	return order, resp, nil
+}
@@ -0,0 +1,129 @@
+package execution
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"go.uber.org/zap"
+
+	"github.com/mselser95/polymarket-arb/pkg/types"
+)
+
+func newSellTestClient(t *testing.T, baseURL string) *OrderClient {
+	t.Helper()
+
+	client, err := NewOrderClient(&OrderClientConfig{
+		APIKey:        "test-api-key",
+		Secret:        "dGVzdC1zZWNyZXQ=",
+		Passphrase:    "test-passphrase",
+		PrivateKey:    "0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef",
+		SignatureType: 0,
+		Logger:        zap.NewNop(),
+		BaseURL:       baseURL,
+	})
+	if err != nil {
+		t.Fatalf("new order client: %v", err)
+	}
+
+	return client
+}
+
+func TestBuildSellOrder(t *testing.T) {
+	client := newSellTestClient(t, "")
+
+	tests := []struct {
+		name         string
+		size         float64
+		price        float64
+		tickSize     float64
+		minSize      float64
+		wantSize     float64
+		wantPrice    float64
+		wantProceeds float64
+		wantErr      error
+	}{
+		{name: "on_tick", size: 10, price: 0.45, tickSize: 0.01, minSize: 5, wantSize: 10, wantPrice: 0.45, wantProceeds: 4.5},
+		{name: "size_rounded_down", size: 10.129, price: 0.45, tickSize: 0.01, minSize: 5, wantSize: 10.12, wantPrice: 0.45, wantProceeds: 4.554},
+		{name: "price_snapped_down_to_tick", size: 10, price: 0.4567, tickSize: 0.01, minSize: 5, wantSize: 10, wantPrice: 0.45, wantProceeds: 4.5},
+		{name: "fine_tick", size: 10, price: 0.4567, tickSize: 0.001, minSize: 5, wantSize: 10, wantPrice: 0.456, wantProceeds: 4.56},
+		{name: "below_min_size", size: 4.999, price: 0.45, tickSize: 0.01, minSize: 5, wantErr: ErrBelowMinSize},
+		{name: "price_below_tick", size: 10, price: 0.004, tickSize: 0.01, minSize: 5, wantErr: ErrInvalidSellPrice},
+		{name: "price_at_one", size: 10, price: 1, tickSize: 0.01, minSize: 5, wantErr: ErrInvalidSellPrice},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			order, err := client.BuildSellOrder("token-1", tt.size, tt.price, tt.tickSize, tt.minSize)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("expected %v, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if !floatEquals(order.Size, tt.wantSize, 1e-9) {
+				t.Errorf("expected size %f, got %f", tt.wantSize, order.Size)
+			}
+			if !floatEquals(order.Price, tt.wantPrice, 1e-9) {
+				t.Errorf("expected price %f, got %f", tt.wantPrice, order.Price)
+			}
+			if !floatEquals(order.Proceeds, tt.wantProceeds, 1e-9) {
+				t.Errorf("expected proceeds %f, got %f", tt.wantProceeds, order.Proceeds)
+			}
+		})
+	}
+}
+
+// TestPlaceSellOrder tests that the submitted order sells tokens (maker amount) for
+// USDC (taker amount) with the rounded amounts.
+func TestPlaceSellOrder(t *testing.T) {
+	var submitted types.OrderSubmissionRequest
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/order" {
+			t.Errorf("expected /order, got %s", r.URL.Path)
+		}
+
+		err := json.NewDecoder(r.Body).Decode(&submitted)
+		if err != nil {
+			t.Errorf("decode request: %v", err)
+		}
+
+		json.NewEncoder(w).Encode(types.OrderSubmissionResponse{Success: true, OrderID: "sell-1", Status: "matched"})
+	}))
+	defer server.Close()
+
+	client := newSellTestClient(t, server.URL)
+
+	order, resp, err := client.PlaceSellOrder(context.Background(), "123456789", 10.129, 0.4567, 0.01, 5)
+	if err != nil {
+		t.Fatalf("place sell order: %v", err)
+	}
+	if resp.OrderID != "sell-1" {
+		t.Errorf("expected order sell-1, got %s", resp.OrderID)
+	}
+	if !floatEquals(order.Size, 10.12, 1e-9) {
+		t.Errorf("expected size 10.12, got %f", order.Size)
+	}
+
+	if submitted.Order.Side != "SELL" {
+		t.Errorf("expected SELL side, got %s", submitted.Order.Side)
+	}
+	if submitted.Order.TokenID != "123456789" {
+		t.Errorf("expected token 123456789, got %s", submitted.Order.TokenID)
+	}
+	// 10.12 tokens for 10.12 * 0.45 = 4.554 USDC
+	if submitted.Order.MakerAmount != "10120000" {
+		t.Errorf("expected maker amount 10120000 (tokens), got %s", submitted.Order.MakerAmount)
+	}
+	if submitted.Order.TakerAmount != "4554000" {
+		t.Errorf("expected taker amount 4554000 (USDC), got %s", submitted.Order.TakerAmount)
+	}
+}
@@ -0,0 +1,64 @@
+package execution
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/mselser95/polymarket-arb/internal/arbitrage"
+	"github.com/mselser95/polymarket-arb/pkg/types"
+	"go.uber.org/zap"
+)
+
+// ErrIncompleteSet is returned when an execution's legs would not buy the same token
+// count of every outcome. Arbitrage only pays out on complete sets; unequal legs leave an
+// unhedged position in the larger outcome, so nothing is placed.
+var ErrIncompleteSet = errors.New("legs do not form a complete set")
+
+// checkCompleteSet verifies that every leg is signed for the same positive, finite token
+// count. sizes are the per-leg token counts after the order client's rounding; they are
+// compared as the raw taker amounts the orders are signed with, so float noise that the
+// order builder rounds away doesn't count as a mismatch.
+func checkCompleteSet(sizes []float64) error {
+	takerAmounts := make([]string, len(sizes))
+	for i, size := range sizes {
+		if math.IsNaN(size) || math.IsInf(size, 0) || size <= 0 {
+			return fmt.Errorf("%w: outcome %d has invalid token count %v", ErrIncompleteSet, i, size)
+		}
+
+		takerAmounts[i] = RawAmount(size, USDCDecimals)
+		if takerAmounts[i] != takerAmounts[0] {
+			return fmt.Errorf("%w: %w: outcome 0 is signed for %.6f tokens, outcome %d for %.6f",
+				ErrIncompleteSet, ErrTakerTokenMismatch, sizes[0], i, size)
+		}
+	}
+
+	return nil
+}
+
+// assertCompleteSet checks, before anything is submitted or simulated, that the orders
+// outcomeParams would be signed for when buying tokens of every outcome of opp form
+// complete sets. A violation is a sizing bug rather than a market condition, so it is
+// logged as an error and counted as an incomplete_set skip.
+func (e *Executor) assertCompleteSet(
+	opp *arbitrage.Opportunity,
+	outcomeParams []types.OutcomeOrderParams,
+	tokens float64,
+) error {
+	sizes := e.orderSizes(outcomeParams, tokens)
+	err := checkCompleteSet(sizes)
+	if err == nil {
+		return nil
+	}
+
+	e.traceLogger(opp).Error("complete-set-invariant-violated",
+		zap.String("opportunity-id", opp.ID),
+		zap.String("market-slug", opp.MarketSlug),
+		zap.Int("outcome-count", len(opp.Outcomes)),
+		zap.Float64("tokens-per-outcome", tokens),
+		zap.Float64s("order-sizes", sizes),
+		zap.Error(err))
+	OpportunitiesSkippedTotal.WithLabelValues("incomplete_set").Inc()
+
+	return err
+}
@@ -0,0 +1,147 @@
+package execution
+
+import (
+	"context"
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	promtestutil "github.com/prometheus/client_golang/prometheus/testutil"
+
+	"github.com/mselser95/polymarket-arb/internal/arbitrage"
+	"github.com/mselser95/polymarket-arb/pkg/types"
+	"go.uber.org/zap"
+)
+
+func TestCheckCompleteSet(t *testing.T) {
+	tests := []struct {
+		name         string
+		sizes        []float64
+		wantMismatch bool // Legs are signed for different token counts
+		wantErr      bool
+	}{
+		{name: "equal_sizes", sizes: []float64{12.34, 12.34, 12.34}},
+		{name: "float_noise", sizes: []float64{0.1 + 0.2, 0.3}},
+		{name: "diverging_sizes", sizes: []float64{12.34, 12.3}, wantMismatch: true, wantErr: true},
+		{name: "diverging_third_leg", sizes: []float64{7.19, 7.19, 7}, wantMismatch: true, wantErr: true},
+		{name: "diverging_below_size_precision", sizes: []float64{12.340001, 12.34}, wantMismatch: true, wantErr: true},
+		{name: "leg_rounds_to_zero", sizes: []float64{0.5, 0}, wantErr: true},
+		{name: "negative", sizes: []float64{-5, -5}, wantErr: true},
+		{name: "nan", sizes: []float64{math.NaN(), math.NaN()}, wantErr: true},
+		{name: "inf", sizes: []float64{math.Inf(1), math.Inf(1)}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkCompleteSet(tt.sizes)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("expected error=%v, got %v", tt.wantErr, err)
+			}
+			if err == nil {
+				return
+			}
+			if !errors.Is(err, ErrIncompleteSet) {
+				t.Errorf("expected ErrIncompleteSet, got %v", err)
+			}
+			if errors.Is(err, ErrTakerTokenMismatch) != tt.wantMismatch {
+				t.Errorf("expected ErrTakerTokenMismatch=%v, got %v", tt.wantMismatch, err)
+			}
+		})
+	}
+}
+
+// divergingSizer sizes the last leg to one decimal place, as an order client whose
+// rounding differed across tick sizes would.
+type divergingSizer struct {
+	*mockLiveClient
+}
+
+func (c *divergingSizer) OrderSizes(outcomes []types.OutcomeOrderParams, tokenCount float64) []float64 {
+	sizes := make([]float64, len(outcomes))
+	for i := range sizes {
+		sizes[i] = roundDown(tokenCount, 2)
+	}
+	sizes[len(sizes)-1] = roundDown(tokenCount, 1)
+	return sizes
+}
+
+// TestExecuteLive_DivergingOrderSizesNotPlaced tests that the invariant checks the sizes
+// the order client signs each leg for, not the unrounded token count.
+func TestExecuteLive_DivergingOrderSizesNotPlaced(t *testing.T) {
+	client := &mockLiveClient{filled: true}
+	exec := New(&Config{
+		Mode:            "live",
+		Logger:          zap.NewNop(),
+		OrderClient:     &divergingSizer{mockLiveClient: client},
+		AggressionTicks: 1,
+		FillTimeout:     time.Second,
+	})
+	exec.ctx = context.Background()
+
+	// $101 at 0.52 buys 194.230... tokens: 194.23 on the first leg, 194.2 on the second
+	opp := arbitrage.CreateTestOpportunity("diverging", "diverging")
+	opp.MaxTradeSize = 101
+
+	result := exec.executeLive(opp)
+	if result.Success || !errors.Is(result.Error, ErrTakerTokenMismatch) {
+		t.Fatalf("expected ErrTakerTokenMismatch failure, got success=%v err=%v", result.Success, result.Error)
+	}
+	if client.placed != nil {
+		t.Errorf("expected no orders placed, got %v", client.placed)
+	}
+}
+
+// TestExecuteLive_IncompleteSetNotPlaced tests that a live execution whose legs would not
+// buy equal token counts fails before any order is submitted.
+func TestExecuteLive_IncompleteSetNotPlaced(t *testing.T) {
+	client := &mockLiveClient{filled: true}
+	exec := newLiveTestExecutor(client, time.Second)
+	exec.ctx = context.Background()
+
+	// A sub-cent budget rounds every leg down to zero tokens
+	opp := arbitrage.CreateTestOpportunity("dust", "dust")
+	opp.MaxTradeSize = 0.001
+
+	skippedBefore := promtestutil.ToFloat64(OpportunitiesSkippedTotal.WithLabelValues("incomplete_set"))
+
+	result := exec.executeLive(opp)
+	if result.Success || !errors.Is(result.Error, ErrIncompleteSet) {
+		t.Fatalf("expected ErrIncompleteSet failure, got success=%v err=%v", result.Success, result.Error)
+	}
+	if client.placed != nil {
+		t.Errorf("expected no orders placed, got %v", client.placed)
+	}
+	if got := promtestutil.ToFloat64(OpportunitiesSkippedTotal.WithLabelValues("incomplete_set")) - skippedBefore; got != 1 {
+		t.Errorf("expected incomplete_set skip counted once, got %.0f", got)
+	}
+	if exposure := exec.OpenExposure(); exposure != 0 {
+		t.Errorf("expected no exposure reserved, got %f", exposure)
+	}
+}
+
+// TestExecutePaper_IncompleteSet tests that a paper execution that can't buy a complete
+// set is rejected instead of recording trades and profit.
+func TestExecutePaper_IncompleteSet(t *testing.T) {
+	exec := New(&Config{Mode: "paper", Logger: zap.NewNop()})
+
+	// A sub-cent budget rounds every leg down to zero tokens
+	opp := arbitrage.CreateTestOpportunity("dust", "dust")
+	opp.MaxTradeSize = 0.001
+
+	result := exec.executePaper(opp)
+	if result.Success || !errors.Is(result.Error, ErrIncompleteSet) {
+		t.Fatalf("expected ErrIncompleteSet failure, got success=%v err=%v", result.Success, result.Error)
+	}
+
+	stats := exec.Stats()
+	if stats.CumulativeProfit != 0 || len(stats.TradesByMode["paper"]) != 0 {
+		t.Errorf("expected nothing recorded, got profit %f trades %v", stats.CumulativeProfit, stats.TradesByMode)
+	}
+
+	// A well-formed opportunity still executes
+	result = exec.executePaper(arbitrage.CreateTestOpportunity("ok", "ok"))
+	if !result.Success {
+		t.Errorf("expected paper execution to succeed, got %v", result.Error)
+	}
+}
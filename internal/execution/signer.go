@@ -0,0 +1,101 @@
+package execution
+
+import (
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/polymarket/go-order-utils/pkg/builder"
+	"github.com/polymarket/go-order-utils/pkg/model"
+	"github.com/polymarket/go-order-utils/pkg/signer"
+)
+
+// ErrInvalidOrderSignature is returned when a Signer's signature does not recover to the
+// order's signer under the client's EIP-712 domain.
+var ErrInvalidOrderSignature = errors.New("invalid order signature")
+
+// Signer signs CTF exchange orders. OrderClient only builds orders and submits them, so
+// the key can live outside the process, e.g. in a hardware wallet or a remote KMS.
+//
+// SignOrder receives the built order rather than OrderData so the client keeps control
+// of the salt, which is derived from correlation IDs (see CorrelationSalt).
+type Signer interface {
+	// Address returns the EOA address whose key signs orders.
+	Address() string
+
+	// SignOrder signs the EIP-712 hash of order for the exchange domain.
+	SignOrder(order *model.Order) (*model.SignedOrder, error)
+}
+
+// LocalSigner signs orders with a private key held in process.
+type LocalSigner struct {
+	privateKey   *ecdsa.PrivateKey
+	address      string
+	orderBuilder builder.ExchangeOrderBuilder
+}
+
+// Compile-time check that LocalSigner implements Signer
+var _ Signer = (*LocalSigner)(nil)
+
+// NewLocalSigner creates a signer from a hex private key (with or without 0x) that signs
+// for the exchange domain of chainID.
+func NewLocalSigner(privateKeyHex string, chainID int64) (*LocalSigner, error) {
+	privateKey, err := crypto.HexToECDSA(strings.TrimPrefix(privateKeyHex, "0x"))
+	if err != nil {
+		return nil, fmt.Errorf("parse private key: %w", err)
+	}
+
+	return &LocalSigner{
+		privateKey:   privateKey,
+		address:      crypto.PubkeyToAddress(privateKey.PublicKey).Hex(),
+		orderBuilder: builder.NewExchangeOrderBuilderImpl(big.NewInt(chainID), nil),
+	}, nil
+}
+
+// Address returns the address derived from the private key.
+func (s *LocalSigner) Address() string {
+	return s.address
+}
+
+// SignOrder hashes and signs order with the private key.
+func (s *LocalSigner) SignOrder(order *model.Order) (*model.SignedOrder, error) {
+	orderHash, err := s.orderBuilder.BuildOrderHash(order, model.CTFExchange)
+	if err != nil {
+		return nil, fmt.Errorf("build order hash: %w", err)
+	}
+
+	signature, err := s.orderBuilder.BuildOrderSignature(s.privateKey, orderHash)
+	if err != nil {
+		return nil, fmt.Errorf("sign order hash: %w", err)
+	}
+
+	return &model.SignedOrder{Order: *order, Signature: signature}, nil
+}
+
+// signOrder signs order through the client's Signer and checks the signature recovers to
+// the order's signer under the client's domain, so a remote signer using another key or
+// chain is caught before the order reaches the CLOB.
+func (c *OrderClient) signOrder(order *model.Order) (*model.SignedOrder, error) {
+	signedOrder, err := c.signer.SignOrder(order)
+	if err != nil {
+		return nil, fmt.Errorf("sign order: %w", err)
+	}
+
+	orderHash, err := c.orderBuilder.BuildOrderHash(&signedOrder.Order, model.CTFExchange)
+	if err != nil {
+		return nil, fmt.Errorf("build order hash: %w", err)
+	}
+
+	ok, err := signer.ValidateSignature(signedOrder.Signer, orderHash, signedOrder.Signature)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidOrderSignature, err)
+	}
+	if !ok {
+		return nil, fmt.Errorf("%w: does not recover to signer %s", ErrInvalidOrderSignature, signedOrder.Signer.Hex())
+	}
+
+	return signedOrder, nil
+}
@@ -0,0 +1,168 @@
+package execution
+
+import (
+	"context"
+	"errors"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/polymarket/go-order-utils/pkg/model"
+	"go.uber.org/zap"
+)
+
+const testSignerKey = "0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
+
+// stubSigner stands in for an external signer: it holds its own key outside the client
+// and records every order it signs.
+type stubSigner struct {
+	mu     sync.Mutex
+	inner  Signer
+	err    error
+	signed []*model.Order
+}
+
+func newStubSigner(t *testing.T, chainID int64) *stubSigner {
+	t.Helper()
+
+	inner, err := NewLocalSigner(testSignerKey, chainID)
+	if err != nil {
+		t.Fatalf("failed to create signer: %v", err)
+	}
+	return &stubSigner{inner: inner}
+}
+
+func (s *stubSigner) Address() string {
+	return s.inner.Address()
+}
+
+func (s *stubSigner) SignOrder(order *model.Order) (*model.SignedOrder, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.signed = append(s.signed, order)
+	if s.err != nil {
+		return nil, s.err
+	}
+	return s.inner.SignOrder(order)
+}
+
+func newSignerTestClient(t *testing.T, serverURL string, signer Signer) *OrderClient {
+	t.Helper()
+
+	client, err := NewOrderClient(&OrderClientConfig{
+		APIKey:     "test-api-key",
+		Secret:     "dGVzdC1zZWNyZXQ=",
+		Passphrase: "test-passphrase",
+		Logger:     zap.NewNop(),
+		BaseURL:    serverURL,
+		Signer:     signer,
+	})
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	return client
+}
+
+func TestNewLocalSigner(t *testing.T) {
+	withPrefix, err := NewLocalSigner("0x"+testSignerKey, PolygonChainID)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	withoutPrefix, err := NewLocalSigner(testSignerKey, PolygonChainID)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if withPrefix.Address() != withoutPrefix.Address() {
+		t.Errorf("expected same address with and without 0x, got %s and %s", withPrefix.Address(), withoutPrefix.Address())
+	}
+
+	if _, err := NewLocalSigner("not-a-key", PolygonChainID); err == nil {
+		t.Error("expected error for invalid private key, got nil")
+	}
+}
+
+// TestOrderClient_ExternalSigner tests that a client without an in-process key places
+// orders signed by its Signer.
+func TestOrderClient_ExternalSigner(t *testing.T) {
+	mock := &mockBatchCLOB{}
+	server := httptest.NewServer(mock)
+	defer server.Close()
+
+	signer := newStubSigner(t, PolygonChainID)
+	client := newSignerTestClient(t, server.URL, signer)
+
+	if client.address != signer.Address() {
+		t.Errorf("expected address %s from signer, got %s", signer.Address(), client.address)
+	}
+
+	outcomes := batchTestOutcomes(3)
+	for i := range outcomes {
+		outcomes[i].CorrelationID = CorrelationID("opp-signer", i)
+	}
+
+	_, err := client.PlaceOrdersMultiOutcome(context.Background(), outcomes, 10)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(signer.signed) != len(outcomes) {
+		t.Fatalf("expected signer to sign %d orders, got %d", len(outcomes), len(signer.signed))
+	}
+	for i, order := range signer.signed {
+		if want := CorrelationSalt(outcomes[i].CorrelationID); order.Salt.Int64() != want {
+			t.Errorf("order %d: expected correlation salt %d, got %d", i, want, order.Salt.Int64())
+		}
+	}
+	if len(mock.batches) != 1 || len(mock.batches[0]) != len(outcomes) {
+		t.Errorf("expected one batch of %d orders, got %v", len(outcomes), mock.batches)
+	}
+}
+
+// TestOrderClient_SignerRejected tests that signer failures and signatures that don't
+// verify under the client's domain stop the order before it reaches the CLOB.
+func TestOrderClient_SignerRejected(t *testing.T) {
+	tests := []struct {
+		name    string
+		signer  func(t *testing.T) *stubSigner
+		wantErr error
+	}{
+		{
+			name: "signer_error",
+			signer: func(t *testing.T) *stubSigner {
+				s := newStubSigner(t, PolygonChainID)
+				s.err = errors.New("kms unavailable")
+				return s
+			},
+		},
+		{
+			// Signs for Amoy while the client trades on Polygon
+			name: "wrong_domain",
+			signer: func(t *testing.T) *stubSigner {
+				return newStubSigner(t, 80002)
+			},
+			wantErr: ErrInvalidOrderSignature,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := &mockBatchCLOB{}
+			server := httptest.NewServer(mock)
+			defer server.Close()
+
+			client := newSignerTestClient(t, server.URL, tt.signer(t))
+
+			_, err := client.PlaceOrdersMultiOutcome(context.Background(), batchTestOutcomes(2), 10)
+			if err == nil {
+				t.Fatal("expected error, got nil")
+			}
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Errorf("expected %v, got %v", tt.wantErr, err)
+			}
+			if len(mock.batches) != 0 {
+				t.Errorf("expected no orders posted, got %v", mock.batches)
+			}
+		})
+	}
+}
@@ -0,0 +1,203 @@
+package execution
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/mselser95/polymarket-arb/pkg/types"
+)
+
+// StateStore persists executor state across restarts.
+// storage.PostgresStorage and storage.SQLiteStorage implement this interface.
+type StateStore interface {
+	// LoadExecutionState returns the last saved state for mode, or nil if none exists.
+	LoadExecutionState(ctx context.Context, mode string) (*types.ExecutionState, error)
+
+	// SaveExecutionState replaces the saved state for state.Mode.
+	SaveExecutionState(ctx context.Context, state *types.ExecutionState) error
+}
+
+// ResultStore records execution results for later analysis. Live executions are recorded
+// twice: when their orders are placed, and again with VerifiedAt set once fill
+// verification completes. Implementations must be safe for concurrent use: results of
+// different opportunities are stored from their own fill verification goroutines.
+// storage.SQLiteStorage implements this interface.
+type ResultStore interface {
+	StoreExecutionResult(ctx context.Context, result *types.ExecutionResult) error
+}
+
+const (
+	// defaultCheckpointInterval is used when Config.CheckpointInterval is unset.
+	defaultCheckpointInterval = 30 * time.Second
+
+	// maxPendingTrades bounds the persisted reconciliation list; oldest trades are dropped first.
+	maxPendingTrades = 500
+
+	// stateIOTimeout bounds a single load or checkpoint.
+	stateIOTimeout = 10 * time.Second
+)
+
+// restoreState loads the last checkpoint for this mode so cumulative profit and trade
+// counts continue from where the previous run stopped.
+func (e *Executor) restoreState(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, stateIOTimeout)
+	defer cancel()
+
+	state, err := e.stateStore.LoadExecutionState(ctx, e.mode)
+	if err != nil {
+		return fmt.Errorf("load execution state: %w", err)
+	}
+
+	if state == nil {
+		e.logger.Info("no-execution-state-to-restore", zap.String("mode", e.mode))
+		return nil
+	}
+
+	e.mu.Lock()
+	e.cumulativeProfit = USDFromFloat(state.CumulativeProfit)
+	if e.tradeCounts == nil {
+		e.tradeCounts = make(map[string]map[string]int)
+	}
+	e.tradeCounts[e.mode] = make(map[string]int, len(state.TradeCounts))
+	for outcome, count := range state.TradeCounts {
+		e.tradeCounts[e.mode][outcome] = count
+	}
+	e.pendingTrades = append([]types.PendingTrade(nil), state.PendingTrades...)
+	e.mu.Unlock()
+
+	e.logger.Info("execution-state-restored",
+		zap.String("mode", e.mode),
+		zap.Float64("cumulative-profit-usd", e.reportUSD(USDFromFloat(state.CumulativeProfit))),
+		zap.Int("pending-trades", len(state.PendingTrades)),
+		zap.Time("saved-at", state.UpdatedAt))
+
+	return nil
+}
+
+// checkpointLoop saves state every checkpoint interval until the executor stops.
+func (e *Executor) checkpointLoop() {
+	defer e.wg.Done()
+
+	ticker := time.NewTicker(e.checkpointInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-e.ctx.Done():
+			return
+		case <-e.stopCheckpoint:
+			return
+		case <-ticker.C:
+			err := e.checkpoint(e.ctx)
+			if err != nil {
+				e.logger.Warn("execution-state-checkpoint-failed", zap.Error(err))
+			}
+		}
+	}
+}
+
+// checkpoint saves a snapshot of the current state. Checkpoints are serialized so a
+// slow save of an older snapshot can never overwrite a newer one.
+func (e *Executor) checkpoint(ctx context.Context) error {
+	e.checkpointMu.Lock()
+	defer e.checkpointMu.Unlock()
+
+	e.mu.Lock()
+	state := &types.ExecutionState{
+		Mode:             e.mode,
+		CumulativeProfit: e.cumulativeProfit.Float64(),
+		TradeCounts:      make(map[string]int, len(e.tradeCounts[e.mode])),
+		PendingTrades:    append([]types.PendingTrade(nil), e.pendingTrades...),
+		UpdatedAt:        time.Now(),
+	}
+	for outcome, count := range e.tradeCounts[e.mode] {
+		state.TradeCounts[outcome] = count
+	}
+	e.mu.Unlock()
+
+	ctx, cancel := context.WithTimeout(ctx, stateIOTimeout)
+	defer cancel()
+
+	err := e.stateStore.SaveExecutionState(ctx, state)
+	if err != nil {
+		return fmt.Errorf("save execution state: %w", err)
+	}
+
+	return nil
+}
+
+// addPendingTrade records a placed live order set until its fills are confirmed.
+func (e *Executor) addPendingTrade(trade types.PendingTrade) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.pendingTrades = append(e.pendingTrades, trade)
+	if excess := len(e.pendingTrades) - maxPendingTrades; excess > 0 {
+		e.pendingTrades = append([]types.PendingTrade(nil), e.pendingTrades[excess:]...)
+	}
+}
+
+// removePendingTrade drops the pending trade containing orderIDs once it is resolved.
+func (e *Executor) removePendingTrade(orderIDs []string) {
+	done := make(map[string]bool, len(orderIDs))
+	for _, id := range orderIDs {
+		done[id] = true
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	kept := e.pendingTrades[:0]
+	for _, trade := range e.pendingTrades {
+		if len(trade.OrderIDs) > 0 && done[trade.OrderIDs[0]] {
+			continue
+		}
+		kept = append(kept, trade)
+	}
+	e.pendingTrades = kept
+}
+
+// pendingOrderIDs returns the order IDs of every pending trade.
+func (e *Executor) pendingOrderIDs() map[string]bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	ids := make(map[string]bool)
+	for _, trade := range e.pendingTrades {
+		for _, id := range trade.OrderIDs {
+			ids[id] = true
+		}
+	}
+
+	return ids
+}
+
+// PendingTrades returns live trades not yet confirmed filled, including any restored
+// from the previous run and not resolved by reconciliation.
+func (e *Executor) PendingTrades() []types.PendingTrade {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	return append([]types.PendingTrade(nil), e.pendingTrades...)
+}
+
+// storeResult records an execution result. Failures are logged to logger; they never fail
+// the execution.
+func (e *Executor) storeResult(logger *zap.Logger, result *types.ExecutionResult) {
+	if e.resultStore == nil {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), stateIOTimeout)
+	defer cancel()
+
+	err := e.resultStore.StoreExecutionResult(ctx, result)
+	if err != nil {
+		logger.Warn("execution-result-store-failed",
+			zap.String("opportunity-id", result.OpportunityID),
+			zap.Error(err))
+	}
+}
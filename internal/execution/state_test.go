@@ -0,0 +1,208 @@
+package execution
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/mselser95/polymarket-arb/internal/arbitrage"
+	"github.com/mselser95/polymarket-arb/pkg/types"
+)
+
+// mockStateStore keeps saved states in memory and checks that saves never go backwards.
+type mockStateStore struct {
+	mu      sync.Mutex
+	states  map[string]*types.ExecutionState
+	saves   int
+	loadErr error
+
+	regressed bool
+}
+
+func newMockStateStore() *mockStateStore {
+	return &mockStateStore{states: make(map[string]*types.ExecutionState)}
+}
+
+func (m *mockStateStore) LoadExecutionState(_ context.Context, mode string) (*types.ExecutionState, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.loadErr != nil {
+		return nil, m.loadErr
+	}
+
+	return m.states[mode], nil
+}
+
+func (m *mockStateStore) SaveExecutionState(_ context.Context, state *types.ExecutionState) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	// Paper profit only grows, so an older snapshot overwriting a newer one shows as a drop
+	if prev := m.states[state.Mode]; prev != nil && state.CumulativeProfit < prev.CumulativeProfit {
+		m.regressed = true
+	}
+
+	m.saves++
+	m.states[state.Mode] = state
+	return nil
+}
+
+// runPaperTrades starts a paper executor on store, executes count opportunities and closes it.
+func runPaperTrades(t *testing.T, store StateStore, count int) *Executor {
+	t.Helper()
+
+	oppChan := make(chan *arbitrage.Opportunity, count)
+	exec := New(&Config{
+		Mode:               "paper",
+		Logger:             zap.NewNop(),
+		OpportunityChannel: oppChan,
+		StateStore:         store,
+		CheckpointInterval: time.Hour,
+	})
+
+	err := exec.Start(context.Background())
+	if err != nil {
+		t.Fatalf("start executor: %v", err)
+	}
+
+	for i := 0; i < count; i++ {
+		oppChan <- arbitrage.CreateTestOpportunity("test-market", "test-slug")
+	}
+	close(oppChan)
+
+	err = exec.Close()
+	if err != nil {
+		t.Fatalf("close executor: %v", err)
+	}
+
+	return exec
+}
+
+// TestExecutor_RestoresStateAfterRestart tests that profit and trade counts carry over
+// to a new executor sharing the same store.
+func TestExecutor_RestoresStateAfterRestart(t *testing.T) {
+	store := newMockStateStore()
+
+	first := runPaperTrades(t, store, 3)
+	firstProfit := first.CumulativeProfit()
+	if firstProfit <= 0 {
+		t.Fatalf("expected positive paper profit, got %f", firstProfit)
+	}
+
+	saved := store.states["paper"]
+	if saved == nil {
+		t.Fatal("expected final checkpoint on close")
+	}
+	if saved.CumulativeProfit != firstProfit {
+		t.Errorf("expected saved profit %f, got %f", firstProfit, saved.CumulativeProfit)
+	}
+
+	second := runPaperTrades(t, store, 2)
+
+	wantProfit := firstProfit * 5 / 3
+	if diff := second.CumulativeProfit() - wantProfit; diff > 1e-9 || diff < -1e-9 {
+		t.Errorf("expected cumulative profit %f after restart, got %f", wantProfit, second.CumulativeProfit())
+	}
+
+	// Two outcomes per opportunity across both runs
+	if got := second.Stats().TotalFilledLegs; got != 10 {
+		t.Errorf("expected 10 filled legs after restart, got %d", got)
+	}
+}
+
+// TestExecutor_RestoreErrorFailsStart tests that a failed load stops Start instead of
+// later checkpoints overwriting the saved profit with zero.
+func TestExecutor_RestoreErrorFailsStart(t *testing.T) {
+	store := newMockStateStore()
+	store.loadErr = errors.New("connection refused")
+
+	exec := New(&Config{
+		Mode:               "paper",
+		Logger:             zap.NewNop(),
+		OpportunityChannel: make(chan *arbitrage.Opportunity),
+		StateStore:         store,
+	})
+
+	err := exec.Start(context.Background())
+	if err == nil {
+		t.Fatal("expected start to fail when state cannot be loaded")
+	}
+
+	if store.saves != 0 {
+		t.Errorf("expected no saves after failed load, got %d", store.saves)
+	}
+}
+
+// TestExecutor_ConcurrentCheckpoints tests that checkpoints racing with trades never
+// save an older snapshot over a newer one.
+func TestExecutor_ConcurrentCheckpoints(t *testing.T) {
+	store := newMockStateStore()
+	oppChan := make(chan *arbitrage.Opportunity, 50)
+	exec := New(&Config{
+		Mode:               "paper",
+		Logger:             zap.NewNop(),
+		OpportunityChannel: oppChan,
+		StateStore:         store,
+		CheckpointInterval: time.Millisecond,
+	})
+
+	err := exec.Start(context.Background())
+	if err != nil {
+		t.Fatalf("start executor: %v", err)
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 20; j++ {
+				_ = exec.checkpoint(context.Background())
+			}
+		}()
+	}
+
+	for i := 0; i < 50; i++ {
+		oppChan <- arbitrage.CreateTestOpportunity("test-market", "test-slug")
+	}
+	close(oppChan)
+
+	wg.Wait()
+	_ = exec.Close()
+
+	if store.regressed {
+		t.Error("expected saved profit to never decrease")
+	}
+
+	if got := store.states["paper"].CumulativeProfit; got != exec.CumulativeProfit() {
+		t.Errorf("expected final saved profit %f, got %f", exec.CumulativeProfit(), got)
+	}
+}
+
+// TestPendingTrades tests tracking of unconfirmed live trades.
+func TestPendingTrades(t *testing.T) {
+	exec := New(&Config{Mode: "live", Logger: zap.NewNop()})
+
+	exec.addPendingTrade(types.PendingTrade{OpportunityID: "a", OrderIDs: []string{"a-yes", "a-no"}})
+	exec.addPendingTrade(types.PendingTrade{OpportunityID: "b", OrderIDs: []string{"b-yes", "b-no"}})
+	exec.addPendingTrade(types.PendingTrade{OpportunityID: "c", OrderIDs: []string{"c-yes", "c-no"}})
+	exec.removePendingTrade([]string{"b-yes", "b-no"})
+
+	got := exec.PendingTrades()
+	if len(got) != 2 || got[0].OpportunityID != "a" || got[1].OpportunityID != "c" {
+		t.Errorf("expected trades [a c], got %+v", got)
+	}
+
+	for i := 0; i < maxPendingTrades+5; i++ {
+		exec.addPendingTrade(types.PendingTrade{OrderIDs: []string{"order"}})
+	}
+
+	if n := len(exec.PendingTrades()); n != maxPendingTrades {
+		t.Errorf("expected pending trades capped at %d, got %d", maxPendingTrades, n)
+	}
+}
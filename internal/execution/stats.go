@@ -0,0 +1,97 @@
+package execution
+
+// Stats is a point-in-time snapshot of executor performance.
+type Stats struct {
+	Mode             string  `json:"mode"`
+	CumulativeProfit float64 `json:"cumulative_profit_usd"`
+	TotalFilledLegs  int     `json:"total_filled_legs"` // Filled outcome legs across all modes, not executions
+
+	// TradesByMode counts filled outcome legs keyed by mode, then outcome name
+	TradesByMode map[string]map[string]int `json:"trades_by_mode"`
+
+	// FillVerifications counts live fill-verification results keyed by status
+	// ("success", "partial", "delayed", "error", "aborted")
+	FillVerifications map[string]int `json:"fill_verifications"`
+}
+
+// Stats returns a snapshot of cumulative profit, rounded to the report precision, trade
+// counts, and fill-verification results. The returned maps are copies and safe to use
+// after the call.
+func (e *Executor) Stats() Stats {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	stats := Stats{
+		Mode:              e.mode,
+		CumulativeProfit:  e.reportUSD(e.cumulativeProfit),
+		TradesByMode:      make(map[string]map[string]int, len(e.tradeCounts)),
+		FillVerifications: make(map[string]int, len(e.fillVerifications)),
+	}
+
+	for mode, byOutcome := range e.tradeCounts {
+		outcomes := make(map[string]int, len(byOutcome))
+		for outcome, count := range byOutcome {
+			outcomes[outcome] = count
+			stats.TotalFilledLegs += count
+		}
+		stats.TradesByMode[mode] = outcomes
+	}
+
+	for status, count := range e.fillVerifications {
+		stats.FillVerifications[status] = count
+	}
+
+	return stats
+}
+
+// recordProfit adds a realized profit to the cumulative total and to mode's
+// ProfitRealizedUSD gauge, and returns the new cumulative total. Caller must hold e.mu.
+func (e *Executor) recordProfit(mode string, profit float64) USD {
+	amount := USDFromFloat(profit)
+	e.cumulativeProfit += amount
+
+	if e.realizedProfit == nil {
+		e.realizedProfit = make(map[string]USD)
+	}
+
+	e.realizedProfit[mode] += amount
+	ProfitRealizedUSD.WithLabelValues(mode).Set(e.realizedProfit[mode].Float64())
+
+	return e.cumulativeProfit
+}
+
+// reportUSD rounds amount to the configured report precision for logs and stats.
+func (e *Executor) reportUSD(amount USD) float64 {
+	if e.profitDecimals <= 0 {
+		return amount.Float64()
+	}
+
+	return amount.Round(e.profitDecimals)
+}
+
+// recordTrade counts one filled outcome leg. Caller must hold e.mu.
+func (e *Executor) recordTrade(mode string, outcome string) {
+	if e.tradeCounts == nil {
+		e.tradeCounts = make(map[string]map[string]int)
+	}
+
+	if e.tradeCounts[mode] == nil {
+		e.tradeCounts[mode] = make(map[string]int)
+	}
+
+	e.tradeCounts[mode][outcome]++
+}
+
+// recordFillVerification counts one fill-verification result by status.
+func (e *Executor) recordFillVerification(status string) {
+	FillVerificationTotal.WithLabelValues(status).Inc()
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.fillVerifications == nil {
+		e.fillVerifications = make(map[string]int)
+	}
+
+	e.fillVerifications[status]++
+}
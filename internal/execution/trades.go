@@ -0,0 +1,123 @@
+package execution
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"go.uber.org/zap"
+
+	"github.com/mselser95/polymarket-arb/pkg/types"
+)
+
+// endCursor marks the last page of a paginated CLOB response.
+const endCursor = "LTE="
+
+// maxTradePages bounds pagination so a misbehaving cursor cannot loop forever.
+const maxTradePages = 50
+
+// TradeQuerier fetches executed trades. OrderClient implements this interface.
+type TradeQuerier interface {
+	GetTrades(ctx context.Context, query TradeQuery) ([]types.TradeResponse, error)
+}
+
+// TradeQuery filters GET /data/trades. Empty fields are not sent.
+type TradeQuery struct {
+	ID      string // Trade ID
+	Market  string // Condition ID
+	AssetID string // Token ID
+}
+
+// TradesResponse is the paginated response of GET /data/trades.
+type TradesResponse struct {
+	Data       []types.TradeResponse `json:"data"`
+	NextCursor string                `json:"next_cursor"`
+	Limit      int                   `json:"limit"`
+	Count      int                   `json:"count"`
+}
+
+// GetTrades fetches the authenticated user's trades matching the query, following pagination.
+func (c *OrderClient) GetTrades(ctx context.Context, query TradeQuery) (trades []types.TradeResponse, err error) {
+	params := url.Values{}
+	if query.ID != "" {
+		params.Set("id", query.ID)
+	}
+	if query.Market != "" {
+		params.Set("market", query.Market)
+	}
+	if query.AssetID != "" {
+		params.Set("asset_id", query.AssetID)
+	}
+
+	cursor := ""
+	for page := 0; page < maxTradePages; page++ {
+		if cursor != "" {
+			params.Set("next_cursor", cursor)
+		}
+
+		requestPath := "/data/trades"
+		if len(params) > 0 {
+			requestPath += "?" + params.Encode()
+		}
+
+		statusCode, body, err := c.doSigned(ctx, http.MethodGet, requestPath, nil)
+		if err != nil {
+			return trades, err
+		}
+
+		if statusCode != http.StatusOK {
+			c.logger.Error("get-trades-api-error",
+				zap.Int("status-code", statusCode),
+				zap.String("response-body", string(body)))
+			return trades, fmt.Errorf("API error (status %d): %s", statusCode, string(body))
+		}
+
+		var resp TradesResponse
+		err = json.Unmarshal(body, &resp)
+		if err != nil {
+			return trades, fmt.Errorf("parse trades response: %w", err)
+		}
+
+		trades = append(trades, resp.Data...)
+
+		if resp.NextCursor == "" || resp.NextCursor == endCursor {
+			return trades, nil
+		}
+		cursor = resp.NextCursor
+	}
+
+	return trades, fmt.Errorf("trades pagination exceeded %d pages", maxTradePages)
+}
+
+// actualFeePaid sums the fees charged to orderID across the trades that filled it.
+func actualFeePaid(ctx context.Context, querier TradeQuerier, orderID string, tradeIDs []string) (float64, error) {
+	total := 0.0
+	for _, tradeID := range tradeIDs {
+		trades, err := querier.GetTrades(ctx, TradeQuery{ID: tradeID})
+		if err != nil {
+			return 0, fmt.Errorf("get trade %s: %w", tradeID, err)
+		}
+
+		found := false
+		for i := range trades {
+			if trades[i].ID != tradeID {
+				continue
+			}
+
+			fee, ok := trades[i].FeeFor(orderID)
+			if !ok {
+				return 0, fmt.Errorf("trade %s does not include order %s", tradeID, orderID)
+			}
+			total += fee
+			found = true
+		}
+
+		if !found {
+			return 0, fmt.Errorf("trade %s not found", tradeID)
+		}
+	}
+
+	return total, nil
+}
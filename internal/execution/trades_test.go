@@ -0,0 +1,201 @@
+package execution
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/mselser95/polymarket-arb/internal/arbitrage"
+	"github.com/mselser95/polymarket-arb/pkg/types"
+)
+
+type mockTradeClient struct {
+	orders map[string]*types.OrderQueryResponse
+	trades map[string]types.TradeResponse
+	err    error
+}
+
+func (m *mockTradeClient) GetOrder(_ context.Context, orderID string) (*types.OrderQueryResponse, error) {
+	return m.orders[orderID], nil
+}
+
+func (m *mockTradeClient) GetTrades(_ context.Context, query TradeQuery) ([]types.TradeResponse, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	trade, ok := m.trades[query.ID]
+	if !ok {
+		return nil, nil
+	}
+	return []types.TradeResponse{trade}, nil
+}
+
+// TestGetTrades_ParsesAndPaginates tests trade parsing and next_cursor pagination.
+func TestGetTrades_ParsesAndPaginates(t *testing.T) {
+	var cursors []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/data/trades" || r.URL.Query().Get("market") != "0xcond" {
+			t.Errorf("unexpected request %s", r.URL.String())
+		}
+		if r.Header.Get("POLY_SIGNATURE") == "" {
+			t.Error("expected signed request")
+		}
+
+		cursor := r.URL.Query().Get("next_cursor")
+		cursors = append(cursors, cursor)
+		if cursor == "" {
+			_, _ = w.Write([]byte(`{"data":[{"id":"trade-1","taker_order_id":"order-1","size":"10",
+				"price":"0.45","fee_rate_bps":"100","status":"CONFIRMED","maker_orders":[
+				{"order_id":"maker-1","matched_amount":"10","price":"0.45","fee_rate_bps":"0"}]}],
+				"next_cursor":"MQ=="}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"data":[{"id":"trade-2","taker_order_id":"order-2","size":"5",
+			"price":"0.80","fee_rate_bps":"200"}],"next_cursor":"LTE="}`))
+	}))
+	defer server.Close()
+
+	client := newSkewTestClient(t, server.URL, false)
+
+	trades, err := client.GetTrades(context.Background(), TradeQuery{Market: "0xcond"})
+	if err != nil {
+		t.Fatalf("get trades: %v", err)
+	}
+
+	if len(trades) != 2 || len(cursors) != 2 || cursors[1] != "MQ==" {
+		t.Fatalf("expected 2 trades over 2 pages, got %d trades, cursors %v", len(trades), cursors)
+	}
+
+	fee, ok := trades[0].FeeFor("order-1")
+	if !ok || !floatEquals(fee, 0.045, 1e-9) {
+		t.Errorf("expected taker fee 0.045, got %f (ok=%v)", fee, ok)
+	}
+	fee, ok = trades[0].FeeFor("maker-1")
+	if !ok || fee != 0 {
+		t.Errorf("expected zero maker fee, got %f (ok=%v)", fee, ok)
+	}
+	// Above 0.50 the fee is charged on the complement: 5 × 0.20 × 2%
+	fee, ok = trades[1].FeeFor("order-2")
+	if !ok || !floatEquals(fee, 0.02, 1e-9) {
+		t.Errorf("expected taker fee 0.02, got %f (ok=%v)", fee, ok)
+	}
+	if _, ok = trades[1].FeeFor("order-1"); ok {
+		t.Error("expected order-1 not to be part of trade-2")
+	}
+}
+
+// TestVerifyFills_ActualFees tests that filled orders carry fees from their trades, and
+// that the estimate is kept when trade data is unavailable.
+func TestVerifyFills_ActualFees(t *testing.T) {
+	orders := map[string]*types.OrderQueryResponse{
+		"order-yes": {OrderID: "order-yes", Status: "MATCHED", Size: 10, SizeFilled: 10, Price: 0.45,
+			AssociateTrades: []string{"trade-1", "trade-2"}},
+		"order-no": {OrderID: "order-no", Status: "MATCHED", Size: 10, SizeFilled: 10, Price: 0.48},
+	}
+	trades := map[string]types.TradeResponse{
+		"trade-1": {ID: "trade-1", TakerOrderID: "order-yes", Size: 6, Price: 0.45, FeeRateBps: 100},
+		"trade-2": {ID: "trade-2", TakerOrderID: "order-yes", Size: 4, Price: 0.45, FeeRateBps: 50},
+	}
+
+	tests := []struct {
+		name        string
+		err         error
+		wantFromFee bool
+		wantFee     float64
+	}{
+		{name: "trades_available", wantFromFee: true, wantFee: 6*0.45*0.01 + 4*0.45*0.005},
+		{name: "trades_unavailable", err: errors.New("503 service unavailable")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := &mockTradeClient{orders: orders, trades: trades, err: tt.err}
+			tracker := NewFillTracker(client, zap.NewNop(), &FillTrackerConfig{
+				InitialBackoff: time.Millisecond,
+				MaxBackoff:     time.Millisecond,
+				BackoffMult:    2.0,
+				FillTimeout:    time.Second,
+			})
+
+			fills, err := tracker.VerifyFills(context.Background(),
+				[]string{"order-yes", "order-no"}, []string{"YES", "NO"}, []float64{10, 10})
+			if err != nil {
+				t.Fatalf("verify fills: %v", err)
+			}
+
+			if fills[0].FeeFromTrades != tt.wantFromFee || !floatEquals(fills[0].ActualFeePaid, tt.wantFee, 1e-9) {
+				t.Errorf("expected fee %f (from trades=%v), got %f (from trades=%v)",
+					tt.wantFee, tt.wantFromFee, fills[0].ActualFeePaid, fills[0].FeeFromTrades)
+			}
+
+			// No associated trades: always falls back to the estimate
+			if fills[1].FeeFromTrades {
+				t.Error("expected order without trades to use the fee estimate")
+			}
+		})
+	}
+}
+
+// TestCalculateActualProfit_ActualVsEstimatedFees tests that trade fees replace the
+// estimate per fill, and that the estimate is used for fills without trade data.
+func TestCalculateActualProfit_ActualVsEstimatedFees(t *testing.T) {
+	fees := arbitrage.FlatFeeModel{TakerRate: 0.01}
+
+	estimated := []types.FillStatus{
+		{Outcome: "YES", FullyFilled: true, SizeFilled: 100, ActualPrice: 0.45},
+		{Outcome: "NO", FullyFilled: true, SizeFilled: 100, ActualPrice: 0.48},
+	}
+
+	// Cost 93, estimated fees 0.93
+	profit, filled := calculateActualProfit(estimated, fees, arbitrage.FeeSideTaker)
+	if !filled || !floatEquals(profit, 100-93-0.93, 1e-9) {
+		t.Errorf("estimated: expected profit %f, got %f (filled=%v)", 100-93-0.93, profit, filled)
+	}
+
+	// Both legs filled as makers: no fees charged
+	actual := []types.FillStatus{
+		{Outcome: "YES", FullyFilled: true, SizeFilled: 100, ActualPrice: 0.45, FeeFromTrades: true},
+		{Outcome: "NO", FullyFilled: true, SizeFilled: 100, ActualPrice: 0.48, FeeFromTrades: true},
+	}
+	profit, filled = calculateActualProfit(actual, fees, arbitrage.FeeSideTaker)
+	if !filled || !floatEquals(profit, 7, 1e-9) {
+		t.Errorf("actual: expected profit 7.00, got %f (filled=%v)", profit, filled)
+	}
+
+	// Mixed: actual fee on YES, estimate on NO
+	mixed := []types.FillStatus{
+		{Outcome: "YES", FullyFilled: true, SizeFilled: 100, ActualPrice: 0.45, ActualFeePaid: 0.90, FeeFromTrades: true},
+		{Outcome: "NO", FullyFilled: true, SizeFilled: 100, ActualPrice: 0.48},
+	}
+	profit, filled = calculateActualProfit(mixed, fees, arbitrage.FeeSideTaker)
+	if !filled || !floatEquals(profit, 100-93-0.90-0.48, 1e-9) {
+		t.Errorf("mixed: expected profit %f, got %f (filled=%v)", 100-93-0.90-0.48, profit, filled)
+	}
+}
+
+// TestCalculateActualProfit_TieredFeeModel tests that estimated fees follow the fee model.
+func TestCalculateActualProfit_TieredFeeModel(t *testing.T) {
+	fees, err := arbitrage.NewTieredFeeModel([]arbitrage.FeeTier{
+		{MinNotional: 0, TakerRate: 0.01},
+		{MinNotional: 46, TakerRate: 0.005},
+	})
+	if err != nil {
+		t.Fatalf("new tiered model: %v", err)
+	}
+
+	fills := []types.FillStatus{
+		{Outcome: "YES", FullyFilled: true, SizeFilled: 100, ActualPrice: 0.45},
+		{Outcome: "NO", FullyFilled: true, SizeFilled: 100, ActualPrice: 0.48},
+	}
+
+	// YES notional 45 pays 1%, NO notional 48 pays 0.5%
+	profit, filled := calculateActualProfit(fills, fees, arbitrage.FeeSideTaker)
+	if !filled || !floatEquals(profit, 100-93-0.45-0.24, 1e-9) {
+		t.Errorf("expected profit %f, got %f (filled=%v)", 100-93-0.45-0.24, profit, filled)
+	}
+}
@@ -0,0 +1,172 @@
+package orderbook
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/mselser95/polymarket-arb/pkg/types"
+	"go.uber.org/zap"
+)
+
+// TestHandleBookMessage_StoresAskDepth tests that the full ask ladder is stored best first
+// and priced into AskLiquidityUSD.
+func TestHandleBookMessage_StoresAskDepth(t *testing.T) {
+	manager := &Manager{
+		books:  make(map[string]*types.OrderbookSnapshot),
+		logger: zap.NewNop(),
+	}
+
+	// Polymarket may send asks worst first; the best level must still be first once stored
+	err := manager.handleBookMessage(&types.OrderbookMessage{
+		EventType: "book",
+		AssetID:   "token-1",
+		Bids:      []types.PriceLevel{{Price: "0.40", Size: "100"}},
+		Asks: []types.PriceLevel{
+			{Price: "0.42", Size: "100"},
+			{Price: "0.50", Size: "200"},
+			{Price: "0.45", Size: "0"}, // empty level
+			{Price: "0.44", Size: "50"},
+		},
+		Timestamp: 1234567890000,
+	})
+	if err != nil {
+		t.Fatalf("handleBookMessage: %v", err)
+	}
+
+	snapshot, _ := manager.GetSnapshot("token-1")
+	if len(snapshot.AskDepth) != 3 || snapshot.AskDepth[0].Price != 0.42 || snapshot.AskDepth[2].Price != 0.50 {
+		t.Fatalf("unexpected ask depth: %+v", snapshot.AskDepth)
+	}
+
+	want := 0.42*100 + 0.44*50 + 0.50*200
+	if got := snapshot.AskLiquidityUSD(); got < want-1e-9 || got > want+1e-9 {
+		t.Errorf("expected ask liquidity %f, got %f", want, got)
+	}
+
+	// A higher best ask consumes the cheaper levels
+	err = manager.handlePriceChangeMessage(&types.OrderbookMessage{
+		EventType: "price_change",
+		AssetID:   "token-1",
+		Bids:      []types.PriceLevel{{Price: "0.40", Size: "0"}},
+		Asks:      []types.PriceLevel{{Price: "0.44", Size: "0"}},
+		Timestamp: 1234567891000,
+	})
+	if err != nil {
+		t.Fatalf("handlePriceChangeMessage: %v", err)
+	}
+
+	updated, _ := manager.GetSnapshot("token-1")
+	if len(updated.AskDepth) != 2 || updated.AskDepth[0].Price != 0.44 {
+		t.Errorf("expected levels below the new best ask trimmed, got %+v", updated.AskDepth)
+	}
+	if len(snapshot.AskDepth) != 3 {
+		t.Error("earlier snapshot copy must not be mutated")
+	}
+}
+
+// TestHandlePriceChangeMessage_AppliesAskChanges tests that ask-side level changes are
+// applied to the ladder, and that a ladder no longer starting at the best ask is dropped.
+func TestHandlePriceChangeMessage_AppliesAskChanges(t *testing.T) {
+	tests := []struct {
+		name        string
+		change      *types.PriceChange
+		bestAsk     string
+		wantDepth   []types.BookLevel
+		wantAskSize float64
+	}{
+		{
+			name:        "best_level_resized",
+			change:      &types.PriceChange{Side: "SELL", Price: "0.42", Size: "30"},
+			bestAsk:     "0.42",
+			wantDepth:   []types.BookLevel{{Price: 0.42, Size: 30}, {Price: 0.44, Size: 50}, {Price: 0.50, Size: 200}},
+			wantAskSize: 30,
+		},
+		{
+			name:        "better_level_added",
+			change:      &types.PriceChange{Side: "SELL", Price: "0.41", Size: "10"},
+			bestAsk:     "0.41",
+			wantDepth:   []types.BookLevel{{Price: 0.41, Size: 10}, {Price: 0.42, Size: 100}, {Price: 0.44, Size: 50}, {Price: 0.50, Size: 200}},
+			wantAskSize: 10,
+		},
+		{
+			name:        "inner_level_added",
+			change:      &types.PriceChange{Side: "SELL", Price: "0.47", Size: "25"},
+			bestAsk:     "0.42",
+			wantDepth:   []types.BookLevel{{Price: 0.42, Size: 100}, {Price: 0.44, Size: 50}, {Price: 0.47, Size: 25}, {Price: 0.50, Size: 200}},
+			wantAskSize: 100,
+		},
+		{
+			name:        "best_level_removed",
+			change:      &types.PriceChange{Side: "SELL", Price: "0.42", Size: "0"},
+			bestAsk:     "0.44",
+			wantDepth:   []types.BookLevel{{Price: 0.44, Size: 50}, {Price: 0.50, Size: 200}},
+			wantAskSize: 50,
+		},
+		{
+			name:        "bid_change_keeps_ladder",
+			change:      &types.PriceChange{Side: "BUY", Price: "0.41", Size: "10"},
+			bestAsk:     "0.42",
+			wantDepth:   []types.BookLevel{{Price: 0.42, Size: 100}, {Price: 0.44, Size: 50}, {Price: 0.50, Size: 200}},
+			wantAskSize: 100,
+		},
+		{
+			// Nothing explains a best ask of 0.43: fall back to the best level only
+			name:        "missed_change_drops_ladder",
+			bestAsk:     "0.43",
+			wantAskSize: 100,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			manager := &Manager{
+				books:  make(map[string]*types.OrderbookSnapshot),
+				logger: zap.NewNop(),
+			}
+
+			err := manager.handleBookMessage(&types.OrderbookMessage{
+				EventType: "book",
+				AssetID:   "token-1",
+				Bids:      []types.PriceLevel{{Price: "0.40", Size: "100"}},
+				Asks: []types.PriceLevel{
+					{Price: "0.42", Size: "100"},
+					{Price: "0.44", Size: "50"},
+					{Price: "0.50", Size: "200"},
+				},
+				Timestamp: 1234567890000,
+			})
+			if err != nil {
+				t.Fatalf("handleBookMessage: %v", err)
+			}
+
+			err = manager.handlePriceChangeMessage(&types.OrderbookMessage{
+				EventType: "price_change",
+				AssetID:   "token-1",
+				Bids:      []types.PriceLevel{{Price: "0.40", Size: "0"}},
+				Asks:      []types.PriceLevel{{Price: tt.bestAsk, Size: "0"}},
+				Change:    tt.change,
+				Timestamp: 1234567891000,
+			})
+			if err != nil {
+				t.Fatalf("handlePriceChangeMessage: %v", err)
+			}
+
+			snapshot, _ := manager.GetSnapshot("token-1")
+			if fmt.Sprint(snapshot.AskDepth) != fmt.Sprint(tt.wantDepth) {
+				t.Errorf("expected ask depth %v, got %v", tt.wantDepth, snapshot.AskDepth)
+			}
+			if snapshot.BestAskSize != tt.wantAskSize {
+				t.Errorf("expected best ask size %f, got %f", tt.wantAskSize, snapshot.BestAskSize)
+			}
+		})
+	}
+}
+
+// TestAskLiquidityUSD_TopOfBookFallback tests that snapshots without a ladder count the best ask only.
+func TestAskLiquidityUSD_TopOfBookFallback(t *testing.T) {
+	snapshot := &types.OrderbookSnapshot{BestAskPrice: 0.45, BestAskSize: 100}
+
+	if got := snapshot.AskLiquidityUSD(); got != 45 {
+		t.Errorf("expected top-of-book liquidity 45, got %f", got)
+	}
+}
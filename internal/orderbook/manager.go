@@ -2,9 +2,14 @@ package orderbook
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"math"
+	"sort"
 	"strconv"
+	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/mselser95/polymarket-arb/pkg/types"
@@ -12,30 +17,126 @@ import (
 	"go.uber.org/zap"
 )
 
+const (
+	// DefaultUpdateBufferSize is the default capacity of the update channel.
+	DefaultUpdateBufferSize = 100000
+
+	// DefaultHighWatermark is the default channel utilization at which backpressure is signaled.
+	DefaultHighWatermark = 0.9
+)
+
+// levelPriceEpsilon is the tolerance for matching price levels parsed from the feed.
+const levelPriceEpsilon = 1e-9
+
+// ErrCrossedBook is returned when a book snapshot has best bid >= best ask.
+var ErrCrossedBook = errors.New("crossed book")
+
+// SubscriptionChecker reports whether a token is subscribed on the websocket feed.
+// websocket.Pool implements this interface.
+type SubscriptionChecker interface {
+	IsSubscribed(tokenID string) bool
+}
+
 // Manager manages orderbook state for all subscribed tokens.
 type Manager struct {
-	books      map[string]*types.OrderbookSnapshot // key: token_id
-	mu         sync.RWMutex
-	logger     *zap.Logger
-	msgChan    <-chan *types.OrderbookMessage
-	updateChan chan *types.OrderbookSnapshot
-	ctx        context.Context
-	wg         sync.WaitGroup
+	books          map[string]*types.OrderbookSnapshot // key: token_id
+	mu             sync.RWMutex
+	logger         *zap.Logger
+	msgChan        <-chan *types.OrderbookMessage
+	updateChan     chan *types.OrderbookSnapshot
+	highWatermark  int // Buffered updates at which backpressure is signaled
+	backpressured  atomic.Bool
+	droppedUpdates atomic.Uint64
+	lastUpdate     atomic.Int64 // Unix nanos of the last processed book/price_change message
+	cleanupEvery   time.Duration
+	snapshotTTL    time.Duration
+	subscriptions  SubscriptionChecker
+	ctx            context.Context
+	wg             sync.WaitGroup
+
+	// REST fallback for stale books (disabled when fetcher is nil)
+	fetcher            BookFetcher
+	fallbackStaleAfter time.Duration
+	fallbackInterval   time.Duration
+	fallbackMaxFetches int
 }
 
 // Config holds orderbook manager configuration.
 type Config struct {
 	Logger         *zap.Logger
 	MessageChannel <-chan *types.OrderbookMessage
+
+	// UpdateBufferSize is the capacity of the update channel (default: 100000).
+	// Updates are dropped (never blocked on) when the channel is full.
+	UpdateBufferSize int
+
+	// HighWatermark is the channel utilization in (0, 1] at which the manager
+	// reports backpressure to consumers (default: 0.9).
+	HighWatermark float64
+
+	// SnapshotTTL evicts snapshots not updated for longer than this, checked every
+	// CleanupInterval. Either at 0 disables eviction, and snapshots are only removed
+	// through RemoveSnapshots. Snapshots of tokens Subscriptions reports as subscribed are
+	// never evicted: the feed only sends them price_change updates, which are dropped
+	// without a snapshot to apply them to.
+	SnapshotTTL     time.Duration
+	CleanupInterval time.Duration
+	Subscriptions   SubscriptionChecker
+
+	// BookFetcher enables the REST fallback when set: every FallbackInterval (default 5s),
+	// books not updated for FallbackStaleAfter are refetched, stalest first and at most
+	// FallbackMaxFetches (default 10) per interval. This keeps detection alive while the
+	// websocket is down. FallbackStaleAfter must be positive for the fallback to run.
+	BookFetcher        BookFetcher
+	FallbackStaleAfter time.Duration
+	FallbackInterval   time.Duration
+	FallbackMaxFetches int
 }
 
 // New creates a new orderbook manager.
 func New(cfg *Config) *Manager {
+	bufferSize := cfg.UpdateBufferSize
+	if bufferSize <= 0 {
+		bufferSize = DefaultUpdateBufferSize
+	}
+
+	highWatermark := cfg.HighWatermark
+	if highWatermark <= 0 || highWatermark > 1 {
+		highWatermark = DefaultHighWatermark
+	}
+
+	// Always require at least one buffered update before signaling backpressure
+	watermark := int(float64(bufferSize) * highWatermark)
+	if watermark < 1 {
+		watermark = 1
+	}
+
+	fallbackInterval := cfg.FallbackInterval
+	if fallbackInterval <= 0 {
+		fallbackInterval = DefaultFallbackInterval
+	}
+
+	fallbackMaxFetches := cfg.FallbackMaxFetches
+	if fallbackMaxFetches <= 0 {
+		fallbackMaxFetches = DefaultFallbackMaxFetches
+	}
+
+	BackpressureActive.Set(0)
+
 	return &Manager{
-		books:      make(map[string]*types.OrderbookSnapshot),
-		logger:     cfg.Logger,
-		msgChan:    cfg.MessageChannel,
-		updateChan: make(chan *types.OrderbookSnapshot, 100000), // Buffer for high update rate
+		books:         make(map[string]*types.OrderbookSnapshot),
+		logger:        cfg.Logger,
+		msgChan:       cfg.MessageChannel,
+		updateChan:    make(chan *types.OrderbookSnapshot, bufferSize),
+		highWatermark: watermark,
+		cleanupEvery:  cfg.CleanupInterval,
+		snapshotTTL:   cfg.SnapshotTTL,
+		subscriptions: cfg.Subscriptions,
+
+		fetcher:            cfg.BookFetcher,
+		fallbackStaleAfter: cfg.FallbackStaleAfter,
+		fallbackInterval:   fallbackInterval,
+		fallbackMaxFetches: fallbackMaxFetches,
 	}
 }
 
@@ -47,9 +148,67 @@ func (m *Manager) Start(ctx context.Context) error {
 	m.wg.Add(1)
 	go m.processMessages()
 
+	if m.snapshotTTL > 0 && m.cleanupEvery > 0 {
+		m.wg.Add(1)
+		go m.evictionLoop()
+	}
+
+	if m.fetcher != nil && m.fallbackStaleAfter > 0 {
+		m.wg.Add(1)
+		go m.fallbackLoop()
+	}
+
 	return nil
 }
 
+// evictionLoop evicts stale snapshots every cleanup interval until the context ends.
+func (m *Manager) evictionLoop() {
+	defer m.wg.Done()
+
+	ticker := time.NewTicker(m.cleanupEvery)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-m.ctx.Done():
+			return
+		case <-ticker.C:
+			evicted := m.evictStale(time.Now().Add(-m.snapshotTTL))
+			if evicted > 0 {
+				m.logger.Info("orderbook-snapshots-evicted",
+					zap.Int("evicted", evicted),
+					zap.Duration("ttl", m.snapshotTTL))
+			}
+		}
+	}
+}
+
+// evictStale removes snapshots last updated before cutoff, except those of subscribed
+// tokens, and returns how many were removed.
+// The check and delete happen under the write lock, so a snapshot refreshed concurrently
+// is either seen as fresh or replaced by the handler's next write.
+func (m *Manager) evictStale(cutoff time.Time) (evicted int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for tokenID, snapshot := range m.books {
+		if !snapshot.LastUpdated.Before(cutoff) {
+			continue
+		}
+		if m.subscriptions != nil && m.subscriptions.IsSubscribed(tokenID) {
+			continue
+		}
+
+		delete(m.books, tokenID)
+		evicted++
+	}
+
+	SnapshotsEvictedTotal.Add(float64(evicted))
+	SnapshotsTracked.Set(float64(len(m.books)))
+
+	return evicted
+}
+
 // processMessages processes incoming orderbook messages.
 func (m *Manager) processMessages() {
 	defer m.wg.Done()
@@ -72,6 +231,10 @@ func (m *Manager) processMessages() {
 					m.logger.Debug("orderbook-empty",
 						zap.String("event-type", msg.EventType),
 						zap.String("asset-id", msg.AssetID))
+				} else if errors.Is(err, ErrCrossedBook) {
+					m.logger.Debug("orderbook-crossed-rejected",
+						zap.String("asset-id", msg.AssetID),
+						zap.Error(err))
 				} else {
 					m.logger.Warn("handle-message-error",
 						zap.Error(err),
@@ -83,6 +246,12 @@ func (m *Manager) processMessages() {
 	}
 }
 
+// ProcessMessage applies a single message synchronously, for callers that drive the
+// manager directly (e.g. backtest replay) instead of through Start.
+func (m *Manager) ProcessMessage(msg *types.OrderbookMessage) error {
+	return m.handleMessage(msg)
+}
+
 // handleMessage processes a single orderbook message.
 func (m *Manager) handleMessage(msg *types.OrderbookMessage) error {
 	timer := prometheus.NewTimer(UpdateProcessingDuration)
@@ -92,8 +261,10 @@ func (m *Manager) handleMessage(msg *types.OrderbookMessage) error {
 
 	switch msg.EventType {
 	case "book":
+		m.lastUpdate.Store(time.Now().UnixNano())
 		return m.handleBookMessage(msg)
 	case "price_change":
+		m.lastUpdate.Store(time.Now().UnixNano())
 		return m.handlePriceChangeMessage(msg)
 	default:
 		// Ignore other message types (last_trade_price, etc.)
@@ -114,6 +285,21 @@ func (m *Manager) handleBookMessage(msg *types.OrderbookMessage) error {
 		return fmt.Errorf("extract best ask: %w", err)
 	}
 
+	askDepth := parseAskDepth(msg.Asks)
+
+	// Reject crossed books rather than storing them: they can create fake arbitrage.
+	// Any previous snapshot is dropped too, since the feed no longer vouches for it.
+	if bestBidPrice >= bestAskPrice {
+		CrossedBookTotal.Inc()
+
+		m.mu.Lock()
+		delete(m.books, msg.AssetID)
+		SnapshotsTracked.Set(float64(len(m.books)))
+		m.mu.Unlock()
+
+		return fmt.Errorf("%w: bid %.4f >= ask %.4f", ErrCrossedBook, bestBidPrice, bestAskPrice)
+	}
+
 	snapshot := &types.OrderbookSnapshot{
 		MarketID:     msg.Market,
 		TokenID:      msg.AssetID,
@@ -121,7 +307,8 @@ func (m *Manager) handleBookMessage(msg *types.OrderbookMessage) error {
 		BestBidSize:  bestBidSize,
 		BestAskPrice: bestAskPrice,
 		BestAskSize:  bestAskSize,
-		LastUpdated:  time.UnixMilli(msg.Timestamp), // Use server timestamp for accurate latency tracking
+		AskDepth:     askDepth,
+		LastUpdated:  normalizeTimestamp(msg.Timestamp), // Use server timestamp for accurate latency tracking
 	}
 
 	// Track lock contention
@@ -138,26 +325,7 @@ func (m *Manager) handleBookMessage(msg *types.OrderbookMessage) error {
 		zap.Float64("best-bid", bestBidPrice),
 		zap.Float64("best-ask", bestAskPrice))
 
-	// Notify subscribers of update (non-blocking)
-	select {
-	case m.updateChan <- snapshot:
-		// Warn if channel is near capacity (90%)
-		buffered := len(m.updateChan)
-		capacity := cap(m.updateChan)
-		if buffered > capacity*9/10 {
-			m.logger.Warn("orderbook-update-channel-near-full",
-				zap.Int("buffered", buffered),
-				zap.Int("capacity", capacity),
-				zap.Float64("utilization", float64(buffered)/float64(capacity)*100))
-		}
-	default:
-		// Channel full, drop update
-		m.logger.Error("CRITICAL-orderbook-update-channel-full-DROPPING-DATA",
-			zap.String("token-id", msg.AssetID),
-			zap.Int("buffer-size", cap(m.updateChan)),
-			zap.String("action", "processing too slow or increase buffer"))
-		UpdatesDroppedTotal.WithLabelValues("channel_full").Inc()
-	}
+	m.notifyUpdate(snapshot)
 
 	return nil
 }
@@ -186,6 +354,8 @@ func (m *Manager) handlePriceChangeMessage(msg *types.OrderbookMessage) error {
 		}
 	}
 
+	changedAsk := parseAskChange(msg.Change)
+
 	// Only hold lock for map access and update
 	lockStart := time.Now()
 	m.mu.Lock()
@@ -210,14 +380,18 @@ func (m *Manager) handlePriceChangeMessage(msg *types.OrderbookMessage) error {
 
 	if hasAsk {
 		snapshot.BestAskPrice = bestAskPrice
+		snapshot.AskDepth = updateAskDepth(snapshot.AskDepth, changedAsk, bestAskPrice)
 		// Only update size if it's valid (> 0)
 		// price_change messages have size="0", so we preserve existing size
 		if bestAskSize > 0 {
 			snapshot.BestAskSize = bestAskSize
 		}
+		if len(snapshot.AskDepth) > 0 {
+			snapshot.BestAskSize = snapshot.AskDepth[0].Size // The ladder starts at the best ask
+		}
 	}
 
-	snapshot.LastUpdated = time.UnixMilli(msg.Timestamp) // Use server timestamp for accurate latency tracking
+	snapshot.LastUpdated = normalizeTimestamp(msg.Timestamp) // Use server timestamp for accurate latency tracking
 
 	// Unlock before logging and channel sends
 	m.mu.Unlock()
@@ -227,29 +401,71 @@ func (m *Manager) handlePriceChangeMessage(msg *types.OrderbookMessage) error {
 		zap.Float64("best-bid", snapshot.BestBidPrice),
 		zap.Float64("best-ask", snapshot.BestAskPrice))
 
-	// Notify subscribers of update (non-blocking)
 	snapshotCopy := *snapshot
+	m.notifyUpdate(&snapshotCopy)
+
+	return nil
+}
+
+// notifyUpdate publishes a snapshot to subscribers without blocking.
+// When the update channel is full the update is dropped and counted; consumers
+// re-read current state via GetSnapshot, so a dropped update only delays detection.
+func (m *Manager) notifyUpdate(snapshot *types.OrderbookSnapshot) {
 	select {
-	case m.updateChan <- &snapshotCopy:
-		// Warn if channel is near capacity (90%)
-		buffered := len(m.updateChan)
-		capacity := cap(m.updateChan)
-		if buffered > capacity*9/10 {
-			m.logger.Warn("orderbook-update-channel-near-full",
-				zap.Int("buffered", buffered),
-				zap.Int("capacity", capacity),
-				zap.Float64("utilization", float64(buffered)/float64(capacity)*100))
-		}
+	case m.updateChan <- snapshot:
 	default:
-		// Channel full, drop update
+		m.droppedUpdates.Add(1)
+		UpdatesDroppedTotal.WithLabelValues("channel_full").Inc()
 		m.logger.Error("CRITICAL-orderbook-update-channel-full-DROPPING-DATA",
-			zap.String("token-id", msg.AssetID),
+			zap.String("token-id", snapshot.TokenID),
 			zap.Int("buffer-size", cap(m.updateChan)),
 			zap.String("action", "processing too slow or increase buffer"))
-		UpdatesDroppedTotal.WithLabelValues("channel_full").Inc()
 	}
 
-	return nil
+	m.IsBackpressured()
+}
+
+// IsBackpressured reports whether the update channel is at or above the high
+// watermark. Consumers can use this to shed work (e.g. skip a scan cycle)
+// until the backlog drains. Recovery is reported as soon as utilization falls
+// back below the watermark.
+func (m *Manager) IsBackpressured() bool {
+	buffered := len(m.updateChan)
+	active := buffered >= m.highWatermark
+
+	if m.backpressured.CompareAndSwap(!active, active) {
+		if active {
+			BackpressureActive.Set(1)
+			BackpressureTransitionsTotal.WithLabelValues("enter").Inc()
+			m.logger.Warn("orderbook-backpressure-entered",
+				zap.Int("buffered", buffered),
+				zap.Int("high-watermark", m.highWatermark),
+				zap.Int("capacity", cap(m.updateChan)))
+		} else {
+			BackpressureActive.Set(0)
+			BackpressureTransitionsTotal.WithLabelValues("exit").Inc()
+			m.logger.Info("orderbook-backpressure-cleared",
+				zap.Int("buffered", buffered),
+				zap.Int("high-watermark", m.highWatermark))
+		}
+	}
+
+	return active
+}
+
+// LastUpdateTime returns when the last orderbook message was received (zero if none yet).
+// Uses local receive time, not the server timestamp, so it reflects feed liveness.
+func (m *Manager) LastUpdateTime() time.Time {
+	nanos := m.lastUpdate.Load()
+	if nanos == 0 {
+		return time.Time{}
+	}
+	return time.Unix(0, nanos)
+}
+
+// DroppedUpdates returns the number of updates dropped because the update channel was full.
+func (m *Manager) DroppedUpdates() uint64 {
+	return m.droppedUpdates.Load()
 }
 
 // extractBestLevel extracts the best (first) price level.
@@ -271,6 +487,102 @@ func extractBestLevel(levels []types.PriceLevel) (float64, float64, error) {
 	return price, size, nil
 }
 
+// parseAskDepth parses an ask ladder sorted best (lowest) price first. Unparseable and
+// empty levels are skipped.
+func parseAskDepth(levels []types.PriceLevel) []types.BookLevel {
+	depth := make([]types.BookLevel, 0, len(levels))
+	for _, level := range levels {
+		price, err := strconv.ParseFloat(level.Price, 64)
+		if err != nil {
+			continue
+		}
+		size, err := strconv.ParseFloat(level.Size, 64)
+		if err != nil || size <= 0 {
+			continue
+		}
+		depth = append(depth, types.BookLevel{Price: price, Size: size})
+	}
+
+	sort.Slice(depth, func(i, j int) bool { return depth[i].Price < depth[j].Price })
+	return depth
+}
+
+// parseAskChange returns the ask level a price_change sets, or nil if the change is
+// missing, on the bid side, or unparseable. A zero size removes the level.
+func parseAskChange(change *types.PriceChange) *types.BookLevel {
+	if change == nil || !strings.EqualFold(change.Side, "SELL") {
+		return nil
+	}
+
+	price, err := strconv.ParseFloat(change.Price, 64)
+	if err != nil {
+		return nil
+	}
+	size, err := strconv.ParseFloat(change.Size, 64)
+	if err != nil || size < 0 {
+		return nil
+	}
+
+	return &types.BookLevel{Price: price, Size: size}
+}
+
+// updateAskDepth applies a price_change to the ask ladder: the changed level is set
+// (or removed at size 0), then levels below the new best ask are trimmed. If the ladder
+// still doesn't start at the best ask, a change was missed and the ladder is dropped, so
+// consumers fall back to the best level only. Returns a new slice so copies handed out by
+// GetSnapshot are never mutated.
+func updateAskDepth(depth []types.BookLevel, changed *types.BookLevel, bestAsk float64) []types.BookLevel {
+	if changed != nil && depth != nil {
+		depth = setAskLevel(depth, *changed)
+	}
+
+	depth = trimAskDepth(depth, bestAsk)
+	if len(depth) == 0 || math.Abs(depth[0].Price-bestAsk) > levelPriceEpsilon {
+		return nil
+	}
+
+	return depth
+}
+
+// setAskLevel returns a copy of depth with the level at changed.Price replaced by changed,
+// inserted in price order, or removed when changed.Size is 0.
+func setAskLevel(depth []types.BookLevel, changed types.BookLevel) []types.BookLevel {
+	updated := make([]types.BookLevel, 0, len(depth)+1)
+	placed := false
+	for _, level := range depth {
+		if !placed && changed.Price <= level.Price+levelPriceEpsilon {
+			placed = true
+			if changed.Size > 0 {
+				updated = append(updated, changed)
+			}
+			if math.Abs(level.Price-changed.Price) <= levelPriceEpsilon {
+				continue // Replaced
+			}
+		}
+		updated = append(updated, level)
+	}
+
+	if !placed && changed.Size > 0 {
+		updated = append(updated, changed)
+	}
+
+	return updated
+}
+
+// trimAskDepth drops ladder levels priced below a new best ask. Returns a new slice so
+// copies handed out by GetSnapshot are never mutated.
+func trimAskDepth(depth []types.BookLevel, bestAsk float64) []types.BookLevel {
+	for i, level := range depth {
+		if level.Price >= bestAsk {
+			if i == 0 {
+				return depth
+			}
+			return append([]types.BookLevel(nil), depth[i:]...)
+		}
+	}
+	return nil
+}
+
 // GetSnapshot returns the orderbook snapshot for a token.
 func (m *Manager) GetSnapshot(tokenID string) (*types.OrderbookSnapshot, bool) {
 	m.mu.RLock()
@@ -301,6 +613,17 @@ func (m *Manager) GetAllSnapshots() map[string]*types.OrderbookSnapshot {
 	return snapshots
 }
 
+// RemoveSnapshots evicts the snapshots for the given tokens (e.g. when their market closes).
+func (m *Manager) RemoveSnapshots(tokenIDs []string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, tokenID := range tokenIDs {
+		delete(m.books, tokenID)
+	}
+	SnapshotsTracked.Set(float64(len(m.books)))
+}
+
 // UpdateChan returns the channel for receiving orderbook updates.
 func (m *Manager) UpdateChan() <-chan *types.OrderbookSnapshot {
 	return m.updateChan
@@ -2,11 +2,14 @@ package orderbook
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"sync"
 	"testing"
 	"time"
 
+	promtestutil "github.com/prometheus/client_golang/prometheus/testutil"
+
 	"github.com/mselser95/polymarket-arb/pkg/types"
 	"go.uber.org/zap"
 )
@@ -735,3 +738,337 @@ func TestManager_UpdateChannel_SlowConsumer(t *testing.T) {
 	cancel() // Must cancel context before Close() so goroutines can exit
 	mgr.Close()
 }
+
+// TestManager_HandleBookMessage_CrossedBook tests crossed books are rejected and not stored
+func TestManager_HandleBookMessage_CrossedBook(t *testing.T) {
+	mgr := New(&Config{
+		Logger:         zap.NewNop(),
+		MessageChannel: make(chan *types.OrderbookMessage),
+	})
+
+	// Valid book first, so we can verify a crossed update doesn't leave it behind
+	err := mgr.handleMessage(&types.OrderbookMessage{
+		EventType: "book",
+		AssetID:   "token1",
+		Market:    "market1",
+		Timestamp: time.Now().UnixMilli(),
+		Bids:      []types.PriceLevel{{Price: "0.50", Size: "100"}},
+		Asks:      []types.PriceLevel{{Price: "0.51", Size: "100"}},
+	})
+	if err != nil {
+		t.Fatalf("handle valid book: %v", err)
+	}
+	<-mgr.UpdateChan()
+
+	tests := []struct {
+		name string
+		bid  string
+		ask  string
+	}{
+		{name: "bid-above-ask", bid: "0.60", ask: "0.40"},
+		{name: "bid-equals-ask", bid: "0.50", ask: "0.50"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := mgr.handleMessage(&types.OrderbookMessage{
+				EventType: "book",
+				AssetID:   "token1",
+				Market:    "market1",
+				Timestamp: time.Now().UnixMilli(),
+				Bids:      []types.PriceLevel{{Price: tt.bid, Size: "100"}},
+				Asks:      []types.PriceLevel{{Price: tt.ask, Size: "100"}},
+			})
+			if !errors.Is(err, ErrCrossedBook) {
+				t.Fatalf("expected ErrCrossedBook, got %v", err)
+			}
+
+			_, exists := mgr.GetSnapshot("token1")
+			if exists {
+				t.Error("expected no snapshot for crossed book")
+			}
+
+			if len(mgr.UpdateChan()) != 0 {
+				t.Error("expected no update notification for crossed book")
+			}
+		})
+	}
+}
+
+// TestManager_Backpressure_WatermarkCrossingAndRecovery tests backpressure is signaled
+// at the high watermark and cleared once the consumer drains the channel
+func TestManager_Backpressure_WatermarkCrossingAndRecovery(t *testing.T) {
+	mgr := New(&Config{
+		Logger:           zap.NewNop(),
+		MessageChannel:   make(chan *types.OrderbookMessage),
+		UpdateBufferSize: 10,
+		HighWatermark:    0.5, // Backpressured at 5 buffered updates
+	})
+
+	sendBook := func(i int) {
+		err := mgr.handleMessage(&types.OrderbookMessage{
+			EventType: "book",
+			AssetID:   fmt.Sprintf("token%d", i),
+			Market:    "market1",
+			Timestamp: time.Now().UnixMilli(),
+			Bids:      []types.PriceLevel{{Price: "0.50", Size: "100"}},
+			Asks:      []types.PriceLevel{{Price: "0.51", Size: "100"}},
+		})
+		if err != nil {
+			t.Fatalf("handle message %d: %v", i, err)
+		}
+	}
+
+	for i := range 4 {
+		sendBook(i)
+	}
+
+	if mgr.IsBackpressured() {
+		t.Fatal("expected no backpressure below high watermark")
+	}
+
+	sendBook(4)
+
+	if !mgr.IsBackpressured() {
+		t.Fatal("expected backpressure at high watermark")
+	}
+
+	// Drain below the watermark
+	for range 2 {
+		<-mgr.UpdateChan()
+	}
+
+	if mgr.IsBackpressured() {
+		t.Fatal("expected backpressure to clear after draining below high watermark")
+	}
+
+	if mgr.DroppedUpdates() != 0 {
+		t.Errorf("expected 0 dropped updates, got %d", mgr.DroppedUpdates())
+	}
+}
+
+// TestManager_Backpressure_DropsCounted tests updates beyond channel capacity are dropped and counted
+func TestManager_Backpressure_DropsCounted(t *testing.T) {
+	mgr := New(&Config{
+		Logger:           zap.NewNop(),
+		MessageChannel:   make(chan *types.OrderbookMessage),
+		UpdateBufferSize: 3,
+	})
+
+	for i := range 5 {
+		err := mgr.handleMessage(&types.OrderbookMessage{
+			EventType: "book",
+			AssetID:   fmt.Sprintf("token%d", i),
+			Market:    "market1",
+			Timestamp: time.Now().UnixMilli(),
+			Bids:      []types.PriceLevel{{Price: "0.50", Size: "100"}},
+			Asks:      []types.PriceLevel{{Price: "0.51", Size: "100"}},
+		})
+		if err != nil {
+			t.Fatalf("handle message %d: %v", i, err)
+		}
+	}
+
+	if mgr.DroppedUpdates() != 2 {
+		t.Errorf("expected 2 dropped updates, got %d", mgr.DroppedUpdates())
+	}
+
+	if len(mgr.UpdateChan()) != 3 {
+		t.Errorf("expected 3 buffered updates, got %d", len(mgr.UpdateChan()))
+	}
+
+	if !mgr.IsBackpressured() {
+		t.Error("expected backpressure with full update channel")
+	}
+
+	// Snapshots are still stored even when the notification is dropped
+	if len(mgr.GetAllSnapshots()) != 5 {
+		t.Errorf("expected 5 snapshots, got %d", len(mgr.GetAllSnapshots()))
+	}
+}
+
+// seedBook stores a book snapshot for tokenID last updated at updatedAt.
+func seedBook(t *testing.T, mgr *Manager, tokenID string, updatedAt time.Time) {
+	t.Helper()
+
+	err := mgr.ProcessMessage(&types.OrderbookMessage{
+		EventType: "book",
+		AssetID:   tokenID,
+		Market:    "test-market",
+		Timestamp: updatedAt.UnixMilli(),
+		Bids:      []types.PriceLevel{{Price: "0.40", Size: "10"}},
+		Asks:      []types.PriceLevel{{Price: "0.45", Size: "10"}},
+	})
+	if err != nil {
+		t.Fatalf("seed book %s: %v", tokenID, err)
+	}
+}
+
+// TestManager_EvictStale tests that snapshots older than the cutoff are evicted and fresh
+// ones are kept, with both metrics updated.
+func TestManager_EvictStale(t *testing.T) {
+	mgr := New(&Config{Logger: zap.NewNop(), UpdateBufferSize: 10})
+
+	now := time.Now()
+	seedBook(t, mgr, "stale-1", now.Add(-2*time.Hour))
+	seedBook(t, mgr, "stale-2", now.Add(-31*time.Minute))
+	seedBook(t, mgr, "fresh", now.Add(-time.Minute))
+
+	evictedBefore := promtestutil.ToFloat64(SnapshotsEvictedTotal)
+
+	evicted := mgr.evictStale(now.Add(-30 * time.Minute))
+	if evicted != 2 {
+		t.Errorf("expected 2 evicted snapshots, got %d", evicted)
+	}
+
+	for _, tokenID := range []string{"stale-1", "stale-2"} {
+		if _, exists := mgr.GetSnapshot(tokenID); exists {
+			t.Errorf("expected %s to be evicted", tokenID)
+		}
+	}
+	if _, exists := mgr.GetSnapshot("fresh"); !exists {
+		t.Error("expected fresh snapshot to be retained")
+	}
+
+	if got := promtestutil.ToFloat64(SnapshotsEvictedTotal) - evictedBefore; got != 2 {
+		t.Errorf("expected evicted counter +2, got %.0f", got)
+	}
+	if got := promtestutil.ToFloat64(SnapshotsTracked); got != 1 {
+		t.Errorf("expected 1 tracked snapshot, got %.0f", got)
+	}
+}
+
+// subscribedTokens reports the tokens in the set as subscribed.
+type subscribedTokens map[string]bool
+
+func (s subscribedTokens) IsSubscribed(tokenID string) bool {
+	return s[tokenID]
+}
+
+// TestManager_EvictStale_KeepsSubscribed tests that stale snapshots of subscribed tokens
+// are kept, since their feed would never rebuild them.
+func TestManager_EvictStale_KeepsSubscribed(t *testing.T) {
+	mgr := New(&Config{
+		Logger:           zap.NewNop(),
+		UpdateBufferSize: 10,
+		Subscriptions:    subscribedTokens{"quiet": true},
+	})
+
+	now := time.Now()
+	seedBook(t, mgr, "quiet", now.Add(-2*time.Hour))
+	seedBook(t, mgr, "closed", now.Add(-2*time.Hour))
+
+	evicted := mgr.evictStale(now.Add(-30 * time.Minute))
+	if evicted != 1 {
+		t.Errorf("expected 1 evicted snapshot, got %d", evicted)
+	}
+	if _, exists := mgr.GetSnapshot("quiet"); !exists {
+		t.Error("expected the subscribed snapshot to be retained")
+	}
+	if _, exists := mgr.GetSnapshot("closed"); exists {
+		t.Error("expected the unsubscribed snapshot to be evicted")
+	}
+}
+
+// TestManager_EvictionLoop tests that a started manager evicts stale snapshots on the
+// cleanup interval, and never runs eviction without a TTL.
+func TestManager_EvictionLoop(t *testing.T) {
+	tests := []struct {
+		name      string
+		ttl       time.Duration
+		wantEvict bool
+	}{
+		{name: "ttl_set", ttl: 30 * time.Minute, wantEvict: true},
+		{name: "ttl_disabled", ttl: 0, wantEvict: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mgr := New(&Config{
+				Logger:           zap.NewNop(),
+				MessageChannel:   make(chan *types.OrderbookMessage),
+				UpdateBufferSize: 10,
+				SnapshotTTL:      tt.ttl,
+				CleanupInterval:  10 * time.Millisecond,
+			})
+
+			seedBook(t, mgr, "stale", time.Now().Add(-time.Hour))
+			seedBook(t, mgr, "fresh", time.Now())
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			if err := mgr.Start(ctx); err != nil {
+				t.Fatalf("start: %v", err)
+			}
+
+			// Wait for eviction, or for several intervals when none is expected
+			deadline := time.Now().Add(time.Second)
+			if !tt.wantEvict {
+				deadline = time.Now().Add(50 * time.Millisecond)
+			}
+			for time.Now().Before(deadline) {
+				if _, exists := mgr.GetSnapshot("stale"); !exists {
+					break
+				}
+				time.Sleep(10 * time.Millisecond)
+			}
+
+			_, staleExists := mgr.GetSnapshot("stale")
+			if staleExists == tt.wantEvict {
+				t.Errorf("expected stale snapshot evicted=%v, still present=%v", tt.wantEvict, staleExists)
+			}
+			if _, exists := mgr.GetSnapshot("fresh"); !exists {
+				t.Error("expected fresh snapshot to be retained")
+			}
+
+			cancel()
+			mgr.wg.Wait()
+		})
+	}
+}
+
+// TestManager_EvictStale_ConcurrentAccess tests eviction racing with writes and reads
+// (run with -race).
+func TestManager_EvictStale_ConcurrentAccess(t *testing.T) {
+	mgr := New(&Config{Logger: zap.NewNop(), UpdateBufferSize: 10})
+
+	var wg sync.WaitGroup
+	done := make(chan struct{})
+
+	for w := range 4 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; ; i++ {
+				select {
+				case <-done:
+					return
+				default:
+				}
+
+				tokenID := fmt.Sprintf("token-%d-%d", w, i%20)
+				// Alternate stale and fresh timestamps so eviction has work to do
+				updatedAt := time.Now()
+				if i%2 == 0 {
+					updatedAt = updatedAt.Add(-time.Hour)
+				}
+				seedBook(t, mgr, tokenID, updatedAt)
+				mgr.GetSnapshot(tokenID)
+				mgr.GetAllSnapshots()
+			}
+		}()
+	}
+
+	for range 200 {
+		mgr.evictStale(time.Now().Add(-30 * time.Minute))
+	}
+	close(done)
+	wg.Wait()
+
+	mgr.evictStale(time.Now().Add(-30 * time.Minute))
+	for tokenID, snapshot := range mgr.GetAllSnapshots() {
+		if time.Since(snapshot.LastUpdated) > 30*time.Minute {
+			t.Errorf("expected stale snapshot %s to be evicted", tokenID)
+		}
+	}
+}
@@ -192,3 +192,34 @@ func TestExtractBestLevel(t *testing.T) {
 		})
 	}
 }
+
+func TestRemoveSnapshots(t *testing.T) {
+	manager := New(&Config{Logger: zap.NewNop()})
+
+	for _, tokenID := range []string{"token-a", "token-b", "token-c"} {
+		err := manager.ProcessMessage(&types.OrderbookMessage{
+			EventType: "book",
+			AssetID:   tokenID,
+			Market:    "test-market",
+			Bids:      []types.PriceLevel{{Price: "0.40", Size: "10"}},
+			Asks:      []types.PriceLevel{{Price: "0.45", Size: "10"}},
+		})
+		if err != nil {
+			t.Fatalf("seed book %s: %v", tokenID, err)
+		}
+	}
+
+	manager.RemoveSnapshots([]string{"token-a", "token-b", "unknown"})
+
+	if _, exists := manager.GetSnapshot("token-a"); exists {
+		t.Error("expected token-a snapshot to be evicted")
+	}
+
+	if _, exists := manager.GetSnapshot("token-b"); exists {
+		t.Error("expected token-b snapshot to be evicted")
+	}
+
+	if _, exists := manager.GetSnapshot("token-c"); !exists {
+		t.Error("expected token-c snapshot to remain")
+	}
+}
@@ -21,6 +21,21 @@ var (
 		Help: "Number of orderbook snapshots tracked in memory",
 	})
 
+	// SnapshotsEvictedTotal tracks snapshots evicted for not being updated within the TTL.
+	SnapshotsEvictedTotal = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "polymarket_orderbook_snapshots_evicted_total",
+		Help: "Total number of orderbook snapshots evicted for exceeding the snapshot TTL",
+	})
+
+	// FallbackFetchesTotal tracks REST /book fetches for stale snapshots by result.
+	FallbackFetchesTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "polymarket_orderbook_rest_fallback_fetches_total",
+			Help: "Total number of REST /book fetches for stale orderbook snapshots",
+		},
+		[]string{"result"}, // success, error, rejected, rate_limited
+	)
+
 	// UpdatesDroppedTotal tracks orderbook updates dropped due to full channel.
 	UpdatesDroppedTotal = promauto.NewCounterVec(
 		prometheus.CounterOpts{
@@ -30,6 +45,27 @@ var (
 		[]string{"reason"},
 	)
 
+	// CrossedBookTotal tracks book snapshots rejected because best bid >= best ask.
+	CrossedBookTotal = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "polymarket_orderbook_crossed_book_total",
+		Help: "Total number of book snapshots rejected as crossed (best bid >= best ask)",
+	})
+
+	// BackpressureActive indicates whether the update channel is above its high watermark (1 = backpressured).
+	BackpressureActive = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "polymarket_orderbook_backpressure_active",
+		Help: "Whether the orderbook update channel is above its high watermark (1 = backpressured, 0 = normal)",
+	})
+
+	// BackpressureTransitionsTotal tracks backpressure state transitions by direction.
+	BackpressureTransitionsTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "polymarket_orderbook_backpressure_transitions_total",
+			Help: "Total number of orderbook backpressure state transitions",
+		},
+		[]string{"direction"}, // enter, exit
+	)
+
 	// UpdateProcessingDuration tracks orderbook update processing time.
 	UpdateProcessingDuration = promauto.NewHistogram(prometheus.HistogramOpts{
 		Name:    "polymarket_orderbook_update_processing_duration_seconds",
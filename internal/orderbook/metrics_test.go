@@ -18,6 +18,18 @@ func TestMetrics_Registration(t *testing.T) {
 		t.Error("UpdatesDroppedTotal not registered")
 	}
 
+	if CrossedBookTotal == nil {
+		t.Error("CrossedBookTotal not registered")
+	}
+
+	if BackpressureActive == nil {
+		t.Error("BackpressureActive not registered")
+	}
+
+	if BackpressureTransitionsTotal == nil {
+		t.Error("BackpressureTransitionsTotal not registered")
+	}
+
 	if UpdateProcessingDuration == nil {
 		t.Error("UpdateProcessingDuration not registered")
 	}
@@ -31,11 +43,14 @@ func TestMetrics_Registration(t *testing.T) {
 func TestMetrics_CounterIncrement(t *testing.T) {
 	UpdatesTotal.WithLabelValues("book").Inc()
 	UpdatesDroppedTotal.WithLabelValues("channel_full").Inc()
+	CrossedBookTotal.Inc()
 }
 
 // TestMetrics_GaugeSet tests gauge can be set
 func TestMetrics_GaugeSet(t *testing.T) {
 	SnapshotsTracked.Set(100)
+	BackpressureActive.Set(1)
+	BackpressureActive.Set(0)
 }
 
 // TestMetrics_HistogramObserve tests histogram can observe values
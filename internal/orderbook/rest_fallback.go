@@ -0,0 +1,185 @@
+package orderbook
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/mselser95/polymarket-arb/pkg/types"
+	"go.uber.org/zap"
+)
+
+const (
+	// DefaultFallbackInterval is how often stale snapshots are checked for a REST refresh.
+	DefaultFallbackInterval = 5 * time.Second
+
+	// DefaultFallbackMaxFetches is the default number of /book requests per interval.
+	DefaultFallbackMaxFetches = 10
+)
+
+// BookFetcher fetches a full orderbook for a token outside the websocket feed.
+// RESTBookFetcher implements this interface.
+type BookFetcher interface {
+	FetchBook(ctx context.Context, tokenID string) (*types.OrderbookMessage, error)
+}
+
+// RESTBookFetcher fetches books from the CLOB /book endpoint.
+type RESTBookFetcher struct {
+	baseURL    string
+	httpClient *http.Client
+}
+
+// NewRESTBookFetcher creates a fetcher for the CLOB API at baseURL.
+func NewRESTBookFetcher(baseURL string) *RESTBookFetcher {
+	return &RESTBookFetcher{
+		baseURL: baseURL,
+		httpClient: &http.Client{
+			Timeout: 10 * time.Second,
+		},
+	}
+}
+
+// FetchBook returns the current book for tokenID as a "book" message. The REST API lists
+// levels worst price first, so they are re-sorted best first to match the websocket feed.
+func (f *RESTBookFetcher) FetchBook(ctx context.Context, tokenID string) (*types.OrderbookMessage, error) {
+	endpoint := fmt.Sprintf("%s/book?token_id=%s", f.baseURL, url.QueryEscape(tokenID))
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, fmt.Errorf("create request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := f.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("do request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("unexpected status code %d: %s", resp.StatusCode, string(body))
+	}
+
+	var msg types.OrderbookMessage
+	err = json.NewDecoder(resp.Body).Decode(&msg)
+	if err != nil {
+		return nil, fmt.Errorf("decode book: %w", err)
+	}
+
+	msg.EventType = "book"
+	if msg.AssetID == "" {
+		msg.AssetID = tokenID
+	}
+	sortLevels(msg.Bids, true)
+	sortLevels(msg.Asks, false)
+
+	return &msg, nil
+}
+
+// sortLevels sorts price levels by price, descending for bids and ascending for asks.
+// Unparseable prices sort last and are rejected when the best level is extracted.
+func sortLevels(levels []types.PriceLevel, descending bool) {
+	sort.SliceStable(levels, func(i, j int) bool {
+		pi, errI := strconv.ParseFloat(levels[i].Price, 64)
+		pj, errJ := strconv.ParseFloat(levels[j].Price, 64)
+		if errI != nil || errJ != nil {
+			return errJ != nil && errI == nil
+		}
+		if descending {
+			return pi > pj
+		}
+		return pi < pj
+	})
+}
+
+// fallbackLoop refreshes stale snapshots over REST every fallback interval until the
+// context ends.
+func (m *Manager) fallbackLoop() {
+	defer m.wg.Done()
+
+	ticker := time.NewTicker(m.fallbackInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-m.ctx.Done():
+			return
+		case <-ticker.C:
+			refreshed := m.refreshStale(m.ctx, time.Now())
+			if refreshed > 0 {
+				m.logger.Info("orderbook-rest-fallback-refreshed",
+					zap.Int("refreshed", refreshed),
+					zap.Duration("stale-after", m.fallbackStaleAfter))
+			}
+		}
+	}
+}
+
+// refreshStale fetches the books of snapshots not updated since now - stale-after,
+// stalest first and at most fallbackMaxFetches of them, and applies each like a websocket
+// book message. Returns how many snapshots were refreshed.
+func (m *Manager) refreshStale(ctx context.Context, now time.Time) (refreshed int) {
+	cutoff := now.Add(-m.fallbackStaleAfter)
+
+	type staleToken struct {
+		tokenID     string
+		lastUpdated time.Time
+	}
+
+	m.mu.RLock()
+	var stale []staleToken
+	for tokenID, snapshot := range m.books {
+		if snapshot.LastUpdated.Before(cutoff) {
+			stale = append(stale, staleToken{tokenID: tokenID, lastUpdated: snapshot.LastUpdated})
+		}
+	}
+	m.mu.RUnlock()
+
+	if len(stale) == 0 {
+		return 0
+	}
+
+	sort.Slice(stale, func(i, j int) bool { return stale[i].lastUpdated.Before(stale[j].lastUpdated) })
+	if len(stale) > m.fallbackMaxFetches {
+		FallbackFetchesTotal.WithLabelValues("rate_limited").Add(float64(len(stale) - m.fallbackMaxFetches))
+		stale = stale[:m.fallbackMaxFetches]
+	}
+
+	for _, token := range stale {
+		msg, err := m.fetcher.FetchBook(ctx, token.tokenID)
+		if err != nil {
+			FallbackFetchesTotal.WithLabelValues("error").Inc()
+			m.logger.Warn("orderbook-rest-fallback-failed",
+				zap.String("token-id", token.tokenID),
+				zap.Error(err))
+			continue
+		}
+
+		// The book is current as of the request. Stamping it with the fetch time keeps an
+		// unchanged book from looking stale again and being refetched every interval.
+		msg.Timestamp = time.Now().UnixMilli()
+
+		// Applied directly rather than through handleMessage, so LastUpdateTime keeps
+		// reporting websocket liveness only.
+		err = m.handleBookMessage(msg)
+		if err != nil {
+			FallbackFetchesTotal.WithLabelValues("rejected").Inc()
+			m.logger.Debug("orderbook-rest-fallback-rejected",
+				zap.String("token-id", token.tokenID),
+				zap.Error(err))
+			continue
+		}
+
+		FallbackFetchesTotal.WithLabelValues("success").Inc()
+		refreshed++
+	}
+
+	return refreshed
+}
@@ -0,0 +1,250 @@
+package orderbook
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	promtestutil "github.com/prometheus/client_golang/prometheus/testutil"
+
+	"github.com/mselser95/polymarket-arb/pkg/types"
+	"go.uber.org/zap"
+)
+
+// mockBookServer serves /book like the CLOB API: levels worst price first and a
+// millisecond timestamp string. Requested token IDs are recorded in order.
+type mockBookServer struct {
+	*httptest.Server
+
+	mu        sync.Mutex
+	requested []string
+	status    int
+}
+
+func newMockBookServer(t *testing.T) *mockBookServer {
+	t.Helper()
+
+	m := &mockBookServer{status: http.StatusOK}
+	m.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/book" {
+			http.NotFound(w, r)
+			return
+		}
+
+		tokenID := r.URL.Query().Get("token_id")
+		m.mu.Lock()
+		m.requested = append(m.requested, tokenID)
+		status := m.status
+		m.mu.Unlock()
+
+		if status != http.StatusOK {
+			http.Error(w, "unavailable", status)
+			return
+		}
+
+		fmt.Fprintf(w, `{"market":"market-1","asset_id":%q,"timestamp":"1700000000000",`+
+			`"bids":[{"price":"0.30","size":"50"},{"price":"0.38","size":"20"}],`+
+			`"asks":[{"price":"0.60","size":"40"},{"price":"0.42","size":"15"},{"price":"0.45","size":"30"}]}`,
+			tokenID)
+	}))
+	t.Cleanup(m.Close)
+
+	return m
+}
+
+func (m *mockBookServer) setStatus(status int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.status = status
+}
+
+func (m *mockBookServer) requests() []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]string(nil), m.requested...)
+}
+
+func TestRESTBookFetcher_FetchBook(t *testing.T) {
+	server := newMockBookServer(t)
+
+	msg, err := NewRESTBookFetcher(server.URL).FetchBook(context.Background(), "token-1")
+	if err != nil {
+		t.Fatalf("FetchBook() error: %v", err)
+	}
+
+	if msg.EventType != "book" || msg.AssetID != "token-1" || msg.Market != "market-1" || msg.Timestamp != 1700000000000 {
+		t.Errorf("unexpected book message: %+v", msg)
+	}
+	if msg.Bids[0].Price != "0.38" || msg.Asks[0].Price != "0.42" || msg.Asks[2].Price != "0.60" {
+		t.Errorf("expected levels sorted best first, got bids %v asks %v", msg.Bids, msg.Asks)
+	}
+
+	server.setStatus(http.StatusServiceUnavailable)
+	_, err = NewRESTBookFetcher(server.URL).FetchBook(context.Background(), "token-1")
+	if err == nil {
+		t.Error("expected error for non-200 response")
+	}
+}
+
+// TestManager_RefreshStale tests that a stale snapshot is refetched over REST and
+// published to the detector, while fresh snapshots are left alone.
+func TestManager_RefreshStale(t *testing.T) {
+	server := newMockBookServer(t)
+	mgr := New(&Config{
+		Logger:             zap.NewNop(),
+		UpdateBufferSize:   10,
+		BookFetcher:        NewRESTBookFetcher(server.URL),
+		FallbackStaleAfter: time.Minute,
+	})
+
+	now := time.Now()
+	seedBook(t, mgr, "stale", now.Add(-5*time.Minute))
+	seedBook(t, mgr, "fresh", now.Add(-time.Second))
+	for len(mgr.updateChan) > 0 {
+		<-mgr.updateChan
+	}
+	lastUpdate := mgr.LastUpdateTime()
+	successBefore := promtestutil.ToFloat64(FallbackFetchesTotal.WithLabelValues("success"))
+
+	refreshed := mgr.refreshStale(context.Background(), now)
+	if refreshed != 1 {
+		t.Fatalf("expected 1 refreshed snapshot, got %d", refreshed)
+	}
+	if got := server.requests(); len(got) != 1 || got[0] != "stale" {
+		t.Errorf("expected only the stale token fetched, got %v", got)
+	}
+
+	snapshot, exists := mgr.GetSnapshot("stale")
+	if !exists {
+		t.Fatal("expected stale snapshot to be refreshed")
+	}
+	if snapshot.BestBidPrice != 0.38 || snapshot.BestAskPrice != 0.42 || len(snapshot.AskDepth) != 3 {
+		t.Errorf("expected REST book applied, got %+v", snapshot)
+	}
+	if snapshot.LastUpdated.Before(now.Add(-time.Second)) {
+		t.Errorf("expected refreshed snapshot stamped with fetch time, got %s", snapshot.LastUpdated)
+	}
+
+	select {
+	case update := <-mgr.UpdateChan():
+		if update.TokenID != "stale" {
+			t.Errorf("expected update for stale token, got %s", update.TokenID)
+		}
+	default:
+		t.Error("expected refreshed snapshot published to the update channel")
+	}
+
+	if !mgr.LastUpdateTime().Equal(lastUpdate) {
+		t.Error("expected REST refresh not to count as websocket activity")
+	}
+	if got := promtestutil.ToFloat64(FallbackFetchesTotal.WithLabelValues("success")) - successBefore; got != 1 {
+		t.Errorf("expected success counter +1, got %.0f", got)
+	}
+
+	// Now fresh, so the next pass fetches nothing
+	if refreshed = mgr.refreshStale(context.Background(), time.Now()); refreshed != 0 {
+		t.Errorf("expected nothing to refresh, got %d", refreshed)
+	}
+}
+
+// TestManager_RefreshStale_RateLimited tests that at most FallbackMaxFetches books are
+// fetched per pass, stalest first, and that failed fetches keep the old snapshot.
+func TestManager_RefreshStale_RateLimited(t *testing.T) {
+	server := newMockBookServer(t)
+	mgr := New(&Config{
+		Logger:             zap.NewNop(),
+		UpdateBufferSize:   10,
+		BookFetcher:        NewRESTBookFetcher(server.URL),
+		FallbackStaleAfter: time.Minute,
+		FallbackMaxFetches: 2,
+	})
+
+	now := time.Now()
+	seedBook(t, mgr, "stale-2m", now.Add(-2*time.Minute))
+	seedBook(t, mgr, "stale-1h", now.Add(-time.Hour))
+	seedBook(t, mgr, "stale-10m", now.Add(-10*time.Minute))
+
+	limitedBefore := promtestutil.ToFloat64(FallbackFetchesTotal.WithLabelValues("rate_limited"))
+
+	if refreshed := mgr.refreshStale(context.Background(), now); refreshed != 2 {
+		t.Fatalf("expected 2 refreshed snapshots, got %d", refreshed)
+	}
+	got := server.requests()
+	if len(got) != 2 || got[0] != "stale-1h" || got[1] != "stale-10m" {
+		t.Errorf("expected the two stalest tokens fetched, got %v", got)
+	}
+	if got := promtestutil.ToFloat64(FallbackFetchesTotal.WithLabelValues("rate_limited")) - limitedBefore; got != 1 {
+		t.Errorf("expected rate_limited counter +1, got %.0f", got)
+	}
+
+	server.setStatus(http.StatusInternalServerError)
+	if refreshed := mgr.refreshStale(context.Background(), now); refreshed != 0 {
+		t.Errorf("expected failed fetch to refresh nothing, got %d", refreshed)
+	}
+	snapshot, exists := mgr.GetSnapshot("stale-2m")
+	if !exists || snapshot.BestAskPrice != 0.45 {
+		t.Errorf("expected old snapshot kept after failed fetch, got %+v", snapshot)
+	}
+}
+
+// TestManager_FallbackLoop tests that a started manager refreshes stale books on the
+// fallback interval, and never fetches without a fetcher.
+func TestManager_FallbackLoop(t *testing.T) {
+	tests := []struct {
+		name        string
+		enabled     bool
+		wantRefresh bool
+	}{
+		{name: "enabled", enabled: true, wantRefresh: true},
+		{name: "disabled", enabled: false, wantRefresh: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newMockBookServer(t)
+
+			var fetcher BookFetcher
+			if tt.enabled {
+				fetcher = NewRESTBookFetcher(server.URL)
+			}
+			mgr := New(&Config{
+				Logger:             zap.NewNop(),
+				MessageChannel:     make(chan *types.OrderbookMessage),
+				UpdateBufferSize:   10,
+				BookFetcher:        fetcher,
+				FallbackStaleAfter: time.Minute,
+				FallbackInterval:   10 * time.Millisecond,
+			})
+
+			seedBook(t, mgr, "stale", time.Now().Add(-time.Hour))
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			if err := mgr.Start(ctx); err != nil {
+				t.Fatalf("start: %v", err)
+			}
+
+			// Wait for a refresh, or for several intervals when none is expected
+			deadline := time.Now().Add(time.Second)
+			if !tt.wantRefresh {
+				deadline = time.Now().Add(50 * time.Millisecond)
+			}
+			for time.Now().Before(deadline) && len(server.requests()) == 0 {
+				time.Sleep(10 * time.Millisecond)
+			}
+
+			cancel()
+			mgr.wg.Wait()
+
+			snapshot, _ := mgr.GetSnapshot("stale")
+			refreshed := snapshot.BestAskPrice == 0.42
+			if refreshed != tt.wantRefresh {
+				t.Errorf("expected refreshed=%v, got snapshot %+v", tt.wantRefresh, snapshot)
+			}
+		})
+	}
+}
@@ -0,0 +1,22 @@
+package orderbook
+
+import "time"
+
+// secondsCutoff separates second- and millisecond-scale Unix timestamps. As seconds it is
+// the year 5138, as milliseconds March 1973, so no real timestamp is ambiguous.
+const secondsCutoff = 100_000_000_000
+
+// normalizeTimestamp converts a Polymarket message timestamp to a time.Time. CLOB
+// messages carry Unix milliseconds, but some payloads use Unix seconds; the scale is
+// inferred from the magnitude. A missing (zero or negative) timestamp falls back to
+// receipt time so the snapshot does not look decades stale.
+func normalizeTimestamp(ts int64) time.Time {
+	switch {
+	case ts <= 0:
+		return time.Now()
+	case ts < secondsCutoff:
+		return time.Unix(ts, 0)
+	default:
+		return time.UnixMilli(ts)
+	}
+}
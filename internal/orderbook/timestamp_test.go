@@ -0,0 +1,122 @@
+package orderbook
+
+import (
+	"encoding/json"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/mselser95/polymarket-arb/pkg/types"
+	"go.uber.org/zap"
+)
+
+// TestNormalizeTimestamp tests that second- and millisecond-scale timestamps map to the same instant.
+func TestNormalizeTimestamp(t *testing.T) {
+	want := time.Date(2025, 10, 14, 12, 30, 45, 0, time.UTC)
+
+	tests := []struct {
+		name string
+		ts   int64
+		want time.Time
+	}{
+		{name: "milliseconds", ts: want.UnixMilli(), want: want},
+		{name: "milliseconds_sub_second", ts: want.UnixMilli() + 250, want: want.Add(250 * time.Millisecond)},
+		{name: "seconds", ts: want.Unix(), want: want},
+		{name: "seconds_2009", ts: 1234567890, want: time.Unix(1234567890, 0)},
+		{name: "milliseconds_2009", ts: 1234567890000, want: time.Unix(1234567890, 0)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := normalizeTimestamp(tt.ts); !got.Equal(tt.want) {
+				t.Errorf("normalizeTimestamp(%d) = %v, want %v", tt.ts, got, tt.want)
+			}
+		})
+	}
+}
+
+// TestNormalizeTimestamp_Missing tests that a missing timestamp falls back to receipt time.
+func TestNormalizeTimestamp_Missing(t *testing.T) {
+	before := time.Now()
+	got := normalizeTimestamp(0)
+
+	if got.Before(before) || time.Since(got) > time.Second {
+		t.Errorf("expected receipt time for missing timestamp, got %v", got)
+	}
+}
+
+// TestLastUpdated_ConsistentAcrossMessageTypes tests that book and price_change messages
+// yield the same LastUpdated whether the feed sends seconds or milliseconds.
+func TestLastUpdated_ConsistentAcrossMessageTypes(t *testing.T) {
+	sent := time.Now().Add(-2 * time.Second).Truncate(time.Second)
+
+	tests := []struct {
+		name      string
+		bookTS    int64
+		changeTS  int64
+		wantBook  time.Time
+		wantAfter time.Time
+	}{
+		{
+			name:      "milliseconds",
+			bookTS:    sent.UnixMilli(),
+			changeTS:  sent.UnixMilli() + 1500,
+			wantBook:  sent,
+			wantAfter: sent.Add(1500 * time.Millisecond),
+		},
+		{
+			name:      "seconds_price_change",
+			bookTS:    sent.UnixMilli(),
+			changeTS:  sent.Unix() + 1,
+			wantBook:  sent,
+			wantAfter: sent.Add(time.Second),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			manager := &Manager{
+				books:  make(map[string]*types.OrderbookSnapshot),
+				logger: zap.NewNop(),
+			}
+
+			var book types.OrderbookMessage
+			err := json.Unmarshal([]byte(`{"event_type":"book","asset_id":"token-1","market":"0xabc",
+				"bids":[{"price":"0.48","size":"100"}],"asks":[{"price":"0.52","size":"100"}],
+				"timestamp":"`+strconv.FormatInt(tt.bookTS, 10)+`"}`), &book)
+			if err != nil {
+				t.Fatalf("unmarshal book: %v", err)
+			}
+
+			err = manager.handleBookMessage(&book)
+			if err != nil {
+				t.Fatalf("handleBookMessage: %v", err)
+			}
+
+			snapshot, _ := manager.GetSnapshot("token-1")
+			if !snapshot.LastUpdated.Equal(tt.wantBook) {
+				t.Errorf("book: expected LastUpdated %v, got %v", tt.wantBook, snapshot.LastUpdated)
+			}
+
+			err = manager.handlePriceChangeMessage(&types.OrderbookMessage{
+				EventType: "price_change",
+				AssetID:   "token-1",
+				Market:    "0xabc",
+				Timestamp: tt.changeTS,
+				Bids:      []types.PriceLevel{{Price: "0.49", Size: "0"}},
+				Asks:      []types.PriceLevel{{Price: "0.51", Size: "0"}},
+			})
+			if err != nil {
+				t.Fatalf("handlePriceChangeMessage: %v", err)
+			}
+
+			snapshot, _ = manager.GetSnapshot("token-1")
+			if !snapshot.LastUpdated.Equal(tt.wantAfter) {
+				t.Errorf("price_change: expected LastUpdated %v, got %v", tt.wantAfter, snapshot.LastUpdated)
+			}
+			if age := time.Since(snapshot.LastUpdated); age < 0 || age > time.Minute {
+				t.Errorf("expected a recent update, got age %v", age)
+			}
+		})
+	}
+}
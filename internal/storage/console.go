@@ -30,6 +30,12 @@ func (c *ConsoleStorage) StoreOpportunity(ctx context.Context, opp *arbitrage.Op
 	fmt.Printf("Market:   %s\n", opp.MarketSlug)
 	fmt.Printf("Question: %s\n", opp.MarketQuestion)
 	fmt.Printf("Time:     %s\n", opp.DetectedAt.Format("2006-01-02 15:04:05"))
+	if opp.NegRisk {
+		fmt.Printf("Neg Risk: yes (settles via neg-risk exchange)\n")
+	}
+	if opp.LinkedGroup != "" {
+		fmt.Printf("Linked:   %s (synthetic set across markets)\n", opp.LinkedGroup)
+	}
 	fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
 	fmt.Printf("OUTCOMES (%d)\n", len(opp.Outcomes))
 
@@ -3,10 +3,14 @@ package storage
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
+	"errors"
 	"fmt"
 
 	_ "github.com/lib/pq"
 	"github.com/mselser95/polymarket-arb/internal/arbitrage"
+	"github.com/mselser95/polymarket-arb/pkg/tracing"
+	"github.com/mselser95/polymarket-arb/pkg/types"
 	"go.uber.org/zap"
 )
 
@@ -108,12 +112,97 @@ func (p *PostgresStorage) StoreOpportunity(ctx context.Context, opp *arbitrage.O
 
 	p.logger.Debug("opportunity-stored",
 		zap.String("opportunity-id", opp.ID),
+		tracing.Field(opp.TraceID),
 		zap.String("market-slug", opp.MarketSlug),
 		zap.Int("outcome-count", len(opp.Outcomes)))
 
 	return nil
 }
 
+// LoadExecutionState loads the saved executor state for mode.
+// Returns nil if no state has been saved for that mode yet.
+func (p *PostgresStorage) LoadExecutionState(ctx context.Context, mode string) (*types.ExecutionState, error) {
+	query := `
+		SELECT cumulative_profit, trade_counts, pending_trades, updated_at
+		FROM executor_state
+		WHERE mode = $1
+	`
+
+	state := &types.ExecutionState{Mode: mode}
+	var tradeCounts, pendingTrades []byte
+
+	err := p.db.QueryRowContext(ctx, query, mode).Scan(
+		&state.CumulativeProfit,
+		&tradeCounts,
+		&pendingTrades,
+		&state.UpdatedAt,
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("select executor state: %w", err)
+	}
+
+	err = json.Unmarshal(tradeCounts, &state.TradeCounts)
+	if err != nil {
+		return nil, fmt.Errorf("decode trade counts: %w", err)
+	}
+
+	err = json.Unmarshal(pendingTrades, &state.PendingTrades)
+	if err != nil {
+		return nil, fmt.Errorf("decode pending trades: %w", err)
+	}
+
+	return state, nil
+}
+
+// SaveExecutionState upserts the executor state for state.Mode.
+func (p *PostgresStorage) SaveExecutionState(ctx context.Context, state *types.ExecutionState) error {
+	tradeCounts, err := json.Marshal(state.TradeCounts)
+	if err != nil {
+		return fmt.Errorf("encode trade counts: %w", err)
+	}
+
+	pendingTrades := state.PendingTrades
+	if pendingTrades == nil {
+		pendingTrades = []types.PendingTrade{}
+	}
+
+	pendingJSON, err := json.Marshal(pendingTrades)
+	if err != nil {
+		return fmt.Errorf("encode pending trades: %w", err)
+	}
+
+	query := `
+		INSERT INTO executor_state (mode, cumulative_profit, trade_counts, pending_trades, updated_at)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (mode) DO UPDATE SET
+			cumulative_profit = EXCLUDED.cumulative_profit,
+			trade_counts = EXCLUDED.trade_counts,
+			pending_trades = EXCLUDED.pending_trades,
+			updated_at = EXCLUDED.updated_at
+	`
+
+	_, err = p.db.ExecContext(ctx, query,
+		state.Mode,
+		state.CumulativeProfit,
+		tradeCounts,
+		pendingJSON,
+		state.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("upsert executor state: %w", err)
+	}
+
+	p.logger.Debug("executor-state-saved",
+		zap.String("mode", state.Mode),
+		zap.Float64("cumulative-profit-usd", state.CumulativeProfit),
+		zap.Int("pending-trades", len(state.PendingTrades)))
+
+	return nil
+}
+
 // Close closes the database connection.
 func (p *PostgresStorage) Close() error {
 	p.logger.Info("closing-postgres-storage")
@@ -0,0 +1,576 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/mselser95/polymarket-arb/internal/arbitrage"
+	"github.com/mselser95/polymarket-arb/pkg/tracing"
+	"github.com/mselser95/polymarket-arb/pkg/types"
+	"go.uber.org/zap"
+	_ "modernc.org/sqlite" // Pure Go driver, so the CGO_ENABLED=0 build keeps working
+)
+
+// sqliteMigrations are applied in order on open. The schema version is kept in
+// PRAGMA user_version, so migration N runs only on databases below version N.
+// Append new migrations; never edit applied ones. Times are Unix nanoseconds.
+//
+//nolint:gochecknoglobals // Static schema definition
+var sqliteMigrations = []string{
+	// 1: opportunities, execution results and executor state
+	`
+	CREATE TABLE opportunities (
+		id               TEXT PRIMARY KEY,
+		market_id        TEXT NOT NULL,
+		market_slug      TEXT NOT NULL,
+		market_question  TEXT NOT NULL,
+		detected_at      INTEGER NOT NULL,
+		outcomes         TEXT NOT NULL,
+		price_sum        REAL NOT NULL,
+		profit_margin    REAL NOT NULL,
+		profit_bps       INTEGER NOT NULL,
+		max_trade_size   REAL NOT NULL,
+		estimated_profit REAL NOT NULL,
+		total_fees       REAL NOT NULL,
+		net_profit       REAL NOT NULL,
+		net_profit_bps   INTEGER NOT NULL,
+		config_threshold REAL NOT NULL,
+		neg_risk         INTEGER NOT NULL,
+		linked_group     TEXT NOT NULL
+	);
+	CREATE INDEX idx_opportunities_detected_at ON opportunities(detected_at);
+	CREATE INDEX idx_opportunities_market_detected_at ON opportunities(market_id, detected_at);
+
+	CREATE TABLE execution_results (
+		id              INTEGER PRIMARY KEY AUTOINCREMENT,
+		opportunity_id  TEXT NOT NULL,
+		market_slug     TEXT NOT NULL,
+		executed_at     INTEGER NOT NULL,
+		success         INTEGER NOT NULL,
+		error           TEXT NOT NULL,
+		realized_profit REAL NOT NULL,
+		expected_profit REAL NOT NULL,
+		notional        REAL NOT NULL,
+		order_ids       TEXT NOT NULL,
+		trades          TEXT NOT NULL
+	);
+	CREATE INDEX idx_execution_results_executed_at ON execution_results(executed_at);
+	CREATE INDEX idx_execution_results_market_executed_at ON execution_results(market_slug, executed_at);
+	CREATE INDEX idx_execution_results_opportunity_id ON execution_results(opportunity_id);
+
+	CREATE TABLE executor_state (
+		mode              TEXT PRIMARY KEY,
+		cumulative_profit REAL NOT NULL,
+		trade_counts      TEXT NOT NULL,
+		pending_trades    TEXT NOT NULL,
+		updated_at        INTEGER NOT NULL
+	);
+	`,
+
+	// 2: profit breakdown and fill verification time of execution results (0 = not verified)
+	`
+	ALTER TABLE execution_results ADD COLUMN gross_profit REAL NOT NULL DEFAULT 0;
+	ALTER TABLE execution_results ADD COLUMN total_fees REAL NOT NULL DEFAULT 0;
+	ALTER TABLE execution_results ADD COLUMN slippage REAL NOT NULL DEFAULT 0;
+	ALTER TABLE execution_results ADD COLUMN verified_at INTEGER NOT NULL DEFAULT 0;
+	`,
+}
+
+// SQLiteStorage implements Storage in a local SQLite file. It also persists execution
+// results and executor state, and answers time-range queries for analysis.
+type SQLiteStorage struct {
+	db     *sql.DB
+	logger *zap.Logger
+}
+
+// SQLiteConfig holds SQLite configuration.
+type SQLiteConfig struct {
+	Path   string // Database file, created if missing (":memory:" for a throwaway database)
+	Logger *zap.Logger
+}
+
+// OpportunityQuery selects stored opportunities. Zero fields don't filter.
+type OpportunityQuery struct {
+	MarketID string
+	From     time.Time // Inclusive
+	To       time.Time // Exclusive
+	Limit    int
+}
+
+// ExecutionResultQuery selects stored execution results. Zero fields don't filter.
+type ExecutionResultQuery struct {
+	MarketSlug string
+	From       time.Time // Inclusive
+	To         time.Time // Exclusive
+	Limit      int
+}
+
+// NewSQLiteStorage opens the database at cfg.Path and migrates it to the latest schema.
+func NewSQLiteStorage(cfg *SQLiteConfig) (*SQLiteStorage, error) {
+	if cfg.Path == "" {
+		return nil, errors.New("path cannot be empty")
+	}
+
+	// WAL lets readers (export, analysis) run while the bot writes; the busy timeout
+	// covers brief lock contention between them.
+	dsn := cfg.Path + "?_pragma=busy_timeout(5000)&_pragma=journal_mode(WAL)"
+
+	db, err := sql.Open("sqlite", dsn)
+	if err != nil {
+		return nil, fmt.Errorf("open database: %w", err)
+	}
+
+	// SQLite allows one writer; a single connection also keeps ":memory:" databases shared
+	db.SetMaxOpenConns(1)
+
+	s := &SQLiteStorage{
+		db:     db,
+		logger: cfg.Logger,
+	}
+
+	version, err := s.migrate(context.Background())
+	if err != nil {
+		_ = db.Close()
+		return nil, err
+	}
+
+	cfg.Logger.Info("sqlite-storage-opened",
+		zap.String("path", cfg.Path),
+		zap.Int("schema-version", version))
+
+	return s, nil
+}
+
+// migrate applies pending migrations, each in its own transaction, and returns the
+// resulting schema version.
+func (s *SQLiteStorage) migrate(ctx context.Context) (version int, err error) {
+	version, err = s.schemaVersion(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	for version < len(sqliteMigrations) {
+		next := version + 1
+
+		tx, err := s.db.BeginTx(ctx, nil)
+		if err != nil {
+			return version, fmt.Errorf("begin migration %d: %w", next, err)
+		}
+
+		_, err = tx.ExecContext(ctx, sqliteMigrations[version])
+		if err == nil {
+			// PRAGMA doesn't take bind parameters; next is an int
+			_, err = tx.ExecContext(ctx, fmt.Sprintf("PRAGMA user_version = %d", next))
+		}
+		if err != nil {
+			_ = tx.Rollback()
+			return version, fmt.Errorf("apply migration %d: %w", next, err)
+		}
+
+		err = tx.Commit()
+		if err != nil {
+			return version, fmt.Errorf("commit migration %d: %w", next, err)
+		}
+
+		s.logger.Info("sqlite-migration-applied", zap.Int("schema-version", next))
+		version = next
+	}
+
+	return version, nil
+}
+
+// schemaVersion returns the database's applied migration count.
+func (s *SQLiteStorage) schemaVersion(ctx context.Context) (version int, err error) {
+	err = s.db.QueryRowContext(ctx, "PRAGMA user_version").Scan(&version)
+	if err != nil {
+		return 0, fmt.Errorf("read schema version: %w", err)
+	}
+
+	return version, nil
+}
+
+// StoreOpportunity stores an arbitrage opportunity with all of its outcomes.
+func (s *SQLiteStorage) StoreOpportunity(ctx context.Context, opp *arbitrage.Opportunity) error {
+	outcomes, err := json.Marshal(opp.Outcomes)
+	if err != nil {
+		return fmt.Errorf("encode outcomes: %w", err)
+	}
+
+	query := `
+		INSERT INTO opportunities (
+			id, market_id, market_slug, market_question, detected_at, outcomes,
+			price_sum, profit_margin, profit_bps, max_trade_size,
+			estimated_profit, total_fees, net_profit, net_profit_bps,
+			config_threshold, neg_risk, linked_group
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`
+
+	_, err = s.db.ExecContext(ctx, query,
+		opp.ID,
+		opp.MarketID,
+		opp.MarketSlug,
+		opp.MarketQuestion,
+		opp.DetectedAt.UnixNano(),
+		string(outcomes),
+		opp.TotalPriceSum,
+		opp.ProfitMargin,
+		opp.ProfitBPS,
+		opp.MaxTradeSize,
+		opp.EstimatedProfit,
+		opp.TotalFees,
+		opp.NetProfit,
+		opp.NetProfitBPS,
+		opp.ConfigMaxPriceSum,
+		opp.NegRisk,
+		opp.LinkedGroup,
+	)
+	if err != nil {
+		return fmt.Errorf("insert opportunity: %w", err)
+	}
+
+	s.logger.Debug("opportunity-stored",
+		zap.String("opportunity-id", opp.ID),
+		tracing.Field(opp.TraceID),
+		zap.String("market-slug", opp.MarketSlug),
+		zap.Int("outcome-count", len(opp.Outcomes)))
+
+	return nil
+}
+
+// QueryOpportunities returns stored opportunities matching q, oldest first.
+func (s *SQLiteStorage) QueryOpportunities(ctx context.Context, q OpportunityQuery) ([]*arbitrage.Opportunity, error) {
+	var where []string
+	var args []any
+	if q.MarketID != "" {
+		where = append(where, "market_id = ?")
+		args = append(args, q.MarketID)
+	}
+	where, args = appendTimeRange(where, args, "detected_at", q.From, q.To)
+
+	query := `
+		SELECT id, market_id, market_slug, market_question, detected_at, outcomes,
+			price_sum, profit_margin, profit_bps, max_trade_size,
+			estimated_profit, total_fees, net_profit, net_profit_bps,
+			config_threshold, neg_risk, linked_group
+		FROM opportunities` + whereClause(where) + `
+		ORDER BY detected_at` + limitClause(q.Limit)
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("select opportunities: %w", err)
+	}
+	defer rows.Close()
+
+	var opps []*arbitrage.Opportunity
+	for rows.Next() {
+		opp := &arbitrage.Opportunity{}
+		var detectedAt int64
+		var outcomes string
+
+		err = rows.Scan(
+			&opp.ID,
+			&opp.MarketID,
+			&opp.MarketSlug,
+			&opp.MarketQuestion,
+			&detectedAt,
+			&outcomes,
+			&opp.TotalPriceSum,
+			&opp.ProfitMargin,
+			&opp.ProfitBPS,
+			&opp.MaxTradeSize,
+			&opp.EstimatedProfit,
+			&opp.TotalFees,
+			&opp.NetProfit,
+			&opp.NetProfitBPS,
+			&opp.ConfigMaxPriceSum,
+			&opp.NegRisk,
+			&opp.LinkedGroup,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("scan opportunity: %w", err)
+		}
+
+		opp.DetectedAt = time.Unix(0, detectedAt)
+
+		err = json.Unmarshal([]byte(outcomes), &opp.Outcomes)
+		if err != nil {
+			return nil, fmt.Errorf("decode outcomes of %s: %w", opp.ID, err)
+		}
+
+		opps = append(opps, opp)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return nil, fmt.Errorf("read opportunities: %w", err)
+	}
+
+	return opps, nil
+}
+
+// StoreExecutionResult stores the outcome of executing an opportunity.
+func (s *SQLiteStorage) StoreExecutionResult(ctx context.Context, result *types.ExecutionResult) error {
+	orderIDs := result.OrderIDs
+	if orderIDs == nil {
+		orderIDs = []string{}
+	}
+
+	orderIDsJSON, err := json.Marshal(orderIDs)
+	if err != nil {
+		return fmt.Errorf("encode order IDs: %w", err)
+	}
+
+	trades := result.AllTrades
+	if trades == nil {
+		trades = []*types.Trade{}
+	}
+
+	tradesJSON, err := json.Marshal(trades)
+	if err != nil {
+		return fmt.Errorf("encode trades: %w", err)
+	}
+
+	var errMsg string
+	if result.Error != nil {
+		errMsg = result.Error.Error()
+	}
+
+	query := `
+		INSERT INTO execution_results (
+			opportunity_id, market_slug, executed_at, success, error,
+			realized_profit, expected_profit, notional, order_ids, trades,
+			gross_profit, total_fees, slippage, verified_at
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`
+
+	var verifiedAt int64
+	if !result.VerifiedAt.IsZero() {
+		verifiedAt = result.VerifiedAt.UnixNano()
+	}
+
+	_, err = s.db.ExecContext(ctx, query,
+		result.OpportunityID,
+		result.MarketSlug,
+		result.ExecutedAt.UnixNano(),
+		result.Success,
+		errMsg,
+		result.RealizedProfit,
+		result.ExpectedProfit,
+		result.Notional,
+		string(orderIDsJSON),
+		string(tradesJSON),
+		result.GrossProfit,
+		result.TotalFees,
+		result.Slippage,
+		verifiedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("insert execution result: %w", err)
+	}
+
+	return nil
+}
+
+// QueryExecutionResults returns stored execution results matching q, oldest first.
+func (s *SQLiteStorage) QueryExecutionResults(ctx context.Context, q ExecutionResultQuery) ([]*types.ExecutionResult, error) {
+	var where []string
+	var args []any
+	if q.MarketSlug != "" {
+		where = append(where, "market_slug = ?")
+		args = append(args, q.MarketSlug)
+	}
+	where, args = appendTimeRange(where, args, "executed_at", q.From, q.To)
+
+	query := `
+		SELECT opportunity_id, market_slug, executed_at, success, error,
+			realized_profit, expected_profit, notional, order_ids, trades,
+			gross_profit, total_fees, slippage, verified_at
+		FROM execution_results` + whereClause(where) + `
+		ORDER BY executed_at, id` + limitClause(q.Limit)
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("select execution results: %w", err)
+	}
+	defer rows.Close()
+
+	var results []*types.ExecutionResult
+	for rows.Next() {
+		result := &types.ExecutionResult{}
+		var executedAt, verifiedAt int64
+		var errMsg, orderIDs, trades string
+
+		err = rows.Scan(
+			&result.OpportunityID,
+			&result.MarketSlug,
+			&executedAt,
+			&result.Success,
+			&errMsg,
+			&result.RealizedProfit,
+			&result.ExpectedProfit,
+			&result.Notional,
+			&orderIDs,
+			&trades,
+			&result.GrossProfit,
+			&result.TotalFees,
+			&result.Slippage,
+			&verifiedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("scan execution result: %w", err)
+		}
+
+		result.ExecutedAt = time.Unix(0, executedAt)
+		if verifiedAt != 0 {
+			result.VerifiedAt = time.Unix(0, verifiedAt)
+		}
+		if errMsg != "" {
+			result.Error = errors.New(errMsg)
+		}
+
+		err = json.Unmarshal([]byte(orderIDs), &result.OrderIDs)
+		if err != nil {
+			return nil, fmt.Errorf("decode order IDs: %w", err)
+		}
+
+		err = json.Unmarshal([]byte(trades), &result.AllTrades)
+		if err != nil {
+			return nil, fmt.Errorf("decode trades: %w", err)
+		}
+
+		results = append(results, result)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return nil, fmt.Errorf("read execution results: %w", err)
+	}
+
+	return results, nil
+}
+
+// LoadExecutionState loads the saved executor state for mode.
+// Returns nil if no state has been saved for that mode yet.
+func (s *SQLiteStorage) LoadExecutionState(ctx context.Context, mode string) (*types.ExecutionState, error) {
+	query := `
+		SELECT cumulative_profit, trade_counts, pending_trades, updated_at
+		FROM executor_state
+		WHERE mode = ?
+	`
+
+	state := &types.ExecutionState{Mode: mode}
+	var tradeCounts, pendingTrades string
+	var updatedAt int64
+
+	err := s.db.QueryRowContext(ctx, query, mode).Scan(
+		&state.CumulativeProfit,
+		&tradeCounts,
+		&pendingTrades,
+		&updatedAt,
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("select executor state: %w", err)
+	}
+
+	state.UpdatedAt = time.Unix(0, updatedAt)
+
+	err = json.Unmarshal([]byte(tradeCounts), &state.TradeCounts)
+	if err != nil {
+		return nil, fmt.Errorf("decode trade counts: %w", err)
+	}
+
+	err = json.Unmarshal([]byte(pendingTrades), &state.PendingTrades)
+	if err != nil {
+		return nil, fmt.Errorf("decode pending trades: %w", err)
+	}
+
+	return state, nil
+}
+
+// SaveExecutionState upserts the executor state for state.Mode.
+func (s *SQLiteStorage) SaveExecutionState(ctx context.Context, state *types.ExecutionState) error {
+	tradeCounts, err := json.Marshal(state.TradeCounts)
+	if err != nil {
+		return fmt.Errorf("encode trade counts: %w", err)
+	}
+
+	pendingTrades := state.PendingTrades
+	if pendingTrades == nil {
+		pendingTrades = []types.PendingTrade{}
+	}
+
+	pendingJSON, err := json.Marshal(pendingTrades)
+	if err != nil {
+		return fmt.Errorf("encode pending trades: %w", err)
+	}
+
+	query := `
+		INSERT INTO executor_state (mode, cumulative_profit, trade_counts, pending_trades, updated_at)
+		VALUES (?, ?, ?, ?, ?)
+		ON CONFLICT (mode) DO UPDATE SET
+			cumulative_profit = excluded.cumulative_profit,
+			trade_counts = excluded.trade_counts,
+			pending_trades = excluded.pending_trades,
+			updated_at = excluded.updated_at
+	`
+
+	_, err = s.db.ExecContext(ctx, query,
+		state.Mode,
+		state.CumulativeProfit,
+		string(tradeCounts),
+		string(pendingJSON),
+		state.UpdatedAt.UnixNano(),
+	)
+	if err != nil {
+		return fmt.Errorf("upsert executor state: %w", err)
+	}
+
+	s.logger.Debug("executor-state-saved",
+		zap.String("mode", state.Mode),
+		zap.Float64("cumulative-profit-usd", state.CumulativeProfit),
+		zap.Int("pending-trades", len(state.PendingTrades)))
+
+	return nil
+}
+
+// Close closes the database.
+func (s *SQLiteStorage) Close() error {
+	s.logger.Info("closing-sqlite-storage")
+	return s.db.Close()
+}
+
+// appendTimeRange adds [from, to) conditions on column for non-zero bounds.
+func appendTimeRange(where []string, args []any, column string, from, to time.Time) ([]string, []any) {
+	if !from.IsZero() {
+		where = append(where, column+" >= ?")
+		args = append(args, from.UnixNano())
+	}
+	if !to.IsZero() {
+		where = append(where, column+" < ?")
+		args = append(args, to.UnixNano())
+	}
+
+	return where, args
+}
+
+func whereClause(conditions []string) string {
+	if len(conditions) == 0 {
+		return ""
+	}
+	return " WHERE " + strings.Join(conditions, " AND ")
+}
+
+func limitClause(limit int) string {
+	if limit <= 0 {
+		return ""
+	}
+	return fmt.Sprintf(" LIMIT %d", limit)
+}
@@ -0,0 +1,298 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/mselser95/polymarket-arb/internal/arbitrage"
+	"github.com/mselser95/polymarket-arb/pkg/types"
+	"go.uber.org/zap"
+)
+
+func newTestSQLiteStorage(t *testing.T, path string) *SQLiteStorage {
+	t.Helper()
+
+	s, err := NewSQLiteStorage(&SQLiteConfig{Path: path, Logger: zap.NewNop()})
+	if err != nil {
+		t.Fatalf("NewSQLiteStorage() error: %v", err)
+	}
+	t.Cleanup(func() { _ = s.Close() })
+
+	return s
+}
+
+func TestNewSQLiteStorage_EmptyPath(t *testing.T) {
+	_, err := NewSQLiteStorage(&SQLiteConfig{Logger: zap.NewNop()})
+	if err == nil {
+		t.Fatal("expected error for empty path")
+	}
+}
+
+// TestSQLiteStorage_MigrationIdempotent tests that reopening a database, or migrating an
+// up-to-date one again, applies nothing and keeps stored data.
+func TestSQLiteStorage_MigrationIdempotent(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "arb.db")
+	ctx := context.Background()
+
+	first, err := NewSQLiteStorage(&SQLiteConfig{Path: path, Logger: zap.NewNop()})
+	if err != nil {
+		t.Fatalf("first open error: %v", err)
+	}
+
+	err = first.StoreOpportunity(ctx, arbitrage.CreateTestOpportunity("market-1", "slug-1"))
+	if err != nil {
+		t.Fatalf("StoreOpportunity() error: %v", err)
+	}
+
+	version, err := first.migrate(ctx)
+	if err != nil {
+		t.Fatalf("second migrate error: %v", err)
+	}
+	if version != len(sqliteMigrations) {
+		t.Errorf("expected schema version %d, got %d", len(sqliteMigrations), version)
+	}
+
+	err = first.Close()
+	if err != nil {
+		t.Fatalf("Close() error: %v", err)
+	}
+
+	second := newTestSQLiteStorage(t, path)
+
+	version, err = second.schemaVersion(ctx)
+	if err != nil {
+		t.Fatalf("schemaVersion() error: %v", err)
+	}
+	if version != len(sqliteMigrations) {
+		t.Errorf("expected schema version %d after reopen, got %d", len(sqliteMigrations), version)
+	}
+
+	opps, err := second.QueryOpportunities(ctx, OpportunityQuery{})
+	if err != nil {
+		t.Fatalf("QueryOpportunities() error: %v", err)
+	}
+	if len(opps) != 1 {
+		t.Errorf("expected the stored opportunity to survive reopening, got %d", len(opps))
+	}
+}
+
+// TestSQLiteStorage_QueryOpportunities tests that stored opportunities round-trip and are
+// filtered by market and by [From, To) time range, oldest first.
+func TestSQLiteStorage_QueryOpportunities(t *testing.T) {
+	s := newTestSQLiteStorage(t, filepath.Join(t.TempDir(), "arb.db"))
+	ctx := context.Background()
+
+	base := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	stored := []struct {
+		id       string
+		marketID string
+		offset   time.Duration
+	}{
+		{id: "opp-1", marketID: "market-a", offset: 0},
+		{id: "opp-2", marketID: "market-b", offset: time.Minute},
+		{id: "opp-3", marketID: "market-a", offset: 2 * time.Minute},
+		{id: "opp-4", marketID: "market-a", offset: time.Hour},
+	}
+	for _, st := range stored {
+		opp := arbitrage.CreateTestOpportunity(st.marketID, st.marketID+"-slug")
+		opp.ID = st.id
+		opp.DetectedAt = base.Add(st.offset)
+		opp.NegRisk = st.id == "opp-3"
+
+		err := s.StoreOpportunity(ctx, opp)
+		if err != nil {
+			t.Fatalf("StoreOpportunity(%s) error: %v", st.id, err)
+		}
+	}
+
+	tests := []struct {
+		name    string
+		query   OpportunityQuery
+		wantIDs []string
+	}{
+		{name: "all", query: OpportunityQuery{}, wantIDs: []string{"opp-1", "opp-2", "opp-3", "opp-4"}},
+		{name: "market", query: OpportunityQuery{MarketID: "market-a"}, wantIDs: []string{"opp-1", "opp-3", "opp-4"}},
+		{
+			name:    "time_range_excludes_end",
+			query:   OpportunityQuery{From: base.Add(time.Minute), To: base.Add(time.Hour)},
+			wantIDs: []string{"opp-2", "opp-3"},
+		},
+		{
+			name:    "market_and_time_range",
+			query:   OpportunityQuery{MarketID: "market-a", From: base.Add(time.Second)},
+			wantIDs: []string{"opp-3", "opp-4"},
+		},
+		{name: "limit", query: OpportunityQuery{Limit: 2}, wantIDs: []string{"opp-1", "opp-2"}},
+		{name: "none", query: OpportunityQuery{From: base.Add(24 * time.Hour)}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opps, err := s.QueryOpportunities(ctx, tt.query)
+			if err != nil {
+				t.Fatalf("QueryOpportunities() error: %v", err)
+			}
+
+			var ids []string
+			for _, opp := range opps {
+				ids = append(ids, opp.ID)
+			}
+			if len(ids) != len(tt.wantIDs) {
+				t.Fatalf("expected %v, got %v", tt.wantIDs, ids)
+			}
+			for i := range ids {
+				if ids[i] != tt.wantIDs[i] {
+					t.Fatalf("expected %v, got %v", tt.wantIDs, ids)
+				}
+			}
+		})
+	}
+
+	opps, err := s.QueryOpportunities(ctx, OpportunityQuery{MarketID: "market-a", Limit: 2})
+	if err != nil {
+		t.Fatalf("QueryOpportunities() error: %v", err)
+	}
+	want := arbitrage.CreateTestOpportunity("market-a", "market-a-slug")
+	got := opps[1]
+	if !got.DetectedAt.Equal(base.Add(2*time.Minute)) || !got.NegRisk || got.NetProfit != want.NetProfit ||
+		got.MarketQuestion != want.MarketQuestion || got.ConfigMaxPriceSum != want.ConfigMaxPriceSum {
+		t.Errorf("opportunity fields not round-tripped: %+v", got)
+	}
+	if len(got.Outcomes) != len(want.Outcomes) || got.Outcomes[1].TokenID != want.Outcomes[1].TokenID ||
+		got.Outcomes[1].AskPrice != want.Outcomes[1].AskPrice {
+		t.Errorf("expected outcomes %+v, got %+v", want.Outcomes, got.Outcomes)
+	}
+}
+
+// TestSQLiteStorage_QueryExecutionResults tests that execution results round-trip,
+// including errors, and are filtered by market and time range.
+func TestSQLiteStorage_QueryExecutionResults(t *testing.T) {
+	s := newTestSQLiteStorage(t, filepath.Join(t.TempDir(), "arb.db"))
+	ctx := context.Background()
+
+	base := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	results := []*types.ExecutionResult{
+		{
+			OpportunityID:  "opp-1",
+			MarketSlug:     "slug-a",
+			ExecutedAt:     base,
+			Success:        true,
+			RealizedProfit: 1.25,
+			ExpectedProfit: 1.5,
+			Notional:       98.5,
+			GrossProfit:    2.0,
+			TotalFees:      0.5,
+			Slippage:       0.25,
+			VerifiedAt:     base.Add(time.Second),
+			OrderIDs:       []string{"order-1", "order-2"},
+			AllTrades: []*types.Trade{
+				{TokenID: "yes", Outcome: "YES", Side: "BUY", Price: 0.48, Size: 100, Timestamp: base},
+				{TokenID: "no", Outcome: "NO", Side: "BUY", Price: 0.51, Size: 100, Timestamp: base},
+			},
+		},
+		{
+			OpportunityID: "opp-2",
+			MarketSlug:    "slug-b",
+			ExecutedAt:    base.Add(time.Minute),
+			Error:         errors.New("order rejected: not enough balance"),
+		},
+	}
+	for _, result := range results {
+		err := s.StoreExecutionResult(ctx, result)
+		if err != nil {
+			t.Fatalf("StoreExecutionResult(%s) error: %v", result.OpportunityID, err)
+		}
+	}
+
+	all, err := s.QueryExecutionResults(ctx, ExecutionResultQuery{})
+	if err != nil {
+		t.Fatalf("QueryExecutionResults() error: %v", err)
+	}
+	if len(all) != 2 {
+		t.Fatalf("expected 2 results, got %d", len(all))
+	}
+
+	got := all[0]
+	if got.OpportunityID != "opp-1" || !got.Success || got.Error != nil || got.RealizedProfit != 1.25 ||
+		got.ExpectedProfit != 1.5 || got.Notional != 98.5 || !got.ExecutedAt.Equal(base) {
+		t.Errorf("result fields not round-tripped: %+v", got)
+	}
+	if got.GrossProfit != 2.0 || got.TotalFees != 0.5 || got.Slippage != 0.25 ||
+		!got.VerifiedAt.Equal(base.Add(time.Second)) {
+		t.Errorf("profit breakdown not round-tripped: %+v", got)
+	}
+	if len(got.OrderIDs) != 2 || got.OrderIDs[1] != "order-2" {
+		t.Errorf("expected order IDs [order-1 order-2], got %v", got.OrderIDs)
+	}
+	if len(got.AllTrades) != 2 || got.AllTrades[1].Price != 0.51 || !got.AllTrades[1].Timestamp.Equal(base) {
+		t.Errorf("trades not round-tripped: %+v", got.AllTrades)
+	}
+
+	failed := all[1]
+	if failed.Success || failed.Error == nil || failed.Error.Error() != "order rejected: not enough balance" {
+		t.Errorf("expected failed result with error, got %+v", failed)
+	}
+	if !failed.VerifiedAt.IsZero() {
+		t.Errorf("expected unverified result, got VerifiedAt %s", failed.VerifiedAt)
+	}
+
+	bySlug, err := s.QueryExecutionResults(ctx, ExecutionResultQuery{MarketSlug: "slug-b"})
+	if err != nil {
+		t.Fatalf("QueryExecutionResults() error: %v", err)
+	}
+	if len(bySlug) != 1 || bySlug[0].OpportunityID != "opp-2" {
+		t.Errorf("expected only opp-2 for slug-b, got %+v", bySlug)
+	}
+
+	byTime, err := s.QueryExecutionResults(ctx, ExecutionResultQuery{To: base.Add(time.Minute)})
+	if err != nil {
+		t.Fatalf("QueryExecutionResults() error: %v", err)
+	}
+	if len(byTime) != 1 || byTime[0].OpportunityID != "opp-1" {
+		t.Errorf("expected only opp-1 before %s, got %+v", base.Add(time.Minute), byTime)
+	}
+}
+
+// TestSQLiteStorage_ExecutionState tests that executor state is saved, replaced and
+// loaded per mode.
+func TestSQLiteStorage_ExecutionState(t *testing.T) {
+	s := newTestSQLiteStorage(t, filepath.Join(t.TempDir(), "arb.db"))
+	ctx := context.Background()
+
+	state, err := s.LoadExecutionState(ctx, "paper")
+	if err != nil || state != nil {
+		t.Fatalf("expected no saved state, got %+v (err %v)", state, err)
+	}
+
+	savedAt := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	for _, profit := range []float64{1.5, 4.25} {
+		err = s.SaveExecutionState(ctx, &types.ExecutionState{
+			Mode:             "paper",
+			CumulativeProfit: profit,
+			TradeCounts:      map[string]int{"YES": 3, "NO": 3},
+			UpdatedAt:        savedAt,
+		})
+		if err != nil {
+			t.Fatalf("SaveExecutionState() error: %v", err)
+		}
+	}
+
+	state, err = s.LoadExecutionState(ctx, "paper")
+	if err != nil {
+		t.Fatalf("LoadExecutionState() error: %v", err)
+	}
+	if state.CumulativeProfit != 4.25 || state.TradeCounts["YES"] != 3 || !state.UpdatedAt.Equal(savedAt) {
+		t.Errorf("expected latest state, got %+v", state)
+	}
+	if state.PendingTrades == nil || len(state.PendingTrades) != 0 {
+		t.Errorf("expected empty pending trades, got %v", state.PendingTrades)
+	}
+
+	other, err := s.LoadExecutionState(ctx, "live")
+	if err != nil || other != nil {
+		t.Errorf("expected no live state, got %+v (err %v)", other, err)
+	}
+}
@@ -3,12 +3,15 @@ package storage
 import (
 	"bytes"
 	"context"
+	"database/sql"
 	"io"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/mselser95/polymarket-arb/internal/arbitrage"
+	"github.com/mselser95/polymarket-arb/pkg/types"
 	"go.uber.org/zap"
 )
 
@@ -314,3 +317,94 @@ func TestStorage_Interface(t *testing.T) {
 
 	var _ Storage = &PostgresStorage{db: db, logger: logger}
 }
+
+func TestPostgresStorage_SaveExecutionState(t *testing.T) {
+	logger, _ := zap.NewDevelopment()
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create sqlmock: %v", err)
+	}
+	defer db.Close()
+
+	storage := &PostgresStorage{
+		db:     db,
+		logger: logger,
+	}
+
+	state := &types.ExecutionState{
+		Mode:             "live",
+		CumulativeProfit: 12.5,
+		TradeCounts:      map[string]int{"YES": 3},
+		UpdatedAt:        time.Now(),
+	}
+
+	// Nil pending trades are stored as an empty JSON array
+	mock.ExpectExec("INSERT INTO executor_state").
+		WithArgs("live", 12.5, []byte(`{"YES":3}`), []byte(`[]`), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	err = storage.SaveExecutionState(context.Background(), state)
+	if err != nil {
+		t.Errorf("expected no error, got %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unfulfilled expectations: %v", err)
+	}
+}
+
+func TestPostgresStorage_LoadExecutionState(t *testing.T) {
+	logger, _ := zap.NewDevelopment()
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create sqlmock: %v", err)
+	}
+	defer db.Close()
+
+	storage := &PostgresStorage{
+		db:     db,
+		logger: logger,
+	}
+
+	savedAt := time.Now()
+	mock.ExpectQuery("SELECT cumulative_profit").
+		WithArgs("live").
+		WillReturnRows(sqlmock.NewRows([]string{"cumulative_profit", "trade_counts", "pending_trades", "updated_at"}).
+			AddRow(12.5, []byte(`{"YES":3,"NO":3}`),
+				[]byte(`[{"opportunity_id":"opp-1","order_ids":["0xabc","0xdef"],"outcomes":["YES","NO"]}]`), savedAt))
+
+	state, err := storage.LoadExecutionState(context.Background(), "live")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if state.Mode != "live" || state.CumulativeProfit != 12.5 {
+		t.Errorf("unexpected state: %+v", state)
+	}
+	if state.TradeCounts["YES"] != 3 || state.TradeCounts["NO"] != 3 {
+		t.Errorf("unexpected trade counts: %v", state.TradeCounts)
+	}
+	if len(state.PendingTrades) != 1 || len(state.PendingTrades[0].OrderIDs) != 2 ||
+		state.PendingTrades[0].OrderIDs[0] != "0xabc" {
+		t.Errorf("unexpected pending trades: %+v", state.PendingTrades)
+	}
+
+	// No saved state yet
+	mock.ExpectQuery("SELECT cumulative_profit").
+		WithArgs("paper").
+		WillReturnError(sql.ErrNoRows)
+
+	state, err = storage.LoadExecutionState(context.Background(), "paper")
+	if err != nil {
+		t.Errorf("expected no error for missing state, got %v", err)
+	}
+	if state != nil {
+		t.Errorf("expected nil state, got %+v", state)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unfulfilled expectations: %v", err)
+	}
+}
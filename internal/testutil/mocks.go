@@ -183,11 +183,13 @@ func (m *MockWebSocket) Close() {
 
 // MockWalletClient is a mock implementation of wallet.Client for testing.
 type MockWalletClient struct {
-	mu             sync.Mutex
-	balances       *wallet.Balances
-	positions      []*wallet.Position
-	getBalancesErr error
-	getPositionsErr error
+	mu                 sync.Mutex
+	balances           *wallet.Balances
+	erc20Balances      map[common.Address]*big.Int // key: token address
+	positions          []*wallet.Position
+	getBalancesErr     error
+	getERC20BalanceErr error
+	getPositionsErr    error
 }
 
 // NewMockWalletClient creates a new mock wallet client.
@@ -198,7 +200,8 @@ func NewMockWalletClient() (client *MockWalletClient) {
 			USDC:          big.NewInt(0),
 			USDCAllowance: big.NewInt(0),
 		},
-		positions: make([]*wallet.Position, 0),
+		erc20Balances: make(map[common.Address]*big.Int),
+		positions:     make([]*wallet.Position, 0),
 	}
 }
 
@@ -219,6 +222,23 @@ func (m *MockWalletClient) GetBalances(ctx context.Context, address common.Addre
 	}, nil
 }
 
+// GetERC20Balance returns the configured mock balance for a token (zero if unset).
+func (m *MockWalletClient) GetERC20Balance(ctx context.Context, token common.Address, holder common.Address) (balance *big.Int, err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.getERC20BalanceErr != nil {
+		return nil, m.getERC20BalanceErr
+	}
+
+	tokenBalance, exists := m.erc20Balances[token]
+	if !exists {
+		return big.NewInt(0), nil
+	}
+
+	return new(big.Int).Set(tokenBalance), nil
+}
+
 // GetPositions returns the configured mock positions.
 func (m *MockWalletClient) GetPositions(ctx context.Context, address common.Address) (positions []*wallet.Position, err error) {
 	m.mu.Lock()
@@ -254,6 +274,14 @@ func (m *MockWalletClient) SetUSDCBalance(usdc *big.Int) {
 	m.balances.USDC = usdc
 }
 
+// SetERC20Balance sets the balance returned by GetERC20Balance for a token.
+func (m *MockWalletClient) SetERC20Balance(token common.Address, balance *big.Int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.erc20Balances[token] = balance
+}
+
 // SetPositions sets the mock positions that will be returned.
 func (m *MockWalletClient) SetPositions(positions []*wallet.Position) {
 	m.mu.Lock()
@@ -270,6 +298,14 @@ func (m *MockWalletClient) SetGetBalancesError(err error) {
 	m.getBalancesErr = err
 }
 
+// SetGetERC20BalanceError sets an error to be returned by GetERC20Balance.
+func (m *MockWalletClient) SetGetERC20BalanceError(err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.getERC20BalanceErr = err
+}
+
 // SetGetPositionsError sets an error to be returned by GetPositions.
 func (m *MockWalletClient) SetGetPositionsError(err error) {
 	m.mu.Lock()
@@ -284,6 +320,7 @@ func (m *MockWalletClient) ResetErrors() {
 	defer m.mu.Unlock()
 
 	m.getBalancesErr = nil
+	m.getERC20BalanceErr = nil
 	m.getPositionsErr = nil
 }
 
@@ -0,0 +1,8 @@
+-- Create executor_state table (one row per execution mode)
+CREATE TABLE IF NOT EXISTS executor_state (
+    mode VARCHAR(16) PRIMARY KEY,
+    cumulative_profit DECIMAL(18, 8) NOT NULL,
+    trade_counts JSONB NOT NULL DEFAULT '{}',
+    pending_order_ids JSONB NOT NULL DEFAULT '[]',
+    updated_at TIMESTAMP NOT NULL
+);
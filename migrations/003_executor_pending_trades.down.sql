@@ -0,0 +1,2 @@
+ALTER TABLE executor_state ADD COLUMN IF NOT EXISTS pending_order_ids JSONB NOT NULL DEFAULT '[]';
+ALTER TABLE executor_state DROP COLUMN IF EXISTS pending_trades;
@@ -0,0 +1,4 @@
+-- Track pending live trades as order sets so restart reconciliation can tell which
+-- orders hedge each other. Replaces the flat pending_order_ids list.
+ALTER TABLE executor_state ADD COLUMN IF NOT EXISTS pending_trades JSONB NOT NULL DEFAULT '[]';
+ALTER TABLE executor_state DROP COLUMN IF EXISTS pending_order_ids;
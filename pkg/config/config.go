@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"strconv"
+	"strings"
 	"time"
 )
 
@@ -14,20 +15,40 @@ type Config struct {
 	LogLevel string
 	HTTPPort string
 
+	// Admin API
+	AdminToken string // Shared secret for /admin endpoints (empty = admin API disabled)
+
+	// Health
+	HealthMaxUpdateAge time.Duration // /readyz fails if no orderbook update within this window (0 = disabled)
+
+	// Startup warmup: /readyz fails and detection is deferred until both hold
+	WarmupPeriod          time.Duration // Minimum time after startup (0 = none)
+	WarmupMinBookFraction float64       // Fraction of subscribed markets with a book for every outcome (0 = none)
+
+	// Status line logging
+	StatusReportEnabled  bool          // Log a periodic one-line status summary
+	StatusReportInterval time.Duration // Time between status lines
+
 	// Polymarket API
 	PolymarketWSURL      string
 	PolymarketGammaURL   string
 	PolymarketAPIKey     string
 	PolymarketSecret     string
 	PolymarketPassphrase string
+	PolymarketChainID    int64 // EIP-712 signing domain chain (137 = Polygon, 80002 = Amoy)
 
 	// Market Discovery
-	DiscoveryPollInterval time.Duration
-	DiscoveryMarketLimit  int
-	MaxMarketDuration     time.Duration // Only subscribe to markets expiring within this duration
+	DiscoveryPollInterval      time.Duration
+	DiscoveryPollJitter        float64 // Randomize poll interval by ±fraction (0.1 = ±10%, 0 = fixed)
+	DiscoveryMarketLimit       int
+	DiscoveryFetchMaxAttempts  int           // Gamma fetch attempts per poll, including the first (1 = no retry)
+	DiscoveryFetchRetryBackoff time.Duration // Wait before the first fetch retry, doubling per attempt
+	DiscoveryFetchBudget       time.Duration // Time allowed for paging through Gamma per poll (0 = unlimited)
+	MaxMarketDuration          time.Duration // Only subscribe to markets expiring within this duration
+	MinMarketDuration          time.Duration // Skip markets expiring sooner than this duration
 
 	// Market Cleanup
-	CleanupInterval time.Duration // How often cleanup command checks for stale markets
+	CleanupInterval time.Duration // How often discovery tears down expired markets
 
 	// WebSocket
 	WSPoolSize              int // Number of WebSocket connections (default: 20)
@@ -37,26 +58,117 @@ type Config struct {
 	WSReconnectInitialDelay time.Duration
 	WSReconnectMaxDelay     time.Duration
 	WSReconnectBackoffMult  float64
+	WSReconnectMaxAttempts  int           // Failed reconnects before a connection gives up (0 = retry forever)
+	WSReconnectMaxDowntime  time.Duration // Downtime before a connection gives up (0 = retry forever)
 	WSMessageBufferSize     int
+	WSResubscribeBatchSize  int           // Max tokens per resubscribe frame after reconnect
+	WSResubscribeBatchDelay time.Duration // Delay between resubscribe frames
+	WSMaxMessageSizeMB      int           // Per-message read limit; larger frames drop the connection (0 = default 10MB)
+	WSRecordPath            string        // Record raw WS messages to rotating files (empty = disabled)
+	WSRecordCompress        bool          // Gzip recording files
+	WSRecordMaxFileSizeMB   int           // Rotate recording files after this many uncompressed MB
+
+	// WebSocket message channel utilization
+	WSChannelWarnThreshold     float64       // Utilization (0-1] above which forwarding logs a warning
+	WSChannelCriticalThreshold float64       // Utilization (0-1] above which forwarding logs an error
+	WSUtilizationInterval      time.Duration // How often per-connection channel utilization is sampled
+
+	// Orderbook
+	OrderbookUpdateBufferSize int           // Capacity of the orderbook -> detector update channel
+	OrderbookHighWatermark    float64       // Channel utilization (0-1] at which backpressure is signaled
+	OrderbookSnapshotTTL      time.Duration // Evict snapshots not updated for this long (0 = never)
+
+	// Orderbook - REST fallback for books the websocket has not updated
+	OrderbookRESTFallback           bool          // Refetch stale books from the CLOB /book endpoint
+	OrderbookRESTFallbackStaleAfter time.Duration // Book age at which it is refetched
+	OrderbookRESTFallbackInterval   time.Duration // How often stale books are checked
+	OrderbookRESTFallbackMaxFetches int           // Max /book requests per interval
 
 	// Arbitrage Detection
-	ArbMaxPriceSum       float64 // Maximum acceptable YES + NO price sum (lower = stricter)
-	ArbMinTradeSize      float64
-	ArbMaxTradeSize      float64
-	ArbDetectionInterval time.Duration
-	ArbMakerFee          float64
-	ArbTakerFee          float64
+	ArbMaxPriceSum         float64 // Maximum acceptable YES + NO price sum (lower = stricter)
+	ArbMinTradeSize        float64
+	ArbMaxTradeSize        float64
+	ArbDetectionInterval   time.Duration
+	ArbMakerFee            float64 // Flat fee model maker rate (negative = rebate)
+	ArbTakerFee            float64
+	ArbFeeModel            string  // "flat" (ARB_TAKER_FEE/ARB_MAKER_FEE) or "tiered" (ARB_FEE_TIERS)
+	ArbFeeTiers            string  // Tiered fee model brackets: "minNotional:takerRate:makerRate,..."
+	ArbMinProfitUSD        float64 // Minimum net profit in USD after fees (0 = disabled)
+	ArbDetectorConcurrency int     // Workers evaluating markets in parallel (1 = serial)
+	ArbMaxOutcomes         int     // Skip markets with more outcomes than this (0 = unlimited)
+	ArbMinAskLiquidityUSD  float64 // Minimum total ask-side USDC across outcomes (0 = disabled)
+	ArbLinkedMarkets       string  // Linked market groups: "name=marketID:outcome,marketID:outcome;..." ("" = none)
+	ArbLimitPrices         bool    // Cap order prices so a set never costs more than ARB_MAX_PRICE_SUM after aggression
+	ArbOpportunityBuffer   int     // Opportunities buffered for the executor; the oldest is dropped when full
+	ArbLogRejections       bool    // Log rejected opportunities with their reason at info instead of debug
+
+	// Arbitrage - Neg-risk tail
+	ArbTailPriceFloor float64 // Skip neg-risk outcomes asking less than this; the rest isn't a complete set (0 = disabled)
 
 	// Execution
 	ExecutionMode            string
 	ExecutionMaxPositionSize float64
+	ExecutionMaxOpenExposure float64 // Max USD notional awaiting fill verification across live trades (0 = unlimited)
+	ExecutionStrictTickSize  bool    // Reject orders whose tick size couldn't be resolved
+	ExecutionStrictOrderHash bool    // Fail placements whose API order ID differs from the local EIP-712 hash
+	ExecutionMaxReprices     int     // Resubmissions at fresh prices after a stale-price rejection (0 = disabled)
+	ExecutionMaxBatchSize    int     // Orders per CLOB batch request; larger sets are split into sub-batches
+	ExecutionSortByTokenID   bool    // Submit multi-outcome batches in ascending token ID order
+	ExecutionRealisticFills  bool    // Paper fills walk the current ask ladder instead of filling at the detected ask
+	ExecutionClockSkewSync   bool    // Adopt server time and retry when a signed request's timestamp is rejected
+	ExecutionSelfTradeMode   string  // Open orders on target tokens: "off", "cancel" them first, or "skip" the opportunity
+	ExecutionRoundingPolicy  string  // "directional" (size down, USD amount up) or "nearest"
+	ExecutionAllowanceCheck  string  // USDC allowance check on live start: "off", "warn", "block", or "approve"
+	ExecutionMinAllowanceUSD float64 // Allowance required on live start (0 = EXECUTION_MAX_POSITION_SIZE)
+
+	// Execution - Per-market cooldown
+	ExecutionMarketCooldown time.Duration // Skip a market's opportunities for this long after executing it (0 = disabled)
+
+	// Execution - Rejection handling (live only)
+	ExecutionRejectionCooldown time.Duration // Skip a market this long after an order rejection that retrying won't fix (0 = disabled)
+
+	// Execution - Complete set monitor (live only)
+	ExecutionCompleteSetInterval    time.Duration // How often held positions are checked for complete sets (0 = disabled)
+	ExecutionCompleteSetMinSellEdge float64       // USD per set the best bids must exceed $1 by to prefer selling over redeeming
+
+	// Execution - Resolution monitor (live only)
+	ExecutionRedeemInterval time.Duration // How often held positions are checked for resolved conditions to redeem (0 = disabled)
 
 	// Execution - Fill Verification
+	ExecutionPricingStrategy  string        // "ask" (cross with aggression), or "bid"/"mid" for maker orders
 	ExecutionAggressionTicks  int           // Ticks above ask to place order
+	ExecutionAggressionMode   string        // "ticks" or "spread_fraction"
+	ExecutionAggressionSpread float64       // Fraction of the bid-ask spread above ask (spread_fraction mode)
 	ExecutionFillTimeout      time.Duration // Max wait for 100% fill
 	ExecutionFillRetryInitial time.Duration // Initial backoff for fill queries
 	ExecutionFillRetryMax     time.Duration // Max backoff between queries
 	ExecutionFillRetryMult    float64       // Exponential backoff multiplier
+	ExecutionFillRetryJitter  float64       // Random fraction added to each fill-query backoff
+	ExecutionFillMaxAttempts  int           // Fill-query rounds before giving up (0 = unlimited)
+	ExecutionFillGracePeriod  time.Duration // Extra time past fill timeout before verification is abandoned
+	ExecutionDrainTimeout     time.Duration // Max wait on shutdown for in-flight fill verifications (0 = don't wait)
+
+	// Execution - Fill verification concurrency
+	ExecutionFillMaxConcurrent int // Fill verifications polling at once; the rest wait for a slot (0 = default)
+
+	// Execution - Profit reporting
+	ExecutionProfitReportDecimals int // Decimal places profit is reported with in logs and stats (0 = default 6)
+
+	// Execution - Opportunity Queue
+	ExecutionQueueSize   int           // Max opportunities buffered for execution (0 = default)
+	ExecutionQueueMaxAge time.Duration // Buffered opportunities older than this are evicted (0 = default)
+
+	// Execution - Opportunity age
+	ExecutionMaxOpportunityAge time.Duration // Opportunities detected longer ago than this are discarded at execution (0 = disabled)
+
+	// Execution - CLOB request timeouts (live only, 0 = 30s)
+	ExecutionSubmitTimeout time.Duration // Deadline for each order submission request
+	ExecutionQueryTimeout  time.Duration // Deadline for each order, open order and trade query
+	ExecutionCancelTimeout time.Duration // Deadline for each cancellation request
+
+	// Execution - State Persistence
+	ExecutionPersistState       bool          // Restore profit/trade counts on start and checkpoint them (postgres or sqlite storage)
+	ExecutionCheckpointInterval time.Duration // Time between state checkpoints
 
 	// Circuit Breaker
 	CircuitBreakerEnabled         bool
@@ -64,91 +176,214 @@ type Config struct {
 	CircuitBreakerTradeMultiplier float64
 	CircuitBreakerMinAbsolute     float64
 	CircuitBreakerHysteresisRatio float64
+	CircuitBreakerCollateral      []string // ERC20 collateral token addresses summed for balance checks
+	CircuitBreakerThresholdMode   string   // "sma" or "ema" average trade size
+	CircuitBreakerEMAAlpha        float64  // EMA smoothing factor (ema mode only)
+	CircuitBreakerRecordAllModes  bool     // Record paper trade sizes too, warming up thresholds before going live
 
 	// Storage
-	StorageMode  string // "postgres" or "console"
+	StorageMode  string // "postgres", "sqlite" or "console"
 	PostgresHost string
 	PostgresPort string
 	PostgresUser string
 	PostgresPass string
 	PostgresDB   string
 	PostgresSSL  string
+	SQLitePath   string // Database file for STORAGE_MODE=sqlite
 }
 
 // LoadFromEnv loads configuration from environment variables with defaults.
 func LoadFromEnv() (*Config, error) {
+	cfg, _, err := LoadWithSources()
+	return cfg, err
+}
+
+// LoadWithSources loads configuration exactly like LoadFromEnv, also returning every
+// setting with its resolved value and where it came from. Settings are returned even
+// when validation fails, so an invalid configuration can be inspected.
+func LoadWithSources() (*Config, []Setting, error) {
+	l := &loader{}
 	cfg := &Config{
 		// Application defaults
-		LogLevel: getEnvOrDefault("LOG_LEVEL", "info"),
-		HTTPPort: getEnvOrDefault("HTTP_PORT", "8080"),
+		LogLevel: l.getEnvOrDefault("LOG_LEVEL", "info"),
+		HTTPPort: l.getEnvOrDefault("HTTP_PORT", "8080"),
+
+		// Admin API defaults
+		AdminToken: l.getEnvOrDefault("ADMIN_TOKEN", ""),
+
+		// Health defaults
+		HealthMaxUpdateAge: l.getDurationOrDefault("HEALTH_MAX_UPDATE_AGE", 60*time.Second),
+
+		// Startup warmup defaults
+		WarmupPeriod:          l.getDurationOrDefault("WARMUP_PERIOD", 0),
+		WarmupMinBookFraction: l.getFloat64OrDefault("WARMUP_MIN_BOOK_FRACTION", 0),
+
+		// Status line defaults
+		StatusReportEnabled:  l.getBoolOrDefault("STATUS_REPORT_ENABLED", false),
+		StatusReportInterval: l.getDurationOrDefault("STATUS_REPORT_INTERVAL", 60*time.Second),
 
 		// Polymarket API defaults
-		PolymarketWSURL:      getEnvOrDefault("POLYMARKET_WS_URL", "wss://ws-subscriptions-clob.polymarket.com/ws/market"),
-		PolymarketGammaURL:   getEnvOrDefault("POLYMARKET_GAMMA_API_URL", "https://gamma-api.polymarket.com"),
-		PolymarketAPIKey:     os.Getenv("POLYMARKET_API_KEY"),
-		PolymarketSecret:     os.Getenv("POLYMARKET_SECRET"),
-		PolymarketPassphrase: os.Getenv("POLYMARKET_PASSPHRASE"),
+		PolymarketWSURL:      l.getEnvOrDefault("POLYMARKET_WS_URL", "wss://ws-subscriptions-clob.polymarket.com/ws/market"),
+		PolymarketGammaURL:   l.getEnvOrDefault("POLYMARKET_GAMMA_API_URL", "https://gamma-api.polymarket.com"),
+		PolymarketAPIKey:     l.getEnvOrDefault("POLYMARKET_API_KEY", ""),
+		PolymarketSecret:     l.getEnvOrDefault("POLYMARKET_SECRET", ""),
+		PolymarketPassphrase: l.getEnvOrDefault("POLYMARKET_PASSPHRASE", ""),
+		PolymarketChainID:    int64(l.getIntOrDefault("POLYMARKET_CHAIN_ID", 137)),
 
 		// Market Discovery defaults
-		DiscoveryPollInterval: getDurationOrDefault("DISCOVERY_POLL_INTERVAL", 30*time.Second),
-		DiscoveryMarketLimit:  getIntOrDefault("DISCOVERY_MARKET_LIMIT", 2500),
-		MaxMarketDuration:     getDurationOrDefault("ARB_MAX_MARKET_DURATION", 0), // 0 = unlimited
+		DiscoveryPollInterval:      l.getDurationOrDefault("DISCOVERY_POLL_INTERVAL", 30*time.Second),
+		DiscoveryPollJitter:        l.getFloat64OrDefault("DISCOVERY_POLL_JITTER", 0),
+		DiscoveryMarketLimit:       l.getIntOrDefault("DISCOVERY_MARKET_LIMIT", 2500),
+		DiscoveryFetchMaxAttempts:  l.getIntOrDefault("DISCOVERY_FETCH_MAX_ATTEMPTS", 3),
+		DiscoveryFetchRetryBackoff: l.getDurationOrDefault("DISCOVERY_FETCH_RETRY_BACKOFF", time.Second),
+		DiscoveryFetchBudget:       l.getDurationOrDefault("DISCOVERY_FETCH_BUDGET", 20*time.Second),
+		MaxMarketDuration:          l.getDurationOrDefault("ARB_MAX_MARKET_DURATION", 0), // 0 = unlimited
+		MinMarketDuration:          l.getDurationOrDefault("ARB_MIN_MARKET_DURATION", 0), // 0 = no minimum
 
 		// Market Cleanup defaults
-		CleanupInterval: getDurationOrDefault("CLEANUP_CHECK_INTERVAL", 5*time.Minute),
+		CleanupInterval: l.getDurationOrDefault("CLEANUP_CHECK_INTERVAL", 5*time.Minute),
 
 		// WebSocket defaults
-		WSPoolSize:              getIntOrDefault("WS_POOL_SIZE", 20),
-		WSDialTimeout:           getDurationOrDefault("WS_DIAL_TIMEOUT", 10*time.Second),
-		WSPongTimeout:           getDurationOrDefault("WS_PONG_TIMEOUT", 15*time.Second),
-		WSPingInterval:          getDurationOrDefault("WS_PING_INTERVAL", 10*time.Second),
-		WSReconnectInitialDelay: getDurationOrDefault("WS_RECONNECT_INITIAL_DELAY", 1*time.Second),
-		WSReconnectMaxDelay:     getDurationOrDefault("WS_RECONNECT_MAX_DELAY", 30*time.Second),
-		WSReconnectBackoffMult:  getFloat64OrDefault("WS_RECONNECT_BACKOFF_MULTIPLIER", 2.0),
-		WSMessageBufferSize:     getIntOrDefault("WS_MESSAGE_BUFFER_SIZE", 100000),
+		WSPoolSize:              l.getIntOrDefault("WS_POOL_SIZE", 20),
+		WSDialTimeout:           l.getDurationOrDefault("WS_DIAL_TIMEOUT", 10*time.Second),
+		WSPongTimeout:           l.getDurationOrDefault("WS_PONG_TIMEOUT", 15*time.Second),
+		WSPingInterval:          l.getDurationOrDefault("WS_PING_INTERVAL", 10*time.Second),
+		WSReconnectInitialDelay: l.getDurationOrDefault("WS_RECONNECT_INITIAL_DELAY", 1*time.Second),
+		WSReconnectMaxDelay:     l.getDurationOrDefault("WS_RECONNECT_MAX_DELAY", 30*time.Second),
+		WSReconnectBackoffMult:  l.getFloat64OrDefault("WS_RECONNECT_BACKOFF_MULTIPLIER", 2.0),
+		WSReconnectMaxAttempts:  l.getIntOrDefault("WS_RECONNECT_MAX_ATTEMPTS", 0),
+		WSReconnectMaxDowntime:  l.getDurationOrDefault("WS_RECONNECT_MAX_DOWNTIME", 0),
+		WSMessageBufferSize:     l.getIntOrDefault("WS_MESSAGE_BUFFER_SIZE", 100000),
+		WSResubscribeBatchSize:  l.getIntOrDefault("WS_RESUBSCRIBE_BATCH_SIZE", 100),
+		WSResubscribeBatchDelay: l.getDurationOrDefault("WS_RESUBSCRIBE_BATCH_DELAY", 50*time.Millisecond),
+		WSMaxMessageSizeMB:      l.getIntOrDefault("WS_MAX_MESSAGE_SIZE_MB", 10),
+		WSRecordPath:            l.getEnvOrDefault("WS_RECORD_PATH", ""),
+		WSRecordCompress:        l.getBoolOrDefault("WS_RECORD_COMPRESS", true),
+		WSRecordMaxFileSizeMB:   l.getIntOrDefault("WS_RECORD_MAX_FILE_SIZE_MB", 100),
+
+		WSChannelWarnThreshold:     l.getFloat64OrDefault("WS_CHANNEL_WARN_THRESHOLD", 0.9),
+		WSChannelCriticalThreshold: l.getFloat64OrDefault("WS_CHANNEL_CRITICAL_THRESHOLD", 0.99),
+		WSUtilizationInterval:      l.getDurationOrDefault("WS_CHANNEL_UTILIZATION_INTERVAL", 5*time.Second),
+
+		// Orderbook defaults
+		OrderbookUpdateBufferSize: l.getIntOrDefault("ORDERBOOK_UPDATE_BUFFER_SIZE", 100000),
+		OrderbookHighWatermark:    l.getFloat64OrDefault("ORDERBOOK_HIGH_WATERMARK", 0.9),
+		OrderbookSnapshotTTL:      l.getDurationOrDefault("ORDERBOOK_SNAPSHOT_TTL", 0),
+
+		// Orderbook REST fallback defaults
+		OrderbookRESTFallback:           l.getBoolOrDefault("ORDERBOOK_REST_FALLBACK", false),
+		OrderbookRESTFallbackStaleAfter: l.getDurationOrDefault("ORDERBOOK_REST_FALLBACK_STALE_AFTER", 30*time.Second),
+		OrderbookRESTFallbackInterval:   l.getDurationOrDefault("ORDERBOOK_REST_FALLBACK_INTERVAL", 5*time.Second),
+		OrderbookRESTFallbackMaxFetches: l.getIntOrDefault("ORDERBOOK_REST_FALLBACK_MAX_FETCHES", 10),
 
 		// Arbitrage defaults
-		ArbMaxPriceSum:       getFloat64OrDefault("ARB_MAX_PRICE_SUM", 0.995),
-		ArbMinTradeSize:      getFloat64OrDefault("ARB_MIN_TRADE_SIZE", 1.0),
-		ArbMaxTradeSize:      getFloat64OrDefault("ARB_MAX_TRADE_SIZE", 2.0),
-		ArbDetectionInterval: getDurationOrDefault("ARB_DETECTION_INTERVAL", 100*time.Millisecond),
-		ArbMakerFee:          getFloat64OrDefault("ARB_MAKER_FEE", 0.0000), // 0% maker fee on Polymarket
-		ArbTakerFee:          getFloat64OrDefault("ARB_TAKER_FEE", 0.0100), // 1% taker fee
+		ArbMaxPriceSum:         l.getFloat64OrDefault("ARB_MAX_PRICE_SUM", 0.995),
+		ArbMinTradeSize:        l.getFloat64OrDefault("ARB_MIN_TRADE_SIZE", 1.0),
+		ArbMaxTradeSize:        l.getFloat64OrDefault("ARB_MAX_TRADE_SIZE", 2.0),
+		ArbDetectionInterval:   l.getDurationOrDefault("ARB_DETECTION_INTERVAL", 100*time.Millisecond),
+		ArbMakerFee:            l.getFloat64OrDefault("ARB_MAKER_FEE", 0.0000), // 0% maker fee on Polymarket
+		ArbTakerFee:            l.getFloat64OrDefault("ARB_TAKER_FEE", 0.0100), // 1% taker fee
+		ArbFeeModel:            l.getEnvOrDefault("ARB_FEE_MODEL", "flat"),
+		ArbFeeTiers:            l.getEnvOrDefault("ARB_FEE_TIERS", ""),
+		ArbMinProfitUSD:        l.getFloat64OrDefault("ARB_MIN_PROFIT_USD", 0.0),
+		ArbDetectorConcurrency: l.getIntOrDefault("ARB_DETECTOR_CONCURRENCY", 1),
+		ArbMaxOutcomes:         l.getIntOrDefault("ARB_MAX_OUTCOMES", 20),
+		ArbMinAskLiquidityUSD:  l.getFloat64OrDefault("ARB_MIN_ASK_LIQUIDITY_USD", 0.0),
+		ArbLinkedMarkets:       l.getEnvOrDefault("ARB_LINKED_MARKETS", ""),
+		ArbLimitPrices:         l.getBoolOrDefault("ARB_LIMIT_PRICES", false),
+		ArbOpportunityBuffer:   l.getIntOrDefault("ARB_OPPORTUNITY_BUFFER_SIZE", 10000),
+		ArbLogRejections:       l.getBoolOrDefault("ARB_LOG_REJECTIONS", false),
+
+		ArbTailPriceFloor: l.getFloat64OrDefault("ARB_TAIL_PRICE_FLOOR", 0),
 
 		// Execution defaults
-		ExecutionMode:            getEnvOrDefault("EXECUTION_MODE", "paper"),
-		ExecutionMaxPositionSize: getFloat64OrDefault("EXECUTION_MAX_POSITION_SIZE", 1000.0),
+		ExecutionMode:            l.getEnvOrDefault("EXECUTION_MODE", "paper"),
+		ExecutionMaxPositionSize: l.getFloat64OrDefault("EXECUTION_MAX_POSITION_SIZE", 1000.0),
+		ExecutionMaxOpenExposure: l.getFloat64OrDefault("EXECUTION_MAX_OPEN_EXPOSURE_USD", 0),
+		ExecutionStrictTickSize:  l.getBoolOrDefault("EXECUTION_STRICT_TICK_SIZE", false),
+		ExecutionStrictOrderHash: l.getBoolOrDefault("EXECUTION_STRICT_ORDER_HASH", false),
+		ExecutionMaxReprices:     l.getIntOrDefault("EXECUTION_MAX_REPRICE_ATTEMPTS", 0),
+		ExecutionMaxBatchSize:    l.getIntOrDefault("EXECUTION_MAX_BATCH_SIZE", 15),
+		ExecutionSortByTokenID:   l.getBoolOrDefault("EXECUTION_SORT_BATCH_BY_TOKEN_ID", false),
+		ExecutionRealisticFills:  l.getBoolOrDefault("EXECUTION_PAPER_REALISTIC_FILLS", false),
+		ExecutionClockSkewSync:   l.getBoolOrDefault("EXECUTION_CLOCK_SKEW_SYNC", true),
+		ExecutionSelfTradeMode:   l.getEnvOrDefault("EXECUTION_SELF_TRADE_PREVENTION", "off"),
+		ExecutionRoundingPolicy:  l.getEnvOrDefault("EXECUTION_ROUNDING_POLICY", "directional"),
+		ExecutionAllowanceCheck:  l.getEnvOrDefault("EXECUTION_ALLOWANCE_CHECK", "warn"),
+		ExecutionMinAllowanceUSD: l.getFloat64OrDefault("EXECUTION_MIN_ALLOWANCE_USD", 0),
+
+		// Execution - Per-market cooldown defaults
+		ExecutionMarketCooldown: l.getDurationOrDefault("EXECUTION_MARKET_COOLDOWN", 0),
+
+		// Execution - Rejection handling defaults
+		ExecutionRejectionCooldown: l.getDurationOrDefault("EXECUTION_REJECTION_COOLDOWN", 10*time.Minute),
+
+		ExecutionCompleteSetInterval:    l.getDurationOrDefault("EXECUTION_COMPLETE_SET_CHECK_INTERVAL", 0), // 0 = disabled
+		ExecutionCompleteSetMinSellEdge: l.getFloat64OrDefault("EXECUTION_COMPLETE_SET_MIN_SELL_EDGE", 0.01),
+
+		ExecutionRedeemInterval: l.getDurationOrDefault("EXECUTION_REDEEM_CHECK_INTERVAL", 0), // 0 = disabled
 
 		// Execution - Fill Verification defaults
-		ExecutionAggressionTicks:  getIntOrDefault("EXECUTION_AGGRESSION_TICKS", 5),
-		ExecutionFillTimeout:      getDurationOrDefault("EXECUTION_FILL_TIMEOUT", 30*time.Second),
-		ExecutionFillRetryInitial: getDurationOrDefault("EXECUTION_FILL_RETRY_INITIAL", 2*time.Second),
-		ExecutionFillRetryMax:     getDurationOrDefault("EXECUTION_FILL_RETRY_MAX", 16*time.Second),
-		ExecutionFillRetryMult:    getFloat64OrDefault("EXECUTION_FILL_RETRY_MULTIPLIER", 2.0),
+		ExecutionPricingStrategy:  l.getEnvOrDefault("EXECUTION_PRICING_STRATEGY", "ask"),
+		ExecutionAggressionTicks:  l.getIntOrDefault("EXECUTION_AGGRESSION_TICKS", 5),
+		ExecutionAggressionMode:   l.getEnvOrDefault("EXECUTION_AGGRESSION_MODE", "ticks"),
+		ExecutionAggressionSpread: l.getFloat64OrDefault("EXECUTION_AGGRESSION_SPREAD_FRACTION", 0.5),
+		ExecutionFillTimeout:      l.getDurationOrDefault("EXECUTION_FILL_TIMEOUT", 30*time.Second),
+		ExecutionFillRetryInitial: l.getDurationOrDefault("EXECUTION_FILL_RETRY_INITIAL", 2*time.Second),
+		ExecutionFillRetryMax:     l.getDurationOrDefault("EXECUTION_FILL_RETRY_MAX", 16*time.Second),
+		ExecutionFillRetryMult:    l.getFloat64OrDefault("EXECUTION_FILL_RETRY_MULTIPLIER", 2.0),
+		ExecutionFillRetryJitter:  l.getFloat64OrDefault("EXECUTION_FILL_RETRY_JITTER", 0.2),
+		ExecutionFillMaxAttempts:  l.getIntOrDefault("EXECUTION_FILL_MAX_ATTEMPTS", 20),
+		ExecutionFillGracePeriod:  l.getDurationOrDefault("EXECUTION_FILL_GRACE_PERIOD", 10*time.Second),
+		ExecutionDrainTimeout:     l.getDurationOrDefault("EXECUTION_DRAIN_TIMEOUT", 40*time.Second),
+
+		ExecutionFillMaxConcurrent: l.getIntOrDefault("EXECUTION_FILL_MAX_CONCURRENT", 10),
+
+		ExecutionProfitReportDecimals: l.getIntOrDefault("EXECUTION_PROFIT_REPORT_DECIMALS", 6),
+
+		// Execution - Opportunity Queue defaults
+		ExecutionQueueSize:   l.getIntOrDefault("EXECUTION_QUEUE_SIZE", 100),
+		ExecutionQueueMaxAge: l.getDurationOrDefault("EXECUTION_QUEUE_MAX_AGE", 5*time.Second),
+
+		ExecutionMaxOpportunityAge: l.getDurationOrDefault("EXECUTION_MAX_OPPORTUNITY_AGE", 0),
+
+		// Execution - CLOB request timeouts defaults
+		ExecutionSubmitTimeout: l.getDurationOrDefault("EXECUTION_SUBMIT_TIMEOUT", 30*time.Second),
+		ExecutionQueryTimeout:  l.getDurationOrDefault("EXECUTION_QUERY_TIMEOUT", 30*time.Second),
+		ExecutionCancelTimeout: l.getDurationOrDefault("EXECUTION_CANCEL_TIMEOUT", 30*time.Second),
+
+		// Execution - State Persistence defaults
+		ExecutionPersistState:       l.getBoolOrDefault("EXECUTION_PERSIST_STATE", false),
+		ExecutionCheckpointInterval: l.getDurationOrDefault("EXECUTION_STATE_CHECKPOINT_INTERVAL", 30*time.Second),
 
 		// Circuit Breaker defaults
-		CircuitBreakerEnabled:         getBoolOrDefault("CIRCUIT_BREAKER_ENABLED", true),
-		CircuitBreakerCheckInterval:   getDurationOrDefault("CIRCUIT_BREAKER_CHECK_INTERVAL", 300*time.Second),
-		CircuitBreakerTradeMultiplier: getFloat64OrDefault("CIRCUIT_BREAKER_TRADE_MULTIPLIER", 3.0),
-		CircuitBreakerMinAbsolute:     getFloat64OrDefault("CIRCUIT_BREAKER_MIN_ABSOLUTE", 5.0),
-		CircuitBreakerHysteresisRatio: getFloat64OrDefault("CIRCUIT_BREAKER_HYSTERESIS_RATIO", 1.5),
+		CircuitBreakerEnabled:         l.getBoolOrDefault("CIRCUIT_BREAKER_ENABLED", true),
+		CircuitBreakerCheckInterval:   l.getDurationOrDefault("CIRCUIT_BREAKER_CHECK_INTERVAL", 300*time.Second),
+		CircuitBreakerTradeMultiplier: l.getFloat64OrDefault("CIRCUIT_BREAKER_TRADE_MULTIPLIER", 3.0),
+		CircuitBreakerMinAbsolute:     l.getFloat64OrDefault("CIRCUIT_BREAKER_MIN_ABSOLUTE", 5.0),
+		CircuitBreakerHysteresisRatio: l.getFloat64OrDefault("CIRCUIT_BREAKER_HYSTERESIS_RATIO", 1.5),
+		CircuitBreakerCollateral:      l.getListOrDefault("CIRCUIT_BREAKER_COLLATERAL_TOKENS", nil),
+		CircuitBreakerThresholdMode:   l.getEnvOrDefault("CIRCUIT_BREAKER_THRESHOLD_MODE", "sma"),
+		CircuitBreakerEMAAlpha:        l.getFloat64OrDefault("CIRCUIT_BREAKER_EMA_ALPHA", 0.2),
+		CircuitBreakerRecordAllModes:  l.getBoolOrDefault("CIRCUIT_BREAKER_RECORD_ALL_MODES", false),
 
 		// Storage defaults
-		StorageMode:  getEnvOrDefault("STORAGE_MODE", "console"),
-		PostgresHost: getEnvOrDefault("POSTGRES_HOST", "localhost"),
-		PostgresPort: getEnvOrDefault("POSTGRES_PORT", "5432"),
-		PostgresUser: getEnvOrDefault("POSTGRES_USER", "polymarket"),
-		PostgresPass: getEnvOrDefault("POSTGRES_PASSWORD", "polymarket123"),
-		PostgresDB:   getEnvOrDefault("POSTGRES_DB", "polymarket_arb"),
-		PostgresSSL:  getEnvOrDefault("POSTGRES_SSLMODE", "disable"),
+		StorageMode:  l.getEnvOrDefault("STORAGE_MODE", "console"),
+		PostgresHost: l.getEnvOrDefault("POSTGRES_HOST", "localhost"),
+		PostgresPort: l.getEnvOrDefault("POSTGRES_PORT", "5432"),
+		PostgresUser: l.getEnvOrDefault("POSTGRES_USER", "polymarket"),
+		PostgresPass: l.getEnvOrDefault("POSTGRES_PASSWORD", "polymarket123"),
+		PostgresDB:   l.getEnvOrDefault("POSTGRES_DB", "polymarket_arb"),
+		PostgresSSL:  l.getEnvOrDefault("POSTGRES_SSLMODE", "disable"),
+		SQLitePath:   l.getEnvOrDefault("SQLITE_PATH", "polymarket-arb.db"),
 	}
 
 	err := cfg.Validate()
 	if err != nil {
-		return nil, fmt.Errorf("validate config: %w", err)
+		return nil, l.settings, fmt.Errorf("validate config: %w", err)
 	}
 
-	return cfg, nil
+	return cfg, l.settings, nil
 }
 
 // Validate checks that configuration values are valid.
@@ -157,6 +392,10 @@ func (c *Config) Validate() (err error) {
 		return errors.New("HTTP_PORT cannot be empty")
 	}
 
+	if c.AdminToken != "" && len(c.AdminToken) < 16 {
+		return errors.New("ADMIN_TOKEN must be at least 16 characters")
+	}
+
 	if c.PolymarketWSURL == "" {
 		return errors.New("POLYMARKET_WS_URL cannot be empty")
 	}
@@ -165,12 +404,16 @@ func (c *Config) Validate() (err error) {
 		return errors.New("POLYMARKET_GAMMA_API_URL cannot be empty")
 	}
 
+	if c.PolymarketChainID < 0 {
+		return fmt.Errorf("POLYMARKET_CHAIN_ID must be non-negative (0 = Polygon), got %d", c.PolymarketChainID)
+	}
+
 	if c.ArbMaxPriceSum <= 0 || c.ArbMaxPriceSum > 1.10 {
 		return fmt.Errorf("ARB_MAX_PRICE_SUM must be between 0 and 1.10 (values > 1.0 for research mode), got %f", c.ArbMaxPriceSum)
 	}
 
-	if c.ExecutionMode != "paper" && c.ExecutionMode != "live" && c.ExecutionMode != "dry-run" {
-		return fmt.Errorf("EXECUTION_MODE must be 'paper', 'live', or 'dry-run', got %q", c.ExecutionMode)
+	if c.ExecutionMode != "paper" && c.ExecutionMode != "live" && c.ExecutionMode != "dry-run" && c.ExecutionMode != "observe" {
+		return fmt.Errorf("EXECUTION_MODE must be 'paper', 'live', 'dry-run', or 'observe', got %q", c.ExecutionMode)
 	}
 
 	// Validate trade size configuration
@@ -182,6 +425,32 @@ func (c *Config) Validate() (err error) {
 		return fmt.Errorf("ARB_MAX_TRADE_SIZE must be positive, got %f", c.ArbMaxTradeSize)
 	}
 
+	if c.ArbMaxOutcomes < 0 {
+		return fmt.Errorf("ARB_MAX_OUTCOMES must be non-negative (0 = unlimited), got %d", c.ArbMaxOutcomes)
+	}
+
+	if c.ArbTailPriceFloor < 0 || c.ArbTailPriceFloor >= 1 {
+		return fmt.Errorf("ARB_TAIL_PRICE_FLOOR must be in [0, 1) (0 = disabled), got %f", c.ArbTailPriceFloor)
+	}
+
+	if c.ArbOpportunityBuffer < 0 {
+		return fmt.Errorf("ARB_OPPORTUNITY_BUFFER_SIZE must be non-negative (0 = default), got %d", c.ArbOpportunityBuffer)
+	}
+
+	switch c.ArbFeeModel {
+	case "", "flat":
+	case "tiered":
+		if c.ArbFeeTiers == "" {
+			return errors.New("ARB_FEE_TIERS is required when ARB_FEE_MODEL is 'tiered'")
+		}
+	default:
+		return fmt.Errorf("ARB_FEE_MODEL must be 'flat' or 'tiered', got %q", c.ArbFeeModel)
+	}
+
+	if c.ArbMinAskLiquidityUSD < 0 {
+		return fmt.Errorf("ARB_MIN_ASK_LIQUIDITY_USD must be non-negative, got %f", c.ArbMinAskLiquidityUSD)
+	}
+
 	if c.ArbMaxTradeSize < c.ArbMinTradeSize {
 		return fmt.Errorf("ARB_MAX_TRADE_SIZE (%f) must be >= ARB_MIN_TRADE_SIZE (%f)",
 			c.ArbMaxTradeSize, c.ArbMinTradeSize)
@@ -192,6 +461,31 @@ func (c *Config) Validate() (err error) {
 		return fmt.Errorf("ARB_MAX_MARKET_DURATION must be non-negative (0 = unlimited), got %s", c.MaxMarketDuration)
 	}
 
+	if c.MinMarketDuration < 0 {
+		return fmt.Errorf("ARB_MIN_MARKET_DURATION must be non-negative (0 = no minimum), got %s", c.MinMarketDuration)
+	}
+
+	if c.MaxMarketDuration > 0 && c.MinMarketDuration >= c.MaxMarketDuration {
+		return fmt.Errorf("ARB_MIN_MARKET_DURATION (%s) must be < ARB_MAX_MARKET_DURATION (%s)",
+			c.MinMarketDuration, c.MaxMarketDuration)
+	}
+
+	if c.DiscoveryPollJitter < 0 || c.DiscoveryPollJitter >= 1 {
+		return fmt.Errorf("DISCOVERY_POLL_JITTER must be in [0, 1) (0 = disabled), got %f", c.DiscoveryPollJitter)
+	}
+
+	if c.DiscoveryFetchMaxAttempts < 0 {
+		return fmt.Errorf("DISCOVERY_FETCH_MAX_ATTEMPTS must be non-negative (0 = default, 1 = no retry), got %d", c.DiscoveryFetchMaxAttempts)
+	}
+
+	if c.DiscoveryFetchBudget < 0 {
+		return fmt.Errorf("DISCOVERY_FETCH_BUDGET must be non-negative (0 = unlimited), got %s", c.DiscoveryFetchBudget)
+	}
+
+	if c.DiscoveryFetchRetryBackoff < 0 {
+		return fmt.Errorf("DISCOVERY_FETCH_RETRY_BACKOFF must be non-negative (0 = default), got %s", c.DiscoveryFetchRetryBackoff)
+	}
+
 	if c.DiscoveryMarketLimit < 0 {
 		return fmt.Errorf("DISCOVERY_MARKET_LIMIT must be non-negative (0 = unlimited), got %d", c.DiscoveryMarketLimit)
 	}
@@ -205,6 +499,208 @@ func (c *Config) Validate() (err error) {
 		return fmt.Errorf("WS_POOL_SIZE must not exceed 20, got %d", c.WSPoolSize)
 	}
 
+	if c.WSReconnectMaxAttempts < 0 {
+		return fmt.Errorf("WS_RECONNECT_MAX_ATTEMPTS must be non-negative (0 = unlimited), got %d", c.WSReconnectMaxAttempts)
+	}
+
+	if c.WSReconnectMaxDowntime < 0 {
+		return fmt.Errorf("WS_RECONNECT_MAX_DOWNTIME must be non-negative (0 = unlimited), got %s", c.WSReconnectMaxDowntime)
+	}
+
+	if c.WSMaxMessageSizeMB < 0 {
+		return fmt.Errorf("WS_MAX_MESSAGE_SIZE_MB must be non-negative (0 = default), got %d", c.WSMaxMessageSizeMB)
+	}
+
+	// Validate message channel utilization thresholds (0 = use default)
+	if c.WSChannelWarnThreshold < 0 || c.WSChannelWarnThreshold > 1 {
+		return fmt.Errorf("WS_CHANNEL_WARN_THRESHOLD must be between 0 and 1, got %f", c.WSChannelWarnThreshold)
+	}
+
+	if c.WSChannelCriticalThreshold < 0 || c.WSChannelCriticalThreshold > 1 {
+		return fmt.Errorf("WS_CHANNEL_CRITICAL_THRESHOLD must be between 0 and 1, got %f", c.WSChannelCriticalThreshold)
+	}
+
+	if c.WSChannelWarnThreshold > 0 && c.WSChannelCriticalThreshold > 0 &&
+		c.WSChannelWarnThreshold > c.WSChannelCriticalThreshold {
+		return fmt.Errorf("WS_CHANNEL_WARN_THRESHOLD (%f) must not exceed WS_CHANNEL_CRITICAL_THRESHOLD (%f)",
+			c.WSChannelWarnThreshold, c.WSChannelCriticalThreshold)
+	}
+
+	if c.WSUtilizationInterval < 0 {
+		return fmt.Errorf("WS_CHANNEL_UTILIZATION_INTERVAL must be non-negative (0 = default), got %s", c.WSUtilizationInterval)
+	}
+
+	// Validate orderbook backpressure configuration (0 = use default)
+	if c.OrderbookUpdateBufferSize < 0 {
+		return fmt.Errorf("ORDERBOOK_UPDATE_BUFFER_SIZE must be non-negative, got %d", c.OrderbookUpdateBufferSize)
+	}
+
+	if c.OrderbookHighWatermark < 0 || c.OrderbookHighWatermark > 1 {
+		return fmt.Errorf("ORDERBOOK_HIGH_WATERMARK must be between 0 and 1, got %f", c.OrderbookHighWatermark)
+	}
+
+	if c.OrderbookSnapshotTTL < 0 {
+		return fmt.Errorf("ORDERBOOK_SNAPSHOT_TTL must be non-negative (0 = never), got %s", c.OrderbookSnapshotTTL)
+	}
+
+	if c.OrderbookRESTFallback {
+		if c.OrderbookRESTFallbackStaleAfter <= 0 {
+			return fmt.Errorf("ORDERBOOK_REST_FALLBACK_STALE_AFTER must be positive, got %s", c.OrderbookRESTFallbackStaleAfter)
+		}
+
+		if c.OrderbookRESTFallbackInterval <= 0 {
+			return fmt.Errorf("ORDERBOOK_REST_FALLBACK_INTERVAL must be positive, got %s", c.OrderbookRESTFallbackInterval)
+		}
+
+		if c.OrderbookRESTFallbackMaxFetches <= 0 {
+			return fmt.Errorf("ORDERBOOK_REST_FALLBACK_MAX_FETCHES must be positive, got %d", c.OrderbookRESTFallbackMaxFetches)
+		}
+
+		if c.OrderbookSnapshotTTL > 0 && c.OrderbookSnapshotTTL <= c.OrderbookRESTFallbackStaleAfter {
+			return fmt.Errorf("ORDERBOOK_SNAPSHOT_TTL (%s) must exceed ORDERBOOK_REST_FALLBACK_STALE_AFTER (%s), or stale books are evicted before they are refetched",
+				c.OrderbookSnapshotTTL, c.OrderbookRESTFallbackStaleAfter)
+		}
+	}
+
+	if c.HealthMaxUpdateAge < 0 {
+		return fmt.Errorf("HEALTH_MAX_UPDATE_AGE must be non-negative (0 = disabled), got %s", c.HealthMaxUpdateAge)
+	}
+
+	if c.WarmupPeriod < 0 {
+		return fmt.Errorf("WARMUP_PERIOD must be non-negative (0 = none), got %s", c.WarmupPeriod)
+	}
+
+	if c.WarmupMinBookFraction < 0 || c.WarmupMinBookFraction > 1 {
+		return fmt.Errorf("WARMUP_MIN_BOOK_FRACTION must be between 0 and 1 (0 = none), got %f", c.WarmupMinBookFraction)
+	}
+
+	if c.ExecutionMaxOpenExposure < 0 {
+		return fmt.Errorf("EXECUTION_MAX_OPEN_EXPOSURE_USD must be non-negative (0 = unlimited), got %f", c.ExecutionMaxOpenExposure)
+	}
+
+	if c.ExecutionMarketCooldown < 0 {
+		return fmt.Errorf("EXECUTION_MARKET_COOLDOWN must be non-negative (0 = disabled), got %s", c.ExecutionMarketCooldown)
+	}
+
+	if c.ExecutionRejectionCooldown < 0 {
+		return fmt.Errorf("EXECUTION_REJECTION_COOLDOWN must be non-negative (0 = disabled), got %s", c.ExecutionRejectionCooldown)
+	}
+
+	if c.ExecutionMaxReprices < 0 {
+		return fmt.Errorf("EXECUTION_MAX_REPRICE_ATTEMPTS must be non-negative (0 = disabled), got %d", c.ExecutionMaxReprices)
+	}
+
+	if c.ExecutionMaxBatchSize < 0 {
+		return fmt.Errorf("EXECUTION_MAX_BATCH_SIZE must be non-negative (0 = default), got %d", c.ExecutionMaxBatchSize)
+	}
+
+	switch c.ExecutionSelfTradeMode {
+	case "", "off", "cancel", "skip":
+	default:
+		return fmt.Errorf("EXECUTION_SELF_TRADE_PREVENTION must be 'off', 'cancel', or 'skip', got %q", c.ExecutionSelfTradeMode)
+	}
+
+	switch c.ExecutionAllowanceCheck {
+	case "", "off", "warn", "block", "approve":
+	default:
+		return fmt.Errorf("EXECUTION_ALLOWANCE_CHECK must be 'off', 'warn', 'block', or 'approve', got %q", c.ExecutionAllowanceCheck)
+	}
+
+	if c.ExecutionMinAllowanceUSD < 0 {
+		return fmt.Errorf("EXECUTION_MIN_ALLOWANCE_USD must be non-negative (0 = max position size), got %f", c.ExecutionMinAllowanceUSD)
+	}
+
+	if c.ExecutionCompleteSetInterval < 0 {
+		return fmt.Errorf("EXECUTION_COMPLETE_SET_CHECK_INTERVAL must be non-negative (0 = disabled), got %s", c.ExecutionCompleteSetInterval)
+	}
+
+	if c.ExecutionCompleteSetMinSellEdge < 0 {
+		return fmt.Errorf("EXECUTION_COMPLETE_SET_MIN_SELL_EDGE must be non-negative, got %f", c.ExecutionCompleteSetMinSellEdge)
+	}
+
+	if c.ExecutionRedeemInterval < 0 {
+		return fmt.Errorf("EXECUTION_REDEEM_CHECK_INTERVAL must be non-negative (0 = disabled), got %s", c.ExecutionRedeemInterval)
+	}
+
+	switch c.ExecutionRoundingPolicy {
+	case "", "directional", "nearest":
+	default:
+		return fmt.Errorf("EXECUTION_ROUNDING_POLICY must be 'directional' or 'nearest', got %q", c.ExecutionRoundingPolicy)
+	}
+
+	switch c.ExecutionPricingStrategy {
+	case "", "ask", "bid", "mid":
+	default:
+		return fmt.Errorf("EXECUTION_PRICING_STRATEGY must be 'ask', 'bid', or 'mid', got %q", c.ExecutionPricingStrategy)
+	}
+
+	switch c.ExecutionAggressionMode {
+	case "", "ticks", "spread_fraction":
+	default:
+		return fmt.Errorf("EXECUTION_AGGRESSION_MODE must be 'ticks' or 'spread_fraction', got %q", c.ExecutionAggressionMode)
+	}
+
+	if c.ExecutionAggressionSpread < 0 {
+		return fmt.Errorf("EXECUTION_AGGRESSION_SPREAD_FRACTION must be non-negative, got %f", c.ExecutionAggressionSpread)
+	}
+
+	if c.ExecutionCheckpointInterval < 0 {
+		return fmt.Errorf("EXECUTION_STATE_CHECKPOINT_INTERVAL must be non-negative (0 = default), got %s", c.ExecutionCheckpointInterval)
+	}
+
+	if c.ExecutionFillRetryJitter < 0 || c.ExecutionFillRetryJitter > 1 {
+		return fmt.Errorf("EXECUTION_FILL_RETRY_JITTER must be between 0 and 1, got %f", c.ExecutionFillRetryJitter)
+	}
+
+	if c.ExecutionFillMaxAttempts < 0 {
+		return fmt.Errorf("EXECUTION_FILL_MAX_ATTEMPTS must be non-negative (0 = unlimited), got %d", c.ExecutionFillMaxAttempts)
+	}
+
+	if c.ExecutionQueueSize < 0 {
+		return fmt.Errorf("EXECUTION_QUEUE_SIZE must be non-negative (0 = default), got %d", c.ExecutionQueueSize)
+	}
+
+	if c.ExecutionQueueMaxAge < 0 {
+		return fmt.Errorf("EXECUTION_QUEUE_MAX_AGE must be non-negative (0 = default), got %s", c.ExecutionQueueMaxAge)
+	}
+
+	if c.ExecutionMaxOpportunityAge < 0 {
+		return fmt.Errorf("EXECUTION_MAX_OPPORTUNITY_AGE must be non-negative (0 = disabled), got %s", c.ExecutionMaxOpportunityAge)
+	}
+
+	if c.ExecutionSubmitTimeout < 0 {
+		return fmt.Errorf("EXECUTION_SUBMIT_TIMEOUT must be non-negative (0 = default), got %s", c.ExecutionSubmitTimeout)
+	}
+
+	if c.ExecutionQueryTimeout < 0 {
+		return fmt.Errorf("EXECUTION_QUERY_TIMEOUT must be non-negative (0 = default), got %s", c.ExecutionQueryTimeout)
+	}
+
+	if c.ExecutionCancelTimeout < 0 {
+		return fmt.Errorf("EXECUTION_CANCEL_TIMEOUT must be non-negative (0 = default), got %s", c.ExecutionCancelTimeout)
+	}
+
+	if c.ExecutionFillGracePeriod < 0 {
+		return fmt.Errorf("EXECUTION_FILL_GRACE_PERIOD must be non-negative (0 = default), got %s", c.ExecutionFillGracePeriod)
+	}
+
+	if c.ExecutionDrainTimeout < 0 {
+		return fmt.Errorf("EXECUTION_DRAIN_TIMEOUT must be non-negative (0 = don't wait), got %s", c.ExecutionDrainTimeout)
+	}
+
+	if c.ExecutionFillMaxConcurrent < 0 {
+		return fmt.Errorf("EXECUTION_FILL_MAX_CONCURRENT must be non-negative (0 = default), got %d", c.ExecutionFillMaxConcurrent)
+	}
+
+	if c.ExecutionProfitReportDecimals < 0 || c.ExecutionProfitReportDecimals > 6 {
+		return fmt.Errorf("EXECUTION_PROFIT_REPORT_DECIMALS must be between 0 and 6 (0 = default), got %d",
+			c.ExecutionProfitReportDecimals)
+	}
+
+	if c.StatusReportInterval < 0 {
+		return fmt.Errorf("STATUS_REPORT_INTERVAL must be non-negative (0 = default), got %s", c.StatusReportInterval)
+	}
+
 	// Validate cleanup configuration
 	if c.CleanupInterval <= 0 {
 		return fmt.Errorf("CLEANUP_CHECK_INTERVAL must be positive, got %s", c.CleanupInterval)
@@ -276,3 +772,21 @@ func getBoolOrDefault(key string, defaultValue bool) bool {
 
 	return boolVal
 }
+
+// getListOrDefault parses a comma-separated list, ignoring empty entries.
+func getListOrDefault(key string, defaultValue []string) []string {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+
+	items := make([]string, 0)
+	for _, item := range strings.Split(value, ",") {
+		item = strings.TrimSpace(item)
+		if item != "" {
+			items = append(items, item)
+		}
+	}
+
+	return items
+}
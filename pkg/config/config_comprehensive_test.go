@@ -297,23 +297,28 @@ func TestValidate_ExecutionMode(t *testing.T) {
 			mode:    "live",
 			wantErr: false,
 		},
+		{
+			name:    "observe-mode",
+			mode:    "observe",
+			wantErr: false,
+		},
 		{
 			name:    "invalid-mode",
 			mode:    "invalid",
 			wantErr: true,
-			errMsg:  "EXECUTION_MODE must be 'paper', 'live', or 'dry-run', got \"invalid\"",
+			errMsg:  "EXECUTION_MODE must be 'paper', 'live', 'dry-run', or 'observe', got \"invalid\"",
 		},
 		{
 			name:    "empty-mode",
 			mode:    "",
 			wantErr: true,
-			errMsg:  "EXECUTION_MODE must be 'paper', 'live', or 'dry-run', got \"\"",
+			errMsg:  "EXECUTION_MODE must be 'paper', 'live', 'dry-run', or 'observe', got \"\"",
 		},
 		{
 			name:    "uppercase-mode",
 			mode:    "PAPER",
 			wantErr: true,
-			errMsg:  "EXECUTION_MODE must be 'paper', 'live', or 'dry-run', got \"PAPER\"",
+			errMsg:  "EXECUTION_MODE must be 'paper', 'live', 'dry-run', or 'observe', got \"PAPER\"",
 		},
 	}
 
@@ -132,6 +132,62 @@ func TestConfig_NegativeValues(t *testing.T) {
 			t.Errorf("expected error %q, got %q", expectedMsg, err.Error())
 		}
 	})
+
+	t.Run("min_duration_above_max_rejected", func(t *testing.T) {
+		cfg := &Config{
+			HTTPPort:             "8080",
+			PolymarketWSURL:      "wss://ws-subscriptions-clob.polymarket.com/ws/market",
+			PolymarketGammaURL:   "https://gamma-api.polymarket.com",
+			ArbMaxPriceSum:       0.995,
+			ArbMinTradeSize:      1.0,
+			ArbMaxTradeSize:      10.0,
+			MaxMarketDuration:    1 * time.Hour,
+			MinMarketDuration:    2 * time.Hour, // Window is empty
+			DiscoveryMarketLimit: 100,
+			CleanupInterval:      5 * time.Minute,
+			WSPoolSize:           5,
+			ExecutionMode:        "paper",
+		}
+
+		err := cfg.Validate()
+		if err == nil {
+			t.Fatal("expected error for min duration above max, got nil")
+		}
+
+		expectedMsg := "ARB_MIN_MARKET_DURATION (2h0m0s) must be < ARB_MAX_MARKET_DURATION (1h0m0s)"
+		if err.Error() != expectedMsg {
+			t.Errorf("expected error %q, got %q", expectedMsg, err.Error())
+		}
+	})
+}
+
+func TestConfig_SelfTradePreventionValidation(t *testing.T) {
+	t.Run("unknown_mode_rejected", func(t *testing.T) {
+		cfg := &Config{
+			HTTPPort:               "8080",
+			PolymarketWSURL:        "wss://ws-subscriptions-clob.polymarket.com/ws/market",
+			PolymarketGammaURL:     "https://gamma-api.polymarket.com",
+			ArbMaxPriceSum:         0.995,
+			ArbMinTradeSize:        1.0,
+			ArbMaxTradeSize:        10.0,
+			MaxMarketDuration:      1 * time.Hour,
+			DiscoveryMarketLimit:   100,
+			CleanupInterval:        5 * time.Minute,
+			WSPoolSize:             5,
+			ExecutionMode:          "live",
+			ExecutionSelfTradeMode: "reject",
+		}
+
+		err := cfg.Validate()
+		if err == nil {
+			t.Fatal("expected error for unknown self-trade prevention mode, got nil")
+		}
+
+		expectedMsg := `EXECUTION_SELF_TRADE_PREVENTION must be 'off', 'cancel', or 'skip', got "reject"`
+		if err.Error() != expectedMsg {
+			t.Errorf("expected error %q, got %q", expectedMsg, err.Error())
+		}
+	})
 }
 
 func TestConfig_PoolSizeValidation(t *testing.T) {
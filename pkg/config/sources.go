@@ -0,0 +1,100 @@
+package config
+
+import (
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Setting sources.
+const (
+	SourceEnv     = "env"     // Set in the environment
+	SourceDefault = "default" // Unset, so the default applies
+	SourceInvalid = "invalid" // Set but unparseable; the default applies
+)
+
+// RedactedValue replaces the value of a secret setting that is set.
+const RedactedValue = "<redacted>"
+
+// secretSettings are credentials whose values are never reported.
+//
+//nolint:gochecknoglobals // Read-only lookup table
+var secretSettings = map[string]bool{
+	"ADMIN_TOKEN":           true,
+	"POLYMARKET_API_KEY":    true,
+	"POLYMARKET_SECRET":     true,
+	"POLYMARKET_PASSPHRASE": true,
+	"POSTGRES_PASSWORD":     true,
+}
+
+// Setting is one resolved configuration value.
+type Setting struct {
+	Name   string `json:"name"   yaml:"name"`   // Environment variable
+	Value  string `json:"value"  yaml:"value"`  // Resolved value; RedactedValue for secrets that are set
+	Source string `json:"source" yaml:"source"` // SourceEnv, SourceDefault or SourceInvalid
+}
+
+// loader resolves settings like the getXOrDefault helpers, recording each one in
+// the order it was read.
+type loader struct {
+	settings []Setting
+}
+
+func (l *loader) getEnvOrDefault(key string, defaultValue string) string {
+	value := getEnvOrDefault(key, defaultValue)
+	l.record(key, value, nil)
+	return value
+}
+
+func (l *loader) getIntOrDefault(key string, defaultValue int) int {
+	_, err := strconv.Atoi(os.Getenv(key))
+	value := getIntOrDefault(key, defaultValue)
+	l.record(key, strconv.Itoa(value), err)
+	return value
+}
+
+func (l *loader) getFloat64OrDefault(key string, defaultValue float64) float64 {
+	_, err := strconv.ParseFloat(os.Getenv(key), 64)
+	value := getFloat64OrDefault(key, defaultValue)
+	l.record(key, strconv.FormatFloat(value, 'g', -1, 64), err)
+	return value
+}
+
+func (l *loader) getDurationOrDefault(key string, defaultValue time.Duration) time.Duration {
+	_, err := time.ParseDuration(os.Getenv(key))
+	value := getDurationOrDefault(key, defaultValue)
+	l.record(key, value.String(), err)
+	return value
+}
+
+func (l *loader) getBoolOrDefault(key string, defaultValue bool) bool {
+	_, err := strconv.ParseBool(os.Getenv(key))
+	value := getBoolOrDefault(key, defaultValue)
+	l.record(key, strconv.FormatBool(value), err)
+	return value
+}
+
+func (l *loader) getListOrDefault(key string, defaultValue []string) []string {
+	value := getListOrDefault(key, defaultValue)
+	l.record(key, strings.Join(value, ","), nil)
+	return value
+}
+
+// record appends a resolved setting. parseErr is the error parsing the raw environment
+// value, which is ignored when the variable is unset.
+func (l *loader) record(key string, value string, parseErr error) {
+	source := SourceDefault
+	if os.Getenv(key) != "" {
+		source = SourceEnv
+		if parseErr != nil {
+			source = SourceInvalid
+		}
+	}
+
+	if secretSettings[key] && value != "" {
+		value = RedactedValue
+	}
+
+	l.settings = append(l.settings, Setting{Name: key, Value: value, Source: source})
+}
@@ -0,0 +1,110 @@
+package config
+
+import (
+	"reflect"
+	"testing"
+)
+
+// settingsByName indexes settings by environment variable.
+func settingsByName(settings []Setting) map[string]Setting {
+	byName := make(map[string]Setting, len(settings))
+	for _, setting := range settings {
+		byName[setting.Name] = setting
+	}
+	return byName
+}
+
+func TestLoadWithSources(t *testing.T) {
+	t.Setenv("ARB_MAX_PRICE_SUM", "0.98")
+	t.Setenv("ARB_DETECTION_INTERVAL", "250ms")
+	t.Setenv("WS_POOL_SIZE", "lots")
+	t.Setenv("EXECUTION_PAPER_REALISTIC_FILLS", "true")
+	t.Setenv("LOG_LEVEL", "")
+
+	cfg, settings, err := LoadWithSources()
+	if err != nil {
+		t.Fatalf("LoadWithSources: %v", err)
+	}
+
+	// Every field is reported, so none is resolved outside the loader
+	if got, want := len(settings), reflect.TypeOf(Config{}).NumField(); got != want {
+		t.Errorf("expected %d settings, one per Config field, got %d", want, got)
+	}
+
+	tests := []struct {
+		name   string
+		value  string
+		source string
+	}{
+		{name: "ARB_MAX_PRICE_SUM", value: "0.98", source: SourceEnv},
+		{name: "ARB_DETECTION_INTERVAL", value: "250ms", source: SourceEnv},
+		{name: "EXECUTION_PAPER_REALISTIC_FILLS", value: "true", source: SourceEnv},
+		{name: "WS_POOL_SIZE", value: "20", source: SourceInvalid},
+		{name: "LOG_LEVEL", value: "info", source: SourceDefault},
+	}
+
+	byName := settingsByName(settings)
+	for _, tt := range tests {
+		setting, exists := byName[tt.name]
+		if !exists {
+			t.Errorf("%s: not reported", tt.name)
+			continue
+		}
+		if setting.Value != tt.value || setting.Source != tt.source {
+			t.Errorf("%s: expected %s (%s), got %s (%s)", tt.name, tt.value, tt.source, setting.Value, setting.Source)
+		}
+	}
+
+	// Reported values match what the bot runs with
+	if cfg.ArbMaxPriceSum != 0.98 || cfg.WSPoolSize != 20 {
+		t.Errorf("expected ArbMaxPriceSum 0.98 and WSPoolSize 20, got %v and %d", cfg.ArbMaxPriceSum, cfg.WSPoolSize)
+	}
+}
+
+func TestLoadWithSources_RedactsSecrets(t *testing.T) {
+	t.Setenv("ADMIN_TOKEN", "admin-token-0123456789")
+	t.Setenv("POLYMARKET_API_KEY", "api-key")
+	t.Setenv("POLYMARKET_SECRET", "api-secret")
+	t.Setenv("POLYMARKET_PASSPHRASE", "")
+	t.Setenv("POSTGRES_PASSWORD", "")
+
+	_, settings, err := LoadWithSources()
+	if err != nil {
+		t.Fatalf("LoadWithSources: %v", err)
+	}
+
+	byName := settingsByName(settings)
+	for name := range secretSettings {
+		setting := byName[name]
+
+		want := RedactedValue
+		if name == "POLYMARKET_PASSPHRASE" {
+			// An unset credential shows as empty, so it's clear it's missing
+			want = ""
+		}
+		if setting.Value != want {
+			t.Errorf("%s: expected %q, got %q", name, want, setting.Value)
+		}
+	}
+
+	// The default Postgres password is a credential too
+	if got := byName["POSTGRES_PASSWORD"]; got.Value != RedactedValue || got.Source != SourceDefault {
+		t.Errorf("POSTGRES_PASSWORD: expected redacted default, got %s (%s)", got.Value, got.Source)
+	}
+}
+
+func TestLoadWithSources_InvalidConfig(t *testing.T) {
+	t.Setenv("EXECUTION_MODE", "yolo")
+
+	cfg, settings, err := LoadWithSources()
+	if err == nil {
+		t.Fatal("expected validation error")
+	}
+	if cfg != nil {
+		t.Errorf("expected no config, got %+v", cfg)
+	}
+
+	if got := settingsByName(settings)["EXECUTION_MODE"]; got.Value != "yolo" || got.Source != SourceEnv {
+		t.Errorf("expected EXECUTION_MODE=yolo (env) to be reported, got %s (%s)", got.Value, got.Source)
+	}
+}
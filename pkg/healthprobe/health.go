@@ -2,15 +2,62 @@ package healthprobe
 
 import (
 	"encoding/json"
+	"fmt"
 	"net/http"
+	"strconv"
 	"sync/atomic"
 	"time"
 )
 
+// ConnectionStatus reports whether upstream connections are established.
+type ConnectionStatus interface {
+	IsConnected() bool
+}
+
+// FatalStatus is optionally implemented by a ConnectionStatus whose connections can
+// fail permanently, e.g. after exhausting reconnection attempts.
+type FatalStatus interface {
+	FatalError() error
+}
+
+// DisconnectStatus is optionally implemented by a ConnectionStatus that records why its
+// connection was last lost. The reason is empty if it never dropped.
+type DisconnectStatus interface {
+	LastDisconnect() (reason string, at time.Time)
+}
+
+// TradingStatus reports whether trade execution is allowed.
+type TradingStatus interface {
+	IsEnabled() bool
+}
+
+// UpdateStatus reports when market data was last received.
+type UpdateStatus interface {
+	LastUpdateTime() time.Time
+}
+
+// WarmupStatus reports whether startup warmup has completed, and how many subscribed
+// markets have orderbooks so far.
+type WarmupStatus interface {
+	IsWarm() bool
+	Progress() (ready int, total int)
+}
+
+// Dependencies are the subsystems checked by the /readyz endpoint.
+// Nil dependencies are not checked.
+type Dependencies struct {
+	WebSocket      ConnectionStatus
+	CircuitBreaker TradingStatus
+	Orderbook      UpdateStatus
+	MaxUpdateAge   time.Duration // Max time since last orderbook update (0 = don't check staleness)
+	Warmup         WarmupStatus
+}
+
 // HealthChecker provides health and readiness checks.
 type HealthChecker struct {
 	startTime time.Time
 	ready     atomic.Bool
+	deps      atomic.Pointer[Dependencies]
 }
 
 // New creates a new HealthChecker.
@@ -25,6 +72,11 @@ func (h *HealthChecker) SetReady(ready bool) {
 	h.ready.Store(ready)
 }
 
+// SetDependencies registers the subsystems checked by the /readyz endpoint.
+func (h *HealthChecker) SetDependencies(deps Dependencies) {
+	h.deps.Store(&deps)
+}
+
 // HealthResponse represents the health check response.
 type HealthResponse struct {
 	Status  string `json:"status"`
@@ -74,3 +126,154 @@ func (h *HealthChecker) Ready() http.HandlerFunc {
 		_ = json.NewEncoder(w).Encode(resp)
 	}
 }
+
+// CheckResult is the outcome of a single readiness check.
+type CheckResult struct {
+	Healthy bool              `json:"healthy"`
+	Message string            `json:"message,omitempty"`
+	Details map[string]string `json:"details,omitempty"` // Diagnostics that don't affect Healthy
+}
+
+// ReadinessResponse represents the aggregated readiness check response.
+type ReadinessResponse struct {
+	Status string                 `json:"status"`
+	Uptime string                 `json:"uptime"`
+	Checks map[string]CheckResult `json:"checks"`
+}
+
+// Healthz returns an HTTP handler for liveness checks.
+// Liveness never depends on subsystems: a disconnected websocket should not restart the process.
+func (h *HealthChecker) Healthz() http.HandlerFunc {
+	return h.Health()
+}
+
+// Readyz returns an HTTP handler for aggregated readiness checks.
+// Returns 503 Service Unavailable if startup is incomplete or any registered
+// dependency is unhealthy, with per-check details in the JSON body.
+func (h *HealthChecker) Readyz() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		checks := h.runChecks(time.Now())
+
+		healthy := true
+		for _, check := range checks {
+			if !check.Healthy {
+				healthy = false
+				break
+			}
+		}
+
+		resp := ReadinessResponse{
+			Status: "ready",
+			Uptime: time.Since(h.startTime).String(),
+			Checks: checks,
+		}
+		statusCode := http.StatusOK
+		if !healthy {
+			resp.Status = "not_ready"
+			statusCode = http.StatusServiceUnavailable
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(statusCode)
+		_ = json.NewEncoder(w).Encode(resp)
+	}
+}
+
+// websocketCheck distinguishes an unrecoverable websocket failure from a disconnect
+// that is still being retried. The last disconnect, if recorded, is reported in either
+// state to help diagnose flaky connectivity.
+func websocketCheck(ws ConnectionStatus) CheckResult {
+	result := CheckResult{Healthy: true}
+
+	if status, ok := ws.(DisconnectStatus); ok {
+		reason, at := status.LastDisconnect()
+		if reason != "" {
+			result.Details = map[string]string{
+				"last_disconnect_reason": reason,
+				"last_disconnect_time":   at.UTC().Format(time.RFC3339),
+			}
+		}
+	}
+
+	if fatal, ok := ws.(FatalStatus); ok {
+		if err := fatal.FatalError(); err != nil {
+			result.Healthy = false
+			result.Message = "websocket unrecoverable: " + err.Error()
+			return result
+		}
+	}
+
+	if !ws.IsConnected() {
+		result.Healthy = false
+		result.Message = "websocket disconnected"
+	}
+
+	return result
+}
+
+// warmupCheck fails until warmup completes, reporting how many markets have orderbooks.
+func warmupCheck(warmup WarmupStatus) CheckResult {
+	if warmup.IsWarm() {
+		return CheckResult{Healthy: true}
+	}
+
+	ready, total := warmup.Progress()
+	return CheckResult{
+		Healthy: false,
+		Message: "warming up",
+		Details: map[string]string{
+			"markets_ready": strconv.Itoa(ready),
+			"markets_total": strconv.Itoa(total),
+		},
+	}
+}
+
+// runChecks evaluates startup state and every registered dependency.
+func (h *HealthChecker) runChecks(now time.Time) map[string]CheckResult {
+	checks := make(map[string]CheckResult, 5)
+
+	if h.ready.Load() {
+		checks["startup"] = CheckResult{Healthy: true}
+	} else {
+		checks["startup"] = CheckResult{Healthy: false, Message: "application is starting"}
+	}
+
+	deps := h.deps.Load()
+	if deps == nil {
+		return checks
+	}
+
+	if deps.WebSocket != nil {
+		checks["websocket"] = websocketCheck(deps.WebSocket)
+	}
+
+	if deps.Warmup != nil {
+		checks["warmup"] = warmupCheck(deps.Warmup)
+	}
+
+	if deps.CircuitBreaker != nil {
+		if deps.CircuitBreaker.IsEnabled() {
+			checks["circuit_breaker"] = CheckResult{Healthy: true}
+		} else {
+			checks["circuit_breaker"] = CheckResult{Healthy: false, Message: "trading disabled by circuit breaker"}
+		}
+	}
+
+	if deps.Orderbook != nil && deps.MaxUpdateAge > 0 {
+		lastUpdate := deps.Orderbook.LastUpdateTime()
+		age := now.Sub(lastUpdate)
+		switch {
+		case lastUpdate.IsZero():
+			checks["orderbook"] = CheckResult{Healthy: false, Message: "no orderbook update received"}
+		case age > deps.MaxUpdateAge:
+			checks["orderbook"] = CheckResult{
+				Healthy: false,
+				Message: fmt.Sprintf("last orderbook update %s ago exceeds %s", age.Round(time.Millisecond), deps.MaxUpdateAge),
+			}
+		default:
+			checks["orderbook"] = CheckResult{Healthy: true}
+		}
+	}
+
+	return checks
+}
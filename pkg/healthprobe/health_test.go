@@ -2,6 +2,7 @@ package healthprobe
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -394,3 +395,317 @@ func TestHealthChecker_ConcurrentAccess(t *testing.T) {
 
 	// If we get here without data race, test passes
 }
+
+type fakeConnection struct{ connected bool }
+
+func (f fakeConnection) IsConnected() bool { return f.connected }
+
+// fakeFatalConnection is a connection that can give up permanently.
+type fakeFatalConnection struct {
+	fakeConnection
+	err error
+}
+
+func (f fakeFatalConnection) FatalError() error { return f.err }
+
+// fakeFlakyConnection is a connection that records its last disconnect.
+type fakeFlakyConnection struct {
+	fakeConnection
+	reason string
+	at     time.Time
+}
+
+func (f fakeFlakyConnection) LastDisconnect() (string, time.Time) { return f.reason, f.at }
+
+type fakeTrading struct{ enabled bool }
+
+func (f fakeTrading) IsEnabled() bool { return f.enabled }
+
+type fakeUpdates struct{ last time.Time }
+
+func (f fakeUpdates) LastUpdateTime() time.Time { return f.last }
+
+type fakeWarmup struct {
+	warm         bool
+	ready, total int
+}
+
+func (f fakeWarmup) IsWarm() bool { return f.warm }
+
+func (f fakeWarmup) Progress() (int, int) { return f.ready, f.total }
+
+func TestHealthz_AlwaysReturnsOK(t *testing.T) {
+	hc := New()
+	hc.SetDependencies(Dependencies{
+		WebSocket:      fakeConnection{connected: false},
+		CircuitBreaker: fakeTrading{enabled: false},
+	})
+
+	w := httptest.NewRecorder()
+	hc.Healthz()(w, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+
+	if w.Code != http.StatusOK {
+		t.Errorf("Status code = %d, want %d", w.Code, http.StatusOK)
+	}
+}
+
+func TestReadyz(t *testing.T) {
+	healthyDeps := func() Dependencies {
+		return Dependencies{
+			WebSocket:      fakeConnection{connected: true},
+			CircuitBreaker: fakeTrading{enabled: true},
+			Orderbook:      fakeUpdates{last: time.Now()},
+			MaxUpdateAge:   time.Minute,
+			Warmup:         fakeWarmup{warm: true},
+		}
+	}
+
+	tests := []struct {
+		name         string
+		ready        bool
+		deps         func() Dependencies
+		expectedCode int
+		failedCheck  string
+	}{
+		{
+			name:         "all_healthy",
+			ready:        true,
+			deps:         healthyDeps,
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:         "not_started",
+			ready:        false,
+			deps:         healthyDeps,
+			expectedCode: http.StatusServiceUnavailable,
+			failedCheck:  "startup",
+		},
+		{
+			name:  "websocket_disconnected",
+			ready: true,
+			deps: func() Dependencies {
+				deps := healthyDeps()
+				deps.WebSocket = fakeConnection{connected: false}
+				return deps
+			},
+			expectedCode: http.StatusServiceUnavailable,
+			failedCheck:  "websocket",
+		},
+		{
+			name:  "websocket_still_retrying",
+			ready: true,
+			deps: func() Dependencies {
+				deps := healthyDeps()
+				deps.WebSocket = fakeFatalConnection{fakeConnection: fakeConnection{connected: true}}
+				return deps
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:  "websocket_unrecoverable",
+			ready: true,
+			deps: func() Dependencies {
+				deps := healthyDeps()
+				deps.WebSocket = fakeFatalConnection{err: errors.New("reconnection attempts exhausted")}
+				return deps
+			},
+			expectedCode: http.StatusServiceUnavailable,
+			failedCheck:  "websocket",
+		},
+		{
+			name:  "circuit_breaker_disabled",
+			ready: true,
+			deps: func() Dependencies {
+				deps := healthyDeps()
+				deps.CircuitBreaker = fakeTrading{enabled: false}
+				return deps
+			},
+			expectedCode: http.StatusServiceUnavailable,
+			failedCheck:  "circuit_breaker",
+		},
+		{
+			name:  "orderbook_stale",
+			ready: true,
+			deps: func() Dependencies {
+				deps := healthyDeps()
+				deps.Orderbook = fakeUpdates{last: time.Now().Add(-2 * time.Minute)}
+				return deps
+			},
+			expectedCode: http.StatusServiceUnavailable,
+			failedCheck:  "orderbook",
+		},
+		{
+			name:  "orderbook_never_updated",
+			ready: true,
+			deps: func() Dependencies {
+				deps := healthyDeps()
+				deps.Orderbook = fakeUpdates{}
+				return deps
+			},
+			expectedCode: http.StatusServiceUnavailable,
+			failedCheck:  "orderbook",
+		},
+		{
+			name:  "warming_up",
+			ready: true,
+			deps: func() Dependencies {
+				deps := healthyDeps()
+				deps.Warmup = fakeWarmup{ready: 3, total: 10}
+				return deps
+			},
+			expectedCode: http.StatusServiceUnavailable,
+			failedCheck:  "warmup",
+		},
+		{
+			name:  "staleness_check_disabled",
+			ready: true,
+			deps: func() Dependencies {
+				deps := healthyDeps()
+				deps.Orderbook = fakeUpdates{}
+				deps.MaxUpdateAge = 0
+				return deps
+			},
+			expectedCode: http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hc := New()
+			hc.SetReady(tt.ready)
+			hc.SetDependencies(tt.deps())
+
+			w := httptest.NewRecorder()
+			hc.Readyz()(w, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+
+			if w.Code != tt.expectedCode {
+				t.Errorf("Status code = %d, want %d", w.Code, tt.expectedCode)
+			}
+
+			var resp ReadinessResponse
+			err := json.NewDecoder(w.Body).Decode(&resp)
+			if err != nil {
+				t.Fatalf("Failed to decode response: %v", err)
+			}
+
+			for name, check := range resp.Checks {
+				shouldFail := name == tt.failedCheck
+				if check.Healthy == shouldFail {
+					t.Errorf("check %q healthy = %v, want %v", name, check.Healthy, !shouldFail)
+				}
+				if shouldFail && check.Message == "" {
+					t.Errorf("check %q should include a failure message", name)
+				}
+			}
+
+			if tt.failedCheck != "" {
+				if _, exists := resp.Checks[tt.failedCheck]; !exists {
+					t.Errorf("expected check %q in response", tt.failedCheck)
+				}
+			}
+		})
+	}
+}
+
+func TestReadyz_NoDependencies(t *testing.T) {
+	hc := New()
+	hc.SetReady(true)
+
+	w := httptest.NewRecorder()
+	hc.Readyz()(w, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+
+	if w.Code != http.StatusOK {
+		t.Errorf("Status code = %d, want %d", w.Code, http.StatusOK)
+	}
+
+	var resp ReadinessResponse
+	err := json.NewDecoder(w.Body).Decode(&resp)
+	if err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+
+	if len(resp.Checks) != 1 {
+		t.Errorf("expected only the startup check, got %v", resp.Checks)
+	}
+}
+
+// TestReadyz_WebSocketLastDisconnect tests that the last disconnect is reported in the
+// websocket check's details without affecting its health.
+func TestReadyz_WebSocketLastDisconnect(t *testing.T) {
+	at := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	tests := []struct {
+		name        string
+		ws          fakeFlakyConnection
+		wantHealthy bool
+		wantDetails map[string]string
+	}{
+		{
+			name:        "never_disconnected",
+			ws:          fakeFlakyConnection{fakeConnection: fakeConnection{connected: true}},
+			wantHealthy: true,
+		},
+		{
+			name:        "reconnected",
+			ws:          fakeFlakyConnection{fakeConnection: fakeConnection{connected: true}, reason: "eof", at: at},
+			wantHealthy: true,
+			wantDetails: map[string]string{"last_disconnect_reason": "eof", "last_disconnect_time": "2026-01-02T03:04:05Z"},
+		},
+		{
+			name:        "disconnected",
+			ws:          fakeFlakyConnection{reason: "timeout", at: at},
+			wantHealthy: false,
+			wantDetails: map[string]string{"last_disconnect_reason": "timeout", "last_disconnect_time": "2026-01-02T03:04:05Z"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hc := New()
+			hc.SetReady(true)
+			hc.SetDependencies(Dependencies{WebSocket: tt.ws})
+
+			w := httptest.NewRecorder()
+			hc.Readyz()(w, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+
+			var resp ReadinessResponse
+			err := json.NewDecoder(w.Body).Decode(&resp)
+			if err != nil {
+				t.Fatalf("Failed to decode response: %v", err)
+			}
+
+			check := resp.Checks["websocket"]
+			if check.Healthy != tt.wantHealthy {
+				t.Errorf("websocket healthy = %v, want %v", check.Healthy, tt.wantHealthy)
+			}
+			if len(check.Details) != len(tt.wantDetails) {
+				t.Fatalf("details = %v, want %v", check.Details, tt.wantDetails)
+			}
+			for key, want := range tt.wantDetails {
+				if check.Details[key] != want {
+					t.Errorf("details[%q] = %q, want %q", key, check.Details[key], want)
+				}
+			}
+		})
+	}
+}
+
+func TestReadyz_WarmupProgress(t *testing.T) {
+	hc := New()
+	hc.SetReady(true)
+	hc.SetDependencies(Dependencies{Warmup: fakeWarmup{ready: 3, total: 10}})
+
+	w := httptest.NewRecorder()
+	hc.Readyz()(w, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+
+	var resp ReadinessResponse
+	err := json.NewDecoder(w.Body).Decode(&resp)
+	if err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+
+	check := resp.Checks["warmup"]
+	if check.Details["markets_ready"] != "3" || check.Details["markets_total"] != "10" {
+		t.Errorf("details = %v, want markets_ready=3 markets_total=10", check.Details)
+	}
+}
@@ -0,0 +1,150 @@
+package httpserver
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"net/http"
+
+	"github.com/mselser95/polymarket-arb/internal/circuitbreaker"
+	"go.uber.org/zap"
+)
+
+// AdminTokenHeader carries the shared secret required by /admin endpoints.
+const AdminTokenHeader = "X-Admin-Token"
+
+// ExecutionController pauses and resumes trade execution.
+// circuitbreaker.BalanceCircuitBreaker implements this interface.
+type ExecutionController interface {
+	Pause()
+	Resume()
+	GetStatus() circuitbreaker.Status
+}
+
+// ThresholdController reads and adjusts the detection threshold.
+// arbitrage.Detector implements this interface.
+type ThresholdController interface {
+	GetThreshold() float64
+	SetThreshold(threshold float64) error
+}
+
+// AdminHandler handles authenticated operator controls.
+type AdminHandler struct {
+	token     string
+	execution ExecutionController // nil when no circuit breaker is running
+	threshold ThresholdController
+	logger    *zap.Logger
+}
+
+// AdminStatus is the response body of GET /admin/status and the control actions.
+type AdminStatus struct {
+	Paused         bool    `json:"paused"`
+	BreakerEnabled *bool   `json:"breaker_enabled,omitempty"` // Balance state; omitted without a circuit breaker
+	Threshold      float64 `json:"threshold"`
+}
+
+type thresholdRequest struct {
+	Value *float64 `json:"value"`
+}
+
+type adminError struct {
+	Error string `json:"error"`
+}
+
+// NewAdminHandler creates a new admin handler. execution may be nil, in which case
+// pause and resume report that no circuit breaker is running.
+func NewAdminHandler(token string, execution ExecutionController, threshold ThresholdController, logger *zap.Logger) *AdminHandler {
+	return &AdminHandler{
+		token:     token,
+		execution: execution,
+		threshold: threshold,
+		logger:    logger,
+	}
+}
+
+// Authenticate rejects requests whose AdminTokenHeader does not match the shared secret.
+func (h *AdminHandler) Authenticate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		provided := r.Header.Get(AdminTokenHeader)
+		if subtle.ConstantTimeCompare([]byte(provided), []byte(h.token)) != 1 {
+			h.logger.Warn("admin-request-unauthorized",
+				zap.String("path", r.URL.Path),
+				zap.String("remote-addr", r.RemoteAddr))
+			h.writeJSON(w, http.StatusUnauthorized, adminError{Error: "unauthorized"})
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// HandleStatus handles GET /admin/status requests.
+func (h *AdminHandler) HandleStatus(w http.ResponseWriter, _ *http.Request) {
+	h.writeJSON(w, http.StatusOK, h.status())
+}
+
+// HandlePause handles POST /admin/pause requests.
+func (h *AdminHandler) HandlePause(w http.ResponseWriter, r *http.Request) {
+	if h.execution == nil {
+		h.writeJSON(w, http.StatusServiceUnavailable, adminError{Error: "circuit breaker not running"})
+		return
+	}
+
+	h.execution.Pause()
+	h.logger.Warn("admin-paused-execution", zap.String("remote-addr", r.RemoteAddr))
+	h.writeJSON(w, http.StatusOK, h.status())
+}
+
+// HandleResume handles POST /admin/resume requests.
+func (h *AdminHandler) HandleResume(w http.ResponseWriter, r *http.Request) {
+	if h.execution == nil {
+		h.writeJSON(w, http.StatusServiceUnavailable, adminError{Error: "circuit breaker not running"})
+		return
+	}
+
+	h.execution.Resume()
+	h.logger.Info("admin-resumed-execution", zap.String("remote-addr", r.RemoteAddr))
+	h.writeJSON(w, http.StatusOK, h.status())
+}
+
+// HandleThreshold handles POST /admin/threshold requests with a {"value": <float>} body.
+func (h *AdminHandler) HandleThreshold(w http.ResponseWriter, r *http.Request) {
+	var req thresholdRequest
+	err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 1024)).Decode(&req)
+	if err != nil || req.Value == nil {
+		h.writeJSON(w, http.StatusBadRequest, adminError{Error: `body must be {"value": <threshold>}`})
+		return
+	}
+
+	err = h.threshold.SetThreshold(*req.Value)
+	if err != nil {
+		h.writeJSON(w, http.StatusBadRequest, adminError{Error: err.Error()})
+		return
+	}
+
+	h.logger.Info("admin-set-threshold",
+		zap.Float64("threshold", *req.Value),
+		zap.String("remote-addr", r.RemoteAddr))
+	h.writeJSON(w, http.StatusOK, h.status())
+}
+
+func (h *AdminHandler) status() AdminStatus {
+	status := AdminStatus{Threshold: h.threshold.GetThreshold()}
+
+	if h.execution != nil {
+		breaker := h.execution.GetStatus()
+		status.Paused = breaker.Paused
+		status.BreakerEnabled = &breaker.Enabled
+	}
+
+	return status
+}
+
+func (h *AdminHandler) writeJSON(w http.ResponseWriter, code int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+
+	err := json.NewEncoder(w).Encode(body)
+	if err != nil {
+		h.logger.Error("failed-to-encode-admin-response", zap.Error(err))
+	}
+}
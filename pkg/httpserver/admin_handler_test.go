@@ -0,0 +1,238 @@
+package httpserver
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/mselser95/polymarket-arb/internal/arbitrage"
+	"github.com/mselser95/polymarket-arb/internal/circuitbreaker"
+	"github.com/mselser95/polymarket-arb/internal/execution"
+	"github.com/mselser95/polymarket-arb/internal/testutil"
+	"github.com/mselser95/polymarket-arb/pkg/healthprobe"
+	"go.uber.org/zap"
+)
+
+const testAdminToken = "test-admin-token-1234"
+
+type mockExecutionController struct {
+	mu     sync.Mutex
+	paused bool
+}
+
+func (m *mockExecutionController) Pause() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.paused = true
+}
+
+func (m *mockExecutionController) Resume() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.paused = false
+}
+
+func (m *mockExecutionController) GetStatus() circuitbreaker.Status {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return circuitbreaker.Status{Enabled: true, Paused: m.paused}
+}
+
+type mockThresholdController struct {
+	threshold float64
+}
+
+func (m *mockThresholdController) GetThreshold() float64 {
+	return m.threshold
+}
+
+func (m *mockThresholdController) SetThreshold(threshold float64) error {
+	if threshold <= 0 || threshold > 1.10 {
+		return errors.New("threshold out of range")
+	}
+	m.threshold = threshold
+	return nil
+}
+
+func newAdminTestServer(execution ExecutionController, threshold ThresholdController) *Server {
+	return New(&Config{
+		Port:                "8080",
+		Logger:              zap.NewNop(),
+		HealthChecker:       healthprobe.New(),
+		AdminToken:          testAdminToken,
+		ExecutionController: execution,
+		ThresholdController: threshold,
+	})
+}
+
+func doAdminRequest(server *Server, method string, path string, token string, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	if token != "" {
+		req.Header.Set(AdminTokenHeader, token)
+	}
+
+	w := httptest.NewRecorder()
+	server.server.Handler.ServeHTTP(w, req)
+	return w
+}
+
+// TestAdmin_RejectsUnauthenticated tests that every admin endpoint requires the shared secret.
+func TestAdmin_RejectsUnauthenticated(t *testing.T) {
+	execution := &mockExecutionController{}
+	threshold := &mockThresholdController{threshold: 0.995}
+	server := newAdminTestServer(execution, threshold)
+
+	endpoints := []struct {
+		method string
+		path   string
+		body   string
+	}{
+		{http.MethodGet, "/admin/status", ""},
+		{http.MethodPost, "/admin/pause", ""},
+		{http.MethodPost, "/admin/resume", ""},
+		{http.MethodPost, "/admin/threshold", `{"value":0.9}`},
+	}
+
+	for _, ep := range endpoints {
+		for _, token := range []string{"", "wrong-token", testAdminToken + "x"} {
+			w := doAdminRequest(server, ep.method, ep.path, token, ep.body)
+			if w.Code != http.StatusUnauthorized {
+				t.Errorf("%s %s with token %q: expected 401, got %d", ep.method, ep.path, token, w.Code)
+			}
+		}
+	}
+
+	if execution.paused || threshold.threshold != 0.995 {
+		t.Error("unauthenticated requests must not change state")
+	}
+}
+
+// TestAdmin_DisabledWithoutToken tests that admin routes are not registered without a secret.
+func TestAdmin_DisabledWithoutToken(t *testing.T) {
+	server := New(&Config{
+		Port:                "8080",
+		Logger:              zap.NewNop(),
+		HealthChecker:       healthprobe.New(),
+		ThresholdController: &mockThresholdController{threshold: 0.995},
+	})
+
+	w := doAdminRequest(server, http.MethodGet, "/admin/status", "", "")
+	if w.Code != http.StatusNotFound {
+		t.Errorf("expected 404 without admin token configured, got %d", w.Code)
+	}
+}
+
+// TestAdmin_Controls tests pause, resume, threshold and status with a valid token.
+func TestAdmin_Controls(t *testing.T) {
+	execution := &mockExecutionController{}
+	threshold := &mockThresholdController{threshold: 0.995}
+	server := newAdminTestServer(execution, threshold)
+
+	decode := func(t *testing.T, w *httptest.ResponseRecorder) AdminStatus {
+		t.Helper()
+		if w.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+		}
+		var status AdminStatus
+		err := json.Unmarshal(w.Body.Bytes(), &status)
+		if err != nil {
+			t.Fatalf("decode status: %v", err)
+		}
+		return status
+	}
+
+	status := decode(t, doAdminRequest(server, http.MethodPost, "/admin/pause", testAdminToken, ""))
+	if !status.Paused || !execution.paused {
+		t.Errorf("expected paused after /admin/pause, got %+v", status)
+	}
+
+	status = decode(t, doAdminRequest(server, http.MethodPost, "/admin/resume", testAdminToken, ""))
+	if status.Paused || execution.paused {
+		t.Errorf("expected resumed after /admin/resume, got %+v", status)
+	}
+
+	status = decode(t, doAdminRequest(server, http.MethodPost, "/admin/threshold", testAdminToken, `{"value":0.98}`))
+	if status.Threshold != 0.98 || threshold.threshold != 0.98 {
+		t.Errorf("expected threshold 0.98, got %+v", status)
+	}
+
+	status = decode(t, doAdminRequest(server, http.MethodGet, "/admin/status", testAdminToken, ""))
+	if status.Threshold != 0.98 || status.Paused || status.BreakerEnabled == nil || !*status.BreakerEnabled {
+		t.Errorf("unexpected status: %+v", status)
+	}
+
+	for _, body := range []string{`{"value":1.5}`, `{"value":0}`, `{}`, `not json`} {
+		w := doAdminRequest(server, http.MethodPost, "/admin/threshold", testAdminToken, body)
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("threshold body %q: expected 400, got %d", body, w.Code)
+		}
+	}
+	if threshold.threshold != 0.98 {
+		t.Errorf("rejected updates must not change threshold, got %f", threshold.threshold)
+	}
+}
+
+// TestAdmin_PauseWithoutBreaker tests that pause and resume report an error when no
+// circuit breaker is running, while threshold controls still work.
+func TestAdmin_PauseWithoutBreaker(t *testing.T) {
+	server := newAdminTestServer(nil, &mockThresholdController{threshold: 0.995})
+
+	for _, path := range []string{"/admin/pause", "/admin/resume"} {
+		w := doAdminRequest(server, http.MethodPost, path, testAdminToken, "")
+		if w.Code != http.StatusServiceUnavailable {
+			t.Errorf("%s: expected 503, got %d", path, w.Code)
+		}
+	}
+
+	w := doAdminRequest(server, http.MethodGet, "/admin/status", testAdminToken, "")
+	if w.Code != http.StatusOK || strings.Contains(w.Body.String(), "breaker_enabled") {
+		t.Errorf("expected status without breaker state, got %d: %s", w.Code, w.Body.String())
+	}
+}
+
+// TestAdmin_PauseGatesPaperExecution tests that a pause through the admin API stops a
+// paper-mode executor whose circuit breaker only records trades, and resume restarts it.
+func TestAdmin_PauseGatesPaperExecution(t *testing.T) {
+	breaker, err := circuitbreaker.New(&circuitbreaker.Config{
+		CheckInterval:   time.Minute,
+		TradeMultiplier: 3.0,
+		MinAbsolute:     5.0,
+		HysteresisRatio: 1.5,
+		WalletClient:    testutil.NewMockWalletClient(),
+		Address:         common.HexToAddress("0x1234567890abcdef1234567890abcdef12345678"),
+		Logger:          zap.NewNop(),
+	})
+	if err != nil {
+		t.Fatalf("create breaker: %v", err)
+	}
+
+	exec := execution.New(&execution.Config{
+		Mode:           "paper",
+		Logger:         zap.NewNop(),
+		CircuitBreaker: breaker,
+		RecordAllModes: true,
+	})
+	server := newAdminTestServer(breaker, &mockThresholdController{threshold: 0.995})
+
+	w := doAdminRequest(server, http.MethodPost, "/admin/pause", testAdminToken, "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("pause: expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if result := exec.Execute(arbitrage.CreateTestOpportunity("market-1", "slug-1")); result != nil {
+		t.Errorf("expected paper execution skipped while paused, got %+v", result)
+	}
+
+	w = doAdminRequest(server, http.MethodPost, "/admin/resume", testAdminToken, "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("resume: expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if result := exec.Execute(arbitrage.CreateTestOpportunity("market-2", "slug-2")); result == nil || !result.Success {
+		t.Errorf("expected paper execution after resume, got %+v", result)
+	}
+}
@@ -29,6 +29,12 @@ type Config struct {
 	HealthChecker    *healthprobe.HealthChecker
 	OrderbookManager *orderbook.Manager
 	DiscoveryService *discovery.Service
+	StatsProvider    StatsProvider // Optional: serves /stats when set
+
+	// Admin API: served under /admin when AdminToken and ThresholdController are set
+	AdminToken          string
+	ExecutionController ExecutionController // Optional: enables pause/resume
+	ThresholdController ThresholdController
 }
 
 // New creates a new HTTP server.
@@ -45,6 +51,8 @@ func New(cfg *Config) *Server {
 	r.Get("/metrics", promhttp.Handler().ServeHTTP)
 	r.Get("/health", cfg.HealthChecker.Health())
 	r.Get("/ready", cfg.HealthChecker.Ready())
+	r.Get("/healthz", cfg.HealthChecker.Healthz())
+	r.Get("/readyz", cfg.HealthChecker.Readyz())
 
 	// Orderbook API endpoint (if components provided)
 	if cfg.OrderbookManager != nil && cfg.DiscoveryService != nil {
@@ -52,6 +60,24 @@ func New(cfg *Config) *Server {
 		r.Get("/api/orderbook", obHandler.HandleOrderbook)
 	}
 
+	// Executor stats endpoint (if provider supplied)
+	if cfg.StatsProvider != nil {
+		statsHandler := NewStatsHandler(cfg.StatsProvider, cfg.Logger)
+		r.Get("/stats", statsHandler.HandleStats)
+	}
+
+	// Admin API (if a shared secret is configured)
+	if cfg.AdminToken != "" && cfg.ThresholdController != nil {
+		adminHandler := NewAdminHandler(cfg.AdminToken, cfg.ExecutionController, cfg.ThresholdController, cfg.Logger)
+		r.Route("/admin", func(r chi.Router) {
+			r.Use(adminHandler.Authenticate)
+			r.Get("/status", adminHandler.HandleStatus)
+			r.Post("/pause", adminHandler.HandlePause)
+			r.Post("/resume", adminHandler.HandleResume)
+			r.Post("/threshold", adminHandler.HandleThreshold)
+		})
+	}
+
 	server := &http.Server{
 		Addr:              ":" + cfg.Port,
 		Handler:           r,
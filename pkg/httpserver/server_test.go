@@ -11,6 +11,7 @@ import (
 	"time"
 
 	"github.com/mselser95/polymarket-arb/internal/discovery"
+	"github.com/mselser95/polymarket-arb/internal/execution"
 	"github.com/mselser95/polymarket-arb/internal/orderbook"
 	"github.com/mselser95/polymarket-arb/pkg/healthprobe"
 	"github.com/mselser95/polymarket-arb/pkg/types"
@@ -551,3 +552,75 @@ func TestOrderbookEndpoint_OnlyWithComponents(t *testing.T) {
 		})
 	}
 }
+
+// fakeStatsProvider returns fixed executor stats.
+type fakeStatsProvider struct {
+	stats execution.Stats
+}
+
+func (f *fakeStatsProvider) Stats() execution.Stats {
+	return f.stats
+}
+
+func TestStatsEndpoint(t *testing.T) {
+	logger := zap.NewNop()
+	healthChecker := healthprobe.New()
+
+	provider := &fakeStatsProvider{stats: execution.Stats{
+		Mode:              "paper",
+		CumulativeProfit:  2.5,
+		TotalFilledLegs:   4,
+		TradesByMode:      map[string]map[string]int{"paper": {"YES": 2, "NO": 2}},
+		FillVerifications: map[string]int{},
+	}}
+
+	server := New(&Config{
+		Port:          "0",
+		Logger:        logger,
+		HealthChecker: healthChecker,
+		StatsProvider: provider,
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/stats", nil)
+	w := httptest.NewRecorder()
+
+	server.server.Handler.ServeHTTP(w, req)
+
+	resp := w.Result()
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("Stats endpoint status = %d, want %d", resp.StatusCode, http.StatusOK)
+	}
+
+	if ct := resp.Header.Get("Content-Type"); ct != "application/json" {
+		t.Errorf("Content-Type = %s, want application/json", ct)
+	}
+
+	var got execution.Stats
+	err := json.NewDecoder(resp.Body).Decode(&got)
+	if err != nil {
+		t.Fatalf("Failed to decode stats response: %v", err)
+	}
+
+	if got.CumulativeProfit != 2.5 || got.TotalFilledLegs != 4 || got.TradesByMode["paper"]["YES"] != 2 {
+		t.Errorf("unexpected stats response: %+v", got)
+	}
+}
+
+func TestStatsEndpoint_NotRegisteredWithoutProvider(t *testing.T) {
+	server := New(&Config{
+		Port:          "0",
+		Logger:        zap.NewNop(),
+		HealthChecker: healthprobe.New(),
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/stats", nil)
+	w := httptest.NewRecorder()
+
+	server.server.Handler.ServeHTTP(w, req)
+
+	if w.Code != http.StatusNotFound {
+		t.Errorf("Stats endpoint status = %d, want %d", w.Code, http.StatusNotFound)
+	}
+}
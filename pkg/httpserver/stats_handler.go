@@ -0,0 +1,39 @@
+package httpserver
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/mselser95/polymarket-arb/internal/execution"
+	"go.uber.org/zap"
+)
+
+// StatsProvider supplies executor performance stats.
+type StatsProvider interface {
+	Stats() execution.Stats
+}
+
+// StatsHandler handles HTTP requests for executor stats.
+type StatsHandler struct {
+	provider StatsProvider
+	logger   *zap.Logger
+}
+
+// NewStatsHandler creates a new stats handler.
+func NewStatsHandler(provider StatsProvider, logger *zap.Logger) *StatsHandler {
+	return &StatsHandler{
+		provider: provider,
+		logger:   logger,
+	}
+}
+
+// HandleStats handles GET /stats requests.
+func (h *StatsHandler) HandleStats(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	err := json.NewEncoder(w).Encode(h.provider.Stats())
+	if err != nil {
+		h.logger.Error("failed-to-encode-stats-response", zap.Error(err))
+	}
+}
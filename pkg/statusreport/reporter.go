@@ -0,0 +1,144 @@
+// Package statusreport periodically logs a single-line operational summary
+// for deployments that don't scrape Prometheus.
+package statusreport
+
+import (
+	"context"
+	"time"
+
+	"github.com/mselser95/polymarket-arb/pkg/types"
+	"go.uber.org/zap"
+)
+
+// ConnectionCounter reports how many WebSocket connections are up.
+type ConnectionCounter interface {
+	ConnectedCount() int
+}
+
+// MarketLister reports the currently subscribed markets.
+type MarketLister interface {
+	GetSubscribedMarkets() []*types.MarketSubscription
+}
+
+// OpportunityCounter reports the total opportunities detected so far.
+type OpportunityCounter interface {
+	OpportunityCount() uint64
+}
+
+// ProfitReporter reports cumulative realized profit.
+type ProfitReporter interface {
+	CumulativeProfit() float64
+}
+
+// TradingStatus reports whether trade execution is allowed.
+type TradingStatus interface {
+	IsEnabled() bool
+}
+
+// defaultInterval is used when Config.Interval is unset.
+const defaultInterval = 60 * time.Second
+
+// StatusReporter logs one structured status line per interval.
+type StatusReporter struct {
+	interval       time.Duration
+	logger         *zap.Logger
+	connections    ConnectionCounter
+	markets        MarketLister
+	opportunities  OpportunityCounter
+	profit         ProfitReporter
+	circuitBreaker TradingStatus
+
+	lastCount uint64
+	lastTime  time.Time
+}
+
+// Config holds status reporter configuration.
+// Nil components are omitted from the status line.
+type Config struct {
+	Interval       time.Duration // Time between status lines (0 = default 60s)
+	Logger         *zap.Logger
+	Connections    ConnectionCounter
+	Markets        MarketLister
+	Opportunities  OpportunityCounter
+	Profit         ProfitReporter
+	CircuitBreaker TradingStatus
+}
+
+// New creates a new status reporter.
+func New(cfg *Config) *StatusReporter {
+	interval := cfg.Interval
+	if interval <= 0 {
+		interval = defaultInterval
+	}
+
+	return &StatusReporter{
+		interval:       interval,
+		logger:         cfg.Logger,
+		connections:    cfg.Connections,
+		markets:        cfg.Markets,
+		opportunities:  cfg.Opportunities,
+		profit:         cfg.Profit,
+		circuitBreaker: cfg.CircuitBreaker,
+	}
+}
+
+// Run logs a status line every interval until ctx is canceled.
+func (r *StatusReporter) Run(ctx context.Context) error {
+	r.lastTime = time.Now()
+	if r.opportunities != nil {
+		r.lastCount = r.opportunities.OpportunityCount()
+	}
+
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case now := <-ticker.C:
+			r.report(now)
+		}
+	}
+}
+
+// report logs one status line. Opportunity rate covers the time since the previous line.
+func (r *StatusReporter) report(now time.Time) {
+	fields := make([]zap.Field, 0, 5)
+
+	if r.connections != nil {
+		fields = append(fields, zap.Int("connections-up", r.connections.ConnectedCount()))
+	}
+
+	if r.markets != nil {
+		fields = append(fields, zap.Int("subscribed-markets", len(r.markets.GetSubscribedMarkets())))
+	}
+
+	if r.opportunities != nil {
+		count := r.opportunities.OpportunityCount()
+
+		perMinute := 0.0
+		elapsed := now.Sub(r.lastTime)
+		if elapsed > 0 {
+			perMinute = float64(count-r.lastCount) / elapsed.Minutes()
+		}
+
+		fields = append(fields, zap.Float64("opportunities-per-min", perMinute))
+		r.lastCount = count
+		r.lastTime = now
+	}
+
+	if r.profit != nil {
+		fields = append(fields, zap.Float64("cumulative-profit-usd", r.profit.CumulativeProfit()))
+	}
+
+	if r.circuitBreaker != nil {
+		state := "enabled"
+		if !r.circuitBreaker.IsEnabled() {
+			state = "disabled"
+		}
+		fields = append(fields, zap.String("circuit-breaker", state))
+	}
+
+	r.logger.Info("status", fields...)
+}
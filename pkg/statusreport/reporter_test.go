@@ -0,0 +1,127 @@
+package statusreport
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/mselser95/polymarket-arb/pkg/types"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+type mockConnections struct{ count int }
+
+func (m *mockConnections) ConnectedCount() int { return m.count }
+
+type mockMarkets struct{ count int }
+
+func (m *mockMarkets) GetSubscribedMarkets() []*types.MarketSubscription {
+	return make([]*types.MarketSubscription, m.count)
+}
+
+type mockOpportunities struct{ count uint64 }
+
+func (m *mockOpportunities) OpportunityCount() uint64 { return m.count }
+
+type mockProfit struct{ profit float64 }
+
+func (m *mockProfit) CumulativeProfit() float64 { return m.profit }
+
+type mockBreaker struct{ enabled bool }
+
+func (m *mockBreaker) IsEnabled() bool { return m.enabled }
+
+func TestReport_Fields(t *testing.T) {
+	core, logs := observer.New(zapcore.InfoLevel)
+	opportunities := &mockOpportunities{count: 10}
+
+	reporter := New(&Config{
+		Interval:       time.Minute,
+		Logger:         zap.New(core),
+		Connections:    &mockConnections{count: 18},
+		Markets:        &mockMarkets{count: 250},
+		Opportunities:  opportunities,
+		Profit:         &mockProfit{profit: 12.5},
+		CircuitBreaker: &mockBreaker{enabled: false},
+	})
+
+	// 30 new opportunities over 2 minutes
+	start := time.Now()
+	reporter.lastTime = start
+	reporter.lastCount = opportunities.count
+	opportunities.count = 40
+	reporter.report(start.Add(2 * time.Minute))
+
+	entries := logs.FilterMessage("status").All()
+	if len(entries) != 1 {
+		t.Fatalf("expected 1 status line, got %d", len(entries))
+	}
+
+	fields := entries[0].ContextMap()
+
+	if fields["connections-up"] != int64(18) {
+		t.Errorf("connections-up = %v, want 18", fields["connections-up"])
+	}
+
+	if fields["subscribed-markets"] != int64(250) {
+		t.Errorf("subscribed-markets = %v, want 250", fields["subscribed-markets"])
+	}
+
+	if fields["opportunities-per-min"] != 15.0 {
+		t.Errorf("opportunities-per-min = %v, want 15", fields["opportunities-per-min"])
+	}
+
+	if fields["cumulative-profit-usd"] != 12.5 {
+		t.Errorf("cumulative-profit-usd = %v, want 12.5", fields["cumulative-profit-usd"])
+	}
+
+	if fields["circuit-breaker"] != "disabled" {
+		t.Errorf("circuit-breaker = %v, want disabled", fields["circuit-breaker"])
+	}
+}
+
+func TestReport_OmitsNilComponents(t *testing.T) {
+	core, logs := observer.New(zapcore.InfoLevel)
+
+	reporter := New(&Config{
+		Logger:      zap.New(core),
+		Connections: &mockConnections{count: 1},
+	})
+	reporter.report(time.Now())
+
+	fields := logs.FilterMessage("status").All()[0].ContextMap()
+	if len(fields) != 1 {
+		t.Errorf("expected only connections-up, got %v", fields)
+	}
+}
+
+func TestNew_DefaultInterval(t *testing.T) {
+	reporter := New(&Config{Logger: zap.NewNop()})
+	if reporter.interval != defaultInterval {
+		t.Errorf("expected default interval %v, got %v", defaultInterval, reporter.interval)
+	}
+}
+
+func TestRun_EmitsPeriodically(t *testing.T) {
+	core, logs := observer.New(zapcore.InfoLevel)
+
+	reporter := New(&Config{
+		Interval:    10 * time.Millisecond,
+		Logger:      zap.New(core),
+		Connections: &mockConnections{count: 2},
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 55*time.Millisecond)
+	defer cancel()
+
+	err := reporter.Run(ctx)
+	if err == nil {
+		t.Fatal("expected context error when Run stops")
+	}
+
+	if got := logs.FilterMessage("status").Len(); got < 2 {
+		t.Errorf("expected at least 2 status lines, got %d", got)
+	}
+}
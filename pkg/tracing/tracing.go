@@ -0,0 +1,48 @@
+package tracing
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// LogKey is the structured log field carrying the trace ID.
+const LogKey = "trace-id"
+
+type traceIDKey struct{}
+
+// NewID returns a new trace ID. One is generated per detected opportunity and follows it
+// through execution, order placement and fill verification.
+func NewID() string {
+	return uuid.New().String()
+}
+
+// WithID returns a copy of ctx carrying the trace ID. An empty ID leaves ctx unchanged.
+func WithID(ctx context.Context, id string) context.Context {
+	if id == "" {
+		return ctx
+	}
+	return context.WithValue(ctx, traceIDKey{}, id)
+}
+
+// IDFromContext returns the trace ID carried by ctx, or "" if there is none.
+func IDFromContext(ctx context.Context) string {
+	id, _ := ctx.Value(traceIDKey{}).(string)
+	return id
+}
+
+// Field returns the log field for a trace ID.
+func Field(id string) zap.Field {
+	return zap.String(LogKey, id)
+}
+
+// Logger returns logger with the trace ID of ctx attached to every entry, or logger
+// itself if ctx carries none.
+func Logger(ctx context.Context, logger *zap.Logger) *zap.Logger {
+	id := IDFromContext(ctx)
+	if id == "" {
+		return logger
+	}
+	return logger.With(Field(id))
+}
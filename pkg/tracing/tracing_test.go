@@ -0,0 +1,54 @@
+package tracing
+
+import (
+	"context"
+	"testing"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestNewID_Unique(t *testing.T) {
+	first, second := NewID(), NewID()
+	if first == "" || first == second {
+		t.Errorf("expected distinct non-empty IDs, got %q and %q", first, second)
+	}
+}
+
+func TestWithID(t *testing.T) {
+	ctx := WithID(context.Background(), "trace-1")
+	if got := IDFromContext(ctx); got != "trace-1" {
+		t.Errorf("expected trace-1, got %q", got)
+	}
+
+	if got := IDFromContext(context.Background()); got != "" {
+		t.Errorf("expected no trace ID, got %q", got)
+	}
+
+	// An empty ID does not mask one set further up
+	if got := IDFromContext(WithID(ctx, "")); got != "trace-1" {
+		t.Errorf("expected trace-1 to survive an empty ID, got %q", got)
+	}
+}
+
+// TestLogger tests that the trace ID of the context is attached to every entry, and that
+// untraced contexts log without it.
+func TestLogger(t *testing.T) {
+	core, logs := observer.New(zapcore.DebugLevel)
+	base := zap.New(core)
+
+	Logger(WithID(context.Background(), "trace-1"), base).Info("traced")
+	Logger(context.Background(), base).Info("untraced")
+
+	entries := logs.All()
+	if len(entries) != 2 {
+		t.Fatalf("expected 2 entries, got %d", len(entries))
+	}
+	if got := entries[0].ContextMap()[LogKey]; got != "trace-1" {
+		t.Errorf("expected %s=trace-1, got %v", LogKey, got)
+	}
+	if _, ok := entries[1].ContextMap()[LogKey]; ok {
+		t.Errorf("expected no %s on untraced entry, got %v", LogKey, entries[1].ContextMap())
+	}
+}
@@ -1,5 +1,20 @@
 package types
 
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Order statuses returned by POST /order and POST /orders.
+const (
+	OrderStatusMatched   = "matched"   // Matched immediately against resting orders
+	OrderStatusLive      = "live"      // Resting on the book
+	OrderStatusDelayed   = "delayed"   // Marketable but queued for delayed matching
+	OrderStatusUnmatched = "unmatched" // Marketable but failed to match after the delay
+)
+
 // OrderSubmissionResponse represents the response from POST /order or POST /orders.
 // This is different from OrderQueryResponse (GET /order).
 // Based on official Polymarket CLOB API documentation.
@@ -13,6 +28,38 @@ type OrderSubmissionResponse struct {
 	MakingAmount string   `json:"makingAmount"`  // Amount being made (as string)
 }
 
+// NormalizedStatus returns Status lowercased. The API has returned both cases.
+func (r *OrderSubmissionResponse) NormalizedStatus() string {
+	return strings.ToLower(r.Status)
+}
+
+// IsMatched reports whether the order matched immediately on submission.
+func (r *OrderSubmissionResponse) IsMatched() bool {
+	return r.NormalizedStatus() == OrderStatusMatched
+}
+
+// IsDelayed reports whether the order was queued for delayed matching.
+// Delayed orders have no fill yet and must be polled via GET /order.
+func (r *OrderSubmissionResponse) IsDelayed() bool {
+	return r.NormalizedStatus() == OrderStatusDelayed
+}
+
+// MatchedAmounts parses MakingAmount and TakingAmount. For a BUY, making is the
+// USDC spent and taking is the tokens received.
+func (r *OrderSubmissionResponse) MatchedAmounts() (making float64, taking float64, err error) {
+	making, err = strconv.ParseFloat(r.MakingAmount, 64)
+	if err != nil {
+		return 0, 0, fmt.Errorf("parse making amount %q: %w", r.MakingAmount, err)
+	}
+
+	taking, err = strconv.ParseFloat(r.TakingAmount, 64)
+	if err != nil {
+		return 0, 0, fmt.Errorf("parse taking amount %q: %w", r.TakingAmount, err)
+	}
+
+	return making, taking, nil
+}
+
 // SignedOrderJSON represents a signed order in the format expected by the CLOB API.
 // Fields match the EIP-712 order structure after signing.
 type SignedOrderJSON struct {
@@ -64,6 +111,7 @@ type OrderQueryResponse struct {
 	Outcome      string  `json:"outcome"`                  // "Yes" or "No"
 	Owner        string  `json:"owner"`                    // API key owner
 	MakerAddress string  `json:"maker_address"`            // Maker wallet address
+	AssociateTrades []string `json:"associate_trades"`     // IDs of trades that filled this order
 	Message      string  `json:"message,omitempty"`        // Optional message
 	Error        string  `json:"error,omitempty"`          // Optional error
 }
@@ -71,8 +119,60 @@ type OrderQueryResponse struct {
 // OutcomeOrderParams holds parameters for a single outcome order.
 // Used by OrderPlacer interface for multi-outcome arbitrage trades.
 type OutcomeOrderParams struct {
-	TokenID  string
-	Price    float64
-	TickSize float64
-	MinSize  float64
+	TokenID         string
+	Price           float64
+	TickSize        float64
+	MinSize         float64
+	TickSizeUnknown bool   // TickSize is a fallback default, not resolved from market metadata
+	CorrelationID   string // Optional: ties the order to its opportunity via a derived salt (empty = random salt)
+}
+
+// TradeResponse is a trade from GET /data/trades. Our order is either the taker order
+// or one of the maker orders.
+type TradeResponse struct {
+	ID           string            `json:"id"`
+	TakerOrderID string            `json:"taker_order_id"`
+	Market       string            `json:"market"`
+	AssetID      string            `json:"asset_id"`
+	Side         string            `json:"side"`
+	Size         float64           `json:"size,string"`
+	Price        float64           `json:"price,string"`
+	FeeRateBps   float64           `json:"fee_rate_bps,string"`
+	Status       string            `json:"status"`
+	MatchTime    string            `json:"match_time"`
+	Outcome      string            `json:"outcome"`
+	TraderSide   string            `json:"trader_side"` // "TAKER" or "MAKER"
+	MakerOrders  []TradeMakerOrder `json:"maker_orders"`
+}
+
+// TradeMakerOrder is a resting order filled by a trade.
+type TradeMakerOrder struct {
+	OrderID       string  `json:"order_id"`
+	MatchedAmount float64 `json:"matched_amount,string"`
+	Price         float64 `json:"price,string"`
+	FeeRateBps    float64 `json:"fee_rate_bps,string"`
+}
+
+// FeeFor returns the fee charged to orderID in this trade in USD, by the exchange formula
+// (see exchangeFee). Trades report the fee rate, not the fee. ok is false if the order did
+// not take part in the trade.
+func (t *TradeResponse) FeeFor(orderID string) (fee float64, ok bool) {
+	if t.TakerOrderID == orderID {
+		return exchangeFee(t.Size, t.Price, t.FeeRateBps), true
+	}
+
+	for _, maker := range t.MakerOrders {
+		if maker.OrderID == orderID {
+			return exchangeFee(maker.MatchedAmount, maker.Price, maker.FeeRateBps), true
+		}
+	}
+
+	return 0, false
+}
+
+// exchangeFee is the CTF exchange fee for matching size tokens at price: the rate applied
+// to min(price, 1-price) per token, so fees are symmetric between an outcome and its
+// complement and shrink towards either end of the price range.
+func exchangeFee(size, price, feeRateBps float64) float64 {
+	return size * math.Min(price, 1-price) * feeRateBps / 10000
 }
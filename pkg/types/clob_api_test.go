@@ -0,0 +1,90 @@
+package types
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestOrderSubmissionResponse_UnmarshalStatuses(t *testing.T) {
+	tests := []struct {
+		name        string
+		input       string
+		wantStatus  string
+		wantMatched bool
+		wantDelayed bool
+		wantMaking  float64
+		wantTaking  float64
+		wantAmounts bool
+	}{
+		{
+			name: "matched",
+			input: `{"success":true,"errorMsg":"","orderId":"0xabc","status":"matched",
+				"makingAmount":"5.2","takingAmount":"10","orderHashes":["0xtx"]}`,
+			wantStatus:  OrderStatusMatched,
+			wantMatched: true,
+			wantMaking:  5.2,
+			wantTaking:  10,
+			wantAmounts: true,
+		},
+		{
+			name:        "matched_uppercase",
+			input:       `{"success":true,"orderId":"0xabc","status":"MATCHED","makingAmount":"2.5","takingAmount":"5"}`,
+			wantStatus:  OrderStatusMatched,
+			wantMatched: true,
+			wantMaking:  2.5,
+			wantTaking:  5,
+			wantAmounts: true,
+		},
+		{
+			name:       "live",
+			input:      `{"success":true,"orderId":"0xabc","status":"live","makingAmount":"","takingAmount":""}`,
+			wantStatus: OrderStatusLive,
+		},
+		{
+			name:        "delayed",
+			input:       `{"success":true,"orderId":"0xabc","status":"delayed","makingAmount":"","takingAmount":""}`,
+			wantStatus:  OrderStatusDelayed,
+			wantDelayed: true,
+		},
+		{
+			name:       "unmatched",
+			input:      `{"success":true,"orderId":"0xabc","status":"unmatched"}`,
+			wantStatus: OrderStatusUnmatched,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var resp OrderSubmissionResponse
+			err := json.Unmarshal([]byte(tt.input), &resp)
+			if err != nil {
+				t.Fatalf("unmarshal: %v", err)
+			}
+
+			if got := resp.NormalizedStatus(); got != tt.wantStatus {
+				t.Errorf("NormalizedStatus() = %q, want %q", got, tt.wantStatus)
+			}
+			if got := resp.IsMatched(); got != tt.wantMatched {
+				t.Errorf("IsMatched() = %v, want %v", got, tt.wantMatched)
+			}
+			if got := resp.IsDelayed(); got != tt.wantDelayed {
+				t.Errorf("IsDelayed() = %v, want %v", got, tt.wantDelayed)
+			}
+
+			making, taking, err := resp.MatchedAmounts()
+			if !tt.wantAmounts {
+				if err == nil {
+					t.Errorf("MatchedAmounts() expected error for empty amounts, got %f/%f", making, taking)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("MatchedAmounts() error: %v", err)
+			}
+			if making != tt.wantMaking || taking != tt.wantTaking {
+				t.Errorf("MatchedAmounts() = %f/%f, want %f/%f", making, taking, tt.wantMaking, tt.wantTaking)
+			}
+		})
+	}
+}
@@ -1,29 +1,114 @@
 package types
 
-import "fmt"
+import (
+	"fmt"
+	"strings"
+)
 
 // OrderError represents an error that occurred during order placement or execution.
 type OrderError struct {
-	Code    string // API error code or internal error code
-	Message string // Human-readable error message
-	OrderID string // Order ID if available
-	Side    string // YES or NO
+	Code    RejectCode // API rejection code parsed from the response, or an internal code
+	Message string     // Human-readable error message
+	OrderID string     // Order ID if available
+	Side    string     // YES or NO; empty for batch and request-level errors
 }
 
 func (e *OrderError) Error() string {
+	prefix := "order failed"
+	if e.Side != "" {
+		prefix = e.Side + " order failed"
+	}
+
 	if e.OrderID != "" {
-		return fmt.Sprintf("%s order failed (ID: %s): %s (%s)", e.Side, e.OrderID, e.Message, e.Code)
+		return fmt.Sprintf("%s (ID: %s): %s (%s)", prefix, e.OrderID, e.Message, e.Code)
 	}
 
-	return fmt.Sprintf("%s order failed: %s (%s)", e.Side, e.Message, e.Code)
+	return fmt.Sprintf("%s: %s (%s)", prefix, e.Message, e.Code)
 }
 
+// RejectCode is a Polymarket CLOB order rejection code.
+type RejectCode string
+
 // Known Polymarket CLOB API error codes
 const (
-	ErrInvalidMinTickSize = "INVALID_ORDER_MIN_TICK_SIZE"
-	ErrNotEnoughBalance   = "INVALID_ORDER_NOT_ENOUGH_BALANCE"
-	ErrFOKNotFilled       = "FOK_ORDER_NOT_FILLED_ERROR"
-	ErrMarketNotReady     = "MARKET_NOT_READY"
-	ErrUnmatched          = "UNMATCHED"
-	ErrUnknownStatus      = "UNKNOWN_STATUS"
+	ErrInvalidMinTickSize = RejectCode("INVALID_ORDER_MIN_TICK_SIZE")
+	ErrInvalidMinSize     = RejectCode("INVALID_ORDER_MIN_SIZE")
+	ErrDuplicatedOrder    = RejectCode("INVALID_ORDER_DUPLICATED")
+	ErrNotEnoughBalance   = RejectCode("INVALID_ORDER_NOT_ENOUGH_BALANCE")
+	ErrInvalidExpiration  = RejectCode("INVALID_ORDER_EXPIRATION")
+	ErrInvalidOrder       = RejectCode("INVALID_ORDER_ERROR")
+	ErrExecution          = RejectCode("EXECUTION_ERROR")
+	ErrOrderDelayed       = RejectCode("DELAYING_ORDER_ERROR")
+	ErrFOKNotFilled       = RejectCode("FOK_ORDER_NOT_FILLED_ERROR")
+	ErrPostOnlyCrosses    = RejectCode("INVALID_POST_ONLY_ORDER")
+	ErrMarketNotReady     = RejectCode("MARKET_NOT_READY")
+	ErrUnmatched          = RejectCode("UNMATCHED")
+	ErrUnknownStatus      = RejectCode("UNKNOWN_STATUS")
+	ErrUnknownRejection   = RejectCode("UNKNOWN_REJECTION") // No known code or message matched
 )
+
+// rejectCodeTokens maps codes as they appear in responses to RejectCodes.
+//
+//nolint:gochecknoglobals // Read-only lookup table
+var rejectCodeTokens = []struct {
+	token string
+	code  RejectCode
+}{
+	{"INVALID_ORDER_MIN_TICK_SIZE", ErrInvalidMinTickSize},
+	{"INVALID_ORDER_MIN_SIZE", ErrInvalidMinSize},
+	{"INVALID_ORDER_DUPLICATED", ErrDuplicatedOrder},
+	{"INVALID_ORDER_NOT_ENOUGH_BALANCE", ErrNotEnoughBalance},
+	{"INSUFFICIENT_BALANCE", ErrNotEnoughBalance},
+	{"INVALID_ORDER_EXPIRATION", ErrInvalidExpiration},
+	{"INVALID_ORDER_ERROR", ErrInvalidOrder},
+	{"EXECUTION_ERROR", ErrExecution},
+	{"DELAYING_ORDER_ERROR", ErrOrderDelayed},
+	{"FOK_ORDER_NOT_FILLED_ERROR", ErrFOKNotFilled},
+	{"INVALID_POST_ONLY_ORDER_TYPE", ErrInvalidOrder}, // Post-only on a non-GTC type; before the prefix below
+	{"INVALID_POST_ONLY_ORDER", ErrPostOnlyCrosses},
+	{"MARKET_NOT_READY", ErrMarketNotReady},
+}
+
+// rejectCodePhrases maps the descriptive messages the CLOB returns in errorMsg to
+// RejectCodes, for responses that carry no code. Matched lowercase, in order.
+//
+//nolint:gochecknoglobals // Read-only lookup table
+var rejectCodePhrases = []struct {
+	phrase string
+	code   RejectCode
+}{
+	{"not enough balance", ErrNotEnoughBalance},
+	{"allowance", ErrNotEnoughBalance},
+	{"tick size", ErrInvalidMinTickSize},
+	{"lower than the minimum", ErrInvalidMinSize},
+	{"duplicated", ErrDuplicatedOrder},
+	{"expiration", ErrInvalidExpiration},
+	{"not yet ready", ErrMarketNotReady},
+	{"couldn't be fully filled", ErrFOKNotFilled},
+	{"no orders found to match", ErrFOKNotFilled},
+	{"crosses book", ErrPostOnlyCrosses},
+	{"could not insert order", ErrInvalidOrder},
+	{"could not run the execution", ErrExecution},
+	{"delayed", ErrOrderDelayed},
+}
+
+// ParseRejectCode extracts the rejection code from an API error message or response
+// body. Explicit codes win over descriptive messages; ErrUnknownRejection is returned
+// when neither matches.
+func ParseRejectCode(msg string) RejectCode {
+	upper := strings.ToUpper(msg)
+	for _, t := range rejectCodeTokens {
+		if strings.Contains(upper, t.token) {
+			return t.code
+		}
+	}
+
+	lower := strings.ToLower(msg)
+	for _, p := range rejectCodePhrases {
+		if strings.Contains(lower, p.phrase) {
+			return p.code
+		}
+	}
+
+	return ErrUnknownRejection
+}
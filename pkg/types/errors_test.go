@@ -0,0 +1,69 @@
+package types
+
+import "testing"
+
+// TestParseRejectCode tests that explicit codes and the CLOB's descriptive messages
+// are parsed into rejection codes.
+func TestParseRejectCode(t *testing.T) {
+	tests := []struct {
+		name string
+		msg  string
+		want RejectCode
+	}{
+		{name: "code_in_body", msg: `{"error":"INVALID_ORDER_MIN_TICK_SIZE"}`, want: ErrInvalidMinTickSize},
+		{name: "code_lowercase", msg: "market_not_ready", want: ErrMarketNotReady},
+		{name: "insufficient_balance_alias", msg: `{"error":"INSUFFICIENT_BALANCE"}`, want: ErrNotEnoughBalance},
+		{name: "balance_message", msg: "not enough balance / allowance", want: ErrNotEnoughBalance},
+		{name: "tick_size_message", msg: "order price breaks minimum tick size rule: 0.01", want: ErrInvalidMinTickSize},
+		{name: "min_size_message", msg: "Size (2) lower than the minimum: 5", want: ErrInvalidMinSize},
+		{name: "duplicated_message", msg: "order 0xabc is invalid. Duplicated.", want: ErrDuplicatedOrder},
+		{name: "expiration_message", msg: "invalid expiration value", want: ErrInvalidExpiration},
+		{name: "market_not_ready_message", msg: "the market is not yet ready to process new orders", want: ErrMarketNotReady},
+		{name: "fok_message", msg: "order couldn't be fully filled. FOK orders are fully filled or killed.", want: ErrFOKNotFilled},
+		{name: "insert_message", msg: "could not insert order", want: ErrInvalidOrder},
+		{name: "execution_message", msg: "could not run the execution", want: ErrExecution},
+		{name: "delayed_message", msg: "order match delayed due to market conditions", want: ErrOrderDelayed},
+		{name: "code_wins_over_message", msg: `{"error":"MARKET_NOT_READY: not enough balance"}`, want: ErrMarketNotReady},
+		{name: "no_match_message", msg: "no orders found to match with FOK order", want: ErrFOKNotFilled},
+		{name: "crosses_book_message", msg: "invalid post-only order: order crosses book", want: ErrPostOnlyCrosses},
+		{name: "post_only_type_code", msg: `{"error":"INVALID_POST_ONLY_ORDER_TYPE"}`, want: ErrInvalidOrder},
+		{name: "unknown", msg: "something went wrong", want: ErrUnknownRejection},
+		{name: "empty", msg: "", want: ErrUnknownRejection},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ParseRejectCode(tt.msg); got != tt.want {
+				t.Errorf("ParseRejectCode(%q) = %s, want %s", tt.msg, got, tt.want)
+			}
+		})
+	}
+}
+
+// TestOrderError_Error tests the message with and without a side and order ID.
+func TestOrderError_Error(t *testing.T) {
+	tests := []struct {
+		name string
+		err  *OrderError
+		want string
+	}{
+		{
+			name: "side_and_order_id",
+			err:  &OrderError{Code: ErrNotEnoughBalance, Message: "not enough balance", OrderID: "0x1", Side: "YES"},
+			want: "YES order failed (ID: 0x1): not enough balance (INVALID_ORDER_NOT_ENOUGH_BALANCE)",
+		},
+		{
+			name: "batch",
+			err:  &OrderError{Code: ErrMarketNotReady, Message: "outcome 0: market not ready"},
+			want: "order failed: outcome 0: market not ready (MARKET_NOT_READY)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.err.Error(); got != tt.want {
+				t.Errorf("expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
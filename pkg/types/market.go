@@ -2,9 +2,16 @@ package types
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
 	"time"
 )
 
+// ErrOutcomeTokenMismatch is returned when a market's outcomes and clobTokenIds can't be
+// paired by index.
+var ErrOutcomeTokenMismatch = errors.New("outcomes and token IDs are not aligned")
+
 // Market represents a Polymarket market from the Gamma API.
 type Market struct {
 	ID          string    `json:"id"`
@@ -16,6 +23,9 @@ type Market struct {
 	CreatedAt   time.Time `json:"createdAt"`
 	EndDate     time.Time `json:"endDate"`
 	Description string    `json:"description"`
+	Category    string    `json:"category"`
+	Liquidity   float64   `json:"liquidityNum"`
+	NegRisk     bool      `json:"negRisk"` // Outcomes settle through the neg-risk adapter and exchange
 	Outcomes    string    `json:"outcomes"`       // JSON string: "[\"Yes\", \"No\"]"
 	ClobTokens  string    `json:"clobTokenIds"`   // JSON string: "[\"token1\", \"token2\"]"
 
@@ -28,6 +38,7 @@ type Market struct {
 func (m *Market) UnmarshalJSON(data []byte) error {
 	type Alias Market
 	aux := &struct {
+		NegRiskSnake *bool `json:"neg_risk"` // CLOB API spelling
 		*Alias
 	}{
 		Alias: (*Alias)(m),
@@ -37,29 +48,89 @@ func (m *Market) UnmarshalJSON(data []byte) error {
 		return err
 	}
 
-	// Parse outcomes and clobTokenIds to populate Tokens
-	if m.Outcomes != "" && m.ClobTokens != "" {
-		var outcomes []string
-		var tokenIDs []string
-
-		if err := json.Unmarshal([]byte(m.Outcomes), &outcomes); err == nil {
-			if err := json.Unmarshal([]byte(m.ClobTokens), &tokenIDs); err == nil {
-				m.Tokens = make([]Token, 0, len(outcomes))
-				for i, outcome := range outcomes {
-					if i < len(tokenIDs) {
-						m.Tokens = append(m.Tokens, Token{
-							TokenID: tokenIDs[i],
-							Outcome: outcome,
-						})
-					}
-				}
-			}
-		}
+	if aux.NegRiskSnake != nil {
+		m.NegRisk = m.NegRisk || *aux.NegRiskSnake
+	}
+
+	// Parse outcomes and clobTokenIds to populate Tokens. Misaligned arrays leave Tokens
+	// empty so the market is skipped rather than mapping outcomes to the wrong tokens.
+	tokens, err := m.ParseTokens()
+	if err == nil {
+		m.Tokens = tokens
 	}
 
 	return nil
 }
 
+// ParseTokens pairs the Gamma outcomes and clobTokenIds arrays by index, normalizing
+// outcome names and trimming token IDs. Returns nil if both arrays are absent, and
+// ErrOutcomeTokenMismatch if only one is present, the arrays differ in length, an outcome
+// name is empty, or a token ID is empty or repeated.
+func (m *Market) ParseTokens() ([]Token, error) {
+	if m.Outcomes == "" && m.ClobTokens == "" {
+		return nil, nil
+	}
+	if m.Outcomes == "" || m.ClobTokens == "" {
+		return nil, fmt.Errorf("%w: outcomes or clobTokenIds missing", ErrOutcomeTokenMismatch)
+	}
+
+	var outcomes []string
+	if err := json.Unmarshal([]byte(m.Outcomes), &outcomes); err != nil {
+		return nil, fmt.Errorf("parse outcomes: %w", err)
+	}
+
+	var tokenIDs []string
+	if err := json.Unmarshal([]byte(m.ClobTokens), &tokenIDs); err != nil {
+		return nil, fmt.Errorf("parse clobTokenIds: %w", err)
+	}
+
+	if len(outcomes) != len(tokenIDs) {
+		return nil, fmt.Errorf("%w: %d outcomes, %d token IDs", ErrOutcomeTokenMismatch, len(outcomes), len(tokenIDs))
+	}
+
+	tokens := make([]Token, 0, len(outcomes))
+	seen := make(map[string]bool, len(tokenIDs))
+	for i, outcome := range outcomes {
+		tokenID := strings.TrimSpace(tokenIDs[i])
+		if tokenID == "" || seen[tokenID] {
+			return nil, fmt.Errorf("%w: outcome %d has empty or repeated token ID %q", ErrOutcomeTokenMismatch, i, tokenID)
+		}
+		seen[tokenID] = true
+
+		outcome = NormalizeOutcome(outcome)
+		if outcome == "" {
+			return nil, fmt.Errorf("%w: outcome %d has an empty name", ErrOutcomeTokenMismatch, i)
+		}
+
+		tokens = append(tokens, Token{
+			TokenID: tokenID,
+			Outcome: outcome,
+		})
+	}
+
+	return tokens, nil
+}
+
+// NormalizeOutcome trims an outcome name and collapses inner runs of whitespace. Binary
+// outcomes in any casing become Gamma's "Yes"/"No"; other names keep their casing.
+func NormalizeOutcome(outcome string) string {
+	outcome = strings.Join(strings.Fields(outcome), " ")
+
+	switch {
+	case strings.EqualFold(outcome, "yes"):
+		return "Yes"
+	case strings.EqualFold(outcome, "no"):
+		return "No"
+	default:
+		return outcome
+	}
+}
+
+// OutcomeEquals reports whether two outcome names match after normalization, ignoring case.
+func OutcomeEquals(a, b string) bool {
+	return strings.EqualFold(NormalizeOutcome(a), NormalizeOutcome(b))
+}
+
 // Token represents a market outcome token (YES or NO).
 type Token struct {
 	TokenID      string  `json:"token_id"`
@@ -70,14 +141,10 @@ type Token struct {
 }
 
 // GetTokenByOutcome returns the token for a specific outcome (YES or NO).
-// Case-insensitive matching (accepts YES/Yes, NO/No).
+// Matching ignores case and surrounding whitespace (accepts YES/Yes/" yes ").
 func (m *Market) GetTokenByOutcome(outcome string) *Token {
 	for i := range m.Tokens {
-		// Normalize comparison: YES/Yes, NO/No
-		tokenOutcome := m.Tokens[i].Outcome
-		if tokenOutcome == outcome ||
-			(outcome == "YES" && tokenOutcome == "Yes") ||
-			(outcome == "NO" && tokenOutcome == "No") {
+		if OutcomeEquals(m.Tokens[i].Outcome, outcome) {
 			return &m.Tokens[i]
 		}
 	}
@@ -100,6 +167,9 @@ type MarketSubscription struct {
 	Question     string
 	Outcomes     []OutcomeToken // All outcomes for this market (2+ outcomes)
 	SubscribedAt time.Time
+	EndDate      time.Time // Zero if the API didn't report one
+	Active       bool      // Active flag at discovery time (for detecting active -> inactive transitions)
+	NegRisk      bool      // Neg-risk market: orders and complete-set conversions go through the neg-risk contracts
 }
 
 // MarketsResponse represents the response from Gamma API /events endpoint.
@@ -0,0 +1,149 @@
+package types
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+// TestMarketUnmarshal_NegRisk tests that the neg-risk flag is captured from Gamma market JSON.
+func TestMarketUnmarshal_NegRisk(t *testing.T) {
+	tests := []struct {
+		name        string
+		json        string
+		wantNegRisk bool
+	}{
+		{
+			name: "gamma_neg_risk_market",
+			json: `{"id":"512345","question":"Will Alice win the election?","slug":"will-alice-win",
+				"active":true,"closed":false,"liquidityNum":15234.5,"negRisk":true,
+				"negRiskMarketID":"0xabc","outcomes":"[\"Yes\", \"No\"]",
+				"clobTokenIds":"[\"111\", \"222\"]"}`,
+			wantNegRisk: true,
+		},
+		{
+			name: "gamma_standard_market",
+			json: `{"id":"512346","slug":"btc-above-100k","negRisk":false,
+				"outcomes":"[\"Yes\", \"No\"]","clobTokenIds":"[\"333\", \"444\"]"}`,
+		},
+		{
+			name: "field_absent",
+			json: `{"id":"512347","slug":"no-flag","outcomes":"[\"Yes\", \"No\"]","clobTokenIds":"[\"555\", \"666\"]"}`,
+		},
+		{
+			name: "clob_spelling",
+			json: `{"id":"512348","slug":"clob-style","neg_risk":true,
+				"outcomes":"[\"Yes\", \"No\"]","clobTokenIds":"[\"777\", \"888\"]"}`,
+			wantNegRisk: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var market Market
+			err := json.Unmarshal([]byte(tt.json), &market)
+			if err != nil {
+				t.Fatalf("unmarshal: %v", err)
+			}
+
+			if market.NegRisk != tt.wantNegRisk {
+				t.Errorf("expected NegRisk=%v, got %v", tt.wantNegRisk, market.NegRisk)
+			}
+
+			// Flag parsing must not disturb token extraction
+			if len(market.Tokens) != 2 || market.GetTokenByOutcome("YES") == nil {
+				t.Errorf("expected YES/NO tokens, got %+v", market.Tokens)
+			}
+		})
+	}
+}
+
+// TestMarketUnmarshal_MessyOutcomes tests that outcome names from Gamma are normalized and
+// stay paired with their token IDs by index.
+func TestMarketUnmarshal_MessyOutcomes(t *testing.T) {
+	tests := []struct {
+		name       string
+		outcomes   string
+		tokenIDs   string
+		wantTokens []Token
+	}{
+		{
+			name:       "binary_casing_and_spaces",
+			outcomes:   `[" YES ", "no"]`,
+			tokenIDs:   `[" 111", "222 "]`,
+			wantTokens: []Token{{TokenID: "111", Outcome: "Yes"}, {TokenID: "222", Outcome: "No"}},
+		},
+		{
+			name:     "candidate_names_keep_casing",
+			outcomes: `["  Alice   Smith", "bob jones ", "Other"]`,
+			tokenIDs: `["1", "2", "3"]`,
+			wantTokens: []Token{
+				{TokenID: "1", Outcome: "Alice Smith"},
+				{TokenID: "2", Outcome: "bob jones"},
+				{TokenID: "3", Outcome: "Other"},
+			},
+		},
+		{name: "more_outcomes_than_tokens", outcomes: `["Yes", "No", "Maybe"]`, tokenIDs: `["1", "2"]`},
+		{name: "more_tokens_than_outcomes", outcomes: `["Yes", "No"]`, tokenIDs: `["1", "2", "3"]`},
+		{name: "empty_token_id", outcomes: `["Yes", "No"]`, tokenIDs: `["1", " "]`},
+		{name: "repeated_token_id", outcomes: `["Yes", "No"]`, tokenIDs: `["1", "1"]`},
+		{name: "empty_outcome_name", outcomes: `["Yes", "  "]`, tokenIDs: `["1", "2"]`},
+		{name: "missing_token_ids", outcomes: `["Yes", "No"]`, tokenIDs: ``},
+		{name: "missing_outcomes", outcomes: ``, tokenIDs: `["1", "2"]`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			payload, _ := json.Marshal(map[string]string{
+				"id": "1", "slug": "messy", "outcomes": tt.outcomes, "clobTokenIds": tt.tokenIDs,
+			})
+
+			var market Market
+			err := json.Unmarshal(payload, &market)
+			if err != nil {
+				t.Fatalf("unmarshal: %v", err)
+			}
+
+			if len(market.Tokens) != len(tt.wantTokens) {
+				t.Fatalf("expected %d tokens, got %+v", len(tt.wantTokens), market.Tokens)
+			}
+			for i, want := range tt.wantTokens {
+				if market.Tokens[i] != want {
+					t.Errorf("token %d: expected %+v, got %+v", i, want, market.Tokens[i])
+				}
+			}
+
+			// Misaligned payloads report why they have no tokens
+			if tt.wantTokens == nil {
+				_, err = market.ParseTokens()
+				if !errors.Is(err, ErrOutcomeTokenMismatch) {
+					t.Errorf("expected ErrOutcomeTokenMismatch, got %v", err)
+				}
+			}
+		})
+	}
+}
+
+func TestOutcomeEquals(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want bool
+	}{
+		{a: "Yes", b: "YES", want: true},
+		{a: " yes", b: "Yes ", want: true},
+		{a: "Alice  Smith", b: "alice smith", want: true},
+		{a: "Yes", b: "No", want: false},
+		{a: "Alice", b: "Alice Smith", want: false},
+	}
+
+	for _, tt := range tests {
+		if got := OutcomeEquals(tt.a, tt.b); got != tt.want {
+			t.Errorf("OutcomeEquals(%q, %q) = %v, want %v", tt.a, tt.b, got, tt.want)
+		}
+	}
+
+	market := Market{Tokens: []Token{{TokenID: "1", Outcome: "Yes"}, {TokenID: "2", Outcome: "No"}}}
+	if token := market.GetTokenByOutcome(" no "); token == nil || token.TokenID != "2" {
+		t.Errorf("expected NO token for \" no \", got %+v", token)
+	}
+}
@@ -15,6 +15,7 @@ type OrderbookMessage struct {
 	Hash      string       `json:"hash,omitempty"`
 	Bids      []PriceLevel `json:"bids,omitempty"`
 	Asks      []PriceLevel `json:"asks,omitempty"`
+	Change    *PriceChange `json:"-"` // price_change only: the level update behind the new best prices
 }
 
 // UnmarshalJSON custom unmarshaler to handle string timestamp.
@@ -58,9 +59,30 @@ type OrderbookSnapshot struct {
 	BestBidSize  float64
 	BestAskPrice float64
 	BestAskSize  float64
+	AskDepth     []BookLevel // Ask ladder from the last book message and later level changes, best first (nil if unknown)
 	LastUpdated  time.Time
 }
 
+// BookLevel is a parsed orderbook price level.
+type BookLevel struct {
+	Price float64
+	Size  float64
+}
+
+// AskLiquidityUSD returns the USDC needed to buy every resting ask. Without a stored
+// ladder only the best ask level is counted.
+func (s *OrderbookSnapshot) AskLiquidityUSD() float64 {
+	if len(s.AskDepth) == 0 {
+		return s.BestAskPrice * s.BestAskSize
+	}
+
+	total := 0.0
+	for _, level := range s.AskDepth {
+		total += level.Price * level.Size
+	}
+	return total
+}
+
 // PriceChangeMessage represents incremental price update messages from the Polymarket CLOB API.
 // The API sends price_change messages with a different structure than book messages.
 type PriceChangeMessage struct {
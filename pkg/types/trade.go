@@ -16,11 +16,14 @@ type Trade struct {
 type FillStatus struct {
 	OrderID      string
 	Outcome      string
-	Status       string  // "matched", "live", "unmatched"
+	Status       string  // "matched", "live", "delayed", "unmatched"
 	OriginalSize float64
 	SizeFilled   float64
 	ActualPrice  float64
 	FullyFilled  bool // true if SizeFilled == OriginalSize
+
+	ActualFeePaid float64 // Fees charged across the order's trades, in USD (valid when FeeFromTrades)
+	FeeFromTrades bool    // ActualFeePaid came from trade data; otherwise fees are estimated from the taker fee
 	VerifiedAt   time.Time
 	Error        error
 }
@@ -34,6 +37,7 @@ type ExecutionResult struct {
 	NoTrade        *Trade    // For binary markets (backward compatibility)
 	AllTrades      []*Trade  // For all markets (binary + multi-outcome)
 	RealizedProfit float64   // ACTUAL profit after fills verified
+	Notional       float64   // USD cost of all legs at order prices
 	Success        bool
 	Error          error
 
@@ -44,4 +48,27 @@ type ExecutionResult struct {
 	ExpectedProfit  float64       // Expected profit at order time
 	VerifiedAt      time.Time     // When fills were verified
 	PriceAdjustment float64       // How much above ask we placed orders
+
+	// Profit breakdown, set once profit is known: RealizedProfit = GrossProfit - TotalFees - Slippage
+	GrossProfit float64 // Profit at the detected ask prices, before fees
+	TotalFees   float64 // Fees paid across all legs
+	Slippage    float64 // Extra cost of filling above the detected asks (negative = price improvement)
+}
+
+// ExecutionState is the executor state persisted across restarts, one record per mode.
+type ExecutionState struct {
+	Mode             string
+	CumulativeProfit float64
+	TradeCounts      map[string]int // Filled outcome legs keyed by outcome name
+	PendingTrades    []PendingTrade // Live trades not yet confirmed filled, for post-restart reconciliation
+	UpdatedAt        time.Time
+}
+
+// PendingTrade is a placed live order set (one order per outcome) awaiting fill confirmation.
+type PendingTrade struct {
+	OpportunityID string    `json:"opportunity_id"`
+	MarketSlug    string    `json:"market_slug"`
+	OrderIDs      []string  `json:"order_ids"`
+	Outcomes      []string  `json:"outcomes"`
+	PlacedAt      time.Time `json:"placed_at"`
 }
@@ -0,0 +1,114 @@
+package types
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// User channel event types.
+const (
+	UserEventOrder = "order" // Order placed, partially matched or canceled
+	UserEventTrade = "trade" // Trade involving one of our orders, with settlement status
+)
+
+// User channel order update types.
+const (
+	UserOrderPlacement    = "PLACEMENT"
+	UserOrderUpdate       = "UPDATE" // Part of the order matched
+	UserOrderCancellation = "CANCELLATION"
+)
+
+// User channel trade statuses, in settlement order. FAILED is terminal.
+const (
+	UserTradeMatched   = "MATCHED"
+	UserTradeMined     = "MINED"
+	UserTradeConfirmed = "CONFIRMED"
+	UserTradeRetrying  = "RETRYING"
+	UserTradeFailed    = "FAILED"
+)
+
+// UserAuth is the L2 API credential payload sent when subscribing to the user channel.
+type UserAuth struct {
+	APIKey     string `json:"apiKey"`
+	Secret     string `json:"secret"`
+	Passphrase string `json:"passphrase"`
+}
+
+// UserSubscribeMessage is the authenticated subscription frame for the user channel.
+// Markets optionally restricts updates to the given condition IDs (empty = all markets).
+type UserSubscribeMessage struct {
+	Auth    UserAuth `json:"auth"`
+	Markets []string `json:"markets"`
+	Type    string   `json:"type"` // "user"
+}
+
+// UserOrderMessage is an update to one of our orders from the user channel.
+type UserOrderMessage struct {
+	EventType       string   `json:"event_type"` // "order"
+	ID              string   `json:"id"`         // Order ID
+	Owner           string   `json:"owner"`      // API key of the order owner
+	Market          string   `json:"market"`     // Condition ID
+	AssetID         string   `json:"asset_id"`
+	Side            string   `json:"side"` // "BUY" or "SELL"
+	Outcome         string   `json:"outcome"`
+	Price           string   `json:"price"`
+	OriginalSize    string   `json:"original_size"`
+	SizeMatched     string   `json:"size_matched"`
+	AssociateTrades []string `json:"associate_trades"`
+	Type            string   `json:"type"`      // PLACEMENT, UPDATE or CANCELLATION
+	Timestamp       string   `json:"timestamp"` // Unix timestamp as string
+}
+
+// Sizes parses the original and matched sizes.
+func (m *UserOrderMessage) Sizes() (original, matched float64, err error) {
+	original, err = strconv.ParseFloat(m.OriginalSize, 64)
+	if err != nil {
+		return 0, 0, fmt.Errorf("parse original_size %q: %w", m.OriginalSize, err)
+	}
+
+	matched, err = strconv.ParseFloat(m.SizeMatched, 64)
+	if err != nil {
+		return 0, 0, fmt.Errorf("parse size_matched %q: %w", m.SizeMatched, err)
+	}
+
+	return original, matched, nil
+}
+
+// UserTradeMessage is a trade involving one of our orders, as taker or maker.
+type UserTradeMessage struct {
+	EventType    string       `json:"event_type"` // "trade"
+	ID           string       `json:"id"`         // Trade ID
+	Market       string       `json:"market"`     // Condition ID
+	AssetID      string       `json:"asset_id"`
+	Side         string       `json:"side"`
+	Outcome      string       `json:"outcome"`
+	Price        string       `json:"price"`
+	Size         string       `json:"size"`
+	Status       string       `json:"status"`      // MATCHED, MINED, CONFIRMED, RETRYING or FAILED
+	TraderSide   string       `json:"trader_side"` // "TAKER" or "MAKER"
+	TakerOrderID string       `json:"taker_order_id"`
+	MakerOrders  []MakerOrder `json:"maker_orders"`
+	MatchTime    string       `json:"matchtime"`
+	LastUpdate   string       `json:"last_update"`
+	Timestamp    string       `json:"timestamp"`
+}
+
+// MakerOrder is a resting order filled by a trade.
+type MakerOrder struct {
+	OrderID       string `json:"order_id"`
+	Owner         string `json:"owner"`
+	AssetID       string `json:"asset_id"`
+	Outcome       string `json:"outcome"`
+	Price         string `json:"price"`
+	MatchedAmount string `json:"matched_amount"`
+}
+
+// UserUpdate carries one user channel message. Exactly one of Order and Trade is set,
+// matching EventType.
+type UserUpdate struct {
+	EventType  string
+	Order      *UserOrderMessage
+	Trade      *UserTradeMessage
+	ReceivedAt time.Time
+}
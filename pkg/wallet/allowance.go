@@ -0,0 +1,282 @@
+package wallet
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"go.uber.org/zap"
+)
+
+const (
+	erc20AllowanceABI = `[
+	{"constant":true,"inputs":[{"name":"owner","type":"address"},{"name":"spender","type":"address"}],"name":"allowance","outputs":[{"name":"","type":"uint256"}],"type":"function"},
+	{"constant":false,"inputs":[{"name":"spender","type":"address"},{"name":"value","type":"uint256"}],"name":"approve","outputs":[{"name":"","type":"bool"}],"type":"function"}
+]`
+
+	// PolygonCTFExchange is the Polymarket CTF Exchange, which must be approved to spend USDC.
+	PolygonCTFExchange = polygonCTFExchange
+
+	// approveGasLimit covers a standard ERC20 approve.
+	approveGasLimit = uint64(100000)
+
+	defaultReceiptPollInterval = 2 * time.Second
+)
+
+// ErrApprovalPending is returned while an approval transaction sent earlier has not been mined.
+var ErrApprovalPending = errors.New("approval transaction pending")
+
+// ErrApprovalReverted is returned when an approval transaction was mined but reverted.
+var ErrApprovalReverted = errors.New("approval transaction reverted")
+
+// MaxAllowance is the max uint256 value, approved when no specific amount is configured.
+var MaxAllowance = new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 256), big.NewInt(1))
+
+// ChainClient is the subset of an Ethereum RPC client used to read and approve ERC20
+// allowances. *ethclient.Client implements this interface; tests can supply a mock.
+type ChainClient interface {
+	CallContract(ctx context.Context, msg ethereum.CallMsg, blockNumber *big.Int) ([]byte, error)
+	PendingNonceAt(ctx context.Context, account common.Address) (uint64, error)
+	SuggestGasPrice(ctx context.Context) (*big.Int, error)
+	ChainID(ctx context.Context) (*big.Int, error)
+	SendTransaction(ctx context.Context, tx *types.Transaction) error
+	TransactionReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error)
+}
+
+// Compile-time check that ethclient.Client implements ChainClient
+var _ ChainClient = (*ethclient.Client)(nil)
+
+// AllowanceConfig holds configuration for the allowance manager.
+type AllowanceConfig struct {
+	Chain      ChainClient
+	PrivateKey *ecdsa.PrivateKey // Signs approvals; the owner address is derived from it
+	Logger     *zap.Logger
+
+	// Token is the ERC20 to approve (default: PolygonUSDCe).
+	Token common.Address
+
+	// ApproveAmount is the allowance granted by an approval (default: MaxAllowance).
+	ApproveAmount *big.Int
+
+	// ReceiptPollInterval is the wait between receipt lookups for a sent approval (default: 2s).
+	ReceiptPollInterval time.Duration
+}
+
+// AllowanceManager checks ERC20 allowances and submits approvals when they run low.
+// At most one approval per spender is in flight; it is remembered across calls so a
+// timed-out wait never leads to a duplicate approval.
+type AllowanceManager struct {
+	chain         ChainClient
+	privateKey    *ecdsa.PrivateKey
+	owner         common.Address
+	token         common.Address
+	approveAmount *big.Int
+	pollInterval  time.Duration
+	logger        *zap.Logger
+	abi           abi.ABI
+
+	mu      sync.Mutex
+	pending map[common.Address]common.Hash // spender -> unmined approval tx
+}
+
+// NewAllowanceManager creates a new allowance manager.
+func NewAllowanceManager(cfg *AllowanceConfig) (m *AllowanceManager, err error) {
+	if cfg.Chain == nil {
+		return nil, errors.New("chain client cannot be nil")
+	}
+
+	if cfg.PrivateKey == nil {
+		return nil, errors.New("private key cannot be nil")
+	}
+
+	if cfg.Logger == nil {
+		return nil, errors.New("logger cannot be nil")
+	}
+
+	parsedABI, err := abi.JSON(strings.NewReader(erc20AllowanceABI))
+	if err != nil {
+		return nil, fmt.Errorf("parse ABI: %w", err)
+	}
+
+	token := cfg.Token
+	if token == (common.Address{}) {
+		token = common.HexToAddress(PolygonUSDCe)
+	}
+
+	approveAmount := cfg.ApproveAmount
+	if approveAmount == nil {
+		approveAmount = MaxAllowance
+	}
+
+	pollInterval := cfg.ReceiptPollInterval
+	if pollInterval <= 0 {
+		pollInterval = defaultReceiptPollInterval
+	}
+
+	m = &AllowanceManager{
+		chain:         cfg.Chain,
+		privateKey:    cfg.PrivateKey,
+		owner:         crypto.PubkeyToAddress(cfg.PrivateKey.PublicKey),
+		token:         token,
+		approveAmount: approveAmount,
+		pollInterval:  pollInterval,
+		logger:        cfg.Logger,
+		abi:           parsedABI,
+		pending:       make(map[common.Address]common.Hash),
+	}
+
+	return m, nil
+}
+
+// Owner returns the address whose allowance is managed.
+func (m *AllowanceManager) Owner() common.Address {
+	return m.owner
+}
+
+// Allowance returns the owner's current allowance for spender, in the token's smallest unit.
+func (m *AllowanceManager) Allowance(ctx context.Context, spender common.Address) (allowance *big.Int, err error) {
+	data, err := m.abi.Pack("allowance", m.owner, spender)
+	if err != nil {
+		return nil, fmt.Errorf("pack ABI: %w", err)
+	}
+
+	msg := ethereum.CallMsg{
+		To:   &m.token,
+		Data: data,
+	}
+
+	result, err := m.chain.CallContract(ctx, msg, nil)
+	if err != nil {
+		return nil, fmt.Errorf("call contract: %w", err)
+	}
+
+	allowance = new(big.Int).SetBytes(result)
+	return allowance, nil
+}
+
+// EnsureAllowance makes sure spender may spend at least minAllowance of the owner's tokens,
+// submitting an approval and waiting for it to be mined if the allowance is below it.
+// Returns ErrApprovalPending if an approval is still unmined when ctx ends or when
+// called again before it is mined, and ErrApprovalReverted if the approval reverted.
+func (m *AllowanceManager) EnsureAllowance(ctx context.Context, spender common.Address, minAllowance *big.Int) (err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	// Settle an approval left in flight by an earlier call before deciding anything
+	if txHash, ok := m.pending[spender]; ok {
+		err = m.checkReceipt(ctx, spender, txHash)
+		if err != nil {
+			return err
+		}
+	}
+
+	allowance, err := m.Allowance(ctx, spender)
+	if err != nil {
+		return fmt.Errorf("get allowance: %w", err)
+	}
+
+	if allowance.Cmp(minAllowance) >= 0 {
+		return nil
+	}
+
+	m.logger.Info("allowance-below-minimum-approving",
+		zap.String("owner", m.owner.Hex()),
+		zap.String("spender", spender.Hex()),
+		zap.String("allowance", allowance.String()),
+		zap.String("min-allowance", minAllowance.String()))
+
+	txHash, err := m.sendApproval(ctx, spender)
+	if err != nil {
+		return fmt.Errorf("send approval: %w", err)
+	}
+	m.pending[spender] = txHash
+
+	err = m.waitForReceipt(ctx, spender, txHash)
+	if err != nil {
+		return err
+	}
+
+	allowance, err = m.Allowance(ctx, spender)
+	if err != nil {
+		return fmt.Errorf("get allowance after approval: %w", err)
+	}
+
+	if allowance.Cmp(minAllowance) < 0 {
+		return fmt.Errorf("allowance %s still below minimum %s after approval", allowance, minAllowance)
+	}
+
+	return nil
+}
+
+// sendApproval signs and submits approve(spender, approveAmount).
+func (m *AllowanceManager) sendApproval(ctx context.Context, spender common.Address) (txHash common.Hash, err error) {
+	data, err := m.abi.Pack("approve", spender, m.approveAmount)
+	if err != nil {
+		return txHash, fmt.Errorf("pack approve call: %w", err)
+	}
+
+	signedTx, err := sendTx(ctx, m.chain, m.privateKey, m.owner, m.token, approveGasLimit, data)
+	if err != nil {
+		return txHash, err
+	}
+
+	txHash = signedTx.Hash()
+	m.logger.Info("approval-sent",
+		zap.String("tx-hash", txHash.Hex()),
+		zap.String("spender", spender.Hex()),
+		zap.Uint64("nonce", signedTx.Nonce()))
+
+	return txHash, nil
+}
+
+// waitForReceipt polls until the approval is mined or ctx ends.
+func (m *AllowanceManager) waitForReceipt(ctx context.Context, spender common.Address, txHash common.Hash) error {
+	receipt, err := waitForTx(ctx, m.chain, txHash, m.pollInterval)
+	return m.settleApproval(spender, txHash, receipt, err)
+}
+
+// checkReceipt looks up the receipt of a sent approval once and settles it.
+func (m *AllowanceManager) checkReceipt(ctx context.Context, spender common.Address, txHash common.Hash) error {
+	receipt, err := txReceipt(ctx, m.chain, txHash)
+	return m.settleApproval(spender, txHash, receipt, err)
+}
+
+// settleApproval handles a receipt lookup for a sent approval: it is forgotten once
+// mined, and ErrApprovalPending is returned while it is not.
+func (m *AllowanceManager) settleApproval(
+	spender common.Address,
+	txHash common.Hash,
+	receipt *types.Receipt,
+	err error,
+) error {
+	if errors.Is(err, errTxPending) {
+		return fmt.Errorf("%w: %s", ErrApprovalPending, txHash.Hex())
+	}
+	if err != nil {
+		return err
+	}
+
+	delete(m.pending, spender)
+
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		return fmt.Errorf("%w: %s", ErrApprovalReverted, txHash.Hex())
+	}
+
+	m.logger.Info("approval-confirmed",
+		zap.String("tx-hash", txHash.Hex()),
+		zap.String("spender", spender.Hex()),
+		zap.Uint64("gas-used", receipt.GasUsed))
+
+	return nil
+}
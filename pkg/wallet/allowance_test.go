@@ -0,0 +1,237 @@
+package wallet
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"go.uber.org/zap"
+)
+
+// mockChain simulates an ERC20 token: approvals are applied when their receipt is
+// released with mine.
+type mockChain struct {
+	mu        sync.Mutex
+	allowance *big.Int
+	sent      []*types.Transaction
+	mined     map[common.Hash]bool
+	revert    bool
+}
+
+func newMockChain(allowance int64) *mockChain {
+	return &mockChain{allowance: big.NewInt(allowance), mined: make(map[common.Hash]bool)}
+}
+
+func (m *mockChain) CallContract(_ context.Context, _ ethereum.CallMsg, _ *big.Int) ([]byte, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return common.LeftPadBytes(m.allowance.Bytes(), 32), nil
+}
+
+func (m *mockChain) PendingNonceAt(_ context.Context, _ common.Address) (uint64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return uint64(len(m.sent)), nil
+}
+
+func (m *mockChain) SuggestGasPrice(_ context.Context) (*big.Int, error) {
+	return big.NewInt(30_000_000_000), nil
+}
+
+func (m *mockChain) ChainID(_ context.Context) (*big.Int, error) {
+	return big.NewInt(137), nil
+}
+
+func (m *mockChain) SendTransaction(_ context.Context, tx *types.Transaction) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.sent = append(m.sent, tx)
+	return nil
+}
+
+func (m *mockChain) TransactionReceipt(_ context.Context, txHash common.Hash) (*types.Receipt, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if !m.mined[txHash] {
+		return nil, ethereum.NotFound
+	}
+	if m.revert {
+		return &types.Receipt{Status: types.ReceiptStatusFailed}, nil
+	}
+	return &types.Receipt{Status: types.ReceiptStatusSuccessful}, nil
+}
+
+// mine confirms every sent approval, setting the allowance to the approved amount.
+func (m *mockChain) mine(t *testing.T) {
+	t.Helper()
+
+	parsedABI, err := abi.JSON(strings.NewReader(erc20AllowanceABI))
+	if err != nil {
+		t.Fatalf("parse ABI: %v", err)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, tx := range m.sent {
+		m.mined[tx.Hash()] = true
+		if m.revert {
+			continue
+		}
+		args, err := parsedABI.Methods["approve"].Inputs.Unpack(tx.Data()[4:])
+		if err != nil {
+			t.Fatalf("unpack approve: %v", err)
+		}
+		m.allowance, _ = args[1].(*big.Int)
+	}
+}
+
+func (m *mockChain) sentCount() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return len(m.sent)
+}
+
+func newTestAllowanceManager(t *testing.T, chain *mockChain, approveAmount *big.Int) *AllowanceManager {
+	t.Helper()
+
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatalf("generate key: %v", err)
+	}
+
+	m, err := NewAllowanceManager(&AllowanceConfig{
+		Chain:               chain,
+		PrivateKey:          key,
+		Logger:              zap.NewNop(),
+		ApproveAmount:       approveAmount,
+		ReceiptPollInterval: time.Millisecond,
+	})
+	if err != nil {
+		t.Fatalf("create allowance manager: %v", err)
+	}
+
+	return m
+}
+
+var testSpender = common.HexToAddress(PolygonCTFExchange)
+
+// TestEnsureAllowance_Sufficient tests that no approval is sent when the allowance covers the minimum.
+func TestEnsureAllowance_Sufficient(t *testing.T) {
+	chain := newMockChain(5_000_000)
+	m := newTestAllowanceManager(t, chain, nil)
+
+	err := m.EnsureAllowance(context.Background(), testSpender, big.NewInt(5_000_000))
+	if err != nil {
+		t.Fatalf("ensure allowance: %v", err)
+	}
+
+	if chain.sentCount() != 0 {
+		t.Errorf("expected no approval, got %d transactions", chain.sentCount())
+	}
+}
+
+// TestEnsureAllowance_Insufficient tests that a low allowance is approved and confirmed.
+func TestEnsureAllowance_Insufficient(t *testing.T) {
+	chain := newMockChain(1_000_000)
+	m := newTestAllowanceManager(t, chain, big.NewInt(100_000_000))
+
+	done := make(chan error, 1)
+	go func() {
+		done <- m.EnsureAllowance(context.Background(), testSpender, big.NewInt(50_000_000))
+	}()
+
+	waitForSent(t, chain, 1)
+	chain.mine(t)
+
+	err := <-done
+	if err != nil {
+		t.Fatalf("ensure allowance: %v", err)
+	}
+
+	tx := chain.sent[0]
+	if tx.To() == nil || *tx.To() != common.HexToAddress(PolygonUSDCe) {
+		t.Errorf("expected approval sent to USDC.e, got %v", tx.To())
+	}
+	if chain.allowance.Cmp(big.NewInt(100_000_000)) != 0 {
+		t.Errorf("expected allowance 100000000, got %s", chain.allowance)
+	}
+}
+
+// TestEnsureAllowance_ApprovalInProgress tests that an unmined approval is reported as
+// pending and is not sent again on the next call.
+func TestEnsureAllowance_ApprovalInProgress(t *testing.T) {
+	chain := newMockChain(0)
+	m := newTestAllowanceManager(t, chain, nil)
+	minAllowance := big.NewInt(10_000_000)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	err := m.EnsureAllowance(ctx, testSpender, minAllowance)
+	if !errors.Is(err, ErrApprovalPending) {
+		t.Fatalf("expected ErrApprovalPending, got %v", err)
+	}
+
+	// Still unmined: pending again, without a second approval
+	err = m.EnsureAllowance(context.Background(), testSpender, minAllowance)
+	if !errors.Is(err, ErrApprovalPending) {
+		t.Fatalf("expected ErrApprovalPending on retry, got %v", err)
+	}
+	if chain.sentCount() != 1 {
+		t.Fatalf("expected 1 approval, got %d", chain.sentCount())
+	}
+
+	chain.mine(t)
+
+	err = m.EnsureAllowance(context.Background(), testSpender, minAllowance)
+	if err != nil {
+		t.Fatalf("expected allowance after approval was mined, got %v", err)
+	}
+	if chain.sentCount() != 1 {
+		t.Errorf("expected no further approvals, got %d", chain.sentCount())
+	}
+}
+
+// TestEnsureAllowance_Reverted tests that a reverted approval is reported and can be retried.
+func TestEnsureAllowance_Reverted(t *testing.T) {
+	chain := newMockChain(0)
+	chain.revert = true
+	m := newTestAllowanceManager(t, chain, nil)
+
+	done := make(chan error, 1)
+	go func() {
+		done <- m.EnsureAllowance(context.Background(), testSpender, big.NewInt(1))
+	}()
+
+	waitForSent(t, chain, 1)
+	chain.mine(t)
+
+	err := <-done
+	if !errors.Is(err, ErrApprovalReverted) {
+		t.Fatalf("expected ErrApprovalReverted, got %v", err)
+	}
+	if len(m.pending) != 0 {
+		t.Error("expected reverted approval to be forgotten")
+	}
+}
+
+func waitForSent(t *testing.T, chain *mockChain, n int) {
+	t.Helper()
+
+	deadline := time.Now().Add(time.Second)
+	for chain.sentCount() < n {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %d approvals", n)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
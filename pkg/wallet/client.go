@@ -21,6 +21,12 @@ const (
 	polygonUSDC        = "0x2791Bca1f2de4661ED88A30C99A7a9449Aa84174"
 	polygonCTFExchange = "0x4bFb41d5B3570DeFd03C39a9A4D8dE6Bd8B8982E"
 	dataAPIBaseURL     = "https://data-api.polymarket.com"
+
+	// PolygonUSDCe is the bridged USDC.e token (Polymarket's default collateral).
+	PolygonUSDCe = polygonUSDC
+
+	// PolygonNativeUSDC is Circle's native USDC token on Polygon.
+	PolygonNativeUSDC = "0x3c499c542cEF5E3811e1192ce70d8cC03d5c3359"
 )
 
 // Client handles wallet data fetching from blockchain and APIs.
@@ -41,7 +47,10 @@ type Balances struct {
 type Position struct {
 	MarketSlug   string
 	ConditionID  string
+	TokenID      string
 	Outcome      string
+	OutcomeIndex int // Outcome slot in the condition
+	NegRisk      bool
 	Size         float64
 	AvgPrice     float64 // Average entry price
 	CurrentPrice float64 // Current market price
@@ -65,6 +74,8 @@ type dataAPIPosition struct {
 	Title        string  `json:"title"`
 	Slug         string  `json:"slug"`
 	Outcome      string  `json:"outcome"`
+	OutcomeIndex int     `json:"outcomeIndex"`
+	NegativeRisk bool    `json:"negativeRisk"`
 }
 
 // NewClient creates a new wallet client.
@@ -123,6 +134,23 @@ func (c *Client) GetBalances(ctx context.Context, address common.Address) (balan
 	return balances, nil
 }
 
+// GetERC20Balance fetches the balance of an arbitrary ERC20 token for a holder.
+// The result is in the token's smallest unit (6 decimals for USDC variants).
+func (c *Client) GetERC20Balance(ctx context.Context, token common.Address, holder common.Address) (balance *big.Int, err error) {
+	client, err := ethclient.DialContext(ctx, c.rpcURL)
+	if err != nil {
+		return nil, fmt.Errorf("dial RPC: %w", err)
+	}
+	defer client.Close()
+
+	balance, err = c.getERC20Balance(ctx, client, holder, token.Hex())
+	if err != nil {
+		return nil, fmt.Errorf("get ERC20 balance for %s: %w", token.Hex(), err)
+	}
+
+	return balance, nil
+}
+
 // getERC20Balance fetches ERC20 token balance for an address.
 func (c *Client) getERC20Balance(
 	ctx context.Context,
@@ -225,7 +253,10 @@ func (c *Client) GetPositions(ctx context.Context, address string) (positions []
 			position := Position{
 				MarketSlug:   pos.Slug,
 				ConditionID:  pos.ConditionID,
+				TokenID:      pos.Asset,
 				Outcome:      pos.Outcome,
+				OutcomeIndex: pos.OutcomeIndex,
+				NegRisk:      pos.NegativeRisk,
 				Size:         pos.Size,
 				AvgPrice:     pos.AvgPrice,
 				CurrentPrice: pos.CurPrice,
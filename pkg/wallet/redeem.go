@@ -0,0 +1,261 @@
+package wallet
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"go.uber.org/zap"
+)
+
+const (
+	conditionalTokensABI = `[
+	{"constant":true,"inputs":[{"name":"conditionId","type":"bytes32"}],"name":"getOutcomeSlotCount","outputs":[{"name":"","type":"uint256"}],"type":"function"},
+	{"constant":true,"inputs":[{"name":"","type":"bytes32"}],"name":"payoutDenominator","outputs":[{"name":"","type":"uint256"}],"type":"function"},
+	{"constant":true,"inputs":[{"name":"","type":"bytes32"},{"name":"","type":"uint256"}],"name":"payoutNumerators","outputs":[{"name":"","type":"uint256"}],"type":"function"},
+	{"constant":false,"inputs":[{"name":"collateralToken","type":"address"},{"name":"parentCollectionId","type":"bytes32"},{"name":"conditionId","type":"bytes32"},{"name":"indexSets","type":"uint256[]"}],"name":"redeemPositions","outputs":[],"type":"function"}
+]`
+
+	// PolygonConditionalTokens is the Gnosis Conditional Tokens contract holding Polymarket
+	// outcome tokens. Resolved conditions are redeemed for collateral here.
+	PolygonConditionalTokens = "0x4D97DCd97eC945f40cF65F87097ACe5EA0476045"
+
+	// redeemGasLimit covers redeemPositions for a condition with a few outcome slots.
+	redeemGasLimit = uint64(200000)
+
+	defaultRedeemReceiptTimeout = 2 * time.Minute
+)
+
+// ErrRedemptionPending is returned while a redemption transaction sent for the condition
+// has not been mined.
+var ErrRedemptionPending = errors.New("redemption transaction pending")
+
+// ErrRedemptionReverted is returned when a redemption transaction was mined but reverted.
+var ErrRedemptionReverted = errors.New("redemption transaction reverted")
+
+// RedeemerConfig holds configuration for the CTF redeemer.
+type RedeemerConfig struct {
+	Chain      ChainClient
+	PrivateKey *ecdsa.PrivateKey // Signs redemptions; positions are redeemed for its address
+	Logger     *zap.Logger
+
+	// Collateral is the token positions were split from (default: PolygonUSDCe).
+	Collateral common.Address
+
+	// ReceiptPollInterval is the wait between receipt lookups for a sent redemption (default: 2s).
+	ReceiptPollInterval time.Duration
+
+	// ReceiptTimeout bounds how long Redeem waits for a redemption to be mined (default: 2m).
+	ReceiptTimeout time.Duration
+}
+
+// CTFRedeemer reads condition payouts from the Conditional Tokens contract and redeems
+// resolved positions for collateral. Neg-risk positions are redeemed through the
+// NegRiskAdapter instead and are not supported. At most one redemption per condition is
+// in flight; it is remembered across calls so a timed-out wait never leads to a resend.
+type CTFRedeemer struct {
+	chain          ChainClient
+	privateKey     *ecdsa.PrivateKey
+	owner          common.Address
+	ctf            common.Address
+	collateral     common.Address
+	pollInterval   time.Duration
+	receiptTimeout time.Duration
+	logger         *zap.Logger
+	abi            abi.ABI
+
+	mu      sync.Mutex
+	pending map[string]common.Hash // condition ID -> unmined redemption tx
+}
+
+// NewCTFRedeemer creates a new CTF redeemer.
+func NewCTFRedeemer(cfg *RedeemerConfig) (r *CTFRedeemer, err error) {
+	if cfg.Chain == nil {
+		return nil, errors.New("chain client cannot be nil")
+	}
+
+	if cfg.PrivateKey == nil {
+		return nil, errors.New("private key cannot be nil")
+	}
+
+	if cfg.Logger == nil {
+		return nil, errors.New("logger cannot be nil")
+	}
+
+	parsedABI, err := abi.JSON(strings.NewReader(conditionalTokensABI))
+	if err != nil {
+		return nil, fmt.Errorf("parse ABI: %w", err)
+	}
+
+	collateral := cfg.Collateral
+	if collateral == (common.Address{}) {
+		collateral = common.HexToAddress(PolygonUSDCe)
+	}
+
+	pollInterval := cfg.ReceiptPollInterval
+	if pollInterval <= 0 {
+		pollInterval = defaultReceiptPollInterval
+	}
+
+	receiptTimeout := cfg.ReceiptTimeout
+	if receiptTimeout <= 0 {
+		receiptTimeout = defaultRedeemReceiptTimeout
+	}
+
+	r = &CTFRedeemer{
+		chain:          cfg.Chain,
+		privateKey:     cfg.PrivateKey,
+		owner:          crypto.PubkeyToAddress(cfg.PrivateKey.PublicKey),
+		ctf:            common.HexToAddress(PolygonConditionalTokens),
+		collateral:     collateral,
+		pollInterval:   pollInterval,
+		receiptTimeout: receiptTimeout,
+		logger:         cfg.Logger,
+		abi:            parsedABI,
+		pending:        make(map[string]common.Hash),
+	}
+
+	return r, nil
+}
+
+// Owner returns the address whose positions are redeemed.
+func (r *CTFRedeemer) Owner() common.Address {
+	return r.owner
+}
+
+// ConditionPayouts returns the payout fraction of each outcome slot of a resolved
+// condition (e.g. [1, 0] when the first outcome won), or nil if it has not resolved.
+func (r *CTFRedeemer) ConditionPayouts(ctx context.Context, conditionID string) (payouts []float64, err error) {
+	condition := common.HexToHash(conditionID)
+
+	denominator, err := r.call(ctx, "payoutDenominator", condition)
+	if err != nil {
+		return nil, fmt.Errorf("get payout denominator: %w", err)
+	}
+	if denominator.Sign() == 0 {
+		return nil, nil
+	}
+
+	slots, err := r.call(ctx, "getOutcomeSlotCount", condition)
+	if err != nil {
+		return nil, fmt.Errorf("get outcome slot count: %w", err)
+	}
+
+	denom := new(big.Float).SetInt(denominator)
+	payouts = make([]float64, slots.Int64())
+	for i := range payouts {
+		numerator, err := r.call(ctx, "payoutNumerators", condition, big.NewInt(int64(i)))
+		if err != nil {
+			return nil, fmt.Errorf("get payout numerator %d: %w", i, err)
+		}
+		payouts[i], _ = new(big.Float).Quo(new(big.Float).SetInt(numerator), denom).Float64()
+	}
+
+	return payouts, nil
+}
+
+// Redeem redeems every outcome slot of a resolved condition for collateral and waits up
+// to the receipt timeout for the transaction to be mined. Losing slots redeem for nothing,
+// so the whole condition is redeemed in one transaction. A redemption still unmined from
+// an earlier call is waited on again instead of being resent. Returns ErrRedemptionPending
+// if it is not mined in time, and ErrRedemptionReverted if it reverted.
+func (r *CTFRedeemer) Redeem(ctx context.Context, conditionID string, outcomeCount int) (txHash string, err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	hash, ok := r.pending[conditionID]
+	if !ok {
+		hash, err = r.sendRedemption(ctx, conditionID, outcomeCount)
+		if err != nil {
+			return "", err
+		}
+		r.pending[conditionID] = hash
+	}
+
+	return hash.Hex(), r.waitForReceipt(ctx, conditionID, hash)
+}
+
+// sendRedemption signs and submits redeemPositions for every outcome slot of the condition.
+func (r *CTFRedeemer) sendRedemption(ctx context.Context, conditionID string, outcomeCount int) (txHash common.Hash, err error) {
+	indexSets := make([]*big.Int, outcomeCount)
+	for i := range indexSets {
+		indexSets[i] = new(big.Int).Lsh(big.NewInt(1), uint(i))
+	}
+
+	data, err := r.abi.Pack("redeemPositions", r.collateral, common.Hash{}, common.HexToHash(conditionID), indexSets)
+	if err != nil {
+		return txHash, fmt.Errorf("pack redeem call: %w", err)
+	}
+
+	signedTx, err := sendTx(ctx, r.chain, r.privateKey, r.owner, r.ctf, redeemGasLimit, data)
+	if err != nil {
+		return txHash, err
+	}
+
+	txHash = signedTx.Hash()
+	r.logger.Info("redemption-sent",
+		zap.String("tx-hash", txHash.Hex()),
+		zap.String("condition-id", conditionID),
+		zap.Uint64("nonce", signedTx.Nonce()))
+
+	return txHash, nil
+}
+
+// call runs a read-only Conditional Tokens method that returns a single uint256.
+func (r *CTFRedeemer) call(ctx context.Context, method string, args ...interface{}) (value *big.Int, err error) {
+	data, err := r.abi.Pack(method, args...)
+	if err != nil {
+		return nil, fmt.Errorf("pack ABI: %w", err)
+	}
+
+	msg := ethereum.CallMsg{
+		To:   &r.ctf,
+		Data: data,
+	}
+
+	result, err := r.chain.CallContract(ctx, msg, nil)
+	if err != nil {
+		return nil, fmt.Errorf("call contract: %w", err)
+	}
+
+	value = new(big.Int).SetBytes(result)
+	return value, nil
+}
+
+// waitForReceipt polls until the redemption is mined, the receipt timeout passes, or ctx
+// ends. The redemption is forgotten once mined.
+func (r *CTFRedeemer) waitForReceipt(ctx context.Context, conditionID string, txHash common.Hash) error {
+	waitCtx, cancel := context.WithTimeout(ctx, r.receiptTimeout)
+	defer cancel()
+
+	receipt, err := waitForTx(waitCtx, r.chain, txHash, r.pollInterval)
+	if errors.Is(err, errTxPending) {
+		return fmt.Errorf("%w: %s", ErrRedemptionPending, txHash.Hex())
+	}
+	if err != nil {
+		return err
+	}
+
+	delete(r.pending, conditionID)
+
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		return fmt.Errorf("%w: %s", ErrRedemptionReverted, txHash.Hex())
+	}
+
+	r.logger.Info("redemption-confirmed",
+		zap.String("tx-hash", txHash.Hex()),
+		zap.String("condition-id", conditionID),
+		zap.Uint64("gas-used", receipt.GasUsed))
+
+	return nil
+}
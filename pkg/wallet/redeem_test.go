@@ -0,0 +1,261 @@
+package wallet
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"go.uber.org/zap"
+)
+
+// mockCTF simulates the Conditional Tokens contract for a single condition. Sent
+// redemptions are mined immediately unless unmined is set.
+type mockCTF struct {
+	t *testing.T
+
+	mu          sync.Mutex
+	abi         abi.ABI
+	numerators  []int64 // nil while unresolved
+	denominator int64
+	sent        []*types.Transaction
+	revert      bool
+	unmined     bool
+}
+
+func newMockCTF(t *testing.T, numerators []int64) *mockCTF {
+	t.Helper()
+
+	parsedABI, err := abi.JSON(strings.NewReader(conditionalTokensABI))
+	if err != nil {
+		t.Fatalf("parse ABI: %v", err)
+	}
+
+	m := &mockCTF{t: t, abi: parsedABI, numerators: numerators}
+	for _, n := range numerators {
+		m.denominator += n
+	}
+	return m
+}
+
+func (m *mockCTF) CallContract(_ context.Context, msg ethereum.CallMsg, _ *big.Int) ([]byte, error) {
+	if *msg.To != common.HexToAddress(PolygonConditionalTokens) {
+		m.t.Errorf("call to %s, expected the Conditional Tokens contract", msg.To.Hex())
+	}
+
+	method, err := m.abi.MethodById(msg.Data[:4])
+	if err != nil {
+		return nil, err
+	}
+
+	var value int64
+	switch method.Name {
+	case "payoutDenominator":
+		value = m.denominator
+	case "getOutcomeSlotCount":
+		value = 2
+	case "payoutNumerators":
+		args, err := method.Inputs.Unpack(msg.Data[4:])
+		if err != nil {
+			return nil, err
+		}
+		index, _ := args[1].(*big.Int)
+		value = m.numerators[index.Int64()]
+	}
+
+	return common.LeftPadBytes(big.NewInt(value).Bytes(), 32), nil
+}
+
+func (m *mockCTF) PendingNonceAt(_ context.Context, _ common.Address) (uint64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return uint64(len(m.sent)), nil
+}
+
+func (m *mockCTF) SuggestGasPrice(_ context.Context) (*big.Int, error) {
+	return big.NewInt(30_000_000_000), nil
+}
+
+func (m *mockCTF) ChainID(_ context.Context) (*big.Int, error) {
+	return big.NewInt(137), nil
+}
+
+func (m *mockCTF) SendTransaction(_ context.Context, tx *types.Transaction) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.sent = append(m.sent, tx)
+	return nil
+}
+
+func (m *mockCTF) TransactionReceipt(_ context.Context, _ common.Hash) (*types.Receipt, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.unmined {
+		return nil, ethereum.NotFound
+	}
+	if m.revert {
+		return &types.Receipt{Status: types.ReceiptStatusFailed}, nil
+	}
+	return &types.Receipt{Status: types.ReceiptStatusSuccessful}, nil
+}
+
+func newTestRedeemer(t *testing.T, chain ChainClient) *CTFRedeemer {
+	t.Helper()
+	return newTestRedeemerWithTimeout(t, chain, 0)
+}
+
+func newTestRedeemerWithTimeout(t *testing.T, chain ChainClient, receiptTimeout time.Duration) *CTFRedeemer {
+	t.Helper()
+
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatalf("generate key: %v", err)
+	}
+
+	r, err := NewCTFRedeemer(&RedeemerConfig{
+		Chain:               chain,
+		PrivateKey:          key,
+		Logger:              zap.NewNop(),
+		ReceiptPollInterval: time.Millisecond,
+		ReceiptTimeout:      receiptTimeout,
+	})
+	if err != nil {
+		t.Fatalf("create redeemer: %v", err)
+	}
+
+	return r
+}
+
+const testConditionID = "0x5f65177b394277fd294cd75650044e32ba009a95022d88a0c1d565897d72f8f1"
+
+// TestConditionPayouts tests that payouts are read as fractions of the denominator, and
+// that an unresolved condition reports nil.
+func TestConditionPayouts(t *testing.T) {
+	tests := []struct {
+		name       string
+		numerators []int64
+		want       []float64
+	}{
+		{name: "unresolved"},
+		{name: "first_wins", numerators: []int64{1, 0}, want: []float64{1, 0}},
+		{name: "second_wins", numerators: []int64{0, 1}, want: []float64{0, 1}},
+		{name: "split", numerators: []int64{1, 1}, want: []float64{0.5, 0.5}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newTestRedeemer(t, newMockCTF(t, tt.numerators))
+
+			payouts, err := r.ConditionPayouts(context.Background(), testConditionID)
+			if err != nil {
+				t.Fatalf("ConditionPayouts() error: %v", err)
+			}
+
+			if len(payouts) != len(tt.want) || (tt.want == nil) != (payouts == nil) {
+				t.Fatalf("expected payouts %v, got %v", tt.want, payouts)
+			}
+			for i := range tt.want {
+				if payouts[i] != tt.want[i] {
+					t.Errorf("expected payouts %v, got %v", tt.want, payouts)
+					break
+				}
+			}
+		})
+	}
+}
+
+// TestRedeem tests that every outcome slot of the condition is redeemed against USDC.e
+// in a transaction to the Conditional Tokens contract.
+func TestRedeem(t *testing.T) {
+	chain := newMockCTF(t, []int64{1, 0})
+	r := newTestRedeemer(t, chain)
+
+	txHash, err := r.Redeem(context.Background(), testConditionID, 2)
+	if err != nil {
+		t.Fatalf("Redeem() error: %v", err)
+	}
+
+	if len(chain.sent) != 1 {
+		t.Fatalf("expected 1 transaction, got %d", len(chain.sent))
+	}
+	tx := chain.sent[0]
+	if txHash != tx.Hash().Hex() {
+		t.Errorf("expected tx hash %s, got %s", tx.Hash().Hex(), txHash)
+	}
+	if *tx.To() != common.HexToAddress(PolygonConditionalTokens) {
+		t.Errorf("expected transaction to the Conditional Tokens contract, got %s", tx.To().Hex())
+	}
+
+	args, err := chain.abi.Methods["redeemPositions"].Inputs.Unpack(tx.Data()[4:])
+	if err != nil {
+		t.Fatalf("unpack redeemPositions: %v", err)
+	}
+	if collateral, _ := args[0].(common.Address); collateral != common.HexToAddress(PolygonUSDCe) {
+		t.Errorf("expected USDC.e collateral, got %s", collateral.Hex())
+	}
+	if condition, _ := args[2].([32]byte); common.Hash(condition) != common.HexToHash(testConditionID) {
+		t.Errorf("expected condition %s, got %x", testConditionID, condition)
+	}
+	indexSets, _ := args[3].([]*big.Int)
+	if len(indexSets) != 2 || indexSets[0].Int64() != 1 || indexSets[1].Int64() != 2 {
+		t.Errorf("expected index sets [1 2], got %v", indexSets)
+	}
+}
+
+// TestRedeem_Reverted tests that a reverted redemption is reported.
+func TestRedeem_Reverted(t *testing.T) {
+	chain := newMockCTF(t, []int64{1, 0})
+	chain.revert = true
+	r := newTestRedeemer(t, chain)
+
+	txHash, err := r.Redeem(context.Background(), testConditionID, 2)
+	if !errors.Is(err, ErrRedemptionReverted) {
+		t.Fatalf("expected ErrRedemptionReverted, got %v", err)
+	}
+	if txHash == "" {
+		t.Error("expected the reverted tx hash to be returned")
+	}
+}
+
+// TestRedeem_PendingNotResent tests that a redemption not mined within the receipt timeout
+// is reported pending, and that later calls wait on the same transaction instead of
+// sending another.
+func TestRedeem_PendingNotResent(t *testing.T) {
+	chain := newMockCTF(t, []int64{1, 0})
+	chain.unmined = true
+	r := newTestRedeemerWithTimeout(t, chain, 10*time.Millisecond)
+
+	first, err := r.Redeem(context.Background(), testConditionID, 2)
+	if !errors.Is(err, ErrRedemptionPending) {
+		t.Fatalf("expected ErrRedemptionPending, got %v", err)
+	}
+
+	second, err := r.Redeem(context.Background(), testConditionID, 2)
+	if !errors.Is(err, ErrRedemptionPending) {
+		t.Fatalf("expected ErrRedemptionPending on retry, got %v", err)
+	}
+
+	chain.mu.Lock()
+	chain.unmined = false
+	chain.mu.Unlock()
+
+	third, err := r.Redeem(context.Background(), testConditionID, 2)
+	if err != nil {
+		t.Fatalf("expected the pending redemption to confirm, got %v", err)
+	}
+
+	if len(chain.sent) != 1 {
+		t.Errorf("expected 1 transaction, got %d", len(chain.sent))
+	}
+	if second != first || third != first {
+		t.Errorf("expected every call to report tx %s, got %s and %s", first, second, third)
+	}
+}
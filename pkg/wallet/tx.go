@@ -0,0 +1,96 @@
+package wallet
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// errTxPending is returned by txReceipt while a transaction has not been mined.
+var errTxPending = errors.New("transaction pending")
+
+// sendTx signs a contract call from key's address to `to` and submits it at the pending
+// nonce and suggested gas price.
+func sendTx(
+	ctx context.Context,
+	chain ChainClient,
+	key *ecdsa.PrivateKey,
+	from, to common.Address,
+	gasLimit uint64,
+	data []byte,
+) (signedTx *types.Transaction, err error) {
+	nonce, err := chain.PendingNonceAt(ctx, from)
+	if err != nil {
+		return nil, fmt.Errorf("get nonce: %w", err)
+	}
+
+	gasPrice, err := chain.SuggestGasPrice(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get gas price: %w", err)
+	}
+
+	chainID, err := chain.ChainID(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get chain ID: %w", err)
+	}
+
+	tx := types.NewTransaction(nonce, to, big.NewInt(0), gasLimit, gasPrice, data)
+
+	signedTx, err = types.SignTx(tx, types.NewEIP155Signer(chainID), key)
+	if err != nil {
+		return nil, fmt.Errorf("sign transaction: %w", err)
+	}
+
+	err = chain.SendTransaction(ctx, signedTx)
+	if err != nil {
+		return nil, fmt.Errorf("send transaction: %w", err)
+	}
+
+	return signedTx, nil
+}
+
+// txReceipt returns the receipt of a mined transaction, or errTxPending if it has not
+// been mined yet.
+func txReceipt(ctx context.Context, chain ChainClient, txHash common.Hash) (*types.Receipt, error) {
+	receipt, err := chain.TransactionReceipt(ctx, txHash)
+	if errors.Is(err, ethereum.NotFound) {
+		return nil, fmt.Errorf("%w: %s", errTxPending, txHash.Hex())
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get receipt for %s: %w", txHash.Hex(), err)
+	}
+
+	return receipt, nil
+}
+
+// waitForTx polls txReceipt every pollInterval until the transaction is mined, a lookup
+// fails, or ctx ends. errTxPending is returned if ctx ends first.
+func waitForTx(
+	ctx context.Context,
+	chain ChainClient,
+	txHash common.Hash,
+	pollInterval time.Duration,
+) (*types.Receipt, error) {
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	for {
+		receipt, err := txReceipt(ctx, chain, txHash)
+		if !errors.Is(err, errTxPending) {
+			return receipt, err
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, err
+		case <-ticker.C:
+		}
+	}
+}
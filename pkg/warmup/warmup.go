@@ -0,0 +1,140 @@
+// Package warmup holds off detection and readiness after startup until market data has
+// arrived, so empty orderbooks aren't mistaken for a market without opportunities.
+package warmup
+
+import (
+	"sync/atomic"
+	"time"
+
+	"github.com/mselser95/polymarket-arb/pkg/types"
+	"go.uber.org/zap"
+)
+
+// defaultCheckInterval is how often a cold gate re-counts markets with books.
+const defaultCheckInterval = time.Second
+
+// MarketLister reports the currently subscribed markets.
+type MarketLister interface {
+	GetSubscribedMarkets() []*types.MarketSubscription
+}
+
+// SnapshotGetter returns the latest orderbook snapshot of a token.
+type SnapshotGetter interface {
+	GetSnapshot(tokenID string) (*types.OrderbookSnapshot, bool)
+}
+
+// Config holds warmup gate configuration.
+type Config struct {
+	Period time.Duration // Minimum time after New before the gate opens (0 = none)
+
+	// MinBookFraction is the fraction of subscribed markets that must have a snapshot for
+	// every outcome before the gate opens (0 = none). With no subscribed markets the
+	// fraction is never met.
+	MinBookFraction float64
+
+	// CheckInterval is how often IsWarm re-counts markets with books while the gate is
+	// cold; calls in between report cold without walking every market (default 1s).
+	CheckInterval time.Duration
+
+	Markets    MarketLister
+	Orderbooks SnapshotGetter
+	Logger     *zap.Logger
+}
+
+// Gate reports whether startup warmup has completed. Once warm it stays warm, so market
+// churn after startup doesn't flap readiness or pause detection.
+type Gate struct {
+	period          time.Duration
+	minBookFraction float64
+	checkInterval   time.Duration
+	markets         MarketLister
+	orderbooks      SnapshotGetter
+	logger          *zap.Logger
+	startTime       time.Time
+	now             func() time.Time
+	warm            atomic.Bool
+	nextCheck       atomic.Int64 // Unix nanoseconds before which book counts aren't re-checked
+}
+
+// New creates a warmup gate whose period starts now.
+func New(cfg *Config) *Gate {
+	checkInterval := cfg.CheckInterval
+	if checkInterval <= 0 {
+		checkInterval = defaultCheckInterval
+	}
+
+	return &Gate{
+		period:          cfg.Period,
+		minBookFraction: cfg.MinBookFraction,
+		checkInterval:   checkInterval,
+		markets:         cfg.Markets,
+		orderbooks:      cfg.Orderbooks,
+		logger:          cfg.Logger,
+		startTime:       time.Now(),
+		now:             time.Now,
+	}
+}
+
+// IsWarm reports whether the warmup period has elapsed and enough subscribed markets
+// have orderbooks. While cold, the book count is re-checked at most once per check
+// interval, since detection calls this on every orderbook update. Safe for concurrent use.
+func (g *Gate) IsWarm() bool {
+	if g.warm.Load() {
+		return true
+	}
+
+	now := g.now()
+	elapsed := now.Sub(g.startTime)
+	if elapsed < g.period {
+		return false
+	}
+
+	// One caller per interval claims the check; the rest report cold until it opens the gate
+	next := g.nextCheck.Load()
+	if now.UnixNano() < next || !g.nextCheck.CompareAndSwap(next, now.Add(g.checkInterval).UnixNano()) {
+		return false
+	}
+
+	ready, total := g.Progress()
+	if g.minBookFraction > 0 && (total == 0 || float64(ready)/float64(total) < g.minBookFraction) {
+		return false
+	}
+
+	// Log once, even if several callers observe the transition
+	if g.warm.CompareAndSwap(false, true) {
+		g.logger.Info("warmup-complete",
+			zap.Duration("elapsed", elapsed),
+			zap.Int("markets-ready", ready),
+			zap.Int("markets-total", total))
+	}
+
+	return true
+}
+
+// Progress returns how many subscribed markets have a snapshot for every outcome, out
+// of the total subscribed.
+func (g *Gate) Progress() (ready int, total int) {
+	markets := g.markets.GetSubscribedMarkets()
+	for _, market := range markets {
+		if g.hasBooks(market) {
+			ready++
+		}
+	}
+
+	return ready, len(markets)
+}
+
+// hasBooks reports whether every outcome of a market has a snapshot.
+func (g *Gate) hasBooks(market *types.MarketSubscription) bool {
+	if len(market.Outcomes) == 0 {
+		return false
+	}
+
+	for _, outcome := range market.Outcomes {
+		if _, exists := g.orderbooks.GetSnapshot(outcome.TokenID); !exists {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,185 @@
+package warmup
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mselser95/polymarket-arb/pkg/types"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+type mockMarkets struct {
+	markets []*types.MarketSubscription
+	calls   int
+}
+
+func (m *mockMarkets) GetSubscribedMarkets() []*types.MarketSubscription {
+	m.calls++
+	return m.markets
+}
+
+type mockOrderbooks struct{ tokens map[string]bool }
+
+func (m *mockOrderbooks) GetSnapshot(tokenID string) (*types.OrderbookSnapshot, bool) {
+	if !m.tokens[tokenID] {
+		return nil, false
+	}
+	return &types.OrderbookSnapshot{TokenID: tokenID}, true
+}
+
+// binaryMarket returns a market with YES and NO tokens "<id>-yes" and "<id>-no".
+func binaryMarket(id string) *types.MarketSubscription {
+	return &types.MarketSubscription{
+		MarketID: id,
+		Outcomes: []types.OutcomeToken{
+			{TokenID: id + "-yes", Outcome: "YES"},
+			{TokenID: id + "-no", Outcome: "NO"},
+		},
+	}
+}
+
+// newTestGate creates a gate over four binary markets m1-m4 with no books, and a clock
+// the test advances.
+func newTestGate(cfg *Config) (*Gate, *mockOrderbooks, *time.Time) {
+	books := &mockOrderbooks{tokens: make(map[string]bool)}
+	cfg.Markets = &mockMarkets{markets: []*types.MarketSubscription{
+		binaryMarket("m1"), binaryMarket("m2"), binaryMarket("m3"), binaryMarket("m4"),
+	}}
+	cfg.Orderbooks = books
+	if cfg.Logger == nil {
+		cfg.Logger = zap.NewNop()
+	}
+
+	gate := New(cfg)
+	now := gate.startTime
+	gate.now = func() time.Time { return now }
+
+	return gate, books, &now
+}
+
+func TestGate_Period(t *testing.T) {
+	gate, _, now := newTestGate(&Config{Period: 30 * time.Second})
+
+	if gate.IsWarm() {
+		t.Fatal("expected gate closed at startup")
+	}
+
+	*now = now.Add(29 * time.Second)
+	if gate.IsWarm() {
+		t.Fatal("expected gate closed before the period elapses")
+	}
+
+	*now = now.Add(time.Second)
+	if !gate.IsWarm() {
+		t.Fatal("expected gate open once the period elapses")
+	}
+}
+
+func TestGate_MinBookFraction(t *testing.T) {
+	gate, books, now := newTestGate(&Config{MinBookFraction: 0.75})
+
+	// Two full markets and one with only its YES book
+	books.tokens["m1-yes"], books.tokens["m1-no"] = true, true
+	books.tokens["m2-yes"], books.tokens["m2-no"] = true, true
+	books.tokens["m3-yes"] = true
+
+	if ready, total := gate.Progress(); ready != 2 || total != 4 {
+		t.Fatalf("expected 2/4 markets ready, got %d/%d", ready, total)
+	}
+	if gate.IsWarm() {
+		t.Fatal("expected gate closed at 50% of markets with books")
+	}
+
+	books.tokens["m3-no"] = true
+	*now = now.Add(time.Second) // Next book check
+	if !gate.IsWarm() {
+		t.Fatal("expected gate open at 75% of markets with books")
+	}
+}
+
+func TestGate_PeriodAndFraction(t *testing.T) {
+	gate, books, now := newTestGate(&Config{Period: time.Minute, MinBookFraction: 0.5})
+
+	// Books arrive before the period ends
+	for _, token := range []string{"m1-yes", "m1-no", "m2-yes", "m2-no"} {
+		books.tokens[token] = true
+	}
+	if gate.IsWarm() {
+		t.Fatal("expected gate closed until the period elapses")
+	}
+
+	*now = now.Add(time.Minute)
+	if !gate.IsWarm() {
+		t.Fatal("expected gate open once both conditions hold")
+	}
+}
+
+func TestGate_NoMarkets(t *testing.T) {
+	gate := New(&Config{
+		MinBookFraction: 0.5,
+		Markets:         &mockMarkets{},
+		Orderbooks:      &mockOrderbooks{},
+		Logger:          zap.NewNop(),
+	})
+
+	if gate.IsWarm() {
+		t.Fatal("expected gate closed with no subscribed markets")
+	}
+}
+
+// TestGate_StaysWarm tests that the gate doesn't close again when markets churn after
+// warmup, and logs the transition once.
+func TestGate_StaysWarm(t *testing.T) {
+	core, logs := observer.New(zapcore.InfoLevel)
+	gate, books, _ := newTestGate(&Config{MinBookFraction: 0.25, Logger: zap.New(core)})
+
+	books.tokens["m1-yes"], books.tokens["m1-no"] = true, true
+	if !gate.IsWarm() {
+		t.Fatal("expected gate open")
+	}
+
+	// m1's books are evicted
+	delete(books.tokens, "m1-yes")
+	if !gate.IsWarm() {
+		t.Fatal("expected gate to stay open after warmup")
+	}
+
+	if n := logs.FilterMessage("warmup-complete").Len(); n != 1 {
+		t.Errorf("expected warmup-complete logged once, got %d", n)
+	}
+}
+
+// TestGate_ThrottlesBookChecks tests that a cold gate re-counts markets with books at
+// most once per check interval.
+func TestGate_ThrottlesBookChecks(t *testing.T) {
+	gate, books, now := newTestGate(&Config{MinBookFraction: 0.5, CheckInterval: 5 * time.Second})
+	markets := gate.markets.(*mockMarkets)
+
+	for range 10 {
+		if gate.IsWarm() {
+			t.Fatal("expected gate closed without books")
+		}
+	}
+	if markets.calls != 1 {
+		t.Fatalf("expected 1 book check within the interval, got %d", markets.calls)
+	}
+
+	// Books arrive, but the gate stays closed until the next check
+	for _, token := range []string{"m1-yes", "m1-no", "m2-yes", "m2-no"} {
+		books.tokens[token] = true
+	}
+	*now = now.Add(4 * time.Second)
+	if gate.IsWarm() {
+		t.Fatal("expected gate closed until the next check")
+	}
+
+	*now = now.Add(time.Second)
+	if !gate.IsWarm() {
+		t.Fatal("expected gate open at the next check")
+	}
+	if markets.calls != 2 {
+		t.Errorf("expected 2 book checks, got %d", markets.calls)
+	}
+}
@@ -0,0 +1,364 @@
+package websocket
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"time"
+
+	json "github.com/goccy/go-json"
+	"github.com/mselser95/polymarket-arb/pkg/types"
+	"go.uber.org/zap"
+)
+
+// messageKind is the frame format a message was routed to before parsing.
+type messageKind int
+
+const (
+	kindUnknown messageKind = iota
+	kindBookArray
+	kindBook
+	kindPriceChange
+	kindLastTradePrice
+	kindTickSizeChange
+)
+
+// errUnexpectedEventType is returned by a parser when the frame decoded but carries a
+// different event type, so the next format should be tried.
+var errUnexpectedEventType = errors.New("unexpected event type")
+
+var eventTypeKey = []byte(`"event_type"`)
+
+// peekMessageKind routes a frame from a cheap scan instead of trial unmarshals: arrays
+// are book snapshot batches, and objects are routed by the first "event_type" value.
+// Returns kindUnknown when the frame can't be classified this way; the caller then falls
+// back to trying every format, as does a frame that fails to parse as its peeked kind.
+func peekMessageKind(message []byte) messageKind {
+	trimmed := bytes.TrimLeft(message, " \t\r\n")
+	if len(trimmed) == 0 {
+		return kindUnknown
+	}
+
+	switch trimmed[0] {
+	case '[':
+		return kindBookArray
+	case '{':
+	default:
+		return kindUnknown
+	}
+
+	idx := bytes.Index(trimmed, eventTypeKey)
+	if idx < 0 {
+		return kindUnknown
+	}
+
+	// Expect `: "value"`, allowing whitespace around the colon
+	rest := bytes.TrimLeft(trimmed[idx+len(eventTypeKey):], " \t\r\n")
+	if len(rest) == 0 || rest[0] != ':' {
+		return kindUnknown
+	}
+	rest = bytes.TrimLeft(rest[1:], " \t\r\n")
+	if len(rest) == 0 || rest[0] != '"' {
+		return kindUnknown
+	}
+	end := bytes.IndexByte(rest[1:], '"')
+	if end < 0 {
+		return kindUnknown
+	}
+
+	switch string(rest[1 : end+1]) {
+	case "book":
+		return kindBook
+	case "price_change":
+		return kindPriceChange
+	case "last_trade_price":
+		return kindLastTradePrice
+	case "tick_size_change":
+		return kindTickSizeChange
+	default:
+		return kindUnknown
+	}
+}
+
+// handleMessage parses a raw frame and forwards its orderbook updates. Frames are routed
+// by peekMessageKind; anything it can't place, or that fails to parse as its peeked kind,
+// goes through handleMessageFallback.
+func (m *Manager) handleMessage(message []byte) {
+	var err error
+	switch peekMessageKind(message) {
+	case kindBookArray:
+		err = m.handleBookArray(message)
+	case kindBook:
+		err = m.handleBook(message)
+	case kindPriceChange:
+		err = m.handlePriceChange(message)
+	case kindLastTradePrice:
+		err = m.handleLastTradePrice(message)
+	case kindTickSizeChange:
+		err = m.handleTickSizeChange(message)
+	default:
+		err = errUnexpectedEventType
+	}
+	if err == nil {
+		return
+	}
+
+	MessageParseFallbacksTotal.Inc()
+	m.handleMessageFallback(message)
+}
+
+// handleMessageFallback tries every known format in turn, then classifies heartbeats and
+// control messages, logging anything still unrecognized in full.
+func (m *Manager) handleMessageFallback(message []byte) {
+	// The API sends messages in multiple formats:
+	// - Array of book snapshots: [{...}, {...}] (initial subscription)
+	// - Single book snapshot: {...} (individual updates)
+	// - price_change messages (incremental updates)
+	// - last_trade_price messages (trade notifications)
+	// - tick_size_change messages (tick size updates)
+	bookErr := m.handleBookArray(message)
+	if bookErr == nil {
+		return
+	}
+
+	singleBookErr := m.handleBook(message)
+	if singleBookErr == nil {
+		return
+	}
+
+	priceErr := m.handlePriceChange(message)
+	if priceErr == nil {
+		return
+	}
+
+	tradeErr := m.handleLastTradePrice(message)
+	if tradeErr == nil {
+		return
+	}
+
+	tickSizeErr := m.handleTickSizeChange(message)
+	if tickSizeErr == nil {
+		return
+	}
+
+	// Identify other message types for better logging
+	messageStr := string(message)
+
+	// Check if it's a heartbeat/keepalive (empty array or minimal content)
+	if messageStr == "[]" || messageStr == "" || len(message) < 10 {
+		m.logger.Debug("websocket-heartbeat-received",
+			zap.Int("bytes", len(message)))
+		return
+	}
+
+	// Check if it's a subscription confirmation or other control message
+	var controlMsg map[string]interface{}
+	if json.Unmarshal(message, &controlMsg) == nil {
+		if msgType, ok := controlMsg["type"].(string); ok {
+			m.logger.Debug("websocket-control-message",
+				zap.String("type", msgType),
+				zap.Int("bytes", len(message)))
+			return
+		}
+	}
+
+	// Unknown message format - log FULL message for debugging
+	m.logger.Warn("websocket-unparseable-message",
+		zap.NamedError("book-array-parse-error", bookErr),
+		zap.NamedError("book-single-parse-error", singleBookErr),
+		zap.NamedError("price-change-parse-error", priceErr),
+		zap.NamedError("trade-parse-error", tradeErr),
+		zap.NamedError("tick-size-change-parse-error", tickSizeErr),
+		zap.Int("bytes", len(message)),
+		zap.String("full-message", messageStr))
+}
+
+// handleBookArray forwards an array of book snapshots (initial subscription).
+func (m *Manager) handleBookArray(message []byte) error {
+	var obMsgs []types.OrderbookMessage
+	err := json.Unmarshal(message, &obMsgs)
+	if err != nil {
+		return err
+	}
+	if len(obMsgs) == 0 {
+		return fmt.Errorf("empty book array")
+	}
+
+	for i := range obMsgs {
+		start := time.Now()
+		obMsg := &obMsgs[i]
+
+		MessagesReceivedTotal.WithLabelValues(obMsg.EventType).Inc()
+		m.forward(obMsg)
+
+		// Observe message processing latency
+		MessageLatencySeconds.Observe(time.Since(start).Seconds())
+	}
+
+	return nil
+}
+
+// handleBook forwards a single book snapshot (not in an array).
+func (m *Manager) handleBook(message []byte) error {
+	var obMsg types.OrderbookMessage
+	err := json.Unmarshal(message, &obMsg)
+	if err != nil {
+		return err
+	}
+	if obMsg.EventType != "book" {
+		return errUnexpectedEventType
+	}
+
+	start := time.Now()
+	MessagesReceivedTotal.WithLabelValues(obMsg.EventType).Inc()
+	m.forward(&obMsg)
+
+	// Observe message processing latency
+	MessageLatencySeconds.Observe(time.Since(start).Seconds())
+
+	return nil
+}
+
+// handlePriceChange converts each entry of a price_change message (incremental updates)
+// to an OrderbookMessage and forwards it.
+func (m *Manager) handlePriceChange(message []byte) error {
+	var priceChangeMsg types.PriceChangeMessage
+	err := json.Unmarshal(message, &priceChangeMsg)
+	if err != nil {
+		return err
+	}
+	if priceChangeMsg.EventType != "price_change" {
+		return errUnexpectedEventType
+	}
+
+	for _, pc := range priceChangeMsg.PriceChanges {
+		start := time.Now()
+
+		// Convert to OrderbookMessage for compatibility with existing orderbook manager
+		// NOTE: price_change messages from CLOB API only include best_bid/best_ask prices,
+		// not sizes. We set size to "0" here, which will overwrite existing size in the snapshot.
+		// This is acceptable since we prioritize price updates over size accuracy.
+		// Initial book snapshots provide accurate sizes; the level update itself rides
+		// along in Change so the manager can keep its ask ladder current.
+		obMsg := &types.OrderbookMessage{
+			EventType: "price_change",
+			AssetID:   pc.AssetID,
+			Market:    priceChangeMsg.Market,
+			Timestamp: priceChangeMsg.Timestamp,
+			Bids:      []types.PriceLevel{{Price: pc.BestBid, Size: "0"}},
+			Asks:      []types.PriceLevel{{Price: pc.BestAsk, Size: "0"}},
+			Change:    &pc,
+		}
+
+		MessagesReceivedTotal.WithLabelValues("price_change").Inc()
+
+		m.logger.Debug("price-change-message-converted",
+			zap.String("asset-id", pc.AssetID),
+			zap.String("best-bid", pc.BestBid),
+			zap.String("best-ask", pc.BestAsk))
+
+		m.forward(obMsg)
+
+		// Observe message processing latency
+		MessageLatencySeconds.Observe(time.Since(start).Seconds())
+	}
+
+	return nil
+}
+
+// handleLastTradePrice logs a trade notification. These are informational only - we
+// don't use them for arbitrage detection.
+func (m *Manager) handleLastTradePrice(message []byte) error {
+	var tradeMsg types.LastTradePriceMessage
+	err := json.Unmarshal(message, &tradeMsg)
+	if err != nil {
+		return err
+	}
+	if tradeMsg.EventType != "last_trade_price" {
+		return errUnexpectedEventType
+	}
+
+	MessagesReceivedTotal.WithLabelValues("last_trade_price").Inc()
+
+	m.logger.Debug("last-trade-price-received",
+		zap.String("market", tradeMsg.Market),
+		zap.String("asset-id", tradeMsg.AssetID),
+		zap.String("price", tradeMsg.Price),
+		zap.String("size", tradeMsg.Size),
+		zap.String("side", tradeMsg.Side))
+
+	return nil
+}
+
+// handleTickSizeChange applies a tick size update to the metadata cache, if configured.
+func (m *Manager) handleTickSizeChange(message []byte) error {
+	var tickSizeMsg types.TickSizeChangeMessage
+	err := json.Unmarshal(message, &tickSizeMsg)
+	if err != nil {
+		return err
+	}
+	if tickSizeMsg.EventType != "tick_size_change" {
+		return errUnexpectedEventType
+	}
+
+	MessagesReceivedTotal.WithLabelValues("tick_size_change").Inc()
+
+	if m.metadataUpdater == nil {
+		m.logger.Info("tick-size-change-received",
+			zap.String("market", tickSizeMsg.Market),
+			zap.String("asset-id", tickSizeMsg.AssetID),
+			zap.String("old-tick-size", tickSizeMsg.OldTickSize),
+			zap.String("new-tick-size", tickSizeMsg.NewTickSize),
+			zap.String("action", "no metadata updater configured"))
+		return nil
+	}
+
+	var newTickSize float64
+	_, scanErr := fmt.Sscanf(tickSizeMsg.NewTickSize, "%f", &newTickSize)
+	if scanErr != nil {
+		m.logger.Warn("tick-size-change-parse-error",
+			zap.String("asset-id", tickSizeMsg.AssetID),
+			zap.String("new-tick-size", tickSizeMsg.NewTickSize),
+			zap.Error(scanErr))
+		return nil
+	}
+
+	m.metadataUpdater.UpdateTickSize(tickSizeMsg.AssetID, newTickSize)
+	m.logger.Info("tick-size-change-received-and-updated",
+		zap.String("market", tickSizeMsg.Market),
+		zap.String("asset-id", tickSizeMsg.AssetID),
+		zap.String("old-tick-size", tickSizeMsg.OldTickSize),
+		zap.String("new-tick-size", tickSizeMsg.NewTickSize),
+		zap.String("action", "metadata cache updated"))
+
+	return nil
+}
+
+// forward sends an orderbook message to the message channel without blocking, dropping
+// and counting it when the channel is full.
+func (m *Manager) forward(obMsg *types.OrderbookMessage) {
+	select {
+	case m.messageChan <- obMsg:
+		// Log before the channel fills up and messages start dropping
+		utilization := m.channelUtilization()
+		switch {
+		case utilization > m.config.ChannelCriticalThreshold:
+			m.logger.Error("websocket-message-channel-critical",
+				zap.Int("buffered", len(m.messageChan)),
+				zap.Int("capacity", cap(m.messageChan)),
+				zap.Float64("utilization", utilization*100),
+				zap.String("action", "increase WS_MESSAGE_BUFFER_SIZE"))
+		case utilization > m.config.ChannelWarnThreshold:
+			m.logger.Warn("websocket-message-channel-near-full",
+				zap.Int("buffered", len(m.messageChan)),
+				zap.Int("capacity", cap(m.messageChan)),
+				zap.Float64("utilization", utilization*100))
+		}
+	default:
+		m.logger.Error("CRITICAL-message-channel-full-DROPPING-DATA",
+			zap.String("event-type", obMsg.EventType),
+			zap.Int("buffer-size", cap(m.messageChan)),
+			zap.String("action", "increase WS_MESSAGE_BUFFER_SIZE"))
+		MessagesDroppedTotal.WithLabelValues("channel_full").Inc()
+	}
+}
@@ -0,0 +1,216 @@
+package websocket
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	promtestutil "github.com/prometheus/client_golang/prometheus/testutil"
+
+	"github.com/mselser95/polymarket-arb/pkg/types"
+	"go.uber.org/zap"
+)
+
+// Realistic market-channel frames, as sent by the CLOB websocket.
+var (
+	bookFrame = realisticBookFrame("book-asset", 20)
+
+	bookArrayFrame = "[" + realisticBookFrame("asset-1", 20) + "," + realisticBookFrame("asset-2", 20) + "]"
+
+	priceChangeFrame = `{"market":"0xmarket","price_changes":[` +
+		`{"asset_id":"asset-1","price":"0.52","size":"120","side":"BUY","hash":"0xa","best_bid":"0.52","best_ask":"0.54"},` +
+		`{"asset_id":"asset-2","price":"0.46","size":"80","side":"SELL","hash":"0xb","best_bid":"0.45","best_ask":"0.46"}],` +
+		`"timestamp":"1757908892351","event_type":"price_change"}`
+
+	lastTradePriceFrame = `{"asset_id":"asset-1","event_type":"last_trade_price","fee_rate_bps":"0",` +
+		`"market":"0xmarket","price":"0.53","side":"BUY","size":"25","timestamp":"1757908892351"}`
+
+	tickSizeChangeFrame = `{"event_type":"tick_size_change","asset_id":"asset-1","market":"0xmarket",` +
+		`"old_tick_size":"0.01","new_tick_size":"0.001","timestamp":"1757908892351"}`
+)
+
+// realisticBookFrame builds a book snapshot with levels price levels per side.
+func realisticBookFrame(assetID string, levels int) string {
+	bids := make([]string, levels)
+	asks := make([]string, levels)
+	for i := 0; i < levels; i++ {
+		bids[i] = fmt.Sprintf(`{"price":"0.%02d","size":"%d.5"}`, 50-i, 100+i*10)
+		asks[i] = fmt.Sprintf(`{"price":"0.%02d","size":"%d.25"}`, 52+i, 90+i*10)
+	}
+
+	return fmt.Sprintf(`{"event_type":"book","asset_id":%q,"market":"0xmarket",`+
+		`"bids":[%s],"asks":[%s],"timestamp":"1757908892351","hash":"0xhash"}`,
+		assetID, strings.Join(bids, ","), strings.Join(asks, ","))
+}
+
+// recordingUpdater records tick size updates.
+type recordingUpdater struct {
+	updates map[string]float64
+}
+
+func (u *recordingUpdater) UpdateTickSize(tokenID string, newTickSize float64) {
+	u.updates[tokenID] = newTickSize
+}
+
+func newDispatchTestManager(bufferSize int) *Manager {
+	return &Manager{
+		logger:      zap.NewNop(),
+		messageChan: make(chan *types.OrderbookMessage, bufferSize),
+	}
+}
+
+// drain empties the manager's message channel and returns what was forwarded.
+func drain(m *Manager) []*types.OrderbookMessage {
+	var forwarded []*types.OrderbookMessage
+	for {
+		select {
+		case msg := <-m.messageChan:
+			forwarded = append(forwarded, msg)
+		default:
+			return forwarded
+		}
+	}
+}
+
+func TestPeekMessageKind(t *testing.T) {
+	tests := []struct {
+		name    string
+		message string
+		want    messageKind
+	}{
+		{name: "book_array", message: bookArrayFrame, want: kindBookArray},
+		{name: "book", message: bookFrame, want: kindBook},
+		{name: "price_change_key_last", message: priceChangeFrame, want: kindPriceChange},
+		{name: "last_trade_price", message: lastTradePriceFrame, want: kindLastTradePrice},
+		{name: "tick_size_change", message: tickSizeChangeFrame, want: kindTickSizeChange},
+		{name: "whitespace_around_colon", message: " \n{\"event_type\" : \"book\"}", want: kindBook},
+		{name: "unknown_event_type", message: `{"event_type":"new_market"}`, want: kindUnknown},
+		{name: "no_event_type", message: `{"type":"subscribed","channel":"market"}`, want: kindUnknown},
+		{name: "event_type_not_string", message: `{"event_type":1}`, want: kindUnknown},
+		{name: "unterminated", message: `{"event_type":"bo`, want: kindUnknown},
+		{name: "empty", message: "", want: kindUnknown},
+		{name: "text_frame", message: "PONG", want: kindUnknown},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := peekMessageKind([]byte(tt.message)); got != tt.want {
+				t.Errorf("expected kind %d, got %d", tt.want, got)
+			}
+		})
+	}
+}
+
+// TestHandleMessage_Routes tests that every message type is still parsed and forwarded
+// correctly, and that only frames the peek can't place take the fallback path.
+func TestHandleMessage_Routes(t *testing.T) {
+	tests := []struct {
+		name         string
+		message      string
+		wantAssets   []string
+		wantEvent    string
+		wantFallback bool
+	}{
+		{name: "book_array", message: bookArrayFrame, wantAssets: []string{"asset-1", "asset-2"}, wantEvent: "book"},
+		{name: "book", message: bookFrame, wantAssets: []string{"book-asset"}, wantEvent: "book"},
+		{name: "price_change", message: priceChangeFrame, wantAssets: []string{"asset-1", "asset-2"}, wantEvent: "price_change"},
+		{name: "last_trade_price", message: lastTradePriceFrame},
+		{name: "tick_size_change", message: tickSizeChangeFrame},
+		{
+			// A nested event_type misleads the peek; the fallback still finds the price change
+			name: "ambiguous_nested_event_type",
+			message: `{"meta":{"event_type":"book"},"event_type":"price_change","market":"0xmarket",` +
+				`"price_changes":[{"asset_id":"asset-1","best_bid":"0.52","best_ask":"0.54"}],"timestamp":"1"}`,
+			wantAssets:   []string{"asset-1"},
+			wantEvent:    "price_change",
+			wantFallback: true,
+		},
+		{name: "heartbeat", message: "[]", wantFallback: true},
+		{name: "control_message", message: `{"type":"subscribed","channel":"market"}`, wantFallback: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newDispatchTestManager(10)
+			updater := &recordingUpdater{updates: make(map[string]float64)}
+			m.metadataUpdater = updater
+			fallbacksBefore := promtestutil.ToFloat64(MessageParseFallbacksTotal)
+
+			m.handleMessage([]byte(tt.message))
+
+			forwarded := drain(m)
+			if len(forwarded) != len(tt.wantAssets) {
+				t.Fatalf("expected %d forwarded messages, got %d", len(tt.wantAssets), len(forwarded))
+			}
+			for i, msg := range forwarded {
+				if msg.AssetID != tt.wantAssets[i] || msg.EventType != tt.wantEvent {
+					t.Errorf("expected %s %s, got %s %s", tt.wantEvent, tt.wantAssets[i], msg.EventType, msg.AssetID)
+				}
+			}
+
+			fallbacks := promtestutil.ToFloat64(MessageParseFallbacksTotal) - fallbacksBefore
+			if (fallbacks > 0) != tt.wantFallback {
+				t.Errorf("expected fallback=%v, got %.0f fallbacks", tt.wantFallback, fallbacks)
+			}
+
+			if tt.name == "tick_size_change" && updater.updates["asset-1"] != 0.001 {
+				t.Errorf("expected tick size 0.001 for asset-1, got %v", updater.updates)
+			}
+		})
+	}
+
+	// The parsed book keeps its levels and timestamp
+	m := newDispatchTestManager(10)
+	m.handleMessage([]byte(bookFrame))
+	book := drain(m)[0]
+	if len(book.Bids) != 20 || book.Bids[0].Price != "0.50" || book.Asks[0].Price != "0.52" || book.Timestamp != 1757908892351 {
+		t.Errorf("unexpected parsed book: %+v", book)
+	}
+
+	// Each price change carries its own level update
+	m.handleMessage([]byte(priceChangeFrame))
+	changes := drain(m)
+	if len(changes) != 2 || changes[0].Change == nil || changes[1].Change == nil ||
+		changes[0].Change.Side != "BUY" || changes[1].Change.Price != "0.46" || changes[1].Change.Size != "80" {
+		t.Errorf("expected level changes on price change messages, got %+v", changes)
+	}
+}
+
+// BenchmarkHandleMessage compares routing by peek against trying every format in turn
+// (the previous readLoop behavior) on realistic frames. Later formats pay for every
+// earlier trial unmarshal on the fallback path.
+func BenchmarkHandleMessage(b *testing.B) {
+	frames := []struct {
+		name    string
+		message string
+	}{
+		{name: "book_array", message: bookArrayFrame},
+		{name: "book", message: bookFrame},
+		{name: "price_change", message: priceChangeFrame},
+		{name: "last_trade_price", message: lastTradePriceFrame},
+	}
+
+	for _, frame := range frames {
+		message := []byte(frame.message)
+
+		b.Run(frame.name+"/peek", func(b *testing.B) {
+			m := newDispatchTestManager(10)
+			b.ReportAllocs()
+			b.SetBytes(int64(len(message)))
+			for i := 0; i < b.N; i++ {
+				m.handleMessage(message)
+				drain(m)
+			}
+		})
+
+		b.Run(frame.name+"/try_all", func(b *testing.B) {
+			m := newDispatchTestManager(10)
+			b.ReportAllocs()
+			b.SetBytes(int64(len(message)))
+			for i := 0; i < b.N; i++ {
+				m.handleMessageFallback(message)
+				drain(m)
+			}
+		})
+	}
+}
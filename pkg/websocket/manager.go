@@ -2,12 +2,16 @@ package websocket
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
+	"net"
+	"os"
+	"slices"
 	"sync"
 	"sync/atomic"
 	"time"
 
-	json "github.com/goccy/go-json"
 	"github.com/gorilla/websocket"
 	"github.com/mselser95/polymarket-arb/pkg/types"
 	"go.uber.org/zap"
@@ -27,14 +31,26 @@ type Manager struct {
 	config          Config
 	messageChan     chan *types.OrderbookMessage
 	metadataUpdater MetadataUpdater // optional: for updating metadata cache
+	recorder        *Recorder       // optional: raw message tap
+	ownsRecorder    bool            // true if this manager created the recorder and must close it
 	ctx             context.Context
 	cancel          context.CancelFunc
 	wg              sync.WaitGroup
 	mu              sync.RWMutex
 	subscribed      map[string]bool // tracks subscribed token IDs
+	sent            map[string]bool // token IDs subscribed on the current connection
+	resyncing       bool            // resubscribeAll owns sending until pending tokens are drained
 	connected       atomic.Bool
 	lastPongTime    atomic.Int64
-	connectionStart atomic.Int64 // Unix timestamp of connection start
+	connectionStart atomic.Int64          // Unix timestamp of connection start
+	fatalErr        atomic.Pointer[error] // Set when reconnection gave up; the manager stays down
+	lastDisconnect  atomic.Pointer[disconnect]
+}
+
+// disconnect records why and when a connection was lost.
+type disconnect struct {
+	reason string
+	at     time.Time
 }
 
 // Config holds WebSocket manager configuration.
@@ -46,20 +62,103 @@ type Config struct {
 	ReconnectInitialDelay time.Duration
 	ReconnectMaxDelay     time.Duration
 	ReconnectBackoffMult  float64
+	ReconnectMaxAttempts  int           // Give up after this many failed reconnects (0 = retry forever)
+	ReconnectMaxDowntime  time.Duration // Give up after this long disconnected (0 = retry forever)
 	MessageBufferSize     int
+	ResubscribeBatchSize  int           // Max tokens per resubscribe frame after reconnect (default: 100)
+	ResubscribeBatchDelay time.Duration // Delay between resubscribe frames (default: 50ms)
+	MaxMessageSize        int64         // Read limit per message in bytes; larger frames drop the connection (default: 10MB)
 	Logger                *zap.Logger
 	MetadataUpdater       MetadataUpdater // optional: for updating metadata cache on tick_size_change
+	OnReconnectExhausted  func(err error) // optional: called when reconnection gives up
+
+	// Connection state callbacks (optional). Called without the manager's lock held, from
+	// the goroutine that observed the change; they must not block.
+	OnConnect    func() // After each successful (re)connect, before resubscription
+	OnDisconnect func() // When a connection is lost: read error or failed resubscription
+
+	// Raw message recording (disabled when RecordPath is empty and Recorder is nil)
+	RecordPath        string    // Base path for recording files
+	RecordCompress    bool      // Gzip recording files
+	RecordMaxFileSize int64     // Rotate after this many uncompressed bytes (default: 100MB)
+	Recorder          *Recorder // Shared recorder (e.g. from Pool); takes precedence over RecordPath
+
+	// Message channel utilization, as a fraction of MessageBufferSize (0 = defaults)
+	ChannelWarnThreshold     float64       // Utilization above which forwarding logs a warning (default: 0.9)
+	ChannelCriticalThreshold float64       // Utilization above which forwarding logs an error (default: 0.99)
+	UtilizationInterval      time.Duration // How often MessageChannelUtilization is sampled (default: 5s)
+	MetricsID                string        // "manager" label on per-manager metrics (default: "0")
 }
 
+const (
+	defaultResubscribeBatchSize  = 100
+	defaultResubscribeBatchDelay = 50 * time.Millisecond
+	defaultMaxMessageSize        = 10 * 1024 * 1024
+
+	defaultChannelWarnThreshold     = 0.9
+	defaultChannelCriticalThreshold = 0.99
+	defaultUtilizationInterval      = 5 * time.Second
+)
+
+// Read error classes for ReadErrorsTotal, also recorded as the disconnect reason.
+const (
+	readErrorReadLimit = "read_limit" // Frame larger than MaxMessageSize
+	readErrorClosed    = "closed"     // Close frame from the server
+	readErrorEOF       = "eof"        // Connection closed without a close frame
+	readErrorTimeout   = "timeout"    // Read deadline exceeded
+	readErrorNetwork   = "network"    // Anything else, e.g. connection reset
+)
+
+// disconnectResubscribeFailed is the disconnect reason when resubscribing after a
+// reconnect fails and the new connection is abandoned.
+const disconnectResubscribeFailed = "resubscribe_failed"
+
 // New creates a new WebSocket manager.
 func New(cfg Config) *Manager {
 	ctx, cancel := context.WithCancel(context.Background())
 
+	if cfg.ResubscribeBatchSize <= 0 {
+		cfg.ResubscribeBatchSize = defaultResubscribeBatchSize
+	}
+	if cfg.ResubscribeBatchDelay <= 0 {
+		cfg.ResubscribeBatchDelay = defaultResubscribeBatchDelay
+	}
+	if cfg.MaxMessageSize <= 0 {
+		cfg.MaxMessageSize = defaultMaxMessageSize
+	}
+	if cfg.ChannelWarnThreshold <= 0 || cfg.ChannelWarnThreshold > 1 {
+		cfg.ChannelWarnThreshold = defaultChannelWarnThreshold
+	}
+	if cfg.ChannelCriticalThreshold <= 0 || cfg.ChannelCriticalThreshold > 1 {
+		cfg.ChannelCriticalThreshold = defaultChannelCriticalThreshold
+	}
+	if cfg.UtilizationInterval <= 0 {
+		cfg.UtilizationInterval = defaultUtilizationInterval
+	}
+	if cfg.MetricsID == "" {
+		cfg.MetricsID = "0"
+	}
+
 	reconnectCfg := ReconnectConfig{
 		InitialDelay:      cfg.ReconnectInitialDelay,
 		MaxDelay:          cfg.ReconnectMaxDelay,
 		BackoffMultiplier: cfg.ReconnectBackoffMult,
 		JitterPercent:     0.2,
+		MaxAttempts:       cfg.ReconnectMaxAttempts,
+		MaxDowntime:       cfg.ReconnectMaxDowntime,
+		OnExhausted:       cfg.OnReconnectExhausted,
+	}
+
+	recorder := cfg.Recorder
+	ownsRecorder := false
+	if recorder == nil && cfg.RecordPath != "" {
+		recorder = NewRecorder(RecorderConfig{
+			Path:        cfg.RecordPath,
+			Compress:    cfg.RecordCompress,
+			MaxFileSize: cfg.RecordMaxFileSize,
+			Logger:      cfg.Logger,
+		})
+		ownsRecorder = true
 	}
 
 	return &Manager{
@@ -69,9 +168,12 @@ func New(cfg Config) *Manager {
 		config:          cfg,
 		messageChan:     make(chan *types.OrderbookMessage, cfg.MessageBufferSize),
 		metadataUpdater: cfg.MetadataUpdater,
+		recorder:        recorder,
+		ownsRecorder:    ownsRecorder,
 		ctx:             ctx,
 		cancel:          cancel,
 		subscribed:      make(map[string]bool),
+		sent:            make(map[string]bool),
 	}
 }
 
@@ -85,15 +187,70 @@ func (m *Manager) Start() error {
 		return fmt.Errorf("initial connection: %w", err)
 	}
 
+	// Send tokens tracked before the connection existed
+	err = m.resubscribeAll(m.ctx)
+	if err != nil {
+		return fmt.Errorf("initial subscription: %w", err)
+	}
+
 	// Start goroutines
-	m.wg.Add(3)
+	m.wg.Add(4)
 	go m.readLoop()
 	go m.pingLoop()
 	go m.reconnectLoop()
+	go m.utilizationLoop()
 
 	return nil
 }
 
+// classifyReadError maps a read error to its ReadErrorsTotal class.
+func classifyReadError(err error) string {
+	if errors.Is(err, websocket.ErrReadLimit) {
+		return readErrorReadLimit
+	}
+
+	// gorilla reports a connection that ended without a close frame as abnormal closure
+	var closeErr *websocket.CloseError
+	if errors.As(err, &closeErr) {
+		if closeErr.Code == websocket.CloseAbnormalClosure {
+			return readErrorEOF
+		}
+		return readErrorClosed
+	}
+
+	if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return readErrorEOF
+	}
+
+	var netErr net.Error
+	if errors.Is(err, os.ErrDeadlineExceeded) || (errors.As(err, &netErr) && netErr.Timeout()) {
+		return readErrorTimeout
+	}
+
+	return readErrorNetwork
+}
+
+// logReadError records a read error that ended the connection and returns its class.
+// An oversized frame is logged as an error with the limit: it will recur on every
+// reconnect until the limit is raised, unlike transient network errors.
+func (m *Manager) logReadError(err error) string {
+	class := classifyReadError(err)
+	ReadErrorsTotal.WithLabelValues(class).Inc()
+
+	if class == readErrorReadLimit {
+		m.logger.Error("websocket-message-exceeds-read-limit",
+			zap.Int64("max-message-size", m.config.MaxMessageSize),
+			zap.String("action", "increase WS_MAX_MESSAGE_SIZE_MB"),
+			zap.Error(err))
+		return class
+	}
+
+	m.logger.Warn("read-error",
+		zap.String("error-class", class),
+		zap.Error(err))
+	return class
+}
+
 // connect establishes a WebSocket connection.
 func (m *Manager) connect(ctx context.Context) error {
 	dialer := websocket.Dialer{
@@ -117,12 +274,16 @@ func (m *Manager) connect(ctx context.Context) error {
 		return nil
 	})
 
-	// Set read limit to 10MB to handle large messages with many price changes
-	// Default is 512KB which can cause truncation with high-volume markets
-	conn.SetReadLimit(10 * 1024 * 1024)
+	// Large book snapshots and price_change batches can run to megabytes. A frame over the
+	// limit fails the read and drops the connection, so readLoop reports it distinctly.
+	conn.SetReadLimit(m.config.MaxMessageSize)
 
+	// A new connection starts with no subscriptions. Subscribe defers to resubscribeAll
+	// until every tracked token has been sent on it, so none is sent twice.
 	m.mu.Lock()
 	m.conn = conn
+	m.sent = make(map[string]bool)
+	m.resyncing = true
 	m.mu.Unlock()
 
 	now := time.Now()
@@ -133,6 +294,10 @@ func (m *Manager) connect(ctx context.Context) error {
 
 	m.logger.Info("websocket-connected")
 
+	if m.config.OnConnect != nil {
+		m.config.OnConnect()
+	}
+
 	return nil
 }
 
@@ -160,9 +325,20 @@ func (m *Manager) Subscribe(ctx context.Context, tokenIDs []string) error {
 		return nil
 	}
 
+	totalSubscribed := len(m.subscribed)
+
+	// An in-progress resubscribe picks up the new tokens before it finishes
+	if m.resyncing && m.conn != nil && m.connected.Load() {
+		m.mu.Unlock()
+		SubscriptionCount.Set(float64(totalSubscribed))
+		m.logger.Debug("subscribe-deferred-to-resubscribe",
+			zap.Int("token-count", len(newTokens)))
+		return nil
+	}
+
 	// Determine message type based on connection state
 	var subscribeMsg map[string]interface{}
-	isInitialSubscription := len(m.subscribed) == len(newTokens)
+	isInitialSubscription := len(m.sent) == 0
 
 	if isInitialSubscription {
 		// Initial subscription
@@ -178,11 +354,17 @@ func (m *Manager) Subscribe(ctx context.Context, tokenIDs []string) error {
 		}
 	}
 
-	totalSubscribed := len(m.subscribed)
+	conn := m.conn
+	connected := m.connected.Load()
+	if conn != nil && connected {
+		for _, tokenID := range newTokens {
+			m.sent[tokenID] = true
+		}
+	}
 	m.mu.Unlock()
 
 	// Check if connection exists before attempting network I/O
-	if m.conn == nil {
+	if conn == nil || !connected {
 		// Keep subscription tracked but don't attempt network I/O
 		// This allows subscription state to be maintained across reconnects
 		SubscriptionCount.Set(float64(totalSubscribed))
@@ -192,12 +374,13 @@ func (m *Manager) Subscribe(ctx context.Context, tokenIDs []string) error {
 	}
 
 	// Network I/O WITHOUT holding the lock
-	err := m.conn.WriteJSON(subscribeMsg)
+	err := conn.WriteJSON(subscribeMsg)
 	if err != nil {
 		// Rollback subscription state on failure
 		m.mu.Lock()
 		for _, tokenID := range newTokens {
 			delete(m.subscribed, tokenID)
+			delete(m.sent, tokenID)
 		}
 		totalSubscribed = len(m.subscribed)
 		m.mu.Unlock()
@@ -225,10 +408,13 @@ func (m *Manager) Unsubscribe(ctx context.Context, tokenIDs []string) (err error
 
 	// Filter to only tokens that are currently subscribed
 	tokensToUnsubscribe := make([]string, 0, len(tokenIDs))
+	wasSent := make(map[string]bool, len(tokenIDs))
 	for _, tokenID := range tokenIDs {
 		if m.subscribed[tokenID] {
 			tokensToUnsubscribe = append(tokensToUnsubscribe, tokenID)
+			wasSent[tokenID] = m.sent[tokenID]
 			delete(m.subscribed, tokenID)
+			delete(m.sent, tokenID)
 		}
 	}
 
@@ -263,6 +449,9 @@ func (m *Manager) Unsubscribe(ctx context.Context, tokenIDs []string) (err error
 		m.mu.Lock()
 		for _, tokenID := range tokensToUnsubscribe {
 			m.subscribed[tokenID] = true
+			if wasSent[tokenID] {
+				m.sent[tokenID] = true
+			}
 		}
 		totalSubscribed = len(m.subscribed)
 		m.mu.Unlock()
@@ -281,6 +470,97 @@ func (m *Manager) Unsubscribe(ctx context.Context, tokenIDs []string) (err error
 	return nil
 }
 
+// Resubscribe unsubscribes and re-subscribes tokenIDs on the current connection, e.g.
+// after a market's tick size or token set changed, so the server sends fresh snapshots.
+// Untracked tokens are simply subscribed. The tokens stay tracked throughout and both
+// frames are written under the lock, so a concurrent reconnect can't interleave with
+// the swap or lose a token between the unsubscribe and the subscribe.
+func (m *Manager) Resubscribe(ctx context.Context, tokenIDs []string) error {
+	if len(tokenIDs) == 0 {
+		return nil
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	tokens := make([]string, 0, len(tokenIDs))
+	for _, tokenID := range tokenIDs {
+		if slices.Contains(tokens, tokenID) {
+			continue
+		}
+		tokens = append(tokens, tokenID)
+		m.subscribed[tokenID] = true
+	}
+	SubscriptionCount.Set(float64(len(m.subscribed)))
+
+	if m.conn == nil || !m.connected.Load() {
+		m.logger.Debug("resubscribe-no-connection-tracked-for-later",
+			zap.Int("token-count", len(tokens)))
+		return fmt.Errorf("no active connection (tokens tracked for later)")
+	}
+
+	// Only tokens already sent on this connection need unsubscribing. Unsent ones are
+	// subscribed fresh, or left to an in-progress resubscribeAll, which picks them up.
+	var swapped, subscribe []string
+	for _, tokenID := range tokens {
+		if m.sent[tokenID] {
+			swapped = append(swapped, tokenID)
+			subscribe = append(subscribe, tokenID)
+		} else if !m.resyncing {
+			subscribe = append(subscribe, tokenID)
+		}
+	}
+	if len(subscribe) == 0 {
+		m.logger.Debug("resubscribe-deferred-to-resubscribe",
+			zap.Int("token-count", len(tokens)))
+		return nil
+	}
+
+	// Decided before the swap: the connection already carries its initial subscription
+	isInitialSubscription := len(m.sent) == 0
+
+	if len(swapped) > 0 {
+		err := m.conn.WriteJSON(map[string]interface{}{
+			"assets_ids": swapped,
+			"operation":  "unsubscribe",
+		})
+		if err != nil {
+			return fmt.Errorf("write unsubscribe message: %w", err)
+		}
+		for _, tokenID := range swapped {
+			delete(m.sent, tokenID)
+		}
+	}
+
+	var subscribeMsg map[string]interface{}
+	if isInitialSubscription {
+		subscribeMsg = map[string]interface{}{
+			"assets_ids": subscribe,
+			"type":       "market",
+		}
+	} else {
+		subscribeMsg = map[string]interface{}{
+			"assets_ids": subscribe,
+			"operation":  "subscribe",
+		}
+	}
+
+	// On failure the tokens stay tracked but unsent, so the reconnect resubscribes them
+	err := m.conn.WriteJSON(subscribeMsg)
+	if err != nil {
+		return fmt.Errorf("write subscribe message: %w", err)
+	}
+	for _, tokenID := range subscribe {
+		m.sent[tokenID] = true
+	}
+
+	m.logger.Info("resubscribed-to-tokens",
+		zap.Int("swapped-count", len(swapped)),
+		zap.Int("subscribed-count", len(subscribe)))
+
+	return nil
+}
+
 // readLoop reads messages from the WebSocket.
 func (m *Manager) readLoop() {
 	defer m.wg.Done()
@@ -303,7 +583,7 @@ func (m *Manager) readLoop() {
 
 		_, message, err := conn.ReadMessage()
 		if err != nil {
-			m.logger.Warn("read-error", zap.Error(err))
+			reason := m.logReadError(err)
 
 			// Observe connection duration before marking as disconnected
 			startTime := m.connectionStart.Load()
@@ -312,230 +592,55 @@ func (m *Manager) readLoop() {
 				ConnectionDuration.Observe(duration)
 			}
 
-			m.connected.Store(false)
+			m.markDisconnected(reason)
 			ActiveConnections.Set(0)
 			return
 		}
 
-		// Parse message - Try different formats based on Polymarket CLOB API
-		// The API sends messages in multiple formats:
-		// - Array of book snapshots: [{...}, {...}] (initial subscription)
-		// - Single book snapshot: {...} (individual updates)
-		// - price_change messages (incremental updates)
-		// - last_trade_price messages (trade notifications)
-
-		// Try #1: Array of book messages (initial snapshots)
-		var obMsgs []types.OrderbookMessage
-		bookErr := json.Unmarshal(message, &obMsgs)
-		if bookErr == nil && len(obMsgs) > 0 {
-			// Successfully parsed as book message array
-			for i := range obMsgs {
-				start := time.Now()
-				obMsg := &obMsgs[i]
-
-				MessagesReceivedTotal.WithLabelValues(obMsg.EventType).Inc()
-
-				// Send to channel (non-blocking)
-				select {
-				case m.messageChan <- obMsg:
-					// Warn if channel is near capacity (90%)
-					buffered := len(m.messageChan)
-					capacity := cap(m.messageChan)
-					if buffered > capacity*9/10 {
-						m.logger.Warn("websocket-message-channel-near-full",
-							zap.Int("buffered", buffered),
-							zap.Int("capacity", capacity),
-							zap.Float64("utilization", float64(buffered)/float64(capacity)*100))
-					}
-				default:
-					m.logger.Error("CRITICAL-message-channel-full-DROPPING-DATA",
-						zap.String("event-type", obMsg.EventType),
-						zap.Int("buffer-size", cap(m.messageChan)),
-						zap.String("action", "increase WS_MESSAGE_BUFFER_SIZE"))
-					MessagesDroppedTotal.WithLabelValues("channel_full").Inc()
-				}
-
-				// Observe message processing latency
-				MessageLatencySeconds.Observe(time.Since(start).Seconds())
-			}
-			continue
+		// Tap raw frames before parsing; Record never blocks
+		if m.recorder != nil {
+			m.recorder.Record(message, time.Now())
 		}
 
-		// Try #1b: Single book message (not in array)
-		var singleObMsg types.OrderbookMessage
-		singleBookErr := json.Unmarshal(message, &singleObMsg)
-		if singleBookErr == nil && singleObMsg.EventType == "book" {
-			// Successfully parsed as single book message
-			start := time.Now()
-
-			MessagesReceivedTotal.WithLabelValues(singleObMsg.EventType).Inc()
-
-			// Send to channel (non-blocking)
-			select {
-			case m.messageChan <- &singleObMsg:
-				// Warn if channel is near capacity (90%)
-				buffered := len(m.messageChan)
-				capacity := cap(m.messageChan)
-				if buffered > capacity*9/10 {
-					m.logger.Warn("websocket-message-channel-near-full",
-						zap.Int("buffered", buffered),
-						zap.Int("capacity", capacity),
-						zap.Float64("utilization", float64(buffered)/float64(capacity)*100))
-				}
-			default:
-				m.logger.Error("CRITICAL-message-channel-full-DROPPING-DATA",
-					zap.String("event-type", singleObMsg.EventType),
-					zap.Int("buffer-size", cap(m.messageChan)),
-					zap.String("action", "increase WS_MESSAGE_BUFFER_SIZE"))
-				MessagesDroppedTotal.WithLabelValues("channel_full").Inc()
-			}
-
-			// Observe message processing latency
-			MessageLatencySeconds.Observe(time.Since(start).Seconds())
-			continue
-		}
-
-		// Try #2: PriceChangeMessage (incremental updates)
-		var priceChangeMsg types.PriceChangeMessage
-		priceErr := json.Unmarshal(message, &priceChangeMsg)
-		if priceErr == nil && priceChangeMsg.EventType == "price_change" {
-			// Successfully parsed as price_change message
-			// Convert each PriceChange to OrderbookMessage format
-			for _, pc := range priceChangeMsg.PriceChanges {
-				start := time.Now()
-
-				// Convert to OrderbookMessage for compatibility with existing orderbook manager
-				// NOTE: price_change messages from CLOB API only include best_bid/best_ask prices,
-				// not sizes. We set size to "0" here, which will overwrite existing size in the snapshot.
-				// This is acceptable since we prioritize price updates over size accuracy.
-				// Initial book snapshots provide accurate sizes.
-				obMsg := &types.OrderbookMessage{
-					EventType: "price_change",
-					AssetID:   pc.AssetID,
-					Market:    priceChangeMsg.Market,
-					Timestamp: priceChangeMsg.Timestamp,
-					Bids:      []types.PriceLevel{{Price: pc.BestBid, Size: "0"}},
-					Asks:      []types.PriceLevel{{Price: pc.BestAsk, Size: "0"}},
-				}
-
-				MessagesReceivedTotal.WithLabelValues("price_change").Inc()
-
-				m.logger.Debug("price-change-message-converted",
-					zap.String("asset-id", pc.AssetID),
-					zap.String("best-bid", pc.BestBid),
-					zap.String("best-ask", pc.BestAsk))
-
-				// Send to channel (non-blocking)
-				select {
-				case m.messageChan <- obMsg:
-					// Warn if channel is near capacity (90%)
-					buffered := len(m.messageChan)
-					capacity := cap(m.messageChan)
-					if buffered > capacity*9/10 {
-						m.logger.Warn("websocket-message-channel-near-full",
-							zap.Int("buffered", buffered),
-							zap.Int("capacity", capacity),
-							zap.Float64("utilization", float64(buffered)/float64(capacity)*100))
-					}
-				default:
-					m.logger.Error("CRITICAL-message-channel-full-DROPPING-DATA",
-						zap.String("event-type", "price_change"),
-						zap.Int("buffer-size", cap(m.messageChan)),
-						zap.String("action", "increase WS_MESSAGE_BUFFER_SIZE"))
-					MessagesDroppedTotal.WithLabelValues("channel_full").Inc()
-				}
-
-				// Observe message processing latency
-				MessageLatencySeconds.Observe(time.Since(start).Seconds())
-			}
-			continue
-		}
+		m.handleMessage(message)
+	}
+}
 
-		// Try #3: LastTradePriceMessage (trade execution notifications)
-		var tradeMsg types.LastTradePriceMessage
-		tradeErr := json.Unmarshal(message, &tradeMsg)
-		if tradeErr == nil && tradeMsg.EventType == "last_trade_price" {
-			// Successfully parsed as last_trade_price message
-			// These are informational only - we don't use them for arbitrage detection
-			MessagesReceivedTotal.WithLabelValues("last_trade_price").Inc()
-
-			m.logger.Debug("last-trade-price-received",
-				zap.String("market", tradeMsg.Market),
-				zap.String("asset-id", tradeMsg.AssetID),
-				zap.String("price", tradeMsg.Price),
-				zap.String("size", tradeMsg.Size),
-				zap.String("side", tradeMsg.Side))
-			continue
-		}
+// utilizationLoop samples message channel utilization into MessageChannelUtilization
+// every UtilizationInterval, so the gauge stays current while no messages arrive.
+func (m *Manager) utilizationLoop() {
+	defer m.wg.Done()
 
-		// Try #4: TickSizeChangeMessage (tick size updates)
-		var tickSizeMsg types.TickSizeChangeMessage
-		tickSizeErr := json.Unmarshal(message, &tickSizeMsg)
-		if tickSizeErr == nil && tickSizeMsg.EventType == "tick_size_change" {
-			// Successfully parsed as tick_size_change message
-			MessagesReceivedTotal.WithLabelValues("tick_size_change").Inc()
-
-			// Update metadata cache if updater is available
-			if m.metadataUpdater != nil {
-				// Parse new tick size
-				var newTickSize float64
-				_, scanErr := fmt.Sscanf(tickSizeMsg.NewTickSize, "%f", &newTickSize)
-				if scanErr == nil {
-					m.metadataUpdater.UpdateTickSize(tickSizeMsg.AssetID, newTickSize)
-					m.logger.Info("tick-size-change-received-and-updated",
-						zap.String("market", tickSizeMsg.Market),
-						zap.String("asset-id", tickSizeMsg.AssetID),
-						zap.String("old-tick-size", tickSizeMsg.OldTickSize),
-						zap.String("new-tick-size", tickSizeMsg.NewTickSize),
-						zap.String("action", "metadata cache updated"))
-				} else {
-					m.logger.Warn("tick-size-change-parse-error",
-						zap.String("asset-id", tickSizeMsg.AssetID),
-						zap.String("new-tick-size", tickSizeMsg.NewTickSize),
-						zap.Error(scanErr))
-				}
-			} else {
-				m.logger.Info("tick-size-change-received",
-					zap.String("market", tickSizeMsg.Market),
-					zap.String("asset-id", tickSizeMsg.AssetID),
-					zap.String("old-tick-size", tickSizeMsg.OldTickSize),
-					zap.String("new-tick-size", tickSizeMsg.NewTickSize),
-					zap.String("action", "no metadata updater configured"))
-			}
-			continue
-		}
+	ticker := time.NewTicker(m.config.UtilizationInterval)
+	defer ticker.Stop()
 
-		// Try #5: Identify other message types for better logging
-		messageStr := string(message)
+	m.reportUtilization()
 
-		// Check if it's a heartbeat/keepalive (empty array or minimal content)
-		if messageStr == "[]" || messageStr == "" || len(message) < 10 {
-			m.logger.Debug("websocket-heartbeat-received",
-				zap.Int("bytes", len(message)))
-			continue
+	for {
+		select {
+		case <-m.ctx.Done():
+			return
+		case <-ticker.C:
+			m.reportUtilization()
 		}
+	}
+}
 
-		// Check if it's a subscription confirmation or other control message
-		var controlMsg map[string]interface{}
-		if json.Unmarshal(message, &controlMsg) == nil {
-			if msgType, ok := controlMsg["type"].(string); ok {
-				m.logger.Debug("websocket-control-message",
-					zap.String("type", msgType),
-					zap.Int("bytes", len(message)))
-				continue
-			}
-		}
+// reportUtilization sets MessageChannelUtilization to the current buffered/capacity ratio
+// and returns it.
+func (m *Manager) reportUtilization() float64 {
+	utilization := m.channelUtilization()
+	MessageChannelUtilization.WithLabelValues(m.config.MetricsID).Set(utilization)
+	return utilization
+}
 
-		// Unknown message format - log FULL message for debugging
-		m.logger.Warn("websocket-unparseable-message",
-			zap.NamedError("book-array-parse-error", bookErr),
-			zap.NamedError("book-single-parse-error", singleBookErr),
-			zap.NamedError("price-change-parse-error", priceErr),
-			zap.NamedError("trade-parse-error", tradeErr),
-			zap.NamedError("tick-size-change-parse-error", tickSizeErr),
-			zap.Int("bytes", len(message)),
-			zap.String("full-message", messageStr))
+// channelUtilization returns the fraction of the message channel buffer in use.
+func (m *Manager) channelUtilization() float64 {
+	capacity := cap(m.messageChan)
+	if capacity == 0 {
+		return 0
 	}
+	return float64(len(m.messageChan)) / float64(capacity)
 }
 
 // pingLoop sends periodic PING messages.
@@ -587,7 +692,9 @@ func (m *Manager) reconnectLoop() {
 			continue
 		}
 
-		m.logger.Warn("connection-lost-initiating-reconnect")
+		reason, _ := m.LastDisconnect()
+		m.logger.Warn("connection-lost-initiating-reconnect",
+			zap.String("reason", reason))
 
 		// Attempt reconnection
 		err := m.reconnectMgr.Reconnect(m.ctx, m.connect)
@@ -595,6 +702,11 @@ func (m *Manager) reconnectLoop() {
 			if err == context.Canceled {
 				return
 			}
+			if errors.Is(err, ErrReconnectExhausted) {
+				// Terminal: stop retrying and report the failure through FatalError
+				m.fatalErr.Store(&err)
+				return
+			}
 			m.logger.Error("reconnection-failed", zap.Error(err))
 			continue
 		}
@@ -603,11 +715,13 @@ func (m *Manager) reconnectLoop() {
 		err = m.resubscribeAll(m.ctx)
 		if err != nil {
 			m.logger.Error("resubscribe-failed", zap.Error(err))
-			m.connected.Store(false)
+			m.markDisconnected(disconnectResubscribeFailed)
 			continue
 		}
 
-		m.logger.Info("reconnection-complete-restarting-read-loop")
+		ReconnectsTotal.WithLabelValues(reason).Inc()
+		m.logger.Info("reconnection-complete-restarting-read-loop",
+			zap.String("reason", reason))
 
 		// Restart read loop
 		m.wg.Add(1)
@@ -615,42 +729,154 @@ func (m *Manager) reconnectLoop() {
 	}
 }
 
-// resubscribeAll resubscribes to all previously subscribed tokens.
+// markDisconnected records why the connection was lost, flags it as lost, which wakes
+// reconnectLoop, and notifies OnDisconnect.
+func (m *Manager) markDisconnected(reason string) {
+	m.lastDisconnect.Store(&disconnect{reason: reason, at: time.Now()})
+	m.connected.Store(false)
+
+	if m.config.OnDisconnect != nil {
+		m.config.OnDisconnect()
+	}
+}
+
+// resubscribeAll subscribes the current connection to every tracked token not yet sent on it.
+// Tokens are sent in frames of at most ResubscribeBatchSize to stay under server
+// frame limits. The first frame on a connection is the initial "market" subscription;
+// the rest use the dynamic subscribe operation. The market channel sends no subscription
+// ack, so a batch counts as acknowledged once its frame is written without error.
+// Tokens passed to Subscribe while this runs are picked up before it returns, so each
+// token is subscribed exactly once per connection.
 func (m *Manager) resubscribeAll(ctx context.Context) error {
-	m.mu.RLock()
-	tokenIDs := make([]string, 0, len(m.subscribed))
-	for tokenID := range m.subscribed {
-		tokenIDs = append(tokenIDs, tokenID)
+	total := 0
+	batches := 0
+
+	for {
+		m.mu.Lock()
+		pending := m.pendingLocked()
+		if len(pending) == 0 {
+			m.resyncing = false
+			m.mu.Unlock()
+			break
+		}
+		m.mu.Unlock()
+
+		for start := 0; start < len(pending); start += m.config.ResubscribeBatchSize {
+			end := min(start+m.config.ResubscribeBatchSize, len(pending))
+			batch := pending[start:end]
+
+			if batches > 0 {
+				// Pace follow-up frames so the server isn't flooded
+				select {
+				case <-ctx.Done():
+					return ctx.Err()
+				case <-time.After(m.config.ResubscribeBatchDelay):
+				}
+			}
+
+			err := m.sendResubscribeBatch(batch)
+			if err != nil {
+				return fmt.Errorf("write resubscribe batch %d-%d of %d: %w", start, end, len(pending), err)
+			}
+
+			batches++
+			total += len(batch)
+			m.logger.Debug("resubscribe-batch-sent",
+				zap.Int("batch", batches),
+				zap.Int("token-count", len(batch)))
+		}
 	}
-	m.mu.RUnlock()
 
-	if len(tokenIDs) == 0 {
-		return nil
+	if total > 0 {
+		m.logger.Info("resubscribed-to-all-markets",
+			zap.Int("count", total),
+			zap.Int("batches", batches))
 	}
 
-	// Initial subscribe message after reconnect
-	subscribeMsg := map[string]interface{}{
-		"assets_ids": tokenIDs,
-		"type":       "market",
+	return nil
+}
+
+// pendingLocked returns tracked tokens not yet subscribed on the current connection.
+// Caller must hold m.mu.
+func (m *Manager) pendingLocked() []string {
+	pending := make([]string, 0, len(m.subscribed)-len(m.sent))
+	for tokenID := range m.subscribed {
+		if !m.sent[tokenID] {
+			pending = append(pending, tokenID)
+		}
 	}
+	return pending
+}
+
+// sendResubscribeBatch writes one resubscribe frame and marks its tokens as sent.
+// Tokens unsubscribed since the batch was built are skipped.
+func (m *Manager) sendResubscribeBatch(batch []string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
 
-	m.mu.RLock()
 	if m.conn == nil {
-		m.mu.RUnlock()
 		return fmt.Errorf("no active connection for resubscribe")
 	}
-	err := m.conn.WriteJSON(subscribeMsg)
-	m.mu.RUnlock()
 
+	tokenIDs := make([]string, 0, len(batch))
+	for _, tokenID := range batch {
+		if m.subscribed[tokenID] && !m.sent[tokenID] {
+			tokenIDs = append(tokenIDs, tokenID)
+		}
+	}
+	if len(tokenIDs) == 0 {
+		return nil
+	}
+
+	var subscribeMsg map[string]interface{}
+	if len(m.sent) == 0 {
+		// Initial subscribe message on this connection
+		subscribeMsg = map[string]interface{}{
+			"assets_ids": tokenIDs,
+			"type":       "market",
+		}
+	} else {
+		subscribeMsg = map[string]interface{}{
+			"assets_ids": tokenIDs,
+			"operation":  "subscribe",
+		}
+	}
+
+	err := m.conn.WriteJSON(subscribeMsg)
 	if err != nil {
-		return fmt.Errorf("write resubscribe message: %w", err)
+		return err
 	}
 
-	m.logger.Info("resubscribed-to-all-markets", zap.Int("count", len(tokenIDs)))
+	for _, tokenID := range tokenIDs {
+		m.sent[tokenID] = true
+	}
+	return nil
+}
+
+// IsConnected reports whether the WebSocket connection is currently established.
+func (m *Manager) IsConnected() bool {
+	return m.connected.Load()
+}
 
+// FatalError returns the error that made the manager give up reconnecting, or nil
+// while it is connected or still retrying.
+func (m *Manager) FatalError() error {
+	if err := m.fatalErr.Load(); err != nil {
+		return *err
+	}
 	return nil
 }
 
+// LastDisconnect returns why and when the connection was last lost: a read error class
+// (read_limit, closed, eof, timeout, network) or resubscribe_failed. The reason is empty
+// and the time zero if the connection hasn't dropped since Start.
+func (m *Manager) LastDisconnect() (reason string, at time.Time) {
+	if last := m.lastDisconnect.Load(); last != nil {
+		return last.reason, last.at
+	}
+	return "", time.Time{}
+}
+
 // MessageChan returns the channel for receiving orderbook messages.
 func (m *Manager) MessageChan() <-chan *types.OrderbookMessage {
 	return m.messageChan
@@ -672,7 +898,12 @@ func (m *Manager) Close() error {
 
 	close(m.messageChan)
 
+	if m.ownsRecorder {
+		_ = m.recorder.Close()
+	}
+
 	ActiveConnections.Set(0)
+	MessageChannelUtilization.WithLabelValues(m.config.MetricsID).Set(0)
 
 	m.logger.Info("websocket-manager-closed")
 
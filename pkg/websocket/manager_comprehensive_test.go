@@ -3,13 +3,25 @@ package websocket
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
 	"sync"
+	"sync/atomic"
+	"syscall"
 	"testing"
 	"time"
 
+	"github.com/gorilla/websocket"
 	"github.com/mselser95/polymarket-arb/pkg/types"
+	promtestutil "github.com/prometheus/client_golang/prometheus/testutil"
 	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
 )
 
 // TestManager_ParseMessage_ArrayOfOrderbooks tests parsing array format
@@ -522,6 +534,97 @@ func TestManager_ResubscribeAll_WithTokens(t *testing.T) {
 	mgr.mu.RUnlock()
 }
 
+// TestManager_ResubscribeAll_Batched tests large resubscriptions are split into bounded frames
+func TestManager_ResubscribeAll_Batched(t *testing.T) {
+	frames := make(chan map[string]interface{}, 100)
+	upgrader := websocket.Upgrader{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		for {
+			var frame map[string]interface{}
+			err := conn.ReadJSON(&frame)
+			if err != nil {
+				return
+			}
+			frames <- frame
+		}
+	}))
+	defer server.Close()
+
+	mgr := New(Config{
+		URL:                   "ws" + strings.TrimPrefix(server.URL, "http"),
+		DialTimeout:           5 * time.Second,
+		MessageBufferSize:     100,
+		ResubscribeBatchSize:  100,
+		ResubscribeBatchDelay: time.Millisecond,
+		Logger:                zap.NewNop(),
+	})
+
+	ctx := context.Background()
+	err := mgr.connect(ctx)
+	if err != nil {
+		t.Fatalf("failed to connect: %v", err)
+	}
+	defer mgr.conn.Close()
+
+	mgr.mu.Lock()
+	for i := range 500 {
+		mgr.subscribed[fmt.Sprintf("token%d", i)] = true
+	}
+	mgr.mu.Unlock()
+
+	err = mgr.resubscribeAll(ctx)
+	if err != nil {
+		t.Fatalf("resubscribeAll failed: %v", err)
+	}
+
+	seen := make(map[string]bool)
+	for i := range 5 {
+		var frame map[string]interface{}
+		select {
+		case frame = <-frames:
+		case <-time.After(2 * time.Second):
+			t.Fatalf("timeout waiting for frame %d", i)
+		}
+
+		assets, ok := frame["assets_ids"].([]interface{})
+		if !ok {
+			t.Fatalf("frame %d missing assets_ids: %v", i, frame)
+		}
+		if len(assets) > 100 {
+			t.Errorf("frame %d has %d tokens, want <= 100", i, len(assets))
+		}
+		for _, asset := range assets {
+			seen[asset.(string)] = true
+		}
+
+		if i == 0 {
+			if frame["type"] != "market" {
+				t.Errorf("first frame should have type=market, got %v", frame)
+			}
+		} else {
+			if frame["operation"] != "subscribe" || frame["type"] != nil {
+				t.Errorf("frame %d should use operation=subscribe, got %v", i, frame)
+			}
+		}
+	}
+
+	if len(seen) != 500 {
+		t.Errorf("expected all 500 tokens resubscribed, got %d", len(seen))
+	}
+
+	select {
+	case frame := <-frames:
+		t.Errorf("unexpected extra frame: %v", frame)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
 // TestManager_ConnectionState_Atomicity tests atomic connection state updates
 func TestManager_ConnectionState_Atomicity(t *testing.T) {
 	logger, _ := zap.NewDevelopment()
@@ -654,3 +757,732 @@ func TestManager_ParseMessage_TickSizeChange(t *testing.T) {
 		t.Errorf("expected event_type 'tick_size_change', got '%s'", message.EventType)
 	}
 }
+
+// subscriptionCLOB is a market channel server that records subscribed tokens per
+// connection and can drop the current connection on demand.
+type subscriptionCLOB struct {
+	mu          sync.Mutex
+	connections []map[string]int // token -> subscription count, per connection
+	frames      [][]string       // "subscribe:<token>" / "unsubscribe:<token>" in arrival order, per connection
+	current     *websocket.Conn
+}
+
+func (s *subscriptionCLOB) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	upgrader := websocket.Upgrader{}
+	conn, err := upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+
+	s.mu.Lock()
+	s.current = conn
+	s.connections = append(s.connections, make(map[string]int))
+	s.frames = append(s.frames, nil)
+	connection := len(s.connections) - 1
+	counts := s.connections[connection]
+	s.mu.Unlock()
+
+	for {
+		var frame struct {
+			AssetsIDs []string `json:"assets_ids"`
+			Operation string   `json:"operation"`
+		}
+		err := conn.ReadJSON(&frame)
+		if err != nil {
+			return
+		}
+
+		s.mu.Lock()
+		for _, tokenID := range frame.AssetsIDs {
+			if frame.Operation == "unsubscribe" {
+				s.frames[connection] = append(s.frames[connection], "unsubscribe:"+tokenID)
+				continue
+			}
+			counts[tokenID]++
+			s.frames[connection] = append(s.frames[connection], "subscribe:"+tokenID)
+		}
+		s.mu.Unlock()
+	}
+}
+
+// log returns the subscribe/unsubscribe entries received on a connection, in order.
+func (s *subscriptionCLOB) log(connection int) []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if connection >= len(s.frames) {
+		return nil
+	}
+	return append([]string(nil), s.frames[connection]...)
+}
+
+func (s *subscriptionCLOB) drop() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_ = s.current.Close()
+}
+
+func (s *subscriptionCLOB) counts(connection int) (int, map[string]int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if connection >= len(s.connections) {
+		return len(s.connections), nil
+	}
+	counts := make(map[string]int, len(s.connections[connection]))
+	for tokenID, n := range s.connections[connection] {
+		counts[tokenID] = n
+	}
+	return len(s.connections), counts
+}
+
+// TestManager_SubscribeDuringDisconnect_SingleSubscriptionAfterReconnect tests that a token
+// subscribed while disconnected is sent exactly once on the new connection, alongside the
+// tokens resubscribed from before the disconnect.
+func TestManager_SubscribeDuringDisconnect_SingleSubscriptionAfterReconnect(t *testing.T) {
+	clob := &subscriptionCLOB{}
+	server := httptest.NewServer(clob)
+	defer server.Close()
+
+	mgr := New(Config{
+		URL:                   "ws" + strings.TrimPrefix(server.URL, "http"),
+		DialTimeout:           5 * time.Second,
+		PongTimeout:           15 * time.Second,
+		PingInterval:          10 * time.Second,
+		ReconnectInitialDelay: 10 * time.Millisecond,
+		ReconnectMaxDelay:     50 * time.Millisecond,
+		ReconnectBackoffMult:  2.0,
+		MessageBufferSize:     100,
+		ResubscribeBatchDelay: time.Millisecond,
+		Logger:                zap.NewNop(),
+	})
+
+	err := mgr.Start()
+	if err != nil {
+		t.Fatalf("start: %v", err)
+	}
+	defer mgr.Close()
+
+	ctx := context.Background()
+	err = mgr.Subscribe(ctx, []string{"token1", "token2"})
+	if err != nil {
+		t.Fatalf("subscribe: %v", err)
+	}
+
+	waitFor := func(what string, cond func() bool) {
+		t.Helper()
+		deadline := time.Now().Add(5 * time.Second)
+		for !cond() {
+			if time.Now().After(deadline) {
+				t.Fatalf("timed out waiting for %s", what)
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+	}
+
+	waitFor("initial subscription", func() bool {
+		_, counts := clob.counts(0)
+		return counts["token1"] == 1 && counts["token2"] == 1
+	})
+
+	clob.drop()
+	waitFor("disconnect", func() bool { return !mgr.IsConnected() })
+
+	// Subscribed during the disconnect window: tracked for the reconnect
+	_ = mgr.Subscribe(ctx, []string{"token3", "token1"})
+
+	waitFor("resubscription", func() bool {
+		_, counts := clob.counts(1)
+		return counts["token1"] > 0 && counts["token2"] > 0 && counts["token3"] > 0
+	})
+
+	// Subscribing again once reconnected must not resend
+	err = mgr.Subscribe(ctx, []string{"token3"})
+	if err != nil {
+		t.Fatalf("subscribe after reconnect: %v", err)
+	}
+	time.Sleep(100 * time.Millisecond)
+
+	connections, counts := clob.counts(1)
+	if connections != 2 {
+		t.Errorf("expected 2 connections, got %d", connections)
+	}
+	for _, tokenID := range []string{"token1", "token2", "token3"} {
+		if counts[tokenID] != 1 {
+			t.Errorf("expected %s subscribed once after reconnect, got %d", tokenID, counts[tokenID])
+		}
+	}
+}
+
+// TestManager_SubscribeDuringResubscribe tests that tokens added while a reconnect's
+// resubscription is in progress are sent once, by the resubscription.
+func TestManager_SubscribeDuringResubscribe(t *testing.T) {
+	clob := &subscriptionCLOB{}
+	server := httptest.NewServer(clob)
+	defer server.Close()
+
+	mgr := New(Config{
+		URL:                   "ws" + strings.TrimPrefix(server.URL, "http"),
+		DialTimeout:           5 * time.Second,
+		MessageBufferSize:     100,
+		ResubscribeBatchDelay: time.Millisecond,
+		Logger:                zap.NewNop(),
+	})
+
+	mgr.mu.Lock()
+	mgr.subscribed["token1"] = true
+	mgr.mu.Unlock()
+
+	ctx := context.Background()
+	err := mgr.connect(ctx)
+	if err != nil {
+		t.Fatalf("connect: %v", err)
+	}
+	defer mgr.conn.Close()
+
+	// Connected but not yet resubscribed: Subscribe defers to resubscribeAll
+	err = mgr.Subscribe(ctx, []string{"token2"})
+	if err != nil {
+		t.Fatalf("subscribe during resubscribe: %v", err)
+	}
+
+	err = mgr.resubscribeAll(ctx)
+	if err != nil {
+		t.Fatalf("resubscribeAll: %v", err)
+	}
+
+	err = mgr.Subscribe(ctx, []string{"token2", "token3"})
+	if err != nil {
+		t.Fatalf("subscribe: %v", err)
+	}
+
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		_, counts := clob.counts(0)
+		if counts["token3"] > 0 || time.Now().After(deadline) {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	time.Sleep(50 * time.Millisecond)
+
+	_, counts := clob.counts(0)
+	for _, tokenID := range []string{"token1", "token2", "token3"} {
+		if counts[tokenID] != 1 {
+			t.Errorf("expected %s subscribed once, got %d", tokenID, counts[tokenID])
+		}
+	}
+}
+
+// TestManager_OversizedFrame_ClassifiedAsReadLimit tests that a frame over MaxMessageSize is
+// reported as a read-limit error rather than a network error.
+func TestManager_OversizedFrame_ClassifiedAsReadLimit(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		upgrader := websocket.Upgrader{}
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		// A book snapshot well over the client's 1KB limit
+		snapshot := fmt.Sprintf(`[{"event_type":"book","asset_id":"token1","bids":[],"asks":[],"hash":%q}]`,
+			strings.Repeat("x", 4096))
+		_ = conn.WriteMessage(websocket.TextMessage, []byte(snapshot))
+
+		// Hold the connection open until the client drops it
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}))
+	defer server.Close()
+
+	core, logs := observer.New(zap.WarnLevel)
+	mgr := New(Config{
+		URL:                   "ws" + strings.TrimPrefix(server.URL, "http"),
+		DialTimeout:           5 * time.Second,
+		PongTimeout:           15 * time.Second,
+		PingInterval:          10 * time.Second,
+		ReconnectInitialDelay: time.Hour,
+		ReconnectMaxDelay:     time.Hour,
+		ReconnectBackoffMult:  2.0,
+		MessageBufferSize:     100,
+		MaxMessageSize:        1024,
+		Logger:                zap.New(core),
+	})
+
+	readLimitBefore := promtestutil.ToFloat64(ReadErrorsTotal.WithLabelValues(readErrorReadLimit))
+	networkBefore := promtestutil.ToFloat64(ReadErrorsTotal.WithLabelValues(readErrorNetwork))
+
+	err := mgr.Start()
+	if err != nil {
+		t.Fatalf("start: %v", err)
+	}
+	defer mgr.Close()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for logs.FilterMessage("websocket-message-exceeds-read-limit").Len() == 0 {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for read-limit error, logs: %+v", logs.All())
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	entry := logs.FilterMessage("websocket-message-exceeds-read-limit").All()[0]
+	if entry.Level != zap.ErrorLevel {
+		t.Errorf("expected read-limit error at error level, got %s", entry.Level)
+	}
+	if got := entry.ContextMap()["max-message-size"]; got != int64(1024) {
+		t.Errorf("expected max-message-size 1024 in log, got %v", got)
+	}
+	if logs.FilterMessage("read-error").Len() != 0 {
+		t.Error("expected oversized frame not to be logged as a generic read error")
+	}
+
+	if got := promtestutil.ToFloat64(ReadErrorsTotal.WithLabelValues(readErrorReadLimit)) - readLimitBefore; got != 1 {
+		t.Errorf("expected 1 read_limit error, got %f", got)
+	}
+	if got := promtestutil.ToFloat64(ReadErrorsTotal.WithLabelValues(readErrorNetwork)) - networkBefore; got != 0 {
+		t.Errorf("expected no network errors, got %f", got)
+	}
+}
+
+func TestClassifyReadError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{name: "read_limit", err: websocket.ErrReadLimit, want: readErrorReadLimit},
+		{name: "wrapped_read_limit", err: fmt.Errorf("read: %w", websocket.ErrReadLimit), want: readErrorReadLimit},
+		{name: "close_frame", err: &websocket.CloseError{Code: websocket.CloseGoingAway}, want: readErrorClosed},
+		{name: "abnormal_closure", err: &websocket.CloseError{Code: websocket.CloseAbnormalClosure}, want: readErrorEOF},
+		{name: "eof", err: io.EOF, want: readErrorEOF},
+		{name: "unexpected_eof", err: fmt.Errorf("read: %w", io.ErrUnexpectedEOF), want: readErrorEOF},
+		{name: "deadline", err: &net.OpError{Op: "read", Err: os.ErrDeadlineExceeded}, want: readErrorTimeout},
+		{name: "net_timeout", err: timeoutError{}, want: readErrorTimeout},
+		{name: "connection_reset", err: &net.OpError{Op: "read", Err: syscall.ECONNRESET}, want: readErrorNetwork},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := classifyReadError(tt.err); got != tt.want {
+				t.Errorf("expected %s, got %s", tt.want, got)
+			}
+		})
+	}
+}
+
+// timeoutError is a net.Error that reports a timeout.
+type timeoutError struct{}
+
+func (timeoutError) Error() string   { return "i/o timeout" }
+func (timeoutError) Timeout() bool   { return true }
+func (timeoutError) Temporary() bool { return true }
+
+// TestManager_DisconnectReason tests that a dropped connection records its read error
+// class as the last disconnect reason, and that the reconnect is counted under it.
+func TestManager_DisconnectReason(t *testing.T) {
+	clob := &subscriptionCLOB{}
+	server := httptest.NewServer(clob)
+	defer server.Close()
+
+	mgr := New(Config{
+		URL:                   "ws" + strings.TrimPrefix(server.URL, "http"),
+		DialTimeout:           5 * time.Second,
+		PongTimeout:           15 * time.Second,
+		PingInterval:          10 * time.Second,
+		ReconnectInitialDelay: 10 * time.Millisecond,
+		ReconnectMaxDelay:     50 * time.Millisecond,
+		ReconnectBackoffMult:  2.0,
+		MessageBufferSize:     100,
+		Logger:                zap.NewNop(),
+	})
+
+	readErrorsBefore := promtestutil.ToFloat64(ReadErrorsTotal.WithLabelValues(readErrorEOF))
+	reconnectsBefore := promtestutil.ToFloat64(ReconnectsTotal.WithLabelValues(readErrorEOF))
+
+	err := mgr.Start()
+	if err != nil {
+		t.Fatalf("start: %v", err)
+	}
+	defer mgr.Close()
+
+	if reason, at := mgr.LastDisconnect(); reason != "" || !at.IsZero() {
+		t.Errorf("expected no disconnect recorded yet, got %q at %v", reason, at)
+	}
+
+	waitFor := func(what string, cond func() bool) {
+		t.Helper()
+		deadline := time.Now().Add(5 * time.Second)
+		for !cond() {
+			if time.Now().After(deadline) {
+				t.Fatalf("timed out waiting for %s", what)
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+	}
+
+	waitFor("connection", func() bool {
+		connections, _ := clob.counts(0)
+		return connections == 1
+	})
+
+	// The server goes away without a close frame
+	dropped := time.Now()
+	clob.drop()
+
+	waitFor("reconnect", func() bool {
+		return promtestutil.ToFloat64(ReconnectsTotal.WithLabelValues(readErrorEOF))-reconnectsBefore == 1
+	})
+
+	reason, at := mgr.LastDisconnect()
+	if reason != readErrorEOF {
+		t.Errorf("expected disconnect reason %q, got %q", readErrorEOF, reason)
+	}
+	if at.Before(dropped) || at.After(time.Now()) {
+		t.Errorf("expected disconnect time after %v, got %v", dropped, at)
+	}
+	if got := promtestutil.ToFloat64(ReadErrorsTotal.WithLabelValues(readErrorEOF)) - readErrorsBefore; got != 1 {
+		t.Errorf("expected 1 eof read error, got %.0f", got)
+	}
+}
+
+// TestManager_ReconnectExhausted_ReportsFatalError tests that a manager whose server goes
+// away stops reconnecting after ReconnectMaxAttempts and reports the failure.
+func TestManager_ReconnectExhausted_ReportsFatalError(t *testing.T) {
+	clob := &subscriptionCLOB{}
+	server := httptest.NewServer(clob)
+
+	exhausted := make(chan error, 1)
+	mgr := New(Config{
+		URL:                   "ws" + strings.TrimPrefix(server.URL, "http"),
+		DialTimeout:           time.Second,
+		PongTimeout:           15 * time.Second,
+		PingInterval:          10 * time.Second,
+		ReconnectInitialDelay: time.Millisecond,
+		ReconnectMaxDelay:     5 * time.Millisecond,
+		ReconnectBackoffMult:  2.0,
+		ReconnectMaxAttempts:  2,
+		MessageBufferSize:     100,
+		Logger:                zap.NewNop(),
+		OnReconnectExhausted:  func(err error) { exhausted <- err },
+	})
+
+	err := mgr.Start()
+	if err != nil {
+		t.Fatalf("start: %v", err)
+	}
+	defer mgr.Close()
+
+	if mgr.FatalError() != nil {
+		t.Fatalf("expected no fatal error while connected, got %v", mgr.FatalError())
+	}
+
+	// Stop accepting connections, then drop the live one
+	server.Close()
+	clob.drop()
+
+	select {
+	case err = <-exhausted:
+		if !errors.Is(err, ErrReconnectExhausted) {
+			t.Errorf("expected ErrReconnectExhausted in callback, got %v", err)
+		}
+	case <-time.After(10 * time.Second):
+		t.Fatal("timed out waiting for reconnection to give up")
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for mgr.FatalError() == nil {
+		if time.Now().After(deadline) {
+			t.Fatal("expected FatalError to be set after giving up")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if mgr.IsConnected() {
+		t.Error("expected manager to stay disconnected")
+	}
+}
+
+// TestManager_ConnectionCallbacks tests that OnConnect fires on the initial connect and
+// after reconnecting, OnDisconnect fires on a read error, and both run without the
+// manager's lock held.
+func TestManager_ConnectionCallbacks(t *testing.T) {
+	clob := &subscriptionCLOB{}
+	server := httptest.NewServer(clob)
+	defer server.Close()
+
+	var mgr *Manager
+	events := make(chan string, 10)
+	mgr = New(Config{
+		URL:                   "ws" + strings.TrimPrefix(server.URL, "http"),
+		DialTimeout:           time.Second,
+		PongTimeout:           15 * time.Second,
+		PingInterval:          10 * time.Second,
+		ReconnectInitialDelay: 10 * time.Millisecond,
+		ReconnectMaxDelay:     50 * time.Millisecond,
+		ReconnectBackoffMult:  2.0,
+		MessageBufferSize:     100,
+		Logger:                zap.NewNop(),
+		OnConnect: func() {
+			// Would deadlock if the callback ran under the manager's lock
+			mgr.mu.Lock()
+			mgr.mu.Unlock()
+			events <- "connect"
+		},
+		OnDisconnect: func() {
+			mgr.mu.Lock()
+			mgr.mu.Unlock()
+			events <- "disconnect"
+		},
+	})
+
+	err := mgr.Start()
+	if err != nil {
+		t.Fatalf("start: %v", err)
+	}
+	defer mgr.Close()
+
+	waitEvent := func(want string) {
+		t.Helper()
+		select {
+		case got := <-events:
+			if got != want {
+				t.Fatalf("expected %s callback, got %s", want, got)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("timed out waiting for %s callback", want)
+		}
+	}
+
+	waitEvent("connect")
+
+	// Simulate a read error by dropping the connection server-side
+	clob.drop()
+	waitEvent("disconnect")
+	waitEvent("connect")
+}
+
+// TestManager_Resubscribe_AtomicSwap tests that Resubscribe unsubscribes then re-subscribes
+// tokens already sent on the connection, subscribes untracked ones, and keeps every
+// token tracked and sent once it returns.
+func TestManager_Resubscribe_AtomicSwap(t *testing.T) {
+	clob := &subscriptionCLOB{}
+	server := httptest.NewServer(clob)
+	defer server.Close()
+
+	mgr := New(Config{
+		URL:                   "ws" + strings.TrimPrefix(server.URL, "http"),
+		DialTimeout:           5 * time.Second,
+		PongTimeout:           15 * time.Second,
+		PingInterval:          10 * time.Second,
+		ReconnectInitialDelay: 10 * time.Millisecond,
+		ReconnectMaxDelay:     50 * time.Millisecond,
+		ReconnectBackoffMult:  2.0,
+		MessageBufferSize:     100,
+		Logger:                zap.NewNop(),
+	})
+
+	err := mgr.Start()
+	if err != nil {
+		t.Fatalf("start: %v", err)
+	}
+	defer mgr.Close()
+
+	ctx := context.Background()
+	err = mgr.Subscribe(ctx, []string{"token1", "token2"})
+	if err != nil {
+		t.Fatalf("subscribe: %v", err)
+	}
+
+	err = mgr.Resubscribe(ctx, []string{"token1", "token3", "token1"})
+	if err != nil {
+		t.Fatalf("resubscribe: %v", err)
+	}
+
+	want := []string{"subscribe:token1", "subscribe:token2", "unsubscribe:token1", "subscribe:token1", "subscribe:token3"}
+	deadline := time.Now().Add(2 * time.Second)
+	for len(clob.log(0)) < len(want) && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	got := clob.log(0)
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("expected frames %v, got %v", want, got)
+	}
+
+	mgr.mu.RLock()
+	defer mgr.mu.RUnlock()
+	for _, tokenID := range []string{"token1", "token2", "token3"} {
+		if !mgr.subscribed[tokenID] || !mgr.sent[tokenID] {
+			t.Errorf("expected %s tracked and sent, got subscribed=%v sent=%v",
+				tokenID, mgr.subscribed[tokenID], mgr.sent[tokenID])
+		}
+	}
+}
+
+// TestManager_Resubscribe_NoConnection tests that Resubscribe while disconnected keeps
+// the tokens tracked, so the reconnect subscribes each of them once.
+func TestManager_Resubscribe_NoConnection(t *testing.T) {
+	clob := &subscriptionCLOB{}
+	server := httptest.NewServer(clob)
+	defer server.Close()
+
+	mgr := New(Config{
+		URL:                   "ws" + strings.TrimPrefix(server.URL, "http"),
+		DialTimeout:           5 * time.Second,
+		MessageBufferSize:     100,
+		ResubscribeBatchDelay: time.Millisecond,
+		Logger:                zap.NewNop(),
+	})
+
+	ctx := context.Background()
+	err := mgr.Resubscribe(ctx, []string{"token1", "token2"})
+	if err == nil {
+		t.Fatal("expected error without a connection")
+	}
+
+	err = mgr.connect(ctx)
+	if err != nil {
+		t.Fatalf("connect: %v", err)
+	}
+	defer mgr.conn.Close()
+
+	// Still resyncing: unsent tokens are left to resubscribeAll
+	err = mgr.Resubscribe(ctx, []string{"token2"})
+	if err != nil {
+		t.Fatalf("resubscribe during resync: %v", err)
+	}
+
+	err = mgr.resubscribeAll(ctx)
+	if err != nil {
+		t.Fatalf("resubscribeAll: %v", err)
+	}
+
+	deadline := time.Now().Add(2 * time.Second)
+	for len(clob.log(0)) < 2 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	time.Sleep(50 * time.Millisecond)
+
+	_, counts := clob.counts(0)
+	for _, tokenID := range []string{"token1", "token2"} {
+		if counts[tokenID] != 1 {
+			t.Errorf("expected %s subscribed once, got %d", tokenID, counts[tokenID])
+		}
+	}
+	for _, frame := range clob.log(0) {
+		if strings.HasPrefix(frame, "unsubscribe:") {
+			t.Errorf("expected no unsubscribe for tokens never sent, got %s", frame)
+		}
+	}
+}
+
+// TestManager_Resubscribe_ConcurrentReconnect tests that tokens being resubscribed are
+// never untracked while connections drop, and that the final connection carries every
+// token.
+func TestManager_Resubscribe_ConcurrentReconnect(t *testing.T) {
+	clob := &subscriptionCLOB{}
+	server := httptest.NewServer(clob)
+	defer server.Close()
+
+	mgr := New(Config{
+		URL:                   "ws" + strings.TrimPrefix(server.URL, "http"),
+		DialTimeout:           5 * time.Second,
+		PongTimeout:           15 * time.Second,
+		PingInterval:          10 * time.Second,
+		ReconnectInitialDelay: 5 * time.Millisecond,
+		ReconnectMaxDelay:     20 * time.Millisecond,
+		ReconnectBackoffMult:  2.0,
+		MessageBufferSize:     100,
+		ResubscribeBatchDelay: time.Millisecond,
+		Logger:                zap.NewNop(),
+	})
+
+	err := mgr.Start()
+	if err != nil {
+		t.Fatalf("start: %v", err)
+	}
+	defer mgr.Close()
+
+	ctx := context.Background()
+	tokens := []string{"token1", "token2", "token3"}
+	err = mgr.Subscribe(ctx, tokens)
+	if err != nil {
+		t.Fatalf("subscribe: %v", err)
+	}
+
+	stop := make(chan struct{})
+	var wg sync.WaitGroup
+	var untracked atomic.Int64
+
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		for {
+			select {
+			case <-stop:
+				return
+			default:
+			}
+			// Errors are expected while disconnected; the tokens stay tracked
+			_ = mgr.Resubscribe(ctx, tokens[:2])
+			time.Sleep(time.Millisecond)
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for {
+			select {
+			case <-stop:
+				return
+			default:
+			}
+			mgr.mu.RLock()
+			for _, tokenID := range tokens {
+				if !mgr.subscribed[tokenID] {
+					untracked.Add(1)
+				}
+			}
+			mgr.mu.RUnlock()
+		}
+	}()
+
+	for range 3 {
+		time.Sleep(20 * time.Millisecond)
+		clob.drop()
+	}
+	time.Sleep(20 * time.Millisecond)
+	close(stop)
+	wg.Wait()
+
+	if n := untracked.Load(); n > 0 {
+		t.Errorf("expected tokens always tracked, saw %d untracked reads", n)
+	}
+
+	// The last connection ends up with every token subscribed once more than unsubscribed
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		connections, _ := clob.counts(0)
+		last := clob.log(connections - 1)
+		balance := make(map[string]int)
+		for _, frame := range last {
+			op, tokenID, _ := strings.Cut(frame, ":")
+			if op == "subscribe" {
+				balance[tokenID]++
+			} else {
+				balance[tokenID]--
+			}
+		}
+		if mgr.IsConnected() && balance["token1"] == 1 && balance["token2"] == 1 && balance["token3"] == 1 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("expected every token subscribed on the last connection, got %v", balance)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
@@ -6,8 +6,12 @@ import (
 	"testing"
 	"time"
 
+	promtestutil "github.com/prometheus/client_golang/prometheus/testutil"
+
 	"github.com/mselser95/polymarket-arb/pkg/types"
 	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
 )
 
 func TestNew(t *testing.T) {
@@ -509,3 +513,138 @@ func TestManager_MessageProcessing(t *testing.T) {
 		// Expected - channel is full
 	}
 }
+
+func TestNew_ChannelUtilizationDefaults(t *testing.T) {
+	mgr := New(Config{Logger: zap.NewNop(), MessageBufferSize: 10})
+
+	if mgr.config.ChannelWarnThreshold != defaultChannelWarnThreshold {
+		t.Errorf("expected warn threshold %v, got %v", defaultChannelWarnThreshold, mgr.config.ChannelWarnThreshold)
+	}
+	if mgr.config.ChannelCriticalThreshold != defaultChannelCriticalThreshold {
+		t.Errorf("expected critical threshold %v, got %v", defaultChannelCriticalThreshold, mgr.config.ChannelCriticalThreshold)
+	}
+	if mgr.config.UtilizationInterval != defaultUtilizationInterval {
+		t.Errorf("expected utilization interval %s, got %s", defaultUtilizationInterval, mgr.config.UtilizationInterval)
+	}
+	if mgr.config.MetricsID != "0" {
+		t.Errorf("expected metrics ID \"0\", got %q", mgr.config.MetricsID)
+	}
+}
+
+// TestManager_ReportUtilization tests that the gauge reflects the buffered/capacity ratio
+// of the message channel.
+func TestManager_ReportUtilization(t *testing.T) {
+	mgr := New(Config{Logger: zap.NewNop(), MessageBufferSize: 10, MetricsID: "report-test"})
+	gauge := MessageChannelUtilization.WithLabelValues("report-test")
+
+	tests := []struct {
+		buffered int
+		want     float64
+	}{
+		{buffered: 0, want: 0},
+		{buffered: 4, want: 0.4},
+		{buffered: 10, want: 1},
+	}
+
+	for _, tt := range tests {
+		for len(mgr.messageChan) < tt.buffered {
+			mgr.forward(&types.OrderbookMessage{EventType: "book"})
+		}
+
+		if got := mgr.reportUtilization(); got != tt.want {
+			t.Errorf("buffered %d: expected utilization %v, got %v", tt.buffered, tt.want, got)
+		}
+		if got := promtestutil.ToFloat64(gauge); got != tt.want {
+			t.Errorf("buffered %d: expected gauge %v, got %v", tt.buffered, tt.want, got)
+		}
+	}
+
+	// Reset when the manager closes
+	_ = mgr.Close()
+	if got := promtestutil.ToFloat64(gauge); got != 0 {
+		t.Errorf("expected gauge reset on close, got %v", got)
+	}
+}
+
+// TestManager_UtilizationLoop tests that utilization is sampled on the interval while no
+// messages are being forwarded.
+func TestManager_UtilizationLoop(t *testing.T) {
+	mgr := New(Config{
+		Logger:              zap.NewNop(),
+		MessageBufferSize:   4,
+		UtilizationInterval: 10 * time.Millisecond,
+		MetricsID:           "loop-test",
+	})
+	gauge := MessageChannelUtilization.WithLabelValues("loop-test")
+
+	mgr.wg.Add(1)
+	go mgr.utilizationLoop()
+	defer func() {
+		mgr.cancel()
+		mgr.wg.Wait()
+	}()
+
+	waitForGauge := func(want float64) {
+		t.Helper()
+		deadline := time.Now().Add(time.Second)
+		for time.Now().Before(deadline) {
+			if promtestutil.ToFloat64(gauge) == want {
+				return
+			}
+			time.Sleep(5 * time.Millisecond)
+		}
+		t.Fatalf("expected gauge %v, got %v", want, promtestutil.ToFloat64(gauge))
+	}
+
+	// Buffered directly, bypassing forward
+	mgr.messageChan <- &types.OrderbookMessage{}
+	mgr.messageChan <- &types.OrderbookMessage{}
+	waitForGauge(0.5)
+
+	<-mgr.messageChan
+	<-mgr.messageChan
+	waitForGauge(0)
+}
+
+// TestManager_ForwardThresholdLogs tests that forwarding logs a warning above the warn
+// threshold and an error above the critical threshold.
+func TestManager_ForwardThresholdLogs(t *testing.T) {
+	core, logs := observer.New(zapcore.WarnLevel)
+	mgr := New(Config{
+		Logger:                   zap.New(core),
+		MessageBufferSize:        10,
+		ChannelWarnThreshold:     0.3,
+		ChannelCriticalThreshold: 0.6,
+	})
+
+	tests := []struct {
+		buffered  int
+		wantLevel zapcore.Level
+		wantMsg   string
+	}{
+		{buffered: 3},
+		{buffered: 4, wantLevel: zapcore.WarnLevel, wantMsg: "websocket-message-channel-near-full"},
+		{buffered: 6, wantLevel: zapcore.WarnLevel, wantMsg: "websocket-message-channel-near-full"},
+		{buffered: 7, wantLevel: zapcore.ErrorLevel, wantMsg: "websocket-message-channel-critical"},
+	}
+
+	for _, tt := range tests {
+		for len(mgr.messageChan) < tt.buffered-1 {
+			mgr.forward(&types.OrderbookMessage{EventType: "book"})
+		}
+		logs.TakeAll()
+
+		mgr.forward(&types.OrderbookMessage{EventType: "book"})
+
+		entries := logs.TakeAll()
+		if tt.wantMsg == "" {
+			if len(entries) != 0 {
+				t.Errorf("buffered %d: expected no log, got %q", tt.buffered, entries[0].Message)
+			}
+			continue
+		}
+		if len(entries) != 1 || entries[0].Message != tt.wantMsg || entries[0].Level != tt.wantLevel {
+			t.Errorf("buffered %d: expected %s %q, got %v", tt.buffered, tt.wantLevel, tt.wantMsg, entries)
+		}
+	}
+}
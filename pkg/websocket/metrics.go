@@ -24,6 +24,21 @@ var (
 		Help: "Total number of WebSocket reconnection failures",
 	})
 
+	// ReconnectsTotal tracks completed reconnections by the reason the connection was lost.
+	ReconnectsTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "polymarket_ws_reconnects_total",
+			Help: "Total number of completed WebSocket reconnections, by disconnect reason (read_limit, closed, eof, timeout, network, resubscribe_failed)",
+		},
+		[]string{"reason"},
+	)
+
+	// ReconnectExhaustedTotal tracks reconnect loops that gave up after their attempt or downtime limit.
+	ReconnectExhaustedTotal = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "polymarket_ws_reconnect_exhausted_total",
+		Help: "Total number of times WebSocket reconnection gave up after reaching its attempt or downtime limit",
+	})
+
 	// MessagesReceivedTotal tracks messages received by type.
 	MessagesReceivedTotal = promauto.NewCounterVec(
 		prometheus.CounterOpts{
@@ -33,6 +48,13 @@ var (
 		[]string{"event_type"},
 	)
 
+	// MessageParseFallbacksTotal tracks frames whose format couldn't be determined from a
+	// peek, and were parsed by trying every known format instead.
+	MessageParseFallbacksTotal = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "polymarket_ws_message_parse_fallbacks_total",
+		Help: "Total number of WebSocket frames parsed by trying every format because their type could not be peeked",
+	})
+
 	// MessageLatencySeconds tracks message processing latency.
 	MessageLatencySeconds = promauto.NewHistogram(prometheus.HistogramOpts{
 		Name:    "polymarket_ws_message_latency_seconds",
@@ -55,6 +77,25 @@ var (
 		[]string{"reason"},
 	)
 
+	// MessageChannelUtilization tracks the fraction of each manager's message buffer in use,
+	// sampled periodically.
+	MessageChannelUtilization = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "polymarket_ws_message_channel_utilization",
+			Help: "Fraction of the WebSocket message channel buffer in use (0-1), per manager",
+		},
+		[]string{"manager"},
+	)
+
+	// ReadErrorsTotal tracks read errors that ended a connection, by class.
+	ReadErrorsTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "polymarket_ws_read_errors_total",
+			Help: "Total number of WebSocket read errors that dropped a connection (class: read_limit, closed, eof, timeout, network)",
+		},
+		[]string{"class"},
+	)
+
 	// ConnectionDuration tracks WebSocket connection lifetime.
 	ConnectionDuration = promauto.NewHistogram(prometheus.HistogramOpts{
 		Name:    "polymarket_ws_connection_duration_seconds",
@@ -68,6 +109,12 @@ var (
 		Help: "Total number of market unsubscriptions",
 	})
 
+	// UserChannelConnected tracks the authenticated user channel connection.
+	UserChannelConnected = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "polymarket_ws_user_channel_connected",
+		Help: "Whether the authenticated user channel connection is established (0 or 1)",
+	})
+
 	// ==============================
 	// Pool-specific metrics
 	// ==============================
@@ -91,4 +138,22 @@ var (
 		Help:    "Latency added by message multiplexing in pool",
 		Buckets: prometheus.ExponentialBuckets(0.000001, 2, 20),
 	})
+
+	// RecordedMessagesTotal tracks raw messages written to the recording file.
+	RecordedMessagesTotal = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "polymarket_ws_recorded_messages_total",
+		Help: "Total number of raw WebSocket messages written to recording files",
+	})
+
+	// RecordDroppedTotal tracks raw messages skipped because the recorder queue was full.
+	RecordDroppedTotal = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "polymarket_ws_record_dropped_total",
+		Help: "Total number of raw WebSocket messages dropped from recording (queue full)",
+	})
+
+	// RecordErrorsTotal tracks recording file I/O errors.
+	RecordErrorsTotal = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "polymarket_ws_record_errors_total",
+		Help: "Total number of WebSocket recording I/O errors",
+	})
 )
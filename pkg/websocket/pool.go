@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"hash/crc32"
 	"reflect"
+	"strconv"
 	"sync"
 	"time"
 
@@ -22,9 +23,22 @@ type PoolConfig struct {
 	ReconnectInitialDelay time.Duration    // Initial reconnect delay
 	ReconnectMaxDelay     time.Duration    // Max reconnect delay
 	ReconnectBackoffMult  float64          // Reconnect backoff multiplier
+	ReconnectMaxAttempts  int              // Failed reconnects before a manager gives up (0 = retry forever)
+	ReconnectMaxDowntime  time.Duration    // Downtime before a manager gives up (0 = retry forever)
 	MessageBufferSize     int              // Per-connection buffer size
+	ResubscribeBatchSize  int              // Max tokens per resubscribe frame after reconnect
+	ResubscribeBatchDelay time.Duration    // Delay between resubscribe frames
+	MaxMessageSize        int64            // Per-message read limit in bytes (0 = default 10MB)
 	Logger                *zap.Logger
 	MetadataUpdater       MetadataUpdater  // optional: for updating metadata cache on tick_size_change
+	RecordPath            string           // optional: record raw messages from all connections to rotating files
+	RecordCompress        bool             // Gzip recording files
+	RecordMaxFileSize     int64            // Rotate recording files after this many uncompressed bytes
+
+	// Per-connection message channel utilization (0 = manager defaults)
+	ChannelWarnThreshold     float64       // Utilization above which forwarding logs a warning
+	ChannelCriticalThreshold float64       // Utilization above which forwarding logs an error
+	UtilizationInterval      time.Duration // How often utilization is sampled
 }
 
 // Pool manages multiple WebSocket connections for load distribution.
@@ -39,6 +53,7 @@ type Pool struct {
 	cancel             context.CancelFunc
 	wg                 sync.WaitGroup
 	logger             *zap.Logger
+	recorder           *Recorder // Shared by all managers; nil when recording is disabled
 }
 
 // NewPool creates a new WebSocket connection pool.
@@ -58,6 +73,16 @@ func NewPool(cfg PoolConfig) *Pool {
 		logger:       cfg.Logger,
 	}
 
+	// One recorder for the whole pool so all connections land in the same files
+	if cfg.RecordPath != "" {
+		pool.recorder = NewRecorder(RecorderConfig{
+			Path:        cfg.RecordPath,
+			Compress:    cfg.RecordCompress,
+			MaxFileSize: cfg.RecordMaxFileSize,
+			Logger:      cfg.Logger,
+		})
+	}
+
 	// Create manager instances
 	for i := range cfg.Size {
 		managerCfg := Config{
@@ -68,9 +93,21 @@ func NewPool(cfg PoolConfig) *Pool {
 			ReconnectInitialDelay: cfg.ReconnectInitialDelay,
 			ReconnectMaxDelay:     cfg.ReconnectMaxDelay,
 			ReconnectBackoffMult:  cfg.ReconnectBackoffMult,
+			ReconnectMaxAttempts:  cfg.ReconnectMaxAttempts,
+			ReconnectMaxDowntime:  cfg.ReconnectMaxDowntime,
 			MessageBufferSize:     cfg.MessageBufferSize,
+			ResubscribeBatchSize:  cfg.ResubscribeBatchSize,
+			ResubscribeBatchDelay: cfg.ResubscribeBatchDelay,
+			MaxMessageSize:        cfg.MaxMessageSize,
 			Logger:                cfg.Logger.With(zap.Int("manager-id", i)),
 			MetadataUpdater:       cfg.MetadataUpdater,
+			Recorder:              pool.recorder,
+
+			// Utilization is reported per manager, labeled by index
+			ChannelWarnThreshold:     cfg.ChannelWarnThreshold,
+			ChannelCriticalThreshold: cfg.ChannelCriticalThreshold,
+			UtilizationInterval:      cfg.UtilizationInterval,
+			MetricsID:                strconv.Itoa(i),
 		}
 
 		pool.managers[i] = New(managerCfg)
@@ -279,6 +316,55 @@ func (p *Pool) Unsubscribe(ctx context.Context, tokenIDs []string) error {
 	return nil
 }
 
+// IsSubscribed reports whether tokenID is subscribed on any manager.
+func (p *Pool) IsSubscribed(tokenID string) bool {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	_, exists := p.tokenToIndex[tokenID]
+	return exists
+}
+
+// ConnectedCount returns the number of managers with an established connection.
+func (p *Pool) ConnectedCount() int {
+	count := 0
+	for _, mgr := range p.managers {
+		if mgr.IsConnected() {
+			count++
+		}
+	}
+	return count
+}
+
+// IsConnected reports whether every manager in the pool is connected.
+// A single disconnected manager leaves its shard of tokens without updates.
+func (p *Pool) IsConnected() bool {
+	return p.ConnectedCount() == len(p.managers)
+}
+
+// FatalError returns the first error from a manager that gave up reconnecting, or nil.
+// Its shard of tokens gets no further updates until restart.
+func (p *Pool) FatalError() error {
+	for i, mgr := range p.managers {
+		if err := mgr.FatalError(); err != nil {
+			return fmt.Errorf("manager %d: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// LastDisconnect returns the most recent connection loss across all managers. The reason
+// is empty and the time zero if no manager has dropped its connection.
+func (p *Pool) LastDisconnect() (reason string, at time.Time) {
+	for _, mgr := range p.managers {
+		mgrReason, mgrAt := mgr.LastDisconnect()
+		if mgrAt.After(at) {
+			reason, at = mgrReason, mgrAt
+		}
+	}
+	return reason, at
+}
+
 // MessageChan returns the multiplexed message channel receiving from all managers.
 func (p *Pool) MessageChan() <-chan *types.OrderbookMessage {
 	return p.messageChan
@@ -315,6 +401,11 @@ func (p *Pool) Close() error {
 	// Close multiplexed message channel
 	close(p.messageChan)
 
+	// Managers are stopped, so no more frames can be recorded
+	if p.recorder != nil {
+		_ = p.recorder.Close()
+	}
+
 	// Update pool metrics
 	PoolActiveConnections.Set(0)
 
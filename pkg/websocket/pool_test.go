@@ -401,3 +401,26 @@ func TestPool_DuplicateSubscription(t *testing.T) {
 		t.Errorf("expected duplicate subscriptions to be ignored, got %d subscriptions (expected %d)", totalSubs, initialCount)
 	}
 }
+
+func TestPool_LastDisconnect(t *testing.T) {
+	pool := NewPool(PoolConfig{
+		Size:              3,
+		WSUrl:             "wss://example.com/ws",
+		MessageBufferSize: 100,
+		Logger:            zap.NewNop(),
+	})
+
+	if reason, at := pool.LastDisconnect(); reason != "" || !at.IsZero() {
+		t.Errorf("expected no disconnect, got %q at %v", reason, at)
+	}
+
+	// The most recent disconnect across managers wins
+	now := time.Now()
+	pool.managers[0].lastDisconnect.Store(&disconnect{reason: readErrorTimeout, at: now.Add(-time.Minute)})
+	pool.managers[2].lastDisconnect.Store(&disconnect{reason: readErrorEOF, at: now})
+
+	reason, at := pool.LastDisconnect()
+	if reason != readErrorEOF || !at.Equal(now) {
+		t.Errorf("expected %q at %v, got %q at %v", readErrorEOF, now, reason, at)
+	}
+}
@@ -2,6 +2,8 @@ package websocket
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"math/rand"
 	"sync"
 	"time"
@@ -9,12 +11,23 @@ import (
 	"go.uber.org/zap"
 )
 
+// ErrReconnectExhausted is returned by Reconnect when MaxAttempts or MaxDowntime is reached.
+var ErrReconnectExhausted = errors.New("reconnection attempts exhausted")
+
 // ReconnectConfig holds the configuration for exponential backoff reconnection.
 type ReconnectConfig struct {
 	InitialDelay      time.Duration
 	MaxDelay          time.Duration
 	BackoffMultiplier float64
 	JitterPercent     float64 // 0.2 = 20%
+
+	// Reconnect gives up after MaxAttempts failed attempts or once MaxDowntime has passed
+	// since it was called, whichever comes first (0 = retry forever)
+	MaxAttempts int
+	MaxDowntime time.Duration
+
+	// OnExhausted is called with the terminal error when Reconnect gives up (optional)
+	OnExhausted func(err error)
 }
 
 // ReconnectManager handles exponential backoff reconnection with jitter.
@@ -35,7 +48,12 @@ func NewReconnectManager(cfg ReconnectConfig, logger *zap.Logger) *ReconnectMana
 }
 
 // Reconnect attempts to reconnect using the provided connect function with exponential backoff.
+// Retries until connected or ctx ends, unless MaxAttempts or MaxDowntime is set, in which case
+// it calls OnExhausted and returns an ErrReconnectExhausted error once either is reached.
 func (rm *ReconnectManager) Reconnect(ctx context.Context, connectFunc func(context.Context) error) error {
+	start := time.Now()
+	attempts := 0
+
 	for {
 		select {
 		case <-ctx.Done():
@@ -71,12 +89,45 @@ func (rm *ReconnectManager) Reconnect(ctx context.Context, connectFunc func(cont
 		// Connection failed
 		rm.logger.Warn("reconnection-failed", zap.Error(err))
 		ReconnectFailuresTotal.Inc()
+		attempts++
+
+		if rm.exhausted(attempts, time.Since(start)) {
+			return rm.giveUp(attempts, time.Since(start), err)
+		}
 
 		// Increment backoff for next attempt
 		rm.incrementBackoff()
 	}
 }
 
+// exhausted reports whether the attempt or downtime limit has been reached.
+func (rm *ReconnectManager) exhausted(attempts int, downtime time.Duration) bool {
+	if rm.config.MaxAttempts > 0 && attempts >= rm.config.MaxAttempts {
+		return true
+	}
+	return rm.config.MaxDowntime > 0 && downtime >= rm.config.MaxDowntime
+}
+
+// giveUp reports the terminal failure and returns the error wrapping ErrReconnectExhausted.
+func (rm *ReconnectManager) giveUp(attempts int, downtime time.Duration, lastErr error) error {
+	err := fmt.Errorf("%w: %d attempts over %s, last error: %w",
+		ErrReconnectExhausted, attempts, downtime.Round(time.Millisecond), lastErr)
+
+	rm.logger.Error("reconnection-exhausted",
+		zap.Int("attempts", attempts),
+		zap.Duration("downtime", downtime),
+		zap.Int("max-attempts", rm.config.MaxAttempts),
+		zap.Duration("max-downtime", rm.config.MaxDowntime),
+		zap.Error(lastErr))
+	ReconnectExhaustedTotal.Inc()
+
+	if rm.config.OnExhausted != nil {
+		rm.config.OnExhausted(err)
+	}
+
+	return err
+}
+
 // Reset resets the backoff to the initial delay.
 func (rm *ReconnectManager) Reset() {
 	rm.mu.Lock()
@@ -506,3 +506,97 @@ func TestReconnectLoop_SubscriptionRace(t *testing.T) {
 	}
 	mgr.mu.RUnlock()
 }
+
+// TestReconnect_MaxAttempts_CallsOnExhausted tests that Reconnect gives up after MaxAttempts
+// failures and fires the terminal callback once.
+func TestReconnect_MaxAttempts_CallsOnExhausted(t *testing.T) {
+	var exhaustedErrs []error
+	rm := NewReconnectManager(ReconnectConfig{
+		InitialDelay:      time.Millisecond,
+		MaxDelay:          5 * time.Millisecond,
+		BackoffMultiplier: 2.0,
+		MaxAttempts:       3,
+		OnExhausted:       func(err error) { exhaustedErrs = append(exhaustedErrs, err) },
+	}, zap.NewNop())
+
+	dialErr := errors.New("connection refused")
+	attempts := 0
+	err := rm.Reconnect(context.Background(), func(_ context.Context) error {
+		attempts++
+		return dialErr
+	})
+
+	if !errors.Is(err, ErrReconnectExhausted) {
+		t.Fatalf("expected ErrReconnectExhausted, got %v", err)
+	}
+	if !errors.Is(err, dialErr) {
+		t.Errorf("expected error to wrap the last connect error, got %v", err)
+	}
+	if attempts != 3 {
+		t.Errorf("expected 3 attempts, got %d", attempts)
+	}
+	if len(exhaustedErrs) != 1 || !errors.Is(exhaustedErrs[0], ErrReconnectExhausted) {
+		t.Errorf("expected OnExhausted called once with the terminal error, got %v", exhaustedErrs)
+	}
+}
+
+// TestReconnect_MaxDowntime tests that Reconnect gives up once MaxDowntime has passed.
+func TestReconnect_MaxDowntime(t *testing.T) {
+	exhausted := 0
+	rm := NewReconnectManager(ReconnectConfig{
+		InitialDelay:      10 * time.Millisecond,
+		MaxDelay:          10 * time.Millisecond,
+		BackoffMultiplier: 1.0,
+		MaxDowntime:       50 * time.Millisecond,
+		OnExhausted:       func(error) { exhausted++ },
+	}, zap.NewNop())
+
+	start := time.Now()
+	err := rm.Reconnect(context.Background(), func(_ context.Context) error {
+		return errors.New("connection refused")
+	})
+
+	if !errors.Is(err, ErrReconnectExhausted) {
+		t.Fatalf("expected ErrReconnectExhausted, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed < 50*time.Millisecond || elapsed > 2*time.Second {
+		t.Errorf("expected to give up shortly after 50ms, took %v", elapsed)
+	}
+	if exhausted != 1 {
+		t.Errorf("expected OnExhausted called once, got %d", exhausted)
+	}
+}
+
+// TestReconnect_UnlimitedByDefault tests that without limits Reconnect retries until
+// the context ends and never fires the terminal callback.
+func TestReconnect_UnlimitedByDefault(t *testing.T) {
+	exhausted := 0
+	rm := NewReconnectManager(ReconnectConfig{
+		InitialDelay:      time.Millisecond,
+		MaxDelay:          time.Millisecond,
+		BackoffMultiplier: 1.0,
+		OnExhausted:       func(error) { exhausted++ },
+	}, zap.NewNop())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	attempts := 0
+	err := rm.Reconnect(ctx, func(_ context.Context) error {
+		attempts++
+		if attempts == 20 {
+			cancel()
+		}
+		return errors.New("connection refused")
+	})
+
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+	if attempts != 20 {
+		t.Errorf("expected 20 attempts, got %d", attempts)
+	}
+	if exhausted != 0 {
+		t.Errorf("expected no OnExhausted call, got %d", exhausted)
+	}
+}
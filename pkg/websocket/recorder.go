@@ -0,0 +1,281 @@
+package websocket
+
+import (
+	"bufio"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	json "github.com/goccy/go-json"
+	"go.uber.org/zap"
+)
+
+const (
+	defaultRecordBufferSize  = 10000
+	defaultRecordMaxFileSize = 100 * 1024 * 1024 // 100MB
+	recordFlushInterval      = time.Second
+)
+
+// RecordedMessage is one line of a recording file.
+type RecordedMessage struct {
+	ReceivedAt time.Time       `json:"received_at"`
+	Data       json.RawMessage `json:"data"` // Raw WebSocket frame (JSON-encoded string if the frame wasn't JSON)
+}
+
+// RecorderConfig holds raw message recorder configuration.
+type RecorderConfig struct {
+	// Path is the base file path, e.g. "recordings/ws.ndjson". Each rotated file
+	// gets a timestamp and sequence number inserted before the extension.
+	Path string
+
+	// Compress gzips each file (".gz" is appended to the file name).
+	Compress bool
+
+	// MaxFileSize is the uncompressed byte count after which the file is rotated (default: 100MB).
+	MaxFileSize int64
+
+	// BufferSize is the number of messages queued for the writer (default: 10000).
+	// Messages are dropped from the recording, never from the read loop, when full.
+	BufferSize int
+
+	Logger *zap.Logger
+}
+
+// Recorder asynchronously writes raw WebSocket messages to rotating NDJSON files.
+type Recorder struct {
+	cfg    RecorderConfig
+	logger *zap.Logger
+	queue  chan RecordedMessage
+	mu     sync.RWMutex // Guards closed against concurrent Record/Close
+	closed bool
+	wg     sync.WaitGroup
+
+	// Writer goroutine state
+	file    *os.File
+	gz      *gzip.Writer
+	buf     *bufio.Writer
+	written int64
+	seq     int
+}
+
+// NewRecorder creates a recorder and starts its writer goroutine.
+// Files are opened lazily on the first message.
+func NewRecorder(cfg RecorderConfig) *Recorder {
+	if cfg.MaxFileSize <= 0 {
+		cfg.MaxFileSize = defaultRecordMaxFileSize
+	}
+	if cfg.BufferSize <= 0 {
+		cfg.BufferSize = defaultRecordBufferSize
+	}
+
+	r := &Recorder{
+		cfg:    cfg,
+		logger: cfg.Logger,
+		queue:  make(chan RecordedMessage, cfg.BufferSize),
+	}
+
+	r.wg.Add(1)
+	go r.writeLoop()
+
+	return r
+}
+
+// Record queues a raw message for writing without blocking.
+// The recorder retains data, so callers must not modify it afterwards.
+func (r *Recorder) Record(data []byte, receivedAt time.Time) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if r.closed {
+		return
+	}
+
+	select {
+	case r.queue <- RecordedMessage{ReceivedAt: receivedAt, Data: data}:
+	default:
+		RecordDroppedTotal.Inc()
+	}
+}
+
+// writeLoop drains the queue to disk, flushing periodically.
+func (r *Recorder) writeLoop() {
+	defer r.wg.Done()
+
+	ticker := time.NewTicker(recordFlushInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case msg, ok := <-r.queue:
+			if !ok {
+				r.closeFile()
+				return
+			}
+
+			err := r.write(msg)
+			if err != nil {
+				RecordErrorsTotal.Inc()
+				r.logger.Error("record-write-failed", zap.Error(err))
+			}
+		case <-ticker.C:
+			err := r.flush()
+			if err != nil {
+				RecordErrorsTotal.Inc()
+				r.logger.Error("record-flush-failed", zap.Error(err))
+			}
+		}
+	}
+}
+
+// write appends one message, rotating the file when it exceeds MaxFileSize.
+func (r *Recorder) write(msg RecordedMessage) error {
+	if !json.Valid(msg.Data) {
+		// Non-JSON frames (e.g. text errors) are stored as strings so each line stays valid JSON
+		quoted, err := json.Marshal(string(msg.Data))
+		if err != nil {
+			return fmt.Errorf("quote message: %w", err)
+		}
+		msg.Data = quoted
+	}
+
+	line, err := json.Marshal(msg)
+	if err != nil {
+		return fmt.Errorf("marshal message: %w", err)
+	}
+	line = append(line, '\n')
+
+	if r.buf == nil || (r.written > 0 && r.written+int64(len(line)) > r.cfg.MaxFileSize) {
+		err = r.rotate()
+		if err != nil {
+			return err
+		}
+	}
+
+	n, err := r.buf.Write(line)
+	r.written += int64(n)
+	if err != nil {
+		return fmt.Errorf("write message: %w", err)
+	}
+
+	RecordedMessagesTotal.Inc()
+
+	return nil
+}
+
+// rotate closes the current file (if any) and opens the next one.
+func (r *Recorder) rotate() error {
+	r.closeFile()
+
+	r.seq++
+	path := r.filePath(time.Now(), r.seq)
+
+	err := os.MkdirAll(filepath.Dir(path), 0o755)
+	if err != nil {
+		return fmt.Errorf("create record dir: %w", err)
+	}
+
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o644)
+	if err != nil {
+		return fmt.Errorf("open record file: %w", err)
+	}
+
+	var w io.Writer = file
+	if r.cfg.Compress {
+		r.gz = gzip.NewWriter(file)
+		w = r.gz
+	}
+
+	r.file = file
+	r.buf = bufio.NewWriterSize(w, 64*1024)
+	r.written = 0
+
+	r.logger.Info("record-file-opened", zap.String("path", path))
+
+	return nil
+}
+
+// filePath builds "<dir>/<name>-<timestamp>-<seq><ext>[.gz]" from the base path.
+func (r *Recorder) filePath(now time.Time, seq int) string {
+	ext := filepath.Ext(r.cfg.Path)
+	base := strings.TrimSuffix(r.cfg.Path, ext)
+
+	path := fmt.Sprintf("%s-%s-%04d%s", base, now.UTC().Format("20060102T150405Z"), seq, ext)
+	if r.cfg.Compress {
+		path += ".gz"
+	}
+
+	return path
+}
+
+// flush pushes buffered data through to the file.
+func (r *Recorder) flush() error {
+	if r.buf == nil {
+		return nil
+	}
+
+	err := r.buf.Flush()
+	if err != nil {
+		return fmt.Errorf("flush buffer: %w", err)
+	}
+
+	if r.gz != nil {
+		err = r.gz.Flush()
+		if err != nil {
+			return fmt.Errorf("flush gzip: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// closeFile flushes and closes the current file.
+func (r *Recorder) closeFile() {
+	if r.file == nil {
+		return
+	}
+
+	err := r.flush()
+	if err != nil {
+		RecordErrorsTotal.Inc()
+		r.logger.Error("record-flush-failed", zap.Error(err))
+	}
+
+	if r.gz != nil {
+		err = r.gz.Close()
+		if err != nil {
+			RecordErrorsTotal.Inc()
+			r.logger.Error("record-gzip-close-failed", zap.Error(err))
+		}
+	}
+
+	err = r.file.Close()
+	if err != nil {
+		RecordErrorsTotal.Inc()
+		r.logger.Error("record-file-close-failed", zap.Error(err))
+	}
+
+	r.file = nil
+	r.gz = nil
+	r.buf = nil
+}
+
+// Close stops accepting messages, writes everything queued, and closes the file.
+func (r *Recorder) Close() error {
+	r.mu.Lock()
+	if r.closed {
+		r.mu.Unlock()
+		return nil
+	}
+	r.closed = true
+	close(r.queue)
+	r.mu.Unlock()
+
+	r.wg.Wait()
+
+	return nil
+}
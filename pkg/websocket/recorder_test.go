@@ -0,0 +1,164 @@
+package websocket
+
+import (
+	"bufio"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	json "github.com/goccy/go-json"
+	"go.uber.org/zap"
+)
+
+// readRecordings reads every recording file under dir in rotation order.
+func readRecordings(t *testing.T, dir string) (files []string, messages []RecordedMessage) {
+	t.Helper()
+
+	files, err := filepath.Glob(filepath.Join(dir, "ws-*"))
+	if err != nil {
+		t.Fatalf("glob recordings: %v", err)
+	}
+	sort.Strings(files)
+
+	for _, path := range files {
+		f, err := os.Open(path)
+		if err != nil {
+			t.Fatalf("open %s: %v", path, err)
+		}
+
+		var r io.Reader = f
+		if strings.HasSuffix(path, ".gz") {
+			gz, err := gzip.NewReader(f)
+			if err != nil {
+				t.Fatalf("gzip reader %s: %v", path, err)
+			}
+			r = gz
+		}
+
+		scanner := bufio.NewScanner(r)
+		for scanner.Scan() {
+			var msg RecordedMessage
+			err = json.Unmarshal(scanner.Bytes(), &msg)
+			if err != nil {
+				t.Fatalf("unmarshal line in %s: %v", path, err)
+			}
+			messages = append(messages, msg)
+		}
+
+		if scanner.Err() != nil {
+			t.Fatalf("scan %s: %v", path, scanner.Err())
+		}
+		f.Close()
+	}
+
+	return files, messages
+}
+
+func TestRecorder_RoundTrip(t *testing.T) {
+	tests := []struct {
+		name        string
+		compress    bool
+		maxFileSize int64
+		minFiles    int
+	}{
+		{name: "plain-single-file", compress: false, maxFileSize: 0, minFiles: 1},
+		{name: "gzip-single-file", compress: true, maxFileSize: 0, minFiles: 1},
+		{name: "gzip-rotated", compress: true, maxFileSize: 1024, minFiles: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+
+			recorder := NewRecorder(RecorderConfig{
+				Path:        filepath.Join(dir, "ws.ndjson"),
+				Compress:    tt.compress,
+				MaxFileSize: tt.maxFileSize,
+				Logger:      zap.NewNop(),
+			})
+
+			const n = 100
+			base := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+			sent := make([]string, n)
+			for i := range n {
+				sent[i] = fmt.Sprintf(`[{"event_type":"book","asset_id":"token-%d","market":"m"}]`, i)
+				recorder.Record([]byte(sent[i]), base.Add(time.Duration(i)*time.Millisecond))
+			}
+
+			err := recorder.Close()
+			if err != nil {
+				t.Fatalf("close: %v", err)
+			}
+
+			files, messages := readRecordings(t, dir)
+			if len(files) < tt.minFiles {
+				t.Errorf("expected at least %d files, got %d", tt.minFiles, len(files))
+			}
+
+			if len(messages) != n {
+				t.Fatalf("expected %d messages, got %d", n, len(messages))
+			}
+
+			for i, msg := range messages {
+				if string(msg.Data) != sent[i] {
+					t.Errorf("message %d: expected %s, got %s", i, sent[i], msg.Data)
+				}
+
+				expected := base.Add(time.Duration(i) * time.Millisecond)
+				if !msg.ReceivedAt.Equal(expected) {
+					t.Errorf("message %d: expected received-at %v, got %v", i, expected, msg.ReceivedAt)
+				}
+			}
+		})
+	}
+}
+
+func TestRecorder_NonJSONFrame(t *testing.T) {
+	dir := t.TempDir()
+
+	recorder := NewRecorder(RecorderConfig{
+		Path:   filepath.Join(dir, "ws.ndjson"),
+		Logger: zap.NewNop(),
+	})
+
+	recorder.Record([]byte("INVALID OPERATION"), time.Now())
+	_ = recorder.Close()
+
+	_, messages := readRecordings(t, dir)
+	if len(messages) != 1 {
+		t.Fatalf("expected 1 message, got %d", len(messages))
+	}
+
+	var text string
+	err := json.Unmarshal(messages[0].Data, &text)
+	if err != nil {
+		t.Fatalf("expected data to be a JSON string: %v", err)
+	}
+
+	if text != "INVALID OPERATION" {
+		t.Errorf("expected %q, got %q", "INVALID OPERATION", text)
+	}
+}
+
+func TestRecorder_RecordAfterClose(t *testing.T) {
+	recorder := NewRecorder(RecorderConfig{
+		Path:   filepath.Join(t.TempDir(), "ws.ndjson"),
+		Logger: zap.NewNop(),
+	})
+
+	_ = recorder.Close()
+
+	// Must not panic or block
+	recorder.Record([]byte(`{}`), time.Now())
+
+	err := recorder.Close()
+	if err != nil {
+		t.Errorf("expected second close to succeed, got %v", err)
+	}
+}
@@ -0,0 +1,309 @@
+package websocket
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	json "github.com/goccy/go-json"
+	"github.com/gorilla/websocket"
+	"github.com/mselser95/polymarket-arb/pkg/types"
+	"go.uber.org/zap"
+)
+
+// UserManager maintains an authenticated connection to the Polymarket "user" channel,
+// which pushes updates for our own orders and trades.
+type UserManager struct {
+	url          string
+	conn         *websocket.Conn
+	logger       *zap.Logger
+	reconnectMgr *ReconnectManager
+	config       UserConfig
+	updateChan   chan *types.UserUpdate
+	ctx          context.Context
+	cancel       context.CancelFunc
+	wg           sync.WaitGroup
+	mu           sync.RWMutex
+	connected    atomic.Bool
+}
+
+// UserConfig holds user channel configuration.
+type UserConfig struct {
+	URL                   string // e.g. wss://ws-subscriptions-clob.polymarket.com/ws/user
+	APIKey                string
+	Secret                string
+	Passphrase            string
+	Markets               []string // Condition IDs to receive updates for (empty = all)
+	DialTimeout           time.Duration
+	PingInterval          time.Duration
+	ReconnectInitialDelay time.Duration
+	ReconnectMaxDelay     time.Duration
+	ReconnectBackoffMult  float64
+	MessageBufferSize     int
+	Logger                *zap.Logger
+}
+
+// NewUserManager creates a new user channel manager.
+func NewUserManager(cfg UserConfig) *UserManager {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	reconnectCfg := ReconnectConfig{
+		InitialDelay:      cfg.ReconnectInitialDelay,
+		MaxDelay:          cfg.ReconnectMaxDelay,
+		BackoffMultiplier: cfg.ReconnectBackoffMult,
+		JitterPercent:     0.2,
+	}
+
+	return &UserManager{
+		url:          cfg.URL,
+		logger:       cfg.Logger,
+		reconnectMgr: NewReconnectManager(reconnectCfg, cfg.Logger),
+		config:       cfg,
+		updateChan:   make(chan *types.UserUpdate, cfg.MessageBufferSize),
+		ctx:          ctx,
+		cancel:       cancel,
+	}
+}
+
+// Start connects, sends the authenticated subscription and starts reading updates.
+func (u *UserManager) Start() error {
+	u.logger.Info("user-channel-starting", zap.String("url", u.url))
+
+	err := u.connect(u.ctx)
+	if err != nil {
+		return fmt.Errorf("initial connection: %w", err)
+	}
+
+	u.wg.Add(3)
+	go u.readLoop()
+	go u.pingLoop()
+	go u.reconnectLoop()
+
+	return nil
+}
+
+// connect dials the user channel and subscribes with our API credentials.
+// The subscription is sent on every (re)connect since auth is per connection.
+func (u *UserManager) connect(ctx context.Context) error {
+	dialer := websocket.Dialer{HandshakeTimeout: u.config.DialTimeout}
+
+	conn, _, err := dialer.DialContext(ctx, u.url, nil)
+	if err != nil {
+		return fmt.Errorf("dial: %w", err)
+	}
+
+	markets := u.config.Markets
+	if markets == nil {
+		markets = []string{}
+	}
+
+	subscribeMsg := types.UserSubscribeMessage{
+		Auth: types.UserAuth{
+			APIKey:     u.config.APIKey,
+			Secret:     u.config.Secret,
+			Passphrase: u.config.Passphrase,
+		},
+		Markets: markets,
+		Type:    "user",
+	}
+
+	err = conn.WriteJSON(subscribeMsg)
+	if err != nil {
+		conn.Close()
+		return fmt.Errorf("write user subscription: %w", err)
+	}
+
+	u.mu.Lock()
+	u.conn = conn
+	u.mu.Unlock()
+
+	u.connected.Store(true)
+	UserChannelConnected.Set(1)
+
+	u.logger.Info("user-channel-connected", zap.Int("market-count", len(markets)))
+
+	return nil
+}
+
+// readLoop reads and parses user channel messages until the connection drops.
+func (u *UserManager) readLoop() {
+	defer u.wg.Done()
+
+	u.mu.RLock()
+	conn := u.conn
+	u.mu.RUnlock()
+
+	for {
+		_, message, err := conn.ReadMessage()
+		if err != nil {
+			if u.ctx.Err() == nil {
+				u.logger.Warn("user-channel-read-error", zap.Error(err))
+			}
+			u.connected.Store(false)
+			UserChannelConnected.Set(0)
+			return
+		}
+
+		updates, err := parseUserMessage(message, time.Now())
+		if err != nil {
+			u.logger.Warn("user-channel-unparseable-message",
+				zap.Error(err),
+				zap.String("full-message", string(message)))
+			continue
+		}
+
+		for _, update := range updates {
+			MessagesReceivedTotal.WithLabelValues("user_" + update.EventType).Inc()
+
+			select {
+			case u.updateChan <- update:
+			default:
+				u.logger.Error("user-update-channel-full-dropping",
+					zap.String("event-type", update.EventType))
+				MessagesDroppedTotal.WithLabelValues("user_channel_full").Inc()
+			}
+		}
+	}
+}
+
+// parseUserMessage decodes a user channel frame, which is either a single event or an
+// array of events. Heartbeats and event types other than order and trade yield no updates.
+func parseUserMessage(message []byte, receivedAt time.Time) ([]*types.UserUpdate, error) {
+	if len(message) == 0 || string(message) == "[]" {
+		return nil, nil
+	}
+
+	var raws []json.RawMessage
+	if message[0] == '[' {
+		err := json.Unmarshal(message, &raws)
+		if err != nil {
+			return nil, fmt.Errorf("decode event array: %w", err)
+		}
+	} else {
+		raws = []json.RawMessage{message}
+	}
+
+	updates := make([]*types.UserUpdate, 0, len(raws))
+	for _, raw := range raws {
+		var envelope struct {
+			EventType string `json:"event_type"`
+		}
+		err := json.Unmarshal(raw, &envelope)
+		if err != nil {
+			return nil, fmt.Errorf("decode event: %w", err)
+		}
+
+		update := &types.UserUpdate{EventType: envelope.EventType, ReceivedAt: receivedAt}
+
+		switch envelope.EventType {
+		case types.UserEventOrder:
+			update.Order = &types.UserOrderMessage{}
+			err = json.Unmarshal(raw, update.Order)
+		case types.UserEventTrade:
+			update.Trade = &types.UserTradeMessage{}
+			err = json.Unmarshal(raw, update.Trade)
+		default:
+			continue
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("decode %s event: %w", envelope.EventType, err)
+		}
+
+		updates = append(updates, update)
+	}
+
+	return updates, nil
+}
+
+// pingLoop sends periodic PING control frames.
+func (u *UserManager) pingLoop() {
+	defer u.wg.Done()
+
+	ticker := time.NewTicker(u.config.PingInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-u.ctx.Done():
+			return
+		case <-ticker.C:
+			if !u.connected.Load() {
+				continue
+			}
+
+			u.mu.RLock()
+			conn := u.conn
+			u.mu.RUnlock()
+
+			err := conn.WriteControl(websocket.PingMessage, []byte{}, time.Now().Add(time.Second))
+			if err != nil {
+				u.logger.Warn("user-channel-ping-error", zap.Error(err))
+			}
+		}
+	}
+}
+
+// reconnectLoop reconnects and re-authenticates when the connection drops.
+func (u *UserManager) reconnectLoop() {
+	defer u.wg.Done()
+
+	for {
+		select {
+		case <-u.ctx.Done():
+			return
+		default:
+		}
+
+		if u.connected.Load() {
+			time.Sleep(100 * time.Millisecond)
+			continue
+		}
+
+		u.logger.Warn("user-channel-connection-lost-reconnecting")
+
+		err := u.reconnectMgr.Reconnect(u.ctx, u.connect)
+		if err != nil {
+			if err == context.Canceled {
+				return
+			}
+			u.logger.Error("user-channel-reconnection-failed", zap.Error(err))
+			continue
+		}
+
+		u.wg.Add(1)
+		go u.readLoop()
+	}
+}
+
+// IsConnected reports whether the user channel connection is currently established.
+func (u *UserManager) IsConnected() bool {
+	return u.connected.Load()
+}
+
+// Updates returns the channel of order and trade updates for our own orders.
+func (u *UserManager) Updates() <-chan *types.UserUpdate {
+	return u.updateChan
+}
+
+// Close closes the connection and the updates channel.
+func (u *UserManager) Close() error {
+	u.logger.Info("closing-user-channel")
+
+	u.cancel()
+
+	u.mu.RLock()
+	if u.conn != nil {
+		u.conn.Close()
+	}
+	u.mu.RUnlock()
+
+	u.wg.Wait()
+
+	close(u.updateChan)
+	UserChannelConnected.Set(0)
+
+	return nil
+}
@@ -0,0 +1,232 @@
+package websocket
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/mselser95/polymarket-arb/pkg/types"
+	"go.uber.org/zap"
+)
+
+const (
+	testUserOrderMsg = `{"event_type":"order","id":"0xorder1","owner":"test-api-key","market":"0xmarket",
+		"asset_id":"token1","side":"BUY","outcome":"Yes","price":"0.48","original_size":"10",
+		"size_matched":"4","associate_trades":["trade-1"],"type":"UPDATE","timestamp":"1700000000"}`
+
+	testUserTradeMsg = `{"event_type":"trade","id":"trade-1","market":"0xmarket","asset_id":"token1",
+		"side":"BUY","outcome":"Yes","price":"0.48","size":"4","status":"MATCHED","trader_side":"TAKER",
+		"taker_order_id":"0xorder1","maker_orders":[{"order_id":"0xmaker","owner":"other",
+		"asset_id":"token1","outcome":"Yes","price":"0.48","matched_amount":"4"}],
+		"matchtime":"1700000000","last_update":"1700000000","timestamp":"1700000000"}`
+)
+
+// mockUserChannel accepts connections that authenticate with the test credentials,
+// then pushes messages. Connections with bad credentials are closed.
+func mockUserChannel(t *testing.T, messages []string, connections *atomic.Int32) *httptest.Server {
+	t.Helper()
+
+	upgrader := websocket.Upgrader{}
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		var sub types.UserSubscribeMessage
+		err = conn.ReadJSON(&sub)
+		if err != nil || sub.Type != "user" || sub.Auth.APIKey != "test-api-key" ||
+			sub.Auth.Secret != "test-secret" || sub.Auth.Passphrase != "test-passphrase" {
+			_ = conn.WriteMessage(websocket.TextMessage, []byte(`{"error":"unauthorized"}`))
+			return
+		}
+		connections.Add(1)
+
+		for _, msg := range messages {
+			err = conn.WriteMessage(websocket.TextMessage, []byte(msg))
+			if err != nil {
+				return
+			}
+		}
+
+		// Hold the connection open until the client closes it
+		for {
+			_, _, err = conn.ReadMessage()
+			if err != nil {
+				return
+			}
+		}
+	}))
+}
+
+func newTestUserManager(url string) *UserManager {
+	return NewUserManager(UserConfig{
+		URL:                   "ws" + strings.TrimPrefix(url, "http"),
+		APIKey:                "test-api-key",
+		Secret:                "test-secret",
+		Passphrase:            "test-passphrase",
+		Markets:               []string{"0xmarket"},
+		DialTimeout:           time.Second,
+		PingInterval:          time.Second,
+		ReconnectInitialDelay: 10 * time.Millisecond,
+		ReconnectMaxDelay:     50 * time.Millisecond,
+		ReconnectBackoffMult:  2.0,
+		MessageBufferSize:     10,
+		Logger:                zap.NewNop(),
+	})
+}
+
+func receiveUpdate(t *testing.T, um *UserManager) *types.UserUpdate {
+	t.Helper()
+
+	select {
+	case update := <-um.Updates():
+		return update
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for user update")
+		return nil
+	}
+}
+
+// TestUserManager_AuthenticatesAndSurfacesUpdates tests the auth handshake and that
+// pushed order and trade messages reach the updates channel.
+func TestUserManager_AuthenticatesAndSurfacesUpdates(t *testing.T) {
+	var connections atomic.Int32
+	server := mockUserChannel(t, []string{testUserOrderMsg, "[]", "[" + testUserTradeMsg + "]"}, &connections)
+	defer server.Close()
+
+	um := newTestUserManager(server.URL)
+	err := um.Start()
+	if err != nil {
+		t.Fatalf("start: %v", err)
+	}
+	defer um.Close()
+
+	order := receiveUpdate(t, um)
+	if order.EventType != types.UserEventOrder || order.Order == nil {
+		t.Fatalf("expected order update, got %+v", order)
+	}
+	if order.Order.ID != "0xorder1" || order.Order.Type != types.UserOrderUpdate {
+		t.Errorf("unexpected order update: %+v", order.Order)
+	}
+
+	original, matched, err := order.Order.Sizes()
+	if err != nil || original != 10 || matched != 4 {
+		t.Errorf("expected sizes 10/4, got %f/%f (err: %v)", original, matched, err)
+	}
+
+	trade := receiveUpdate(t, um)
+	if trade.EventType != types.UserEventTrade || trade.Trade == nil {
+		t.Fatalf("expected trade update, got %+v", trade)
+	}
+	if trade.Trade.TakerOrderID != "0xorder1" || trade.Trade.Status != types.UserTradeMatched {
+		t.Errorf("unexpected trade update: %+v", trade.Trade)
+	}
+	if len(trade.Trade.MakerOrders) != 1 || trade.Trade.MakerOrders[0].MatchedAmount != "4" {
+		t.Errorf("unexpected maker orders: %+v", trade.Trade.MakerOrders)
+	}
+
+	if connections.Load() != 1 || !um.IsConnected() {
+		t.Errorf("expected one authenticated connection, got %d (connected=%v)", connections.Load(), um.IsConnected())
+	}
+}
+
+// TestUserManager_ReauthenticatesOnReconnect tests that the subscription is resent after
+// the server drops the connection.
+func TestUserManager_ReauthenticatesOnReconnect(t *testing.T) {
+	var connections atomic.Int32
+	upgrader := websocket.Upgrader{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		var sub types.UserSubscribeMessage
+		if conn.ReadJSON(&sub) != nil || sub.Auth.APIKey != "test-api-key" {
+			return
+		}
+
+		// First connection drops immediately; the second pushes an update
+		if connections.Add(1) == 1 {
+			return
+		}
+
+		_ = conn.WriteMessage(websocket.TextMessage, []byte(testUserTradeMsg))
+		for {
+			if _, _, err = conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}))
+	defer server.Close()
+
+	um := newTestUserManager(server.URL)
+	err := um.Start()
+	if err != nil {
+		t.Fatalf("start: %v", err)
+	}
+	defer um.Close()
+
+	update := receiveUpdate(t, um)
+	if update.Trade == nil || update.Trade.ID != "trade-1" {
+		t.Errorf("expected trade update after reconnect, got %+v", update)
+	}
+
+	if got := connections.Load(); got != 2 {
+		t.Errorf("expected 2 authenticated connections, got %d", got)
+	}
+}
+
+// TestParseUserMessage tests decoding of user channel frames.
+func TestParseUserMessage(t *testing.T) {
+	tests := []struct {
+		name       string
+		message    string
+		wantEvents []string
+		wantErr    bool
+	}{
+		{name: "single_order", message: testUserOrderMsg, wantEvents: []string{"order"}},
+		{name: "single_trade", message: testUserTradeMsg, wantEvents: []string{"trade"}},
+		{name: "array", message: "[" + testUserOrderMsg + "," + testUserTradeMsg + "]", wantEvents: []string{"order", "trade"}},
+		{name: "heartbeat", message: "[]"},
+		{name: "unknown_event_ignored", message: `{"event_type":"book","asset_id":"token1"}`},
+		{name: "malformed", message: `{"event_type":"order",`, wantErr: true},
+		{name: "bad_field_type", message: `{"event_type":"trade","maker_orders":"oops"}`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			updates, err := parseUserMessage([]byte(tt.message), time.Now())
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("expected error, got %d updates", len(updates))
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if len(updates) != len(tt.wantEvents) {
+				t.Fatalf("expected %d updates, got %d", len(tt.wantEvents), len(updates))
+			}
+
+			for i, update := range updates {
+				if update.EventType != tt.wantEvents[i] {
+					t.Errorf("update %d: expected %s, got %s", i, tt.wantEvents[i], update.EventType)
+				}
+				if (update.Order != nil) != (update.EventType == types.UserEventOrder) ||
+					(update.Trade != nil) != (update.EventType == types.UserEventTrade) {
+					t.Errorf("update %d: payload does not match event type: %+v", i, update)
+				}
+			}
+		})
+	}
+}